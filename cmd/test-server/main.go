@@ -20,29 +20,51 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"os"
 	"strings"
+	"sync"
 
 	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	metadatav1 "github.com/osac-project/fulfillment-common/api/metadata/v1"
 	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	grpcmetadata "google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	"github.com/osac-project/fulfillment-cli/internal/testing"
 )
 
 const (
 	serverPort          = "8080"
+	issuerPort          = "8081"
 	defaultScenarioFile = "internal/testing/testdata/cluster-lifecycle.yaml"
 )
 
-// loggingEventsServer wraps EventsServerFuncs to add logging for the standalone server
+// Modes for the objects when the scenario is reloaded:
+const (
+	reloadModeReset = "reset"
+	reloadModeMerge = "merge"
+)
+
+// loggingEventsServer wraps EventsServerFuncs to add logging for the standalone server. The wrapped server can be
+// replaced when the scenario is reloaded, and then the streams opened after that use the new one.
 type loggingEventsServer struct {
-	*testing.EventsServerFuncs
+	eventsv1.UnimplementedEventsServer
+	lock   sync.Mutex
+	server *testing.EventsServerFuncs
+}
+
+func (s *loggingEventsServer) replace(server *testing.EventsServerFuncs) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.server = server
 }
 
 func (s *loggingEventsServer) Watch(request *eventsv1.EventsWatchRequest, stream eventsv1.Events_WatchServer) error {
@@ -53,7 +75,10 @@ func (s *loggingEventsServer) Watch(request *eventsv1.EventsWatchRequest, stream
 	loggingStream := &loggingWatchServer{Events_WatchServer: stream}
 
 	// Call the underlying Watch function
-	err := s.EventsServerFuncs.Watch(request, loggingStream)
+	s.lock.Lock()
+	server := s.server
+	s.lock.Unlock()
+	err := server.Watch(request, loggingStream)
 
 	if err != nil {
 		log.Printf("Client disconnected: %v", err)
@@ -75,88 +100,6 @@ func (s *loggingWatchServer) Send(response *eventsv1.EventsWatchResponse) error
 	return s.Events_WatchServer.Send(response)
 }
 
-// Dummy clusters server - just to satisfy the CLI's requirements
-type clustersServer struct {
-	ffv1.UnimplementedClustersServer
-}
-
-func (s *clustersServer) List(ctx context.Context, request *ffv1.ClustersListRequest) (*ffv1.ClustersListResponse, error) {
-	// Return empty list
-	return &ffv1.ClustersListResponse{}, nil
-}
-
-// Simple mock compute instances server for testing
-type computeInstancesServer struct {
-	ffv1.UnimplementedComputeInstancesServer
-}
-
-func (s *computeInstancesServer) Create(ctx context.Context, request *ffv1.ComputeInstancesCreateRequest) (*ffv1.ComputeInstancesCreateResponse, error) {
-	instance := request.GetObject()
-
-	// Set mock ID and state if not already set
-	if instance.Id == "" {
-		instance.Id = "ci-mock-12345"
-	}
-	if instance.Status == nil {
-		instance.Status = &ffv1.ComputeInstanceStatus{
-			State: ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_STARTING,
-		}
-	}
-
-	log.Printf("Created compute instance: %s (name: %s, template: %s)",
-		instance.Id,
-		instance.GetMetadata().GetName(),
-		instance.GetSpec().GetTemplate())
-
-	return &ffv1.ComputeInstancesCreateResponse{Object: instance}, nil
-}
-
-func (s *computeInstancesServer) Get(ctx context.Context, request *ffv1.ComputeInstancesGetRequest) (*ffv1.ComputeInstancesGetResponse, error) {
-	// Return a mock instance
-	instance := &ffv1.ComputeInstance{
-		Id: request.Id,
-		Metadata: &sharedv1.Metadata{
-			Name: "mock-instance",
-		},
-		Spec: &ffv1.ComputeInstanceSpec{
-			Template: "small-instance",
-		},
-		Status: &ffv1.ComputeInstanceStatus{
-			State:     ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
-			IpAddress: "192.168.1.100",
-		},
-	}
-
-	log.Printf("Retrieved compute instance: %s", request.Id)
-	return &ffv1.ComputeInstancesGetResponse{Object: instance}, nil
-}
-
-func (s *computeInstancesServer) List(ctx context.Context, request *ffv1.ComputeInstancesListRequest) (*ffv1.ComputeInstancesListResponse, error) {
-	// Return a mock list with one instance
-	instance := &ffv1.ComputeInstance{
-		Id: "ci-mock-12345",
-		Metadata: &sharedv1.Metadata{
-			Name: "mock-instance",
-		},
-		Spec: &ffv1.ComputeInstanceSpec{
-			Template: "small-instance",
-		},
-		Status: &ffv1.ComputeInstanceStatus{
-			State:     ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
-			IpAddress: "192.168.1.100",
-		},
-	}
-
-	size := int32(1)
-	total := int32(1)
-	log.Printf("Listed compute instances")
-	return &ffv1.ComputeInstancesListResponse{
-		Items: []*ffv1.ComputeInstance{instance},
-		Size:  &size,
-		Total: &total,
-	}, nil
-}
-
 // Simple mock compute instance templates server
 type computeInstanceTemplatesServer struct {
 	ffv1.UnimplementedComputeInstanceTemplatesServer
@@ -192,21 +135,11 @@ func (s *computeInstanceTemplatesServer) List(ctx context.Context, request *ffv1
 		},
 	}
 
-	// Apply filter if provided (simple string matching for mock purposes)
+	// Apply the filter, if provided:
 	filter := request.GetFilter()
-	var templates []*ffv1.ComputeInstanceTemplate
-
-	if filter != "" {
-		// Simple filter: check if filter contains the template ID or name
-		// This is a mock implementation - real server would parse CEL expressions
-		for _, tmpl := range allTemplates {
-			// Check if filter mentions this template's ID or name
-			if strings.Contains(filter, tmpl.Id) || strings.Contains(filter, tmpl.GetMetadata().GetName()) {
-				templates = append(templates, tmpl)
-			}
-		}
-	} else {
-		templates = allTemplates
+	templates, err := testing.FilterObjects(allTemplates, filter)
+	if err != nil {
+		return nil, err
 	}
 
 	size := int32(len(templates))
@@ -219,20 +152,122 @@ func (s *computeInstanceTemplatesServer) List(ctx context.Context, request *ffv1
 	}, nil
 }
 
-// Dummy metadata server - required for login
+// Metadata server - required for login. It returns the authentication settings of the scenario, if any.
 type metadataServer struct {
 	metadatav1.UnimplementedMetadataServer
+	auth    *testing.ScenarioAuth
+	version string
 }
 
 func (s *metadataServer) Get(ctx context.Context, request *metadatav1.MetadataGetRequest) (*metadatav1.MetadataGetResponse, error) {
-	// Return minimal metadata - no authentication required for test server
-	return &metadatav1.MetadataGetResponse{}, nil
+	// Advertise the version if requested:
+	if s.version != "" {
+		err := grpc.SetHeader(ctx, grpcmetadata.Pairs("Server", "fulfillment-service/"+s.version))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return minimal metadata if the scenario doesn't have authentication settings:
+	if s.auth == nil {
+		return &metadatav1.MetadataGetResponse{}, nil
+	}
+	return &metadatav1.MetadataGetResponse{
+		Authn: &metadatav1.Authn{
+			TrustedTokenIssuers: s.auth.TrustedTokenIssuers,
+		},
+	}, nil
+}
+
+// authChecker rejects requests that don't have a bearer token when the scenario requires authentication. The
+// metadata, health and reflection services are always available, as the CLI needs them before it has a token.
+type authChecker struct {
+	auth   *testing.ScenarioAuth
+	issuer *testing.Issuer
+}
+
+func (c *authChecker) check(ctx context.Context, method string) error {
+	if c.auth == nil || !c.auth.Required {
+		return nil
+	}
+	if method == metadatav1.Metadata_Get_FullMethodName ||
+		strings.HasPrefix(method, "/grpc.health.v1.Health/") ||
+		strings.HasPrefix(method, "/grpc.reflection.") {
+		return nil
+	}
+	md, _ := grpcmetadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if !ok || token == "" {
+			continue
+		}
+		if c.issuer == nil {
+			return nil
+		}
+		_, err := c.issuer.Verify(token)
+		if err != nil {
+			log.Printf("Rejected call to %s with invalid token: %v", method, err)
+			return status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		return nil
+	}
+	log.Printf("Rejected call to %s without bearer token", method)
+	return status.Error(codes.Unauthenticated, "a bearer token is required")
+}
+
+func (c *authChecker) unary(ctx context.Context, request any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	err := c.check(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+func (c *authChecker) stream(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	err := c.check(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(server, stream)
 }
 
 func main() {
 	// Parse command line flags
 	scenarioFile := flag.String("scenario", defaultScenarioFile, "Path to event scenario YAML file")
+	validateFile := flag.String("validate-scenario", "", "Validate the given scenario YAML file and exit")
+	enableIssuer := flag.Bool("issuer", false, "Start an embedded OAuth token issuer on port "+issuerPort)
+	serverVersion := flag.String("version", "", "Version advertised in the 'Server' header of the metadata responses")
+	lifecycleDelay := flag.Duration(
+		"lifecycle-delay",
+		testing.DefaultLifecycleDelay,
+		"Time that clusters and compute instances created through the server take to be ready",
+	)
+	watchScenario := flag.Bool("watch-scenario", false, "Reload the scenario file when it changes, without restarting")
+	reloadMode := flag.String(
+		"reload-mode",
+		reloadModeReset,
+		"What to do with the existing objects when the scenario is reloaded: '"+reloadModeReset+"' removes them "+
+			"before playing the new scenario, '"+reloadModeMerge+"' keeps them and applies the new events on top",
+	)
 	flag.Parse()
+	if *reloadMode != reloadModeReset && *reloadMode != reloadModeMerge {
+		log.Fatalf("Reload mode should be '%s' or '%s', but it is '%s'", reloadModeReset, reloadModeMerge, *reloadMode)
+	}
+
+	// If requested only validate the scenario file:
+	if *validateFile != "" {
+		errs := testing.ValidateScenarioFile(*validateFile)
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		if len(errs) > 0 {
+			os.Exit(1)
+		}
+		fmt.Printf("Scenario file '%s' is valid\n", *validateFile)
+		return
+	}
 
 	// Load scenario from file
 	scenario, err := testing.LoadScenarioFromFile(*scenarioFile)
@@ -240,24 +275,114 @@ func main() {
 		log.Fatalf("Failed to load scenario from %s: %v", *scenarioFile, err)
 	}
 	log.Printf("Loaded scenario: %s - %s", scenario.Name, scenario.Description)
+	if scenario.Auth != nil {
+		log.Printf(
+			"Trusted token issuers: %s, authentication required: %t",
+			strings.Join(scenario.Auth.TrustedTokenIssuers, ", "), scenario.Auth.Required,
+		)
+	}
+
+	// Start the embedded token issuer if requested. When the scenario doesn't advertise any issuer we advertise this
+	// one, and when it does, we assume that it is this one and use it to verify tokens.
+	var issuer *testing.Issuer
+	if *enableIssuer {
+		issuer, err = testing.NewIssuer().
+			SetLogger(slog.New(slog.NewTextHandler(os.Stderr, nil))).
+			SetAddress("127.0.0.1:"+issuerPort).
+			AddClient("fulfillment-cli", "").
+			AddClient("test-client", "test-secret").
+			SetAutoApprove(true).
+			Build()
+		if err != nil {
+			log.Fatalf("Failed to create token issuer: %v", err)
+		}
+		issuer.Start()
+		defer issuer.Stop()
+		if scenario.Auth == nil {
+			scenario.Auth = &testing.ScenarioAuth{}
+		}
+		if len(scenario.Auth.TrustedTokenIssuers) == 0 {
+			scenario.Auth.TrustedTokenIssuers = []string{issuer.URL()}
+		}
+		log.Printf("Started token issuer at %s", issuer.URL())
+	}
 
 	listener, err := net.Listen("tcp", "127.0.0.1:"+serverPort)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-
-	// Create events server using the builder with loaded scenario
-	eventsServerFuncs := testing.NewMockEventsServerBuilder().
-		WithScenario(scenario).
+	// Create the fault injector, it does nothing if the scenario doesn't have faults:
+	injector, err := testing.NewFaultInjector().
+		SetLogger(slog.New(slog.NewTextHandler(os.Stderr, nil))).
+		SetFaults(scenario.Faults).
 		Build()
-	eventsv1.RegisterEventsServer(grpcServer, &loggingEventsServer{EventsServerFuncs: eventsServerFuncs})
+	if err != nil {
+		log.Fatalf("Failed to create fault injector: %v", err)
+	}
+	if scenario.Faults != nil {
+		log.Printf("Injecting %d faults with seed %d", len(scenario.Faults.Rules), scenario.Faults.Seed)
+	}
+
+	checker := &authChecker{auth: scenario.Auth, issuer: issuer}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(checker.unary, injector.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(checker.stream, injector.StreamInterceptor()),
+	)
+
+	// Create the servers for the objects of the scenario, and apply the events of the scenario to them following the
+	// same timeline that the events server uses:
+	objects := testing.NewMockObjects()
+	objects.SetLifecycleDelay(*lifecycleDelay)
+	objects.Start(scenario)
+	defer objects.Stop()
+
+	// Create events server using the builder with loaded scenario, and with the changes made to the objects:
+	eventsServer := &loggingEventsServer{
+		server: testing.NewMockEventsServerBuilder().
+			WithScenario(scenario).
+			WithObjects(objects).
+			Build(),
+	}
+	eventsv1.RegisterEventsServer(grpcServer, eventsServer)
+
+	// If requested, reload the scenario when the file changes. The events of the new scenario are played again from
+	// the beginning, both for the objects and for the streams opened after the reload. Authentication and faults are
+	// configured only when the server starts, so changes to them require a restart.
+	if *watchScenario {
+		watcher, err := testing.NewScenarioWatcher().
+			SetLogger(slog.New(slog.NewTextHandler(os.Stderr, nil))).
+			SetFile(*scenarioFile).
+			SetHandler(func(scenario *testing.EventScenario) {
+				// Stop the previous scenario and wait for it, otherwise it could apply events after the reset:
+				objects.Stop()
+				if *reloadMode == reloadModeReset {
+					objects.Reset()
+				}
+				objects.Start(scenario)
+				eventsServer.replace(
+					testing.NewMockEventsServerBuilder().
+						WithScenario(scenario).
+						WithObjects(objects).
+						Build(),
+				)
+			}).
+			Build()
+		if err != nil {
+			log.Fatalf("Failed to create scenario watcher: %v", err)
+		}
+		go watcher.Run(context.Background())
+		log.Printf("Watching scenario file %s, reload mode is '%s'", *scenarioFile, *reloadMode)
+	}
 
-	ffv1.RegisterClustersServer(grpcServer, &clustersServer{})
-	ffv1.RegisterComputeInstancesServer(grpcServer, &computeInstancesServer{})
+	ffv1.RegisterClustersServer(grpcServer, objects.ClustersServer())
+	ffv1.RegisterClusterTemplatesServer(grpcServer, objects.ClusterTemplatesServer())
+	ffv1.RegisterHostsServer(grpcServer, objects.HostsServer())
+	ffv1.RegisterHostPoolsServer(grpcServer, objects.HostPoolsServer())
+	ffv1.RegisterHostClassesServer(grpcServer, objects.HostClassesServer())
+	ffv1.RegisterComputeInstancesServer(grpcServer, objects.ComputeInstancesServer())
 	ffv1.RegisterComputeInstanceTemplatesServer(grpcServer, &computeInstanceTemplatesServer{})
-	metadatav1.RegisterMetadataServer(grpcServer, &metadataServer{})
+	metadatav1.RegisterMetadataServer(grpcServer, &metadataServer{auth: scenario.Auth, version: *serverVersion})
 
 	// Register health service
 	healthServer := health.NewServer()
@@ -277,8 +402,8 @@ func main() {
 	fmt.Printf("  ./fulfillment-cli login --plaintext http://127.0.0.1:%s\n", serverPort)
 	fmt.Println("")
 	fmt.Println("2. Test commands:")
-	fmt.Println("  ./fulfillment-cli create computeinstance --template tpl-small-001 --name test-instance")
-	fmt.Println("  ./fulfillment-cli describe computeinstance ci-mock-12345")
+	fmt.Println("  ./fulfillment-cli create computeinstance --template tpl-small-001 --name test-instance --wait")
+	fmt.Println("  ./fulfillment-cli describe computeinstance test-instance")
 	fmt.Println("  ./fulfillment-cli get clusters --watch")
 	fmt.Println("")
 	fmt.Println("Press Ctrl+C to stop the server")
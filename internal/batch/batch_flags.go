@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package batch
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// FlagName is the name of the command line flag that sets the number of objects processed at the same time.
+const FlagName = "concurrency"
+
+// AddFlag adds to the given flag set the flag that specifies how many objects are processed at the same time. All
+// the commands that process multiple objects should use this, so that the flag is the same.
+func AddFlag(flags *pflag.FlagSet, value *int) {
+	flags.IntVar(
+		value,
+		FlagName,
+		DefaultConcurrency,
+		"Number of objects to process at the same time. Failures of some objects don't stop the processing of "+
+			"the rest, unless one of the failure limits is reached, and all the errors are reported at the end.",
+	)
+}
+
+// MaxConsecutiveFailuresFlagName is the name of the command line flag that sets the number of consecutive failures
+// after which the processing of the rest of the objects is aborted.
+const MaxConsecutiveFailuresFlagName = "max-consecutive-failures"
+
+// MaxFailureRateFlagName is the name of the command line flag that sets the percentage of failures after which the
+// processing of the rest of the objects is aborted.
+const MaxFailureRateFlagName = "max-failure-rate"
+
+// AddLimitFlags adds to the given flag set the flags that specify when to stop processing objects because too many of
+// them are failing, for example because of a misconfiguration that makes all the calls to the server fail.
+func AddLimitFlags(flags *pflag.FlagSet, maxConsecutiveFailures, maxFailureRate *int) {
+	flags.IntVar(
+		maxConsecutiveFailures,
+		MaxConsecutiveFailuresFlagName,
+		DefaultMaxConsecutiveFailures,
+		"Stop processing objects after this number of consecutive failures. Zero means no limit.",
+	)
+	flags.IntVar(
+		maxFailureRate,
+		MaxFailureRateFlagName,
+		DefaultMaxFailureRate,
+		"Stop processing objects when this percentage of them has failed. It is checked only after ten "+
+			"objects have been processed. Zero means no limit.",
+	)
+}
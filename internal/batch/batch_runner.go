@@ -0,0 +1,323 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package batch contains the logic used to run the same operation on many objects, like deleting or creating them,
+// using a pool of workers.
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency is the number of objects processed at the same time when not explicitly specified. It is one so
+// that by default objects are processed in order, as that is what matters when some of them depend on others.
+const DefaultConcurrency = 1
+
+// DefaultMaxConsecutiveFailures is the number of consecutive failures after which the commands stop processing the
+// rest of the objects, unless a different value is given with the command line flag.
+const DefaultMaxConsecutiveFailures = 10
+
+// DefaultMaxFailureRate is the percentage of failures after which the commands stop processing the rest of the
+// objects, unless a different value is given with the command line flag.
+const DefaultMaxFailureRate = 50
+
+// minFailureRateTasks is the number of tasks that need to finish before the failure rate is checked, so that a couple
+// of failures at the beginning of the batch don't stop it.
+const minFailureRateTasks = 10
+
+// Task is the operation that is applied to the object with the given index.
+type Task func(ctx context.Context, index int) error
+
+// RunnerBuilder contains the data and logic needed to create a runner. Don't create instances of this type directly,
+// use the NewRunner function instead.
+type RunnerBuilder struct {
+	logger                 *slog.Logger
+	concurrency            int
+	maxConsecutiveFailures int
+	maxFailureRate         int
+}
+
+// Runner runs a task for each object of a collection, with a limited number of tasks running at the same time. Don't
+// create instances of this type directly, use the NewRunner function instead.
+type Runner struct {
+	logger                 *slog.Logger
+	concurrency            int
+	maxConsecutiveFailures int
+	maxFailureRate         int
+}
+
+// Error is the error returned when the task failed for some of the objects. It contains the errors of all the
+// objects that failed, ordered by index.
+type Error struct {
+	// Total is the number of objects that were processed.
+	Total int
+
+	// Failures are the errors of the objects that failed.
+	Failures []*Failure
+
+	// Aborted is the number of objects that weren't processed because the runner stopped after reaching one of the
+	// failure limits.
+	Aborted int
+
+	// Reason explains which of the failure limits was reached. It is empty if the runner didn't stop.
+	Reason string
+}
+
+// Failure is the error of one object.
+type Failure struct {
+	// Index is the position of the object in the collection.
+	Index int
+
+	// Err is the error returned by the task.
+	Err error
+}
+
+// NewRunner creates a builder that can then be used to configure and create a runner.
+func NewRunner() *RunnerBuilder {
+	return &RunnerBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *RunnerBuilder) SetLogger(value *slog.Logger) *RunnerBuilder {
+	b.logger = value
+	return b
+}
+
+// SetConcurrency sets the maximum number of tasks that will run at the same time. This is optional, the default is
+// one, which means that the objects are processed in order.
+func (b *RunnerBuilder) SetConcurrency(value int) *RunnerBuilder {
+	b.concurrency = value
+	return b
+}
+
+// SetMaxConsecutiveFailures sets the number of consecutive failures after which the runner stops starting new tasks.
+// The default is zero, which means that there is no limit.
+func (b *RunnerBuilder) SetMaxConsecutiveFailures(value int) *RunnerBuilder {
+	b.maxConsecutiveFailures = value
+	return b
+}
+
+// SetMaxFailureRate sets the percentage of failed tasks after which the runner stops starting new tasks. The rate is
+// only checked after a few tasks have finished. The default is zero, which means that there is no limit.
+func (b *RunnerBuilder) SetMaxFailureRate(value int) *RunnerBuilder {
+	b.maxFailureRate = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new runner.
+func (b *RunnerBuilder) Build() (result *Runner, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.concurrency < 0 {
+		err = fmt.Errorf("concurrency should be positive, but it is %d", b.concurrency)
+		return
+	}
+	if b.maxConsecutiveFailures < 0 {
+		err = fmt.Errorf(
+			"maximum number of consecutive failures should be zero or positive, but it is %d",
+			b.maxConsecutiveFailures,
+		)
+		return
+	}
+	if b.maxFailureRate < 0 || b.maxFailureRate > 100 {
+		err = fmt.Errorf(
+			"maximum failure rate should be a percentage between 0 and 100, but it is %d",
+			b.maxFailureRate,
+		)
+		return
+	}
+
+	// Set the default concurrency:
+	concurrency := b.concurrency
+	if concurrency == 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	// Create and populate the object:
+	result = &Runner{
+		logger:                 b.logger,
+		concurrency:            concurrency,
+		maxConsecutiveFailures: b.maxConsecutiveFailures,
+		maxFailureRate:         b.maxFailureRate,
+	}
+	return
+}
+
+// Run calls the task for each index from zero to count minus one, and waits till all of them have finished. The
+// failure of one task doesn't stop the others, unless one of the failure limits is reached: then the tasks that
+// haven't started yet aren't started, and they are reported as aborted. If the context is cancelled the tasks that
+// haven't started yet aren't started either, and they are reported as failed with the error of the context. The
+// result is nil if all the tasks succeeded, or an *Error containing the errors of the tasks that failed.
+func (r *Runner) Run(ctx context.Context, count int, task Task) error {
+	r.logger.DebugContext(
+		ctx,
+		"Running batch",
+		slog.Int("count", count),
+		slog.Int("concurrency", r.concurrency),
+		slog.Int("max_consecutive_failures", r.maxConsecutiveFailures),
+		slog.Int("max_failure_rate", r.maxFailureRate),
+	)
+	var (
+		lock        sync.Mutex
+		failures    []*Failure
+		finished    int
+		failed      int
+		consecutive int
+		aborted     int
+		reason      string
+	)
+	group := &errgroup.Group{}
+	group.SetLimit(r.concurrency)
+	for i := range count {
+		// Don't start more tasks if a limit has been reached:
+		lock.Lock()
+		if reason != "" {
+			aborted += count - i
+			lock.Unlock()
+			break
+		}
+		lock.Unlock()
+
+		group.Go(func() error {
+			// Check again the limits, as they may have been reached while waiting for a free worker:
+			lock.Lock()
+			if reason != "" {
+				aborted++
+				lock.Unlock()
+				return nil
+			}
+			lock.Unlock()
+
+			// Run the task, unless the context has already been cancelled:
+			err := ctx.Err()
+			cancelled := err != nil
+			if !cancelled {
+				err = task(ctx, i)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				r.logger.DebugContext(
+					ctx,
+					"Batch task failed",
+					slog.Int("index", i),
+					slog.Any("error", err),
+				)
+				failures = append(failures, &Failure{
+					Index: i,
+					Err:   err,
+				})
+			}
+
+			// Tasks that weren't started because of the context don't count for the limits, as they don't
+			// indicate a problem with the operation:
+			if cancelled {
+				return nil
+			}
+			finished++
+			if err != nil {
+				failed++
+				consecutive++
+			} else {
+				consecutive = 0
+			}
+			if reason == "" {
+				reason = r.checkLimits(finished, failed, consecutive)
+				if reason != "" {
+					r.logger.DebugContext(
+						ctx,
+						"Batch stopped",
+						slog.String("reason", reason),
+						slog.Int("finished", finished),
+						slog.Int("failed", failed),
+					)
+				}
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+	if len(failures) == 0 {
+		return nil
+	}
+	slices.SortFunc(failures, func(a, b *Failure) int {
+		return a.Index - b.Index
+	})
+	return &Error{
+		Total:    count,
+		Failures: failures,
+		Aborted:  aborted,
+		Reason:   reason,
+	}
+}
+
+// checkLimits checks if the failure limits have been reached, and returns the explanation if they have, or an empty
+// string if they haven't.
+func (r *Runner) checkLimits(finished, failed, consecutive int) string {
+	if r.maxConsecutiveFailures > 0 && consecutive >= r.maxConsecutiveFailures {
+		return fmt.Sprintf("%d consecutive operations failed", consecutive)
+	}
+	if r.maxFailureRate > 0 && finished >= minFailureRateTasks && failed*100 >= r.maxFailureRate*finished {
+		return fmt.Sprintf("%d%% of the operations failed", failed*100/finished)
+	}
+	return ""
+}
+
+// Error is the implementation of the error interface. When only one object failed the message is the message of its
+// error, so that processing one object reports the same error than processing it without a runner. When the runner
+// stopped because a failure limit was reached the message also contains the number of objects that succeeded, failed
+// and were aborted.
+func (e *Error) Error() string {
+	if len(e.Failures) == 1 && e.Aborted == 0 {
+		return e.Failures[0].Err.Error()
+	}
+	buffer := &strings.Builder{}
+	if e.Aborted > 0 {
+		fmt.Fprintf(
+			buffer,
+			"stopped because %s, %d of %d operations succeeded, %d failed and %d were aborted:",
+			e.Reason, e.Succeeded(), e.Total, len(e.Failures), e.Aborted,
+		)
+	} else {
+		fmt.Fprintf(buffer, "%d of %d operations failed:", len(e.Failures), e.Total)
+	}
+	for _, failure := range e.Failures {
+		fmt.Fprintf(buffer, "\n  - %s", failure.Err)
+	}
+	return buffer.String()
+}
+
+// Succeeded returns the number of objects that were processed without errors.
+func (e *Error) Succeeded() int {
+	return e.Total - len(e.Failures) - e.Aborted
+}
+
+// Unwrap returns the errors of the objects that failed, so that they can be checked with errors.Is and errors.As.
+func (e *Error) Unwrap() []error {
+	result := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		result[i] = failure.Err
+	}
+	return result
+}
@@ -0,0 +1,289 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+var _ = Describe("Runner", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("Can't be created without a logger", func() {
+		runner, err := NewRunner().Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+		Expect(runner).To(BeNil())
+	})
+
+	It("Can't be created with a negative concurrency", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetConcurrency(-1).
+			Build()
+		Expect(err).To(MatchError("concurrency should be positive, but it is -1"))
+		Expect(runner).To(BeNil())
+	})
+
+	It("Runs the tasks in order by default", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var indexes []int
+		err = runner.Run(ctx, 5, func(ctx context.Context, i int) error {
+			indexes = append(indexes, i)
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(indexes).To(Equal([]int{0, 1, 2, 3, 4}))
+	})
+
+	It("Doesn't run more tasks than the concurrency at the same time", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetConcurrency(3).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var (
+			lock    sync.Mutex
+			running int
+			peak    int
+			total   atomic.Int32
+		)
+		err = runner.Run(ctx, 20, func(ctx context.Context, i int) error {
+			lock.Lock()
+			running++
+			peak = max(peak, running)
+			lock.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			lock.Lock()
+			running--
+			lock.Unlock()
+			total.Add(1)
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(total.Load()).To(BeNumerically("==", 20))
+		Expect(peak).To(Equal(3))
+	})
+
+	It("Runs all the tasks and aggregates the errors", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetConcurrency(4).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var total atomic.Int32
+		err = runner.Run(ctx, 10, func(ctx context.Context, i int) error {
+			total.Add(1)
+			if i%3 == 0 {
+				return fmt.Errorf("failed to process object %d", i)
+			}
+			return nil
+		})
+		Expect(total.Load()).To(BeNumerically("==", 10))
+		var batchErr *Error
+		Expect(errors.As(err, &batchErr)).To(BeTrue())
+		Expect(batchErr.Total).To(Equal(10))
+		Expect(batchErr.Failures).To(HaveLen(4))
+		Expect(err.Error()).To(Equal(
+			"4 of 10 operations failed:\n" +
+				"  - failed to process object 0\n" +
+				"  - failed to process object 3\n" +
+				"  - failed to process object 6\n" +
+				"  - failed to process object 9",
+		))
+	})
+
+	It("Reports a single failure with its own message", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = runner.Run(ctx, 3, func(ctx context.Context, i int) error {
+			if i == 1 {
+				return exit.Error(1)
+			}
+			return nil
+		})
+		Expect(err).To(MatchError("1"))
+		var exitErr exit.Error
+		Expect(errors.As(err, &exitErr)).To(BeTrue())
+		Expect(exitErr.Code()).To(Equal(1))
+	})
+
+	It("Doesn't start tasks after the context is cancelled", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var total atomic.Int32
+		err = runner.Run(ctx, 5, func(ctx context.Context, i int) error {
+			total.Add(1)
+			if i == 1 {
+				cancel()
+			}
+			return nil
+		})
+		Expect(total.Load()).To(BeNumerically("==", 2))
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		var batchErr *Error
+		Expect(errors.As(err, &batchErr)).To(BeTrue())
+		Expect(batchErr.Failures).To(HaveLen(3))
+	})
+
+	It("Can't be created with a negative maximum of consecutive failures", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetMaxConsecutiveFailures(-1).
+			Build()
+		Expect(err).To(MatchError(
+			"maximum number of consecutive failures should be zero or positive, but it is -1",
+		))
+		Expect(runner).To(BeNil())
+	})
+
+	It("Can't be created with a failure rate that isn't a percentage", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetMaxFailureRate(101).
+			Build()
+		Expect(err).To(MatchError("maximum failure rate should be a percentage between 0 and 100, but it is 101"))
+		Expect(runner).To(BeNil())
+	})
+
+	It("Stops after the maximum number of consecutive failures", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetMaxConsecutiveFailures(3).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var total atomic.Int32
+		err = runner.Run(ctx, 100, func(ctx context.Context, i int) error {
+			total.Add(1)
+			if i == 0 {
+				return nil
+			}
+			return fmt.Errorf("failed to process object %d", i)
+		})
+		Expect(total.Load()).To(BeNumerically("==", 4))
+		var batchErr *Error
+		Expect(errors.As(err, &batchErr)).To(BeTrue())
+		Expect(batchErr.Failures).To(HaveLen(3))
+		Expect(batchErr.Aborted).To(Equal(96))
+		Expect(batchErr.Succeeded()).To(Equal(1))
+		Expect(err.Error()).To(Equal(
+			"stopped because 3 consecutive operations failed, 1 of 100 operations succeeded, 3 failed and " +
+				"96 were aborted:\n" +
+				"  - failed to process object 1\n" +
+				"  - failed to process object 2\n" +
+				"  - failed to process object 3",
+		))
+	})
+
+	It("Resets the count of consecutive failures when a task succeeds", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetMaxConsecutiveFailures(2).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var total atomic.Int32
+		err = runner.Run(ctx, 10, func(ctx context.Context, i int) error {
+			total.Add(1)
+			if i%2 == 0 {
+				return fmt.Errorf("failed to process object %d", i)
+			}
+			return nil
+		})
+		Expect(total.Load()).To(BeNumerically("==", 10))
+		var batchErr *Error
+		Expect(errors.As(err, &batchErr)).To(BeTrue())
+		Expect(batchErr.Failures).To(HaveLen(5))
+		Expect(batchErr.Aborted).To(BeZero())
+		Expect(batchErr.Reason).To(BeEmpty())
+	})
+
+	It("Stops when the failure rate is reached", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetMaxFailureRate(50).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var total atomic.Int32
+		err = runner.Run(ctx, 100, func(ctx context.Context, i int) error {
+			total.Add(1)
+			if i%4 != 0 {
+				return fmt.Errorf("failed to process object %d", i)
+			}
+			return nil
+		})
+		Expect(total.Load()).To(BeNumerically("==", 10))
+		var batchErr *Error
+		Expect(errors.As(err, &batchErr)).To(BeTrue())
+		Expect(batchErr.Reason).To(Equal("70% of the operations failed"))
+		Expect(batchErr.Failures).To(HaveLen(7))
+		Expect(batchErr.Aborted).To(Equal(90))
+	})
+
+	It("Doesn't check the failure rate till some tasks have finished", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetMaxFailureRate(50).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = runner.Run(ctx, 5, func(ctx context.Context, i int) error {
+			return fmt.Errorf("failed to process object %d", i)
+		})
+		var batchErr *Error
+		Expect(errors.As(err, &batchErr)).To(BeTrue())
+		Expect(batchErr.Failures).To(HaveLen(5))
+		Expect(batchErr.Aborted).To(BeZero())
+	})
+
+	It("Finishes the running tasks when a limit is reached", func() {
+		runner, err := NewRunner().
+			SetLogger(logger).
+			SetConcurrency(4).
+			SetMaxConsecutiveFailures(1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var total atomic.Int32
+		err = runner.Run(ctx, 100, func(ctx context.Context, i int) error {
+			total.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return fmt.Errorf("failed to process object %d", i)
+		})
+		var batchErr *Error
+		Expect(errors.As(err, &batchErr)).To(BeTrue())
+		Expect(int(total.Load())).To(BeNumerically("<=", 8))
+		Expect(batchErr.Failures).To(HaveLen(int(total.Load())))
+		Expect(batchErr.Aborted).To(Equal(100 - int(total.Load())))
+	})
+})
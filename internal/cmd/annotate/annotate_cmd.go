@@ -19,14 +19,20 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync/atomic"
 
 	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/osac-project/fulfillment-cli/internal/batch"
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/macros"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
@@ -37,18 +43,40 @@ var templatesFS embed.FS
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:   "annotate OBJECT ID|NAME ANNOTATION...",
+		Use:   "annotate OBJECT [OPTION]... [ID|NAME]... ANNOTATION...",
 		Short: "Add or remove annotations from objects",
-		RunE:  runner.run,
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
 	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.args.filter,
+		"filter",
+		"",
+		"Annotate all the objects that match this CEL expression.",
+	)
+	batch.AddFlag(flags, &runner.args.concurrency)
+	batch.AddLimitFlags(flags, &runner.args.maxConsecutiveFailures, &runner.args.maxFailureRate)
+	refs.AddFlags(flags)
 	return result
 }
 
 type runnerContext struct {
+	args struct {
+		filter                 string
+		concurrency            int
+		maxConsecutiveFailures int
+		maxFailureRate         int
+	}
 	logger  *slog.Logger
 	console *terminal.Console
 	conn    *grpc.ClientConn
 	helper  *reflection.ObjectHelper
+	macros  *macros.Expander
+	refMode refs.Mode
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
@@ -76,6 +104,12 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("there is no configuration, run the 'login' command")
 	}
 
+	// Create the object that expands the macros defined in the configuration:
+	c.macros, err = cfg.Expander(c.logger)
+	if err != nil {
+		return err
+	}
+
 	// Create the gRPC connection from the configuration:
 	c.conn, err = cfg.Connect(ctx, cmd.Flags())
 	if err != nil {
@@ -99,7 +133,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		c.console.Render(ctx, "no_object.txt", map[string]any{
 			"Helper": helper,
 		})
-		return nil
+		return exit.Usage
 	}
 
 	// Get the information about the object type:
@@ -109,87 +143,180 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 			"Helper": helper,
 			"Object": args[0],
 		})
-		return nil
+		return exit.Usage
 	}
 
-	// Check that the object identifier or name has been specified:
-	if len(args) < 2 {
+	// Separate the identifiers or names of the objects from the annotation operations:
+	keys, specs := splitArgs(args[1:])
+	if c.args.filter != "" && len(keys) > 0 {
+		return exit.Usagef("option '--filter' can't be used together with identifiers or names")
+	}
+	if c.args.filter == "" && len(keys) == 0 {
 		c.console.Render(ctx, "no_id.txt", map[string]any{})
-		return nil
+		return exit.Usage
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
 	}
-	ref := args[1]
 
 	// Check that at least one annotation operation has been specified:
-	if len(args) < 3 {
+	if len(specs) == 0 {
 		c.console.Render(ctx, "no_annotations.txt", map[string]any{})
-		return nil
+		return exit.Usage
 	}
 
 	// Parse the annotation operations:
-	operations, err := c.parseAnnotationOperations(args[2:])
+	operations, err := c.parseAnnotationOperations(specs)
 	if err != nil {
 		return err
 	}
 
-	// Find the object by identifier or name:
-	object, err := c.findObject(ctx, ref)
+	// Find the objects, either with the filter or by identifier or name:
+	var objects []proto.Message
+	if c.args.filter != "" {
+		objects, err = c.findFiltered(ctx)
+	} else {
+		objects, err = c.findObjects(ctx, keys)
+	}
 	if err != nil {
 		return err
 	}
-	if object == nil {
+	if len(objects) == 0 {
 		return nil
 	}
 
-	// Apply the annotation operations:
-	metadata := c.helper.GetMetadata(object)
-	c.applyAnnotationOperations(metadata, operations)
+	// Apply the annotation operations and save the results:
+	return c.updateObjects(ctx, objects, operations)
+}
 
-	// Save the result:
-	_, err = c.helper.Update(ctx, object)
+// splitArgs separates the identifiers or names of the objects from the annotation operations. The operations are all
+// the arguments after the first one that contains a '=' or ends with a '-'.
+func splitArgs(args []string) (keys, specs []string) {
+	for i, arg := range args {
+		if strings.Contains(arg, "=") || strings.HasSuffix(arg, "-") {
+			keys = args[:i]
+			specs = args[i:]
+			return
+		}
+	}
+	keys = args
+	return
+}
+
+// updateObjects applies the annotation operations to the given objects and saves them, using as many workers as
+// requested with the '--concurrency' flag. When there are multiple objects a line is written for each of them, and a
+// summary at the end.
+func (c *runnerContext) updateObjects(ctx context.Context, objects []proto.Message,
+	operations []annotationOperation) error {
+	runner, err := batch.NewRunner().
+		SetLogger(c.logger).
+		SetConcurrency(c.args.concurrency).
+		SetMaxConsecutiveFailures(c.args.maxConsecutiveFailures).
+		SetMaxFailureRate(c.args.maxFailureRate).
+		Build()
 	if err != nil {
 		return err
 	}
-
-	return nil
+	var updated atomic.Int32
+	err = runner.Run(ctx, len(objects), func(ctx context.Context, i int) error {
+		object := objects[i]
+		id := c.helper.GetId(object)
+
+		// Apply the annotation operations:
+		original := proto.Clone(object)
+		metadata := c.helper.GetMetadata(object)
+		c.applyAnnotationOperations(metadata, operations)
+
+		// Save the result, sending only the changed fields so that concurrent changes to other fields aren't lost. If
+		// nothing changed, for example when removing a annotation that doesn't exist, don't send anything, as an update
+		// without fields would replace the complete object.
+		paths := reflection.ChangedPaths(original, object)
+		if len(paths) == 0 {
+			c.console.Printf(ctx, "No changes to the annotations of %s '%s'.\n", c.helper.Singular(), id)
+			return nil
+		}
+		_, err := c.helper.Update(ctx, object, paths...)
+		if err != nil {
+			return fmt.Errorf("failed to annotate %s '%s': %w", c.helper.Singular(), id, err)
+		}
+		updated.Add(1)
+		if len(objects) > 1 {
+			c.console.Printf(ctx, "Annotated %s '%s'.\n", c.helper.Singular(), id)
+		}
+		return nil
+	})
+	if len(objects) > 1 {
+		c.console.Printf(ctx, "Annotated %d of %d %s.\n", updated.Load(), len(objects), c.helper.Plural())
+	}
+	return err
 }
 
-// findObject tries to find an object by identifier or name. It uses the list method with a filter that matches
-// either the identifier or the name. Returns an error if no match is found or if multiple matches are found.
-func (c *runnerContext) findObject(ctx context.Context, ref string) (result proto.Message, err error) {
-	filter := fmt.Sprintf(`this.id == %[1]q || this.metadata.name == %[1]q`, ref)
+// findFiltered finds all the objects that match the filter given with the '--filter' option.
+func (c *runnerContext) findFiltered(ctx context.Context) (result []proto.Message, err error) {
+	filter, err := c.macros.Expand(c.args.filter)
+	if err != nil {
+		err = fmt.Errorf("failed to expand macros in filter '%s': %w", c.args.filter, err)
+		return
+	}
 	response, err := c.helper.List(ctx, reflection.ListOptions{
 		Filter: filter,
-		Limit:  10,
 	})
 	if err != nil {
-		err = fmt.Errorf(
-			"failed to find object of type '%s' with identifier or name '%s': %w",
-			c.helper, ref, err,
-		)
+		err = fmt.Errorf("failed to find objects of type '%s': %w", c.helper, err)
 		return
 	}
-	items := response.Items
-	total := response.Total
-
-	switch len(items) {
-	case 0:
-		c.console.Render(ctx, "no_matches.txt", map[string]any{
-			"Object": c.helper.Singular(),
-			"Ref":    ref,
-		})
-		return
-	case 1:
-		result = items[0]
-		return
-	default:
-		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
-			"Matches": items,
-			"Object":  c.helper.Singular(),
-			"Ref":     ref,
-			"Total":   total,
+	result = response.Items
+	if len(result) == 0 {
+		c.console.Render(ctx, "no_filter_matches.txt", map[string]any{
+			"Filter": c.args.filter,
+			"Object": c.helper.Plural(),
 		})
+	}
+	return
+}
+
+// findObjects finds the objects with the given identifiers or names using a single list operation. If any of the
+// references doesn't match exactly one object the problem is explained to the user and an error is returned, so that
+// no object is modified.
+func (c *runnerContext) findObjects(ctx context.Context, keys []string) (result []proto.Message, err error) {
+	// Find all the objects matching any of the references:
+	filter := c.refMode.Filter(keys...)
+	response, err := c.helper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find objects of type '%s': %w", c.helper, err)
 		return
 	}
+
+	// Check that each reference matches exactly one object, preferring the object whose identifier matches exactly:
+	objects := make([]proto.Message, 0, len(keys))
+	for _, ref := range keys {
+		matches := refs.Resolve(c.refMode, ref, response.Items, c.helper.GetId, c.helper.GetName)
+		switch len(matches) {
+		case 0:
+			c.console.Render(ctx, "no_matches.txt", map[string]any{
+				"Object": c.helper.Singular(),
+				"Ref":    ref,
+			})
+			err = exit.NotFound
+			return
+		case 1:
+			objects = append(objects, matches[0])
+		default:
+			c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+				"Matches": matches,
+				"Object":  c.helper.Singular(),
+				"Ref":     ref,
+				"Total":   len(matches),
+			})
+			err = exit.General
+			return
+		}
+	}
+	result = objects
+	return
 }
 
 // annotationOperation represents a single annotation set or remove operation.
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"time"
+
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/kubeconfig"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "kubeconfig CLUSTER [OPTION]...",
+		Short: "Check that the kubeconfig of a cluster works",
+		Long: "Fetch the kubeconfig of a cluster and send a version request to its API server, to check that " +
+			"it is reachable, and a self subject review request, to check that the credentials are accepted. " +
+			"This verifies access to the cluster without installing kubectl.",
+		Args:              cobra.MaximumNArgs(1),
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectsOf((*ffv1.Cluster)(nil), 1),
+	}
+	flags := result.Flags()
+	flags.DurationVar(
+		&runner.args.timeout,
+		"timeout",
+		defaultTimeout,
+		"Maximum time to wait for the API server of the cluster.",
+	)
+	refs.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		timeout time.Duration
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Check the arguments:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_key.txt", nil)
+		return exit.Usage
+	}
+	key := args[0]
+	refMode, err := refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Try to find a cluster that has an identifier or name matching the given key:
+	client := ffv1.NewClustersClient(conn)
+	listResponse, err := client.List(ctx, ffv1.ClustersListRequest_builder{
+		Filter: proto.String(refMode.Filter(key)),
+		Limit:  proto.Int32(10),
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	total := listResponse.GetTotal()
+	clusters := refs.Resolve(refMode, key, listResponse.GetItems(), (*ffv1.Cluster).GetId, clusterName)
+	var cluster *ffv1.Cluster
+	switch len(clusters) {
+	case 0:
+		c.console.Render(ctx, "no_match.txt", map[string]any{
+			"Key": key,
+		})
+		return exit.NotFound
+	case 1:
+		cluster = clusters[0]
+	default:
+		ids := make([]string, len(clusters))
+		for i, cluster := range clusters {
+			ids[i] = cluster.GetId()
+		}
+		sort.Strings(ids)
+		ids = slices.Compact(ids)
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Ids":   ids,
+			"Key":   key,
+			"Total": total,
+		})
+		return exit.General
+	}
+
+	// Get and parse the kubeconfig:
+	response, err := client.GetKubeconfig(ctx, ffv1.ClustersGetKubeconfigRequest_builder{
+		Id: cluster.GetId(),
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	kc, err := kubeconfig.Parse([]byte(response.GetKubeconfig()))
+	if err != nil {
+		return err
+	}
+
+	// Probe the API server:
+	return c.probe(ctx, kc)
+}
+
+// probe sends the requests to the API server and reports the results. It returns an error if the API server isn't
+// reachable or if it rejects the credentials.
+func (c *runnerContext) probe(ctx context.Context, kc *kubeconfig.Config) error {
+	probeCtx, cancel := context.WithTimeout(ctx, c.args.timeout)
+	defer cancel()
+	result, err := kc.Probe(probeCtx)
+	if err != nil {
+		return err
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Probed API server",
+		slog.String("server", result.Server),
+		slog.Bool("reachable", result.Reachable),
+		slog.String("version", result.Version),
+		slog.String("auth", string(result.Auth)),
+		slog.String("user", result.User),
+		slog.Int("code", result.Code),
+		slog.Any("error", result.Error),
+	)
+	c.console.Render(ctx, "result.txt", result)
+	if !result.Reachable || result.Auth == kubeconfig.AuthStatusRejected {
+		return exit.Error(1)
+	}
+	return nil
+}
+
+// defaultTimeout is the default maximum time to wait for the API server of the cluster.
+const defaultTimeout = 10 * time.Second
+
+// clusterName returns the name of the given cluster.
+func clusterName(cluster *ffv1.Cluster) string {
+	return cluster.GetMetadata().GetName()
+}
@@ -0,0 +1,247 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package clean
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "clean [flags]",
+		Short: "Remove log files and cached data",
+		Long: "Remove the log files from the state directory and the cached data from the cache directory. By " +
+			"default both are removed. The configuration is never removed.",
+		Example: "  # Remove all the log files and cached data:\n" +
+			"  fulfillment-cli clean\n\n" +
+			"  # Remove the log files that haven't been modified in the last week:\n" +
+			"  fulfillment-cli clean --logs --older-than 168h\n\n" +
+			"  # Show what would be removed, without removing anything:\n" +
+			"  fulfillment-cli clean --dry-run",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.args.logs,
+		"logs",
+		false,
+		"Remove the log files.",
+	)
+	flags.BoolVar(
+		&runner.args.cache,
+		"cache",
+		false,
+		"Remove the cached data.",
+	)
+	flags.DurationVar(
+		&runner.args.olderThan,
+		"older-than",
+		0,
+		"Only remove files that haven't been modified during this time, for example '168h' for one week.",
+	)
+	flags.BoolVar(
+		&runner.args.dryRun,
+		"dry-run",
+		false,
+		"Show the files that would be removed, but don't remove them.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		logs      bool
+		cache     bool
+		olderThan time.Duration
+		dryRun    bool
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+// removedFile contains the details of a file that has been removed, or that would be removed in dry run mode.
+type removedFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// cleanResult is the result that is written when the output format is JSON.
+type cleanResult struct {
+	DryRun bool           `json:"dry_run"`
+	Files  []*removedFile `json:"files"`
+	Size   int64          `json:"size"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// If no kind of file has been selected then remove all of them:
+	if !c.args.logs && !c.args.cache {
+		c.args.logs = true
+		c.args.cache = true
+	}
+
+	// Load the settings, as they may contain the locations of the directories:
+	settings, err := config.LoadFile()
+	if err != nil {
+		return err
+	}
+
+	// Find the files that should be removed:
+	cutoff := time.Now().Add(-c.args.olderThan)
+	var files []*removedFile
+	if c.args.logs {
+		stateDir, err := settings.StateDirectory()
+		if err != nil {
+			return fmt.Errorf("failed to get state directory: %w", err)
+		}
+		logs, err := c.findFiles(stateDir, cutoff, isLogFile)
+		if err != nil {
+			return err
+		}
+		files = append(files, logs...)
+	}
+	if c.args.cache {
+		cacheDir, err := settings.CacheDirectory()
+		if err != nil {
+			return fmt.Errorf("failed to get cache directory: %w", err)
+		}
+		cached, err := c.findFiles(cacheDir, cutoff, nil)
+		if err != nil {
+			return err
+		}
+		files = append(files, cached...)
+	}
+
+	// Remove the files:
+	result := &cleanResult{
+		DryRun: c.args.dryRun,
+		Files:  []*removedFile{},
+	}
+	for _, file := range files {
+		if !c.args.dryRun {
+			err := os.Remove(file.Path)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to remove file '%s': %w", file.Path, err)
+			}
+			c.logger.DebugContext(
+				ctx,
+				"Removed file",
+				slog.String("path", file.Path),
+				slog.Int64("size", file.Size),
+			)
+		}
+		result.Files = append(result.Files, file)
+		result.Size += file.Size
+	}
+
+	// Report the result:
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, result)
+		return nil
+	}
+	if len(result.Files) == 0 {
+		c.console.Printf(ctx, "There is nothing to remove.\n")
+		return nil
+	}
+	if c.args.dryRun {
+		c.console.Printf(
+			ctx,
+			"Would remove %d files (%s):\n",
+			len(result.Files), humanize.IBytes(uint64(result.Size)),
+		)
+		for _, file := range result.Files {
+			c.console.Printf(ctx, "  %s\n", file.Path)
+		}
+		return nil
+	}
+	c.console.Printf(
+		ctx,
+		"Removed %d files (%s).\n",
+		len(result.Files), humanize.IBytes(uint64(result.Size)),
+	)
+	return nil
+}
+
+// findFiles returns the regular files inside the given directory, including sub-directories, that haven't been modified
+// since the given time. If a filter is given only the files accepted by it are returned.
+func (c *runnerContext) findFiles(dir string, cutoff time.Time, filter func(string) bool) (result []*removedFile,
+	err error) {
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if filter != nil && !filter(entry.Name()) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(cutoff) {
+			return nil
+		}
+		result = append(result, &removedFile{
+			Path: path,
+			Size: info.Size(),
+		})
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to find files in directory '%s': %w", dir, err)
+		return
+	}
+	slices.SortFunc(result, func(a, b *removedFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return
+}
+
+// isLogFile checks if the given file name corresponds to a log file. This includes the current log file, for example
+// 'fulfillment-cli.log', and the rotated ones, for example 'fulfillment-cli.log.1'.
+func isLogFile(name string) bool {
+	return strings.HasSuffix(name, ".log") || strings.Contains(name, ".log.")
+}
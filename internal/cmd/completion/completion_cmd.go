@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package completion
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// Supported shells:
+const (
+	shellBash       = "bash"
+	shellFish       = "fish"
+	shellPowershell = "powershell"
+	shellZsh        = "zsh"
+)
+
+// Cmd creates and returns the command that generates the shell completion scripts.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   fmt.Sprintf("completion %s|%s|%s|%s", shellBash, shellZsh, shellFish, shellPowershell),
+		Short: "Generate shell completion scripts",
+		Long: "Generate the completion script for the given shell. For example, to enable completion in the " +
+			"current bash session:\n\n" +
+			"  source <(fulfillment-cli completion bash)\n\n" +
+			"Besides commands and flags, the generated scripts complete object types and the identifiers " +
+			"and names of existing objects, querying the server with the saved configuration.",
+		ValidArgs: []string{
+			shellBash,
+			shellFish,
+			shellPowershell,
+			shellZsh,
+		},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE:                  runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the console, which is where the script will be written:
+	console := terminal.ConsoleFromContext(ctx)
+
+	// Generate the script for the selected shell:
+	root := cmd.Root()
+	switch args[0] {
+	case shellBash:
+		return root.GenBashCompletionV2(console, true)
+	case shellZsh:
+		return root.GenZshCompletion(console)
+	case shellFish:
+		return root.GenFishCompletion(console, true)
+	case shellPowershell:
+		return root.GenPowerShellCompletionWithDesc(console)
+	default:
+		return exit.Usagef("unsupported shell '%s'", args[0])
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package exportcmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "export [flags]",
+		Short: "Export the connection settings to a file that can be shared with other users",
+		Long: "Export the connection settings to a file that can be shared with other users, and then loaded " +
+			"with the 'config import' command. The tokens are never exported. The OAuth client secret, user and " +
+			"password are exported only when a passphrase is given, and then they are encrypted with it.",
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.file,
+		"file",
+		"f",
+		"",
+		"File where the settings will be written. If not specified they will be written to the standard output.",
+	)
+	flags.StringVar(
+		&runner.args.passphrase,
+		"passphrase",
+		"",
+		fmt.Sprintf(
+			"Passphrase used to encrypt the secrets. If not specified the value of the '%s' environment "+
+				"variable is used, and if that isn't set either the secrets aren't exported.",
+			config.PassphraseEnv,
+		),
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		file       string
+		passphrase string
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Take the passphrase from the environment if it wasn't given explicitly. Note that this can't be the default
+	// value of the flag, as that would show the passphrase in the help.
+	if !cmd.Flags().Changed("passphrase") {
+		c.args.passphrase = os.Getenv(config.PassphraseEnv)
+	}
+
+	// Load the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Address == "" {
+		c.console.Printf(ctx, "There is nothing to export, use the 'login' command first.\n")
+		return nil
+	}
+
+	// Generate the exported settings:
+	data, err := config.Export(cfg, c.args.passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to export configuration: %w", err)
+	}
+
+	// Write the result:
+	if c.args.file == "" {
+		_, err = c.console.Write(data)
+		return err
+	}
+	err = os.WriteFile(c.args.file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write file '%s': %w", c.args.file, err)
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Exported configuration",
+		slog.String("file", c.args.file),
+		slog.Bool("secrets", c.args.passphrase != ""),
+	)
+	c.console.Printf(ctx, "Exported settings to '%s'.\n", c.args.file)
+	if cfg.HasSecrets() {
+		if c.args.passphrase != "" {
+			c.console.Printf(ctx, "The secrets are encrypted, share the passphrase separately.\n")
+		} else {
+			c.console.Printf(ctx, "The secrets weren't exported, use the '--passphrase' option to include them.\n")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package importcmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "import [flags]",
+		Short: "Import the connection settings from a file generated with 'config export'",
+		Long: "Import the connection settings from a file generated with the 'config export' command. The current " +
+			"connection settings and tokens are replaced. If the file contains settings that run scripts, like the " +
+			"token script or the hooks, they are displayed and confirmation is requested before saving them.",
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.file,
+		"file",
+		"f",
+		"",
+		"File containing the exported settings.",
+	)
+	flags.StringVar(
+		&runner.args.passphrase,
+		"passphrase",
+		"",
+		fmt.Sprintf(
+			"Passphrase used to decrypt the secrets, if the file contains them. If not specified the value of "+
+				"the '%s' environment variable is used.",
+			config.PassphraseEnv,
+		),
+	)
+	flags.BoolVar(
+		&runner.args.trustScripts,
+		"trust-scripts",
+		false,
+		"Save the settings that run scripts without asking for confirmation.",
+	)
+	result.MarkFlagRequired("file")
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		file         string
+		passphrase   string
+		trustScripts bool
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	input   *os.File
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Take the passphrase from the environment if it wasn't given explicitly. Note that this can't be the default
+	// value of the flag, as that would show the passphrase in the help.
+	if !cmd.Flags().Changed("passphrase") {
+		c.args.passphrase = os.Getenv(config.PassphraseEnv)
+	}
+
+	// Read and parse the file:
+	data, err := os.ReadFile(c.args.file)
+	if err != nil {
+		return fmt.Errorf("failed to read file '%s': %w", c.args.file, err)
+	}
+	imported, err := config.Import(data, c.args.passphrase)
+	if errors.Is(err, config.ErrPassphraseRequired) {
+		c.console.Printf(
+			ctx,
+			"File '%s' contains encrypted secrets, use the '--passphrase' option to decrypt them.\n",
+			c.args.file,
+		)
+		return exit.Error(1)
+	}
+	if errors.Is(err, config.ErrWrongPassphrase) {
+		c.console.Printf(ctx, "Failed to decrypt the secrets of file '%s', check the passphrase.\n", c.args.file)
+		return exit.Error(1)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Preserve the token storage selected by the user when the file doesn't specify it, and the settings that are
+	// local to this user and aren't exported: directories, log settings, favorites, color theme, units, time zone,
+	// telemetry and macros.
+	// Note that the tokens aren't preserved, as they will probably not be valid for the imported server.
+	current, err := config.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if imported.TokenStorage == "" {
+		imported.TokenStorage = current.TokenStorage
+	}
+	imported.StateDir = current.StateDir
+	imported.CacheDir = current.CacheDir
+	imported.LogMaxSize = current.LogMaxSize
+	imported.LogMaxAge = current.LogMaxAge
+	imported.LogMaxFiles = current.LogMaxFiles
+	imported.Favorites = current.Favorites
+	imported.Theme = current.Theme
+	imported.Units = current.Units
+	imported.TimeZone = current.TimeZone
+	imported.OtelEndpoint = current.OtelEndpoint
+	imported.OtelHeaders = current.OtelHeaders
+	imported.Macros = current.Macros
+
+	// Settings that run scripts run them in every later command, so show them and ask for confirmation before saving
+	// them:
+	scripts := scriptSettings(imported)
+	if len(scripts) > 0 {
+		c.console.Printf(ctx, "File '%s' contains settings that will run these scripts:\n", c.args.file)
+		for _, script := range scripts {
+			c.console.Printf(ctx, "  %s\n", script)
+		}
+		if !c.args.trustScripts {
+			var confirmed bool
+			confirmed, err = c.confirm(ctx)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				c.console.Printf(ctx, "The settings weren't imported.\n")
+				return exit.Error(1)
+			}
+		}
+	}
+
+	// Save the configuration:
+	err = config.Save(imported)
+	if err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Imported configuration",
+		slog.String("file", c.args.file),
+		slog.String("address", imported.Address),
+	)
+	c.console.Printf(ctx, "Imported settings for server '%s'.\n", imported.Address)
+	if imported.OAuthFlow != "" && imported.OAuthClientSecret == "" && imported.OAuthPassword == "" {
+		c.console.Printf(ctx, "Use the 'login' command to obtain the tokens.\n")
+	}
+	return nil
+}
+
+// confirm asks the user to confirm that the settings that run scripts should be saved.
+func (c *runnerContext) confirm(ctx context.Context) (result bool, err error) {
+	input := c.input
+	if input == nil {
+		input = os.Stdin
+	}
+	if !isatty.IsTerminal(input.Fd()) {
+		c.console.Printf(
+			ctx,
+			"Can't ask for confirmation because the input isn't a terminal, use '--trust-scripts'.\n",
+		)
+		return
+	}
+	c.console.Printf(ctx, "Save these scripts? [y/N]: ")
+	line, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("failed to read confirmation: %w", err)
+		return
+	}
+	err = nil
+	answer := strings.ToLower(strings.TrimSpace(line))
+	result = answer == "y" || answer == "yes"
+	return
+}
+
+// scriptSettings returns the settings of the configuration that run scripts, one line for each of them, in the same
+// format used in the exported file.
+func scriptSettings(cfg *config.Config) []string {
+	var result []string
+	if cfg.TokenScript != "" {
+		result = append(result, fmt.Sprintf("token_script: %s", cfg.TokenScript))
+	}
+	if cfg.TokenScriptShell != "" {
+		result = append(result, fmt.Sprintf("token_script_shell: %s", cfg.TokenScriptShell))
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Hooks)) {
+		result = append(result, fmt.Sprintf("hooks.%s: %s", name, cfg.Hooks[name]))
+	}
+	if cfg.HooksShell != "" {
+		result = append(result, fmt.Sprintf("hooks_shell: %s", cfg.HooksShell))
+	}
+	return result
+}
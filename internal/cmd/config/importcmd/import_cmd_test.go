@@ -0,0 +1,174 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package importcmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/osac-project/fulfillment-common/logging"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+var _ = Describe("Import command", func() {
+	var (
+		ctx    context.Context
+		buffer *bytes.Buffer
+		tmp    string
+	)
+
+	BeforeEach(func() {
+		tmp = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tmp)
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+		buffer = &bytes.Buffer{}
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		ctx = logging.LoggerIntoContext(context.Background(), logger)
+		ctx = terminal.ConsoleIntoContext(ctx, console)
+	})
+
+	// importFile exports the given configuration to a file, and then runs the command to import it with the given
+	// additional arguments. The standard input of the tests isn't a terminal, so confirmation can't be requested.
+	importFile := func(cfg *config.Config, args ...string) error {
+		data, err := config.Export(cfg, "")
+		Expect(err).ToNot(HaveOccurred())
+		file := filepath.Join(tmp, "exported.yaml")
+		Expect(os.WriteFile(file, data, 0600)).To(Succeed())
+		cmd := Cmd()
+		cmd.SetArgs(append([]string{"--file", file}, args...))
+		cmd.SetOut(GinkgoWriter)
+		cmd.SetErr(GinkgoWriter)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return cmd.ExecuteContext(ctx)
+	}
+
+	It("Imports settings that don't run scripts without confirmation", func() {
+		err := importFile(&config.Config{
+			Address: "api.example.com:443",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(ContainSubstring("Imported settings for server 'api.example.com:443'."))
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Address).To(Equal("api.example.com:443"))
+	})
+
+	It("Preserves the local settings", func() {
+		logMaxFiles := 3
+		err := config.Save(&config.Config{
+			Address:      "old.example.com:443",
+			TokenStorage: config.TokenStorageFile,
+			StateDir:     "/my/state",
+			CacheDir:     "/my/cache",
+			LogMaxSize:   "10MiB",
+			LogMaxAge:    "24h",
+			LogMaxFiles:  &logMaxFiles,
+			Favorites: []config.Favorite{{
+				Type: "fulfillment.v1.Cluster",
+				Id:   "123",
+				Name: "my-cluster",
+			}},
+			Theme:        "dark",
+			Units:        "decimal",
+			TimeZone:     "Europe/Madrid",
+			OtelEndpoint: "https://otel.example.com",
+			OtelHeaders: map[string]string{
+				"Authorization": "Bearer my-token",
+			},
+			Macros: map[string]string{
+				"mine": "this.metadata.creators.exists(c, c == 'me')",
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		err = importFile(&config.Config{
+			Address: "api.example.com:443",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Address).To(Equal("api.example.com:443"))
+		Expect(cfg.TokenStorage).To(Equal(config.TokenStorageFile))
+		Expect(cfg.StateDir).To(Equal("/my/state"))
+		Expect(cfg.CacheDir).To(Equal("/my/cache"))
+		Expect(cfg.LogMaxSize).To(Equal("10MiB"))
+		Expect(cfg.LogMaxAge).To(Equal("24h"))
+		Expect(cfg.LogMaxFiles).To(Equal(&logMaxFiles))
+		Expect(cfg.Favorites).To(Equal([]config.Favorite{{
+			Type: "fulfillment.v1.Cluster",
+			Id:   "123",
+			Name: "my-cluster",
+		}}))
+		Expect(cfg.Theme).To(Equal("dark"))
+		Expect(cfg.Units).To(Equal("decimal"))
+		Expect(cfg.TimeZone).To(Equal("Europe/Madrid"))
+		Expect(cfg.OtelEndpoint).To(Equal("https://otel.example.com"))
+		Expect(cfg.OtelHeaders).To(Equal(map[string]string{
+			"Authorization": "Bearer my-token",
+		}))
+		Expect(cfg.Macros).To(Equal(map[string]string{
+			"mine": "this.metadata.creators.exists(c, c == 'me')",
+		}))
+	})
+
+	It("Doesn't save scripts without confirmation", func() {
+		err := importFile(&config.Config{
+			Address:          "api.example.com:443",
+			TokenScript:      "get-token.sh",
+			TokenScriptShell: "/bin/bash",
+			Hooks: map[string]string{
+				"pre_delete": "./confirm-change.sh",
+			},
+			HooksShell: "pwsh",
+		})
+		Expect(err).To(MatchError(exit.General))
+		Expect(buffer.String()).To(ContainSubstring("  token_script: get-token.sh\n"))
+		Expect(buffer.String()).To(ContainSubstring("  token_script_shell: /bin/bash\n"))
+		Expect(buffer.String()).To(ContainSubstring("  hooks.pre_delete: ./confirm-change.sh\n"))
+		Expect(buffer.String()).To(ContainSubstring("  hooks_shell: pwsh\n"))
+		Expect(buffer.String()).To(ContainSubstring("use '--trust-scripts'"))
+		Expect(buffer.String()).To(ContainSubstring("The settings weren't imported."))
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Address).To(BeEmpty())
+		Expect(cfg.TokenScript).To(BeEmpty())
+		Expect(cfg.Hooks).To(BeEmpty())
+	})
+
+	It("Saves scripts when they are explicitly trusted", func() {
+		err := importFile(
+			&config.Config{
+				Address:     "api.example.com:443",
+				TokenScript: "get-token.sh",
+			},
+			"--trust-scripts",
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(ContainSubstring("  token_script: get-token.sh\n"))
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.TokenScript).To(Equal("get-token.sh"))
+	})
+})
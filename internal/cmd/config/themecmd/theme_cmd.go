@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package themecmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "theme [NAME]",
+		Short: "List or select the color themes",
+		Long: "Without arguments list the color themes, marking the one that is currently selected. With the name " +
+			"of a theme select it and save it in the configuration. Themes are used only when the output uses " +
+			"color, see the '--color' option.",
+		Example: "  # List the color themes:\n" +
+			"  fulfillment-cli config theme\n\n" +
+			"  # Select the high contrast theme:\n" +
+			"  fulfillment-cli config theme high-contrast",
+		Args: cobra.MaximumNArgs(1),
+		RunE: runner.run,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string,
+			cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return rendering.ThemeNames(), cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+// themeResult is the representation of a theme in the machine readable output.
+type themeResult struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Current     bool   `json:"current"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Get the currently selected theme:
+	settings, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	current := settings.Theme
+	if current == "" {
+		current = rendering.DefaultThemeName
+	}
+
+	// Without arguments list the themes:
+	if len(args) == 0 {
+		return c.list(ctx, current)
+	}
+
+	// Check that the theme exists:
+	name := args[0]
+	if rendering.LookupTheme(name) == nil {
+		return exit.Usagef(
+			"unknown color theme '%s', should be %s",
+			name, rendering.QuoteList(rendering.ThemeNames()),
+		)
+	}
+
+	// Save the theme:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.Theme = name
+	err = config.Save(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Selected color theme",
+		slog.String("previous", current),
+		slog.String("theme", name),
+	)
+	c.console.Printf(ctx, "Selected color theme '%s'.\n", name)
+	return nil
+}
+
+// list writes the list of themes, marking the current one, and with an example of the colors of each theme when the
+// output uses color.
+func (c *runnerContext) list(ctx context.Context, current string) error {
+	themes := rendering.Themes()
+
+	// Write the machine readable output if requested:
+	if output.IsJson(ctx) {
+		results := make([]themeResult, len(themes))
+		for i, theme := range themes {
+			results[i] = themeResult{
+				Name:        theme.Name,
+				Description: theme.Description,
+				Current:     theme.Name == current,
+			}
+		}
+		c.console.RenderJson(ctx, results)
+		return nil
+	}
+
+	// The example goes in the last column because the escape sequences would break the alignment of the rest:
+	colored := c.console.MessagesTheme() != nil
+	buffer := &bytes.Buffer{}
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CURRENT\tNAME\tDESCRIPTION")
+	if colored {
+		fmt.Fprintf(writer, "\tEXAMPLE")
+	}
+	fmt.Fprintf(writer, "\n")
+	for _, theme := range themes {
+		mark := ""
+		if theme.Name == current {
+			mark = "*"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s", mark, theme.Name, theme.Description)
+		if colored {
+			examples := []string{"READY", "PROGRESSING", "FAILED"}
+			for i, example := range examples {
+				examples[i] = theme.State(example)
+			}
+			fmt.Fprintf(writer, "\t%s", strings.Join(examples, " "))
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+	err := writer.Flush()
+	if err != nil {
+		return err
+	}
+	c.console.Printf(ctx, "%s", buffer.String())
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// Cmd creates and returns the command that converts manifests between formats.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "convert --filename FILE [--output yaml|json]",
+		Short: "Convert manifests between YAML and JSON",
+		Long: "Convert a manifest, like those accepted by the 'create --filename' command, between the YAML and " +
+			"JSON formats. The objects are decoded and encoded again with the protocol buffers types compiled " +
+			"into the binary, so unknown types and fields are reported, and the result is normalized. Nothing is " +
+			"sent to the server.",
+		Example: "  # Convert a JSON manifest to YAML:\n" +
+			"  fulfillment-cli convert --filename my-cluster.json --output yaml > my-cluster.yaml\n\n" +
+			"  # Normalize a YAML manifest read from the standard input:\n" +
+			"  fulfillment-cli convert --filename - < my-cluster.yaml",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.file,
+		"filename",
+		"f",
+		"",
+		"Name of the file containing the objects to convert. This is mandatory. If the value is '-' the objects "+
+			"are read from the standard input.",
+	)
+	flags.StringVarP(
+		&runner.args.format,
+		"output",
+		"o",
+		manifest.FormatYaml,
+		fmt.Sprintf("Output format, '%s' or '%s'.", manifest.FormatYaml, manifest.FormatJson),
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		file   string
+		format string
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	input   io.Reader
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Check the flags:
+	if c.args.file == "" {
+		return exit.Usagef("it is mandatory to specify the input file with the '--filename' or '-f' options")
+	}
+	if c.args.format != manifest.FormatYaml && c.args.format != manifest.FormatJson {
+		return exit.Usagef(
+			"unknown output format '%s', should be '%s' or '%s'",
+			c.args.format, manifest.FormatYaml, manifest.FormatJson,
+		)
+	}
+
+	// Open the input:
+	reader := c.input
+	if c.args.file == "-" {
+		if reader == nil {
+			reader = os.Stdin
+		}
+	} else {
+		var file *os.File
+		file, err = os.Open(c.args.file)
+		if err != nil {
+			return fmt.Errorf("failed to open the file '%s': %w", c.args.file, err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	// Decode the objects, which checks that the types and fields exist, and then encode them in the requested
+	// format:
+	objects, err := manifest.Decode(reader)
+	if err != nil {
+		return fmt.Errorf("failed to decode the objects of file '%s': %w", c.args.file, err)
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Converting objects",
+		slog.String("file", c.args.file),
+		slog.Int("count", len(objects)),
+		slog.String("format", c.args.format),
+	)
+	return manifest.Encode(c.console, objects, c.args.format)
+}
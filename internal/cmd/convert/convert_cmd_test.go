@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/osac-project/fulfillment-common/logging"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+var _ = Describe("Convert command", func() {
+	var (
+		ctx    context.Context
+		buffer *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		ctx = logging.LoggerIntoContext(context.Background(), logger)
+		ctx = terminal.ConsoleIntoContext(ctx, console)
+	})
+
+	// convert runs the command with the given input and arguments.
+	convert := func(input string, args ...string) error {
+		cmd := Cmd()
+		cmd.SetArgs(args)
+		cmd.SetOut(GinkgoWriter)
+		cmd.SetErr(GinkgoWriter)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		if input != "" {
+			file := filepath.Join(GinkgoT().TempDir(), "input")
+			Expect(os.WriteFile(file, []byte(input), 0600)).To(Succeed())
+			cmd.SetArgs(append([]string{"--filename", file}, args...))
+		}
+		return cmd.ExecuteContext(ctx)
+	}
+
+	It("Converts JSON to YAML", func() {
+		err := convert(
+			`{"@type": "type.googleapis.com/fulfillment.v1.Cluster", "metadata": {"name": "my-cluster"}}`,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"'@type': type.googleapis.com/fulfillment.v1.Cluster\n" +
+				"metadata:\n" +
+				"    name: my-cluster\n",
+		))
+	})
+
+	It("Converts YAML to JSON", func() {
+		err := convert(
+			"'@type': type.googleapis.com/fulfillment.v1.Cluster\n"+
+				"spec:\n"+
+				"  template: my-template\n",
+			"--output", "json",
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(MatchJSON(`{
+			"@type": "type.googleapis.com/fulfillment.v1.Cluster",
+			"spec": {
+				"template": "my-template"
+			}
+		}`))
+	})
+
+	It("Writes multiple objects as separate YAML documents", func() {
+		err := convert(`[
+			{"@type": "type.googleapis.com/fulfillment.v1.Cluster", "metadata": {"name": "my-cluster"}},
+			{"@type": "type.googleapis.com/fulfillment.v1.Host", "metadata": {"name": "my-host"}}
+		]`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.Split(buffer.String(), "---\n")).To(HaveLen(2))
+	})
+
+	It("Rejects unknown fields", func() {
+		err := convert(`{"@type": "type.googleapis.com/fulfillment.v1.Cluster", "spek": {}}`)
+		Expect(err).To(MatchError(ContainSubstring(`unknown field "spek"`)))
+		Expect(buffer.Len()).To(BeZero())
+	})
+
+	It("Rejects unknown types", func() {
+		err := convert(`{"@type": "type.googleapis.com/fulfillment.v1.Clustr"}`)
+		Expect(err).To(MatchError(ContainSubstring("fulfillment.v1.Clustr")))
+	})
+
+	It("Requires the input file", func() {
+		err := convert("")
+		Expect(err).To(MatchError(ContainSubstring("it is mandatory to specify the input file")))
+		code, ok := exit.CodeOf(err)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(exit.Usage))
+	})
+
+	It("Rejects unknown output formats", func() {
+		err := convert(`{"@type": "type.googleapis.com/fulfillment.v1.Cluster"}`, "--output", "xml")
+		Expect(err).To(MatchError("unknown output format 'xml', should be 'yaml' or 'json'"))
+	})
+})
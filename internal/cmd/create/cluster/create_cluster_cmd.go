@@ -16,27 +16,24 @@ package cluster
 import (
 	"context"
 	"embed"
-	"errors"
 	"fmt"
 	"log/slog"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/anypb"
-	"google.golang.org/protobuf/types/known/durationpb"
-	"google.golang.org/protobuf/types/known/timestamppb"
-	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/output"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/wait"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
 )
@@ -67,29 +64,28 @@ func Cmd() *cobra.Command {
 		"",
 		"Template identifier or name",
 	)
-	flags.StringSliceVarP(
-		&runner.args.templateParameterValues,
-		"template-parameter",
-		"p",
+	templateparams.AddFlags(flags, &runner.args.templateParameters)
+	flags.StringArrayVar(
+		&runner.args.nodeSets,
+		"node-set",
 		[]string{},
-		"Template parameter in the format 'name=value'.",
-	)
-	flags.StringSliceVarP(
-		&runner.args.templateParameterFiles,
-		"template-parameter-file",
-		"f",
-		[]string{},
-		"Template parameter from file in the format 'name=filename'.",
+		"Node set in the format 'name=host_class:value,size:value' (e.g., 'workers=host_class:acme_1tb,size:5'). "+
+			"The name must be one of the node sets defined by the template, and the values that aren't given "+
+			"are taken from the template.",
 	)
+	manifest.AddFlag(flags, &runner.args.saveManifest)
+	wait.AddFlags(flags, &runner.args.wait)
 	return result
 }
 
 type runnerContext struct {
 	args struct {
-		name                    string
-		template                string
-		templateParameterValues []string
-		templateParameterFiles  []string
+		name               string
+		template           string
+		templateParameters templateparams.Args
+		nodeSets           []string
+		saveManifest       string
+		wait               wait.Args
 	}
 	logger          *slog.Logger
 	console         *terminal.Console
@@ -124,7 +120,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	// Check that we have a template:
 	if c.args.template == "" {
-		return fmt.Errorf("template identifier or name is required")
+		return exit.Usagef("template identifier or name is required")
 	}
 
 	// Create the gRPC connection from the configuration:
@@ -154,20 +150,40 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if template == nil {
-		return exit.Error(1)
-	}
 
 	// Parse the template parameters:
-	templateParameterValues, templateParameterIssues := c.parseTemplateParameters(ctx, template)
+	parser, err := templateparams.NewParser().
+		SetLogger(c.logger).
+		AddDefinitions(templateparams.Definitions(template.GetParameters())...).
+		SetFileEncoding(c.args.templateParameters.FileEncoding).
+		SetFileMaxSize(c.args.templateParameters.FileMaxSize).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create template parameters parser: %w", err)
+	}
+	templateParameterValues, templateParameterIssues := parser.Parse(
+		ctx,
+		c.args.templateParameters.Values,
+		c.args.templateParameters.Files,
+	)
 	if len(templateParameterIssues) > 0 {
-		validTemplateParameters := c.validTemplateParameters(template)
 		c.console.Render(ctx, "template_parameter_issues.txt", map[string]any{
 			"Template":   c.args.template,
-			"Parameters": validTemplateParameters,
+			"Parameters": parser.Valid(),
 			"Issues":     templateParameterIssues,
 		})
-		return exit.Error(1)
+		return exit.Usage
+	}
+
+	// Parse the node sets:
+	nodeSets, nodeSetIssues := c.parseNodeSets(template)
+	if len(nodeSetIssues) > 0 {
+		c.console.Render(ctx, "node_set_issues.txt", map[string]any{
+			"Template": c.args.template,
+			"NodeSets": c.validNodeSets(template),
+			"Issues":   nodeSetIssues,
+		})
+		return exit.Usage
 	}
 
 	// Prepare the cluster:
@@ -178,9 +194,19 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		Spec: ffv1.ClusterSpec_builder{
 			Template:           template.GetId(),
 			TemplateParameters: templateParameterValues,
+			NodeSets:           nodeSets,
 		}.Build(),
 	}.Build()
 
+	// Check that the server supports the cluster before trying to create it:
+	objectHelper := helper.Lookup(string(cluster.ProtoReflect().Descriptor().FullName()))
+	if objectHelper != nil {
+		err = objectHelper.Probe(ctx, cluster)
+		if err != nil {
+			return fmt.Errorf("cluster can't be created: %w", err)
+		}
+	}
+
 	// Create the cluster:
 	response, err := c.clustersClient.Create(ctx, ffv1.ClustersCreateRequest_builder{
 		Object: cluster,
@@ -190,10 +216,40 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display the result:
-	cluster = response.Object
-	c.console.Printf(ctx, "Created cluster '%s'.\n", cluster.Id)
+	c.console.Printf(ctx, "Created cluster '%s'.\n", response.Object.GetId())
+
+	// Save the manifest if requested:
+	if c.args.saveManifest != "" {
+		file, err := manifest.Save(c.args.saveManifest, cluster, response.Object.GetId())
+		if err != nil {
+			return err
+		}
+		c.console.Printf(ctx, "Saved manifest to '%s'.\n", file)
+	}
 
-	return nil
+	// Wait till the cluster is ready if requested:
+	var object proto.Message = response.GetObject()
+	if c.args.wait.Wait {
+		waiter, err := wait.NewWaiter().
+			SetLogger(c.logger).
+			SetConnection(conn).
+			SetListener(func(ctx context.Context, object proto.Message, state string) {
+				c.console.Printf(ctx, "Cluster '%s' is %s.\n", response.GetObject().GetId(), c.console.State(state))
+			}).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to create waiter: %w", err)
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, c.args.wait.Timeout)
+		defer cancel()
+		object, err = waiter.Wait(waitCtx, object)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write the created object if the machine readable output format was requested:
+	return output.WriteObjects(ctx, object)
 }
 
 // findTemplate finds a cluster template by identifier or name. It tries to find by identifier first, and if that fails
@@ -229,7 +285,7 @@ func (c *runnerContext) findTemplate(ctx context.Context) (result *ffv1.ClusterT
 			"Ref":     c.args.template,
 			"Total":   total,
 		})
-		err = exit.Error(1)
+		err = exit.General
 		return
 	}
 
@@ -245,108 +301,38 @@ func (c *runnerContext) findTemplate(ctx context.Context) (result *ffv1.ClusterT
 		"Examples": examples,
 		"Ref":      c.args.template,
 	})
-	err = exit.Error(1)
+	err = exit.NotFound
 	return
 }
 
-// parseTemplateParameters parses the '--template-parameter' and '--template-parameter-file' flags into a map of
-// parameter name to value, and a list of issues found. The issues are intended for display to the user.
-func (c *runnerContext) parseTemplateParameters(ctx context.Context,
-	template *ffv1.ClusterTemplate) (result map[string]*anypb.Any, issues []string) {
-	// Prepare empty results and issues:
-	result = map[string]*anypb.Any{}
-
-	// Make a map of parameter definitions indexed by name for quick lookup:
-	definitions := map[string]*ffv1.ClusterTemplateParameterDefinition{}
-	for _, definition := range template.GetParameters() {
-		definitions[definition.GetName()] = definition
+// parseNodeSets parses the '--node-set' flags into a map of node set name to node set, and a list of issues found. The
+// issues are intended for display to the user. Values that aren't given in the flags are copied from the node set of
+// the template. The result is nil if no node set was given, so that the server uses the defaults of the template.
+func (c *runnerContext) parseNodeSets(template *ffv1.ClusterTemplate) (result map[string]*ffv1.ClusterNodeSet,
+	issues []string) {
+	if len(c.args.nodeSets) == 0 {
+		return
 	}
-
-	// Parse '--template-parameter' flags:
-	for _, flag := range c.args.templateParameterValues {
+	result = map[string]*ffv1.ClusterNodeSet{}
+	definitions := template.GetNodeSets()
+	for _, flag := range c.args.nodeSets {
 		parts := strings.SplitN(flag, "=", 2)
 		if len(parts) != 2 {
-			name := strings.TrimSpace(flag)
-			definition := definitions[name]
-			if definition == nil {
-				issues = append(
-					issues,
-					fmt.Sprintf(
-						"In '%s' parameter '%s' doesn't exist, and if it existed the value "+
-							"would be missing",
-						flag, name,
-					),
-				)
-			} else {
-				issues = append(
-					issues,
-					fmt.Sprintf(
-						"In '%s' parameter value is missing",
-						flag,
-					),
-				)
-			}
-			continue
-		}
-		name := strings.TrimSpace(parts[0])
-		if name == "" {
 			issues = append(
 				issues,
 				fmt.Sprintf(
-					"In '%s' parameter name is missing",
+					"In '%s' expected format 'name=host_class:value,size:value'",
 					flag,
 				),
 			)
 			continue
 		}
-		definition := definitions[name]
-		if definition == nil {
-			issues = append(
-				issues,
-				fmt.Sprintf(
-					"In '%s' parameter '%s' doesn't exist",
-					flag, name,
-				),
-			)
-			continue
-		}
-		text := strings.TrimSpace(parts[1])
-		value, issue := c.convertTextToTemplateParameterValue(ctx, text, definition.GetType())
-		if issue != "" {
-			issues = append(issues, fmt.Sprintf("In '%s' %s", flag, issue))
-			continue
-		}
-		result[name] = value
-	}
-
-	// Parse '--template-parameter-file' flags:
-	for _, flag := range c.args.templateParameterFiles {
-		parts := strings.SplitN(flag, "=", 2)
-		if len(parts) != 2 {
-			name := strings.TrimSpace(flag)
-			definition := definitions[name]
-			if definition == nil {
-				issues = append(issues, fmt.Sprintf(
-					"In '%s' parameter '%s' doesn't exist, and if existed the file would be "+
-						"missing",
-					flag, name,
-				))
-			} else {
-				issues = append(
-					issues,
-					fmt.Sprintf(
-						"In '%s' file is missing",
-						flag,
-					))
-			}
-			continue
-		}
 		name := strings.TrimSpace(parts[0])
 		if name == "" {
 			issues = append(
 				issues,
 				fmt.Sprintf(
-					"In '%s' parameter name is missing",
+					"In '%s' node set name is missing",
 					flag,
 				),
 			)
@@ -357,264 +343,114 @@ func (c *runnerContext) parseTemplateParameters(ctx context.Context,
 			issues = append(
 				issues,
 				fmt.Sprintf(
-					"In '%s' parameter '%s' doesn't exist",
+					"In '%s' node set '%s' doesn't exist",
 					flag, name,
 				),
 			)
 			continue
 		}
-		file := strings.TrimSpace(parts[1])
-		if file == "" {
+		if _, exists := result[name]; exists {
 			issues = append(
 				issues,
 				fmt.Sprintf(
-					"In '%s' file is missing",
-					flag,
-				),
-			)
-			continue
-		}
-		data, err := os.ReadFile(file)
-		if errors.Is(err, os.ErrNotExist) {
-			issues = append(
-				issues, fmt.Sprintf(
-					"In '%s' file '%s' doesn't exist",
-					flag, file,
+					"In '%s' node set '%s' has already been specified",
+					flag, name,
 				),
 			)
 			continue
 		}
-		if err != nil {
+		if strings.TrimSpace(parts[1]) == "" {
 			issues = append(
 				issues,
 				fmt.Sprintf(
-					"In '%s' failed to read file '%s': %v",
-					flag, file, err,
+					"In '%s' at least one of 'host_class' or 'size' is required",
+					flag,
 				),
 			)
 			continue
 		}
-		text := string(data)
-		value, issue := c.convertTextToTemplateParameterValue(ctx, text, definition.GetType())
-		if issue != "" {
-			issues = append(
-				issues,
-				fmt.Sprintf("In '%s' %s'", flag, issue),
-			)
-			continue
-		}
-		result[name] = value
-	}
-
-	// Add issues for missing required parameters, at the end of the list and sorted by parameter name:
-	var missing []*ffv1.ClusterTemplateParameterDefinition
-	for _, definition := range template.GetParameters() {
-		if definition.GetRequired() && result[definition.GetName()] == nil {
-			missing = append(missing, definition)
-		}
-	}
-	sort.Slice(missing, func(i, j int) bool {
-		return missing[i].GetName() < missing[j].GetName()
-	})
-	for _, definition := range missing {
-		issues = append(
-			issues,
-			fmt.Sprintf("Parameter '%s' is required", definition.GetName()),
-		)
-	}
-
-	return
-}
-
-// convertTextToTemplateParameterValue converts a string value to the appropriate protobuf type based on the kind. It
-// returns the value and a string descibing the issue if the conversion fails.
-func (c *runnerContext) convertTextToTemplateParameterValue(ctx context.Context, text,
-	kind string) (result *anypb.Any, issue string) {
-	var wrapper proto.Message
-	switch kind {
-	case "type.googleapis.com/google.protobuf.StringValue":
-		wrapper = &wrapperspb.StringValue{Value: text}
-	case "type.googleapis.com/google.protobuf.BoolValue":
-		text = strings.TrimSpace(text)
-		value, err := strconv.ParseBool(text)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse boolean",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf(
-				"value '%s' isn't a valid boolean, valid values are 'true' and 'false'",
-				text,
-			)
-			return
-		}
-		wrapper = &wrapperspb.BoolValue{Value: value}
-	case "type.googleapis.com/google.protobuf.Int32Value":
-		text = strings.TrimSpace(text)
-		var value int64
-		value, err := strconv.ParseInt(text, 10, 32)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 32-bit integer number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 32-bit integer", text)
-			return
-		}
-		wrapper = &wrapperspb.Int32Value{Value: int32(value)}
-	case "type.googleapis.com/google.protobuf.Int64Value":
-		text = strings.TrimSpace(text)
-		var value int64
-		value, err := strconv.ParseInt(text, 10, 64)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 64-bit integer number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 64-bit integer", text)
-			return
-		}
-		wrapper = &wrapperspb.Int64Value{Value: value}
-	case "type.googleapis.com/google.protobuf.FloatValue":
-		text = strings.TrimSpace(text)
-		var value float64
-		value, err := strconv.ParseFloat(text, 32)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 32-bit floating point number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 32-bit floating point number", text)
-			return
-		}
-		wrapper = &wrapperspb.FloatValue{Value: float32(value)}
-	case "type.googleapis.com/google.protobuf.DoubleValue":
-		text = strings.TrimSpace(text)
-		var value float64
-		value, err := strconv.ParseFloat(text, 64)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 64-bit floating point number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 64-bit floating point numberw", text)
-			return
-		}
-		wrapper = &wrapperspb.DoubleValue{Value: value}
-	case "type.googleapis.com/google.protobuf.BytesValue":
-		wrapper = &wrapperspb.BytesValue{Value: []byte(text)}
-	case "type.googleapis.com/google.protobuf.Timestamp":
-		text = strings.TrimSpace(text)
-		var value time.Time
-		value, err := time.Parse(time.RFC3339, text)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse RFC3339 timestamp",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid RFC3339 timestamp", text)
-			return
+		hostClass := definition.GetHostClass()
+		size := definition.GetSize()
+		valid := true
+		for _, field := range strings.Split(parts[1], ",") {
+			key, value, found := strings.Cut(field, ":")
+			key = strings.TrimSpace(key)
+			value = strings.TrimSpace(value)
+			if !found || value == "" {
+				issues = append(
+					issues,
+					fmt.Sprintf(
+						"In '%s' value of '%s' is missing",
+						flag, key,
+					),
+				)
+				valid = false
+				continue
+			}
+			switch key {
+			case "host_class":
+				hostClass = value
+			case "size":
+				parsed, err := strconv.ParseInt(value, 10, 32)
+				if err != nil || parsed <= 0 {
+					issues = append(
+						issues,
+						fmt.Sprintf(
+							"In '%s' size '%s' isn't a positive integer",
+							flag, value,
+						),
+					)
+					valid = false
+					continue
+				}
+				size = int32(parsed)
+			default:
+				issues = append(
+					issues,
+					fmt.Sprintf(
+						"In '%s' unknown field '%s', valid fields are 'host_class' and 'size'",
+						flag, key,
+					),
+				)
+				valid = false
+			}
 		}
-		wrapper = timestamppb.New(value)
-	case "type.googleapis.com/google.protobuf.Duration":
-		var value time.Duration
-		value, err := time.ParseDuration(text)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse duration",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid duration", text)
-			return
+		if !valid {
+			continue
 		}
-		wrapper = durationpb.New(value)
-	default:
-		issue = fmt.Sprintf("flag has is of an unsupported type '%s'", kind)
-		return
-	}
-	if issue != "" {
-		return
-	}
-	result, err := anypb.New(wrapper)
-	if err != nil {
-		c.logger.DebugContext(
-			ctx,
-			"Failed to create protobuf value for template parameter",
-			slog.String("text", text),
-			slog.String("kind", kind),
-			slog.Any("error", err),
-		)
-		issue = fmt.Sprintf("Failed to create protobuf value for template parameter: %v", err)
-		return
+		result[name] = ffv1.ClusterNodeSet_builder{
+			HostClass: hostClass,
+			Size:      size,
+		}.Build()
 	}
 	return
 }
 
-// validTemplateParameter contains the information about a valid template parameter, for use in the error messages that
-// display them.
-type validTemplateParameter struct {
-	// Name is the name of the parameter.
+// validNodeSet contains the information about a node set of a template, for use in the error messages that display
+// them.
+type validNodeSet struct {
+	// Name is the name of the node set.
 	Name string
 
-	// Type is the type of the parameter.
-	Type string
+	// HostClass is the default host class of the node set.
+	HostClass string
 
-	// Title is the title of the parameter.
-	Title string
+	// Size is the default size of the node set.
+	Size int32
 }
 
-// validTemplateParameters returns the list of valid template parameters for the given template.
-func (c *runnerContext) validTemplateParameters(template *ffv1.ClusterTemplate) []validTemplateParameter {
-	// Prepare the results:
-	results := []validTemplateParameter{}
-	for _, parameter := range template.GetParameters() {
-		result := validTemplateParameter{
-			Name:  parameter.GetName(),
-			Title: parameter.GetTitle(),
-		}
-		switch parameter.GetType() {
-		case "type.googleapis.com/google.protobuf.StringValue":
-			result.Type = "string"
-		case "type.googleapis.com/google.protobuf.BoolValue":
-			result.Type = "boolean"
-		case "type.googleapis.com/google.protobuf.Int32Value":
-			result.Type = "int32"
-		case "type.googleapis.com/google.protobuf.Int64Value":
-			result.Type = "int64"
-		case "type.googleapis.com/google.protobuf.FloatValue":
-			result.Type = "float"
-		case "type.googleapis.com/google.protobuf.DoubleValue":
-			result.Type = "double"
-		case "type.googleapis.com/google.protobuf.BytesValue":
-			result.Type = "bytes"
-		case "type.googleapis.com/google.protobuf.Timestamp":
-			result.Type = "timestamp"
-		case "type.googleapis.com/google.protobuf.Duration":
-			result.Type = "duration"
-		default:
-			result.Type = "unknown"
-		}
-		results = append(results, result)
+// validNodeSets returns the list of node sets of the given template, sorted by name.
+func (c *runnerContext) validNodeSets(template *ffv1.ClusterTemplate) []validNodeSet {
+	results := []validNodeSet{}
+	for name, nodeSet := range template.GetNodeSets() {
+		results = append(results, validNodeSet{
+			Name:      name,
+			HostClass: nodeSet.GetHostClass(),
+			Size:      nodeSet.GetSize(),
+		})
 	}
-
-	// Sort the result by name so that the output will be predictable:
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Name < results[j].Name
 	})
-
 	return results
 }
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		runner := &runnerContext{}
+		template := ffv1.ClusterTemplate_builder{
+			Id: "small",
+			NodeSets: map[string]*ffv1.ClusterTemplateNodeSet{
+				"workers": ffv1.ClusterTemplateNodeSet_builder{
+					HostClass: "acme_1tib",
+					Size:      3,
+				}.Build(),
+			},
+		}.Build()
+		templates := []*ffv1.ClusterTemplate{
+			ffv1.ClusterTemplate_builder{Id: "small"}.Build(),
+			ffv1.ClusterTemplate_builder{Id: "large"}.Build(),
+		}
+		err = checker.Check(consoletest.Cases{
+			"node_set_issues.txt": {
+				map[string]any{
+					"Template": "small",
+					"NodeSets": runner.validNodeSets(template),
+					"Issues":   []string{"node set 'junk' doesn't exist"},
+				},
+				map[string]any{
+					"Template": "small",
+					"NodeSets": []validNodeSet{},
+					"Issues":   []string{"node set 'junk' doesn't exist"},
+				},
+			},
+			"template_conflict.txt": {
+				map[string]any{
+					"Matches": templates,
+					"Ref":     "my",
+					"Total":   int32(2),
+				},
+				map[string]any{
+					"Matches": templates,
+					"Ref":     "my",
+					"Total":   int32(10),
+				},
+			},
+			"template_not_found.txt": {
+				map[string]any{
+					"Examples": templates,
+					"Ref":      "medium",
+				},
+				map[string]any{
+					"Examples": []*ffv1.ClusterTemplate{},
+					"Ref":      "medium",
+				},
+			},
+			"template_parameter_issues.txt": {
+				map[string]any{
+					"Template": "small",
+					"Parameters": []templateparams.ValidParameter{
+						{
+							Name:  "cores",
+							Type:  "int32",
+							Title: "Cores",
+						},
+						{
+							Name: "memory",
+							Type: "string",
+						},
+					},
+					"Issues": []string{"unknown parameter 'junk'"},
+				},
+				map[string]any{
+					"Template":   "small",
+					"Parameters": []templateparams.ValidParameter{},
+					"Issues":     []string{"unknown parameter 'junk'"},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -16,27 +16,24 @@ package computeinstance
 import (
 	"context"
 	"embed"
-	"errors"
 	"fmt"
 	"log/slog"
-	"os"
-	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
-	"google.golang.org/protobuf/types/known/durationpb"
-	"google.golang.org/protobuf/types/known/timestamppb"
-	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/output"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/wait"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
 )
@@ -67,20 +64,7 @@ func Cmd() *cobra.Command {
 		"",
 		"Template identifier or name",
 	)
-	flags.StringSliceVarP(
-		&runner.args.templateParameterValues,
-		"template-parameter",
-		"p",
-		[]string{},
-		"Template parameter in the format 'name=value'.",
-	)
-	flags.StringSliceVarP(
-		&runner.args.templateParameterFiles,
-		"template-parameter-file",
-		"f",
-		[]string{},
-		"Template parameter from file in the format 'name=filename'.",
-	)
+	templateparams.AddFlags(flags, &runner.args.templateParameters)
 	flags.Int32Var(
 		&runner.args.cores,
 		"cores",
@@ -141,25 +125,28 @@ func Cmd() *cobra.Command {
 		"",
 		"Name of the secret containing cloud-init user data.",
 	)
+	manifest.AddFlag(flags, &runner.args.saveManifest)
+	wait.AddFlags(flags, &runner.args.wait)
 	return result
 }
 
 type runnerContext struct {
 	args struct {
-		name                    string
-		template                string
-		templateParameterValues []string
-		templateParameterFiles  []string
-		cores                   int32
-		memoryGiB               int32
-		imageSourceRef          string
-		imageSourceType         string
-		sshKey                  string
-		bootDiskSizeGiB         int32
-		bootDiskStorageClass    string
-		additionalDisks         []string
-		runStrategy             string
-		userDataSecretRef       string
+		name                 string
+		template             string
+		templateParameters   templateparams.Args
+		cores                int32
+		memoryGiB            int32
+		imageSourceRef       string
+		imageSourceType      string
+		sshKey               string
+		bootDiskSizeGiB      int32
+		bootDiskStorageClass string
+		additionalDisks      []string
+		runStrategy          string
+		userDataSecretRef    string
+		saveManifest         string
+		wait                 wait.Args
 	}
 	logger                 *slog.Logger
 	console                *terminal.Console
@@ -194,7 +181,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	// Check that we have a template:
 	if c.args.template == "" {
-		return fmt.Errorf("template identifier or name is required")
+		return exit.Usagef("template identifier or name is required")
 	}
 
 	// Create the gRPC connection from the configuration:
@@ -224,20 +211,30 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if template == nil {
-		return exit.Error(1)
-	}
 
 	// Parse the template parameters:
-	templateParameterValues, templateParameterIssues := c.parseTemplateParameters(ctx, template)
+	parser, err := templateparams.NewParser().
+		SetLogger(c.logger).
+		AddDefinitions(templateparams.Definitions(template.GetParameters())...).
+		SetDefaults(true).
+		SetFileEncoding(c.args.templateParameters.FileEncoding).
+		SetFileMaxSize(c.args.templateParameters.FileMaxSize).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create template parameters parser: %w", err)
+	}
+	templateParameterValues, templateParameterIssues := parser.Parse(
+		ctx,
+		c.args.templateParameters.Values,
+		c.args.templateParameters.Files,
+	)
 	if len(templateParameterIssues) > 0 {
-		validTemplateParameters := c.validTemplateParameters(template)
 		c.console.Render(ctx, "template_parameter_issues.txt", map[string]any{
 			"Template":   c.args.template,
-			"Parameters": validTemplateParameters,
+			"Parameters": parser.Valid(),
 			"Issues":     templateParameterIssues,
 		})
-		return exit.Error(1)
+		return exit.Usage
 	}
 
 	// Build the spec:
@@ -254,6 +251,15 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		Spec: spec,
 	}.Build()
 
+	// Check that the server supports the compute instance before trying to create it:
+	objectHelper := helper.Lookup(string(computeInstance.ProtoReflect().Descriptor().FullName()))
+	if objectHelper != nil {
+		err = objectHelper.Probe(ctx, computeInstance)
+		if err != nil {
+			return fmt.Errorf("compute instance can't be created: %w", err)
+		}
+	}
+
 	// Create the compute instance:
 	response, err := c.computeInstancesClient.Create(ctx, ffv1.ComputeInstancesCreateRequest_builder{
 		Object: computeInstance,
@@ -263,10 +269,44 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display the result:
-	computeInstance = response.Object
-	c.console.Printf(ctx, "Created compute instance '%s'.\n", computeInstance.Id)
+	c.console.Printf(ctx, "Created compute instance '%s'.\n", response.Object.GetId())
+
+	// Save the manifest if requested:
+	if c.args.saveManifest != "" {
+		file, err := manifest.Save(c.args.saveManifest, computeInstance, response.Object.GetId())
+		if err != nil {
+			return err
+		}
+		c.console.Printf(ctx, "Saved manifest to '%s'.\n", file)
+	}
+
+	// Wait till the compute instance is ready if requested:
+	var object proto.Message = response.GetObject()
+	if c.args.wait.Wait {
+		waiter, err := wait.NewWaiter().
+			SetLogger(c.logger).
+			SetConnection(conn).
+			SetListener(func(ctx context.Context, object proto.Message, state string) {
+				c.console.Printf(
+					ctx,
+					"Compute instance '%s' is %s.\n",
+					response.GetObject().GetId(), c.console.State(state),
+				)
+			}).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to create waiter: %w", err)
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, c.args.wait.Timeout)
+		defer cancel()
+		object, err = waiter.Wait(waitCtx, object)
+		if err != nil {
+			return err
+		}
+	}
 
-	return nil
+	// Write the created object if the machine readable output format was requested:
+	return output.WriteObjects(ctx, object)
 }
 
 // findTemplate finds a compute instance template by identifier or name. It tries to find by identifier or name using a
@@ -301,7 +341,7 @@ func (c *runnerContext) findTemplate(ctx context.Context) (result *ffv1.ComputeI
 			"Ref":     c.args.template,
 			"Total":   total,
 		})
-		err = exit.Error(1)
+		err = exit.General
 		return
 	}
 
@@ -317,322 +357,7 @@ func (c *runnerContext) findTemplate(ctx context.Context) (result *ffv1.ComputeI
 		"Examples": examples,
 		"Ref":      c.args.template,
 	})
-	err = exit.Error(1)
-	return
-}
-
-// parseTemplateParameters parses the '--template-parameter' and '--template-parameter-file' flags into a map of
-// parameter name to value, and a list of issues found. The issues are intended for display to the user.
-func (c *runnerContext) parseTemplateParameters(ctx context.Context,
-	template *ffv1.ComputeInstanceTemplate) (result map[string]*anypb.Any, issues []string) {
-	// Prepare empty results and issues:
-	result = map[string]*anypb.Any{}
-
-	// Make a map of parameter definitions indexed by name for quick lookup:
-	definitions := map[string]*ffv1.ComputeInstanceTemplateParameterDefinition{}
-	for _, definition := range template.GetParameters() {
-		definitions[definition.GetName()] = definition
-	}
-
-	// Parse '--template-parameter' flags:
-	for _, flag := range c.args.templateParameterValues {
-		parts := strings.SplitN(flag, "=", 2)
-		if len(parts) != 2 {
-			name := strings.TrimSpace(flag)
-			definition := definitions[name]
-			if definition == nil {
-				issues = append(
-					issues,
-					fmt.Sprintf(
-						"In '%s' parameter '%s' doesn't exist, and if it existed the value "+
-							"would be missing",
-						flag, name,
-					),
-				)
-			} else {
-				issues = append(
-					issues,
-					fmt.Sprintf(
-						"In '%s' parameter value is missing",
-						flag,
-					),
-				)
-			}
-			continue
-		}
-		name := strings.TrimSpace(parts[0])
-		if name == "" {
-			issues = append(
-				issues,
-				fmt.Sprintf(
-					"In '%s' parameter name is missing",
-					flag,
-				),
-			)
-			continue
-		}
-		definition := definitions[name]
-		if definition == nil {
-			issues = append(
-				issues,
-				fmt.Sprintf(
-					"In '%s' parameter '%s' doesn't exist",
-					flag, name,
-				),
-			)
-			continue
-		}
-		text := strings.TrimSpace(parts[1])
-		value, issue := c.convertTextToTemplateParameterValue(ctx, text, definition.GetType())
-		if issue != "" {
-			issues = append(issues, fmt.Sprintf("In '%s' %s", flag, issue))
-			continue
-		}
-		result[name] = value
-	}
-
-	// Parse '--template-parameter-file' flags:
-	for _, flag := range c.args.templateParameterFiles {
-		parts := strings.SplitN(flag, "=", 2)
-		if len(parts) != 2 {
-			name := strings.TrimSpace(flag)
-			definition := definitions[name]
-			if definition == nil {
-				issues = append(issues, fmt.Sprintf(
-					"In '%s' parameter '%s' doesn't exist, and if existed the file would be "+
-						"missing",
-					flag, name,
-				))
-			} else {
-				issues = append(
-					issues,
-					fmt.Sprintf(
-						"In '%s' file is missing",
-						flag,
-					))
-			}
-			continue
-		}
-		name := strings.TrimSpace(parts[0])
-		if name == "" {
-			issues = append(
-				issues,
-				fmt.Sprintf(
-					"In '%s' parameter name is missing",
-					flag,
-				),
-			)
-			continue
-		}
-		definition := definitions[name]
-		if definition == nil {
-			issues = append(
-				issues,
-				fmt.Sprintf(
-					"In '%s' parameter '%s' doesn't exist",
-					flag, name,
-				),
-			)
-			continue
-		}
-		file := strings.TrimSpace(parts[1])
-		if file == "" {
-			issues = append(
-				issues,
-				fmt.Sprintf(
-					"In '%s' file is missing",
-					flag,
-				),
-			)
-			continue
-		}
-		data, err := os.ReadFile(file)
-		if errors.Is(err, os.ErrNotExist) {
-			issues = append(
-				issues, fmt.Sprintf(
-					"In '%s' file '%s' doesn't exist",
-					flag, file,
-				),
-			)
-			continue
-		}
-		if err != nil {
-			issues = append(
-				issues,
-				fmt.Sprintf(
-					"In '%s' failed to read file '%s': %v",
-					flag, file, err,
-				),
-			)
-			continue
-		}
-		text := string(data)
-		value, issue := c.convertTextToTemplateParameterValue(ctx, text, definition.GetType())
-		if issue != "" {
-			issues = append(
-				issues,
-				fmt.Sprintf("In '%s' %s'", flag, issue),
-			)
-			continue
-		}
-		result[name] = value
-	}
-
-	// Add issues for missing required parameters, at the end of the list and sorted by parameter name:
-	var missing []*ffv1.ComputeInstanceTemplateParameterDefinition
-	for _, definition := range template.GetParameters() {
-		if definition.GetRequired() && result[definition.GetName()] == nil {
-			missing = append(missing, definition)
-		}
-	}
-	sort.Slice(missing, func(i, j int) bool {
-		return missing[i].GetName() < missing[j].GetName()
-	})
-	for _, definition := range missing {
-		issues = append(
-			issues,
-			fmt.Sprintf("Parameter '%s' is required", definition.GetName()),
-		)
-	}
-
-	return
-}
-
-// convertTextToTemplateParameterValue converts a string value to the appropriate protobuf type based on the kind. It
-// returns the value and a string descibing the issue if the conversion fails.
-func (c *runnerContext) convertTextToTemplateParameterValue(ctx context.Context, text,
-	kind string) (result *anypb.Any, issue string) {
-	var wrapper proto.Message
-	switch kind {
-	case "type.googleapis.com/google.protobuf.StringValue":
-		wrapper = &wrapperspb.StringValue{Value: text}
-	case "type.googleapis.com/google.protobuf.BoolValue":
-		text = strings.TrimSpace(text)
-		value, err := strconv.ParseBool(text)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse boolean",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf(
-				"value '%s' isn't a valid boolean, valid values are 'true' and 'false'",
-				text,
-			)
-			return
-		}
-		wrapper = &wrapperspb.BoolValue{Value: value}
-	case "type.googleapis.com/google.protobuf.Int32Value":
-		text = strings.TrimSpace(text)
-		var value int64
-		value, err := strconv.ParseInt(text, 10, 32)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 32-bit integer number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 32-bit integer", text)
-			return
-		}
-		wrapper = &wrapperspb.Int32Value{Value: int32(value)}
-	case "type.googleapis.com/google.protobuf.Int64Value":
-		text = strings.TrimSpace(text)
-		var value int64
-		value, err := strconv.ParseInt(text, 10, 64)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 64-bit integer number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 64-bit integer", text)
-			return
-		}
-		wrapper = &wrapperspb.Int64Value{Value: value}
-	case "type.googleapis.com/google.protobuf.FloatValue":
-		text = strings.TrimSpace(text)
-		var value float64
-		value, err := strconv.ParseFloat(text, 32)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 32-bit floating point number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 32-bit floating point number", text)
-			return
-		}
-		wrapper = &wrapperspb.FloatValue{Value: float32(value)}
-	case "type.googleapis.com/google.protobuf.DoubleValue":
-		text = strings.TrimSpace(text)
-		var value float64
-		value, err := strconv.ParseFloat(text, 64)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse 64-bit floating point number",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid 64-bit floating point numberw", text)
-			return
-		}
-		wrapper = &wrapperspb.DoubleValue{Value: value}
-	case "type.googleapis.com/google.protobuf.BytesValue":
-		wrapper = &wrapperspb.BytesValue{Value: []byte(text)}
-	case "type.googleapis.com/google.protobuf.Timestamp":
-		text = strings.TrimSpace(text)
-		var value time.Time
-		value, err := time.Parse(time.RFC3339, text)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse RFC3339 timestamp",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid RFC3339 timestamp", text)
-			return
-		}
-		wrapper = timestamppb.New(value)
-	case "type.googleapis.com/google.protobuf.Duration":
-		var value time.Duration
-		value, err := time.ParseDuration(text)
-		if err != nil {
-			c.logger.DebugContext(
-				ctx,
-				"Failed to parse duration",
-				slog.String("text", text),
-				slog.Any("error", err),
-			)
-			issue = fmt.Sprintf("value '%s' isn't a valid duration", text)
-			return
-		}
-		wrapper = durationpb.New(value)
-	default:
-		issue = fmt.Sprintf("flag has is of an unsupported type '%s'", kind)
-		return
-	}
-	if issue != "" {
-		return
-	}
-	result, err := anypb.New(wrapper)
-	if err != nil {
-		c.logger.DebugContext(
-			ctx,
-			"Failed to create protobuf value for template parameter",
-			slog.String("text", text),
-			slog.String("kind", kind),
-			slog.Any("error", err),
-		)
-		issue = fmt.Sprintf("Failed to create protobuf value for template parameter: %v", err)
-		return
-	}
+	err = exit.NotFound
 	return
 }
 
@@ -698,7 +423,7 @@ func parseAdditionalDisks(diskArgs []string) ([]*ffv1.ComputeInstanceDisk, error
 		}
 		sizeStr, ok := fields["size"]
 		if !ok {
-			return nil, fmt.Errorf("invalid disk format '%s': 'size' is required", arg)
+			return nil, exit.Usagef("invalid disk format '%s': 'size' is required", arg)
 		}
 		sizeGiB, err := strconv.ParseInt(sizeStr, 10, 32)
 		if err != nil {
@@ -714,58 +439,3 @@ func parseAdditionalDisks(diskArgs []string) ([]*ffv1.ComputeInstanceDisk, error
 	}
 	return disks, nil
 }
-
-// validTemplateParameter contains the information about a valid template parameter, for use in the error messages that
-// display them.
-type validTemplateParameter struct {
-	// Name is the name of the parameter.
-	Name string
-
-	// Type is the type of the parameter.
-	Type string
-
-	// Title is the title of the parameter.
-	Title string
-}
-
-// validTemplateParameters returns the list of valid template parameters for the given template.
-func (c *runnerContext) validTemplateParameters(template *ffv1.ComputeInstanceTemplate) []validTemplateParameter {
-	// Prepare the results:
-	results := []validTemplateParameter{}
-	for _, parameter := range template.GetParameters() {
-		result := validTemplateParameter{
-			Name:  parameter.GetName(),
-			Title: parameter.GetTitle(),
-		}
-		switch parameter.GetType() {
-		case "type.googleapis.com/google.protobuf.StringValue":
-			result.Type = "string"
-		case "type.googleapis.com/google.protobuf.BoolValue":
-			result.Type = "boolean"
-		case "type.googleapis.com/google.protobuf.Int32Value":
-			result.Type = "int32"
-		case "type.googleapis.com/google.protobuf.Int64Value":
-			result.Type = "int64"
-		case "type.googleapis.com/google.protobuf.FloatValue":
-			result.Type = "float"
-		case "type.googleapis.com/google.protobuf.DoubleValue":
-			result.Type = "double"
-		case "type.googleapis.com/google.protobuf.BytesValue":
-			result.Type = "bytes"
-		case "type.googleapis.com/google.protobuf.Timestamp":
-			result.Type = "timestamp"
-		case "type.googleapis.com/google.protobuf.Duration":
-			result.Type = "duration"
-		default:
-			result.Type = "unknown"
-		}
-		results = append(results, result)
-	}
-
-	// Sort the result by name so that the output will be predictable:
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Name < results[j].Name
-	})
-
-	return results
-}
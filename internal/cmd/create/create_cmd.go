@@ -14,8 +14,7 @@ language governing permissions and limitations under the License.
 package create
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
@@ -24,16 +23,18 @@ import (
 	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/anypb"
-	"gopkg.in/yaml.v3"
 
+	"github.com/osac-project/fulfillment-cli/internal/batch"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/create/cluster"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/create/computeinstance"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/create/host"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/create/hostpool"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/create/hub"
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/output"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
@@ -43,10 +44,14 @@ func Cmd() *cobra.Command {
 	result := &cobra.Command{
 		Use:   "create [OPTION]...",
 		Short: "Create objects",
-		RunE:  runner.run,
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		RunE: runner.run,
 	}
 	result.AddCommand(cluster.Cmd())
 	result.AddCommand(computeinstance.Cmd())
+	result.AddCommand(host.Cmd())
 	result.AddCommand(hostpool.Cmd())
 	result.AddCommand(hub.Cmd())
 	flags := result.Flags()
@@ -58,12 +63,17 @@ func Cmd() *cobra.Command {
 		"Name of the file containg the object to create. This is mandatory. If the value is '-' the object is "+
 			"read from the standard input.",
 	)
+	batch.AddFlag(flags, &runner.args.concurrency)
+	batch.AddLimitFlags(flags, &runner.args.maxConsecutiveFailures, &runner.args.maxFailureRate)
 	return result
 }
 
 type runnerContext struct {
 	args struct {
-		file string
+		file                   string
+		concurrency            int
+		maxConsecutiveFailures int
+		maxFailureRate         int
 	}
 	logger  *slog.Logger
 	console *terminal.Console
@@ -106,7 +116,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	// Check the flags:
 	if c.args.file == "" {
-		return fmt.Errorf("it is mandatory to specify the input file with the '--filename' or '-f' options")
+		return exit.Usagef("it is mandatory to specify the input file with the '--filename' or '-f' options")
 	}
 
 	// Open the input:
@@ -133,10 +143,14 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Convert the input to a list of objects, and then create them:
-	objects, err := c.decodeObjects(reader)
+	objects, err := manifest.Decode(reader)
 	if err != nil {
 		return err
 	}
+
+	// Check that the server supports all the objects before creating any of them, so that a version mismatch
+	// between the CLI and the server doesn't result in a partially created set of objects:
+	objectHelpers := make([]*reflection.ObjectHelper, len(objects))
 	for i, object := range objects {
 		objectDesc := object.ProtoReflect().Descriptor()
 		objectType := string(objectDesc.FullName())
@@ -144,7 +158,28 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		if objectHelper == nil {
 			return fmt.Errorf("input object at index %d is of an unknown type '%s'", i, objectType)
 		}
-		object, err = objectHelper.Create(ctx, object)
+		err = objectHelper.Probe(ctx, object)
+		if err != nil {
+			return fmt.Errorf("input object at index %d can't be created: %w", i, err)
+		}
+		objectHelpers[i] = objectHelper
+	}
+
+	// Create the objects, using as many workers as requested. Note that with the default concurrency the objects
+	// are created in the order they appear in the input, which matters when some of them reference others.
+	runner, err := batch.NewRunner().
+		SetLogger(c.logger).
+		SetConcurrency(c.args.concurrency).
+		SetMaxConsecutiveFailures(c.args.maxConsecutiveFailures).
+		SetMaxFailureRate(c.args.maxFailureRate).
+		Build()
+	if err != nil {
+		return err
+	}
+	created := make([]proto.Message, len(objects))
+	err = runner.Run(ctx, len(objects), func(ctx context.Context, i int) error {
+		objectHelper := objectHelpers[i]
+		object, err := objectHelper.Create(ctx, objects[i])
 		if err != nil {
 			return fmt.Errorf("failed to create object at index %d: %w", i, err)
 		}
@@ -164,72 +199,13 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 				objectSingular, objectId,
 			)
 		}
+		created[i] = object
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
-
-// decode reads the given input, which may contain multiple YAML or JSON documents, each of them being a single object
-// or alist, and returns the corresponding list of protocol buffers messages
-func (c *runnerContext) decodeObjects(input io.Reader) (result []proto.Message, err error) {
-	// Parse the input file assuming it is a YAML file. As JSON is a subset of YAML, this will also work for JSON.
-	decoder := yaml.NewDecoder(input)
-	var items []any
-	for {
-		var item any
-		err = decoder.Decode(&item)
-		if errors.Is(err, io.EOF) {
-			break
-		}
-		if err != nil {
-			return
-		}
-		items = append(items, item)
-	}
-
-	// Items may be a single object or a list of objects. Those that are a list need to be converted to single
-	// objects.
-	list := make([]any, 0, len(items))
-	for _, item := range items {
-		switch item := item.(type) {
-		case []any:
-			list = append(list, item...)
-		default:
-			list = append(list, item)
-		}
-	}
-
-	// We assume that input objects are protocol buffers any objects, and we need to convert them to the
-	// appropriate type.
-	objects := make([]proto.Message, len(list))
-	for i, item := range list {
-		var data []byte
-		data, err = json.Marshal(item)
-		if err != nil {
-			err = fmt.Errorf("failed to convert item at index %d to JSON: %w", i, err)
-			return
-		}
-		value := &anypb.Any{}
-		err = protojson.Unmarshal(data, value)
-		if err != nil {
-			err = fmt.Errorf(
-				"failed to unmarshal item at index %d to a protocol buffers any: %w",
-				i, err,
-			)
-			return
-		}
-		var object proto.Message
-		object, err = value.UnmarshalNew()
-		if err != nil {
-			err = fmt.Errorf(
-				"failed to unmarshal object at index %d to a protocol buffers object: %w",
-				i, err,
-			)
-			return
-		}
-		objects[i] = object
-	}
-
-	result = objects
-	return
+	// Write the created objects if the machine readable output format was requested:
+	return output.WriteObjects(ctx, created...)
 }
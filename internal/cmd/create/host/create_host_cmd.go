@@ -0,0 +1,425 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package host
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// Annotations used to store the onboarding details of hosts. The host type doesn't have fields for these details
+// yet, so they are stored in the metadata for the server side tools that complete the registration.
+const (
+	BmcAddressAnnotation     = "osac.io/bmc-address"
+	BmcCredentialsAnnotation = "osac.io/bmc-credentials"
+	BootMacAnnotation        = "osac.io/boot-mac-address"
+	HostClassAnnotation      = "osac.io/host-class"
+)
+
+// Schemes accepted in BMC addresses given as URLs:
+var bmcSchemes = []string{"https", "http", "redfish", "redfish-virtualmedia", "idrac", "idrac-virtualmedia", "ipmi"}
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "host [flags]",
+		Aliases: []string{string(proto.MessageName((*ffv1.Host)(nil)))},
+		Short:   "Register a host",
+		Long: "Register a new host giving the address of its BMC, the reference to the credentials used to access " +
+			"it, and its host class. The host class is checked against the host classes that exist in the " +
+			"server.\n\n" +
+			"With the '--discover' option the values that aren't given in the command line are requested " +
+			"interactively, validating each of them before moving to the next. With the '--file' option the " +
+			"hosts are read from an inventory file, which is convenient to register all the hosts of a rack " +
+			"at once. Inventory files can be CSV files, with one host per row, or YAML files containing a " +
+			"list of hosts. All the hosts of the file are validated before registering any of them, and the " +
+			"problems are reported with the line where they are.",
+		Example: "  # Register a host giving all the details in the command line:\n" +
+			"  fulfillment-cli create host --name rack1-node1 --bmc-address redfish://10.0.0.11 " +
+			"--bmc-credentials rack1-bmc --host-class large --mac 52:54:00:6b:3c:58\n\n" +
+			"  # Register a host answering questions for the details:\n" +
+			"  fulfillment-cli create host --discover\n\n" +
+			"  # Register all the hosts described in a CSV file:\n" +
+			"  fulfillment-cli create host --file rack1.csv\n\n" +
+			"  # Check the hosts described in a YAML file without registering them:\n" +
+			"  fulfillment-cli create host --file rack1.yaml --dry-run",
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.name,
+		"name",
+		"n",
+		"",
+		"Name of the host.",
+	)
+	flags.StringVar(
+		&runner.args.bmcAddress,
+		"bmc-address",
+		"",
+		"Address of the BMC of the host, either a host name or IP address with an optional port, or an URL "+
+			"like 'redfish://10.0.0.11'.",
+	)
+	flags.StringVar(
+		&runner.args.bmcCredentials,
+		"bmc-credentials",
+		"",
+		"Reference to the credentials used to access the BMC, for example the name of a secret. The "+
+			"credentials themselves are never sent by this command.",
+	)
+	flags.StringVar(
+		&runner.args.mac,
+		"mac",
+		"",
+		"MAC address of the network interface that the host uses to boot, for example '52:54:00:6b:3c:58'. "+
+			"This is optional.",
+	)
+	flags.StringVar(
+		&runner.args.hostClass,
+		"host-class",
+		"",
+		"Identifier or name of the host class.",
+	)
+	flags.BoolVar(
+		&runner.args.discover,
+		"discover",
+		false,
+		"Request interactively the details that aren't given in the command line.",
+	)
+	addFileFlag(flags, &runner.args.file)
+	flags.StringVar(
+		&runner.args.csv,
+		"csv",
+		"",
+		"Name of a CSV file containing the hosts to register. This is the same than '--file', but the file "+
+			"is always parsed as CSV, regardless of its extension.",
+	)
+	flags.BoolVar(
+		&runner.args.dryRun,
+		"dry-run",
+		false,
+		"Validate the hosts without registering them.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		name           string
+		bmcAddress     string
+		bmcCredentials string
+		hostClass      string
+		mac            string
+		discover       bool
+		file           string
+		csv            string
+		dryRun         bool
+	}
+	logger        *slog.Logger
+	console       *terminal.Console
+	hostsClient   ffv1.HostsClient
+	classesClient ffv1.HostClassesClient
+	classes       []*ffv1.HostClass
+	input         io.Reader
+}
+
+// hostDetails contains the details needed to register a host.
+type hostDetails struct {
+	Name           string
+	BmcAddress     string
+	BmcCredentials string
+	HostClass      string
+	Mac            string
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and the console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Check the options:
+	if c.args.file != "" && c.args.csv != "" {
+		return exit.Usagef("options '--file' and '--csv' can't be used together")
+	}
+	file, format := c.args.file, inventoryFormat(c.args.file)
+	if c.args.csv != "" {
+		file, format = c.args.csv, inventoryFormatCsv
+	}
+	if file != "" && c.args.discover {
+		return exit.Usagef("options '--file' and '--discover' can't be used together")
+	}
+	if file != "" && c.hasDetailFlags() {
+		return exit.Usagef(
+			"options '--name', '--bmc-address', '--bmc-credentials', '--host-class' and '--mac' can't be " +
+				"used together with '--file', the details are taken from the file",
+		)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the gRPC clients:
+	c.hostsClient = ffv1.NewHostsClient(conn)
+	c.classesClient = ffv1.NewHostClassesClient(conn)
+
+	// Load the host classes, as they are needed to validate the details:
+	err = c.loadClasses(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Register the hosts from the inventory file, if requested:
+	if file != "" {
+		return c.runInventory(ctx, file, format)
+	}
+
+	// Collect the details of the host, asking for them if requested:
+	details := &hostDetails{
+		Name:           c.args.name,
+		BmcAddress:     c.args.bmcAddress,
+		BmcCredentials: c.args.bmcCredentials,
+		HostClass:      c.args.hostClass,
+		Mac:            c.args.mac,
+	}
+	if c.args.discover {
+		err = c.discover(ctx, details)
+		if err != nil {
+			return err
+		}
+	}
+	err = c.validate(details)
+	if err != nil {
+		return err
+	}
+
+	// Register the host:
+	if c.args.dryRun {
+		c.console.Printf(ctx, "Host '%s' is valid, not registered because of '--dry-run'.\n", details.Name)
+		return nil
+	}
+	host, err := c.create(ctx, details)
+	if err != nil {
+		return err
+	}
+	c.console.Printf(ctx, "Registered host '%s'.\n", host.GetId())
+
+	// Write the created object if the machine readable output format was requested:
+	return output.WriteObjects(ctx, host)
+}
+
+// hasDetailFlags checks if any of the flags that give the details of a single host has been used.
+func (c *runnerContext) hasDetailFlags() bool {
+	return c.args.name != "" || c.args.bmcAddress != "" || c.args.bmcCredentials != "" || c.args.hostClass != "" ||
+		c.args.mac != ""
+}
+
+// loadClasses loads the host classes that exist in the server. If the server doesn't support host classes they are
+// left empty and then the host class isn't validated.
+func (c *runnerContext) loadClasses(ctx context.Context) error {
+	response, err := c.classesClient.List(ctx, ffv1.HostClassesListRequest_builder{}.Build())
+	if grpcstatus.Code(err) == codes.Unimplemented {
+		c.logger.WarnContext(
+			ctx,
+			"Server doesn't support host classes, they will not be validated",
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list host classes: %w", err)
+	}
+	c.classes = response.GetItems()
+	return nil
+}
+
+// classNames returns the names of the host classes, or the identifiers for the classes that don't have a name.
+func (c *runnerContext) classNames() []string {
+	result := make([]string, len(c.classes))
+	for i, class := range c.classes {
+		result[i] = class.GetMetadata().GetName()
+		if result[i] == "" {
+			result[i] = class.GetId()
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// lookupClass finds the host class with the given identifier or name. It returns nil if there is no such class.
+func (c *runnerContext) lookupClass(ref string) *ffv1.HostClass {
+	for _, class := range c.classes {
+		if class.GetId() == ref || class.GetMetadata().GetName() == ref {
+			return class
+		}
+	}
+	return nil
+}
+
+// validate checks that all the details of the host are present and have valid values.
+func (c *runnerContext) validate(details *hostDetails) error {
+	if details.Name == "" {
+		return fmt.Errorf("name is mandatory")
+	}
+	err := validateBmcAddress(details.BmcAddress)
+	if err != nil {
+		return err
+	}
+	if details.BmcCredentials == "" {
+		return fmt.Errorf("BMC credentials reference is mandatory")
+	}
+	err = validateMac(details.Mac)
+	if err != nil {
+		return err
+	}
+	return c.validateClass(details.HostClass)
+}
+
+// validateClass checks that the given host class exists. When the server doesn't support host classes it only checks
+// that the value isn't empty.
+func (c *runnerContext) validateClass(ref string) error {
+	if ref == "" {
+		return fmt.Errorf("host class is mandatory")
+	}
+	if c.classes == nil || c.lookupClass(ref) != nil {
+		return nil
+	}
+	names := c.classNames()
+	if len(names) == 0 {
+		return fmt.Errorf("host class '%s' doesn't exist, and there are no host classes", ref)
+	}
+	return fmt.Errorf(
+		"host class '%s' doesn't exist, valid host classes are '%s'",
+		ref, strings.Join(names, "', '"),
+	)
+}
+
+// validateBmcAddress checks that the given BMC address is a host name or IP address with an optional port, or an URL
+// with one of the supported schemes.
+func validateBmcAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("BMC address is mandatory")
+	}
+	host := address
+	if strings.Contains(address, "://") {
+		parsed, err := url.Parse(address)
+		if err != nil {
+			return fmt.Errorf("BMC address '%s' isn't a valid URL: %w", address, err)
+		}
+		if !slices.Contains(bmcSchemes, parsed.Scheme) {
+			return fmt.Errorf(
+				"BMC address '%s' has unsupported scheme '%s', valid schemes are '%s'",
+				address, parsed.Scheme, strings.Join(bmcSchemes, "', '"),
+			)
+		}
+		host = parsed.Host
+	}
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if host == "" || strings.ContainsAny(host, " /\t") {
+		return fmt.Errorf("BMC address '%s' doesn't contain a valid host name or IP address", address)
+	}
+	return nil
+}
+
+// validateMac checks that the given boot MAC address, if not empty, is a valid Ethernet address.
+func validateMac(address string) error {
+	if address == "" {
+		return nil
+	}
+	parsed, err := net.ParseMAC(address)
+	if err != nil || len(parsed) != 6 {
+		return fmt.Errorf("boot MAC address '%s' isn't valid, it should be like '52:54:00:6b:3c:58'", address)
+	}
+	return nil
+}
+
+// create registers the host with the given details.
+func (c *runnerContext) create(ctx context.Context, details *hostDetails) (result *ffv1.Host, err error) {
+	annotations := map[string]string{
+		BmcAddressAnnotation:     details.BmcAddress,
+		BmcCredentialsAnnotation: details.BmcCredentials,
+		HostClassAnnotation:      details.HostClass,
+	}
+	if details.Mac != "" {
+		annotations[BootMacAnnotation] = details.Mac
+	}
+	host := ffv1.Host_builder{
+		Metadata: sharedv1.Metadata_builder{
+			Name:        details.Name,
+			Annotations: annotations,
+		}.Build(),
+		Spec: ffv1.HostSpec_builder{}.Build(),
+	}.Build()
+	response, err := c.hostsClient.Create(ctx, ffv1.HostsCreateRequest_builder{
+		Object: host,
+	}.Build())
+	if err != nil {
+		err = fmt.Errorf("failed to register host '%s': %w", details.Name, err)
+		return
+	}
+	result = response.GetObject()
+	c.logger.DebugContext(
+		ctx,
+		"Registered host",
+		slog.String("id", result.GetId()),
+		slog.String("name", details.Name),
+		slog.String("bmc_address", details.BmcAddress),
+		slog.String("host_class", details.HostClass),
+		slog.String("mac", details.Mac),
+	)
+	return
+}
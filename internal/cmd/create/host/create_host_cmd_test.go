@@ -0,0 +1,483 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package host
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+// hostsServer is a hosts server that remembers the hosts created, and rejects the names that start with 'bad-'.
+type hostsServer struct {
+	ffv1.UnimplementedHostsServer
+	created []*ffv1.Host
+}
+
+func (s *hostsServer) Create(ctx context.Context, request *ffv1.HostsCreateRequest) (*ffv1.HostsCreateResponse,
+	error) {
+	host := request.GetObject()
+	if strings.HasPrefix(host.GetMetadata().GetName(), "bad-") {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "name is rejected")
+	}
+	host.SetId(fmt.Sprintf("host-%d", len(s.created)+1))
+	s.created = append(s.created, host)
+	return ffv1.HostsCreateResponse_builder{
+		Object: host,
+	}.Build(), nil
+}
+
+// classesServer is a host classes server that returns a fixed list of classes.
+type classesServer struct {
+	ffv1.UnimplementedHostClassesServer
+}
+
+func (s *classesServer) List(ctx context.Context, request *ffv1.HostClassesListRequest) (
+	*ffv1.HostClassesListResponse, error) {
+	return ffv1.HostClassesListResponse_builder{
+		Items: []*ffv1.HostClass{
+			ffv1.HostClass_builder{
+				Id: "123",
+				Metadata: sharedv1.Metadata_builder{
+					Name: "small",
+				}.Build(),
+			}.Build(),
+			ffv1.HostClass_builder{
+				Id: "456",
+				Metadata: sharedv1.Metadata_builder{
+					Name: "large",
+				}.Build(),
+			}.Build(),
+		},
+	}.Build(), nil
+}
+
+var _ = Describe("Create host", func() {
+	var (
+		ctx    context.Context
+		buffer *gbytes.Buffer
+		hosts  *hostsServer
+		runner *runnerContext
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		// Start the server:
+		hosts = &hostsServer{}
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterHostsServer(server.Registrar(), hosts)
+		ffv1.RegisterHostClassesServer(server.Registrar(), &classesServer{})
+		server.Start()
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		// Create the runner:
+		buffer = gbytes.NewBuffer()
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		runner = &runnerContext{
+			logger:        logger,
+			console:       console,
+			hostsClient:   ffv1.NewHostsClient(conn),
+			classesClient: ffv1.NewHostClassesClient(conn),
+		}
+		err = runner.loadClasses(ctx)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable(
+		"Validates BMC addresses",
+		func(address string, expected string) {
+			err := validateBmcAddress(address)
+			if expected == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			}
+		},
+		Entry("IP address", "10.0.0.11", ""),
+		Entry("IP address and port", "10.0.0.11:623", ""),
+		Entry("IPv6 address and port", "[fd00::11]:623", ""),
+		Entry("Host name", "bmc1.example.com", ""),
+		Entry("Redfish URL", "redfish://10.0.0.11/redfish/v1/Systems/1", ""),
+		Entry("HTTPS URL with port", "https://bmc1.example.com:8443", ""),
+		Entry("Empty", "", "mandatory"),
+		Entry("Unsupported scheme", "ftp://10.0.0.11", "unsupported scheme 'ftp'"),
+		Entry("URL without host", "redfish:///systems", "valid host name"),
+		Entry("Spaces", "bmc 1", "valid host name"),
+	)
+
+	DescribeTable(
+		"Validates boot MAC addresses",
+		func(address string, expected string) {
+			err := validateMac(address)
+			if expected == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			}
+		},
+		Entry("Empty", "", ""),
+		Entry("Colons", "52:54:00:6b:3c:58", ""),
+		Entry("Dashes", "52-54-00-6B-3C-58", ""),
+		Entry("Too short", "52:54:00:6b:3c", "isn't valid"),
+		Entry("Infiniband", "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01", "isn't valid"),
+		Entry("Junk", "junk", "isn't valid"),
+	)
+
+	DescribeTable(
+		"Calculates the format of inventory files",
+		func(file string, expected string) {
+			Expect(inventoryFormat(file)).To(Equal(expected))
+		},
+		Entry("CSV", "rack1.csv", inventoryFormatCsv),
+		Entry("YAML", "rack1.yaml", inventoryFormatYaml),
+		Entry("YML in upper case", "RACK1.YML", inventoryFormatYaml),
+		Entry("JSON", "rack1.json", inventoryFormatYaml),
+		Entry("No extension", "rack1", inventoryFormatCsv),
+		Entry("Standard input", "-", inventoryFormatCsv),
+	)
+
+	It("Accepts host classes by identifier or name", func() {
+		Expect(runner.validateClass("123")).To(Succeed())
+		Expect(runner.validateClass("large")).To(Succeed())
+	})
+
+	It("Rejects host classes that don't exist", func() {
+		err := runner.validateClass("huge")
+		Expect(err).To(MatchError("host class 'huge' doesn't exist, valid host classes are 'large', 'small'"))
+	})
+
+	It("Stores the details in annotations", func() {
+		host, err := runner.create(ctx, &hostDetails{
+			Name:           "rack1-node1",
+			BmcAddress:     "redfish://10.0.0.11",
+			BmcCredentials: "rack1-bmc",
+			HostClass:      "large",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(host.GetId()).To(Equal("host-1"))
+		Expect(host.GetMetadata().GetName()).To(Equal("rack1-node1"))
+		Expect(host.GetMetadata().GetAnnotations()).To(Equal(map[string]string{
+			BmcAddressAnnotation:     "redfish://10.0.0.11",
+			BmcCredentialsAnnotation: "rack1-bmc",
+			HostClassAnnotation:      "large",
+		}))
+	})
+
+	It("Stores the boot MAC address in an annotation", func() {
+		host, err := runner.create(ctx, &hostDetails{
+			Name:           "rack1-node1",
+			BmcAddress:     "redfish://10.0.0.11",
+			BmcCredentials: "rack1-bmc",
+			HostClass:      "large",
+			Mac:            "52:54:00:6b:3c:58",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(host.GetMetadata().GetAnnotations()).To(HaveKeyWithValue(BootMacAnnotation, "52:54:00:6b:3c:58"))
+	})
+
+	It("Asks for the missing details until they are valid", func() {
+		runner.input = strings.NewReader(strings.Join(
+			[]string{
+				"",
+				"rack1-node1",
+				"ftp://10.0.0.11",
+				"10.0.0.11",
+				"rack1-bmc",
+				"junk",
+				"52:54:00:6b:3c:58",
+				"huge",
+				"small",
+			},
+			"\n",
+		) + "\n")
+		details := &hostDetails{}
+		err := runner.discover(ctx, details)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(details).To(Equal(&hostDetails{
+			Name:           "rack1-node1",
+			BmcAddress:     "10.0.0.11",
+			BmcCredentials: "rack1-bmc",
+			HostClass:      "small",
+			Mac:            "52:54:00:6b:3c:58",
+		}))
+		Expect(buffer).To(gbytes.Say("Name: Invalid value: name is mandatory."))
+		Expect(buffer).To(gbytes.Say("unsupported scheme 'ftp'"))
+		Expect(buffer).To(gbytes.Say("boot MAC address 'junk' isn't valid"))
+		Expect(buffer).To(gbytes.Say("Available host classes: large, small"))
+		Expect(buffer).To(gbytes.Say("host class 'huge' doesn't exist"))
+	})
+
+	It("Asks only for the details that weren't given", func() {
+		runner.input = strings.NewReader("rack1-bmc\n")
+		details := &hostDetails{
+			Name:       "rack1-node1",
+			BmcAddress: "10.0.0.11",
+			HostClass:  "small",
+		}
+		err := runner.discover(ctx, details)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(details.BmcCredentials).To(Equal("rack1-bmc"))
+		Expect(details.Mac).To(BeEmpty())
+	})
+
+	It("Fails if the input ends before a valid value is entered", func() {
+		runner.input = strings.NewReader("rack1-node1\nftp://10.0.0.11")
+		err := runner.discover(ctx, &hostDetails{})
+		Expect(err).To(MatchError(ContainSubstring("input ended before a valid bmc address was entered")))
+	})
+
+	Describe("CSV", func() {
+		writeCsv := func(text string) string {
+			file := filepath.Join(GinkgoT().TempDir(), "hosts.csv")
+			err := os.WriteFile(file, []byte(text), 0600)
+			Expect(err).ToNot(HaveOccurred())
+			return file
+		}
+
+		It("Accepts columns in any order and with different spelling", func() {
+			rows, err := parseCsv(strings.NewReader(
+				"\ufeffHost Class,Name,bmc-address,BMC_Credentials\n" +
+					"small,rack1-node1,10.0.0.11,rack1-bmc\n" +
+					"\n" +
+					"large, rack1-node2 ,10.0.0.12,rack1-bmc\n",
+			))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rows).To(HaveLen(2))
+			Expect(rows[0].line).To(Equal(2))
+			Expect(rows[0].details).To(Equal(&hostDetails{
+				Name:           "rack1-node1",
+				BmcAddress:     "10.0.0.11",
+				BmcCredentials: "rack1-bmc",
+				HostClass:      "small",
+			}))
+			Expect(rows[1].line).To(Equal(4))
+			Expect(rows[1].details.Name).To(Equal("rack1-node2"))
+		})
+
+		It("Reads the optional MAC column", func() {
+			rows, err := parseCsv(strings.NewReader(
+				"name,bmc_address,bmc_credentials,host_class,mac\n" +
+					"rack1-node1,10.0.0.11,rack1-bmc,small,52:54:00:6b:3c:58\n" +
+					"rack1-node2,10.0.0.12,rack1-bmc,small\n",
+			))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rows).To(HaveLen(2))
+			Expect(rows[0].details.Mac).To(Equal("52:54:00:6b:3c:58"))
+			Expect(rows[1].details.Mac).To(BeEmpty())
+		})
+
+		It("Rejects a header without the mandatory columns", func() {
+			_, err := parseCsv(strings.NewReader("name,bmc_address\n"))
+			Expect(err).To(MatchError(ContainSubstring("doesn't contain column 'bmc_credentials'")))
+		})
+
+		It("Registers all the hosts of the file", func() {
+			file := writeCsv(
+				"name,bmc_address,bmc_credentials,host_class\n" +
+					"rack1-node1,10.0.0.11,rack1-bmc,small\n" +
+					"rack1-node2,10.0.0.12,rack1-bmc,large\n",
+			)
+			err := runner.runInventory(ctx, file, inventoryFormatCsv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hosts.created).To(HaveLen(2))
+			Expect(buffer).To(gbytes.Say("Line 2: registered host 'rack1-node1' with identifier 'host-1'."))
+			Expect(buffer).To(gbytes.Say("Line 3: registered host 'rack1-node2' with identifier 'host-2'."))
+			Expect(buffer).To(gbytes.Say("Registered 2 of 2 hosts."))
+		})
+
+		It("Doesn't register any host if a row is invalid", func() {
+			file := writeCsv(
+				"name,bmc_address,bmc_credentials,host_class\n" +
+					"rack1-node1,10.0.0.11,rack1-bmc,small\n" +
+					"rack1-node2,10.0.0.12,rack1-bmc,huge\n" +
+					"rack1-node1,10.0.0.13,rack1-bmc,small\n",
+			)
+			err := runner.runInventory(ctx, file, inventoryFormatCsv)
+			Expect(err).To(Equal(exit.Error(1)))
+			Expect(hosts.created).To(BeEmpty())
+			Expect(buffer).To(gbytes.Say("Line 3: host class 'huge' doesn't exist"))
+			Expect(buffer).To(gbytes.Say("Line 4: name 'rack1-node1' is already used in line 2."))
+			Expect(buffer).To(gbytes.Say("No host was registered"))
+		})
+
+		It("Rejects repeated MAC addresses", func() {
+			file := writeCsv(
+				"name,bmc_address,bmc_credentials,host_class,mac\n" +
+					"rack1-node1,10.0.0.11,rack1-bmc,small,52:54:00:6b:3c:58\n" +
+					"rack1-node2,10.0.0.12,rack1-bmc,small,52:54:00:6B:3C:58\n",
+			)
+			err := runner.runInventory(ctx, file, inventoryFormatCsv)
+			Expect(err).To(Equal(exit.Error(1)))
+			Expect(hosts.created).To(BeEmpty())
+			Expect(buffer).To(gbytes.Say(
+				"Line 3: boot MAC address '52:54:00:6b:3c:58' is already used in line 2.",
+			))
+		})
+
+		It("Reports the rows that the server rejects and continues", func() {
+			file := writeCsv(
+				"name,bmc_address,bmc_credentials,host_class\n" +
+					"bad-node,10.0.0.11,rack1-bmc,small\n" +
+					"rack1-node2,10.0.0.12,rack1-bmc,large\n",
+			)
+			err := runner.runInventory(ctx, file, inventoryFormatCsv)
+			Expect(err).To(Equal(exit.Error(1)))
+			Expect(hosts.created).To(HaveLen(1))
+			Expect(buffer).To(gbytes.Say("Line 2: failed to register host 'bad-node'"))
+			Expect(buffer).To(gbytes.Say("Line 3: registered host 'rack1-node2'"))
+			Expect(buffer).To(gbytes.Say("Registered 1 of 2 hosts."))
+		})
+
+		It("Doesn't register hosts in dry run mode", func() {
+			runner.args.dryRun = true
+			file := writeCsv(
+				"name,bmc_address,bmc_credentials,host_class\n" +
+					"rack1-node1,10.0.0.11,rack1-bmc,small\n",
+			)
+			err := runner.runInventory(ctx, file, inventoryFormatCsv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hosts.created).To(BeEmpty())
+			Expect(buffer).To(gbytes.Say("The 1 hosts of the file are valid"))
+		})
+	})
+
+	Describe("YAML", func() {
+		It("Reads a list of hosts", func() {
+			rows, err := parseYaml(strings.NewReader(
+				"# Rack 1:\n" +
+					"- name: rack1-node1\n" +
+					"  bmc_address: 10.0.0.11\n" +
+					"  bmc_credentials: rack1-bmc\n" +
+					"  host_class: small\n" +
+					"  mac: 52:54:00:6b:3c:58\n" +
+					"- name: rack1-node2\n" +
+					"  bmc-address: 10.0.0.12\n" +
+					"  BMC Credentials: rack1-bmc\n" +
+					"  host_class: large\n",
+			))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rows).To(HaveLen(2))
+			Expect(rows[0].line).To(Equal(2))
+			Expect(rows[0].details).To(Equal(&hostDetails{
+				Name:           "rack1-node1",
+				BmcAddress:     "10.0.0.11",
+				BmcCredentials: "rack1-bmc",
+				HostClass:      "small",
+				Mac:            "52:54:00:6b:3c:58",
+			}))
+			Expect(rows[1].line).To(Equal(7))
+			Expect(rows[1].details).To(Equal(&hostDetails{
+				Name:           "rack1-node2",
+				BmcAddress:     "10.0.0.12",
+				BmcCredentials: "rack1-bmc",
+				HostClass:      "large",
+			}))
+		})
+
+		It("Reads a map with a list of hosts", func() {
+			rows, err := parseYaml(strings.NewReader(
+				"hosts:\n" +
+					"- name: rack1-node1\n" +
+					"  bmc_address: 10.0.0.11\n",
+			))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rows).To(HaveLen(1))
+			Expect(rows[0].line).To(Equal(2))
+			Expect(rows[0].details.Name).To(Equal("rack1-node1"))
+		})
+
+		It("Accepts JSON", func() {
+			rows, err := parseYaml(strings.NewReader(
+				`[{"name": "rack1-node1", "bmc_address": "10.0.0.11"}]`,
+			))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rows).To(HaveLen(1))
+			Expect(rows[0].details.BmcAddress).To(Equal("10.0.0.11"))
+		})
+
+		It("Accepts an empty file", func() {
+			rows, err := parseYaml(strings.NewReader("# Nothing yet\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rows).To(BeEmpty())
+		})
+
+		It("Rejects unknown fields", func() {
+			_, err := parseYaml(strings.NewReader(
+				"- name: rack1-node1\n" +
+					"  bmc_adress: 10.0.0.11\n",
+			))
+			Expect(err).To(MatchError(ContainSubstring("line 2: unknown field 'bmc_adress'")))
+		})
+
+		It("Rejects values that aren't strings", func() {
+			_, err := parseYaml(strings.NewReader(
+				"- name: rack1-node1\n" +
+					"  host_class: [small, large]\n",
+			))
+			Expect(err).To(MatchError(ContainSubstring("line 2: value of field 'host_class' should be a string")))
+		})
+
+		It("Reports the lines of the invalid hosts", func() {
+			file := filepath.Join(GinkgoT().TempDir(), "hosts.yaml")
+			err := os.WriteFile(
+				file,
+				[]byte(
+					"- name: rack1-node1\n"+
+						"  bmc_address: 10.0.0.11\n"+
+						"  bmc_credentials: rack1-bmc\n"+
+						"  host_class: small\n"+
+						"- name: rack1-node2\n"+
+						"  bmc_address: 10.0.0.12\n"+
+						"  bmc_credentials: rack1-bmc\n"+
+						"  host_class: small\n"+
+						"  mac: junk\n",
+				),
+				0600,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			err = runner.runInventory(ctx, file, inventoryFormat(file))
+			Expect(err).To(Equal(exit.Error(1)))
+			Expect(hosts.created).To(BeEmpty())
+			Expect(buffer).To(gbytes.Say("Line 5: boot MAC address 'junk' isn't valid"))
+		})
+	})
+})
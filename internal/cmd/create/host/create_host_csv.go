@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package host
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Names of the columns of the CSV file:
+const (
+	csvNameColumn           = "name"
+	csvBmcAddressColumn     = "bmc_address"
+	csvBmcCredentialsColumn = "bmc_credentials"
+	csvHostClassColumn      = "host_class"
+	csvMacColumn            = "mac"
+)
+
+// Columns that must be present in the CSV file. The 'mac' column is optional, and other columns are ignored.
+var csvColumns = []string{
+	csvNameColumn,
+	csvBmcAddressColumn,
+	csvBmcCredentialsColumn,
+	csvHostClassColumn,
+}
+
+// parseCsv reads the rows of the given CSV file. The first row must contain the names of the columns, in any order.
+// Empty rows are ignored.
+func parseCsv(reader io.Reader) (result []inventoryRow, err error) {
+	parser := csv.NewReader(reader)
+	parser.FieldsPerRecord = -1
+	parser.TrimLeadingSpace = true
+	header, err := parser.Read()
+	if err == io.EOF {
+		err = fmt.Errorf("CSV file is empty, the first row should contain the names of the columns")
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read CSV header: %w", err)
+		return
+	}
+	indexes := map[string]int{}
+	for i, column := range header {
+		column = normalizeColumn(column)
+		if _, ok := indexes[column]; ok {
+			err = fmt.Errorf("column '%s' appears more than once in the CSV header", column)
+			return
+		}
+		indexes[column] = i
+	}
+	for _, column := range csvColumns {
+		if _, ok := indexes[column]; !ok {
+			err = fmt.Errorf(
+				"CSV header doesn't contain column '%s', it should contain '%s'",
+				column, strings.Join(csvColumns, "', '"),
+			)
+			return
+		}
+	}
+	for {
+		var record []string
+		record, err = parser.Read()
+		if err == io.EOF {
+			err = nil
+			return
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to read CSV file: %w", err)
+			return
+		}
+		line, _ := parser.FieldPos(0)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		value := func(column string) string {
+			index, ok := indexes[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		result = append(result, inventoryRow{
+			line: line,
+			details: &hostDetails{
+				Name:           value(csvNameColumn),
+				BmcAddress:     value(csvBmcAddressColumn),
+				BmcCredentials: value(csvBmcCredentialsColumn),
+				HostClass:      value(csvHostClassColumn),
+				Mac:            value(csvMacColumn),
+			},
+		})
+	}
+}
+
+// normalizeColumn converts the name of a column to lower case and replaces dashes and spaces with underscores, so
+// that 'BMC Address' and 'bmc-address' are both accepted for the 'bmc_address' column. It also removes the byte order
+// mark that some spreadsheets add at the beginning of the file.
+func normalizeColumn(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+	return name
+}
@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package host
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+// discover requests interactively the details of the host that are missing, checking each of them before requesting
+// the next one.
+func (c *runnerContext) discover(ctx context.Context, details *hostDetails) error {
+	input := c.input
+	if input == nil {
+		if !isatty.IsTerminal(os.Stdin.Fd()) {
+			return exit.Usagef("option '--discover' requires a terminal, use the other options to give the details")
+		}
+		input = os.Stdin
+	}
+	reader := bufio.NewReader(input)
+	var err error
+	if details.Name == "" {
+		details.Name, err = c.ask(ctx, reader, "Name", func(value string) error {
+			if value == "" {
+				return fmt.Errorf("name is mandatory")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if details.BmcAddress == "" {
+		details.BmcAddress, err = c.ask(ctx, reader, "BMC address", validateBmcAddress)
+		if err != nil {
+			return err
+		}
+	}
+	if details.BmcCredentials == "" {
+		details.BmcCredentials, err = c.ask(ctx, reader, "BMC credentials reference", func(value string) error {
+			if value == "" {
+				return fmt.Errorf("BMC credentials reference is mandatory")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if details.Mac == "" {
+		details.Mac, err = c.ask(ctx, reader, "Boot MAC address (optional)", validateMac)
+		if err != nil {
+			return err
+		}
+	}
+	if details.HostClass == "" {
+		names := c.classNames()
+		if len(names) > 0 {
+			c.console.Printf(ctx, "Available host classes: %s\n", strings.Join(names, ", "))
+		}
+		details.HostClass, err = c.ask(ctx, reader, "Host class", c.validateClass)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ask writes the given prompt and reads the answer, repeating until the answer passes the given check.
+func (c *runnerContext) ask(ctx context.Context, reader *bufio.Reader, prompt string,
+	check func(string) error) (result string, err error) {
+	for {
+		c.console.Printf(ctx, "%s: ", prompt)
+		var line string
+		line, err = reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			err = fmt.Errorf("failed to read %s: %w", strings.ToLower(prompt), err)
+			return
+		}
+		eof := err != nil
+		err = nil
+		value := strings.TrimSpace(line)
+		checkErr := check(value)
+		if checkErr == nil {
+			result = value
+			return
+		}
+		if eof {
+			c.console.Printf(ctx, "\n")
+			err = fmt.Errorf("input ended before a valid %s was entered: %w", strings.ToLower(prompt), checkErr)
+			return
+		}
+		c.console.Printf(ctx, "Invalid value: %v.\n", checkErr)
+	}
+}
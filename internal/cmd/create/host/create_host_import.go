@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package host
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+)
+
+// ImportCmd creates the 'import hosts' command. It does the same than 'create host --file', but it is easier to find
+// for those that think about onboarding a rack as importing its inventory.
+func ImportCmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "hosts -f FILE [flags]",
+		Aliases: []string{"host"},
+		Short:   "Register the hosts of an inventory file",
+		Long: "Register the hosts described in an inventory file, that can be a CSV file with one host per row, " +
+			"or a YAML file containing a list of hosts. All the hosts are validated before registering any of " +
+			"them, and the problems are reported with the line of the file where they are. If the server " +
+			"rejects some of the hosts the rest are still registered, and the rejected ones are reported.",
+		Example: "  # Register the hosts of a rack:\n" +
+			"  fulfillment-cli import hosts -f rack1.csv\n\n" +
+			"  # Check the inventory file without registering the hosts:\n" +
+			"  fulfillment-cli import hosts -f rack1.yaml --dry-run",
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	addFileFlag(flags, &runner.args.file)
+	flags.BoolVar(
+		&runner.args.dryRun,
+		"dry-run",
+		false,
+		"Validate the hosts without registering them.",
+	)
+	result.MarkFlagRequired("file")
+	return result
+}
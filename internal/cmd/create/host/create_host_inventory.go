@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package host
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+// Formats of inventory files:
+const (
+	inventoryFormatCsv  = "csv"
+	inventoryFormatYaml = "yaml"
+)
+
+// inventoryRow contains the details of a host read from an inventory file, and the number of the line where it was.
+type inventoryRow struct {
+	line    int
+	details *hostDetails
+}
+
+// addFileFlag adds the flag used to give the name of the inventory file.
+func addFileFlag(flags *pflag.FlagSet, target *string) {
+	flags.StringVarP(
+		target,
+		"file",
+		"f",
+		"",
+		"Name of an inventory file containing the hosts to register. Files with the '.yaml', '.yml' or "+
+			"'.json' extensions should contain a list of hosts, each with the 'name', 'bmc_address', "+
+			"'bmc_credentials', 'host_class' and optionally 'mac' fields. Other files are parsed as CSV, "+
+			"with one host per row, and the first row containing the names of the columns. If the value is "+
+			"'-' the file is read from the standard input, as CSV.",
+	)
+}
+
+// inventoryFormat returns the format of the given inventory file, calculated from its extension.
+func inventoryFormat(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml", ".json":
+		return inventoryFormatYaml
+	default:
+		return inventoryFormatCsv
+	}
+}
+
+// runInventory registers the hosts described in the inventory file. All the rows are validated before registering
+// any host, so that a mistake in one row doesn't result in a partially registered rack.
+func (c *runnerContext) runInventory(ctx context.Context, file string, format string) error {
+	var reader io.Reader
+	if file == "-" {
+		reader = c.input
+		if reader == nil {
+			reader = os.Stdin
+		}
+	} else {
+		handle, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed to open inventory file: %w", err)
+		}
+		defer handle.Close()
+		reader = handle
+	}
+	var rows []inventoryRow
+	var err error
+	switch format {
+	case inventoryFormatYaml:
+		rows, err = parseYaml(reader)
+	default:
+		rows, err = parseCsv(reader)
+	}
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		c.console.Printf(ctx, "There are no hosts in the inventory file.\n")
+		return nil
+	}
+
+	// Validate all the rows, including that names and MAC addresses aren't repeated:
+	failed := false
+	names := map[string]int{}
+	macs := map[string]int{}
+	for _, row := range rows {
+		err = c.validate(row.details)
+		if err == nil {
+			err = checkRepeated(names, "name", row.details.Name, row.line)
+		}
+		if err == nil && row.details.Mac != "" {
+			err = checkRepeated(macs, "boot MAC address", strings.ToLower(row.details.Mac), row.line)
+		}
+		if err != nil {
+			c.console.Printf(ctx, "Line %d: %v.\n", row.line, err)
+			failed = true
+		}
+	}
+	if failed {
+		c.console.Printf(ctx, "No host was registered, fix the errors and try again.\n")
+		return exit.Error(1)
+	}
+	if c.args.dryRun {
+		c.console.Printf(ctx, "The %d hosts of the file are valid, not registered because of '--dry-run'.\n", len(rows))
+		return nil
+	}
+
+	// Register the hosts, reporting the errors but continuing with the rest of the rows:
+	registered := 0
+	for _, row := range rows {
+		host, err := c.create(ctx, row.details)
+		if err != nil {
+			c.console.Printf(ctx, "Line %d: %v.\n", row.line, err)
+			continue
+		}
+		c.console.Printf(ctx, "Line %d: registered host '%s' with identifier '%s'.\n",
+			row.line, row.details.Name, host.GetId())
+		registered++
+	}
+	c.console.Printf(ctx, "Registered %d of %d hosts.\n", registered, len(rows))
+	if registered < len(rows) {
+		return exit.Error(1)
+	}
+	return nil
+}
+
+// checkRepeated checks that the given value hasn't been used in a previous line, and remembers it otherwise.
+func checkRepeated(seen map[string]int, what string, value string, line int) error {
+	previous, repeated := seen[value]
+	if repeated {
+		return fmt.Errorf("%s '%s' is already used in line %d", what, value, previous)
+	}
+	seen[value] = line
+	return nil
+}
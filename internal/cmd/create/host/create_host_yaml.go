@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package host
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseYaml reads the hosts of the given YAML inventory file. The file should contain a list of hosts, or a map with
+// a 'hosts' field containing that list. Each host is a map that uses the same names than the columns of the CSV file.
+func parseYaml(reader io.Reader) (result []inventoryRow, err error) {
+	var document yaml.Node
+	err = yaml.NewDecoder(reader).Decode(&document)
+	if errors.Is(err, io.EOF) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read YAML file: %w", err)
+		return
+	}
+	if len(document.Content) == 0 {
+		return
+	}
+	list := document.Content[0]
+	if list.Kind == yaml.MappingNode {
+		list = yamlField(list, "hosts")
+		if list == nil {
+			err = fmt.Errorf("YAML file should contain a list of hosts, or a map with a 'hosts' field")
+			return
+		}
+	}
+	if list.Kind != yaml.SequenceNode {
+		err = fmt.Errorf("line %d: expected a list of hosts", list.Line)
+		return
+	}
+	fields := append(slices.Clone(csvColumns), csvMacColumn)
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			err = fmt.Errorf("line %d: expected a host, with fields '%s'", item.Line, strings.Join(fields, "', '"))
+			return
+		}
+		values := map[string]string{}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key := item.Content[i]
+			value := item.Content[i+1]
+			field := normalizeColumn(key.Value)
+			if !slices.Contains(fields, field) {
+				err = fmt.Errorf(
+					"line %d: unknown field '%s', valid fields are '%s'",
+					key.Line, key.Value, strings.Join(fields, "', '"),
+				)
+				return
+			}
+			if value.Kind != yaml.ScalarNode {
+				err = fmt.Errorf("line %d: value of field '%s' should be a string", value.Line, key.Value)
+				return
+			}
+			values[field] = strings.TrimSpace(value.Value)
+		}
+		result = append(result, inventoryRow{
+			line: item.Line,
+			details: &hostDetails{
+				Name:           values[csvNameColumn],
+				BmcAddress:     values[csvBmcAddressColumn],
+				BmcCredentials: values[csvBmcCredentialsColumn],
+				HostClass:      values[csvHostClassColumn],
+				Mac:            values[csvMacColumn],
+			},
+		})
+	}
+	return
+}
+
+// yamlField returns the value of the given field of a YAML map, or nil if there is no such field.
+func yamlField(node *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
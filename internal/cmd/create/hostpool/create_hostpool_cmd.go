@@ -24,6 +24,10 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
 )
@@ -51,16 +55,19 @@ func Cmd() *cobra.Command {
 		[]string{},
 		"Host set in the format 'name=host_class:value,size:value' (e.g., 'workers=host_class:worker-class,size:5').",
 	)
+	manifest.AddFlag(flags, &runner.args.saveManifest)
 	return result
 }
 
 type runnerContext struct {
 	args struct {
-		name     string
-		hostSets []string
+		name         string
+		hostSets     []string
+		saveManifest string
 	}
-	logger *slog.Logger
-	client ffv1.HostPoolsClient
+	logger  *slog.Logger
+	console *terminal.Console
+	client  ffv1.HostPoolsClient
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
@@ -69,8 +76,9 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Get the context:
 	ctx := cmd.Context()
 
-	// Get the logger:
+	// Get the logger and the console:
 	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
 
 	// Get the configuration:
 	cfg, err := config.Load(ctx)
@@ -83,7 +91,9 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	// Check that we have at least one host set:
 	if len(c.args.hostSets) == 0 {
-		return fmt.Errorf("at least one host set is required, use --host-set flag in format 'name=host_class:value,size:value'")
+		return exit.Usagef(
+			"at least one host set is required, use --host-set flag in format 'name=host_class:value,size:value'",
+		)
 	}
 
 	// Create the gRPC connection from the configuration:
@@ -122,9 +132,19 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	// Display the result:
 	createdHostPool := response.Object
-	fmt.Printf("Created host pool '%s'.\n", createdHostPool.Id)
+	c.console.Printf(ctx, "Created host pool '%s'.\n", createdHostPool.GetId())
 
-	return nil
+	// Save the manifest if requested:
+	if c.args.saveManifest != "" {
+		file, err := manifest.Save(c.args.saveManifest, hostPool, createdHostPool.GetId())
+		if err != nil {
+			return err
+		}
+		c.console.Printf(ctx, "Saved manifest to '%s'.\n", file)
+	}
+
+	// Write the created object if the machine readable output format was requested:
+	return output.WriteObjects(ctx, createdHostPool)
 }
 
 // parseHostSets parses the --host-set flags into a map of host set name to HostPoolHostSet
@@ -135,12 +155,12 @@ func (c *runnerContext) parseHostSets() (map[string]*ffv1.HostPoolHostSet, error
 		// Split by '=' to get name and parameters
 		parts := strings.SplitN(hostSetFlag, "=", 2)
 		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid host set format '%s', expected 'name=host_class:value,size:value'", hostSetFlag)
+			return nil, exit.Usagef("invalid host set format '%s', expected 'name=host_class:value,size:value'", hostSetFlag)
 		}
 
 		hostSetName := strings.TrimSpace(parts[0])
 		if hostSetName == "" {
-			return nil, fmt.Errorf("host set name cannot be empty in '%s'", hostSetFlag)
+			return nil, exit.Usagef("host set name cannot be empty in '%s'", hostSetFlag)
 		}
 
 		// Check for duplicate host set names
@@ -166,7 +186,10 @@ func (c *runnerContext) parseHostSetParameters(paramStr, originalFlag string) (*
 	// Split by comma to get individual parameters
 	params := strings.Split(paramStr, ",")
 	if len(params) != 2 {
-		return nil, fmt.Errorf("invalid parameters '%s' in '%s', expected 'host_class:value,size:value'", paramStr, originalFlag)
+		return nil, exit.Usagef(
+			"invalid parameters '%s' in '%s', expected 'host_class:value,size:value'",
+			paramStr, originalFlag,
+		)
 	}
 
 	var hostClass string
@@ -178,7 +201,7 @@ func (c *runnerContext) parseHostSetParameters(paramStr, originalFlag string) (*
 		// Split each parameter by ':' to get key:value
 		kvParts := strings.SplitN(param, ":", 2)
 		if len(kvParts) != 2 {
-			return nil, fmt.Errorf("invalid parameter '%s' in '%s', expected 'key:value' format", param, originalFlag)
+			return nil, exit.Usagef("invalid parameter '%s' in '%s', expected 'key:value' format", param, originalFlag)
 		}
 
 		key := strings.TrimSpace(kvParts[0])
@@ -187,32 +210,32 @@ func (c *runnerContext) parseHostSetParameters(paramStr, originalFlag string) (*
 		switch key {
 		case "host_class":
 			if value == "" {
-				return nil, fmt.Errorf("host_class value cannot be empty in '%s'", originalFlag)
+				return nil, exit.Usagef("host_class value cannot be empty in '%s'", originalFlag)
 			}
 			hostClass = value
 		case "size":
 			if value == "" {
-				return nil, fmt.Errorf("size value cannot be empty in '%s'", originalFlag)
+				return nil, exit.Usagef("size value cannot be empty in '%s'", originalFlag)
 			}
 			sizeInt, err := strconv.ParseInt(value, 10, 32)
 			if err != nil {
-				return nil, fmt.Errorf("invalid size '%s' in '%s', must be a positive integer", value, originalFlag)
+				return nil, exit.Usagef("invalid size '%s' in '%s', must be a positive integer", value, originalFlag)
 			}
 			if sizeInt <= 0 {
-				return nil, fmt.Errorf("size must be positive in '%s', got %d", originalFlag, sizeInt)
+				return nil, exit.Usagef("size must be positive in '%s', got %d", originalFlag, sizeInt)
 			}
 			size = int32(sizeInt)
 		default:
-			return nil, fmt.Errorf("unknown parameter '%s' in '%s', expected 'host_class' or 'size'", key, originalFlag)
+			return nil, exit.Usagef("unknown parameter '%s' in '%s', expected 'host_class' or 'size'", key, originalFlag)
 		}
 	}
 
 	// Verify both required parameters were provided
 	if hostClass == "" {
-		return nil, fmt.Errorf("missing required parameter 'host_class' in '%s'", originalFlag)
+		return nil, exit.Usagef("missing required parameter 'host_class' in '%s'", originalFlag)
 	}
 	if size == 0 {
-		return nil, fmt.Errorf("missing required parameter 'size' in '%s'", originalFlag)
+		return nil, exit.Usagef("missing required parameter 'size' in '%s'", originalFlag)
 	}
 
 	return ffv1.HostPoolHostSet_builder{
@@ -21,6 +21,9 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/output"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 	privatev1 "github.com/osac-project/fulfillment-common/api/private/v1"
 )
@@ -52,14 +55,16 @@ func Cmd() *cobra.Command {
 		"",
 		"Namespace where cluster orders will be created.",
 	)
+	manifest.AddFlag(flags, &runner.saveManifest)
 	return result
 }
 
 type runnerContext struct {
-	console    *terminal.Console
-	id         string
-	kubeconfig string
-	namespace  string
+	console      *terminal.Console
+	id           string
+	kubeconfig   string
+	namespace    string
+	saveManifest string
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
@@ -80,16 +85,16 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	// Check the parameters:
 	if c.id == "" {
-		return fmt.Errorf("identifier is required")
+		return exit.Usagef("identifier is required")
 	}
 	if c.namespace == "" {
-		return fmt.Errorf("namespace name is required")
+		return exit.Usagef("namespace name is required")
 	}
 	if c.kubeconfig == "" {
-		return fmt.Errorf("kubeconfig file is required")
+		return exit.Usagef("kubeconfig file is required")
 	}
 	if c.namespace == "" {
-		return fmt.Errorf("namespace name is required")
+		return exit.Usagef("namespace name is required")
 	}
 
 	// Create the gRPC connection from the configuration:
@@ -123,8 +128,17 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display the result:
-	hub = response.Object
-	c.console.Printf(ctx, "Created hub `%s`.\n", hub.GetId())
+	c.console.Printf(ctx, "Created hub `%s`.\n", response.Object.GetId())
+
+	// Save the manifest if requested:
+	if c.saveManifest != "" {
+		file, err := manifest.Save(c.saveManifest, hub, response.Object.GetId())
+		if err != nil {
+			return err
+		}
+		c.console.Printf(ctx, "Saved manifest to '%s'.\n", file)
+	}
 
-	return nil
+	// Write the created object if the machine readable output format was requested:
+	return output.WriteObjects(ctx, response.Object)
 }
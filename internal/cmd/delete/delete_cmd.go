@@ -14,13 +14,19 @@ language governing permissions and limitations under the License.
 package delete
 
 import (
+	"bufio"
 	"context"
 	"embed"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
+	"github.com/mattn/go-isatty"
 	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
@@ -28,9 +34,14 @@ import (
 	grpcstatus "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/osac-project/fulfillment-cli/internal/batch"
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/macros"
+	"github.com/osac-project/fulfillment-cli/internal/output"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
@@ -42,16 +53,54 @@ func Cmd() *cobra.Command {
 	result := &cobra.Command{
 		Use:   "delete OBJECT [OPTION]... [ID|NAME]...",
 		Short: "Delete objects",
-		RunE:  runner.run,
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
 	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.args.filter,
+		"filter",
+		"",
+		"Delete all the objects that match this CEL expression.",
+	)
+	flags.BoolVar(
+		&runner.args.all,
+		"all",
+		false,
+		"Delete all the objects of the given type.",
+	)
+	flags.BoolVarP(
+		&runner.args.yes,
+		"yes",
+		"y",
+		false,
+		"Don't ask for confirmation before deleting multiple objects with '--filter' or '--all'.",
+	)
+	batch.AddFlag(flags, &runner.args.concurrency)
+	batch.AddLimitFlags(flags, &runner.args.maxConsecutiveFailures, &runner.args.maxFailureRate)
+	refs.AddFlags(flags)
 	return result
 }
 
 type runnerContext struct {
+	args struct {
+		filter                 string
+		all                    bool
+		yes                    bool
+		concurrency            int
+		maxConsecutiveFailures int
+		maxFailureRate         int
+	}
 	logger  *slog.Logger
 	console *terminal.Console
 	conn    *grpc.ClientConn
 	helper  *reflection.ObjectHelper
+	macros  *macros.Expander
+	input   *os.File
+	refMode refs.Mode
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
@@ -79,6 +128,12 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("there is no configuration, run the 'login' command")
 	}
 
+	// Create the object that expands the macros defined in the configuration:
+	c.macros, err = cfg.Expander(c.logger)
+	if err != nil {
+		return err
+	}
+
 	// Create the gRPC connection from the configuration:
 	c.conn, err = cfg.Connect(ctx, cmd.Flags())
 	if err != nil {
@@ -102,13 +157,24 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		c.console.Render(ctx, "no_object.txt", map[string]any{
 			"Helper": helper,
 		})
-		return nil
+		return exit.Usage
 	}
 
-	// Check that at least one object identifier or name has been specified:
-	if len(args) < 2 {
+	// Check that at least one object identifier or name has been specified, or else a filter:
+	bulk := c.args.filter != "" || c.args.all
+	if bulk && len(args) > 1 {
+		return exit.Usagef("options '--filter' and '--all' can't be used together with identifiers or names")
+	}
+	if c.args.filter != "" && c.args.all {
+		return exit.Usagef("options '--filter' and '--all' can't be used together")
+	}
+	if !bulk && len(args) < 2 {
 		c.console.Render(ctx, "no_id.txt", map[string]any{})
-		return nil
+		return exit.Usage
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
 	}
 
 	// Get the object helper:
@@ -118,20 +184,25 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 			"Helper": helper,
 			"Object": args[0],
 		})
-		return nil
+		return exit.Usage
+	}
+
+	// Delete in bulk if requested:
+	if bulk {
+		return c.deleteBulk(ctx)
 	}
 
 	// Find all objects matching the provided references using a single list operation:
-	refs := args[1:]
-	matches, err := c.findMatches(ctx, refs)
+	keys := args[1:]
+	matches, err := c.findMatches(ctx, keys)
 	if err != nil {
 		return err
 	}
 
 	// Validate that each reference has exactly one match. If any resolution fails or is ambiguous we stop and show
 	// the error without deleting anything.
-	objects := make([]proto.Message, 0, len(refs))
-	for _, ref := range refs {
+	objects := make([]proto.Message, 0, len(keys))
+	for _, ref := range keys {
 		matches := matches[ref]
 		switch len(matches) {
 		case 0:
@@ -139,56 +210,194 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 				"Object": c.helper.Singular(),
 				"Ref":    ref,
 			})
-			return nil
+			return exit.NotFound
 		case 1:
 			objects = append(objects, matches[0])
 		default:
+			picked := c.pickMatch(ctx, ref, matches)
+			if picked != nil {
+				objects = append(objects, picked)
+				continue
+			}
 			c.console.Render(ctx, "multiple_matches.txt", map[string]any{
 				"Matches": matches,
 				"Object":  c.helper.Singular(),
 				"Ref":     ref,
 				"Total":   len(matches),
 			})
-			return nil
+			return exit.General
+		}
+	}
+
+	// Delete the resolved objects:
+	return c.deleteObjects(ctx, objects)
+}
+
+// pickMatch asks the user to select one of the objects that match an ambiguous reference, when the console is a
+// terminal. It returns nil if that isn't possible or if the user cancels the selection.
+func (c *runnerContext) pickMatch(ctx context.Context, ref string, matches []proto.Message) proto.Message {
+	if !c.console.CanPick() {
+		return nil
+	}
+	index, err := c.console.PickObject(
+		ctx,
+		fmt.Sprintf("Name or identifier '%s' is ambiguous, select the %s to delete", ref, c.helper.Singular()),
+		matches,
+	)
+	if err != nil {
+		if !errors.Is(err, terminal.ErrPickCancelled) {
+			c.logger.ErrorContext(
+				ctx,
+				"Failed to pick object",
+				slog.String("ref", ref),
+				slog.Any("error", err),
+			)
 		}
+		return nil
 	}
+	return matches[index]
+}
 
-	// Delete each resolved object:
-	for _, object := range objects {
-		id := c.helper.GetId(object)
-		err = c.helper.Delete(ctx, id)
+// deleteBulk deletes all the objects that match the filter, or all the objects of the type if no filter has been
+// given. It first shows the objects that will be deleted and asks the user to confirm typing the number of objects.
+func (c *runnerContext) deleteBulk(ctx context.Context) error {
+	// Find the objects, excluding those that are already being deleted:
+	filter := "!has(this.metadata.deletion_timestamp)"
+	if c.args.filter != "" {
+		expanded, err := c.macros.Expand(c.args.filter)
+		if err != nil {
+			return fmt.Errorf("failed to expand macros in filter '%s': %w", c.args.filter, err)
+		}
+		filter = fmt.Sprintf("%s && (%s)", filter, expanded)
+	}
+	response, err := c.helper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find objects of type '%s': %w", c.helper, err)
+	}
+	objects := response.Items
+	if len(objects) == 0 {
+		c.console.Render(ctx, "bulk_no_matches.txt", map[string]any{
+			"Filter": c.args.filter,
+			"Object": c.helper.Plural(),
+		})
+		return nil
+	}
+
+	// Show the objects that will be deleted:
+	c.console.Render(ctx, "bulk_preview.txt", map[string]any{
+		"Count":   len(objects),
+		"Objects": objects,
+		"Object":  c.helper.Plural(),
+		"Total":   response.Total,
+	})
+
+	// Ask for confirmation, unless explicitly disabled:
+	if !c.args.yes {
+		confirmed, err := c.confirm(ctx, len(objects))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return exit.Error(1)
+		}
+	}
+
+	// Delete the objects:
+	err = c.deleteObjects(ctx, objects)
+	if err != nil {
+		return err
+	}
+	if int(response.Total) > len(objects) {
+		c.console.Render(ctx, "bulk_remaining.txt", map[string]any{
+			"Remaining": int(response.Total) - len(objects),
+		})
+	}
+	return nil
+}
+
+// confirm asks the user to type the number of objects that will be deleted, and returns true if the answer matches.
+func (c *runnerContext) confirm(ctx context.Context, count int) (result bool, err error) {
+	input := c.input
+	if input == nil {
+		input = os.Stdin
+	}
+	if !isatty.IsTerminal(input.Fd()) {
+		c.console.Render(ctx, "bulk_no_terminal.txt", nil)
+		return
+	}
+	c.console.Printf(ctx, "To confirm type the number of objects that will be deleted: ")
+	line, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("failed to read confirmation: %w", err)
+		return
+	}
+	err = nil
+	answer := strings.TrimSpace(line)
+	if answer != strconv.Itoa(count) {
+		c.console.Render(ctx, "bulk_not_confirmed.txt", map[string]any{
+			"Answer": answer,
+			"Count":  count,
+		})
+		return
+	}
+	result = true
+	return
+}
+
+// deleteObjects deletes the given objects, using as many workers as requested with the '--concurrency' flag. The
+// failure to delete one object doesn't stop the deletion of the rest, and all the errors are reported at the end.
+func (c *runnerContext) deleteObjects(ctx context.Context, objects []proto.Message) error {
+	runner, err := batch.NewRunner().
+		SetLogger(c.logger).
+		SetConcurrency(c.args.concurrency).
+		SetMaxConsecutiveFailures(c.args.maxConsecutiveFailures).
+		SetMaxFailureRate(c.args.maxFailureRate).
+		Build()
+	if err != nil {
+		return err
+	}
+	var missing atomic.Bool
+	err = runner.Run(ctx, len(objects), func(ctx context.Context, i int) error {
+		id := c.helper.GetId(objects[i])
+		err := c.helper.Delete(ctx, id)
 		if err != nil {
 			status, ok := grpcstatus.FromError(err)
 			if ok && status.Code() == grpccodes.NotFound {
 				c.console.Printf(
 					ctx,
 					"Can't delete %s '%s' because it doesn't exist.\n",
-					args[0], id,
+					c.helper.Singular(), id,
 				)
-				return exit.Error(1)
+				missing.Store(true)
+				return nil
 			}
 			return fmt.Errorf(
 				"failed to delete %s '%s': %w",
-				args[0], id, err,
+				c.helper.Singular(), id, err,
 			)
 		}
-		fmt.Printf("Deleted %s '%s'.\n", args[0], id)
+		c.console.Printf(ctx, "Deleted %s '%s'.\n", c.helper.Singular(), id)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if missing.Load() {
+		return exit.Error(1)
 	}
 
-	return nil
+	// Write the deleted objects if the machine readable output format was requested:
+	return output.WriteObjects(ctx, objects...)
 }
 
 // findMatches finds all objects matching the provided references using a single list operation. It builds a filter that
 // matches all the provided references at once and returns a map where the key is the reference and the value is the
 // list of matching objectx.
-func (c *runnerContext) findMatches(ctx context.Context, refs []string) (result map[string][]proto.Message, err error) {
+func (c *runnerContext) findMatches(ctx context.Context, keys []string) (result map[string][]proto.Message, err error) {
 	// Build a filter that matches all references:
-	quoted := make([]string, len(refs))
-	for i, ref := range refs {
-		quoted[i] = strconv.Quote(ref)
-	}
-	list := strings.Join(quoted, ", ")
-	filter := fmt.Sprintf(`this.id in [%[1]s] || this.metadata.name in [%[1]s]`, list)
+	filter := c.refMode.Filter(keys...)
 
 	// Find all objects matching any of the references:
 	response, err := c.helper.List(ctx, reflection.ListOptions{
@@ -199,17 +408,11 @@ func (c *runnerContext) findMatches(ctx context.Context, refs []string) (result
 		return
 	}
 
-	// Build a map where the key is the reference and the value is the list of matching objects:
+	// Build a map where the key is the reference and the value is the list of matching objects, preferring the object
+	// whose identifier matches exactly:
 	result = map[string][]proto.Message{}
-	for _, object := range response.Items {
-		id := c.helper.GetId(object)
-		name := c.helper.GetName(object)
-		for _, ref := range refs {
-			if id == ref || name == ref {
-				matches := result[ref]
-				result[ref] = append(matches, object)
-			}
-		}
+	for _, ref := range keys {
+		result[ref] = refs.Resolve(c.refMode, ref, response.Items, c.helper.GetId, c.helper.GetName)
 	}
 
 	return
@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package delete
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		helper := checker.Helper()
+		clusters := []*ffv1.Cluster{
+			ffv1.Cluster_builder{Id: "123"}.Build(),
+			ffv1.Cluster_builder{Id: "456"}.Build(),
+		}
+		err = checker.Check(consoletest.Cases{
+			"bulk_no_matches.txt": {
+				map[string]any{
+					"Filter": "this.metadata.name == 'my'",
+					"Object": "clusters",
+				},
+				map[string]any{
+					"Filter": "",
+					"Object": "clusters",
+				},
+			},
+			"bulk_no_terminal.txt": {
+				nil,
+			},
+			"bulk_not_confirmed.txt": {
+				map[string]any{
+					"Answer": "yes",
+					"Count":  2,
+				},
+			},
+			"bulk_preview.txt": {
+				map[string]any{
+					"Count":   2,
+					"Objects": clusters,
+					"Object":  "clusters",
+					"Total":   int32(2),
+				},
+				map[string]any{
+					"Count":   2,
+					"Objects": clusters,
+					"Object":  "clusters",
+					"Total":   int32(10),
+				},
+			},
+			"bulk_remaining.txt": {
+				map[string]any{
+					"Remaining": 8,
+				},
+			},
+			"multiple_matches.txt": {
+				map[string]any{
+					"Matches": clusters,
+					"Object":  "cluster",
+					"Ref":     "my",
+					"Total":   2,
+				},
+			},
+			"no_id.txt": {
+				map[string]any{},
+			},
+			"no_matches.txt": {
+				map[string]any{
+					"Object": "cluster",
+					"Ref":    "my",
+				},
+			},
+			"no_object.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"object_list.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"wrong_object.txt": {
+				map[string]any{
+					"Helper": helper,
+					"Object": "junk",
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
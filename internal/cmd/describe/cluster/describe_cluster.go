@@ -19,29 +19,35 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
 
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/describe"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "cluster [flags] ID",
-		Aliases: []string{"clusters"},
-		Short:   "Describe a cluster",
-		RunE:    runner.run,
+		Use:               "cluster [flags] ID",
+		Aliases:           []string{"clusters"},
+		Short:             "Describe a cluster",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectsOf((*ffv1.Cluster)(nil), 1),
 	}
+	describe.AddFlags(result.Flags(), &runner.lists)
 	return result
 }
 
 type runnerContext struct {
 	logger  *slog.Logger
 	console *terminal.Console
+	lists   describe.ListOptions
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
@@ -55,6 +61,12 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 	id := args[0]
 
+	// Check the options of the lists:
+	err := c.lists.Check()
+	if err != nil {
+		return err
+	}
+
 	// Get the context:
 	ctx := cmd.Context()
 
@@ -88,6 +100,13 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to describe order: %w", err)
 	}
 
+	// Load the prices, if configured. This is optional, so a failure is reported but doesn't prevent displaying the
+	// rest of the details.
+	prices, err := cfg.Prices(ctx, c.logger)
+	if err != nil {
+		c.console.Printf(ctx, "Warning: %v.\n", err)
+	}
+
 	// Display the clusters:
 	writer := tabwriter.NewWriter(c.console, 0, 0, 2, ' ', 0)
 	cluster := response.Object
@@ -103,6 +122,37 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(writer, "ID:\t%s\n", cluster.Id)
 	fmt.Fprintf(writer, "Template:\t%s\n", template)
 	fmt.Fprintf(writer, "State:\t%s\n", state)
+
+	// Display the estimated cost of the hosts of the node sets:
+	if prices != nil {
+		hosts := map[string]int{}
+		for _, nodeSet := range cluster.GetSpec().GetNodeSets() {
+			hosts[nodeSet.GetHostClass()] += int(nodeSet.GetSize())
+		}
+		fmt.Fprintf(writer, "Estimated Cost:\t%s\n", prices.Estimate(hosts))
+	}
+
+	// Display the conditions:
+	conditions := make([]describe.Item, len(cluster.GetStatus().GetConditions()))
+	for i, condition := range cluster.GetStatus().GetConditions() {
+		conditionType := strings.TrimPrefix(condition.GetType().String(), "CLUSTER_CONDITION_TYPE_")
+		conditionStatus := strings.TrimPrefix(condition.GetStatus().String(), "CONDITION_STATUS_")
+		var conditionTime time.Time
+		conditionTimeText := "-"
+		if condition.HasLastTransitionTime() {
+			conditionTime = condition.GetLastTransitionTime().AsTime()
+			conditionTimeText = c.console.Time(conditionTime)
+		}
+		conditions[i] = describe.Item{
+			Name: conditionType,
+			Time: conditionTime,
+			Line: fmt.Sprintf(
+				"%s\t%s\t%s\t%s",
+				conditionType, conditionStatus, conditionTimeText, condition.GetReason(),
+			),
+		}
+	}
+	describe.WriteList(writer, "Conditions", conditions, c.lists)
 	writer.Flush()
 
 	return nil
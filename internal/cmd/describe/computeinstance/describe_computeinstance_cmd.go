@@ -23,6 +23,7 @@ import (
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 	"github.com/osac-project/fulfillment-common/logging"
@@ -32,9 +33,10 @@ import (
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:   "computeinstance [flags] ID",
-		Short: "Describe a compute instance",
-		RunE:  runner.run,
+		Use:               "computeinstance [flags] ID",
+		Short:             "Describe a compute instance",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectsOf((*ffv1.ComputeInstance)(nil), 1),
 	}
 	return result
 }
@@ -113,8 +115,23 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		state = ci.Status.State.String()
 		state = strings.Replace(state, "COMPUTE_INSTANCE_STATE_", "", -1)
 	}
+	cores := "-"
+	if ci.Spec.HasCores() {
+		cores = fmt.Sprintf("%d", ci.Spec.GetCores())
+	}
+	memory := "-"
+	if ci.Spec.HasMemoryGib() {
+		memory = c.console.Size(int64(ci.Spec.GetMemoryGib()), "GiB")
+	}
+	disk := "-"
+	if ci.Spec.HasBootDisk() {
+		disk = c.console.Size(int64(ci.Spec.GetBootDisk().GetSizeGib()), "GiB")
+	}
 	fmt.Fprintf(writer, "ID:\t%s\n", ci.Id)
 	fmt.Fprintf(writer, "Template:\t%s\n", template)
+	fmt.Fprintf(writer, "Cores:\t%s\n", cores)
+	fmt.Fprintf(writer, "Memory:\t%s\n", memory)
+	fmt.Fprintf(writer, "Boot disk:\t%s\n", disk)
 	fmt.Fprintf(writer, "State:\t%s\n", state)
 	writer.Flush()
 
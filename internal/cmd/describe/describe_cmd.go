@@ -20,6 +20,7 @@ import (
 	"github.com/osac-project/fulfillment-cli/internal/cmd/describe/computeinstance"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/describe/host"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/describe/hostpool"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/describe/template"
 )
 
 func Cmd() *cobra.Command {
@@ -28,8 +29,11 @@ func Cmd() *cobra.Command {
 		Short: "Describe a resource",
 	}
 	result.AddCommand(cluster.Cmd())
+	result.AddCommand(template.ClusterCmd())
 	result.AddCommand(computeinstance.Cmd())
+	result.AddCommand(template.ComputeInstanceCmd())
 	result.AddCommand(host.Cmd())
 	result.AddCommand(hostpool.Cmd())
+	result.AddCommand(template.Cmd())
 	return result
 }
@@ -23,6 +23,7 @@ import (
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 	"github.com/osac-project/fulfillment-common/logging"
@@ -31,10 +32,11 @@ import (
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "host [flags] ID",
-		Aliases: []string{"hosts"},
-		Short:   "Describe a host",
-		RunE:    runner.run,
+		Use:               "host [flags] ID",
+		Aliases:           []string{"hosts"},
+		Short:             "Describe a host",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectsOf((*ffv1.Host)(nil), 1),
 	}
 	return result
 }
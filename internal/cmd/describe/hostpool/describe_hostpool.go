@@ -17,14 +17,15 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"sort"
 	"strings"
 	"text/tabwriter"
 
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/describe"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 	"github.com/osac-project/fulfillment-common/logging"
 )
@@ -32,17 +33,20 @@ import (
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "hostpool [flags] ID",
-		Aliases: []string{"hostpools"},
-		Short:   "Describe a host pool",
-		RunE:    runner.run,
+		Use:               "hostpool [flags] ID",
+		Aliases:           []string{"hostpools"},
+		Short:             "Describe a host pool",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectsOf((*ffv1.HostPool)(nil), 1),
 	}
+	describe.AddFlags(result.Flags(), &runner.lists)
 	return result
 }
 
 type runnerContext struct {
 	logger  *slog.Logger
 	console *terminal.Console
+	lists   describe.ListOptions
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
@@ -56,6 +60,12 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 	id := args[0]
 
+	// Check the options of the lists:
+	err := c.lists.Check()
+	if err != nil {
+		return err
+	}
+
 	// Get the context:
 	ctx := cmd.Context()
 
@@ -90,6 +100,13 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to describe host pool: %w", err)
 	}
 
+	// Load the prices, if configured. This is optional, so a failure is reported but doesn't prevent displaying the
+	// rest of the details.
+	prices, err := cfg.Prices(ctx, c.logger)
+	if err != nil {
+		c.console.Printf(ctx, "Warning: %v.\n", err)
+	}
+
 	// Display the host pool:
 	writer := tabwriter.NewWriter(c.console, 0, 0, 2, ' ', 0)
 	hostPool := response.Object
@@ -111,35 +128,37 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(writer, "Host Sets (Spec):\t%d\n", specHostSets)
 	fmt.Fprintf(writer, "Allocated Hosts:\t%d\n", allocatedHosts)
 
-	// Display host sets details if available
-	if hostPool.Spec != nil && len(hostPool.Spec.HostSets) > 0 {
-		fmt.Fprintf(writer, "\nHost Sets:\n")
-
-		// Sort host classes for consistent output
-		hostSets := make([]string, 0, len(hostPool.Spec.HostSets))
-		for hostSet := range hostPool.Spec.HostSets {
-			hostSets = append(hostSets, hostSet)
+	// Display the estimated cost of the requested hosts:
+	if prices != nil {
+		hosts := map[string]int{}
+		for _, hostSet := range hostPool.GetSpec().GetHostSets() {
+			hosts[hostSet.GetHostClass()] += int(hostSet.GetSize())
 		}
-		sort.Strings(hostSets)
+		fmt.Fprintf(writer, "Estimated Cost:\t%s\n", prices.Estimate(hosts))
+	}
 
-		for _, hostSetName := range hostSets {
-			hostSet := hostPool.Spec.HostSets[hostSetName]
-			fmt.Fprintf(writer, "  %s:\t%d %s hosts\n", hostSetName, hostSet.Size, hostSet.HostClass)
+	// Display host sets details if available
+	if hostPool.Spec != nil {
+		hostSets := make([]describe.Item, 0, len(hostPool.Spec.HostSets))
+		for hostSetName, hostSet := range hostPool.Spec.HostSets {
+			hostSets = append(hostSets, describe.Item{
+				Name: hostSetName,
+				Line: fmt.Sprintf("%s:\t%d %s hosts", hostSetName, hostSet.Size, hostSet.HostClass),
+			})
 		}
+		describe.WriteList(writer, "Host Sets", hostSets, c.lists)
 	}
 
 	// Display allocated hosts if available
-	if hostPool.Status != nil && len(hostPool.Status.Hosts) > 0 {
-		fmt.Fprintf(writer, "\nAllocated Hosts:\n")
-
-		// Sort hosts for consistent output
-		hosts := make([]string, len(hostPool.Status.Hosts))
-		copy(hosts, hostPool.Status.Hosts)
-		sort.Strings(hosts)
-
-		for _, host := range hosts {
-			fmt.Fprintf(writer, "  %s\n", host)
+	if hostPool.Status != nil {
+		hosts := make([]describe.Item, len(hostPool.Status.Hosts))
+		for i, host := range hostPool.Status.Hosts {
+			hosts[i] = describe.Item{
+				Name: host,
+				Line: host,
+			}
 		}
+		describe.WriteList(writer, "Allocated Hosts", hosts, c.lists)
 	}
 
 	writer.Flush()
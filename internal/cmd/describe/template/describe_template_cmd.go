@@ -0,0 +1,381 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/describe"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Cmd creates the command to describe a template of any type. The name is looked up in the cluster templates and in
+// the compute instance templates.
+func Cmd() *cobra.Command {
+	return newCmd(
+		"template",
+		[]string{"templates"},
+		"Describe a cluster or compute instance template",
+		[]kind{clusterTemplates, computeInstanceTemplates},
+		func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			clusters, _ := completion.ObjectsOf((*ffv1.ClusterTemplate)(nil), 1)(cmd, args, toComplete)
+			instances, directive := completion.ObjectsOf((*ffv1.ComputeInstanceTemplate)(nil), 1)(
+				cmd, args, toComplete,
+			)
+			return append(clusters, instances...), directive
+		},
+	)
+}
+
+// ClusterCmd creates the command to describe a cluster template.
+func ClusterCmd() *cobra.Command {
+	return newCmd(
+		"clustertemplate",
+		[]string{"clustertemplates"},
+		"Describe a cluster template",
+		[]kind{clusterTemplates},
+		completion.ObjectsOf((*ffv1.ClusterTemplate)(nil), 1),
+	)
+}
+
+// ComputeInstanceCmd creates the command to describe a compute instance template.
+func ComputeInstanceCmd() *cobra.Command {
+	return newCmd(
+		"computeinstancetemplate",
+		[]string{"computeinstancetemplates"},
+		"Describe a compute instance template",
+		[]kind{computeInstanceTemplates},
+		completion.ObjectsOf((*ffv1.ComputeInstanceTemplate)(nil), 1),
+	)
+}
+
+func newCmd(name string, aliases []string, short string, kinds []kind,
+	complete cobra.CompletionFunc) *cobra.Command {
+	runner := &runnerContext{
+		kinds: kinds,
+	}
+	result := &cobra.Command{
+		Use:     name + " [flags] NAME",
+		Aliases: aliases,
+		Short:   short,
+		Long: short + ", including the table of parameters with their types, defaults and descriptions, " +
+			"which are the values that can be given with the '--template-parameter' option when creating " +
+			"objects from the template.",
+		Args:              cobra.MaximumNArgs(1),
+		RunE:              runner.run,
+		ValidArgsFunction: complete,
+	}
+	flags := result.Flags()
+	describe.AddFlags(flags, &runner.lists)
+	refs.AddFlags(flags)
+	return result
+}
+
+// kind contains the information needed to find the templates of one type.
+type kind struct {
+	// name is the human friendly name of the type, like 'cluster template'.
+	name string
+
+	// plural is the name of the type used with the 'get' command, like 'clustertemplates'.
+	plural string
+
+	// list returns the templates that match the given filter, and the total number of matches.
+	list func(ctx context.Context, conn *grpc.ClientConn, filter string) ([]*match, int32, error)
+}
+
+// match contains the details of a template, independent of its type.
+type match struct {
+	kind        string
+	id          string
+	name        string
+	title       string
+	description string
+	parameters  []templateparams.Details
+	nodeSets    []describe.Item
+}
+
+var clusterTemplates = kind{
+	name:   "cluster template",
+	plural: "clustertemplates",
+	list: func(ctx context.Context, conn *grpc.ClientConn, filter string) (result []*match, total int32,
+		err error) {
+		response, err := ffv1.NewClusterTemplatesClient(conn).List(ctx, ffv1.ClusterTemplatesListRequest_builder{
+			Filter: proto.String(filter),
+			Limit:  proto.Int32(10),
+		}.Build())
+		if err != nil {
+			err = fmt.Errorf("failed to list cluster templates: %w", err)
+			return
+		}
+		for _, template := range response.GetItems() {
+			nodeSets := make([]describe.Item, 0, len(template.GetNodeSets()))
+			for nodeSetName, nodeSet := range template.GetNodeSets() {
+				nodeSets = append(nodeSets, describe.Item{
+					Name: nodeSetName,
+					Line: fmt.Sprintf("%s:\t%d %s hosts", nodeSetName, nodeSet.GetSize(), nodeSet.GetHostClass()),
+				})
+			}
+			result = append(result, &match{
+				kind:        "cluster template",
+				id:          template.GetId(),
+				name:        template.GetMetadata().GetName(),
+				title:       template.GetTitle(),
+				description: template.GetDescription(),
+				parameters:  templateparams.DetailsOf(template.GetParameters()),
+				nodeSets:    nodeSets,
+			})
+		}
+		total = response.GetTotal()
+		return
+	},
+}
+
+var computeInstanceTemplates = kind{
+	name:   "compute instance template",
+	plural: "computeinstancetemplates",
+	list: func(ctx context.Context, conn *grpc.ClientConn, filter string) (result []*match, total int32,
+		err error) {
+		client := ffv1.NewComputeInstanceTemplatesClient(conn)
+		response, err := client.List(ctx, ffv1.ComputeInstanceTemplatesListRequest_builder{
+			Filter: proto.String(filter),
+			Limit:  proto.Int32(10),
+		}.Build())
+		if err != nil {
+			err = fmt.Errorf("failed to list compute instance templates: %w", err)
+			return
+		}
+		for _, template := range response.GetItems() {
+			result = append(result, &match{
+				kind:        "compute instance template",
+				id:          template.GetId(),
+				name:        template.GetMetadata().GetName(),
+				title:       template.GetTitle(),
+				description: template.GetDescription(),
+				parameters:  templateparams.DetailsOf(template.GetParameters()),
+			})
+		}
+		total = response.GetTotal()
+		return
+	},
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+	kinds   []kind
+	lists   describe.ListOptions
+	refMode refs.Mode
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Check the flags and the arguments:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_key.txt", map[string]any{
+			"Command": cmd.Name(),
+		})
+		return exit.Usage
+	}
+	key := args[0]
+	err = c.lists.Check()
+	if err != nil {
+		return err
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Find the templates of all the types that match the given key:
+	var (
+		candidates []*match
+		total      int32
+	)
+	for _, kind := range c.kinds {
+		matches, count, err := kind.list(ctx, conn, c.refMode.Filter(key))
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, matches...)
+		total += count
+	}
+	templates := refs.Resolve(
+		c.refMode, key, candidates,
+		func(template *match) string { return template.id },
+		func(template *match) string { return template.name },
+	)
+	var template *match
+	switch len(templates) {
+	case 0:
+		names := make([]string, len(c.kinds))
+		plurals := make([]string, len(c.kinds))
+		for i, kind := range c.kinds {
+			names[i] = kind.name
+			plurals[i] = kind.plural
+		}
+		c.console.Render(ctx, "no_match.txt", map[string]any{
+			"Key":     key,
+			"Kinds":   strings.Join(names, " or "),
+			"Plurals": plurals,
+		})
+		return exit.NotFound
+	case 1:
+		template = templates[0]
+	default:
+		template = c.pickTemplate(ctx, key, templates)
+		if template != nil {
+			break
+		}
+		matches := make([]map[string]any, len(templates))
+		for i, template := range templates {
+			matches[i] = map[string]any{
+				"Id":   template.id,
+				"Kind": template.kind,
+				"Name": template.name,
+			}
+		}
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Command": cmd.Name(),
+			"Key":     key,
+			"Matches": matches,
+			"Total":   total,
+		})
+		return exit.General
+	}
+
+	// Display the template:
+	writer := tabwriter.NewWriter(c.console, 0, 0, 2, ' ', 0)
+	c.writeTemplate(writer, template)
+	writer.Flush()
+
+	return nil
+}
+
+// writeTemplate writes the details of the template, followed by the table of parameters.
+func (c *runnerContext) writeTemplate(writer io.Writer, template *match) {
+	fmt.Fprintf(writer, "ID:\t%s\n", template.id)
+	fmt.Fprintf(writer, "Name:\t%s\n", valueOrDash(template.name))
+	fmt.Fprintf(writer, "Type:\t%s\n", template.kind)
+	fmt.Fprintf(writer, "Title:\t%s\n", valueOrDash(template.title))
+	fmt.Fprintf(writer, "Description:\t%s\n", valueOrDash(firstParagraph(template.description)))
+	describe.WriteList(writer, "Node Sets", template.nodeSets, c.lists)
+	if len(template.parameters) == 0 {
+		fmt.Fprintf(writer, "\nParameters: none\n")
+		return
+	}
+	fmt.Fprintf(writer, "\nParameters:\n")
+	fmt.Fprintf(writer, "  NAME\tTYPE\tREQUIRED\tDEFAULT\tDESCRIPTION\n")
+	for _, parameter := range template.parameters {
+		required := "no"
+		if parameter.Required {
+			required = "yes"
+		}
+		description := parameter.Description
+		if description == "" {
+			description = parameter.Title
+		}
+		fmt.Fprintf(
+			writer,
+			"  %s\t%s\t%s\t%s\t%s\n",
+			parameter.Name, parameter.Type, required, valueOrDash(parameter.Default), valueOrDash(description),
+		)
+	}
+}
+
+// pickTemplate asks the user to select one of the templates that match an ambiguous reference, when the console is a
+// terminal. It returns nil if that isn't possible or if the user cancels the selection.
+func (c *runnerContext) pickTemplate(ctx context.Context, key string, templates []*match) *match {
+	result, _ := refs.Pick(
+		ctx,
+		c.console,
+		fmt.Sprintf("Name or identifier '%s' is ambiguous, select the template", key),
+		[]string{"ID", "NAME", "TYPE", "TITLE"},
+		templates,
+		func(template *match) []string {
+			return []string{
+				template.id,
+				template.name,
+				template.kind,
+				template.title,
+			}
+		},
+	)
+	return result
+}
+
+// firstParagraph returns the first paragraph of the given text, with the lines joined, so that it fits in one line of
+// the output.
+func firstParagraph(text string) string {
+	text = strings.TrimSpace(text)
+	paragraph, _, _ := strings.Cut(text, "\n\n")
+	return strings.Join(strings.Fields(paragraph), " ")
+}
+
+// valueOrDash returns the given value, or a dash if it is empty, so that empty values are visible in the output.
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
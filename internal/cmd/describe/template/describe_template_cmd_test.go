@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"text/tabwriter"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/describe"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
+)
+
+var _ = Describe("Describe template", func() {
+	var runner *runnerContext
+
+	BeforeEach(func() {
+		runner = &runnerContext{
+			lists: describe.ListOptions{
+				Sort:     describe.SortByName,
+				MaxItems: describe.DefaultMaxItems,
+			},
+		}
+	})
+
+	// write returns the text generated for the given template.
+	write := func(template *match) string {
+		buffer := &bytes.Buffer{}
+		writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+		runner.writeTemplate(writer, template)
+		Expect(writer.Flush()).To(Succeed())
+		return buffer.String()
+	}
+
+	It("Writes the table of parameters", func() {
+		text := write(&match{
+			kind:        "cluster template",
+			id:          "123",
+			name:        "small",
+			title:       "Small cluster",
+			description: "Cluster with\nthree nodes.\n\nMore details.",
+			parameters: []templateparams.Details{
+				{
+					Name:     "pull_secret",
+					Title:    "Pull secret",
+					Type:     "string",
+					Required: true,
+				},
+				{
+					Name:        "version",
+					Title:       "Version",
+					Type:        "string",
+					Default:     `"4.17"`,
+					Description: "OpenShift version.",
+				},
+			},
+			nodeSets: []describe.Item{
+				{
+					Name: "compute",
+					Line: "compute:\t3 acme_1tib hosts",
+				},
+			},
+		})
+		Expect(text).To(Equal(
+			"ID:           123\n" +
+				"Name:         small\n" +
+				"Type:         cluster template\n" +
+				"Title:        Small cluster\n" +
+				"Description:  Cluster with three nodes.\n" +
+				"\n" +
+				"Node Sets:\n" +
+				"  compute:  3 acme_1tib hosts\n" +
+				"\n" +
+				"Parameters:\n" +
+				"  NAME         TYPE    REQUIRED  DEFAULT  DESCRIPTION\n" +
+				"  pull_secret  string  yes       -        Pull secret\n" +
+				"  version      string  no        \"4.17\"   OpenShift version.\n",
+		))
+	})
+
+	It("Says when there are no parameters", func() {
+		text := write(&match{
+			kind: "compute instance template",
+			id:   "456",
+		})
+		Expect(text).To(Equal(
+			"ID:           456\n" +
+				"Name:         -\n" +
+				"Type:         compute instance template\n" +
+				"Title:        -\n" +
+				"Description:  -\n" +
+				"\n" +
+				"Parameters: none\n",
+		))
+	})
+})
@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package template
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		matches := []map[string]any{
+			{
+				"Id":   "123",
+				"Kind": "cluster template",
+				"Name": "small",
+			},
+			{
+				"Id":   "456",
+				"Kind": "compute instance template",
+				"Name": "small",
+			},
+		}
+		err = checker.Check(consoletest.Cases{
+			"multiple_matches.txt": {
+				map[string]any{
+					"Command": "template",
+					"Key":     "small",
+					"Matches": matches,
+					"Total":   int32(2),
+				},
+				map[string]any{
+					"Command": "template",
+					"Key":     "small",
+					"Matches": matches,
+					"Total":   int32(20),
+				},
+			},
+			"no_key.txt": {
+				map[string]any{
+					"Command": "clustertemplate",
+				},
+			},
+			"no_match.txt": {
+				map[string]any{
+					"Key":     "small",
+					"Kinds":   "cluster template",
+					"Plurals": []string{"clustertemplates"},
+				},
+				map[string]any{
+					"Key":     "small",
+					"Kinds":   "cluster template or compute instance template",
+					"Plurals": []string{"clustertemplates", "computeinstancetemplates"},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,280 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/diff"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "diff [OPTION]...",
+		Short: "Compare objects defined in a file with the objects in the server",
+		Long: "Compare the objects defined in a file with the objects in the server, finding them by identifier, " +
+			"or by name if the identifier isn't set. Only the fields that are set in the file are compared, the " +
+			"fields that are populated by the server are ignored. The differences are displayed as an unified " +
+			"diff, and the command exits with a non-zero code if there are differences.",
+		Example: "  # Compare a cluster defined in a file with the cluster in the server:\n" +
+			"  fulfillment-cli diff -f cluster.yaml",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.file,
+		"filename",
+		"f",
+		"",
+		"Name of the file containg the objects to compare. This is mandatory. If the value is '-' the objects are "+
+			"read from the standard input.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		file string
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	helper  *reflection.Helper
+}
+
+// objectDiff contains the result of comparing one of the objects of the file. This is what is written when the output
+// format is JSON.
+type objectDiff struct {
+	Type    string         `json:"type"`
+	Id      string         `json:"id,omitempty"`
+	Name    string         `json:"name,omitempty"`
+	Exists  bool           `json:"exists"`
+	Changes []*diff.Change `json:"changes"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) (err error) {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Check the flags:
+	if c.args.file == "" {
+		return exit.Usagef("it is mandatory to specify the input file with the '--filename' or '-f' options")
+	}
+
+	// Read the objects from the file:
+	objects, err := c.readObjects()
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper:
+	c.helper, err = reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Compare the objects:
+	results := make([]*objectDiff, 0, len(objects))
+	different := false
+	for i, object := range objects {
+		var result *objectDiff
+		result, err = c.compare(ctx, i, object)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+		if len(result.Changes) > 0 {
+			different = true
+		}
+	}
+
+	// Display the results:
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, results)
+	} else if !different {
+		c.console.Printf(ctx, "There are no differences.\n")
+	} else {
+		for _, result := range results {
+			if len(result.Changes) == 0 {
+				continue
+			}
+			c.console.RenderDiff(ctx, diff.Unified(c.serverLabel(result), c.fileLabel(result), result.Changes))
+		}
+	}
+	if different {
+		return exit.Error(1)
+	}
+	return nil
+}
+
+// readObjects reads the objects from the file, or from the standard input if the file name is '-'.
+func (c *runnerContext) readObjects() (result []proto.Message, err error) {
+	var reader io.ReadCloser
+	if c.args.file == "-" {
+		reader = os.Stdin
+	} else {
+		reader, err = os.Open(c.args.file)
+		if err != nil {
+			err = fmt.Errorf("failed to open the file '%s': %w", c.args.file, err)
+			return
+		}
+		defer reader.Close()
+	}
+	result, err = manifest.Decode(reader)
+	return
+}
+
+// compare finds the object in the server that corresponds to the given local object, and compares them. Only the
+// fields that are set in the local object are compared.
+func (c *runnerContext) compare(ctx context.Context, index int, local proto.Message) (result *objectDiff, err error) {
+	objectType := string(local.ProtoReflect().Descriptor().FullName())
+	objectHelper := c.helper.Lookup(objectType)
+	if objectHelper == nil {
+		err = fmt.Errorf("input object at index %d is of an unknown type '%s'", index, objectType)
+		return
+	}
+	result = &objectDiff{
+		Type: objectHelper.Singular(),
+		Id:   objectHelper.GetId(local),
+		Name: objectHelper.GetName(local),
+	}
+	live, err := c.find(ctx, objectHelper, result.Id, result.Name)
+	if err != nil {
+		err = fmt.Errorf("failed to find object at index %d: %w", index, err)
+		return
+	}
+	result.Exists = live != nil
+	changes, err := diff.Compare(live, local)
+	if err != nil {
+		err = fmt.Errorf("failed to compare object at index %d: %w", index, err)
+		return
+	}
+	result.Changes = []*diff.Change{}
+	for _, change := range changes {
+		if change.After == nil {
+			continue
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Compared object",
+		slog.String("type", objectType),
+		slog.String("id", result.Id),
+		slog.String("name", result.Name),
+		slog.Bool("exists", result.Exists),
+		slog.Int("changes", len(result.Changes)),
+	)
+	return
+}
+
+// find finds the object in the server using the identifier if it isn't empty, or else the name. It returns nil if the
+// object doesn't exist.
+func (c *runnerContext) find(ctx context.Context, objectHelper *reflection.ObjectHelper, id string,
+	name string) (result proto.Message, err error) {
+	if id != "" {
+		result, err = objectHelper.Get(ctx, id)
+		if grpcstatus.Code(err) == codes.NotFound {
+			result = nil
+			err = nil
+		}
+		return
+	}
+	if name == "" {
+		err = fmt.Errorf("the object has neither identifier nor name")
+		return
+	}
+	list, err := objectHelper.List(ctx, reflection.ListOptions{
+		Filter: fmt.Sprintf("this.metadata.name == %q && !has(this.metadata.deletion_timestamp)", name),
+	})
+	if err != nil {
+		return
+	}
+	switch len(list.Items) {
+	case 0:
+	case 1:
+		result = list.Items[0]
+	default:
+		err = fmt.Errorf(
+			"there are %d %s with name '%s', use the identifier instead",
+			len(list.Items), objectHelper.Plural(), name,
+		)
+	}
+	return
+}
+
+func (c *runnerContext) serverLabel(result *objectDiff) string {
+	if !result.Exists {
+		return fmt.Sprintf("%s (doesn't exist in the server)", c.objectLabel(result))
+	}
+	return fmt.Sprintf("%s (server)", c.objectLabel(result))
+}
+
+func (c *runnerContext) fileLabel(result *objectDiff) string {
+	file := c.args.file
+	if file == "-" {
+		file = "standard input"
+	}
+	return fmt.Sprintf("%s (%s)", c.objectLabel(result), file)
+}
+
+func (c *runnerContext) objectLabel(result *objectDiff) string {
+	if result.Name != "" {
+		return fmt.Sprintf("%s '%s'", result.Type, result.Name)
+	}
+	return fmt.Sprintf("%s '%s'", result.Type, result.Id)
+}
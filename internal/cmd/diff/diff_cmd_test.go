@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package diff
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Diff command", func() {
+	var (
+		ctx    context.Context
+		runner *runnerContext
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		ctx = context.Background()
+
+		logger := slog.New(slog.NewTextHandler(GinkgoWriter, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))
+
+		// Prepare a server that contains one cluster, and two clusters with the same name:
+		live := ffv1.Cluster_builder{
+			Id: "123",
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "small",
+			}.Build(),
+			Status: ffv1.ClusterStatus_builder{
+				State: ffv1.ClusterState_CLUSTER_STATE_READY,
+			}.Build(),
+		}.Build()
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+			GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest,
+			) (response *ffv1.ClustersGetResponse, err error) {
+				if request.GetId() != live.GetId() {
+					err = grpcstatus.Errorf(codes.NotFound, "cluster '%s' doesn't exist", request.GetId())
+					return
+				}
+				response = ffv1.ClustersGetResponse_builder{
+					Object: live,
+				}.Build()
+				return
+			},
+			ListFunc: func(ctx context.Context, request *ffv1.ClustersListRequest,
+			) (response *ffv1.ClustersListResponse, err error) {
+				var items []*ffv1.Cluster
+				switch {
+				case strings.Contains(request.GetFilter(), `"my-cluster"`):
+					items = []*ffv1.Cluster{live}
+				case strings.Contains(request.GetFilter(), `"your-cluster"`):
+					items = []*ffv1.Cluster{live, live}
+				}
+				response = ffv1.ClustersListResponse_builder{
+					Size:  proto.Int32(int32(len(items))),
+					Total: proto.Int32(int32(len(items))),
+					Items: items,
+				}.Build()
+				return
+			},
+		})
+		server.Start()
+
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		helper, err := reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(conn).
+			AddPackage("fulfillment.v1", 0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		runner = &runnerContext{
+			logger: logger,
+			helper: helper,
+		}
+	})
+
+	It("Compares only the fields that are set in the file", func() {
+		local := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "large",
+			}.Build(),
+		}.Build()
+		result, err := runner.compare(ctx, 0, local)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Exists).To(BeTrue())
+		Expect(result.Changes).To(HaveLen(1))
+		Expect(result.Changes[0].String()).To(Equal(`spec.template: "small" -> "large"`))
+	})
+
+	It("Finds the object by identifier", func() {
+		local := ffv1.Cluster_builder{
+			Id: "123",
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "small",
+			}.Build(),
+		}.Build()
+		result, err := runner.compare(ctx, 0, local)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Exists).To(BeTrue())
+		Expect(result.Changes).To(BeEmpty())
+	})
+
+	It("Reports all the fields when the object doesn't exist", func() {
+		local := ffv1.Cluster_builder{
+			Id: "456",
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "small",
+			}.Build(),
+		}.Build()
+		result, err := runner.compare(ctx, 0, local)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Exists).To(BeFalse())
+		Expect(result.Changes).To(HaveLen(2))
+	})
+
+	It("Fails if the name is ambiguous", func() {
+		local := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "your-cluster",
+			}.Build(),
+		}.Build()
+		_, err := runner.compare(ctx, 0, local)
+		Expect(err).To(MatchError(ContainSubstring("there are 2 clusters with name 'your-cluster'")))
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -31,8 +32,12 @@ import (
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v3"
 
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/conflict"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
@@ -54,7 +59,11 @@ func Cmd() *cobra.Command {
 	result := &cobra.Command{
 		Use:   "edit OBJECT ID|NAME",
 		Short: "Edit objects",
-		RunE:  runner.run,
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(1),
 	}
 	flags := result.Flags()
 	flags.StringVarP(
@@ -67,6 +76,13 @@ func Cmd() *cobra.Command {
 			outputFormatJson, outputFormatYaml,
 		),
 	)
+	flags.BoolVar(
+		&runner.force,
+		"force",
+		false,
+		"Edit the object even if it is being deleted.",
+	)
+	refs.AddFlags(flags)
 	return result
 }
 
@@ -74,6 +90,8 @@ type runnerContext struct {
 	logger         *slog.Logger
 	console        *terminal.Console
 	format         string
+	force          bool
+	refMode        refs.Mode
 	conn           *grpc.ClientConn
 	marshalOptions protojson.MarshalOptions
 	helper         *reflection.ObjectHelper
@@ -127,7 +145,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		c.console.Render(ctx, "no_object.txt", map[string]any{
 			"Helper": helper,
 		})
-		return nil
+		return exit.Usage
 	}
 
 	// Get the information about the object type:
@@ -137,21 +155,25 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 			"Helper": helper,
 			"Object": args[0],
 		})
-		return nil
+		return exit.Usage
 	}
 
 	// Check the flags:
 	if c.format != outputFormatJson && c.format != outputFormatYaml {
-		return fmt.Errorf(
+		return exit.Usagef(
 			"unknown output format '%s', should be '%s' or '%s'",
 			c.format, outputFormatJson, outputFormatYaml,
 		)
 	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
 
 	// Check that the object identifier or name has been specified:
 	if len(args) < 2 {
 		c.console.Render(ctx, "no_id.txt", map[string]any{})
-		return nil
+		return exit.Usage
 	}
 	key := args[1]
 
@@ -160,10 +182,189 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if object == nil {
+
+	// Changes to objects that are being deleted are usually mistakes, so require explicit confirmation:
+	deletionTimestamp := c.helper.GetMetadata(object).GetDeletionTimestamp()
+	if deletionTimestamp != nil {
+		c.console.Render(ctx, "deleting.txt", map[string]any{
+			"Object":    c.helper.Singular(),
+			"Id":        c.helper.GetId(object),
+			"Timestamp": c.console.Time(deletionTimestamp.AsTime()),
+			"Force":     c.force,
+		})
+		if !c.force {
+			return exit.Error(1)
+		}
+	}
+
+	// Edit the object and save the result:
+	updated, err := c.editAndUpdate(ctx, object)
+	if err != nil {
+		return err
+	}
+	if updated == nil {
+		c.console.Printf(ctx, "No changes were made to %s '%s'.\n", c.helper.Singular(), c.helper.GetId(object))
 		return nil
 	}
 
+	c.showWatchSuggestion(ctx, updated)
+
+	return nil
+}
+
+// findEditor tries to find the name of the editor command. It will first try with the content of the `EDITOR` and
+// `VISUAL` environment variables, and if those are empty it defaults to `vi`.
+func (c *runnerContext) findEditor(ctx context.Context) string {
+	for _, editorEnvVar := range editorEnvVars {
+		value, ok := os.LookupEnv(editorEnvVar)
+		if ok && value != "" {
+			c.logger.DebugContext(
+				ctx,
+				"Found editor using environment variable",
+				slog.String("var", editorEnvVar),
+				slog.String("value", value),
+			)
+			return value
+		}
+	}
+	c.logger.InfoContext(
+		ctx,
+		"Didn't find a editor in the environment, will use the default",
+		slog.Any("vars", editorEnvVars),
+		slog.String("default", defaultEditor),
+	)
+	return defaultEditor
+}
+
+// findObject tries to find an object by identifier or name. It uses the list method with a filter that matches
+// either the identifier or the name, preferring the object whose identifier matches exactly. If multiple matches are
+// found and the console is a terminal the user is asked to select one of them. Returns an error if no match is found
+// or if multiple matches are found and none is selected.
+func (c *runnerContext) findObject(ctx context.Context, ref string) (result proto.Message, err error) {
+	// Find the objects matching the reference (identifier or name):
+	filter := c.refMode.Filter(ref)
+	response, err := c.helper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+		Limit:  10,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find object of type '%s' with identifier or name '%s': %w", c.helper, ref, err)
+		return
+	}
+	items := refs.Resolve(c.refMode, ref, response.Items, c.helper.GetId, c.helper.GetName)
+	total := response.Total
+
+	// Prepare the response based on the number of objects found:
+	switch len(items) {
+	case 0:
+		c.console.Render(ctx, "no_matches.txt", map[string]any{
+			"Object": c.helper.Singular(),
+			"Ref":    ref,
+		})
+		err = exit.NotFound
+		return
+	case 1:
+		result = items[0]
+		return
+	default:
+		if c.console.CanPick() {
+			var index int
+			index, err = c.console.PickObject(
+				ctx,
+				fmt.Sprintf("Name or identifier '%s' is ambiguous, select the %s to edit", ref, c.helper.Singular()),
+				items,
+			)
+			if err == nil {
+				result = items[index]
+				return
+			}
+			if !errors.Is(err, terminal.ErrPickCancelled) {
+				c.logger.ErrorContext(
+					ctx,
+					"Failed to pick object",
+					slog.String("ref", ref),
+					slog.Any("error", err),
+				)
+			}
+		}
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Matches": items,
+			"Object":  c.helper.Singular(),
+			"Ref":     ref,
+			"Total":   total,
+		})
+		err = exit.General
+		return
+	}
+}
+
+// editAndUpdate opens the editor with the given object and then saves the result. If saving fails because the object
+// was modified by someone else in the meantime the current version is fetched and the changes made by the user are
+// applied to it. If those changes conflict with the modifications made by others, the editor is opened again so that
+// the user can review them. If there are no changes nothing is saved, and the returned object is nil.
+func (c *runnerContext) editAndUpdate(ctx context.Context, object proto.Message) (result proto.Message, err error) {
+	base := object
+	edited, err := c.edit(ctx, object, nil)
+	if err != nil {
+		return
+	}
+	for attempt := 1; ; attempt++ {
+		result, err = c.update(ctx, base, edited)
+		if err == nil || !conflict.IsConflict(err) || attempt > maxConflictRetries {
+			return
+		}
+		objectId := c.helper.GetId(base)
+		c.logger.InfoContext(
+			ctx,
+			"Object was modified concurrently, will merge the changes and retry",
+			slog.String("type", c.helper.String()),
+			slog.String("id", objectId),
+			slog.Int("attempt", attempt),
+			slog.Any("error", err),
+		)
+
+		// Get the current version and apply the changes made by the user:
+		var current proto.Message
+		current, err = c.helper.Get(ctx, objectId)
+		if err != nil {
+			err = fmt.Errorf("failed to get current version of %s '%s': %w", c.helper, objectId, err)
+			return
+		}
+		var conflicts []conflict.Conflict
+		edited, conflicts, err = conflict.Merge(base, edited, current)
+		if err != nil {
+			return
+		}
+		base = current
+		if len(conflicts) == 0 {
+			c.console.Render(ctx, "conflict_merged.txt", map[string]any{
+				"Object": c.helper.Singular(),
+				"Id":     objectId,
+			})
+			continue
+		}
+
+		// Ask the user to review the conflicting changes:
+		lines := make([]string, len(conflicts))
+		for i, item := range conflicts {
+			lines[i] = item.String()
+		}
+		c.console.Render(ctx, "conflict_reopen.txt", map[string]any{
+			"Object":    c.helper.Singular(),
+			"Id":        objectId,
+			"Conflicts": lines,
+		})
+		edited, err = c.edit(ctx, edited, lines)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// edit writes the object to a temporary file, opens the editor and returns the object parsed from the modified file.
+// The banner lines are added to the beginning of the file as comments when the format supports them.
+func (c *runnerContext) edit(ctx context.Context, object proto.Message, banner []string) (result proto.Message,
+	err error) {
 	// Render the object:
 	var render func(proto.Message) ([]byte, error)
 	switch c.format {
@@ -174,13 +375,22 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 	data, err := render(object)
 	if err != nil {
-		return err
+		return
+	}
+	if len(banner) > 0 && c.format == outputFormatYaml {
+		buffer := &bytes.Buffer{}
+		buffer.WriteString(conflictBannerHeader)
+		for _, line := range banner {
+			fmt.Fprintf(buffer, "#   %s\n", line)
+		}
+		buffer.Write(data)
+		data = buffer.Bytes()
 	}
 
 	// Write the rendered object to a temporary file:
 	tmpDir, err := os.MkdirTemp("", "")
 	if err != nil {
-		return err
+		return
 	}
 	defer func() {
 		err := os.RemoveAll(tmpDir)
@@ -197,14 +407,16 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	tmpFile := filepath.Join(tmpDir, fmt.Sprintf("%s-%s.%s", c.helper, objectId, c.format))
 	err = os.WriteFile(tmpFile, data, 0600)
 	if err != nil {
-		return fmt.Errorf("failed to create temporary file '%s': %w", tmpFile, err)
+		err = fmt.Errorf("failed to create temporary file '%s': %w", tmpFile, err)
+		return
 	}
 
 	// Run the editor:
 	editorName := c.findEditor(ctx)
 	editorPath, err := exec.LookPath(editorName)
 	if err != nil {
-		return fmt.Errorf("failed to find editor command '%s': %w", editorName, err)
+		err = fmt.Errorf("failed to find editor command '%s': %w", editorName, err)
+		return
 	}
 	editorCmd := &exec.Cmd{
 		Path: editorPath,
@@ -218,13 +430,15 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 	err = editorCmd.Run()
 	if err != nil {
-		return fmt.Errorf("failed to edit: %w", err)
+		err = fmt.Errorf("failed to edit: %w", err)
+		return
 	}
 
 	// Load the potentiall modified file:
 	data, err = os.ReadFile(tmpFile)
 	if err != nil {
-		return fmt.Errorf("failed to read back temporary file '%s': %w", tmpFile, err)
+		err = fmt.Errorf("failed to read back temporary file '%s': %w", tmpFile, err)
+		return
 	}
 
 	// Parse the result:
@@ -235,86 +449,33 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	default:
 		parse = c.parseYaml
 	}
-	object, err = parse(data)
+	result, err = parse(data)
 	if err != nil {
-		return fmt.Errorf("failed to parse modified object: %w", err)
+		err = fmt.Errorf("failed to parse modified object: %w", err)
 	}
-
-	// Save the result:
-	updated, err := c.update(ctx, object)
-	if err != nil {
-		return err
-	}
-
-	c.showWatchSuggestion(ctx, updated)
-
-	return nil
+	return
 }
 
-// findEditor tries to find the name of the editor command. It will first try with the content of the `EDITOR` and
-// `VISUAL` environment variables, and if those are empty it defaults to `vi`.
-func (c *runnerContext) findEditor(ctx context.Context) string {
-	for _, editorEnvVar := range editorEnvVars {
-		value, ok := os.LookupEnv(editorEnvVar)
-		if ok && value != "" {
-			c.logger.DebugContext(
-				ctx,
-				"Found editor using environment variable",
-				slog.String("var", editorEnvVar),
-				slog.String("value", value),
-			)
-			return value
-		}
+// update saves the edited object, sending only the fields that are different from the base version so that changes
+// made concurrently to other fields aren't overwritten. If no field is different nothing is sent, as an update without
+// a mask would replace the complete object, and the returned object is nil.
+func (c *runnerContext) update(ctx context.Context, base, edited proto.Message) (result proto.Message, err error) {
+	paths := reflection.ChangedPaths(base, edited)
+	if len(paths) == 0 {
+		c.logger.DebugContext(
+			ctx,
+			"Object hasn't changed, will not update it",
+			slog.String("type", c.helper.String()),
+		)
+		return
 	}
-	c.logger.InfoContext(
+	c.logger.DebugContext(
 		ctx,
-		"Didn't find a editor in the environment, will use the default",
-		slog.Any("vars", editorEnvVars),
-		slog.String("default", defaultEditor),
+		"Updating object",
+		slog.String("type", c.helper.String()),
+		slog.Any("paths", paths),
 	)
-	return defaultEditor
-}
-
-// findObject tries to find an object by identifier or name. It uses the list method with a filter that matches
-// either the identifier or the name. Returns an error if no match is found or if multiple matches are found.
-func (c *runnerContext) findObject(ctx context.Context, ref string) (result proto.Message, err error) {
-	// Find the objects matching the reference (identifier or name):
-	filter := fmt.Sprintf(`this.id == %[1]q || this.metadata.name == %[1]q`, ref)
-	response, err := c.helper.List(ctx, reflection.ListOptions{
-		Filter: filter,
-		Limit:  10,
-	})
-	if err != nil {
-		err = fmt.Errorf("failed to find object of type '%s' with identifier or name '%s': %w", c.helper, ref, err)
-		return
-	}
-	items := response.Items
-	total := response.Total
-
-	// Prepare the response based on the number of objects found:
-	switch len(items) {
-	case 0:
-		c.console.Render(ctx, "no_matches.txt", map[string]any{
-			"Object": c.helper.Singular(),
-			"Ref":    ref,
-		})
-		return
-	case 1:
-		result = items[0]
-		return
-	default:
-		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
-			"Matches": items,
-			"Object":  c.helper.Singular(),
-			"Ref":     ref,
-			"Total":   total,
-		})
-		return
-	}
-}
-
-func (c *runnerContext) update(ctx context.Context, object proto.Message) (result proto.Message, err error) {
-	result, err = c.helper.Update(ctx, object)
+	result, err = c.helper.Update(ctx, edited, paths...)
 	return
 }
 
@@ -384,3 +545,14 @@ var editorEnvVars = []string{
 
 // defualtEditor is the editor used when the environment variables don't indicate any other editor.
 const defaultEditor = "vi"
+
+// maxConflictRetries is the maximum number of times that saving the object will be retried when it fails because the
+// object was modified concurrently.
+const maxConflictRetries = 3
+
+// conflictBannerHeader is added to the beginning of the file when the editor is opened again because of conflicting
+// changes. It is followed by the description of the conflicts.
+const conflictBannerHeader = `# The object was modified by someone else while you were editing it. Your changes have been
+# applied to the current version, but some of them conflict with the other modifications.
+# Review the following fields and save the file to try again:
+`
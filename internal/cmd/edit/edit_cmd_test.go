@@ -17,13 +17,18 @@ import (
 	"bytes"
 	"context"
 	"log/slog"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
 	. "github.com/onsi/gomega"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
@@ -104,4 +109,100 @@ var _ = Describe("Edit command", func() {
 			Expect(outputStr).To(ContainSubstring("watch for changes"))
 		})
 	})
+
+	Describe("editAndUpdate", func() {
+		It("Doesn't send the update if the object hasn't changed", func() {
+			// Use an editor that doesn't modify the file:
+			DeferCleanup(os.Setenv, "EDITOR", os.Getenv("EDITOR"))
+			os.Setenv("EDITOR", "true")
+
+			// Note that the server doesn't implement the clusters service, so sending the update would fail.
+			runner := &runnerContext{
+				logger:  logger,
+				console: console,
+				format:  outputFormatYaml,
+				helper:  helper,
+			}
+			object := ffv1.Cluster_builder{
+				Id: "123",
+			}.Build()
+			result, err := runner.editAndUpdate(ctx, object)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("Applies the changes to the current version when there is a conflict", func() {
+			// Use an editor that changes the template:
+			editor := filepath.Join(GinkgoT().TempDir(), "editor.sh")
+			err := os.WriteFile(editor, []byte("#!/bin/sh\necho 'spec: {template: my-template}' >> \"$1\"\n"), 0700)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.Setenv, "EDITOR", os.Getenv("EDITOR"))
+			os.Setenv("EDITOR", editor)
+
+			// Prepare a server that rejects the first update because the object was modified:
+			original := ffv1.Cluster_builder{
+				Id: "123",
+				Status: ffv1.ClusterStatus_builder{
+					State: ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+				}.Build(),
+			}.Build()
+			current := ffv1.Cluster_builder{
+				Id: "123",
+				Status: ffv1.ClusterStatus_builder{
+					State: ffv1.ClusterState_CLUSTER_STATE_READY,
+				}.Build(),
+			}.Build()
+			var updates []*ffv1.Cluster
+			conflictServer := testing.NewServer()
+			DeferCleanup(conflictServer.Stop)
+			ffv1.RegisterClustersServer(conflictServer.Registrar(), &testing.ClustersServerFuncs{
+				GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest) (*ffv1.ClustersGetResponse,
+					error) {
+					return ffv1.ClustersGetResponse_builder{
+						Object: current,
+					}.Build(), nil
+				},
+				UpdateFunc: func(ctx context.Context, request *ffv1.ClustersUpdateRequest) (
+					*ffv1.ClustersUpdateResponse, error) {
+					updates = append(updates, request.GetObject())
+					if len(updates) == 1 {
+						return nil, grpcstatus.Error(codes.Aborted, "object was modified")
+					}
+					return ffv1.ClustersUpdateResponse_builder{
+						Object: request.GetObject(),
+					}.Build(), nil
+				},
+			})
+			conflictServer.Start()
+			conflictConn, err := grpc.NewClient(
+				conflictServer.Address(),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(conflictConn.Close)
+			reflectionHelper, err := reflection.NewHelper().
+				SetLogger(logger).
+				SetConnection(conflictConn).
+				AddPackage("fulfillment.v1", 0).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			runner := &runnerContext{
+				logger:  logger,
+				console: console,
+				format:  outputFormatYaml,
+				helper:  reflectionHelper.Lookup("cluster"),
+			}
+			result, err := runner.editAndUpdate(ctx, original)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updates).To(HaveLen(2))
+			expected := proto.Clone(current).(*ffv1.Cluster)
+			expected.SetSpec(ffv1.ClusterSpec_builder{
+				Template: "my-template",
+			}.Build())
+			Expect(proto.Equal(updates[1], expected)).To(BeTrue())
+			Expect(proto.Equal(result, expected)).To(BeTrue())
+			Expect(output.String()).To(ContainSubstring("was modified by someone else"))
+		})
+	})
 })
@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package edit
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		helper := checker.Helper()
+		matches := []*ffv1.Cluster{
+			ffv1.Cluster_builder{Id: "123"}.Build(),
+			ffv1.Cluster_builder{Id: "456"}.Build(),
+		}
+		err = checker.Check(consoletest.Cases{
+			"conflict_merged.txt": {
+				map[string]any{
+					"Object": "cluster",
+					"Id":     "123",
+				},
+			},
+			"conflict_reopen.txt": {
+				map[string]any{
+					"Object":    "cluster",
+					"Id":        "123",
+					"Conflicts": []string{"spec.node_sets.workers.size: 3 != 4"},
+				},
+			},
+			"deleting.txt": {
+				map[string]any{
+					"Object":    "cluster",
+					"Id":        "123",
+					"Timestamp": "2025-01-01T00:00:00Z",
+					"Force":     false,
+				},
+				map[string]any{
+					"Object":    "cluster",
+					"Id":        "123",
+					"Timestamp": "2025-01-01T00:00:00Z",
+					"Force":     true,
+				},
+			},
+			"multiple_matches.txt": {
+				map[string]any{
+					"Matches": matches,
+					"Object":  "cluster",
+					"Ref":     "my",
+					"Total":   int32(2),
+				},
+				map[string]any{
+					"Matches": matches,
+					"Object":  "cluster",
+					"Ref":     "my",
+					"Total":   int32(10),
+				},
+			},
+			"no_id.txt": {
+				map[string]any{},
+			},
+			"no_matches.txt": {
+				map[string]any{
+					"Object": "cluster",
+					"Ref":    "my",
+				},
+			},
+			"no_object.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"object_list.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"watch_suggestion.txt": {
+				map[string]any{
+					"Object": "cluster",
+					"Id":     "123",
+				},
+			},
+			"wrong_object.txt": {
+				map[string]any{
+					"Helper": helper,
+					"Object": "junk",
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
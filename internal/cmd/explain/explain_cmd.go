@@ -0,0 +1,186 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package explain
+
+import (
+	"embed"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "explain OBJECT[.FIELD]...",
+		Short: "Describe the fields of objects",
+		Long: "Describe the fields of objects, with their types and documentation, using the protocol buffers " +
+			"descriptors.",
+		Example: "  # Explain the fields of a cluster:\n" +
+			"  fulfillment-cli explain cluster\n\n" +
+			"  # Explain the node sets of the specification of a cluster:\n" +
+			"  fulfillment-cli explain cluster.spec.node_sets\n\n" +
+			"  # Explain all the fields of the specification of a cluster, recursively:\n" +
+			"  fulfillment-cli explain cluster.spec --recursive",
+		Args: cobra.MaximumNArgs(1),
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.args.recursive,
+		"recursive",
+		false,
+		"Show the nested fields of all the levels, not only the first one.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		recursive bool
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+// row contains the data needed to render one field in the list of fields.
+type row struct {
+	Indent      string
+	Name        string
+	Type        string
+	Cardinality string
+	Description []string
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration. Note that the descriptors are compiled into the binary, but
+	// the reflection helper still needs the connection, even if no method is called.
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Check that the object type has been specified:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Helper": helper,
+		})
+		return exit.Usage
+	}
+
+	// Find the object type, trying the longest prefixes first so that fully qualified names also work:
+	segments := strings.Split(args[0], ".")
+	var objectHelper *reflection.ObjectHelper
+	var path string
+	for i := len(segments); i > 0; i-- {
+		objectHelper = helper.Lookup(strings.Join(segments[:i], "."))
+		if objectHelper != nil {
+			path = strings.Join(segments[i:], ".")
+			break
+		}
+	}
+	if objectHelper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Helper": helper,
+			"Object": segments[0],
+		})
+		return exit.Usage
+	}
+
+	// Get the documentation of the field:
+	depth := 1
+	if c.args.recursive {
+		depth = -1
+	}
+	field, err := objectHelper.Explain(path, depth)
+	if err != nil {
+		return err
+	}
+
+	// Render the result:
+	rows := []row{}
+	c.flatten(field.Fields, "", &rows)
+	c.console.Render(ctx, "explain.txt", map[string]any{
+		"Kind":        objectHelper.Descriptor().Name(),
+		"Path":        path,
+		"Field":       field,
+		"Description": strings.Split(field.Description, "\n"),
+		"Rows":        rows,
+	})
+	return nil
+}
+
+// flatten converts the tree of fields into a list of rows, adding indentation for the nested fields.
+func (c *runnerContext) flatten(fields []*reflection.Field, indent string, rows *[]row) {
+	for _, field := range fields {
+		var description []string
+		if field.Description != "" {
+			description = strings.Split(field.Description, "\n")
+		}
+		*rows = append(*rows, row{
+			Indent:      indent,
+			Name:        field.Name,
+			Type:        field.Type,
+			Cardinality: field.Cardinality,
+			Description: description,
+		})
+		c.flatten(field.Fields, indent+"  ", rows)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package explain
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		helper := checker.Helper()
+		explain := func(object, path string, depth int) map[string]any {
+			objectHelper := helper.Lookup(object)
+			Expect(objectHelper).ToNot(BeNil())
+			field, err := objectHelper.Explain(path, depth)
+			Expect(err).ToNot(HaveOccurred())
+			rows := []row{}
+			runner := &runnerContext{}
+			runner.flatten(field.Fields, "", &rows)
+			return map[string]any{
+				"Kind":        objectHelper.Descriptor().Name(),
+				"Path":        path,
+				"Field":       field,
+				"Description": strings.Split(field.Description, "\n"),
+				"Rows":        rows,
+			}
+		}
+		err = checker.Check(consoletest.Cases{
+			"explain.txt": {
+				explain("cluster", "", 1),
+				explain("cluster", "spec", -1),
+				explain("cluster", "status.state", 1),
+			},
+			"no_object.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"object_list.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"wrong_object.txt": {
+				map[string]any{
+					"Helper": helper,
+					"Object": "junk",
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,174 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package explainerror
+
+import (
+	"embed"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/failure"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "explain-error [CODE|last]",
+		Short: "Explain exit codes and errors",
+		Long: "Explain the meaning of an exit code, with the most likely causes and suggestions to fix them. When the " +
+			"argument is 'last' it explains the last error reported by the CLI. Without arguments it lists all the " +
+			"exit codes.",
+		Example: "  # List all the exit codes:\n" +
+			"  fulfillment-cli explain-error\n\n" +
+			"  # Explain exit code 3:\n" +
+			"  fulfillment-cli explain-error 3\n\n" +
+			"  # Explain the last error:\n" +
+			"  fulfillment-cli explain-error last",
+		Args: cobra.MaximumNArgs(1),
+		Annotations: map[string]string{
+			failure.SkipRecordAnnotation: "true",
+		},
+		RunE: runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+// lastResult is the result that is written when explaining the last error and the output format is JSON.
+type lastResult struct {
+	Error       *failure.Record      `json:"error"`
+	Explanation *failure.Explanation `json:"explanation"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Without arguments list all the exit codes:
+	if len(args) == 0 {
+		explanations := failure.Explanations()
+		if output.IsJson(ctx) {
+			c.console.RenderJson(ctx, explanations)
+			return nil
+		}
+		c.console.Render(ctx, "explanation_list.txt", map[string]any{
+			"Explanations": explanations,
+		})
+		return nil
+	}
+
+	// Explain the last error:
+	if args[0] == "last" {
+		return c.explainLast(cmd)
+	}
+
+	// Explain the given exit code:
+	code, err := strconv.Atoi(args[0])
+	if err != nil {
+		return exit.Usagef("argument '%s' isn't an exit code, it should be a number or 'last'", args[0])
+	}
+	explanation := failure.Explain(code)
+	if explanation == nil {
+		return fmt.Errorf("exit code %d isn't used by the CLI", code)
+	}
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, explanation)
+		return nil
+	}
+	c.console.Render(ctx, "explanation.txt", map[string]any{
+		"Explanation": explanation,
+	})
+	return nil
+}
+
+func (c *runnerContext) explainLast(cmd *cobra.Command) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Load the record of the last error:
+	settings, err := config.LoadFile()
+	if err != nil {
+		return err
+	}
+	stateDir, err := settings.StateDirectory()
+	if err != nil {
+		return fmt.Errorf("failed to get state directory: %w", err)
+	}
+	record, err := failure.Load(filepath.Join(stateDir, failure.RecordFileName))
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		if output.IsJson(ctx) {
+			c.console.RenderJson(ctx, &lastResult{})
+			return nil
+		}
+		c.console.Printf(ctx, "No error has been recorded.\n")
+		return nil
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Loaded last error",
+		slog.Any("record", record),
+	)
+
+	// Find the explanation for the exit code, using the generic one for codes that the CLI doesn't know:
+	explanation := failure.Explain(record.ExitCode)
+	if explanation == nil {
+		explanation = &failure.Explanation{
+			Code:    record.ExitCode,
+			Summary: "This exit code isn't used by this version of the CLI.",
+		}
+	}
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, &lastResult{
+			Error:       record,
+			Explanation: explanation,
+		})
+		return nil
+	}
+	c.console.Render(ctx, "explanation.txt", map[string]any{
+		"Record":      record,
+		"Explanation": explanation,
+	})
+	return nil
+}
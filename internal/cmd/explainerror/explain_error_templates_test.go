@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package explainerror
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+	"github.com/osac-project/fulfillment-cli/internal/failure"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		err = checker.Check(consoletest.Cases{
+			"explanation.txt": {
+				map[string]any{
+					"Explanation": failure.Explain(1),
+				},
+				map[string]any{
+					"Record": &failure.Record{
+						Time:     time.Now(),
+						Command:  "get clusters",
+						Message:  "token has expired",
+						Status:   "UNAUTHENTICATED",
+						ExitCode: 1,
+					},
+					"Explanation": failure.Explain(1),
+				},
+				map[string]any{
+					"Record": &failure.Record{
+						Time:     time.Now(),
+						Command:  "get clusters",
+						Message:  "something failed",
+						ExitCode: 99,
+					},
+					"Explanation": &failure.Explanation{
+						Code:    99,
+						Summary: "This exit code isn't used by this version of the CLI.",
+					},
+				},
+			},
+			"explanation_list.txt": {
+				map[string]any{
+					"Explanations": failure.Explanations(),
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,204 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package add
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Cmd creates and returns the command that adds objects to the favorites.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:               "add OBJECT ID|NAME...",
+		Short:             "Add objects to the favorites",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
+	}
+	refs.AddFlags(result.Flags())
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	helper  *reflection.ObjectHelper
+	refMode refs.Mode
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and the console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+	c.console.SetHelper(helper)
+
+	// Check that the object type has been specified:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Helper": helper,
+		})
+		return exit.Usage
+	}
+
+	// Get the information about the object type:
+	c.helper = helper.Lookup(args[0])
+	if c.helper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Helper": helper,
+			"Object": args[0],
+		})
+		return exit.Usage
+	}
+
+	// Check that the object identifier or name has been specified:
+	if len(args) < 2 {
+		c.console.Render(ctx, "no_id.txt", map[string]any{})
+		return exit.Usage
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Find all the objects before changing the configuration, so that nothing is saved if any of them fails:
+	objects := make([]proto.Message, 0, len(args)-1)
+	for _, ref := range args[1:] {
+		var object proto.Message
+		object, err = c.findObject(ctx, ref)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+
+	// Add the objects to the favorites and save the configuration:
+	added := make([]bool, len(objects))
+	for i, object := range objects {
+		added[i] = cfg.AddFavorite(config.Favorite{
+			Type: string(c.helper.FullName()),
+			Id:   c.helper.GetId(object),
+			Name: c.helper.GetName(object),
+		})
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	for i, object := range objects {
+		if added[i] {
+			c.console.Printf(ctx, "Added %s '%s' to the favorites.\n", c.helper.Singular(), c.helper.GetId(object))
+		} else {
+			c.console.Printf(ctx, "The %s '%s' is already a favorite.\n", c.helper.Singular(), c.helper.GetId(object))
+		}
+	}
+
+	return nil
+}
+
+// findObject tries to find an object by identifier or name. It uses the list method with a filter that matches
+// either the identifier or the name, preferring the object whose identifier matches exactly. If there is no match or
+// if there are multiple matches it explains the problem to the user and returns an error that contains the exit code.
+func (c *runnerContext) findObject(ctx context.Context, ref string) (result proto.Message, err error) {
+	// Find the objects matching the reference (identifier or name):
+	filter := c.refMode.Filter(ref)
+	response, err := c.helper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+		Limit:  10,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find object of type '%s' with identifier or name '%s': %w", c.helper, ref, err)
+		return
+	}
+	items := refs.Resolve(c.refMode, ref, response.Items, c.helper.GetId, c.helper.GetName)
+	total := response.Total
+
+	// Prepare the response based on the number of objects found:
+	switch len(items) {
+	case 0:
+		c.console.Render(ctx, "no_matches.txt", map[string]any{
+			"Object": c.helper.Singular(),
+			"Ref":    ref,
+		})
+		err = exit.NotFound
+		return
+	case 1:
+		result = items[0]
+		return
+	default:
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Matches": items,
+			"Object":  c.helper.Singular(),
+			"Ref":     ref,
+			"Total":   total,
+		})
+		err = exit.General
+		return
+	}
+}
@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package favorite
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/cmd/favorite/add"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/favorite/remove"
+)
+
+func Cmd() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "favorite",
+		Short: "Manage favorite objects",
+	}
+	result.AddCommand(add.Cmd())
+	result.AddCommand(remove.Cmd())
+	return result
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package remove
+
+import (
+	"embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Cmd creates and returns the command that removes objects from the favorites.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "remove OBJECT ID|NAME...",
+		Short: "Remove objects from the favorites",
+		RunE:  runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+	conn    *grpc.ClientConn
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and the console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// The objects are only removed from the configuration, but the reflection helper is still needed to translate
+	// the object type given by the user, and that requires a connection, even if it will not be used.
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Check that the object type has been specified:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Helper": helper,
+		})
+		return exit.Usage
+	}
+
+	// Get the information about the object type:
+	objectHelper := helper.Lookup(args[0])
+	if objectHelper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Helper": helper,
+			"Object": args[0],
+		})
+		return exit.Usage
+	}
+
+	// Check that the object identifier or name has been specified:
+	if len(args) < 2 {
+		c.console.Render(ctx, "no_id.txt", map[string]any{})
+		return exit.Usage
+	}
+
+	// Remove the favorites, and fail without saving anything if any of them doesn't exist:
+	objectType := string(objectHelper.FullName())
+	var removed []config.Favorite
+	for _, ref := range args[1:] {
+		matches := cfg.RemoveFavorites(objectType, ref)
+		if len(matches) == 0 {
+			c.console.Render(ctx, "no_matches.txt", map[string]any{
+				"Object": objectHelper.Singular(),
+				"Ref":    ref,
+			})
+			return exit.NotFound
+		}
+		removed = append(removed, matches...)
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	for _, favorite := range removed {
+		c.console.Printf(
+			ctx,
+			"Removed %s '%s' from the favorites.\n",
+			objectHelper.Singular(), favorite.Id,
+		)
+	}
+
+	return nil
+}
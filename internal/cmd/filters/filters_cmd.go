@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package filters
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "filters [OBJECT]",
+		Short: "Show the syntax of filters and examples for the fields of objects",
+		Long: "Show the syntax of the CEL expressions accepted by the '--filter' option, and examples for the " +
+			"fields of objects generated from the protocol buffers descriptors.",
+		Example: "  # Show a summary of the syntax of filters:\n" +
+			"  fulfillment-cli filters\n\n" +
+			"  # Show examples of filters for clusters:\n" +
+			"  fulfillment-cli filters clusters\n\n" +
+			"  # Show examples also for the fields nested in the specification of clusters:\n" +
+			"  fulfillment-cli filters clusters --depth 3",
+		Args:              cobra.MaximumNArgs(1),
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(0),
+	}
+	flags := result.Flags()
+	flags.IntVar(
+		&runner.args.depth,
+		"depth",
+		2,
+		"Number of levels of nested fields to generate examples for.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		depth int
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Check the flags:
+	if c.args.depth < 1 {
+		return exit.Usagef("depth must be at least one, but it is %d", c.args.depth)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration. Note that the descriptors are compiled into the binary, but
+	// the reflection helper still needs the connection, even if no method is called.
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Without an object type show the summary of the syntax:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Helper": helper,
+		})
+		return nil
+	}
+
+	// Find the object type:
+	objectHelper := helper.Lookup(args[0])
+	if objectHelper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Helper": helper,
+			"Object": args[0],
+		})
+		return exit.Usage
+	}
+
+	// Generate and write the examples:
+	examples := objectHelper.FilterExamples(c.args.depth)
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, examples)
+		return nil
+	}
+	c.console.Render(ctx, "examples.txt", map[string]any{
+		"Object": objectHelper.Plural(),
+		"Table":  c.formatExamples(ctx, examples),
+	})
+	return nil
+}
+
+// formatExamples generates the table of examples.
+func (c *runnerContext) formatExamples(ctx context.Context, examples []reflection.FilterExample) string {
+	buffer := &strings.Builder{}
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "FIELD\tTYPE\tEXAMPLE\n")
+	for _, example := range examples {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", example.Path, example.Type, example.Expression)
+	}
+	err := writer.Flush()
+	if err != nil {
+		c.logger.ErrorContext(
+			ctx,
+			"Failed to write examples",
+			slog.Any("error", err),
+		)
+	}
+	return buffer.String()
+}
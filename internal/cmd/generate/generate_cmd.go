@@ -0,0 +1,364 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package generate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Output formats:
+const (
+	formatYaml = "yaml"
+	formatJson = "json"
+)
+
+// Names of the fields used to find the template of objects:
+const (
+	specFieldName               = protoreflect.Name("spec")
+	templateFieldName           = protoreflect.Name("template")
+	templateParametersFieldName = protoreflect.Name("template_parameters")
+	parametersFieldName         = protoreflect.Name("parameters")
+)
+
+// Cmd creates and returns the command that generates example manifests.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "generate OBJECT [flags]",
+		Short: "Generate an example manifest",
+		Long: "Generate a ready to edit manifest for an object, with placeholder values and comments describing " +
+			"the fields, that can then be created with the 'create --filename' command. Nothing is sent to the " +
+			"server, which is only used to get the parameters of the template when the '--template' option is used.",
+		Example: "  # Generate a manifest for a cluster:\n" +
+			"  fulfillment-cli generate cluster --template my-template > my-cluster.yaml\n\n" +
+			"  # Edit it and create the cluster:\n" +
+			"  fulfillment-cli create --filename my-cluster.yaml",
+		Args:              cobra.MaximumNArgs(1),
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectTypes,
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.name,
+		"name",
+		"n",
+		"",
+		"Name of the object. The default is a placeholder based on the type of object, like 'my-cluster'.",
+	)
+	flags.StringVarP(
+		&runner.args.template,
+		"template",
+		"t",
+		"",
+		"Identifier or name of the template. The manifest will include all its parameters, with the default "+
+			"values or placeholders.",
+	)
+	flags.StringVarP(
+		&runner.args.format,
+		"output",
+		"o",
+		formatYaml,
+		fmt.Sprintf(
+			"Output format, '%s' or '%s'. Note that comments are only included in the '%s' format.",
+			formatYaml, formatJson, formatYaml,
+		),
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		name     string
+		template string
+		format   string
+	}
+	logger         *slog.Logger
+	console        *terminal.Console
+	helper         *reflection.Helper
+	objectHelper   *reflection.ObjectHelper
+	templateHelper *reflection.ObjectHelper
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Check the flags:
+	if c.args.format != formatYaml && c.args.format != formatJson {
+		return exit.Usagef(
+			"unknown output format '%s', should be '%s' or '%s'",
+			c.args.format, formatYaml, formatJson,
+		)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration. Note that the descriptors are compiled into the binary, so
+	// the connection is only used if there is a template to fetch.
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the reflection helper:
+	c.helper, err = reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+	c.console.SetHelper(c.helper)
+
+	// Check that the object type has been specified:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Helper": c.helper,
+		})
+		return exit.Usage
+	}
+	c.objectHelper = c.helper.Lookup(args[0])
+	if c.objectHelper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Helper": c.helper,
+			"Object": args[0],
+		})
+		return exit.Usage
+	}
+
+	// Prepare the object:
+	name := c.args.name
+	if name == "" {
+		name = "my-" + c.objectHelper.Singular()
+	}
+	object := c.objectHelper.Instance()
+	populate(object.ProtoReflect(), map[protoreflect.FullName]bool{})
+	setName(object.ProtoReflect(), name)
+
+	// Add the template and its parameters if requested:
+	comments := map[string]string{
+		"": fmt.Sprintf(
+			"Example %s generated by the 'generate' command. Edit the values and then create it with\n"+
+				"the 'create --filename' command.",
+			c.objectHelper.Singular(),
+		),
+	}
+	if c.args.template != "" {
+		c.templateHelper = c.lookupTemplateHelper(c.objectHelper)
+		if c.templateHelper == nil {
+			return fmt.Errorf("objects of type '%s' don't use templates", c.objectHelper.Singular())
+		}
+		var template proto.Message
+		template, err = c.findTemplate(ctx)
+		if err != nil {
+			return err
+		}
+		err = c.setTemplate(object, template, comments)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Render the manifest:
+	fields, err := c.objectHelper.Explain("", -1)
+	if err != nil {
+		return err
+	}
+	data, err := render(object, fields, comments, c.args.format)
+	if err != nil {
+		return err
+	}
+	_, err = c.console.Write(data)
+	return err
+}
+
+// lookupTemplateHelper returns the helper for the templates of the given object type, or nil if the object doesn't
+// use templates. Objects use templates when their spec has the 'template' and 'template_parameters' fields, and there
+// is a type with the same name and the 'Template' suffix, for example 'ComputeInstanceTemplate'.
+func (c *runnerContext) lookupTemplateHelper(objectHelper *reflection.ObjectHelper) *reflection.ObjectHelper {
+	specField := objectHelper.Descriptor().Fields().ByName(specFieldName)
+	if specField == nil || specField.Message() == nil {
+		return nil
+	}
+	specFields := specField.Message().Fields()
+	if specFields.ByName(templateFieldName) == nil || specFields.ByName(templateParametersFieldName) == nil {
+		return nil
+	}
+	templateType := objectHelper.FullName() + "Template"
+	return c.helper.Lookup(string(templateType))
+}
+
+// findTemplate finds the template by identifier or name. If there is no match or multiple matches it explains the
+// problem to the user and returns an error that contains the exit code.
+func (c *runnerContext) findTemplate(ctx context.Context) (result proto.Message, err error) {
+	ref := c.args.template
+	response, err := c.templateHelper.List(ctx, reflection.ListOptions{
+		Filter: fmt.Sprintf(`this.id == %[1]q || this.metadata.name == %[1]q`, ref),
+		Limit:  10,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find template '%s': %w", ref, err)
+		return
+	}
+	switch len(response.Items) {
+	case 1:
+		result = response.Items[0]
+		return
+	case 0:
+		var examples reflection.ListResult
+		examples, err = c.templateHelper.List(ctx, reflection.ListOptions{
+			Limit: 10,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to list templates: %w", err)
+			return
+		}
+		c.console.Render(ctx, "template_not_found.txt", map[string]any{
+			"Examples":  examples.Items,
+			"Ref":       ref,
+			"Templates": c.templateHelper.Plural(),
+		})
+		err = exit.NotFound
+		return
+	default:
+		c.console.Render(ctx, "template_conflict.txt", map[string]any{
+			"Matches": response.Items,
+			"Object":  c.objectHelper.Singular(),
+			"Ref":     ref,
+			"Total":   response.Total,
+		})
+		err = exit.General
+		return
+	}
+}
+
+// setTemplate sets the template of the object, and a value for each of the template parameters. That value is the
+// default of the parameter, or a placeholder with the right type if there is no default. The descriptions of the
+// parameters are added to the comments.
+func (c *runnerContext) setTemplate(object proto.Message, template proto.Message, comments map[string]string) error {
+	// Get the definitions of the parameters:
+	templateMessage := template.ProtoReflect()
+	parametersField := templateMessage.Descriptor().Fields().ByName(parametersFieldName)
+	var definitions []templateparams.DetailedDefinition
+	if parametersField != nil && parametersField.IsList() {
+		list := templateMessage.Get(parametersField).List()
+		for i := range list.Len() {
+			definition, ok := list.Get(i).Message().Interface().(templateparams.DetailedDefinition)
+			if ok {
+				definitions = append(definitions, definition)
+			}
+		}
+	}
+
+	// Get the types of the parameters, in the format that is used in the rest of the messages:
+	parser, err := templateparams.NewParser().
+		SetLogger(c.logger).
+		AddDefinitions(templateparams.Definitions(definitions)...).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create template parameters parser: %w", err)
+	}
+	types := map[string]string{}
+	for _, valid := range parser.Valid() {
+		types[valid.Name] = valid.Type
+	}
+
+	// Set the template and the parameters:
+	objectMessage := object.ProtoReflect()
+	spec := objectMessage.Mutable(objectMessage.Descriptor().Fields().ByName(specFieldName)).Message()
+	specFields := spec.Descriptor().Fields()
+	spec.Set(specFields.ByName(templateFieldName), protoreflect.ValueOfString(c.templateHelper.GetId(template)))
+	parameters := spec.Mutable(specFields.ByName(templateParametersFieldName)).Map()
+	for _, definition := range definitions {
+		value := definition.GetDefault()
+		if value == nil {
+			value, err = placeholder(definition.GetType())
+			if err != nil {
+				return fmt.Errorf("failed to create value for parameter '%s': %w", definition.GetName(), err)
+			}
+		}
+		parameters.Set(
+			protoreflect.ValueOfString(definition.GetName()).MapKey(),
+			protoreflect.ValueOfMessage(value.ProtoReflect()),
+		)
+		comment := fmt.Sprintf("%s (%s", definition.GetTitle(), types[definition.GetName()])
+		if definition.GetRequired() {
+			comment += ", required"
+		}
+		comment += ")"
+		description := firstParagraph(definition.GetDescription())
+		if description != "" {
+			comment += "\n" + description
+		}
+		comments[fmt.Sprintf("%s.%s.%s", specFieldName, templateParametersFieldName, definition.GetName())] = comment
+	}
+	return nil
+}
+
+// placeholder creates the placeholder value for a template parameter of the given type, which is the zero value of
+// that type.
+func placeholder(typeUrl string) (result *anypb.Any, err error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByURL(typeUrl)
+	if err != nil {
+		return
+	}
+	message := messageType.New().Interface()
+	if value, ok := message.(*structpb.Value); ok {
+		value.Kind = &structpb.Value_StringValue{}
+	}
+	result, err = anypb.New(message)
+	return
+}
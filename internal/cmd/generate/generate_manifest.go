@@ -0,0 +1,281 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+)
+
+// Names of the fields that are populated by the server, and that are therefore removed from the manifest:
+const (
+	idFieldName       = "id"
+	statusFieldName   = "status"
+	metadataFieldName = "metadata"
+	nameFieldName     = "name"
+)
+
+// metadataFields are the fields of the metadata that are kept in the manifest, the rest are populated by the server.
+var metadataFields = []string{
+	"name",
+	"labels",
+	"annotations",
+}
+
+// populate creates empty values for the nested messages and the optional fields of the given message, so that all
+// their fields appear in the manifest with placeholder values. Messages that contain themselves are only populated
+// once, well known types like timestamps aren't populated, and neither are the fields that are part of a 'oneof', as
+// only one of them can be set.
+func populate(message protoreflect.Message, visited map[protoreflect.FullName]bool) {
+	desc := message.Descriptor()
+	visited[desc.FullName()] = true
+	defer delete(visited, desc.FullName())
+	fields := desc.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.IsList() || field.IsMap() {
+			continue
+		}
+		if field.Message() == nil {
+			if field.HasOptionalKeyword() && !message.Has(field) {
+				message.Set(field, field.Default())
+			}
+			continue
+		}
+		if field.ContainingOneof() != nil && !field.ContainingOneof().IsSynthetic() {
+			continue
+		}
+		if field.Message().ParentFile().Package() == "google.protobuf" || visited[field.Message().FullName()] {
+			continue
+		}
+		if field.Name() == statusFieldName {
+			continue
+		}
+		populate(message.Mutable(field).Message(), visited)
+	}
+}
+
+// setName sets the name in the metadata of the given object, if it has metadata.
+func setName(message protoreflect.Message, name string) {
+	metadataField := message.Descriptor().Fields().ByName(metadataFieldName)
+	if metadataField == nil || metadataField.Message() == nil {
+		return
+	}
+	metadata := message.Mutable(metadataField).Message()
+	nameField := metadata.Descriptor().Fields().ByName(nameFieldName)
+	if nameField == nil || nameField.Kind() != protoreflect.StringKind {
+		return
+	}
+	metadata.Set(nameField, protoreflect.ValueOfString(name))
+}
+
+// render converts the object into a manifest in the given format. In the YAML format the fields are preceded by
+// comments that describe their types, using the documentation of the given fields, and the additional comments,
+// indexed by path. The comment with the empty path is added to the beginning of the manifest.
+func render(object proto.Message, fields *reflection.Field, comments map[string]string,
+	format string) (result []byte, err error) {
+	// Convert the object to JSON including all the fields, even if they don't have a value, and then parse it again
+	// so that we can manipulate the fields and add the comments:
+	wrapper, err := anypb.New(object)
+	if err != nil {
+		err = fmt.Errorf("failed to wrap object: %w", err)
+		return
+	}
+	data, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(wrapper)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal object: %w", err)
+		return
+	}
+	var document yaml.Node
+	err = yaml.Unmarshal(data, &document)
+	if err != nil {
+		err = fmt.Errorf("failed to parse object: %w", err)
+		return
+	}
+	root := document.Content[0]
+
+	// Remove the fields that are populated by the server, and the fields that don't have a value:
+	removeKeys(root, func(key string) bool {
+		return key == idFieldName || key == statusFieldName
+	})
+	metadata := lookupKey(root, metadataFieldName)
+	if metadata != nil {
+		removeKeys(metadata, func(key string) bool {
+			return !slices.Contains(metadataFields, key)
+		})
+	}
+	prune(root)
+
+	// Generate the output:
+	switch format {
+	case formatJson:
+		var value any
+		err = root.Decode(&value)
+		if err != nil {
+			err = fmt.Errorf("failed to decode manifest: %w", err)
+			return
+		}
+		result, err = json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			err = fmt.Errorf("failed to encode manifest as JSON: %w", err)
+			return
+		}
+		result = append(result, '\n')
+	default:
+		document.HeadComment = comments[""]
+		annotate(root, "", fields.Fields, comments)
+		buffer := &bytes.Buffer{}
+		encoder := yaml.NewEncoder(buffer)
+		encoder.SetIndent(2)
+		err = encoder.Encode(&document)
+		if err != nil {
+			err = fmt.Errorf("failed to encode manifest as YAML: %w", err)
+			return
+		}
+		err = encoder.Close()
+		if err != nil {
+			err = fmt.Errorf("failed to encode manifest as YAML: %w", err)
+			return
+		}
+		result = buffer.Bytes()
+	}
+	return
+}
+
+// lookupKey returns the value of the given key of a mapping node, or nil if it doesn't exist.
+func lookupKey(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeKeys removes from the given mapping node the keys accepted by the given function.
+func removeKeys(node *yaml.Node, remove func(key string) bool) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	var content []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !remove(node.Content[i].Value) {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+	node.Content = content
+}
+
+// prune removes recursively the null values, and resets the style inherited from the JSON syntax, so that the result
+// uses the block style, and quotes only the strings that need it.
+func prune(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.MappingNode {
+		var content []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value := node.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+				continue
+			}
+			content = append(content, node.Content[i], value)
+		}
+		node.Content = content
+	}
+	for _, child := range node.Content {
+		prune(child)
+	}
+	if (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && len(node.Content) == 0 {
+		node.Style = yaml.FlowStyle
+	}
+}
+
+// annotate adds to the keys of the given mapping node the comments describing the corresponding fields.
+func annotate(node *yaml.Node, path string, fields []*reflection.Field, comments map[string]string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + "." + key.Value
+		}
+		var field *reflection.Field
+		for _, candidate := range fields {
+			if candidate.Name == key.Value {
+				field = candidate
+				break
+			}
+		}
+		var lines []string
+		if field != nil {
+			lines = append(lines, describe(field))
+		}
+		comment, ok := comments[keyPath]
+		if ok {
+			lines = append(lines, comment)
+		}
+		key.HeadComment = strings.Join(lines, "\n")
+		if field == nil {
+			continue
+		}
+		if field.Cardinality == "map" {
+			annotate(value, keyPath, nil, comments)
+		} else {
+			annotate(value, keyPath, field.Fields, comments)
+		}
+	}
+}
+
+// describe returns the comment that describes a field: the type, the valid values if it is an enum, and the first
+// paragraph of the documentation, if available.
+func describe(field *reflection.Field) string {
+	result := field.Type
+	values := slices.DeleteFunc(slices.Clone(field.Values), func(value string) bool {
+		return strings.HasSuffix(value, "_UNSPECIFIED")
+	})
+	if len(values) > 0 {
+		result += ", one of " + strings.Join(values, ", ")
+	}
+	description := firstParagraph(field.Description)
+	if description != "" {
+		result += "\n" + description
+	}
+	return result
+}
+
+// firstParagraph returns the first paragraph of the given text, which is usually enough to understand the meaning
+// of a field, without the details.
+func firstParagraph(text string) string {
+	text = strings.TrimSpace(text)
+	paragraph, _, _ := strings.Cut(text, "\n\n")
+	return paragraph
+}
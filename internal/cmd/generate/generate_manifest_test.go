@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/osac-project/fulfillment-cli/internal/manifest"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+)
+
+var _ = Describe("Manifest", func() {
+	var runner *runnerContext
+
+	BeforeEach(func() {
+		// Create a connection that is never used, as the descriptors are compiled into the binary:
+		conn, err := grpc.NewClient("127.0.0.1:0", grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		// Create the runner with the helpers:
+		runner = &runnerContext{
+			logger: logger,
+		}
+		runner.helper, err = reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(conn).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		runner.objectHelper = runner.helper.Lookup("computeinstance")
+		Expect(runner.objectHelper).ToNot(BeNil())
+		runner.templateHelper = runner.lookupTemplateHelper(runner.objectHelper)
+		Expect(runner.templateHelper).ToNot(BeNil())
+	})
+
+	// generate generates the manifest for a compute instance with the given template, in the given format.
+	generate := func(template proto.Message, format string) []byte {
+		object := runner.objectHelper.Instance()
+		populate(object.ProtoReflect(), map[protoreflect.FullName]bool{})
+		setName(object.ProtoReflect(), "my-instance")
+		comments := map[string]string{
+			"": "My header",
+		}
+		if template != nil {
+			Expect(runner.setTemplate(object, template, comments)).To(Succeed())
+		}
+		fields, err := runner.objectHelper.Explain("", -1)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := render(object, fields, comments, format)
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	// decode decodes the given manifest, checking that it contains exactly one compute instance.
+	decode := func(data []byte) *ffv1.ComputeInstance {
+		objects, err := manifest.Decode(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+		instance, ok := objects[0].(*ffv1.ComputeInstance)
+		Expect(ok).To(BeTrue())
+		return instance
+	}
+
+	It("Generates a YAML manifest that can be created", func() {
+		data := generate(nil, formatYaml)
+		text := string(data)
+		Expect(text).To(HavePrefix("# My header\n"))
+		Expect(text).To(ContainSubstring("# int32\n  cores: 0\n"))
+		Expect(text).ToNot(ContainSubstring("status"))
+		Expect(text).ToNot(ContainSubstring("creation_timestamp"))
+		instance := decode(data)
+		Expect(instance.GetMetadata().GetName()).To(Equal("my-instance"))
+		Expect(instance.GetSpec().HasCores()).To(BeTrue())
+		Expect(instance.GetSpec().HasBootDisk()).To(BeTrue())
+	})
+
+	It("Generates a JSON manifest without comments", func() {
+		data := generate(nil, formatJson)
+		var value map[string]any
+		Expect(json.Unmarshal(data, &value)).To(Succeed())
+		Expect(value).To(HaveKeyWithValue("@type", "type.googleapis.com/fulfillment.v1.ComputeInstance"))
+		Expect(value).ToNot(HaveKey("id"))
+		Expect(value).ToNot(HaveKey("status"))
+		instance := decode(data)
+		Expect(instance.GetMetadata().GetName()).To(Equal("my-instance"))
+	})
+
+	It("Includes the template parameters with defaults or placeholders", func() {
+		defaultDisk, err := anypb.New(wrapperspb.Int32(100))
+		Expect(err).ToNot(HaveOccurred())
+		template := ffv1.ComputeInstanceTemplate_builder{
+			Id: "my-template",
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-template-name",
+			}.Build(),
+			Parameters: []*ffv1.ComputeInstanceTemplateParameterDefinition{
+				ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+					Name:        "disk_size",
+					Title:       "Disk size",
+					Description: "Size of the disk in GiB.\n\nMore details.",
+					Type:        "type.googleapis.com/google.protobuf.Int32Value",
+					Default:     defaultDisk,
+				}.Build(),
+				ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+					Name:     "ssh_user",
+					Title:    "SSH user",
+					Required: true,
+					Type:     "type.googleapis.com/google.protobuf.StringValue",
+				}.Build(),
+			},
+		}.Build()
+		data := generate(template, formatYaml)
+		text := string(data)
+		Expect(text).To(ContainSubstring("# Disk size (int32)\n    # Size of the disk in GiB.\n"))
+		Expect(text).ToNot(ContainSubstring("More details."))
+		Expect(text).To(ContainSubstring("# SSH user (string, required)\n"))
+		instance := decode(data)
+		Expect(instance.GetSpec().GetTemplate()).To(Equal("my-template"))
+		parameters := instance.GetSpec().GetTemplateParameters()
+		Expect(parameters).To(HaveLen(2))
+		disk := &wrapperspb.Int32Value{}
+		Expect(parameters["disk_size"].UnmarshalTo(disk)).To(Succeed())
+		Expect(disk.GetValue()).To(BeEquivalentTo(100))
+		user := &wrapperspb.StringValue{}
+		Expect(parameters["ssh_user"].UnmarshalTo(user)).To(Succeed())
+		Expect(user.GetValue()).To(BeEmpty())
+	})
+})
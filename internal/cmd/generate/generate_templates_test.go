@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package generate
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		helper := checker.Helper()
+		templates := []*ffv1.ClusterTemplate{
+			ffv1.ClusterTemplate_builder{Id: "small"}.Build(),
+			ffv1.ClusterTemplate_builder{Id: "large"}.Build(),
+		}
+		err = checker.Check(consoletest.Cases{
+			"no_object.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"object_list.txt": {
+				map[string]any{
+					"Helper": helper,
+				},
+			},
+			"template_conflict.txt": {
+				map[string]any{
+					"Matches": templates,
+					"Object":  "cluster",
+					"Ref":     "my",
+					"Total":   int32(2),
+				},
+				map[string]any{
+					"Matches": templates,
+					"Object":  "cluster",
+					"Ref":     "my",
+					"Total":   int32(10),
+				},
+			},
+			"template_not_found.txt": {
+				map[string]any{
+					"Examples":  templates,
+					"Ref":       "medium",
+					"Templates": "clustertemplates",
+				},
+				map[string]any{
+					"Examples":  []*ffv1.ClusterTemplate{},
+					"Ref":       "medium",
+					"Templates": "clustertemplates",
+				},
+			},
+			"wrong_object.txt": {
+				map[string]any{
+					"Helper": helper,
+					"Object": "junk",
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
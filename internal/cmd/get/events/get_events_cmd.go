@@ -0,0 +1,423 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// Format of the row of the table used for each event:
+const rowFormat = "%-8s  %-23s  %-16s  %-36s  %s\n"
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "events [OPTION]...",
+		Aliases: []string{"event"},
+		Short:   "Show the events of all the object types",
+		Long: "Show the events sent by the server for all the object types, or only for the types given with the " +
+			"'--type' option. The events service doesn't keep the history, so this shows the events that the " +
+			"server sends when the stream is opened, if any, and then the new events until the server ends the " +
+			"stream or the command is interrupted.\n\n" +
+			"Events don't carry a timestamp, so the one displayed is the creation time of the object for created " +
+			"events, the deletion time for deleted events, and the time when the event was received otherwise.",
+		Example: "  # Show the events of all the object types:\n" +
+			"  fulfillment-cli events\n\n" +
+			"  # Show the events of clusters that happened in the last hour:\n" +
+			"  fulfillment-cli events --since 1h --type cluster",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringSliceVar(
+		&runner.args.types,
+		"type",
+		nil,
+		"Object types to show events for, for example 'cluster'. Can be repeated or contain a comma separated "+
+			"list. By default the events of all the types are shown.",
+	)
+	flags.DurationVar(
+		&runner.args.since,
+		"since",
+		0,
+		"Skip the events older than this duration, for example '1h'.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		types []string
+		since time.Duration
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	helper  *reflection.Helper
+	kinds   map[protoreflect.Name]string
+	now     func() time.Time
+}
+
+// eventResult is the representation of an event in the machine readable output.
+type eventResult struct {
+	Id        string    `json:"id"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Kind      string    `json:"kind"`
+	ObjectId  string    `json:"object_id"`
+	Summary   string    `json:"summary,omitempty"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+	c.now = time.Now
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper, used to give the object types the same names that other commands use:
+	c.helper, err = reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+	c.kinds = c.payloadKinds()
+
+	// Build the filter from the object types:
+	filter, err := c.buildFilter()
+	if err != nil {
+		return err
+	}
+
+	// Watch till the server ends the stream, it fails, or the user interrupts it. When it doesn't end normally the
+	// end of the JSON output is marked, so that tools reading it can detect that it is incomplete.
+	ctx, stop := output.InterruptContext(ctx)
+	defer stop()
+	err = c.watch(ctx, eventsv1.NewEventsClient(c.conn), filter)
+	if err == nil {
+		return nil
+	}
+	interrupted := output.Interrupted(ctx)
+	reason := err
+	if interrupted {
+		reason = output.ErrInterrupted
+	}
+	if output.IsJson(ctx) {
+		markErr := output.MarkTruncated(c.console, rendering.FormatJson, reason)
+		if markErr != nil {
+			c.logger.ErrorContext(
+				ctx,
+				"Failed to mark truncated output",
+				slog.Any("error", markErr),
+			)
+		}
+	}
+	if interrupted {
+		return exit.Interrupted
+	}
+	return err
+}
+
+// payloadKinds returns the names of the object types that can be in the payload of events, indexed by the name of the
+// corresponding field of the event. The name is the one used by the reflection helper when the server supports the
+// type, or the name of the field without underscores otherwise.
+func (c *runnerContext) payloadKinds() map[protoreflect.Name]string {
+	result := map[protoreflect.Name]string{}
+	oneof := (&eventsv1.Event{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+	fields := oneof.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.Message() == nil {
+			continue
+		}
+		kind := strings.ReplaceAll(string(field.Name()), "_", "")
+		if c.helper != nil {
+			objectHelper := c.helper.Lookup(string(field.Message().FullName()))
+			if objectHelper != nil {
+				kind = objectHelper.Singular()
+			}
+		}
+		result[field.Name()] = kind
+	}
+	return result
+}
+
+// buildFilter builds the filter that selects the events of the object types requested by the user. It returns an
+// empty string when all the types are requested.
+func (c *runnerContext) buildFilter() (result string, err error) {
+	if len(c.args.types) == 0 {
+		return
+	}
+	var parts []string
+	for _, value := range c.args.types {
+		value = strings.TrimSpace(value)
+		field, ok := c.lookupKind(value)
+		if !ok {
+			kinds := make([]string, 0, len(c.kinds))
+			for _, kind := range c.kinds {
+				kinds = append(kinds, kind)
+			}
+			slices.Sort(kinds)
+			err = fmt.Errorf(
+				"object type '%s' doesn't have events, valid types are '%s'",
+				value, strings.Join(kinds, "', '"),
+			)
+			return
+		}
+		part := fmt.Sprintf("has(event.%s)", field)
+		if !slices.Contains(parts, part) {
+			parts = append(parts, part)
+		}
+	}
+	result = strings.Join(parts, " || ")
+	return
+}
+
+// lookupKind finds the event field that corresponds to the given object type. The type can be the singular or plural
+// name used by the reflection helper, or the name of the field.
+func (c *runnerContext) lookupKind(value string) (result protoreflect.Name, ok bool) {
+	var objectHelper *reflection.ObjectHelper
+	if c.helper != nil {
+		objectHelper = c.helper.Lookup(value)
+	}
+	for field, kind := range c.kinds {
+		if strings.EqualFold(value, kind) || strings.EqualFold(value, string(field)) {
+			return field, true
+		}
+		if objectHelper != nil && objectHelper.Singular() == kind {
+			return field, true
+		}
+	}
+	return
+}
+
+// watch opens the events stream and displays the events until the server ends it.
+func (c *runnerContext) watch(ctx context.Context, client eventsv1.EventsClient, filter string) error {
+	request := &eventsv1.EventsWatchRequest{}
+	if filter != "" {
+		request.Filter = &filter
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Watching events",
+		slog.String("filter", filter),
+		slog.Duration("since", c.args.since),
+	)
+	stream, err := client.Watch(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to start watching events: %w", err)
+	}
+	if !output.IsJson(ctx) {
+		c.console.Printf(ctx, rowFormat, "EVENT", "TIMESTAMP", "KIND", "ID", "SUMMARY")
+	}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive event: %w", err)
+		}
+		event := response.GetEvent()
+		if event == nil {
+			continue
+		}
+		result := c.describeEvent(event)
+		if c.tooOld(result) {
+			c.logger.DebugContext(
+				ctx,
+				"Skipping old event",
+				slog.String("event_id", result.Id),
+				slog.Time("timestamp", result.Timestamp),
+			)
+			continue
+		}
+		c.display(ctx, result)
+	}
+}
+
+// describeEvent extracts from the given event the details that are displayed.
+func (c *runnerContext) describeEvent(event *eventsv1.Event) *eventResult {
+	result := &eventResult{
+		Id:        event.GetId(),
+		Type:      strings.ToLower(strings.TrimPrefix(event.GetType().String(), "EVENT_TYPE_OBJECT_")),
+		Timestamp: c.now(),
+		Kind:      "-",
+		ObjectId:  "-",
+	}
+	message := event.ProtoReflect()
+	field := message.WhichOneof(message.Descriptor().Oneofs().ByName("payload"))
+	if field == nil || field.Message() == nil {
+		return result
+	}
+	result.Kind = c.kinds[field.Name()]
+	object := message.Get(field).Message()
+	if id := stringField(object, "id"); id != "" {
+		result.ObjectId = id
+	}
+	metadata := messageField(object, "metadata")
+	switch event.GetType() {
+	case eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED:
+		if timestamp, ok := timestampField(metadata, "creation_timestamp"); ok {
+			result.Timestamp = timestamp
+		}
+	case eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED:
+		if timestamp, ok := timestampField(metadata, "deletion_timestamp"); ok {
+			result.Timestamp = timestamp
+		}
+	}
+	result.Summary = summarize(object)
+	return result
+}
+
+// tooOld checks if the given event is older than the duration given with the '--since' option.
+func (c *runnerContext) tooOld(event *eventResult) bool {
+	return c.args.since > 0 && event.Timestamp.Before(c.now().Add(-c.args.since))
+}
+
+// display writes the given event as a row of the table, or as JSON if requested.
+func (c *runnerContext) display(ctx context.Context, event *eventResult) {
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, event)
+		return
+	}
+	summary := event.Summary
+	if summary == "" {
+		summary = "-"
+	}
+	c.console.Printf(
+		ctx, rowFormat,
+		event.Type, c.console.Time(event.Timestamp), event.Kind, event.ObjectId, summary,
+	)
+}
+
+// summarize returns a short text describing the given object, containing its name and state when available.
+func summarize(object protoreflect.Message) string {
+	var parts []string
+	if name := stringField(messageField(object, "metadata"), "name"); name != "" {
+		parts = append(parts, "name="+name)
+	}
+	if state := enumField(messageField(object, "status"), "state"); state != "" {
+		parts = append(parts, "state="+state)
+	}
+	return strings.Join(parts, " ")
+}
+
+// messageField returns the value of the message field with the given name, or nil if there is no such field or it
+// isn't set.
+func messageField(message protoreflect.Message, name protoreflect.Name) protoreflect.Message {
+	if message == nil {
+		return nil
+	}
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.Message() == nil || field.IsList() || field.IsMap() || !message.Has(field) {
+		return nil
+	}
+	return message.Get(field).Message()
+}
+
+// stringField returns the value of the string field with the given name, or an empty string if there is no such field.
+func stringField(message protoreflect.Message, name protoreflect.Name) string {
+	if message == nil {
+		return ""
+	}
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return ""
+	}
+	return message.Get(field).String()
+}
+
+// enumField returns the name of the value of the enum field with the given name, without the prefix that is common
+// to all the values of the type, or an empty string if there is no such field or it has the unspecified value.
+func enumField(message protoreflect.Message, name protoreflect.Name) string {
+	if message == nil {
+		return ""
+	}
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.Enum() == nil || field.IsList() {
+		return ""
+	}
+	number := message.Get(field).Enum()
+	if number == 0 {
+		return ""
+	}
+	value := field.Enum().Values().ByNumber(number)
+	if value == nil {
+		return fmt.Sprintf("%d", number)
+	}
+	text := string(value.Name())
+	index := strings.Index(text, "_STATE_")
+	if index >= 0 {
+		text = text[index+len("_STATE_"):]
+	}
+	return text
+}
+
+// timestampField returns the value of the timestamp field with the given name, and a flag indicating if it is set.
+func timestampField(message protoreflect.Message, name protoreflect.Name) (result time.Time, ok bool) {
+	value := messageField(message, name)
+	if value == nil {
+		return
+	}
+	timestamp, ok := value.Interface().(*timestamppb.Timestamp)
+	if !ok {
+		return
+	}
+	result = timestamp.AsTime()
+	return
+}
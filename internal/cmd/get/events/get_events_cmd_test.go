@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Events command", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		buffer *gbytes.Buffer
+		runner *runnerContext
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		buffer = gbytes.NewBuffer()
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			SetTimeZone(rendering.TimeZoneUTC).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		runner = &runnerContext{
+			logger:  logger,
+			console: console,
+			now: func() time.Time {
+				return now
+			},
+		}
+		runner.kinds = runner.payloadKinds()
+	})
+
+	It("Uses the names of the payload fields as kinds when there is no helper", func() {
+		Expect(runner.kinds).To(HaveKeyWithValue(BeEquivalentTo("cluster"), "cluster"))
+		Expect(runner.kinds).To(HaveKeyWithValue(BeEquivalentTo("cluster_template"), "clustertemplate"))
+	})
+
+	It("Builds an empty filter when no types are requested", func() {
+		filter, err := runner.buildFilter()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter).To(BeEmpty())
+	})
+
+	It("Builds a filter for the requested types", func() {
+		runner.args.types = []string{"cluster", "cluster_template", "Cluster"}
+		filter, err := runner.buildFilter()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter).To(Equal("has(event.cluster) || has(event.cluster_template)"))
+	})
+
+	It("Rejects types that don't have events", func() {
+		runner.args.types = []string{"host"}
+		_, err := runner.buildFilter()
+		Expect(err).To(MatchError(
+			"object type 'host' doesn't have events, valid types are 'cluster', 'clustertemplate'",
+		))
+	})
+
+	It("Uses the creation timestamp for created events", func() {
+		created := now.Add(-2 * time.Hour)
+		result := runner.describeEvent(eventsv1.Event_builder{
+			Id:   "event-1",
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			Cluster: ffv1.Cluster_builder{
+				Id: "cluster-1",
+				Metadata: sharedv1.Metadata_builder{
+					Name:              "my-cluster",
+					CreationTimestamp: timestamppb.New(created),
+				}.Build(),
+				Status: ffv1.ClusterStatus_builder{
+					State: ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+				}.Build(),
+			}.Build(),
+		}.Build())
+		Expect(result.Id).To(Equal("event-1"))
+		Expect(result.Type).To(Equal("created"))
+		Expect(result.Timestamp).To(BeTemporally("==", created))
+		Expect(result.Kind).To(Equal("cluster"))
+		Expect(result.ObjectId).To(Equal("cluster-1"))
+		Expect(result.Summary).To(Equal("name=my-cluster state=PROGRESSING"))
+	})
+
+	It("Uses the current time for updated events", func() {
+		result := runner.describeEvent(eventsv1.Event_builder{
+			Id:   "event-2",
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+			ClusterTemplate: ffv1.ClusterTemplate_builder{
+				Id: "template-1",
+			}.Build(),
+		}.Build())
+		Expect(result.Type).To(Equal("updated"))
+		Expect(result.Timestamp).To(BeTemporally("==", now))
+		Expect(result.Kind).To(Equal("clustertemplate"))
+		Expect(result.ObjectId).To(Equal("template-1"))
+		Expect(result.Summary).To(BeEmpty())
+	})
+
+	It("Displays the events received from the server", func() {
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		eventsv1.RegisterEventsServer(
+			server.Registrar(),
+			testing.NewMockEventsServerBuilder().
+				WithScenario(&testing.EventScenario{
+					Name: "events",
+					Events: []*testing.ScenarioEvent{
+						{
+							ID:   "event-1",
+							Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+							Cluster: &testing.ClusterEventData{
+								ID:    "cluster-1",
+								Name:  "my-cluster",
+								State: ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+							},
+						},
+						{
+							ID:   "event-2",
+							Type: eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+							Cluster: &testing.ClusterEventData{
+								ID:    "cluster-1",
+								Name:  "my-cluster",
+								State: ffv1.ClusterState_CLUSTER_STATE_READY,
+							},
+						},
+					},
+				}).
+				Build(),
+		)
+		server.Start()
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- runner.watch(ctx, eventsv1.NewEventsClient(conn), "")
+		}()
+		Eventually(buffer).Should(gbytes.Say(`EVENT\s+TIMESTAMP\s+KIND\s+ID\s+SUMMARY`))
+		Eventually(buffer).Should(gbytes.Say(
+			`updated\s+2025-06-01 12:00:00 UTC\s+cluster\s+cluster-1\s+name=my-cluster state=READY`,
+		))
+		cancel()
+		Eventually(done).Should(Receive())
+	})
+
+	It("Skips events older than the requested duration", func() {
+		deleted := func(age time.Duration) *eventResult {
+			return runner.describeEvent(eventsv1.Event_builder{
+				Type: eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED,
+				Cluster: ffv1.Cluster_builder{
+					Id: "cluster-1",
+					Metadata: sharedv1.Metadata_builder{
+						DeletionTimestamp: timestamppb.New(now.Add(-age)),
+					}.Build(),
+				}.Build(),
+			}.Build())
+		}
+		Expect(runner.tooOld(deleted(2 * time.Hour))).To(BeFalse())
+		runner.args.since = time.Hour
+		Expect(runner.tooOld(deleted(2 * time.Hour))).To(BeTrue())
+		Expect(runner.tooOld(deleted(30 * time.Minute))).To(BeFalse())
+	})
+})
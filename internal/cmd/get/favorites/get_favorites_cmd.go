@@ -0,0 +1,264 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package favorites
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Values of the state column for favorites that can't be checked:
+const (
+	stateNotFound    = "NOT_FOUND"
+	stateUnknownType = "UNKNOWN_TYPE"
+)
+
+// Cmd creates and returns the command that lists the favorite objects with their current state.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "favorites",
+		Short: "Get favorite objects",
+		Args:  cobra.NoArgs,
+		RunE:  runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	helper  *reflection.Helper
+}
+
+// favoriteResult is the current state of a favorite object, as it is reported to the user.
+type favoriteResult struct {
+	Type  string `json:"type"`
+	Id    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	State string `json:"state"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and the console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Nothing else to do if there are no favorites:
+	if len(cfg.Favorites) == 0 {
+		if output.IsJson(ctx) {
+			c.console.RenderJson(ctx, []favoriteResult{})
+		} else {
+			c.console.Render(ctx, "no_favorites.txt", nil)
+		}
+		return nil
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper:
+	c.helper, err = reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Fetch the objects, with only one request for each type:
+	objects := map[string]map[string]proto.Message{}
+	for _, favorite := range cfg.Favorites {
+		_, ok := objects[favorite.Type]
+		if ok {
+			continue
+		}
+		objects[favorite.Type], err = c.fetchObjects(ctx, favorite.Type, cfg.Favorites)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Calculate the results:
+	results := make([]favoriteResult, len(cfg.Favorites))
+	for i, favorite := range cfg.Favorites {
+		results[i] = c.makeResult(favorite, objects[favorite.Type])
+	}
+
+	// Write the results:
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, results)
+	} else {
+		c.renderResults(ctx, results)
+	}
+	return nil
+}
+
+// fetchObjects fetches the favorite objects of the given type, using one request with a filter that matches all their
+// identifiers. It returns a map where the key is the identifier of the object, or nil if the type isn't known.
+func (c *runnerContext) fetchObjects(ctx context.Context, objectType string,
+	favorites []config.Favorite) (result map[string]proto.Message, err error) {
+	objectHelper := c.helper.Lookup(objectType)
+	if objectHelper == nil {
+		c.logger.WarnContext(
+			ctx,
+			"Ignoring favorites of unknown type",
+			slog.String("type", objectType),
+		)
+		return
+	}
+	var ids []string
+	for _, favorite := range favorites {
+		if favorite.Type == objectType {
+			ids = append(ids, fmt.Sprintf("%q", favorite.Id))
+		}
+	}
+	response, err := objectHelper.List(ctx, reflection.ListOptions{
+		Filter: fmt.Sprintf("this.id in [%s]", strings.Join(ids, ", ")),
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to get favorite %s: %w", objectHelper.Plural(), err)
+		return
+	}
+	result = make(map[string]proto.Message, len(response.Items))
+	for _, item := range response.Items {
+		result[objectHelper.GetId(item)] = item
+	}
+	return
+}
+
+// makeResult calculates the result for a favorite, using the current version of the object if it still exists.
+func (c *runnerContext) makeResult(favorite config.Favorite, objects map[string]proto.Message) favoriteResult {
+	result := favoriteResult{
+		Type:  favorite.Type,
+		Id:    favorite.Id,
+		Name:  favorite.Name,
+		State: stateUnknownType,
+	}
+	objectHelper := c.helper.Lookup(favorite.Type)
+	if objectHelper == nil {
+		return result
+	}
+	object, ok := objects[favorite.Id]
+	if !ok {
+		result.State = stateNotFound
+		return result
+	}
+	result.Name = objectHelper.GetName(object)
+	result.State = objectState(object)
+	return result
+}
+
+// renderResults writes the table of results.
+func (c *runnerContext) renderResults(ctx context.Context, results []favoriteResult) {
+	writer := tabwriter.NewWriter(c.console, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TYPE\tID\tNAME\tSTATE\n")
+	for _, result := range results {
+		objectType := result.Type
+		objectHelper := c.helper.Lookup(objectType)
+		if objectHelper != nil {
+			objectType = objectHelper.Singular()
+		}
+		name := result.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", objectType, result.Id, name, result.State)
+	}
+	err := writer.Flush()
+	if err != nil {
+		c.logger.ErrorContext(
+			ctx,
+			"Failed to write favorites",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// objectState returns the value of the 'status.state' field of the object, without the prefix of the enum type, or
+// '-' if the object doesn't have that field.
+func objectState(object proto.Message) string {
+	message := object.ProtoReflect()
+	statusField := message.Descriptor().Fields().ByName("status")
+	if statusField == nil || statusField.Message() == nil {
+		return "-"
+	}
+	status := message.Get(statusField).Message()
+	stateField := statusField.Message().Fields().ByName("state")
+	if stateField == nil || stateField.Enum() == nil {
+		return "-"
+	}
+	valueDescs := stateField.Enum().Values()
+	valueDesc := valueDescs.ByNumber(status.Get(stateField).Enum())
+	if valueDesc == nil {
+		return "-"
+	}
+	valueText := string(valueDesc.Name())
+
+	// Remove the prefix of the type, calculated from the name of the value with number zero, as the table renderer
+	// does:
+	unspecifiedDesc := valueDescs.ByNumber(protoreflect.EnumNumber(0))
+	if unspecifiedDesc != nil {
+		unspecifiedText := string(unspecifiedDesc.Name())
+		prefixIndex := strings.LastIndex(unspecifiedText, "_")
+		if prefixIndex != -1 && strings.HasPrefix(valueText, unspecifiedText[0:prefixIndex+1]) {
+			valueText = valueText[prefixIndex+1:]
+		}
+	}
+	return valueText
+}
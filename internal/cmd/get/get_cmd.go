@@ -16,61 +16,84 @@ package get
 import (
 	"context"
 	"embed"
-	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/osac-project/fulfillment-cli/internal/cmd/get/events"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/get/favorites"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/get/kubeconfig"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/get/password"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/get/sshkey"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/get/token"
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/macros"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
 	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/selector"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
 //go:embed templates
 var templatesFS embed.FS
 
-// Possible output formats:
-const (
-	outputFormatTable = "table"
-	outputFormatJson  = "json"
-	outputFormatYaml  = "yaml"
+// Descriptors of the host and host pool types, used to check the types that support the '--by-pool' option:
+var (
+	hostDescriptor     = (*ffv1.Host)(nil).ProtoReflect().Descriptor()
+	hostPoolDescriptor = (*ffv1.HostPool)(nil).ProtoReflect().Descriptor()
 )
 
+// outputFormatChanges is an output format that is only supported in watch mode, and displays only the fields that
+// changed in each event. The rest of the output formats are the ones available in the rendering registry.
+const outputFormatChanges = "changes"
+
 func Cmd() *cobra.Command {
-	runner := &runnerContext{
-		marshalOptions: protojson.MarshalOptions{
-			UseProtoNames: true,
-		},
-	}
+	runner := &runnerContext{}
 	result := &cobra.Command{
 		Use:   "get OBJECT [OPTION]... [ID|NAME]...",
 		Short: "Get objects",
-		RunE:  runner.run,
+		Example: "  # List all the clusters:\n" +
+			"  fulfillment-cli get clusters\n\n" +
+			"  # Get a cluster by name, in YAML format:\n" +
+			"  fulfillment-cli get cluster my-cluster -o yaml\n\n" +
+			"  # List the clusters whose name starts with 'prod-':\n" +
+			"  fulfillment-cli get clusters --filter 'this.metadata.name.startsWith(\"prod-\")'\n\n" +
+			"  # List the clusters with label 'env' equal to 'prod':\n" +
+			"  fulfillment-cli get clusters -l env=prod\n\n" +
+			"  # Build a filter for clusters interactively:\n" +
+			"  fulfillment-cli get clusters --build-filter",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
 	}
+	result.AddCommand(events.Cmd())
+	result.AddCommand(favorites.Cmd())
 	result.AddCommand(kubeconfig.Cmd())
 	result.AddCommand(password.Cmd())
+	result.AddCommand(sshkey.Cmd())
 	result.AddCommand(token.Cmd())
 	flags := result.Flags()
 	flags.StringVarP(
 		&runner.args.format,
 		"output",
 		"o",
-		outputFormatTable,
+		rendering.FormatTable,
 		fmt.Sprintf(
-			"Output format, one of '%s', '%s' or '%s'.",
-			outputFormatTable, outputFormatJson, outputFormatYaml,
+			"Output format, one of %s. In watch mode it can also be '%s', to display only the fields that "+
+				"changed in each event.",
+			rendering.QuoteList(rendering.DefaultRegistry.Names()), outputFormatChanges,
 		),
 	)
 	flags.StringVar(
@@ -79,12 +102,75 @@ func Cmd() *cobra.Command {
 		"",
 		"CEL expression used for filtering results.",
 	)
+	flags.BoolVar(
+		&runner.args.buildFilter,
+		"build-filter",
+		false,
+		"Build the filter interactively, selecting the fields, operators and values step by step. The resulting "+
+			"CEL expression is printed, so that it can be used later with '--filter'.",
+	)
+	flags.StringVarP(
+		&runner.args.selector,
+		"selector",
+		"l",
+		"",
+		"Label selector used for filtering results, with the same syntax that Kubernetes uses. For example "+
+			"'env=prod,tier!=frontend' or 'env in (prod,staging)'. It can be combined with '--filter'.",
+	)
 	flags.BoolVar(
 		&runner.args.includeDeleted,
 		"include-deleted",
 		false,
 		"Include deleted objects.",
 	)
+	flags.StringVar(
+		&runner.args.groupBy,
+		"group-by",
+		"",
+		"CEL expression used to partition the results into groups. Only for the table output format.",
+	)
+	flags.BoolVar(
+		&runner.args.byPool,
+		"by-pool",
+		false,
+		"Group hosts by the host pool that they belong to. Only for the table output format.",
+	)
+	flags.BoolVar(
+		&runner.args.noTruncate,
+		"no-truncate",
+		false,
+		"Don't truncate values to fit the width of the terminal. Only for the table output format.",
+	)
+	flags.StringSliceVar(
+		&runner.args.columns,
+		"columns",
+		nil,
+		"Comma separated list of the headers of the columns to display, in the given order, for example "+
+			"'ID,STATE,NAME'. Only for the table output format.",
+	)
+	flags.StringSliceVar(
+		&runner.args.hideColumns,
+		"hide-columns",
+		nil,
+		"Comma separated list of the headers of the columns that will not be displayed. Only for the table "+
+			"output format.",
+	)
+	flags.BoolVar(
+		&runner.args.debugLayouts,
+		"debug-layouts",
+		false,
+		"Write to the standard error the table layout used for each type. This is intended to troubleshoot "+
+			"table layouts. Only for the table output format.",
+	)
+	flags.IntVar(
+		&runner.args.treeDepth,
+		"tree-depth",
+		rendering.DefaultTreeDepth,
+		fmt.Sprintf(
+			"Number of levels of related objects to retrieve. Only for the '%s' output format.",
+			rendering.FormatTree,
+		),
+	)
 	flags.BoolVarP(
 		&runner.args.watch,
 		"watch",
@@ -92,6 +178,55 @@ func Cmd() *cobra.Command {
 		false,
 		"Watch for changes to objects",
 	)
+	flags.BoolVar(
+		&runner.args.watchOnly,
+		"watch-only",
+		false,
+		"In watch mode display only the events, without first displaying the objects that already exist.",
+	)
+	flags.BoolVar(
+		&runner.args.diff,
+		"diff",
+		false,
+		"In watch mode display only the fields that changed in update events, instead of the complete object. "+
+			"Only for the table output format.",
+	)
+	flags.BoolVar(
+		&runner.args.reconnect,
+		"reconnect",
+		true,
+		"In watch mode reconnect automatically when the connection to the server is lost. After reconnecting the "+
+			"objects are listed again, and the changes made while disconnected are displayed as events without "+
+			"identifier.",
+	)
+	flags.StringVar(
+		&runner.args.resumeFrom,
+		"resume-from",
+		"",
+		"In watch mode skip the events that the server sends until the event with this identifier, included. "+
+			"This is only useful with servers that send again past events, starting with this one, when a watch "+
+			"starts. If the first event received is a different one nothing is skipped and a warning is displayed.",
+	)
+	flags.StringVar(
+		&runner.args.exec,
+		"exec",
+		"",
+		"In watch mode run this shell command for each event. The command receives the event in JSON format in "+
+			"the standard input, and the identifier of the event, the type of event, the type of object and the "+
+			"identifier of the object in the 'FULFILLMENT_EVENT_ID', 'FULFILLMENT_EVENT_TYPE', "+
+			"'FULFILLMENT_OBJECT_TYPE' and 'FULFILLMENT_OBJECT_ID' environment variables. Events that the server "+
+			"sends again after reconnecting are skipped, so the command runs only once for each event.",
+	)
+	flags.StringSliceVar(
+		&runner.args.eventTypes,
+		"event-types",
+		nil,
+		fmt.Sprintf(
+			"Comma separated list of the types of events to display in watch mode, from '%s', '%s' and '%s'. "+
+				"By default all the events are displayed.",
+			eventTypeCreated, eventTypeUpdated, eventTypeDeleted,
+		),
+	)
 	return result
 }
 
@@ -99,16 +234,38 @@ type runnerContext struct {
 	args struct {
 		format         string
 		filter         string
+		buildFilter    bool
+		selector       string
 		includeDeleted bool
+		groupBy        string
+		byPool         bool
+		noTruncate     bool
+		columns        []string
+		hideColumns    []string
+		debugLayouts   bool
+		treeDepth      int
 		watch          bool
+		watchOnly      bool
+		eventTypes     []string
+		diff           bool
+		reconnect      bool
+		resumeFrom     string
+		exec           string
 	}
 	ctx            context.Context
 	logger         *slog.Logger
 	console        *terminal.Console
 	conn           *grpc.ClientConn
-	marshalOptions protojson.MarshalOptions
 	globalHelper   *reflection.Helper
 	objectHelper   *reflection.ObjectHelper
+	macros         *macros.Expander
+	eventTypes     []eventsv1.EventType
+	previous       map[string]proto.Message
+	reconnectDelay time.Duration
+
+	// input is where the answers of the filter builder are read from. This is intended for tests, and when it is nil
+	// the standard input is used.
+	input io.Reader
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
@@ -140,6 +297,12 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("there is no configuration, run the 'login' command")
 	}
 
+	// Create the object that expands the macros defined in the configuration:
+	c.macros, err = cfg.Expander(c.logger)
+	if err != nil {
+		return err
+	}
+
 	// Create the gRPC connection from the configuration:
 	c.conn, err = cfg.Connect(ctx, cmd.Flags())
 	if err != nil {
@@ -162,7 +325,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		c.console.Render(ctx, "no_object.txt", map[string]any{
 			"Helper": c.globalHelper,
 		})
-		return nil
+		return exit.Usage
 	}
 
 	// Get the object helper:
@@ -172,20 +335,103 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 			"Helper": c.globalHelper,
 			"Object": args[0],
 		})
-		return nil
+		return exit.Usage
 	}
 
 	// Check the flags:
-	if c.args.format != outputFormatTable && c.args.format != outputFormatJson && c.args.format != outputFormatYaml {
-		return fmt.Errorf(
-			"unknown output format '%s', should be '%s', '%s' or '%s'",
-			c.args.format, outputFormatTable, outputFormatJson, outputFormatYaml,
+	switch {
+	case c.args.format == outputFormatChanges:
+		if !c.args.watch {
+			return exit.Usagef("output format '%s' can only be used with '--watch'", outputFormatChanges)
+		}
+	case !rendering.DefaultRegistry.Contains(c.args.format):
+		return exit.Usagef(
+			"unknown output format '%s', should be %s",
+			c.args.format, rendering.QuoteList(append(rendering.DefaultRegistry.Names(), outputFormatChanges)),
 		)
 	}
 
+	if c.args.selector != "" {
+		_, err = selector.Filter(c.args.selector)
+		if err != nil {
+			return exit.WithCode(exit.Usage, err)
+		}
+	}
+	if c.args.groupBy != "" && c.args.byPool {
+		return exit.Usagef("options '--group-by' and '--by-pool' can't be used together")
+	}
+	if (c.args.groupBy != "" || c.args.byPool) && (c.args.format != rendering.FormatTable || c.args.watch) {
+		return exit.Usagef(
+			"options '--group-by' and '--by-pool' are only supported with the '%s' output format and "+
+				"without '--watch'",
+			rendering.FormatTable,
+		)
+	}
+	if (len(c.args.columns) > 0 || len(c.args.hideColumns) > 0) &&
+		c.args.format != rendering.FormatTable && c.args.format != outputFormatChanges {
+		return exit.Usagef(
+			"options '--columns' and '--hide-columns' are only supported with the '%s' output format",
+			rendering.FormatTable,
+		)
+	}
+	if c.args.format == rendering.FormatTree && c.args.watch {
+		return exit.Usagef("output format '%s' can't be used with '--watch'", rendering.FormatTree)
+	}
+	if cmd.Flags().Changed("tree-depth") {
+		if c.args.format != rendering.FormatTree {
+			return exit.Usagef(
+				"option '--tree-depth' is only supported with the '%s' output format",
+				rendering.FormatTree,
+			)
+		}
+		if c.args.treeDepth < 1 {
+			return exit.Usagef("tree depth should be at least one, but it is %d", c.args.treeDepth)
+		}
+	}
+	if c.args.byPool && c.objectHelper.Descriptor().Name() != hostDescriptor.Name() {
+		return exit.Usagef("option '--by-pool' is only supported for hosts")
+	}
+
+	if len(c.args.eventTypes) > 0 {
+		if !c.args.watch {
+			return exit.Usagef("option '--event-types' can only be used with '--watch'")
+		}
+		c.eventTypes, err = parseEventTypes(c.args.eventTypes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.args.watchOnly && !c.args.watch {
+		return exit.Usagef("option '--watch-only' can only be used with '--watch'")
+	}
+	if c.args.exec != "" && !c.args.watch {
+		return exit.Usagef("option '--exec' can only be used with '--watch'")
+	}
+	if c.args.resumeFrom != "" && !c.args.watch {
+		return exit.Usagef("option '--resume-from' can only be used with '--watch'")
+	}
+	if c.args.diff && (!c.args.watch || c.args.format != rendering.FormatTable) {
+		return exit.Usagef(
+			"option '--diff' can only be used with '--watch' and the '%s' output format",
+			rendering.FormatTable,
+		)
+	}
+
+	// Build the filter interactively if requested:
+	if c.args.buildFilter {
+		if c.args.filter != "" {
+			return exit.Usagef("options '--build-filter' and '--filter' can't be used together")
+		}
+		c.args.filter, err = c.buildFilter(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// If watch mode is enabled, watch for events instead of listing
 	if c.args.watch {
-		return c.watch(ctx, args[1:])
+		return c.watchUntilInterrupted(ctx, args[1:])
 	}
 
 	// Get the objects using the list method, which will handle filtering by identifiers or names if provided.
@@ -194,17 +440,75 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Render the items:
-	var render func(context.Context, []proto.Message) error
-	switch c.args.format {
-	case outputFormatJson:
-		render = c.renderJson
-	case outputFormatYaml:
-		render = c.renderYaml
-	default:
-		render = c.renderTable
+	// Render the items, and fail if none of the objects requested by identifier or name exists:
+	err = c.render(ctx, objects)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 && len(objects) == 0 {
+		return exit.NotFound
+	}
+	return nil
+}
+
+// render renders the given objects using the output format selected by the user. In the 'changes' format the objects
+// are rendered as a table.
+func (c *runnerContext) render(ctx context.Context, objects []proto.Message) error {
+	format := c.args.format
+	if format == outputFormatChanges {
+		format = rendering.FormatTable
+	}
+
+	// Check if there are results. Formats intended for tools write an empty list instead of the message, so that they
+	// don't need to parse it.
+	if len(objects) == 0 && !isDocumentFormat(format) {
+		c.console.Render(ctx, "no_matching_objects.txt", nil)
+		return nil
+	}
+
+	// Calculate the expression used to group the objects:
+	groupBy := c.args.groupBy
+	if c.args.byPool {
+		var err error
+		groupBy, err = c.poolGroupBy(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Truncate values to fit the width of the terminal, unless explicitly disabled:
+	maxWidth := 0
+	if !c.args.noTruncate {
+		maxWidth = c.console.Width()
+	}
+
+	// Create the renderer and use it to render the objects:
+	renderer, err := rendering.DefaultRegistry.Create(format, &rendering.Options{
+		Logger:         c.logger,
+		Helper:         c.globalHelper,
+		Writer:         c.console,
+		IncludeDeleted: c.args.includeDeleted,
+		GroupBy:        groupBy,
+		MaxWidth:       maxWidth,
+		Columns:        c.args.columns,
+		HideColumns:    c.args.hideColumns,
+		Theme:          c.console.Theme(),
+		TreeDepth:      c.args.treeDepth,
+		Units:          c.console.Units(),
+		TimeZone:       c.console.TimeZone(),
+		Warnings:       os.Stderr,
+		DebugLayouts:   c.args.debugLayouts,
+		Macros:         c.macros,
+	})
+	if err != nil {
+		return err
 	}
-	return render(ctx, objects)
+	return renderer.Render(ctx, objects)
+}
+
+// isDocumentFormat checks if the given output format writes the objects as JSON or YAML documents, intended for tools.
+func isDocumentFormat(format string) bool {
+	return format == rendering.FormatJson || format == rendering.FormatYaml
 }
 
 func (c *runnerContext) list(ctx context.Context, keys []string) (results []proto.Message, err error) {
@@ -223,12 +527,32 @@ func (c *runnerContext) list(ctx context.Context, keys []string) (results []prot
 		)
 	}
 
-	// Apply the user-provided filter if specified.
+	// Apply the user-provided filter if specified, expanding the macros that it uses.
 	if c.args.filter != "" {
+		var filter string
+		filter, err = c.macros.Expand(c.args.filter)
+		if err != nil {
+			err = fmt.Errorf("failed to expand macros in filter '%s': %w", c.args.filter, err)
+			return
+		}
 		if options.Filter != "" {
-			options.Filter = fmt.Sprintf("(%s) && (%s)", options.Filter, c.args.filter)
+			options.Filter = fmt.Sprintf("(%s) && (%s)", options.Filter, filter)
 		} else {
-			options.Filter = c.args.filter
+			options.Filter = filter
+		}
+	}
+
+	// Apply the label selector if specified.
+	if c.args.selector != "" {
+		var filter string
+		filter, err = selector.Filter(c.args.selector)
+		if err != nil {
+			return
+		}
+		if options.Filter != "" {
+			options.Filter = fmt.Sprintf("(%s) && (%s)", options.Filter, filter)
+		} else {
+			options.Filter = filter
 		}
 	}
 
@@ -250,76 +574,39 @@ func (c *runnerContext) list(ctx context.Context, keys []string) (results []prot
 	return
 }
 
-func (c *runnerContext) renderTable(ctx context.Context, objects []proto.Message) error {
-	// Check if there are results:
-	if len(objects) == 0 {
-		c.console.Render(ctx, "no_matching_objects.txt", nil)
-		return nil
+// poolGroupBy returns a CEL expression that calculates the name of the host pool that a host belongs to. Hosts don't
+// have a reference to their host pool, it is the host pool that contains the list of hosts, so this lists the host
+// pools and builds a map literal from host identifiers to host pool names.
+func (c *runnerContext) poolGroupBy(ctx context.Context) (result string, err error) {
+	// The host pool type is in the same package than the host type:
+	poolType := c.objectHelper.Descriptor().ParentFile().Package().Append(hostPoolDescriptor.Name())
+	poolHelper := c.globalHelper.Lookup(string(poolType))
+	if poolHelper == nil {
+		err = fmt.Errorf("failed to find host pool type '%s'", poolType)
+		return
 	}
-
-	// Create the table renderer:
-	renderer, err := rendering.NewTableRenderer().
-		SetLogger(c.logger).
-		SetHelper(c.globalHelper).
-		SetWriter(c.console).
-		SetIncludeDeleted(c.args.includeDeleted).
-		Build()
+	listResult, err := poolHelper.List(ctx, reflection.ListOptions{
+		Filter: "!has(this.metadata.deletion_timestamp)",
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create table renderer: %w", err)
-	}
-
-	// Use the table renderer to render the objects:
-	return renderer.Render(ctx, objects)
-}
-
-func (c *runnerContext) renderJson(ctx context.Context, objects []proto.Message) error {
-	values, err := c.encodeObjects(objects)
-	if err != nil {
-		return err
-	}
-	if len(values) == 1 {
-		c.console.RenderJson(ctx, values[0])
-	} else {
-		c.console.RenderJson(ctx, values)
-	}
-	return nil
-}
-
-func (c *runnerContext) renderYaml(ctx context.Context, objects []proto.Message) error {
-	values, err := c.encodeObjects(objects)
-	if err != nil {
-		return err
-	}
-	if len(values) == 1 {
-		c.console.RenderYaml(ctx, values[0])
-	} else {
-		c.console.RenderYaml(ctx, values)
+		err = fmt.Errorf("failed to list host pools: %w", err)
+		return
 	}
-	return nil
-}
 
-func (c *runnerContext) encodeObjects(objects []proto.Message) (result []any, err error) {
-	values := make([]any, len(objects))
-	for i, object := range objects {
-		values[i], err = c.encodeObject(object)
-		if err != nil {
-			return
+	// Build the map from host identifiers to host pool names:
+	var entries []string
+	for _, pool := range listResult.Items {
+		label := poolHelper.GetName(pool)
+		if label == "" {
+			label = poolHelper.GetId(pool)
+		}
+		status := pool.ProtoReflect().Get(pool.ProtoReflect().Descriptor().Fields().ByName("status")).Message()
+		hosts := status.Get(status.Descriptor().Fields().ByName("hosts")).List()
+		for i := range hosts.Len() {
+			entries = append(entries, fmt.Sprintf("%q: %q", hosts.Get(i).String(), label))
 		}
 	}
-	result = values
-	return
-}
-
-func (c *runnerContext) encodeObject(object proto.Message) (result any, err error) {
-	wrapper, err := anypb.New(object)
-	if err != nil {
-		return
-	}
-	var data []byte
-	data, err = c.marshalOptions.Marshal(wrapper)
-	if err != nil {
-		return
-	}
-	err = json.Unmarshal(data, &result)
+	pools := fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+	result = fmt.Sprintf("this.id in %[1]s? %[1]s[this.id]: ''", pools)
 	return
 }
@@ -0,0 +1,449 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package get
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// Operators offered for the different kinds of fields:
+var (
+	buildFilterEqualityOperators   = []string{"==", "!="}
+	buildFilterComparisonOperators = []string{"==", "!=", "<", "<=", ">", ">="}
+	buildFilterStringOperators     = []string{"==", "!=", "startsWith", "endsWith", "contains", "matches"}
+	buildFilterTimeOperators       = []string{"<", ">"}
+)
+
+// buildFilter asks the user interactively for the conditions of the filter, walking the fields of the object type,
+// and returns the resulting CEL expression.
+func (c *runnerContext) buildFilter(ctx context.Context) (result string, err error) {
+	input := c.input
+	if input == nil {
+		if !isatty.IsTerminal(os.Stdin.Fd()) {
+			err = exit.Usagef("option '--build-filter' requires a terminal, use '--filter' instead")
+			return
+		}
+		input = os.Stdin
+	}
+	builder := &filterBuilder{
+		console: c.console,
+		reader:  bufio.NewReader(input),
+	}
+	c.console.Render(ctx, "build_filter_start.txt", map[string]any{
+		"Object": c.objectHelper.Plural(),
+	})
+	result, err = builder.build(ctx, c.objectHelper.Descriptor())
+	if err != nil {
+		return
+	}
+	c.console.Render(ctx, "build_filter_result.txt", map[string]any{
+		"Object": c.objectHelper.Plural(),
+		"Filter": result,
+		"Quoted": shellQuote(result),
+	})
+	return
+}
+
+// filterBuilder contains the logic that asks the user for the conditions of a filter.
+type filterBuilder struct {
+	console *terminal.Console
+	reader  *bufio.Reader
+}
+
+// build asks for conditions until the user decides to finish, and combines them with the selected logical operators.
+// When the operators are mixed the previous conditions are surrounded by parenthesis, so that they are evaluated in
+// the order that they were entered.
+func (b *filterBuilder) build(ctx context.Context, message protoreflect.MessageDescriptor) (result string,
+	err error) {
+	result, err = b.condition(ctx, message)
+	if err != nil {
+		return
+	}
+	previous := ""
+	for {
+		b.console.Printf(ctx, "\nCurrent filter: %s\n\n", result)
+		var combine string
+		combine, err = b.ask(ctx, "Add another condition with 'and' or 'or', or press Enter to finish",
+			func(value string) error {
+				switch value {
+				case "", "and", "or":
+					return nil
+				default:
+					return fmt.Errorf("expected 'and', 'or' or nothing, but got '%s'", value)
+				}
+			},
+		)
+		if errors.Is(err, io.EOF) {
+			err = nil
+			return
+		}
+		if err != nil || combine == "" {
+			return
+		}
+		var condition string
+		condition, err = b.condition(ctx, message)
+		if err != nil {
+			return
+		}
+		operator := "&&"
+		if combine == "or" {
+			operator = "||"
+		}
+		if previous != "" && previous != operator {
+			result = fmt.Sprintf("(%s)", result)
+		}
+		result = fmt.Sprintf("%s %s %s", result, operator, condition)
+		previous = operator
+	}
+}
+
+// condition asks for the field, navigating the nested messages, and then for the condition that it should satisfy.
+func (b *filterBuilder) condition(ctx context.Context, message protoreflect.MessageDescriptor) (result string,
+	err error) {
+	path := "this"
+	for {
+		// Ask for the field. For nested messages the first option checks if the message is present, as that is
+		// often what is needed.
+		fields := message.Fields()
+		var options []string
+		if path != "this" {
+			options = append(options, "is set")
+		}
+		for i := range fields.Len() {
+			field := fields.Get(i)
+			options = append(options, fmt.Sprintf("%s (%s)", field.Name(), reflection.FieldType(field)))
+		}
+		var choice int
+		choice, err = b.choose(ctx, fmt.Sprintf("Fields of '%s'", path), options, func(option string) string {
+			name, _, _ := strings.Cut(option, " (")
+			return name
+		})
+		if err != nil {
+			return
+		}
+		if path != "this" {
+			if choice == 0 {
+				result = fmt.Sprintf("has(%s)", path)
+				return
+			}
+			choice--
+		}
+		field := fields.Get(choice)
+		path = fmt.Sprintf("%s.%s", path, field.Name())
+
+		// Continue with the fields of nested messages, and ask for the condition for the rest:
+		nested := field.Message()
+		if nested != nil && !field.IsList() && !field.IsMap() && nested.ParentFile().Package() != "google.protobuf" {
+			message = nested
+			continue
+		}
+		result, err = b.fieldCondition(ctx, field, path)
+		return
+	}
+}
+
+// fieldCondition asks for the condition for a field that isn't a nested message.
+func (b *filterBuilder) fieldCondition(ctx context.Context, field protoreflect.FieldDescriptor,
+	path string) (result string, err error) {
+	switch {
+	case field.IsMap():
+		return b.mapCondition(ctx, field, path)
+	case field.IsList():
+		return b.listCondition(ctx, field, path)
+	}
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		var choice int
+		choice, err = b.choose(ctx, "Condition", []string{"true", "false"}, nil)
+		if err != nil {
+			return
+		}
+		result = path
+		if choice == 1 {
+			result = "!" + path
+		}
+		return
+	case protoreflect.StringKind:
+		var operator, value string
+		operator, err = b.operator(ctx, buildFilterStringOperators)
+		if err != nil {
+			return
+		}
+		value, err = b.value(ctx, field)
+		if err != nil {
+			return
+		}
+		if strings.HasPrefix(operator, "=") || strings.HasPrefix(operator, "!") {
+			result = fmt.Sprintf("%s %s %s", path, operator, value)
+		} else {
+			result = fmt.Sprintf("%s.%s(%s)", path, operator, value)
+		}
+		return
+	case protoreflect.EnumKind, protoreflect.BytesKind:
+		return b.comparison(ctx, field, path, buildFilterEqualityOperators)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch field.Message().FullName() {
+		case "google.protobuf.Timestamp", "google.protobuf.Duration":
+			return b.comparison(ctx, field, path, buildFilterTimeOperators)
+		}
+		result = fmt.Sprintf("has(%s)", path)
+		return
+	default:
+		return b.comparison(ctx, field, path, buildFilterComparisonOperators)
+	}
+}
+
+// mapCondition asks for the condition for a map field, either that it contains a key or that the value of a key is
+// equal to a given value.
+func (b *filterBuilder) mapCondition(ctx context.Context, field protoreflect.FieldDescriptor,
+	path string) (result string, err error) {
+	options := []string{"contains key"}
+	if field.MapValue().Message() == nil {
+		options = append(options, "key has value")
+	}
+	choice, err := b.choose(ctx, "Condition", options, nil)
+	if err != nil {
+		return
+	}
+	key, err := b.value(ctx, field.MapKey())
+	if err != nil {
+		return
+	}
+	if choice == 0 {
+		result = fmt.Sprintf("%s in %s", key, path)
+		return
+	}
+	value, err := b.value(ctx, field.MapValue())
+	if err != nil {
+		return
+	}
+	result = fmt.Sprintf("%s[%s] == %s", path, key, value)
+	return
+}
+
+// listCondition asks for the condition for a list field, either that it contains a value, or that it is empty or
+// not.
+func (b *filterBuilder) listCondition(ctx context.Context, field protoreflect.FieldDescriptor,
+	path string) (result string, err error) {
+	options := []string{"is not empty", "is empty"}
+	if field.Message() == nil {
+		options = append(options, "contains")
+	}
+	choice, err := b.choose(ctx, "Condition", options, nil)
+	if err != nil {
+		return
+	}
+	switch choice {
+	case 0:
+		result = fmt.Sprintf("size(%s) > 0", path)
+	case 1:
+		result = fmt.Sprintf("size(%s) == 0", path)
+	default:
+		var value string
+		value, err = b.value(ctx, field)
+		if err != nil {
+			return
+		}
+		result = fmt.Sprintf("%s in %s", value, path)
+	}
+	return
+}
+
+// comparison asks for one of the given operators and for the value, and returns the comparison.
+func (b *filterBuilder) comparison(ctx context.Context, field protoreflect.FieldDescriptor, path string,
+	operators []string) (result string, err error) {
+	operator, err := b.operator(ctx, operators)
+	if err != nil {
+		return
+	}
+	value, err := b.value(ctx, field)
+	if err != nil {
+		return
+	}
+	result = fmt.Sprintf("%s %s %s", path, operator, value)
+	return
+}
+
+// operator asks for one of the given operators.
+func (b *filterBuilder) operator(ctx context.Context, operators []string) (result string, err error) {
+	choice, err := b.choose(ctx, "Operators", operators, nil)
+	if err != nil {
+		return
+	}
+	result = operators[choice]
+	return
+}
+
+// value asks for a value for the type of the given field, ignoring the cardinality, and returns the corresponding
+// CEL literal. For enums the values are offered as a list, and for the rest the text entered is checked.
+func (b *filterBuilder) value(ctx context.Context, field protoreflect.FieldDescriptor) (result string, err error) {
+	if field.Kind() == protoreflect.EnumKind {
+		enum := field.Enum()
+		values := enum.Values()
+		var names []string
+		for i := range values.Len() {
+			value := values.Get(i)
+			if value.Number() == 0 && values.Len() > 1 {
+				continue
+			}
+			names = append(names, string(value.Name()))
+		}
+		var choice int
+		choice, err = b.choose(ctx, "Values", names, nil)
+		if err != nil {
+			return
+		}
+		result = string(enum.FullName().Append(protoreflect.Name(names[choice])))
+		return
+	}
+	_, err = b.ask(ctx, "Value", func(text string) error {
+		var literalErr error
+		result, literalErr = filterLiteral(field, text)
+		return literalErr
+	})
+	return
+}
+
+// filterLiteral converts the text entered by the user into a CEL literal for the type of the given field, ignoring the
+// cardinality. It returns an error if the text isn't valid for that type.
+func filterLiteral(field protoreflect.FieldDescriptor, text string) (result string, err error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		result = strconv.Quote(text)
+	case protoreflect.BytesKind:
+		result = "b" + strconv.Quote(text)
+	case protoreflect.BoolKind:
+		_, err = strconv.ParseBool(text)
+		result = text
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		_, err = strconv.ParseFloat(text, 64)
+		result = text
+		if err == nil && !strings.ContainsAny(text, ".eE") {
+			result = text + ".0"
+		}
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		_, err = strconv.ParseUint(text, 10, 64)
+		result = text + "u"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch field.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			_, err = time.Parse(time.RFC3339, text)
+			result = fmt.Sprintf("timestamp(%s)", strconv.Quote(text))
+		case "google.protobuf.Duration":
+			_, err = time.ParseDuration(text)
+			result = fmt.Sprintf("duration(%s)", strconv.Quote(text))
+		default:
+			err = fmt.Errorf("fields of type '%s' can't be compared", field.Message().Name())
+		}
+	default:
+		_, err = strconv.ParseInt(text, 10, 64)
+		result = text
+	}
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			err = numErr.Err
+		}
+		err = fmt.Errorf("'%s' isn't a valid %s: %w", text, reflection.FieldType(field), err)
+		result = ""
+	}
+	return
+}
+
+// choose writes the numbered list of options and asks for one of them, that can be selected by number or by name.
+// The optional name function extracts the name from the text of the option. It returns the index of the option.
+func (b *filterBuilder) choose(ctx context.Context, title string, options []string,
+	name func(string) string) (result int, err error) {
+	if name == nil {
+		name = func(option string) string {
+			return option
+		}
+	}
+	b.console.Printf(ctx, "%s:\n", title)
+	for i, option := range options {
+		b.console.Printf(ctx, "  %2d) %s\n", i+1, option)
+	}
+	_, err = b.ask(ctx, "Choice", func(value string) error {
+		number, numberErr := strconv.Atoi(value)
+		if numberErr == nil && number >= 1 && number <= len(options) {
+			result = number - 1
+			return nil
+		}
+		index := slices.IndexFunc(options, func(option string) bool {
+			return name(option) == value
+		})
+		if index >= 0 {
+			result = index
+			return nil
+		}
+		return fmt.Errorf("expected a number between 1 and %d or the name of an option", len(options))
+	})
+	return
+}
+
+// ask writes the given prompt and reads the answer, repeating until the answer passes the given check.
+func (b *filterBuilder) ask(ctx context.Context, prompt string, check func(string) error) (result string, err error) {
+	for {
+		b.console.Printf(ctx, "%s: ", prompt)
+		var line string
+		line, err = b.reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			err = fmt.Errorf("failed to read answer: %w", err)
+			return
+		}
+		eof := err != nil
+		err = nil
+		if eof && line == "" {
+			b.console.Printf(ctx, "\n")
+			err = fmt.Errorf("input ended before the filter was complete: %w", io.EOF)
+			return
+		}
+		value := strings.TrimSpace(line)
+		checkErr := check(value)
+		if checkErr == nil {
+			if eof {
+				b.console.Printf(ctx, "\n")
+			}
+			result = value
+			return
+		}
+		if eof {
+			b.console.Printf(ctx, "\n")
+			err = fmt.Errorf("input ended before the filter was complete: %w", checkErr)
+			return
+		}
+		b.console.Printf(ctx, "Invalid value: %v.\n", checkErr)
+	}
+}
+
+// shellQuote quotes the given text so that it can be used as a single argument in a POSIX shell.
+func shellQuote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
@@ -0,0 +1,150 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package get
+
+import (
+	"bufio"
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+var _ = Describe("Filter builder", func() {
+	var buffer *gbytes.Buffer
+
+	// build runs the filter builder for clusters with the given answers, one per line.
+	build := func(answers ...string) (string, error) {
+		buffer = gbytes.NewBuffer()
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		builder := &filterBuilder{
+			console: console,
+			reader:  newAnswersReader(answers),
+		}
+		return builder.build(context.Background(), (*ffv1.Cluster)(nil).ProtoReflect().Descriptor())
+	}
+
+	DescribeTable("Builds conditions",
+		func(expected string, answers ...string) {
+			actual, err := build(answers...)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+		Entry(
+			"String equality",
+			`this.id == "123"`,
+			"id", "==", "123", "",
+		),
+		Entry(
+			"String method",
+			`this.metadata.name.startsWith("prod-")`,
+			"metadata", "name", "startsWith", "prod-", "",
+		),
+		Entry(
+			"Enum selected by number",
+			"this.status.state == fulfillment.v1.ClusterState.CLUSTER_STATE_READY",
+			"status", "state", "1", "CLUSTER_STATE_READY", "",
+		),
+		Entry(
+			"Presence of nested message",
+			"has(this.status)",
+			"status", "1", "",
+		),
+		Entry(
+			"Map key",
+			`"env" in this.metadata.labels`,
+			"metadata", "labels", "contains key", "env", "",
+		),
+		Entry(
+			"Map value",
+			`this.metadata.labels["env"] == "prod"`,
+			"metadata", "labels", "key has value", "env", "prod", "",
+		),
+		Entry(
+			"Timestamp",
+			`this.metadata.creation_timestamp > timestamp("2025-01-01T00:00:00Z")`,
+			"metadata", "creation_timestamp", ">", "2025-01-01T00:00:00Z", "",
+		),
+		Entry(
+			"Conditions combined with the same operator",
+			`this.id == "1" || this.id == "2" || this.id == "3"`,
+			"id", "==", "1", "or", "id", "==", "2", "or", "id", "==", "3", "",
+		),
+		Entry(
+			"Conditions combined with mixed operators",
+			`(this.id == "1" || this.id == "2") && has(this.status)`,
+			"id", "==", "1", "or", "id", "==", "2", "and", "status", "is set", "",
+		),
+	)
+
+	It("Asks again when the answer is wrong", func() {
+		actual, err := build("junk", "id", "==", "123", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(Equal(`this.id == "123"`))
+		Expect(buffer).To(gbytes.Say("Invalid value: expected a number between 1 and"))
+	})
+
+	It("Finishes when the input ends after a condition", func() {
+		actual, err := build("id", "==", "123")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(Equal(`this.id == "123"`))
+	})
+
+	It("Fails if the input ends before the condition is complete", func() {
+		_, err := build("id", "==")
+		Expect(err).To(MatchError(ContainSubstring("input ended before the filter was complete")))
+	})
+
+	DescribeTable("Converts values to literals",
+		func(kind string, text string, expected string) {
+			field := (*ffv1.Cluster)(nil).ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(kind))
+			Expect(field).ToNot(BeNil())
+			actual, err := filterLiteral(field, text)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+		Entry("String with quotes", "id", `my "id"`, `"my \"id\""`),
+	)
+
+	It("Rejects invalid timestamps", func() {
+		field := (*ffv1.Cluster)(nil).ProtoReflect().Descriptor().Fields().ByName("metadata").Message().
+			Fields().ByName("creation_timestamp")
+		_, err := filterLiteral(field, "yesterday")
+		Expect(err).To(MatchError(ContainSubstring("'yesterday' isn't a valid Timestamp")))
+	})
+
+	It("Quotes filters for the shell", func() {
+		Expect(shellQuote(`this.id == 'a'`)).To(Equal(`'this.id == '\''a'\'''`))
+	})
+})
+
+// newAnswersReader returns a reader that returns the given answers, one per line.
+func newAnswersReader(answers []string) *bufio.Reader {
+	text := strings.Join(answers, "\n")
+	if len(answers) > 0 && answers[len(answers)-1] == "" {
+		text += "\n"
+	}
+	return bufio.NewReader(strings.NewReader(text))
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package get
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+var _ = Describe("Get command", func() {
+	DescribeTable("Renders an empty result",
+		func(format string, expected string) {
+			buffer := gbytes.NewBuffer()
+			console, err := terminal.NewConsole().
+				SetLogger(logger).
+				SetWriter(buffer).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = console.AddTemplates(templatesFS, "templates")
+			Expect(err).ToNot(HaveOccurred())
+			runner := &runnerContext{
+				logger:  logger,
+				console: console,
+			}
+			runner.args.format = format
+			err = runner.render(context.Background(), nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(buffer.Contents())).To(Equal(expected))
+		},
+		Entry("JSON", rendering.FormatJson, "[]\n"),
+		Entry("YAML", rendering.FormatYaml, "[]\n"),
+		Entry("Table", rendering.FormatTable, "There are no objects maching the given criteria.\n\n"),
+		Entry("Changes", outputFormatChanges, "There are no objects maching the given criteria.\n\n"),
+	)
+})
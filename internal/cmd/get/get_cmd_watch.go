@@ -14,18 +14,66 @@ language governing permissions and limitations under the License.
 package get
 
 import (
+	"container/list"
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
 	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+)
+
+// Delays used when reconnecting after the events stream is interrupted:
+const (
+	defaultReconnectDelay = time.Second
+	maxReconnectDelay     = 30 * time.Second
 )
 
-// watch watches for events and displays updated objects.
+// watchHistorySize is the number of event identifiers that are remembered in order to skip the events that the server
+// sends again after reconnecting, so that they aren't displayed or passed to the '--exec' command twice.
+const watchHistorySize = 1000
+
+// watchUntilInterrupted watches for events till the server ends the stream, the watch fails, or the user interrupts it.
+// When it doesn't end normally the end of the JSON or YAML output is marked, so that tools reading it can detect that
+// it is incomplete. Interruption isn't reported as an error, only with the exit code.
+func (c *runnerContext) watchUntilInterrupted(ctx context.Context, keys []string) error {
+	ctx, stop := output.InterruptContext(ctx)
+	defer stop()
+	err := c.watch(ctx, keys)
+	if err == nil {
+		return nil
+	}
+	interrupted := output.Interrupted(ctx)
+	reason := err
+	if interrupted {
+		reason = output.ErrInterrupted
+	}
+	markErr := output.MarkTruncated(c.console, c.args.format, reason)
+	if markErr != nil {
+		c.logger.ErrorContext(
+			ctx,
+			"Failed to mark truncated output",
+			slog.Any("error", markErr),
+		)
+	}
+	if interrupted {
+		return exit.Interrupted
+	}
+	return err
+}
+
+// watch watches for events and displays updated objects. When the stream is interrupted by a transient error, for
+// example because the server is restarted, it reconnects using an exponential backoff.
 func (c *runnerContext) watch(ctx context.Context, keys []string) error {
 	// Build filter for events
 	filter, err := c.buildEventFilter(keys)
@@ -36,31 +84,261 @@ func (c *runnerContext) watch(ctx context.Context, keys []string) error {
 	// Create events client
 	eventsClient := eventsv1.NewEventsClient(c.conn)
 
+	// Display the current objects before the events, unless the user asked only for the events. Note that changes
+	// that happen between the list and the start of the stream will not be displayed. When reconnecting is enabled
+	// the objects are listed even if they aren't displayed, as they are the starting point to find the changes that
+	// happen while disconnected.
+	state := &watchState{
+		keys:       keys,
+		resumeFrom: c.args.resumeFrom,
+		history:    newEventHistory(watchHistorySize),
+	}
+	if !c.args.watchOnly {
+		err = c.displayCurrent(ctx, keys)
+		if err != nil {
+			return err
+		}
+		state.synced = true
+	} else if c.args.reconnect {
+		_, err = c.listCurrent(ctx, keys)
+		if err != nil {
+			c.logger.WarnContext(
+				ctx,
+				"Failed to list objects, changes made while disconnected will not be displayed",
+				slog.Any("error", err),
+			)
+		} else {
+			state.synced = true
+		}
+	}
+
 	// Start watching
 	c.console.Printf(ctx, "Watching for changes (Ctrl+C to stop)...\n\n")
+	if c.args.resumeFrom != "" {
+		c.console.Printf(ctx, "Skipping events until event '%s'.\n\n", c.args.resumeFrom)
+	}
+	initialDelay := c.reconnectDelay
+	if initialDelay == 0 {
+		initialDelay = defaultReconnectDelay
+	}
+	delay := initialDelay
+	for {
+		received, err := c.watchStream(ctx, eventsClient, filter, state)
+		if err == nil {
+			return nil
+		}
+		if !c.args.reconnect || !isTransientWatchError(err) {
+			return err
+		}
+		if received {
+			delay = initialDelay
+		}
+		c.logger.InfoContext(
+			ctx,
+			"Events stream interrupted, will reconnect",
+			slog.Duration("delay", delay),
+			slog.String("last_event", state.lastEvent),
+			slog.Any("error", err),
+		)
+		c.console.Printf(
+			ctx,
+			"Connection lost (%s), reconnecting in %s...\n\n",
+			grpcstatus.Code(err), delay,
+		)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to reconnect: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxReconnectDelay)
+		state.reconnected = true
+	}
+}
+
+// displayCurrent lists and displays the objects that exist when the watch starts.
+func (c *runnerContext) displayCurrent(ctx context.Context, keys []string) error {
+	objects, err := c.listCurrent(ctx, keys)
+	if err != nil {
+		return err
+	}
+	// When there are no objects tables show nothing, but formats intended for tools write the empty list, so that the
+	// stream always starts with the initial state:
+	if len(objects) == 0 && !isDocumentFormat(c.args.format) {
+		return nil
+	}
+	err = c.render(ctx, objects)
+	if err != nil {
+		return err
+	}
+	c.console.Printf(ctx, "\n")
+	return nil
+}
+
+// listCurrent lists the objects that exist when the watch starts. When versions are tracked they are also remembered
+// as the previous versions, so that the first update of each object can be displayed as a diff, and so that the
+// changes made while disconnected can be found.
+func (c *runnerContext) listCurrent(ctx context.Context, keys []string) (result []proto.Message, err error) {
+	result, err = c.list(ctx, keys)
+	if err != nil {
+		err = fmt.Errorf("failed to list objects: %w", err)
+		return
+	}
+	if c.tracksVersions() {
+		c.previous = make(map[string]proto.Message, len(result))
+		for _, object := range result {
+			c.previous[c.getObjectId(object)] = object
+		}
+	}
+	return
+}
+
+// resync lists the objects again after reconnecting, and handles the differences with the previous versions as if
+// they were events, so that the changes made while disconnected aren't lost. The server doesn't send again the
+// events that happened while disconnected, as the watch request has no way to ask for them.
+func (c *runnerContext) resync(ctx context.Context, keys []string) {
+	objects, err := c.list(ctx, keys)
+	if err != nil {
+		c.logger.WarnContext(
+			ctx,
+			"Failed to list objects after reconnecting",
+			slog.Any("error", err),
+		)
+		c.console.Printf(ctx, "Warning: changes made while disconnected can't be displayed: %v\n\n", err)
+		return
+	}
+	current := make(map[string]bool, len(objects))
+	for _, object := range objects {
+		objectId := c.getObjectId(object)
+		current[objectId] = true
+		previous, ok := c.previous[objectId]
+		switch {
+		case !ok:
+			c.handleEvent(ctx, c.syntheticEvent(eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED, object), object)
+		case !proto.Equal(previous, object):
+			c.handleEvent(ctx, c.syntheticEvent(eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED, object), object)
+		}
+	}
+	var deleted []string
+	for objectId := range c.previous {
+		if !current[objectId] {
+			deleted = append(deleted, objectId)
+		}
+	}
+	slices.Sort(deleted)
+	for _, objectId := range deleted {
+		object := c.previous[objectId]
+		c.handleEvent(ctx, c.syntheticEvent(eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED, object), object)
+	}
+}
+
+// syntheticEvent creates an event of the given type for the given object, for the changes that are found comparing
+// versions instead of received from the server. These events have no identifier.
+func (c *runnerContext) syntheticEvent(eventType eventsv1.EventType, object proto.Message) *eventsv1.Event {
+	result := &eventsv1.Event{
+		Type: eventType,
+	}
+	switch typed := object.(type) {
+	case *ffv1.Cluster:
+		result.Payload = &eventsv1.Event_Cluster{
+			Cluster: typed,
+		}
+	case *ffv1.ClusterTemplate:
+		result.Payload = &eventsv1.Event_ClusterTemplate{
+			ClusterTemplate: typed,
+		}
+	}
+	return result
+}
+
+// watchState contains the information that is preserved when the events stream is reconnected.
+type watchState struct {
+	// keys are the identifiers or names of the objects that are watched.
+	keys []string
+
+	// resumeFrom is the identifier of the event after which events should be displayed. It is cleared when the first
+	// event is received, whether it is that event or not.
+	resumeFrom string
+
+	// synced is true when the previous versions of the objects contain all the objects that existed when the watch
+	// started, so that they can be compared to find the changes made while disconnected.
+	synced bool
 
+	// lastEvent is the identifier of the last event received.
+	lastEvent string
+
+	// history contains the identifiers of the events already received.
+	history *eventHistory
+
+	// reconnected is true when the stream is being opened again after being interrupted.
+	reconnected bool
+}
+
+// watchStream opens the events stream and displays the events until the stream ends or fails. It returns nil when
+// the server ends the stream, and a flag indicating if any event was received before it failed.
+func (c *runnerContext) watchStream(ctx context.Context, eventsClient eventsv1.EventsClient, filter string,
+	state *watchState) (received bool, err error) {
 	stream, err := eventsClient.Watch(ctx, &eventsv1.EventsWatchRequest{
 		Filter: &filter,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to start watching events: %w", err)
+		err = fmt.Errorf("failed to start watching events: %w", err)
+		return
+	}
+	if state.reconnected {
+		c.console.Printf(ctx, "Reconnected.\n\n")
+		state.reconnected = false
+		if state.synced {
+			c.resync(ctx, state.keys)
+		}
 	}
 
 	// Process events
 	for {
-		response, err := stream.Recv()
-		if err == io.EOF {
-			return nil
+		response, recvErr := stream.Recv()
+		if recvErr == io.EOF {
+			return
 		}
-		if err != nil {
-			return fmt.Errorf("failed to receive event: %w", err)
+		if recvErr != nil {
+			err = fmt.Errorf("failed to receive event: %w", recvErr)
+			return
 		}
+		received = true
 
 		event := response.GetEvent()
 		if event == nil {
 			continue
 		}
 
+		// Skip the events that were already received before reconnecting, and the events before the one that the
+		// user asked to resume from:
+		eventId := event.GetId()
+		if eventId != "" {
+			if state.history.seen(eventId) {
+				c.logger.DebugContext(
+					ctx,
+					"Skipping duplicated event",
+					slog.String("event_id", eventId),
+				)
+				continue
+			}
+			state.lastEvent = eventId
+		}
+		// Servers that send again past events start with the one requested, so if the first event is a different
+		// one the server doesn't do that, and skipping events would skip all of them.
+		if state.resumeFrom != "" {
+			resumeFrom := state.resumeFrom
+			state.resumeFrom = ""
+			if eventId == resumeFrom {
+				continue
+			}
+			c.console.Printf(
+				ctx,
+				"Warning: the first event received isn't '%s', the server probably doesn't send again past "+
+					"events, so no event will be skipped.\n\n",
+				resumeFrom,
+			)
+		}
+
 		// Extract the object from the event payload
 		object, err := c.extractObjectFromEvent(event)
 		if err != nil {
@@ -76,12 +354,55 @@ func (c *runnerContext) watch(ctx context.Context, keys []string) error {
 		if object == nil {
 			continue
 		}
+		c.handleEvent(ctx, event, object)
+	}
+}
 
-		// Display the event
-		c.displayEvent(ctx, event, object)
+// handleEvent displays the event and runs the command requested by the user, unless the type of event is one that the
+// user isn't interested in. That is checked here and not in the filter sent to the server because not all servers
+// support filtering by event type.
+func (c *runnerContext) handleEvent(ctx context.Context, event *eventsv1.Event, object proto.Message) {
+	if len(c.eventTypes) > 0 && !slices.Contains(c.eventTypes, event.GetType()) {
+		c.rememberVersion(event, object)
+		return
+	}
+	c.displayEvent(ctx, event, object)
+	if c.args.exec != "" {
+		c.runExec(ctx, event, object)
 	}
 }
 
+// Names of the event types accepted by the '--event-types' option:
+const (
+	eventTypeCreated = "created"
+	eventTypeUpdated = "updated"
+	eventTypeDeleted = "deleted"
+)
+
+var eventTypesByName = map[string]eventsv1.EventType{
+	eventTypeCreated: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+	eventTypeUpdated: eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+	eventTypeDeleted: eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED,
+}
+
+// parseEventTypes converts the names of event types given in the command line to the corresponding enum values.
+func parseEventTypes(names []string) (result []eventsv1.EventType, err error) {
+	for _, name := range names {
+		eventType, ok := eventTypesByName[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			err = exit.Usagef(
+				"unknown event type '%s', should be '%s', '%s' or '%s'",
+				name, eventTypeCreated, eventTypeUpdated, eventTypeDeleted,
+			)
+			return
+		}
+		if !slices.Contains(result, eventType) {
+			result = append(result, eventType)
+		}
+	}
+	return
+}
+
 // buildEventFilter builds a CEL filter expression for watching events.
 func (c *runnerContext) buildEventFilter(keys []string) (string, error) {
 	// Get the field name for this object type in the Event message
@@ -144,24 +465,54 @@ func (c *runnerContext) extractObjectFromEvent(event *eventsv1.Event) (proto.Mes
 
 // displayEvent displays an event and the updated object.
 func (c *runnerContext) displayEvent(ctx context.Context, event *eventsv1.Event, object proto.Message) {
-	timestamp := time.Now().Format(time.TimeOnly)
+	timestamp := time.Now().In(c.console.Location()).Format(time.TimeOnly)
 	eventType := strings.TrimPrefix(event.GetType().String(), "EVENT_TYPE_")
 
 	objectId := c.getObjectId(object)
 
 	c.console.Printf(ctx, "[%s] %s %s '%s'\n", timestamp, eventType, c.objectHelper.Singular(), objectId)
 
-	var render func(context.Context, []proto.Message) error
-	switch c.args.format {
-	case outputFormatJson:
-		render = c.renderJson
-	case outputFormatYaml:
-		render = c.renderYaml
-	default:
-		render = c.renderTable
+	// Remember the versions of the objects, as they are needed to display the fields that changed:
+	previous := c.rememberVersion(event, object)
+
+	// In the changes format display only the fields that changed in update events, without blank lines between
+	// events, so that the output is compact:
+	if c.args.format == outputFormatChanges {
+		if event.GetType() != eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED {
+			return
+		}
+		if previous == nil {
+			c.console.Printf(ctx, "  Previous version unknown.\n")
+			return
+		}
+		err := c.displayDiff(ctx, previous, object)
+		if err != nil {
+			c.logger.WarnContext(
+				ctx,
+				"Failed to compare object versions",
+				"object_id", objectId,
+				"error", err,
+			)
+		}
+		return
+	}
+
+	// If requested, display only the fields that changed since the previous version of the object:
+	if c.args.diff && previous != nil && event.GetType() == eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED {
+		err := c.displayDiff(ctx, previous, object)
+		if err != nil {
+			c.logger.WarnContext(
+				ctx,
+				"Failed to compare object versions",
+				"object_id", objectId,
+				"error", err,
+			)
+		}
+		c.console.Printf(ctx, "\n")
+		return
 	}
 
-	err := render(ctx, []proto.Message{object})
+	err := c.render(ctx, []proto.Message{object})
 	if err != nil {
 		c.logger.WarnContext(
 			ctx,
@@ -174,6 +525,31 @@ func (c *runnerContext) displayEvent(ctx context.Context, event *eventsv1.Event,
 	c.console.Printf(ctx, "\n")
 }
 
+// rememberVersion saves the version of the object received in the event, if versions are tracked, and returns the
+// previous version. That is the one received in the last event for the same object, or in the initial list, if any.
+func (c *runnerContext) rememberVersion(event *eventsv1.Event, object proto.Message) (result proto.Message) {
+	if !c.tracksVersions() {
+		return
+	}
+	if c.previous == nil {
+		c.previous = map[string]proto.Message{}
+	}
+	objectId := c.getObjectId(object)
+	result = c.previous[objectId]
+	if event.GetType() == eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED {
+		delete(c.previous, objectId)
+	} else {
+		c.previous[objectId] = object
+	}
+	return
+}
+
+// tracksVersions returns true if the versions of the objects need to be remembered in order to display the fields that
+// changed in update events, or to find the changes made while disconnected.
+func (c *runnerContext) tracksVersions() bool {
+	return c.args.diff || c.args.format == outputFormatChanges || c.args.reconnect
+}
+
 // getObjectId extracts the ID from an object.
 func (c *runnerContext) getObjectId(object proto.Message) string {
 	// Use reflection to get the ID field
@@ -188,3 +564,58 @@ func (c *runnerContext) getObjectId(object proto.Message) string {
 
 	return "<unknown>"
 }
+
+// isTransientWatchError checks if the given error, returned while watching events, is likely to disappear if the
+// stream is opened again.
+func isTransientWatchError(err error) bool {
+	switch grpcstatus.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
+
+// eventHistory remembers the identifiers of the most recently seen events. When the size limit is reached the least
+// recently seen identifier is discarded.
+type eventHistory struct {
+	size  int
+	order *list.List
+	items map[string]*list.Element
+}
+
+func newEventHistory(size int) *eventHistory {
+	return &eventHistory{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+// contains checks if the given identifier is in the history, without changing the order.
+func (h *eventHistory) contains(id string) bool {
+	_, ok := h.items[id]
+	return ok
+}
+
+// add adds the given identifier to the history, or marks it as the most recently seen if it is already there.
+func (h *eventHistory) add(id string) {
+	element, ok := h.items[id]
+	if ok {
+		h.order.MoveToFront(element)
+		return
+	}
+	h.items[id] = h.order.PushFront(id)
+	if h.order.Len() > h.size {
+		oldest := h.order.Back()
+		h.order.Remove(oldest)
+		delete(h.items, oldest.Value.(string))
+	}
+}
+
+// seen adds the given identifier to the history and returns true if it was already there.
+func (h *eventHistory) seen(id string) bool {
+	result := h.contains(id)
+	h.add(id)
+	return result
+}
@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package get
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/diff"
+)
+
+// displayDiff displays the fields that are different in the given versions of an object.
+func (c *runnerContext) displayDiff(ctx context.Context, before, after proto.Message) error {
+	changes, err := diff.Compare(before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		c.console.Printf(ctx, "  No changes.\n")
+		return nil
+	}
+	for _, change := range changes {
+		c.console.Printf(ctx, "  %s\n", change)
+	}
+	return nil
+}
@@ -18,14 +18,18 @@ import (
 	"time"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 	"github.com/osac-project/fulfillment-cli/internal/testing"
 )
@@ -92,6 +96,26 @@ var _ = Describe("Watch e2e", func() {
 		// Register the events server
 		eventsv1.RegisterEventsServer(server.Registrar(), eventsServer)
 
+		// Register a clusters server that returns one existing cluster, for the initial list:
+		ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+			ListFunc: func(ctx context.Context, request *ffv1.ClustersListRequest,
+			) (response *ffv1.ClustersListResponse, err error) {
+				response = ffv1.ClustersListResponse_builder{
+					Size:  proto.Int32(1),
+					Total: proto.Int32(1),
+					Items: []*ffv1.Cluster{
+						ffv1.Cluster_builder{
+							Id: "existing-cluster-1",
+							Metadata: sharedv1.Metadata_builder{
+								Name: "my-existing-cluster",
+							}.Build(),
+						}.Build(),
+					},
+				}.Build()
+				return
+			},
+		})
+
 		// Start the server
 		server.Start()
 
@@ -131,16 +155,9 @@ var _ = Describe("Watch e2e", func() {
 			globalHelper: globalHelper,
 			objectHelper: helper,
 			console:      console,
-			args: struct {
-				format         string
-				filter         string
-				includeDeleted bool
-				watch          bool
-			}{
-				format: outputFormatTable,
-				watch:  true,
-			},
 		}
+		runner.args.format = rendering.FormatTable
+		runner.args.watch = true
 
 		// Start watching in a goroutine
 		done := make(chan error, 1)
@@ -163,6 +180,139 @@ var _ = Describe("Watch e2e", func() {
 		Expect(err.Error()).To(ContainSubstring("context canceled"))
 	})
 
+	DescribeTable("should display only the selected event types",
+		func(eventTypes []eventsv1.EventType, expected bool) {
+			globalHelper, err := reflection.NewHelper().
+				SetLogger(logger).
+				SetConnection(conn).
+				AddPackage("fulfillment.v1", 0).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			// Use a console that writes to a buffer, so that we can check the output:
+			buffer := gbytes.NewBuffer()
+			bufferConsole, err := terminal.NewConsole().
+				SetLogger(logger).
+				SetWriter(buffer).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			runner := &runnerContext{
+				logger:       logger,
+				conn:         conn,
+				globalHelper: globalHelper,
+				objectHelper: helper,
+				console:      bufferConsole,
+				eventTypes:   eventTypes,
+			}
+			runner.args.format = rendering.FormatTable
+			runner.args.watch = true
+
+			done := make(chan error, 1)
+			go func() {
+				done <- runner.watch(ctx, []string{})
+			}()
+			if expected {
+				Eventually(buffer).Should(gbytes.Say("CREATED cluster 'test-cluster-1'"))
+			} else {
+				Consistently(buffer, 200*time.Millisecond).ShouldNot(gbytes.Say("CREATED"))
+			}
+			cancel()
+			<-done
+		},
+		Entry("selected", []eventsv1.EventType{eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED}, true),
+		Entry("not selected", []eventsv1.EventType{eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED}, false),
+	)
+
+	DescribeTable("should display the existing objects before the events",
+		func(watchOnly bool) {
+			globalHelper, err := reflection.NewHelper().
+				SetLogger(logger).
+				SetConnection(conn).
+				AddPackage("fulfillment.v1", 0).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			// Use a console that writes to a buffer, so that we can check the output:
+			buffer := gbytes.NewBuffer()
+			bufferConsole, err := terminal.NewConsole().
+				SetLogger(logger).
+				SetWriter(buffer).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			runner := &runnerContext{
+				logger:       logger,
+				conn:         conn,
+				globalHelper: globalHelper,
+				objectHelper: helper,
+				console:      bufferConsole,
+			}
+			runner.args.format = rendering.FormatTable
+			runner.args.watch = true
+			runner.args.watchOnly = watchOnly
+
+			done := make(chan error, 1)
+			go func() {
+				done <- runner.watch(ctx, []string{})
+			}()
+			if watchOnly {
+				Eventually(buffer).Should(gbytes.Say("CREATED cluster 'test-cluster-1'"))
+				Expect(string(buffer.Contents())).ToNot(ContainSubstring("existing-cluster-1"))
+			} else {
+				Eventually(buffer).Should(gbytes.Say("existing-cluster-1"))
+				Eventually(buffer).Should(gbytes.Say("Watching for changes"))
+				Eventually(buffer).Should(gbytes.Say("CREATED cluster 'test-cluster-1'"))
+			}
+			cancel()
+			<-done
+		},
+		Entry("list and watch", false),
+		Entry("watch only", true),
+	)
+
+	It("should display only the changed fields with the changes format", func() {
+		buffer := gbytes.NewBuffer()
+		bufferConsole, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		runner := &runnerContext{
+			logger:       logger,
+			objectHelper: helper,
+			console:      bufferConsole,
+		}
+		runner.args.format = outputFormatChanges
+		runner.args.watch = true
+
+		makeEvent := func(eventType eventsv1.EventType, state ffv1.ClusterState) (*eventsv1.Event, *ffv1.Cluster) {
+			cluster := ffv1.Cluster_builder{
+				Id: "test-cluster-1",
+				Status: ffv1.ClusterStatus_builder{
+					State: state,
+				}.Build(),
+			}.Build()
+			return &eventsv1.Event{Type: eventType}, cluster
+		}
+		event, cluster := makeEvent(
+			eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+		)
+		runner.displayEvent(ctx, event, cluster)
+		event, cluster = makeEvent(
+			eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+			ffv1.ClusterState_CLUSTER_STATE_READY,
+		)
+		runner.displayEvent(ctx, event, cluster)
+		Expect(buffer).To(gbytes.Say("CREATED cluster 'test-cluster-1'\n"))
+		Expect(buffer).To(gbytes.Say(
+			"UPDATED cluster 'test-cluster-1'\n" +
+				`  status.state: "CLUSTER_STATE_PROGRESSING" -> "CLUSTER_STATE_READY"\n`,
+		))
+		Expect(string(buffer.Contents())).ToNot(ContainSubstring("\n\n"))
+	})
+
 	It("should build correct filter for specific cluster", func() {
 		runner := &runnerContext{
 			objectHelper: helper,
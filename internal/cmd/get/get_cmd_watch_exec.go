@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package get
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/shell"
+)
+
+// Names of the environment variables passed to the command given with the '--exec' option:
+const (
+	execEventIdEnv    = "FULFILLMENT_EVENT_ID"
+	execEventTypeEnv  = "FULFILLMENT_EVENT_TYPE"
+	execObjectTypeEnv = "FULFILLMENT_OBJECT_TYPE"
+	execObjectIdEnv   = "FULFILLMENT_OBJECT_ID"
+)
+
+// runExec runs the command given with the '--exec' option for the given event. The command is executed with the shell
+// of the operating system, receives the event in JSON format in the standard input, and the details of the event in
+// environment variables. The other variables of the tool aren't passed, as they may contain secrets. The output of the
+// command is written to the console. Failures are reported, but they don't stop the watch.
+func (c *runnerContext) runExec(ctx context.Context, event *eventsv1.Event, object proto.Message) {
+	// Convert the event to JSON:
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		c.logger.ErrorContext(
+			ctx,
+			"Failed to marshal event for command",
+			slog.String("event_id", event.GetId()),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	// Prepare the command:
+	eventType := strings.ToLower(strings.TrimPrefix(event.GetType().String(), "EVENT_TYPE_OBJECT_"))
+	cmd := shell.Command(ctx, "", c.args.exec)
+	cmd.Env = append(
+		cmd.Env,
+		execEventIdEnv+"="+event.GetId(),
+		execEventTypeEnv+"="+eventType,
+		execObjectTypeEnv+"="+c.objectHelper.Singular(),
+		execObjectIdEnv+"="+c.getObjectId(object),
+	)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = c.console
+	cmd.Stderr = os.Stderr
+
+	// Run it:
+	c.logger.DebugContext(
+		ctx,
+		"Running command for event",
+		slog.String("command", c.args.exec),
+		slog.String("event_id", event.GetId()),
+	)
+	err = cmd.Run()
+	if err != nil {
+		c.logger.WarnContext(
+			ctx,
+			"Command for event failed",
+			slog.String("command", c.args.exec),
+			slog.String("event_id", event.GetId()),
+			slog.Any("error", err),
+		)
+		c.console.Printf(ctx, "Command for event '%s' failed: %v\n\n", event.GetId(), err)
+	}
+}
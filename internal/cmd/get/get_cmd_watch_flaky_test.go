@@ -0,0 +1,261 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Watch with flaky streams", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		buffer *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+	})
+
+	// clusterEvent creates a scenario event for the given cluster.
+	clusterEvent := func(eventId string, eventType eventsv1.EventType, clusterId string,
+		state ffv1.ClusterState) *testing.ScenarioEvent {
+		return &testing.ScenarioEvent{
+			ID:   eventId,
+			Type: eventType,
+			Cluster: &testing.ClusterEventData{
+				ID:    clusterId,
+				State: state,
+			},
+		}
+	}
+
+	// failure creates a scenario event that interrupts the stream with the given code.
+	failure := func(code codes.Code, times int) *testing.ScenarioEvent {
+		return &testing.ScenarioEvent{
+			Failure: &testing.ScenarioFailure{
+				Code:    code,
+				Message: "simulated failure",
+				Times:   times,
+			},
+		}
+	}
+
+	// makeRunner starts a server that uses the given scenario and returns a runner connected to it.
+	makeRunner := func(scenario *testing.EventScenario, replay bool) *runnerContext {
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		eventsv1.RegisterEventsServer(
+			server.Registrar(),
+			testing.NewMockEventsServerBuilder().
+				WithScenario(scenario).
+				WithReplay(replay).
+				Build(),
+		)
+		server.Start()
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+		helper, err := reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(conn).
+			AddPackage("fulfillment.v1", 0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		buffer = gbytes.NewBuffer()
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		runner := &runnerContext{
+			logger:         logger,
+			conn:           conn,
+			globalHelper:   helper,
+			objectHelper:   helper.Lookup("cluster"),
+			console:        console,
+			reconnectDelay: 10 * time.Millisecond,
+		}
+		runner.args.format = rendering.FormatTable
+		runner.args.watch = true
+		runner.args.watchOnly = true
+		runner.args.reconnect = true
+		return runner
+	}
+
+	// startWatch runs the watch in the background and returns the channel where the result will be sent.
+	startWatch := func(runner *runnerContext) chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- runner.watch(ctx, []string{})
+		}()
+		return done
+	}
+
+	// lifecycle is a scenario where the stream fails once after the first event, and twice after the second one.
+	lifecycle := func() *testing.EventScenario {
+		return &testing.EventScenario{
+			Name: "flaky-lifecycle",
+			Events: []*testing.ScenarioEvent{
+				clusterEvent(
+					"event-1", eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+					"cluster-1", ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+				),
+				failure(codes.Unavailable, 1),
+				clusterEvent(
+					"event-2", eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+					"cluster-1", ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+				),
+				failure(codes.Internal, 2),
+				clusterEvent(
+					"event-3", eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+					"cluster-1", ffv1.ClusterState_CLUSTER_STATE_READY,
+				),
+			},
+		}
+	}
+
+	DescribeTable(
+		"Displays each event once after repeated failures",
+		func(replay bool) {
+			runner := makeRunner(lifecycle(), replay)
+			done := startWatch(runner)
+			Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-1'"))
+			Eventually(buffer).Should(gbytes.Say("Connection lost \\(Unavailable\\), reconnecting"))
+			Eventually(buffer).Should(gbytes.Say("Reconnected"))
+			Eventually(buffer).Should(gbytes.Say("UPDATED cluster 'cluster-1'"))
+			Eventually(buffer).Should(gbytes.Say("Connection lost \\(Internal\\), reconnecting"))
+			Eventually(buffer).Should(gbytes.Say("Connection lost \\(Internal\\), reconnecting"))
+			Eventually(buffer).Should(gbytes.Say("UPDATED cluster 'cluster-1'"))
+			Eventually(buffer).Should(gbytes.Say("READY"))
+			cancel()
+			Eventually(done).Should(Receive())
+			output := string(buffer.Contents())
+			Expect(strings.Count(output, "CREATED cluster 'cluster-1'")).To(Equal(1))
+			Expect(strings.Count(output, "UPDATED cluster 'cluster-1'")).To(Equal(2))
+			Expect(strings.Count(output, "Connection lost")).To(Equal(3))
+		},
+		Entry("When the server sends again the previous events", true),
+		Entry("When the server continues where it failed", false),
+	)
+
+	It("Waits for delayed events", func() {
+		delayed := clusterEvent(
+			"event-2", eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+			"cluster-1", ffv1.ClusterState_CLUSTER_STATE_READY,
+		)
+		delayed.Delay = 300 * time.Millisecond
+		runner := makeRunner(&testing.EventScenario{
+			Name: "delayed",
+			Events: []*testing.ScenarioEvent{
+				clusterEvent(
+					"event-1", eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+					"cluster-1", ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+				),
+				delayed,
+			},
+		}, false)
+		done := startWatch(runner)
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-1'"))
+		Consistently(buffer, 100*time.Millisecond).ShouldNot(gbytes.Say("UPDATED"))
+		Eventually(buffer).Should(gbytes.Say("UPDATED cluster 'cluster-1'"))
+		cancel()
+		Eventually(done).Should(Receive())
+	})
+
+	It("Fails without reconnecting when the error isn't transient", func() {
+		runner := makeRunner(&testing.EventScenario{
+			Name: "denied",
+			Events: []*testing.ScenarioEvent{
+				clusterEvent(
+					"event-1", eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+					"cluster-1", ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+				),
+				failure(codes.PermissionDenied, 1),
+			},
+		}, false)
+		var err error
+		Eventually(startWatch(runner)).Should(Receive(&err))
+		Expect(grpcstatus.Code(err)).To(Equal(codes.PermissionDenied))
+		Expect(string(buffer.Contents())).To(ContainSubstring("CREATED cluster 'cluster-1'"))
+		Expect(string(buffer.Contents())).ToNot(ContainSubstring("Connection lost"))
+	})
+
+	It("Runs the command only once for each event", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "events.txt")
+		runner := makeRunner(lifecycle(), true)
+		runner.args.exec = fmt.Sprintf(
+			`echo "$FULFILLMENT_EVENT_ID $FULFILLMENT_EVENT_TYPE $FULFILLMENT_OBJECT_TYPE $FULFILLMENT_OBJECT_ID" >> %s`,
+			file,
+		)
+		done := startWatch(runner)
+		Eventually(buffer).Should(gbytes.Say("READY"))
+		Eventually(func() ([]byte, error) {
+			return os.ReadFile(file)
+		}).Should(ContainSubstring("event-3"))
+		cancel()
+		Eventually(done).Should(Receive())
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(
+			"event-1 created cluster cluster-1\n" +
+				"event-2 updated cluster cluster-1\n" +
+				"event-3 updated cluster cluster-1\n",
+		))
+	})
+
+	It("Passes the event to the command in the standard input", func() {
+		runner := makeRunner(lifecycle(), false)
+		runner.args.exec = "cat; echo"
+		done := startWatch(runner)
+		Eventually(buffer).Should(gbytes.Say(`"id":\s*"event-1"`))
+		cancel()
+		Eventually(done).Should(Receive())
+	})
+
+	It("Doesn't pass the variables of the tool to the command", func() {
+		GinkgoT().Setenv("FULFILLMENT_CLI_PASSPHRASE", "secret")
+		runner := makeRunner(lifecycle(), false)
+		runner.args.exec = `echo "passphrase=${FULFILLMENT_CLI_PASSPHRASE:-none} event=$FULFILLMENT_EVENT_ID"`
+		done := startWatch(runner)
+		Eventually(buffer).Should(gbytes.Say("passphrase=none event=event-1"))
+		cancel()
+		Eventually(done).Should(Receive())
+	})
+})
@@ -0,0 +1,235 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package get
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Watch reconnection", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		calls  atomic.Int32
+		lists  atomic.Int32
+		listed func(call int32) []*ffv1.Cluster
+		buffer *gbytes.Buffer
+		runner *runnerContext
+	)
+
+	// makeEvent creates a cluster creation event with the given event and cluster identifiers.
+	makeEvent := func(eventId, clusterId string) *eventsv1.EventsWatchResponse {
+		return &eventsv1.EventsWatchResponse{
+			Event: &eventsv1.Event{
+				Id:   eventId,
+				Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				Payload: &eventsv1.Event_Cluster{
+					Cluster: &ffv1.Cluster{
+						Id: clusterId,
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+
+		// Create cancellable context:
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		// Create a server that fails the first stream after sending one event, and then sends again that event
+		// followed by a new one:
+		calls.Store(0)
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		eventsv1.RegisterEventsServer(server.Registrar(), &testing.EventsServerFuncs{
+			WatchFunc: func(request *eventsv1.EventsWatchRequest, stream eventsv1.Events_WatchServer) error {
+				call := calls.Add(1)
+				err := stream.Send(makeEvent("event-1", "cluster-1"))
+				if err != nil {
+					return err
+				}
+				if call == 1 {
+					return grpcstatus.Error(codes.Unavailable, "server is restarting")
+				}
+				err = stream.Send(makeEvent("event-2", "cluster-2"))
+				if err != nil {
+					return err
+				}
+				<-stream.Context().Done()
+				return stream.Context().Err()
+			},
+		})
+
+		// By default the list of clusters contains the clusters created by the events, so there are no changes to
+		// find after reconnecting:
+		lists.Store(0)
+		listed = func(call int32) []*ffv1.Cluster {
+			return []*ffv1.Cluster{
+				{Id: "cluster-1"},
+				{Id: "cluster-2"},
+			}
+		}
+		ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+			ListFunc: func(ctx context.Context, request *ffv1.ClustersListRequest) (*ffv1.ClustersListResponse,
+				error) {
+				items := listed(lists.Add(1))
+				size := int32(len(items))
+				return &ffv1.ClustersListResponse{Items: items, Size: &size, Total: &size}, nil
+			},
+		})
+		server.Start()
+
+		// Create the client connection:
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		// Create the reflection helper:
+		helper, err := reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(conn).
+			AddPackage("fulfillment.v1", 0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Create a console that writes to a buffer, so that we can check the output:
+		buffer = gbytes.NewBuffer()
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Create the runner:
+		runner = &runnerContext{
+			logger:         logger,
+			conn:           conn,
+			globalHelper:   helper,
+			objectHelper:   helper.Lookup("cluster"),
+			console:        console,
+			reconnectDelay: 10 * time.Millisecond,
+		}
+		runner.args.format = rendering.FormatTable
+		runner.args.watch = true
+		runner.args.watchOnly = true
+	})
+
+	It("Reconnects and skips the events already received", func() {
+		runner.args.reconnect = true
+		done := make(chan error, 1)
+		go func() {
+			done <- runner.watch(ctx, []string{})
+		}()
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-1'"))
+		Eventually(buffer).Should(gbytes.Say("Connection lost \\(Unavailable\\), reconnecting"))
+		Eventually(buffer).Should(gbytes.Say("Reconnected"))
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-2'"))
+		Expect(calls.Load()).To(BeNumerically("==", 2))
+		cancel()
+		Eventually(done).Should(Receive())
+		Expect(strings.Count(string(buffer.Contents()), "CREATED cluster 'cluster-1'")).To(Equal(1))
+	})
+
+	It("Fails without reconnecting if disabled", func() {
+		runner.args.reconnect = false
+		err := runner.watch(ctx, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(grpcstatus.Code(err)).To(Equal(codes.Unavailable))
+		Expect(calls.Load()).To(BeNumerically("==", 1))
+	})
+
+	It("Skips the events until the one to resume from", func() {
+		runner.args.reconnect = true
+		runner.args.resumeFrom = "event-1"
+		done := make(chan error, 1)
+		go func() {
+			done <- runner.watch(ctx, []string{})
+		}()
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-2'"))
+		cancel()
+		Eventually(done).Should(Receive())
+		Expect(string(buffer.Contents())).ToNot(ContainSubstring("CREATED cluster 'cluster-1'"))
+		Expect(string(buffer.Contents())).ToNot(ContainSubstring("Warning"))
+	})
+
+	It("Stops skipping if the first event isn't the one to resume from", func() {
+		runner.args.reconnect = true
+		runner.args.resumeFrom = "event-0"
+		done := make(chan error, 1)
+		go func() {
+			done <- runner.watch(ctx, []string{})
+		}()
+		Eventually(buffer).Should(gbytes.Say("Warning: the first event received isn't 'event-0'"))
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-1'"))
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-2'"))
+		cancel()
+		Eventually(done).Should(Receive())
+	})
+
+	It("Displays the changes made while disconnected", func() {
+		runner.args.reconnect = true
+		listed = func(call int32) []*ffv1.Cluster {
+			if call == 1 {
+				return []*ffv1.Cluster{
+					{Id: "cluster-0"},
+				}
+			}
+			return []*ffv1.Cluster{
+				{
+					Id: "cluster-1",
+					Status: &ffv1.ClusterStatus{
+						State: ffv1.ClusterState_CLUSTER_STATE_READY,
+					},
+				},
+				{Id: "cluster-3"},
+			}
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- runner.watch(ctx, []string{})
+		}()
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-1'"))
+		Eventually(buffer).Should(gbytes.Say("Reconnected"))
+		Eventually(buffer).Should(gbytes.Say("UPDATED cluster 'cluster-1'"))
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-3'"))
+		Eventually(buffer).Should(gbytes.Say("DELETED cluster 'cluster-0'"))
+		Eventually(buffer).Should(gbytes.Say("CREATED cluster 'cluster-2'"))
+		cancel()
+		Eventually(done).Should(Receive())
+	})
+})
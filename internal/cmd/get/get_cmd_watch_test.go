@@ -61,6 +61,40 @@ var _ = Describe("Watch command", func() {
 		),
 	)
 
+	DescribeTable("parseEventTypes",
+		func(names []string, expected []eventsv1.EventType) {
+			actual, err := parseEventTypes(names)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+		Entry("single type",
+			[]string{"deleted"},
+			[]eventsv1.EventType{eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED},
+		),
+		Entry("multiple types",
+			[]string{"created", "deleted"},
+			[]eventsv1.EventType{
+				eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED,
+			},
+		),
+		Entry("ignores case and spaces",
+			[]string{" Updated "},
+			[]eventsv1.EventType{eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED},
+		),
+		Entry("removes duplicates",
+			[]string{"created", "created"},
+			[]eventsv1.EventType{eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED},
+		),
+	)
+
+	It("parseEventTypes rejects unknown types", func() {
+		_, err := parseEventTypes([]string{"created", "junk"})
+		Expect(err).To(MatchError(
+			"unknown event type 'junk', should be 'created', 'updated' or 'deleted'",
+		))
+	})
+
 	DescribeTable("buildEventFilter",
 		func(objectType string, keys []string, expectedFilter string) {
 			// This would require creating a mock helper, which we'll skip for now
@@ -72,4 +106,29 @@ var _ = Describe("Watch command", func() {
 		Entry("cluster with no keys", "cluster", []string{}, "has(event.cluster)"),
 		Entry("cluster with specific ID", "cluster", []string{"123"}, "has(event.cluster) && (event.cluster.id == \"123\" || event.cluster.metadata.name == \"123\")"),
 	)
+
+	It("eventHistory discards the oldest identifiers", func() {
+		history := newEventHistory(2)
+		history.add("a")
+		history.add("b")
+		Expect(history.contains("a")).To(BeTrue())
+		history.add("c")
+		Expect(history.contains("a")).To(BeFalse())
+		Expect(history.contains("b")).To(BeTrue())
+		Expect(history.contains("c")).To(BeTrue())
+		history.add("d")
+		Expect(history.contains("b")).To(BeFalse())
+		Expect(history.contains("d")).To(BeTrue())
+	})
+
+	It("eventHistory keeps the recently seen identifiers", func() {
+		history := newEventHistory(2)
+		Expect(history.seen("a")).To(BeFalse())
+		Expect(history.seen("b")).To(BeFalse())
+		Expect(history.seen("a")).To(BeTrue())
+		Expect(history.seen("c")).To(BeFalse())
+		Expect(history.contains("a")).To(BeTrue())
+		Expect(history.contains("b")).To(BeFalse())
+		Expect(history.contains("c")).To(BeTrue())
+	})
 })
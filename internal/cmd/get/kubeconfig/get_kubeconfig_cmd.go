@@ -14,11 +14,15 @@ language governing permissions and limitations under the License.
 package kubeconfig
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"slices"
 	"sort"
+	"strings"
 
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	"github.com/osac-project/fulfillment-common/logging"
@@ -28,8 +32,11 @@ import (
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v3"
 
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/kubeconfig"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
@@ -39,9 +46,10 @@ var templatesFS embed.FS
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:   "kubeconfig [CLUSTER] [OPTION]...",
-		Short: "Get kubeconfig",
-		RunE:  runner.run,
+		Use:               "kubeconfig [CLUSTER] [OPTION]...",
+		Short:             "Get kubeconfig",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectsOf((*ffv1.Cluster)(nil), 1),
 	}
 	flags := result.Flags()
 	flags.StringVar(
@@ -51,6 +59,28 @@ func Cmd() *cobra.Command {
 		"Name or identifier of the cluster.",
 	)
 	flags.MarkDeprecated("cluster", "use positional argument instead.\n")
+	flags.StringVar(
+		&runner.args.outputFile,
+		"output-file",
+		"",
+		"Write the kubeconfig to this file instead of the standard output. When used with '--merge' the "+
+			"default is the first file in the 'KUBECONFIG' environment variable, or '~/.kube/config'.",
+	)
+	flags.BoolVar(
+		&runner.args.merge,
+		"merge",
+		false,
+		"Merge the kubeconfig into the output file, adding a context named after the cluster and making it "+
+			"the current context, instead of replacing the file.",
+	)
+	flags.BoolVar(
+		&runner.args.execCredential,
+		"exec-credential",
+		false,
+		"Write an 'ExecCredential' document containing the credentials of the cluster instead of the "+
+			"kubeconfig, so that the command can be used as a 'kubectl' credential plugin.",
+	)
+	refs.AddFlags(flags)
 	return result
 }
 
@@ -59,8 +89,12 @@ type runnerContext struct {
 	flags   *pflag.FlagSet
 	console *terminal.Console
 	conn    *grpc.ClientConn
+	refMode refs.Mode
 	args    struct {
-		key string
+		key            string
+		outputFile     string
+		merge          bool
+		execCredential bool
 	}
 }
 
@@ -83,6 +117,11 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Get the flags:
 	c.flags = cmd.Flags()
 
+	// Check that the exec credential mode isn't combined with the options that write files:
+	if c.args.execCredential && (c.args.outputFile != "" || c.args.merge) {
+		return exit.Usagef("option '--exec-credential' can't be used together with '--output-file' or '--merge'")
+	}
+
 	// Get the configuration:
 	cfg, err := config.Load(ctx)
 	if err != nil {
@@ -108,32 +147,32 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Check the flags:
 	if key == "" {
 		c.console.Render(ctx, "no_key.txt", nil)
-		return exit.Error(1)
+		return exit.Usage
+	}
+	c.refMode, err = refs.ModeFromFlags(c.flags)
+	if err != nil {
+		return err
 	}
 
 	// Try to find a cluster that has an identifier or name matching the given identifier:
 	client := ffv1.NewClustersClient(c.conn)
-	listFilter := fmt.Sprintf(
-		"this.id == %[1]q || this.metadata.name == %[1]q",
-		key,
-	)
 	listResponse, err := client.List(ctx, ffv1.ClustersListRequest_builder{
-		Filter: proto.String(listFilter),
+		Filter: proto.String(c.refMode.Filter(key)),
 		Limit:  proto.Int32(10),
 	}.Build())
 	if err != nil {
 		return fmt.Errorf("failed to list clusters: %w", err)
 	}
 	total := listResponse.GetTotal()
-	clusters := listResponse.GetItems()
+	clusters := refs.Resolve(c.refMode, key, listResponse.GetItems(), (*ffv1.Cluster).GetId, clusterName)
 	var cluster *ffv1.Cluster
-	switch {
-	case total == 0:
+	switch len(clusters) {
+	case 0:
 		c.console.Render(ctx, "no_match.txt", map[string]any{
 			"Key": key,
 		})
-		return exit.Error(1)
-	case total == 1:
+		return exit.NotFound
+	case 1:
 		cluster = clusters[0]
 	default:
 		ids := make([]string, len(clusters))
@@ -147,7 +186,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 			"Key":   key,
 			"Total": total,
 		})
-		return exit.Error(1)
+		return exit.General
 	}
 
 	// Get the kubeconfig:
@@ -158,6 +197,17 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	kcText := getKubeconfigResponse.GetKubeconfig()
+
+	// Write the exec credential if requested:
+	if c.args.execCredential {
+		return c.writeExecCredential(ctx, cfg, kcText)
+	}
+
+	// Write or merge to the output file if requested:
+	if c.args.outputFile != "" || c.args.merge {
+		return c.writeFile(ctx, cluster, kcText)
+	}
+
 	var kcYaml any
 	err = yaml.Unmarshal([]byte(kcText), &kcYaml)
 	if err != nil {
@@ -173,3 +223,122 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// writeExecCredential writes the exec credential document containing the credentials of the kubeconfig. When the
+// kubeconfig doesn't contain static credentials the access token of the CLI is used instead, assuming that the cluster
+// trusts the same token issuer than the fulfillment service.
+func (c *runnerContext) writeExecCredential(ctx context.Context, cfg *config.Config, kcText string) error {
+	source, err := kubeconfig.Parse([]byte(kcText))
+	if err != nil {
+		return err
+	}
+	credential, err := source.ExecCredential()
+	if err != nil {
+		return err
+	}
+	if credential == nil {
+		c.logger.DebugContext(
+			ctx,
+			"Kubeconfig doesn't contain static credentials, will use the access token",
+		)
+		credential, err = kubeconfig.NewExecCredential()
+		if err != nil {
+			return err
+		}
+		tokenSource, err := cfg.TokenSource(ctx)
+		if err != nil {
+			return err
+		}
+		if tokenSource == nil {
+			return fmt.Errorf("the kubeconfig doesn't contain credentials, and there is no access token")
+		}
+		token, err := tokenSource.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get access token: %w", err)
+		}
+		credential.Status.Token = token.Access
+		credential.SetExpiration(token.Expiry)
+	}
+	data, err := credential.Marshal()
+	if err != nil {
+		return err
+	}
+	c.console.Printf(ctx, "%s\n", data)
+	return nil
+}
+
+// writeFile writes the kubeconfig to the output file, or merges it into that file if requested.
+func (c *runnerContext) writeFile(ctx context.Context, cluster *ffv1.Cluster, kcText string) error {
+	// Calculate the name of the file, expanding the home directory if needed, as that isn't done by the shell when
+	// the flag is used like '--output-file=~/.kube/config':
+	file := c.args.outputFile
+	if file == "" {
+		var err error
+		file, err = kubeconfig.DefaultFile()
+		if err != nil {
+			return err
+		}
+	}
+	if strings.HasPrefix(file, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to find home directory: %w", err)
+		}
+		file = filepath.Join(home, file[2:])
+	}
+
+	// If merge isn't requested then just replace the file:
+	if !c.args.merge {
+		err := kubeconfig.Save(file, []byte(kcText))
+		if err != nil {
+			return err
+		}
+		c.console.Render(ctx, "file_written.txt", map[string]any{
+			"File": file,
+		})
+		return nil
+	}
+
+	// Merge the new configuration into the existing file, using the name of the cluster as the name of the
+	// context, or the identifier if it has no name:
+	name := cluster.GetMetadata().GetName()
+	if name == "" {
+		name = cluster.GetId()
+	}
+	source, err := kubeconfig.Parse([]byte(kcText))
+	if err != nil {
+		return err
+	}
+	target, err := kubeconfig.Load(file)
+	if err != nil {
+		return err
+	}
+	err = target.Merge(source, name)
+	if err != nil {
+		return fmt.Errorf("failed to merge kubeconfig: %w", err)
+	}
+	data, err := target.Marshal()
+	if err != nil {
+		return err
+	}
+	err = kubeconfig.Save(file, data)
+	if err != nil {
+		return err
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Merged kubeconfig",
+		slog.String("file", file),
+		slog.String("context", name),
+	)
+	c.console.Render(ctx, "file_merged.txt", map[string]any{
+		"Context": name,
+		"File":    file,
+	})
+	return nil
+}
+
+// clusterName returns the name of the given cluster.
+func clusterName(cluster *ffv1.Cluster) string {
+	return cluster.GetMetadata().GetName()
+}
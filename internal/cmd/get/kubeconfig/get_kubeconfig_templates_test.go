@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		err = checker.Check(consoletest.Cases{
+			"file_merged.txt": {
+				map[string]any{
+					"Context": "my",
+					"File":    "/home/me/.kube/config",
+				},
+			},
+			"file_written.txt": {
+				map[string]any{
+					"File": "/home/me/.kube/config",
+				},
+			},
+			"multiple_matches.txt": {
+				map[string]any{
+					"Ids":   []string{"123", "456"},
+					"Key":   "my",
+					"Total": int32(2),
+				},
+				map[string]any{
+					"Ids":   []string{"123", "456"},
+					"Key":   "my",
+					"Total": int32(10),
+				},
+			},
+			"no_key.txt": {
+				nil,
+			},
+			"no_match.txt": {
+				map[string]any{
+					"Key": "my",
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -14,11 +14,13 @@ language governing permissions and limitations under the License.
 package password
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"log/slog"
 	"slices"
 	"sort"
+	"strings"
 
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	"github.com/osac-project/fulfillment-common/logging"
@@ -27,8 +29,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/osac-project/fulfillment-cli/internal/completion"
 	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
@@ -38,9 +42,16 @@ var templatesFS embed.FS
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:   "password [CLUSTER] [OPTION]...",
+		Use:   "password [cluster|computeinstance] NAME [OPTION]...",
 		Short: "Get password",
-		RunE:  runner.run,
+		Long: "Get the password of the administrator of a cluster. The object type is optional, the default is " +
+			"'cluster'. Compute instances don't have passwords, they are accessed with the SSH key given when " +
+			"they are created, which can be retrieved with the 'get sshkey' command.",
+		Example: "  # Get the password of a cluster:\n" +
+			"  fulfillment-cli get password my-cluster",
+		Args:              cobra.MaximumNArgs(2),
+		RunE:              runner.run,
+		ValidArgsFunction: completeArgs,
 	}
 	flags := result.Flags()
 	flags.StringVar(
@@ -50,6 +61,7 @@ func Cmd() *cobra.Command {
 		"Name or identifier of the cluster.",
 	)
 	flags.MarkDeprecated("cluster", "use positional argument instead.\n")
+	refs.AddFlags(flags)
 	return result
 }
 
@@ -58,6 +70,7 @@ type runnerContext struct {
 	flags   *pflag.FlagSet
 	console *terminal.Console
 	conn    *grpc.ClientConn
+	refMode refs.Mode
 	args    struct {
 		key string
 	}
@@ -82,6 +95,35 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Get the flags:
 	c.flags = cmd.Flags()
 
+	// Get the object type, which is optional, and the name or identifier: from the flag if provided, otherwise from
+	// the positional arguments.
+	objectType := objectTypeCluster
+	key := c.args.key
+	switch {
+	case len(args) == 2:
+		objectType = normalizeObjectType(args[0])
+		key = args[1]
+	case len(args) == 1 && key == "":
+		key = args[0]
+	}
+	switch objectType {
+	case objectTypeCluster:
+	case objectTypeComputeInstance:
+		c.console.Render(ctx, "compute_instance.txt", map[string]any{
+			"Key": key,
+		})
+		return exit.Usage
+	default:
+		return exit.Usagef(
+			"object type should be '%s' or '%s', but it is '%s'",
+			objectTypeCluster, objectTypeComputeInstance, args[0],
+		)
+	}
+	if key == "" {
+		c.console.Render(ctx, "no_key.txt", nil)
+		return exit.Usage
+	}
+
 	// Get the configuration:
 	cfg, err := config.Load(ctx)
 	if err != nil {
@@ -98,43 +140,37 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 	defer c.conn.Close()
 
-	// Get the cluster name or identifier: from the flag if provided, otherwise from the first positional argument.
-	key := c.args.key
-	if key == "" && len(args) > 0 {
-		key = args[0]
-	}
-
 	// Check the flags:
-	if key == "" {
-		c.console.Render(ctx, "no_key.txt", nil)
-		return exit.Error(1)
+	c.refMode, err = refs.ModeFromFlags(c.flags)
+	if err != nil {
+		return err
 	}
 
 	// Try to find a cluster that has an identifier or name matching the given identifier:
 	client := ffv1.NewClustersClient(c.conn)
-	listFilter := fmt.Sprintf(
-		"this.id == %[1]q || this.metadata.name == %[1]q",
-		key,
-	)
 	listResponse, err := client.List(ctx, ffv1.ClustersListRequest_builder{
-		Filter: proto.String(listFilter),
+		Filter: proto.String(c.refMode.Filter(key)),
 		Limit:  proto.Int32(10),
 	}.Build())
 	if err != nil {
 		return fmt.Errorf("failed to list clusters: %w", err)
 	}
 	total := listResponse.GetTotal()
-	clusters := listResponse.GetItems()
+	clusters := refs.Resolve(c.refMode, key, listResponse.GetItems(), (*ffv1.Cluster).GetId, clusterName)
 	var cluster *ffv1.Cluster
-	switch {
-	case total == 0:
+	switch len(clusters) {
+	case 0:
 		c.console.Render(ctx, "no_match.txt", map[string]any{
 			"Key": key,
 		})
-		return exit.Error(1)
-	case total == 1:
+		return exit.NotFound
+	case 1:
 		cluster = clusters[0]
 	default:
+		cluster = c.pickCluster(ctx, key, clusters)
+		if cluster != nil {
+			break
+		}
 		ids := make([]string, len(clusters))
 		for i, cluster := range clusters {
 			ids[i] = cluster.GetId()
@@ -146,7 +182,7 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 			"Key":   key,
 			"Total": total,
 		})
-		return exit.Error(1)
+		return exit.General
 	}
 
 	// Get the password:
@@ -160,3 +196,56 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// pickCluster asks the user to select one of the clusters that match an ambiguous reference, when the console is a
+// terminal. It returns nil if that isn't possible or if the user cancels the selection.
+func (c *runnerContext) pickCluster(ctx context.Context, key string, clusters []*ffv1.Cluster) *ffv1.Cluster {
+	result, _ := refs.Pick(
+		ctx,
+		c.console,
+		fmt.Sprintf("Name or identifier '%s' is ambiguous, select the cluster", key),
+		[]string{"ID", "NAME", "STATE"},
+		clusters,
+		func(cluster *ffv1.Cluster) []string {
+			return []string{
+				cluster.GetId(),
+				clusterName(cluster),
+				strings.TrimPrefix(cluster.GetStatus().GetState().String(), "CLUSTER_STATE_"),
+			}
+		},
+	)
+	return result
+}
+
+// Object types supported by the command:
+const (
+	objectTypeCluster         = "cluster"
+	objectTypeComputeInstance = "computeinstance"
+)
+
+// normalizeObjectType converts the plural forms of the object types to the singular.
+func normalizeObjectType(value string) string {
+	switch strings.ToLower(value) {
+	case "cluster", "clusters":
+		return objectTypeCluster
+	case "computeinstance", "computeinstances":
+		return objectTypeComputeInstance
+	default:
+		return value
+	}
+}
+
+// completeArgs completes the names of the clusters, or the names of the compute instances when the first argument is
+// that object type.
+func completeArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion,
+	cobra.ShellCompDirective) {
+	if len(args) == 1 && normalizeObjectType(args[0]) == objectTypeComputeInstance {
+		return completion.ObjectsOf((*ffv1.ComputeInstance)(nil), 2)(cmd, args, toComplete)
+	}
+	return completion.ObjectsOf((*ffv1.Cluster)(nil), 1)(cmd, args, toComplete)
+}
+
+// clusterName returns the name of the given cluster.
+func clusterName(cluster *ffv1.Cluster) string {
+	return cluster.GetMetadata().GetName()
+}
@@ -0,0 +1,225 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package sshkey
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "sshkey [computeinstance] NAME [OPTION]...",
+		Aliases: []string{"ssh-key"},
+		Short:   "Get SSH key",
+		Long: "Get the public SSH key that was given when a compute instance was created, and that grants access " +
+			"to it. The object type is optional, compute instances are the only objects that have SSH keys.",
+		Example: "  # Get the SSH key of a compute instance:\n" +
+			"  fulfillment-cli get sshkey my-instance\n\n" +
+			"  # Check which of the local keys grants access to the compute instance:\n" +
+			"  fulfillment-cli get sshkey my-instance | ssh-keygen -l -f -",
+		Args:              cobra.MaximumNArgs(2),
+		RunE:              runner.run,
+		ValidArgsFunction: completeArgs,
+	}
+	flags := result.Flags()
+	refs.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	flags   *pflag.FlagSet
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	refMode refs.Mode
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and flags:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the flags:
+	c.flags = cmd.Flags()
+
+	// Get the name or identifier, optionally preceded by the object type:
+	var key string
+	switch len(args) {
+	case 2:
+		if !isComputeInstanceType(args[0]) {
+			return exit.Usagef("object type should be 'computeinstance', but it is '%s'", args[0])
+		}
+		key = args[1]
+	case 1:
+		key = args[0]
+	}
+	if key == "" {
+		c.console.Render(ctx, "no_key.txt", nil)
+		return exit.Usage
+	}
+	c.refMode, err = refs.ModeFromFlags(c.flags)
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, c.flags)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Try to find a compute instance that has an identifier or name matching the given key:
+	client := ffv1.NewComputeInstancesClient(c.conn)
+	listResponse, err := client.List(ctx, ffv1.ComputeInstancesListRequest_builder{
+		Filter: proto.String(c.refMode.Filter(key)),
+		Limit:  proto.Int32(10),
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to list compute instances: %w", err)
+	}
+	total := listResponse.GetTotal()
+	instances := refs.Resolve(
+		c.refMode, key, listResponse.GetItems(),
+		(*ffv1.ComputeInstance).GetId, instanceName,
+	)
+	var instance *ffv1.ComputeInstance
+	switch len(instances) {
+	case 0:
+		c.console.Render(ctx, "no_match.txt", map[string]any{
+			"Key": key,
+		})
+		return exit.NotFound
+	case 1:
+		instance = instances[0]
+	default:
+		instance = c.pickInstance(ctx, key, instances)
+		if instance != nil {
+			break
+		}
+		ids := make([]string, len(instances))
+		for i, instance := range instances {
+			ids[i] = instance.GetId()
+		}
+		sort.Strings(ids)
+		ids = slices.Compact(ids)
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Ids":   ids,
+			"Key":   key,
+			"Total": total,
+		})
+		return exit.General
+	}
+
+	// Write the key:
+	sshKey := strings.TrimSpace(instance.GetSpec().GetSshKey())
+	if sshKey == "" {
+		c.console.Render(ctx, "no_ssh_key.txt", map[string]any{
+			"Id": instance.GetId(),
+		})
+		return exit.NotFound
+	}
+	fmt.Printf("%s\n", sshKey)
+
+	return nil
+}
+
+// pickInstance asks the user to select one of the compute instances that match an ambiguous reference, when the
+// console is a terminal. It returns nil if that isn't possible or if the user cancels the selection.
+func (c *runnerContext) pickInstance(ctx context.Context, key string,
+	instances []*ffv1.ComputeInstance) *ffv1.ComputeInstance {
+	result, _ := refs.Pick(
+		ctx,
+		c.console,
+		fmt.Sprintf("Name or identifier '%s' is ambiguous, select the compute instance", key),
+		[]string{"ID", "NAME", "STATE", "IP ADDRESS"},
+		instances,
+		func(instance *ffv1.ComputeInstance) []string {
+			return []string{
+				instance.GetId(),
+				instanceName(instance),
+				strings.TrimPrefix(instance.GetStatus().GetState().String(), "COMPUTE_INSTANCE_STATE_"),
+				instance.GetStatus().GetIpAddress(),
+			}
+		},
+	)
+	return result
+}
+
+// instanceName returns the name of the given compute instance.
+func instanceName(instance *ffv1.ComputeInstance) string {
+	return instance.GetMetadata().GetName()
+}
+
+// isComputeInstanceType checks if the given text is one of the names of the compute instance type.
+func isComputeInstanceType(value string) bool {
+	switch strings.ToLower(value) {
+	case "computeinstance", "computeinstances":
+		return true
+	default:
+		return false
+	}
+}
+
+// completeArgs completes the names of the compute instances, also after the optional object type.
+func completeArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion,
+	cobra.ShellCompDirective) {
+	if len(args) == 1 && isComputeInstanceType(args[0]) {
+		return completion.ObjectsOf((*ffv1.ComputeInstance)(nil), 2)(cmd, args, toComplete)
+	}
+	return completion.ObjectsOf((*ffv1.ComputeInstance)(nil), 1)(cmd, args, toComplete)
+}
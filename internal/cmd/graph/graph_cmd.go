@@ -0,0 +1,294 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/relations"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Supported graph formats:
+const (
+	formatDot     = "dot"
+	formatMermaid = "mermaid"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "graph OBJECT ID|NAME...",
+		Short: "Export the graph of related objects",
+		Long: "Export a graph of the relationships between the given objects and the objects that they reference, " +
+			"like the node sets of a cluster, the host pools that provide them and the hosts of those pools. The " +
+			"graph is written as a Graphviz DOT document, or as a Mermaid diagram, that can be used in " +
+			"documentation and incident reviews.",
+		Example: "  # Write the graph of a cluster to a DOT file, and convert it to an image:\n" +
+			"  fulfillment-cli graph cluster my-cluster --out graph.dot\n" +
+			"  dot -Tsvg graph.dot > graph.svg\n\n" +
+			"  # Write the graph of a host pool as a Mermaid diagram:\n" +
+			"  fulfillment-cli graph hostpool my-pool --format mermaid",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.args.out,
+		"out",
+		"",
+		"File where the graph will be written. If not specified it will be written to the standard output.",
+	)
+	flags.StringVar(
+		&runner.args.format,
+		"format",
+		"",
+		fmt.Sprintf(
+			"Format of the graph, '%s' or '%s'. The default is '%s' when the file given with '--out' has the "+
+				"'.mmd' or '.mermaid' extension, and '%s' otherwise.",
+			formatDot, formatMermaid, formatMermaid, formatDot,
+		),
+	)
+	flags.IntVar(
+		&runner.args.depth,
+		"depth",
+		relations.DefaultDepth,
+		"Number of levels of related objects to retrieve.",
+	)
+	refs.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		out    string
+		format string
+		depth  int
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	helper  *reflection.ObjectHelper
+	refMode refs.Mode
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and the console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Check the flags:
+	format := c.args.format
+	if format == "" {
+		format = formatDot
+		switch strings.ToLower(filepath.Ext(c.args.out)) {
+		case ".mmd", ".mermaid":
+			format = formatMermaid
+		}
+	}
+	if format != formatDot && format != formatMermaid {
+		return exit.Usagef("unknown graph format '%s', should be '%s' or '%s'", format, formatDot, formatMermaid)
+	}
+	if c.args.depth < 1 {
+		return exit.Usagef("depth should be at least one, but it is %d", c.args.depth)
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+	c.console.SetHelper(helper)
+
+	// Check that the object type has been specified:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Helper": helper,
+		})
+		return exit.Usage
+	}
+
+	// Get the object helper:
+	c.helper = helper.Lookup(args[0])
+	if c.helper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Helper": helper,
+			"Object": args[0],
+		})
+		return exit.Usage
+	}
+
+	// Check that at least one identifier or name has been specified:
+	if len(args) < 2 {
+		c.console.Render(ctx, "no_id.txt", nil)
+		return exit.Usage
+	}
+
+	// Find the objects:
+	var objects []proto.Message
+	for _, ref := range args[1:] {
+		var object proto.Message
+		object, err = c.findObject(ctx, ref)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+
+	// Build the graph:
+	graph, err := relations.NewGraph().
+		SetLogger(c.logger).
+		SetHelper(helper).
+		SetMaxDepth(c.args.depth).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create graph: %w", err)
+	}
+	for _, object := range objects {
+		graph.Add(ctx, object)
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Built graph",
+		slog.Int("nodes", len(graph.Nodes())),
+		slog.Int("links", len(graph.Links())),
+	)
+
+	// In machine readable format write the nodes and links, as that is easier to process than the document:
+	if output.IsJson(ctx) && c.args.out == "" {
+		c.console.RenderJson(ctx, map[string]any{
+			"nodes": graph.Nodes(),
+			"links": graph.Links(),
+		})
+		return nil
+	}
+
+	// Generate the document:
+	var data []byte
+	switch format {
+	case formatMermaid:
+		data = writeMermaid(graph.Nodes(), graph.Links())
+	default:
+		data = writeDot(graph.Nodes(), graph.Links())
+	}
+
+	// Write the result:
+	if c.args.out == "" {
+		_, err = c.console.Write(data)
+		return err
+	}
+	err = os.WriteFile(c.args.out, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file '%s': %w", c.args.out, err)
+	}
+	c.console.Printf(
+		ctx,
+		"Wrote graph with %d objects and %d relationships to '%s'.\n",
+		len(graph.Nodes()), len(graph.Links()), c.args.out,
+	)
+	return nil
+}
+
+// findObject tries to find an object by identifier or name, preferring the object whose identifier matches exactly. If
+// there are no matches or multiple matches it explains the problem to the user and returns an error that contains the
+// exit code.
+func (c *runnerContext) findObject(ctx context.Context, ref string) (result proto.Message, err error) {
+	// Find the objects matching the reference (identifier or name):
+	filter := c.refMode.Filter(ref)
+	response, err := c.helper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+		Limit:  10,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find object of type '%s' with identifier or name '%s': %w", c.helper, ref, err)
+		return
+	}
+
+	// Prepare the response based on the number of objects found:
+	items := refs.Resolve(c.refMode, ref, response.Items, c.helper.GetId, c.helper.GetName)
+	switch len(items) {
+	case 0:
+		c.console.Render(ctx, "no_matches.txt", map[string]any{
+			"Object": c.helper.Singular(),
+			"Ref":    ref,
+		})
+		err = exit.NotFound
+	case 1:
+		result = items[0]
+	default:
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Matches": items,
+			"Object":  c.helper.Singular(),
+			"Ref":     ref,
+			"Total":   response.Total,
+		})
+		err = exit.General
+	}
+	return
+}
@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/osac-project/fulfillment-cli/internal/relations"
+)
+
+// nodeLines returns the lines of the label of a node: the kind, the name and the state or the problem, for example
+// 'cluster', 'my-cluster' and 'READY'.
+func nodeLines(node *relations.Node) []string {
+	if node.Group {
+		result := []string{fmt.Sprintf("%s %s", node.Kind, node.Name)}
+		return append(result, node.Details...)
+	}
+	result := []string{node.Kind, node.Name}
+	if node.State != "" {
+		result = append(result, node.State)
+	}
+	if node.Problem != "" {
+		result = append(result, node.Problem)
+	}
+	if node.Truncated {
+		result = append(result, "…")
+	}
+	return result
+}
+
+// writeDot generates the Graphviz DOT document for the given nodes and links. Groups are drawn as ellipses and the
+// objects that couldn't be retrieved with dashed lines.
+func writeDot(nodes []*relations.Node, links []*relations.Link) []byte {
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "digraph fulfillment {\n")
+	fmt.Fprintf(buffer, "  rankdir=LR;\n")
+	fmt.Fprintf(buffer, "  node [shape=box];\n")
+	for _, node := range nodes {
+		lines := nodeLines(node)
+		for i, line := range lines {
+			lines[i] = dotEscape(line)
+		}
+		var attrs []string
+		attrs = append(attrs, fmt.Sprintf(`label="%s"`, strings.Join(lines, `\n`)))
+		if node.Group {
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if node.Problem != "" {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(buffer, "  %s [%s];\n", node.Id, strings.Join(attrs, ", "))
+	}
+	for _, link := range links {
+		fmt.Fprintf(buffer, "  %s -> %s [label=\"%s\"];\n", link.From, link.To, dotEscape(link.Label))
+	}
+	fmt.Fprintf(buffer, "}\n")
+	return buffer.Bytes()
+}
+
+// dotEscape escapes the characters that have a special meaning inside DOT quoted strings.
+func dotEscape(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	return strings.ReplaceAll(text, `"`, `\"`)
+}
+
+// writeMermaid generates the Mermaid flowchart for the given nodes and links. Groups are drawn as stadiums and the
+// objects that couldn't be retrieved with dashed lines.
+func writeMermaid(nodes []*relations.Node, links []*relations.Link) []byte {
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "graph LR\n")
+	var problems []string
+	for _, node := range nodes {
+		lines := nodeLines(node)
+		for i, line := range lines {
+			lines[i] = mermaidEscape(line)
+		}
+		label := strings.Join(lines, "<br/>")
+		if node.Group {
+			fmt.Fprintf(buffer, "  %s([\"%s\"])\n", node.Id, label)
+		} else {
+			fmt.Fprintf(buffer, "  %s[\"%s\"]\n", node.Id, label)
+		}
+		if node.Problem != "" {
+			problems = append(problems, node.Id)
+		}
+	}
+	for _, link := range links {
+		fmt.Fprintf(buffer, "  %s -->|%s| %s\n", link.From, mermaidEscape(link.Label), link.To)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(buffer, "  classDef problem stroke-dasharray: 5 5\n")
+		fmt.Fprintf(buffer, "  class %s problem\n", strings.Join(problems, ","))
+	}
+	return buffer.Bytes()
+}
+
+// mermaidEscape replaces the characters that have a special meaning in Mermaid labels with entity codes.
+func mermaidEscape(text string) string {
+	replacer := strings.NewReplacer(
+		`"`, "#quot;",
+		"|", "#124;",
+		"<", "#lt;",
+		">", "#gt;",
+	)
+	return replacer.Replace(text)
+}
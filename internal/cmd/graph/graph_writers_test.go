@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package graph
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/relations"
+)
+
+var _ = Describe("Graph writers", func() {
+	nodes := []*relations.Node{
+		{Id: "n1", Kind: "cluster", Name: "my-cluster", ObjectId: "123", State: "READY"},
+		{Id: "n2", Kind: "node set", Name: "compute", Details: []string{"size 3"}, Group: true},
+		{Id: "n3", Kind: "hostpool", Name: `my "pool"`, ObjectId: "456", Truncated: true},
+		{Id: "n4", Kind: "host", Name: "789", ObjectId: "789", Problem: "not found"},
+	}
+	links := []*relations.Link{
+		{From: "n1", To: "n2", Label: "node sets"},
+		{From: "n2", To: "n3", Label: "host class"},
+		{From: "n3", To: "n4", Label: "hosts"},
+	}
+
+	It("Writes DOT", func() {
+		Expect(string(writeDot(nodes, links))).To(Equal(
+			"digraph fulfillment {\n" +
+				"  rankdir=LR;\n" +
+				"  node [shape=box];\n" +
+				"  n1 [label=\"cluster\\nmy-cluster\\nREADY\"];\n" +
+				"  n2 [label=\"node set compute\\nsize 3\", shape=ellipse];\n" +
+				"  n3 [label=\"hostpool\\nmy \\\"pool\\\"\\n…\"];\n" +
+				"  n4 [label=\"host\\n789\\nnot found\", style=dashed];\n" +
+				"  n1 -> n2 [label=\"node sets\"];\n" +
+				"  n2 -> n3 [label=\"host class\"];\n" +
+				"  n3 -> n4 [label=\"hosts\"];\n" +
+				"}\n",
+		))
+	})
+
+	It("Writes Mermaid", func() {
+		Expect(string(writeMermaid(nodes, links))).To(Equal(
+			"graph LR\n" +
+				"  n1[\"cluster<br/>my-cluster<br/>READY\"]\n" +
+				"  n2([\"node set compute<br/>size 3\"])\n" +
+				"  n3[\"hostpool<br/>my #quot;pool#quot;<br/>…\"]\n" +
+				"  n4[\"host<br/>789<br/>not found\"]\n" +
+				"  n1 -->|node sets| n2\n" +
+				"  n2 -->|host class| n3\n" +
+				"  n3 -->|hosts| n4\n" +
+				"  classDef problem stroke-dasharray: 5 5\n" +
+				"  class n4 problem\n",
+		))
+	})
+})
@@ -0,0 +1,464 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package csvcmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/batch"
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "csv --type TYPE --file FILE [--map COLUMN=FIELD,...]",
+		Short: "Create objects from the rows of a CSV file",
+		Long: "Create one object for each row of a CSV file. The first row of the file contains the names of the " +
+			"columns. By default each column name is the path of the field where the value is stored, for " +
+			"example 'metadata.name' or 'metadata.labels.env'. With the '--map' option the columns can have " +
+			"other names, and the columns that aren't mapped are ignored.\n\n" +
+			"Values use their usual text representation. Enum values can be given with or without the common " +
+			"prefix, timestamps use RFC 3339 format, and the items of lists are separated by semicolons. Empty " +
+			"cells leave the field unset.\n\n" +
+			"All the rows are parsed and checked before creating any object, and the parsed objects are displayed " +
+			"and confirmation is requested before creating them.",
+		Example: "  # Create hosts from a spreadsheet where the columns have the names of the fields:\n" +
+			"  fulfillment-cli import csv --type host --file hosts.csv\n\n" +
+			"  # Create hosts from a spreadsheet with other column names:\n" +
+			"  fulfillment-cli import csv --type host --file hosts.csv " +
+			"--map 'Hostname=metadata.name,Rack=metadata.labels.rack'",
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.objectType,
+		"type",
+		"t",
+		"",
+		"Type of the objects to create, for example 'host' or 'computeinstance'. This is mandatory.",
+	)
+	flags.StringVarP(
+		&runner.args.file,
+		"file",
+		"f",
+		"",
+		"Name of the CSV file. This is mandatory. If the value is '-' the file is read from the standard input, "+
+			"and then the '--yes' option is required.",
+	)
+	flags.StringSliceVarP(
+		&runner.args.mappings,
+		"map",
+		"m",
+		nil,
+		"Mapping from a column of the file to the path of a field, in the format 'COLUMN=FIELD'. Can be "+
+			"repeated or contain a comma separated list.",
+	)
+	flags.BoolVarP(
+		&runner.args.yes,
+		"yes",
+		"y",
+		false,
+		"Create the objects without asking for confirmation.",
+	)
+	flags.BoolVar(
+		&runner.args.dryRun,
+		"dry-run",
+		false,
+		"Parse and display the objects without creating them.",
+	)
+	batch.AddLimitFlags(flags, &runner.args.maxConsecutiveFailures, &runner.args.maxFailureRate)
+	_ = result.RegisterFlagCompletionFunc("type", completion.ObjectTypes)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		objectType string
+		file       string
+		mappings   []string
+		yes        bool
+		dryRun     bool
+
+		maxConsecutiveFailures int
+		maxFailureRate         int
+	}
+	logger       *slog.Logger
+	console      *terminal.Console
+	helper       *reflection.Helper
+	objectHelper *reflection.ObjectHelper
+	input        *os.File
+}
+
+// column contains the information about a column of the file that is stored in a field of the objects.
+type column struct {
+	index int
+	name  string
+	path  string
+}
+
+// row contains an object parsed from a row of the file, and the number of the line where the row starts.
+type row struct {
+	line   int
+	object proto.Message
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Check the flags:
+	if c.args.objectType == "" {
+		return exit.Usagef("it is mandatory to specify the type of the objects with the '--type' option")
+	}
+	if c.args.file == "" {
+		return exit.Usagef("it is mandatory to specify the CSV file with the '--file' option")
+	}
+	if c.args.file == "-" && !c.args.yes && !c.args.dryRun {
+		return exit.Usagef("when the file is read from the standard input the '--yes' option is required")
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the reflection helper:
+	c.helper, err = reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+	c.objectHelper = c.helper.Lookup(c.args.objectType)
+	if c.objectHelper == nil {
+		return exit.Usagef(
+			"unknown object type '%s', valid types are %s",
+			c.args.objectType, rendering.QuoteList(c.helper.Singulars()),
+		)
+	}
+
+	// Open the file:
+	var reader io.Reader
+	if c.args.file == "-" {
+		reader = os.Stdin
+	} else {
+		var file *os.File
+		file, err = os.Open(c.args.file)
+		if err != nil {
+			return fmt.Errorf("failed to open the file '%s': %w", c.args.file, err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	// Parse the rows, reporting all the errors before creating anything:
+	rows, errs := c.parse(reader)
+	if len(errs) > 0 {
+		for _, err := range errs {
+			c.console.Printf(ctx, "%v.\n", err)
+		}
+		c.console.Printf(ctx, "No object was created, fix the errors and try again.\n")
+		return exit.Error(1)
+	}
+	if len(rows) == 0 {
+		c.console.Printf(ctx, "There are no rows in the file.\n")
+		return nil
+	}
+
+	// Check that the server supports the objects:
+	for _, row := range rows {
+		err = c.objectHelper.Probe(ctx, row.object)
+		if err != nil {
+			return fmt.Errorf("object in line %d can't be created: %w", row.line, err)
+		}
+	}
+
+	// Display the objects and ask for confirmation:
+	err = c.preview(ctx, rows)
+	if err != nil {
+		return err
+	}
+	if c.args.dryRun {
+		c.console.Printf(ctx, "Not creating the %d objects because of '--dry-run'.\n", len(rows))
+		return nil
+	}
+	if !c.args.yes {
+		confirmed, err := c.confirm(ctx, len(rows))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return exit.Error(1)
+		}
+	}
+
+	// Create the objects, reporting the errors but continuing with the rest of the rows:
+	return c.create(ctx, rows)
+}
+
+// parse reads the rows of the file and converts them into objects. It returns all the errors found, each of them
+// prefixed with the number of the line.
+func (c *runnerContext) parse(reader io.Reader) (rows []row, errs []error) {
+	parser := csv.NewReader(reader)
+	parser.FieldsPerRecord = -1
+	header, err := parser.Read()
+	if err == io.EOF {
+		errs = append(errs, fmt.Errorf("file is empty, the first row should contain the names of the columns"))
+		return
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to read header: %w", err))
+		return
+	}
+	columns, err := c.columns(header)
+	if err != nil {
+		errs = append(errs, err)
+		return
+	}
+	for {
+		record, err := parser.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read file: %w", err))
+			return
+		}
+		line, _ := parser.FieldPos(0)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		object := c.objectHelper.Instance()
+		failed := false
+		for _, column := range columns {
+			if column.index >= len(record) {
+				continue
+			}
+			value := strings.TrimSpace(record[column.index])
+			if value == "" {
+				continue
+			}
+			err = c.objectHelper.Assign(object, column.path, value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("line %d, column '%s': %w", line, column.name, err))
+				failed = true
+			}
+		}
+		if !failed {
+			rows = append(rows, row{
+				line:   line,
+				object: object,
+			})
+		}
+	}
+}
+
+// columns calculates the columns of the file that are stored in fields, using the mappings given in the command line,
+// or the header itself if there are no mappings.
+func (c *runnerContext) columns(header []string) (result []column, err error) {
+	for i, name := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+	}
+	paths := map[string]string{}
+	if len(c.args.mappings) > 0 {
+		for _, mapping := range c.args.mappings {
+			name, path, ok := strings.Cut(mapping, "=")
+			name = strings.TrimSpace(name)
+			path = strings.TrimSpace(path)
+			if !ok || name == "" || path == "" {
+				err = fmt.Errorf("mapping '%s' isn't valid, it should be 'COLUMN=FIELD'", mapping)
+				return
+			}
+			if !slices.Contains(header, name) {
+				err = fmt.Errorf(
+					"mapping '%s' uses column '%s', but the file doesn't have it, columns are %s",
+					mapping, name, rendering.QuoteList(header),
+				)
+				return
+			}
+			paths[name] = path
+		}
+	} else {
+		for _, name := range header {
+			if name != "" {
+				paths[name] = name
+			}
+		}
+	}
+	for i, name := range header {
+		path, ok := paths[name]
+		if !ok {
+			c.logger.Debug(
+				"Ignoring column that isn't mapped",
+				slog.String("column", name),
+			)
+			continue
+		}
+		err = c.objectHelper.CheckPath(path)
+		if err != nil {
+			err = fmt.Errorf("column '%s' can't be stored in field '%s': %w", name, path, err)
+			return
+		}
+		result = append(result, column{
+			index: i,
+			name:  name,
+			path:  path,
+		})
+	}
+	return
+}
+
+// preview displays the objects that will be created.
+func (c *runnerContext) preview(ctx context.Context, rows []row) error {
+	if output.IsJson(ctx) {
+		return nil
+	}
+	objects := make([]proto.Message, len(rows))
+	for i, row := range rows {
+		objects[i] = row.object
+	}
+	renderer, err := rendering.DefaultRegistry.Create(rendering.FormatTable, &rendering.Options{
+		Logger:   c.logger,
+		Helper:   c.helper,
+		Writer:   c.console,
+		Theme:    c.console.Theme(),
+		Units:    c.console.Units(),
+		TimeZone: c.console.TimeZone(),
+		Warnings: os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+	err = renderer.Render(ctx, objects)
+	if err != nil {
+		return err
+	}
+	c.console.Printf(ctx, "\n")
+	return nil
+}
+
+// confirm asks the user to confirm that the objects should be created.
+func (c *runnerContext) confirm(ctx context.Context, count int) (result bool, err error) {
+	input := c.input
+	if input == nil {
+		input = os.Stdin
+	}
+	if !isatty.IsTerminal(input.Fd()) {
+		c.console.Printf(ctx, "Can't ask for confirmation because the input isn't a terminal, use '--yes'.\n")
+		return
+	}
+	c.console.Printf(ctx, "Create %d %s? [y/N]: ", count, c.objectHelper.Plural())
+	line, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("failed to read confirmation: %w", err)
+		return
+	}
+	err = nil
+	answer := strings.ToLower(strings.TrimSpace(line))
+	result = answer == "y" || answer == "yes"
+	return
+}
+
+// create creates the objects, reporting the result of each row. The rows are created in order, and the rest of the
+// rows are aborted if one of the failure limits is reached.
+func (c *runnerContext) create(ctx context.Context, rows []row) error {
+	runner, err := batch.NewRunner().
+		SetLogger(c.logger).
+		SetMaxConsecutiveFailures(c.args.maxConsecutiveFailures).
+		SetMaxFailureRate(c.args.maxFailureRate).
+		Build()
+	if err != nil {
+		return err
+	}
+	results := make([]proto.Message, len(rows))
+	err = runner.Run(ctx, len(rows), func(ctx context.Context, i int) error {
+		row := rows[i]
+		object, err := c.objectHelper.Create(ctx, row.object)
+		if err != nil {
+			c.console.Printf(ctx, "Line %d: failed to create %s: %v.\n", row.line, c.objectHelper.Singular(), err)
+			return err
+		}
+		c.console.Printf(
+			ctx,
+			"Line %d: created %s '%s'.\n",
+			row.line, c.objectHelper.Singular(), c.objectHelper.GetId(object),
+		)
+		results[i] = object
+		return nil
+	})
+	created := slices.DeleteFunc(results, func(object proto.Message) bool {
+		return object == nil
+	})
+	c.console.Printf(ctx, "Created %d of %d %s.\n", len(created), len(rows), c.objectHelper.Plural())
+	var batchErr *batch.Error
+	if errors.As(err, &batchErr) && batchErr.Aborted > 0 {
+		c.console.Printf(
+			ctx,
+			"Stopped because %s, %d of %d rows weren't processed.\n",
+			batchErr.Reason, batchErr.Aborted, len(rows),
+		)
+	}
+	writeErr := output.WriteObjects(ctx, created...)
+	if writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return exit.Error(1)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package csvcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Import CSV command", func() {
+	var (
+		ctx     context.Context
+		buffer  *gbytes.Buffer
+		runner  *runnerContext
+		created []*ffv1.Cluster
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		// Start a server that creates clusters, except the ones whose name starts with 'bad-':
+		created = nil
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+			CreateFunc: func(ctx context.Context, request *ffv1.ClustersCreateRequest) (
+				*ffv1.ClustersCreateResponse, error) {
+				cluster := request.GetObject()
+				if strings.HasPrefix(cluster.GetMetadata().GetName(), "bad-") {
+					return nil, grpcstatus.Errorf(codes.InvalidArgument, "name is rejected")
+				}
+				cluster.SetId(fmt.Sprintf("cluster-%d", len(created)+1))
+				created = append(created, cluster)
+				return ffv1.ClustersCreateResponse_builder{
+					Object: cluster,
+				}.Build(), nil
+			},
+		})
+		server.Start()
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		// Create the runner:
+		helper, err := reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(conn).
+			AddPackage("fulfillment.v1", 0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		buffer = gbytes.NewBuffer()
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		runner = &runnerContext{
+			logger:       logger,
+			console:      console,
+			helper:       helper,
+			objectHelper: helper.Lookup("cluster"),
+		}
+	})
+
+	// clusters returns the clusters of the given rows.
+	clusters := func(rows []row) []*ffv1.Cluster {
+		result := make([]*ffv1.Cluster, len(rows))
+		for i, row := range rows {
+			result[i] = row.object.(*ffv1.Cluster)
+		}
+		return result
+	}
+
+	It("Uses the column names as field paths by default", func() {
+		rows, errs := runner.parse(strings.NewReader(
+			"metadata.name,spec.template,metadata.labels.env,spec.node_sets.workers.size\n" +
+				"my-cluster,ocp_4_17_small,prod,3\n" +
+				"\n" +
+				"your-cluster,ocp_4_17_large,,5\n",
+		))
+		Expect(errs).To(BeEmpty())
+		Expect(rows).To(HaveLen(2))
+		Expect(rows[0].line).To(Equal(2))
+		Expect(rows[1].line).To(Equal(4))
+		objects := clusters(rows)
+		Expect(objects[0].GetMetadata().GetName()).To(Equal("my-cluster"))
+		Expect(objects[0].GetSpec().GetTemplate()).To(Equal("ocp_4_17_small"))
+		Expect(objects[0].GetMetadata().GetLabels()).To(HaveKeyWithValue("env", "prod"))
+		Expect(objects[0].GetSpec().GetNodeSets()["workers"].GetSize()).To(BeEquivalentTo(3))
+		Expect(objects[1].GetMetadata().GetLabels()).To(BeEmpty())
+	})
+
+	It("Uses the mappings and ignores the columns that aren't mapped", func() {
+		runner.args.mappings = []string{"Name=metadata.name", "Template=spec.template"}
+		rows, errs := runner.parse(strings.NewReader(
+			"Name,Template,Comments\n" +
+				"my-cluster,ocp_4_17_small,Whatever\n",
+		))
+		Expect(errs).To(BeEmpty())
+		Expect(rows).To(HaveLen(1))
+		object := rows[0].object.(*ffv1.Cluster)
+		Expect(object.GetMetadata().GetName()).To(Equal("my-cluster"))
+		Expect(object.GetSpec().GetTemplate()).To(Equal("ocp_4_17_small"))
+	})
+
+	It("Rejects mappings that use columns that don't exist", func() {
+		runner.args.mappings = []string{"Hostname=metadata.name"}
+		_, errs := runner.parse(strings.NewReader("Name\nmy-cluster\n"))
+		Expect(errs).To(ConsistOf(MatchError(
+			"mapping 'Hostname=metadata.name' uses column 'Hostname', but the file doesn't have it, " +
+				"columns are 'Name'",
+		)))
+	})
+
+	It("Rejects columns that don't correspond to fields", func() {
+		_, errs := runner.parse(strings.NewReader("metadata.nombre\nmy-cluster\n"))
+		Expect(errs).To(ConsistOf(MatchError(
+			"column 'metadata.nombre' can't be stored in field 'metadata.nombre': field 'metadata' has no " +
+				"field 'nombre'",
+		)))
+	})
+
+	It("Reports the errors of all the rows", func() {
+		rows, errs := runner.parse(strings.NewReader(
+			"metadata.name,spec.node_sets.workers.size\n" +
+				"my-cluster,three\n" +
+				"your-cluster,5\n" +
+				"their-cluster,-\n",
+		))
+		Expect(rows).To(HaveLen(1))
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0]).To(MatchError(ContainSubstring("line 2, column 'spec.node_sets.workers.size'")))
+		Expect(errs[1]).To(MatchError(ContainSubstring("line 4, column 'spec.node_sets.workers.size'")))
+	})
+
+	It("Creates the objects and reports the rows that fail", func() {
+		good := runner.objectHelper.Instance()
+		Expect(runner.objectHelper.Assign(good, "metadata.name", "my-cluster")).To(Succeed())
+		bad := runner.objectHelper.Instance()
+		Expect(runner.objectHelper.Assign(bad, "metadata.name", "bad-cluster")).To(Succeed())
+		rows := []row{
+			{
+				line:   2,
+				object: good,
+			},
+			{
+				line:   3,
+				object: bad,
+			},
+		}
+		err := runner.create(ctx, rows)
+		Expect(err).To(Equal(exit.Error(1)))
+		Expect(created).To(HaveLen(1))
+		Expect(buffer).To(gbytes.Say("Line 2: created cluster 'cluster-1'."))
+		Expect(buffer).To(gbytes.Say("Line 3: failed to create cluster: .*name is rejected"))
+		Expect(buffer).To(gbytes.Say("Created 1 of 2 clusters."))
+	})
+
+	It("Stops creating objects after too many consecutive failures", func() {
+		runner.args.maxConsecutiveFailures = 2
+		var rows []row
+		for i, name := range []string{"my-cluster", "bad-cluster", "bad-cluster", "your-cluster"} {
+			object := runner.objectHelper.Instance()
+			Expect(runner.objectHelper.Assign(object, "metadata.name", name)).To(Succeed())
+			rows = append(rows, row{
+				line:   i + 2,
+				object: object,
+			})
+		}
+		err := runner.create(ctx, rows)
+		Expect(err).To(Equal(exit.Error(1)))
+		Expect(created).To(HaveLen(1))
+		Expect(buffer).To(gbytes.Say("Line 2: created cluster 'cluster-1'."))
+		Expect(buffer).To(gbytes.Say("Line 3: failed to create cluster"))
+		Expect(buffer).To(gbytes.Say("Line 4: failed to create cluster"))
+		Expect(buffer).To(gbytes.Say("Created 1 of 4 clusters."))
+		Expect(buffer).To(gbytes.Say("Stopped because 2 consecutive operations failed, 1 of 4 rows weren't processed."))
+	})
+})
@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package importcmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/cmd/create/host"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/importcmd/csvcmd"
+)
+
+func Cmd() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "import",
+		Short: "Create objects from files in other formats",
+	}
+	result.AddCommand(csvcmd.Cmd())
+	result.AddCommand(host.ImportCmd())
+	return result
+}
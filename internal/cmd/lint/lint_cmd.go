@@ -0,0 +1,294 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/lint"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "lint [OBJECT]...",
+		Short: "Find problems in objects",
+		Long: "Find objects of the same type with the same name, objects that don't have the labels required by the " +
+			"policy of the team, and references to objects that don't exist. By default all the object types are " +
+			"checked. The command exits with a non-zero code if there are problems.",
+		Example: "  # Check all the objects:\n" +
+			"  fulfillment-cli lint\n\n" +
+			"  # Check the clusters, requiring the 'team' label:\n" +
+			"  fulfillment-cli lint clusters --require-label team\n\n" +
+			"  # Check all the objects using the rules from a file:\n" +
+			"  fulfillment-cli lint --rules rules.yaml",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.args.rules,
+		"rules",
+		"",
+		"YAML file containing the rules, for example the labels that are required for each object type.",
+	)
+	flags.StringSliceVar(
+		&runner.args.requireLabels,
+		"require-label",
+		nil,
+		"Key of a label that all the objects must have. Can be used multiple times.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		rules         string
+		requireLabels []string
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	helper  *reflection.Helper
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the reflection helper:
+	c.helper, err = reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Find the types to check, all of them if none were given:
+	var types []protoreflect.FullName
+	if len(args) == 0 {
+		for _, name := range c.helper.Names() {
+			types = append(types, protoreflect.FullName(name))
+		}
+	}
+	for _, arg := range args {
+		objectHelper := c.helper.Lookup(arg)
+		if objectHelper == nil {
+			c.console.Render(ctx, "wrong_object.txt", map[string]any{
+				"Helper": c.helper,
+				"Object": arg,
+			})
+			return exit.Usage
+		}
+		if !slices.Contains(types, objectHelper.FullName()) {
+			types = append(types, objectHelper.FullName())
+		}
+	}
+
+	// Load the rules:
+	rules, err := c.loadRules()
+	if err != nil {
+		return err
+	}
+
+	// Get the objects of the types to check. When the types weren't explicitly requested those that can't be listed,
+	// for example because the user doesn't have permission, are skipped.
+	inventory := lint.Inventory{}
+	for _, objectType := range slices.Clone(types) {
+		err = c.addObjects(ctx, inventory, objectType)
+		if err != nil && len(args) > 0 {
+			return err
+		}
+		if err != nil {
+			c.logger.WarnContext(
+				ctx,
+				"Failed to get objects, they will not be checked",
+				slog.String("type", string(objectType)),
+				slog.Any("error", err),
+			)
+			types = slices.DeleteFunc(types, func(t protoreflect.FullName) bool {
+				return t == objectType
+			})
+		}
+	}
+
+	// Get also the objects of the types that are referenced:
+	for _, reference := range lint.References {
+		if !slices.Contains(types, reference.Source) || c.helper.Lookup(string(reference.Target)) == nil {
+			continue
+		}
+		if _, ok := inventory[reference.Target]; ok {
+			continue
+		}
+		err = c.addObjects(ctx, inventory, reference.Target)
+		if err != nil {
+			c.logger.WarnContext(
+				ctx,
+				"Failed to get referenced objects, references to them will not be checked",
+				slog.String("type", string(reference.Target)),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	// Check the objects:
+	checker, err := lint.NewChecker().
+		SetLogger(c.logger).
+		SetRules(rules).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create checker: %w", err)
+	}
+	problems := checker.Check(ctx, inventory, types)
+
+	// Report the problems:
+	objects := 0
+	for _, objectType := range types {
+		objects += len(inventory[objectType])
+	}
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, problems)
+	} else if len(problems) > 0 {
+		c.renderProblems(ctx, problems)
+	}
+	if len(problems) == 0 {
+		c.console.Render(ctx, "no_problems.txt", map[string]any{
+			"Objects": objects,
+		})
+		return nil
+	}
+	c.console.Render(ctx, "problems.txt", map[string]any{
+		"Problems": len(problems),
+		"Objects":  objects,
+	})
+	return exit.Error(1)
+}
+
+// loadRules loads the rules from the file given in the command line, adds the labels required in the command line,
+// and replaces the object types with their full names.
+func (c *runnerContext) loadRules() (result *lint.Rules, err error) {
+	rules := &lint.Rules{}
+	if c.args.rules != "" {
+		rules, err = lint.LoadRules(c.args.rules)
+		if err != nil {
+			return
+		}
+	}
+	for _, key := range c.args.requireLabels {
+		rules.RequiredLabels = append(rules.RequiredLabels, &lint.RequiredLabel{
+			Key: key,
+		})
+	}
+	for _, label := range rules.RequiredLabels {
+		for i, objectType := range label.Types {
+			objectHelper := c.helper.Lookup(objectType)
+			if objectHelper == nil {
+				err = fmt.Errorf("unknown object type '%s' in the rules for label '%s'", objectType, label.Key)
+				return
+			}
+			label.Types[i] = string(objectHelper.FullName())
+		}
+	}
+	result = rules
+	return
+}
+
+// addObjects gets the objects of the given type and adds them to the inventory.
+func (c *runnerContext) addObjects(ctx context.Context, inventory lint.Inventory,
+	objectType protoreflect.FullName) error {
+	objectHelper := c.helper.Lookup(string(objectType))
+	response, err := objectHelper.List(ctx, reflection.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", objectHelper.Plural(), err)
+	}
+	inventory[objectType] = response.Items
+	return nil
+}
+
+// renderProblems writes the table of problems.
+func (c *runnerContext) renderProblems(ctx context.Context, problems []*lint.Problem) {
+	writer := tabwriter.NewWriter(c.console, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TYPE\tID\tNAME\tPROBLEM\tDETAILS\n")
+	for _, problem := range problems {
+		objectType := string(problem.Type)
+		objectHelper := c.helper.Lookup(objectType)
+		if objectHelper != nil {
+			objectType = objectHelper.Singular()
+		}
+		name := problem.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\n",
+			objectType, problem.Id, name, problem.Kind, problem.Message,
+		)
+	}
+	err := writer.Flush()
+	if err != nil {
+		c.logger.ErrorContext(
+			ctx,
+			"Failed to write problems",
+			slog.Any("error", err),
+		)
+	}
+}
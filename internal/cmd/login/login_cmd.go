@@ -37,6 +37,7 @@ import (
 	"github.com/osac-project/fulfillment-cli/internal/exit"
 	internalnetwork "github.com/osac-project/fulfillment-cli/internal/network"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/tokenscript"
 	metadatav1 "github.com/osac-project/fulfillment-common/api/metadata/v1"
 )
 
@@ -46,10 +47,12 @@ var templatesFS embed.FS
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:                   "login [FLAGS] ADDRESS",
+		Use:                   "login [FLAGS] [ADDRESS]",
 		DisableFlagsInUseLine: true,
 		Short:                 "Save connection and authentication details.",
-		RunE:                  runner.run,
+		Long: "Save connection and authentication details. When the address isn't given the settings of the " +
+			"current configuration are reused, and only new credentials are obtained.",
+		RunE: runner.run,
 	}
 	flags := result.Flags()
 	flags.BoolVar(
@@ -98,6 +101,20 @@ func Cmd() *cobra.Command {
 			"to quote this shell command correctly, as it will be passed to your shell for "+
 			"execution.",
 	)
+	flags.DurationVar(
+		&runner.args.tokenScriptTimeout,
+		"token-script-timeout",
+		tokenscript.DefaultTimeout,
+		"Maximum time that the token script can run. If it takes longer it is killed and the command fails. "+
+			"Zero means no limit.",
+	)
+	flags.StringVar(
+		&runner.args.tokenScriptShell,
+		"token-script-shell",
+		"",
+		"Shell used to run the token script. It can be a POSIX shell like 'bash', 'cmd.exe' or PowerShell. By "+
+			"default it is the value of the 'SHELL' environment variable, or 'cmd.exe' in Windows.",
+	)
 	flags.StringVar(
 		&runner.args.oauthIssuer,
 		"oauth-issuer",
@@ -162,10 +179,39 @@ func Cmd() *cobra.Command {
 			oauth.PasswordFlow,
 		),
 	)
+	flags.StringVar(
+		&runner.args.tokenStorage,
+		"token-storage",
+		config.TokenStorageFile,
+		fmt.Sprintf(
+			"Where to store the tokens. Must be '%s' or '%s'. With '%s' the tokens are stored in the keyring "+
+				"of the operating system, and if it isn't available they are stored in the configuration file.",
+			config.TokenStorageFile, config.TokenStorageKeyring, config.TokenStorageKeyring,
+		),
+	)
+	flags.BoolVar(
+		&runner.args.readOnly,
+		"read-only",
+		false,
+		"Don't allow commands that create, modify or delete objects. This is intended for demonstrations and "+
+			"for users that should only inspect the objects. If not specified the value of the current "+
+			"configuration is preserved.",
+	)
+	flags.BoolVar(
+		&runner.args.production,
+		"production",
+		false,
+		"Mark the server as production, so that commands that create, modify or delete objects need to be "+
+			"confirmed typing the name of the server or with the '--confirm-production' flag. If not "+
+			"specified the value of the current configuration is preserved when logging in again to the "+
+			"same server.",
+	)
 	flags.MarkHidden("address")
 	flags.MarkHidden("private")
 	flags.MarkHidden("token")
 	flags.MarkHidden("token-script")
+	flags.MarkHidden("token-script-timeout")
+	flags.MarkHidden("token-script-shell")
 	return result
 }
 
@@ -177,22 +223,29 @@ type runnerContext struct {
 	plaintext  bool
 	caPool     *x509.CertPool
 	tokenStore auth.TokenStore
+	previous   *config.Config
+	input      *os.File
 	args       struct {
-		plaintext         bool
-		insecure          bool
-		caFiles           []string
-		address           string
-		private           bool
-		token             string
-		tokenScript       string
-		oauthIssuer       string
-		oauthFlow         string
-		oauthClientId     string
-		oauthClientSecret string
-		oauthScopes       []string
-		oauthRedirectUri  string
-		oauthUser         string
-		oauthPassword     string
+		plaintext          bool
+		insecure           bool
+		caFiles            []string
+		address            string
+		private            bool
+		token              string
+		tokenScript        string
+		tokenScriptTimeout time.Duration
+		tokenScriptShell   string
+		oauthIssuer        string
+		oauthFlow          string
+		oauthClientId      string
+		oauthClientSecret  string
+		oauthScopes        []string
+		oauthRedirectUri   string
+		oauthUser          string
+		oauthPassword      string
+		tokenStorage       string
+		readOnly           bool
+		production         bool
 	}
 }
 
@@ -215,18 +268,54 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	// The address used to be specified with a command line flag, but now we also take it from the arguments:
 	c.address = c.args.address
+	if c.address == "" && len(args) == 1 {
+		c.address = args[0]
+	}
+
+	// If there is no address, offer to reuse the settings of the current configuration, so that the user only needs
+	// to obtain new credentials. Otherwise parse the address.
 	if c.address == "" {
-		if len(args) == 1 {
-			c.address = args[0]
-		} else {
-			return fmt.Errorf("address is mandatory")
+		c.previous, err = c.selectPrevious(ctx)
+		if err != nil {
+			return err
+		}
+		if c.previous == nil {
+			return exit.Usagef("address is mandatory")
+		}
+		err = c.reusePrevious()
+		if err != nil {
+			return err
+		}
+	} else {
+		c.address, c.plaintext, err = c.parseAddress(c.address)
+		if err != nil {
+			return fmt.Errorf("failed to parse address: %w", err)
 		}
 	}
 
-	// Parse the address:
-	c.address, c.plaintext, err = c.parseAddress(c.address)
-	if err != nil {
-		return fmt.Errorf("failed to parse address: %w", err)
+	// Check the token storage, and if the keyring was requested check that it is available, as otherwise we need to
+	// fall back to the file:
+	tokenStorage := c.args.tokenStorage
+	switch tokenStorage {
+	case config.TokenStorageFile:
+	case config.TokenStorageKeyring:
+		err = config.KeyringAvailable()
+		if err != nil {
+			c.logger.WarnContext(
+				ctx,
+				"Keyring isn't available",
+				slog.Any("error", err),
+			)
+			c.console.Render(ctx, "keyring_unavailable.txt", map[string]any{
+				"Error": err,
+			})
+			tokenStorage = config.TokenStorageFile
+		}
+	default:
+		return exit.Usagef(
+			"unknown token storage '%s', should be '%s' or '%s'",
+			tokenStorage, config.TokenStorageFile, config.TokenStorageKeyring,
+		)
 	}
 
 	// Check if the plaintext flag has been explcitly set, and if it conflicts with the result of parsing the
@@ -236,16 +325,21 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 			"Address":   c.address,
 			"Plaintext": c.plaintext,
 		})
-		return exit.Error(1)
+		return exit.Usage
 	}
 
-	// Create the CA pool:
-	c.caPool, err = network.NewCertPool().
-		SetLogger(c.logger).
-		AddSystemFiles(true).
-		AddKubernetesFiles(true).
-		AddFiles(c.args.caFiles...).
-		Build()
+	// Create the CA pool. When reusing the previous configuration and no CA files have been given, use the ones of
+	// that configuration.
+	if c.previous != nil && !c.flags.Changed("ca-file") {
+		c.caPool, err = c.previous.CaPool(ctx)
+	} else {
+		c.caPool, err = network.NewCertPool().
+			SetLogger(c.logger).
+			AddSystemFiles(true).
+			AddKubernetesFiles(true).
+			AddFiles(c.args.caFiles...).
+			Build()
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create CA pool: %w", err)
 	}
@@ -276,10 +370,13 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}()
 
 	// Fetch the metadata:
+	step := c.console.StartStep(ctx, "Fetching server metadata")
 	metadata, err := c.fetchMetadata(ctx, grpcConn)
 	if err != nil {
+		step.Fail()
 		return fmt.Errorf("failed to fetch metadata: %w", err)
 	}
+	step.Done()
 	c.logger.DebugContext(
 		ctx,
 		"Fetched metadata",
@@ -293,8 +390,14 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to select token issuer: %w", err)
 	}
 
-	// Create an empty configuration and a token store that will load/save tokens from/to that configuration:
-	cfg := &config.Config{}
+	// Start from the settings of the current configuration, without the connection and authentication details, so
+	// that logging in again only replaces those. Then create a token store that will load/save tokens from/to that
+	// configuration:
+	cfg, err := config.CurrentSettings(c.address)
+	if err != nil {
+		return fmt.Errorf("failed to load current configuration: %w", err)
+	}
+	cfg.TokenStorage = tokenStorage
 	c.tokenStore = cfg.TokenStore()
 
 	// Create the token source only if a token issuer has been selected.
@@ -306,10 +409,13 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// If we got a token source, then try to obtain a token using it, as this will trigger the authentication flow
 	// and verify that it works correctly.
 	if tokenSource != nil {
+		step = c.console.StartStep(ctx, "Obtaining token")
 		_, err = tokenSource.Token(ctx)
 		if err != nil {
+			step.Fail()
 			return fmt.Errorf("failed to obtain token using token source: %w", err)
 		}
+		step.Done()
 	}
 
 	// Save the basic details of the configuration:
@@ -318,9 +424,22 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	cfg.Address = c.address
 	cfg.Private = c.args.private
 
+	// The read only and production settings are only changed when the flags are explicitly given, otherwise the
+	// values of the current configuration are preserved. Note that the production setting was already removed if the
+	// current configuration is for a different server.
+	if c.flags.Changed("read-only") {
+		cfg.ReadOnly = c.args.readOnly
+	}
+	if c.flags.Changed("production") {
+		cfg.Production = c.args.production
+	}
+
 	// For CA files that are absolute we need to store only the path, but for those that are relative we need to
 	// save the content because otherwise we will not be able to use them when the command is executed from a
-	// different directory.
+	// different directory. The CA files of the previous configuration are already in that form.
+	if c.previous != nil && !c.flags.Changed("ca-file") {
+		cfg.CaFiles = c.previous.CaFiles
+	}
 	for _, caFile := range c.args.caFiles {
 		if filepath.IsAbs(caFile) {
 			cfg.CaFiles = append(cfg.CaFiles, config.CaFile{
@@ -344,6 +463,10 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		cfg.AccessToken = c.args.token
 	} else if c.args.tokenScript != "" {
 		cfg.TokenScript = c.args.tokenScript
+		cfg.TokenScriptShell = c.args.tokenScriptShell
+		if c.args.tokenScriptTimeout != tokenscript.DefaultTimeout {
+			cfg.TokenScriptTimeout = c.args.tokenScriptTimeout.String()
+		}
 	} else if tokenIssuer != "" {
 		cfg.OauthIssuer = tokenIssuer
 		cfg.OAuthFlow = oauth.Flow(c.args.oauthFlow)
@@ -374,20 +497,27 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if the configuration is working by invoking the health check method:
+	step = c.console.StartStep(ctx, "Checking server health")
 	healthClient := healthv1.NewHealthClient(grpcConn)
 	healthResponse, err := healthClient.Check(ctx, &healthv1.HealthCheckRequest{})
 	if err != nil {
+		step.Fail()
 		return err
 	}
 	if healthResponse.Status != healthv1.HealthCheckResponse_SERVING {
+		step.Fail()
 		return fmt.Errorf("server is not serving, status is '%s'", healthResponse.Status)
 	}
+	step.Done()
 
 	// Everything is working, so we can save the configuration:
+	step = c.console.StartStep(ctx, "Saving configuration")
 	err = config.Save(cfg)
 	if err != nil {
+		step.Fail()
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
+	step.Done()
 
 	return nil
 }
@@ -454,10 +584,12 @@ func (c *runnerContext) createTokenSource(ctx context.Context, tokenIssuer strin
 
 	// Use a token script if specified::
 	if c.args.tokenScript != "" {
-		result, err = auth.NewScriptTokenSource().
+		result, err = tokenscript.NewTokenSource().
 			SetLogger(c.logger).
 			SetScript(c.args.tokenScript).
+			SetShell(c.args.tokenScriptShell).
 			SetStore(c.tokenStore).
+			SetTimeout(c.args.tokenScriptTimeout).
 			Build()
 		if err != nil {
 			err = fmt.Errorf("failed to create script token source: %w", err)
@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package login
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+)
+
+// selectPrevious loads the current configuration and, if it has an address, asks the user if it should be reused.
+// The question is only asked when the standard input is a terminal, otherwise the configuration is reused. Returns
+// nil if there is no configuration or the user doesn't want to reuse it.
+func (c *runnerContext) selectPrevious(ctx context.Context) (result *config.Config, err error) {
+	previous, err := config.Load(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to load current configuration: %w", err)
+		return
+	}
+	if previous == nil || previous.Address == "" {
+		return
+	}
+	input := c.input
+	if input == nil {
+		input = os.Stdin
+	}
+	if isatty.IsTerminal(input.Fd()) {
+		c.console.Printf(ctx, "Log in again to '%s' with the saved settings? [Y/n] ", previous.Address)
+		var line string
+		line, err = bufio.NewReader(input).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			err = fmt.Errorf("failed to read answer: %w", err)
+			return
+		}
+		err = nil
+		answer := strings.ToLower(strings.TrimSpace(line))
+		if answer != "" && answer != "y" && answer != "yes" {
+			return
+		}
+	}
+	c.console.Render(ctx, "reuse_config.txt", map[string]any{
+		"Address": previous.Address,
+	})
+	result = previous
+	return
+}
+
+// reusePrevious copies the address and the settings of the previous configuration, except the ones that have been
+// explicitly given in the command line. The credentials aren't copied, new ones will be obtained.
+func (c *runnerContext) reusePrevious() error {
+	previous := c.previous
+	c.address = previous.Address
+	c.plaintext = previous.Plaintext
+	if !c.flags.Changed("insecure") {
+		c.args.insecure = previous.Insecure
+	}
+	if !c.flags.Changed("private") {
+		c.args.private = previous.Private
+	}
+	if !c.flags.Changed("token-storage") && previous.TokenStorage != "" {
+		c.args.tokenStorage = previous.TokenStorage
+	}
+
+	// Reuse the authentication method only if no other has been explicitly given:
+	if c.flags.Changed("token") || c.flags.Changed("token-script") {
+		return nil
+	}
+	switch {
+	case previous.TokenScript != "":
+		c.args.tokenScript = previous.TokenScript
+		if !c.flags.Changed("token-script-shell") {
+			c.args.tokenScriptShell = previous.TokenScriptShell
+		}
+		if !c.flags.Changed("token-script-timeout") && previous.TokenScriptTimeout != "" {
+			timeout, err := time.ParseDuration(previous.TokenScriptTimeout)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to parse token script timeout '%s': %w",
+					previous.TokenScriptTimeout, err,
+				)
+			}
+			c.args.tokenScriptTimeout = timeout
+		}
+	case previous.OauthIssuer != "":
+		if !c.flags.Changed("oauth-flow") && previous.OAuthFlow != "" {
+			c.args.oauthFlow = string(previous.OAuthFlow)
+		}
+		if !c.flags.Changed("oauth-client-id") && previous.OAuthClientId != "" {
+			c.args.oauthClientId = previous.OAuthClientId
+		}
+		if !c.flags.Changed("oauth-client-secret") {
+			c.args.oauthClientSecret = previous.OAuthClientSecret
+		}
+		if !c.flags.Changed("oauth-scopes") {
+			c.args.oauthScopes = previous.OAuthScopes
+		}
+		if !c.flags.Changed("oauth-redirect-uri") && previous.OAuthRedirectUri != "" {
+			c.args.oauthRedirectUri = previous.OAuthRedirectUri
+		}
+		if !c.flags.Changed("oauth-user") {
+			c.args.oauthUser = previous.OAuthUser
+		}
+		if !c.flags.Changed("oauth-password") {
+			c.args.oauthPassword = previous.OAuthPassword
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package login
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	metadatav1 "github.com/osac-project/fulfillment-common/api/metadata/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+// metadataServer is a metadata server that doesn't advertise any token issuer.
+type metadataServer struct {
+	metadatav1.UnimplementedMetadataServer
+}
+
+func (s *metadataServer) Get(ctx context.Context,
+	request *metadatav1.MetadataGetRequest) (*metadatav1.MetadataGetResponse, error) {
+	return &metadatav1.MetadataGetResponse{}, nil
+}
+
+var _ = Describe("Login command", func() {
+	var (
+		ctx     context.Context
+		address string
+	)
+
+	BeforeEach(func() {
+		tmp := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tmp)
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(&bytes.Buffer{}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		ctx = logging.LoggerIntoContext(context.Background(), logger)
+		ctx = terminal.ConsoleIntoContext(ctx, console)
+
+		// Start a server that only has the metadata and health services, as that is all that the command needs:
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		metadatav1.RegisterMetadataServer(server.Registrar(), &metadataServer{})
+		healthv1.RegisterHealthServer(server.Registrar(), health.NewServer())
+		server.Start()
+		address = "http://" + server.Address()
+	})
+
+	// login runs the command with the given arguments.
+	login := func(args ...string) {
+		cmd := Cmd()
+		cmd.SetArgs(args)
+		cmd.SetOut(GinkgoWriter)
+		cmd.SetErr(GinkgoWriter)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.ExecuteContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("Preserves the units when logging in again", func() {
+		login(address)
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.Units = "decimal"
+		Expect(config.Save(cfg)).To(Succeed())
+
+		login()
+		cfg, err = config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Address).To(Equal(strings.TrimPrefix(address, "http://")))
+		Expect(cfg.Units).To(Equal("decimal"))
+	})
+	It("Preserves the telemetry settings when logging in again", func() {
+		login(address)
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.OtelEndpoint = "https://otel.example.com"
+		cfg.OtelHeaders = map[string]string{
+			"Authorization": "Bearer my-token",
+		}
+		Expect(config.Save(cfg)).To(Succeed())
+
+		login()
+		cfg, err = config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.OtelEndpoint).To(Equal("https://otel.example.com"))
+		Expect(cfg.OtelHeaders).To(Equal(map[string]string{
+			"Authorization": "Bearer my-token",
+		}))
+	})
+	It("Preserves the directories when logging in again", func() {
+		login(address)
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.StateDir = "/my/state"
+		cfg.CacheDir = "/my/cache"
+		Expect(config.Save(cfg)).To(Succeed())
+
+		login()
+		cfg, err = config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.StateDir).To(Equal("/my/state"))
+		Expect(cfg.CacheDir).To(Equal("/my/cache"))
+	})
+	It("Preserves the log settings when logging in again", func() {
+		login(address)
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		logMaxFiles := 3
+		cfg.LogMaxSize = "10MiB"
+		cfg.LogMaxAge = "24h"
+		cfg.LogMaxFiles = &logMaxFiles
+		Expect(config.Save(cfg)).To(Succeed())
+
+		login()
+		cfg, err = config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.LogMaxSize).To(Equal("10MiB"))
+		Expect(cfg.LogMaxAge).To(Equal("24h"))
+		Expect(cfg.LogMaxFiles).To(Equal(&logMaxFiles))
+	})
+
+	It("Preserves settings added by the user when logging in to another server", func() {
+		login(address)
+		cfg, err := config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.LogMaxSize = "10MiB"
+		cfg.Favorites = []config.Favorite{{
+			Type: "fulfillment.v1.Cluster",
+			Id:   "123",
+		}}
+		Expect(config.Save(cfg)).To(Succeed())
+
+		// Log in to a different address for the same server, so that it is considered a different server:
+		login(strings.Replace(address, "127.0.0.1", "localhost", 1))
+		cfg, err = config.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.LogMaxSize).To(Equal("10MiB"))
+		Expect(cfg.Favorites).To(BeEmpty())
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package login
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		err = checker.Check(consoletest.Cases{
+			"auth_failure.txt": {
+				nil,
+			},
+			"auth_success.txt": {
+				nil,
+			},
+			"keyring_unavailable.txt": {
+				map[string]any{
+					"Error": errors.New("no keyring available"),
+				},
+			},
+			"plaintext_conflict.txt": {
+				map[string]any{
+					"Address":   "localhost:8000",
+					"Plaintext": true,
+				},
+				map[string]any{
+					"Address":   "localhost:8000",
+					"Plaintext": false,
+				},
+			},
+			"reuse_config.txt": {
+				map[string]any{
+					"Address": "api.example.com:443",
+				},
+			},
+			"start_code_flow.txt": {
+				map[string]any{
+					"AuthorizationUri": "https://sso.example.com/auth",
+				},
+			},
+			"start_device_flow.txt": {
+				map[string]any{
+					"VerificationUri": "https://sso.example.com/device",
+					"UserCode":        "ABCD-EFGH",
+					"ExpiresIn":       "10 minutes from now",
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
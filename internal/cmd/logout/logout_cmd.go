@@ -14,12 +14,20 @@ language governing permissions and limitations under the License.
 package logout
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
-	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/osac-project/fulfillment-common/oauth"
 	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/revocation"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
 )
 
 func Cmd() *cobra.Command {
@@ -29,16 +37,32 @@ func Cmd() *cobra.Command {
 		Short: "Discard connection and authentication details",
 		RunE:  runner.run,
 	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.args.revoke,
+		"revoke",
+		false,
+		"Revoke the OAuth tokens at the token issuer before discarding them.",
+	)
 	return result
 }
 
 type runnerContext struct {
+	args struct {
+		revoke bool
+	}
+	logger  *slog.Logger
+	console *terminal.Console
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Get the context:
 	ctx := cmd.Context()
 
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
 	// Load the configuration:
 	cfg, err := config.Load(ctx)
 	if err != nil {
@@ -48,6 +72,13 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		cfg = &config.Config{}
 	}
 
+	// Revoke the tokens if requested. Note that if this fails we still want to discard the local details, as that is
+	// what the user asked for, but we remember the failure in order to report it with the exit code.
+	revoked := true
+	if c.args.revoke {
+		revoked = c.revoke(ctx, cfg)
+	}
+
 	// Clear all the details:
 	cfg.AccessToken = ""
 	cfg.Plaintext = false
@@ -67,5 +98,66 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
+	if !revoked {
+		return exit.Error(1)
+	}
 	return nil
 }
+
+// revoke tries to revoke the refresh and access tokens at the token issuer. It returns false if that failed.
+func (c *runnerContext) revoke(ctx context.Context, cfg *config.Config) bool {
+	// Tokens obtained without OAuth, for example with a token script, can't be revoked:
+	if cfg.OauthIssuer == "" || (cfg.AccessToken == "" && cfg.RefreshToken == "") {
+		c.console.Printf(ctx, "There are no OAuth tokens to revoke.\n")
+		return true
+	}
+
+	// Create the revoker:
+	caPool, err := cfg.CaPool(ctx)
+	if err != nil {
+		c.console.Printf(ctx, "Failed to load CA certificates: %v\n", err)
+		return false
+	}
+	revoker, err := revocation.NewRevoker().
+		SetLogger(c.logger).
+		SetIssuer(cfg.OauthIssuer).
+		SetClientId(cfg.OAuthClientId).
+		SetClientSecret(cfg.OAuthClientSecret).
+		SetInsecure(cfg.Insecure).
+		SetCaPool(caPool).
+		Build()
+	if err != nil {
+		c.console.Printf(ctx, "Failed to revoke tokens: %v\n", err)
+		return false
+	}
+
+	// Revoke the refresh token first, as for most issuers that also invalidates the access tokens obtained with it,
+	// and then the access token, in case it is still valid:
+	tokens := []struct {
+		value string
+		hint  string
+	}{
+		{cfg.RefreshToken, revocation.RefreshTokenHint},
+		{cfg.AccessToken, revocation.AccessTokenHint},
+	}
+	for _, token := range tokens {
+		if token.value == "" {
+			continue
+		}
+		err = revoker.Revoke(ctx, token.value, token.hint)
+		if errors.Is(err, revocation.ErrNotSupported) {
+			c.console.Printf(
+				ctx,
+				"Token issuer '%s' doesn't support revocation, tokens will remain valid until they expire.\n",
+				cfg.OauthIssuer,
+			)
+			return true
+		}
+		if err != nil {
+			c.console.Printf(ctx, "Failed to revoke tokens: %v\n", err)
+			return false
+		}
+	}
+	c.console.Printf(ctx, "Revoked tokens at '%s'.\n", cfg.OauthIssuer)
+	return true
+}
@@ -0,0 +1,277 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package open
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	neturl "net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	browser "github.com/skratchdot/open-golang/open"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "open [cluster] NAME [OPTION]...",
+		Aliases: []string{"console"},
+		Short:   "Open the web console of a cluster",
+		Long: "Open in the default browser the web console of a cluster, using the URL reported in the status " +
+			"of the cluster. The object type is optional, clusters are the only objects that have a web " +
+			"console. In environments without a browser use the '--print-url' option to get the URL instead.",
+		Example: "  # Open the web console of a cluster:\n" +
+			"  fulfillment-cli open cluster my-cluster\n\n" +
+			"  # Print the URL of the web console, without opening it:\n" +
+			"  fulfillment-cli open cluster my-cluster --print-url",
+		Args:              cobra.MaximumNArgs(2),
+		RunE:              runner.run,
+		ValidArgsFunction: completeArgs,
+	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.args.printUrl,
+		"print-url",
+		false,
+		"Print the URL of the web console instead of opening it in the browser.",
+	)
+	refs.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		printUrl bool
+	}
+	logger  *slog.Logger
+	flags   *pflag.FlagSet
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	refMode refs.Mode
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the flags:
+	c.flags = cmd.Flags()
+
+	// Get the name or identifier, optionally preceded by the object type:
+	var key string
+	switch len(args) {
+	case 2:
+		if !isClusterType(args[0]) {
+			return exit.Usagef("object type should be 'cluster', but it is '%s'", args[0])
+		}
+		key = args[1]
+	case 1:
+		key = args[0]
+	}
+	if key == "" {
+		c.console.Render(ctx, "no_key.txt", nil)
+		return exit.Usage
+	}
+	c.refMode, err = refs.ModeFromFlags(c.flags)
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, c.flags)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Try to find a cluster that has an identifier or name matching the given key:
+	client := ffv1.NewClustersClient(c.conn)
+	listResponse, err := client.List(ctx, ffv1.ClustersListRequest_builder{
+		Filter: proto.String(c.refMode.Filter(key)),
+		Limit:  proto.Int32(10),
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	total := listResponse.GetTotal()
+	clusters := refs.Resolve(c.refMode, key, listResponse.GetItems(), (*ffv1.Cluster).GetId, clusterName)
+	var cluster *ffv1.Cluster
+	switch len(clusters) {
+	case 0:
+		c.console.Render(ctx, "no_match.txt", map[string]any{
+			"Key": key,
+		})
+		return exit.NotFound
+	case 1:
+		cluster = clusters[0]
+	default:
+		cluster = c.pickCluster(ctx, key, clusters)
+		if cluster != nil {
+			break
+		}
+		ids := make([]string, len(clusters))
+		for i, cluster := range clusters {
+			ids[i] = cluster.GetId()
+		}
+		sort.Strings(ids)
+		ids = slices.Compact(ids)
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Ids":   ids,
+			"Key":   key,
+			"Total": total,
+		})
+		return exit.General
+	}
+
+	// The URL of the console is only available once the cluster is ready:
+	url := cluster.GetStatus().GetConsoleUrl()
+	if url == "" {
+		c.console.Render(ctx, "no_console_url.txt", map[string]any{
+			"Id":    cluster.GetId(),
+			"State": clusterState(cluster),
+		})
+		return exit.NotFound
+	}
+
+	// Print or open the URL:
+	if c.args.printUrl {
+		fmt.Printf("%s\n", url)
+		return nil
+	}
+	// The URL comes from the server, so make sure that it is a web URL before handing it to the system opener, as
+	// that would also run local files or other kinds of URLs:
+	if !isWebUrl(url) {
+		c.console.Render(ctx, "unsafe_console_url.txt", map[string]any{
+			"Id":  cluster.GetId(),
+			"Url": url,
+		})
+		return exit.General
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Opening console",
+		slog.String("id", cluster.GetId()),
+		slog.String("url", url),
+	)
+	err = browser.Run(url)
+	if err != nil {
+		c.console.Render(ctx, "open_failed.txt", map[string]any{
+			"Error": err,
+			"Url":   url,
+		})
+		return exit.General
+	}
+	c.console.Printf(ctx, "Opened console of cluster '%s': %s\n", cluster.GetId(), url)
+
+	return nil
+}
+
+// isWebUrl checks if the given text is an absolute URL with the 'http' or 'https' scheme.
+func isWebUrl(text string) bool {
+	parsed, err := neturl.Parse(text)
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	return (scheme == "http" || scheme == "https") && parsed.Host != ""
+}
+
+// pickCluster asks the user to select one of the clusters that match an ambiguous reference, when the console is a
+// terminal. It returns nil if that isn't possible or if the user cancels the selection.
+func (c *runnerContext) pickCluster(ctx context.Context, key string, clusters []*ffv1.Cluster) *ffv1.Cluster {
+	result, _ := refs.Pick(
+		ctx,
+		c.console,
+		fmt.Sprintf("Name or identifier '%s' is ambiguous, select the cluster", key),
+		[]string{"ID", "NAME", "STATE"},
+		clusters,
+		func(cluster *ffv1.Cluster) []string {
+			return []string{
+				cluster.GetId(),
+				clusterName(cluster),
+				clusterState(cluster),
+			}
+		},
+	)
+	return result
+}
+
+// clusterName returns the name of the given cluster.
+func clusterName(cluster *ffv1.Cluster) string {
+	return cluster.GetMetadata().GetName()
+}
+
+// clusterState returns the state of the given cluster, without the prefix of the enum values.
+func clusterState(cluster *ffv1.Cluster) string {
+	return strings.TrimPrefix(cluster.GetStatus().GetState().String(), "CLUSTER_STATE_")
+}
+
+// isClusterType checks if the given text is one of the names of the cluster type.
+func isClusterType(value string) bool {
+	switch strings.ToLower(value) {
+	case "cluster", "clusters":
+		return true
+	default:
+		return false
+	}
+}
+
+// completeArgs completes the names of the clusters, also after the optional object type.
+func completeArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion,
+	cobra.ShellCompDirective) {
+	if len(args) == 1 && isClusterType(args[0]) {
+		return completion.ObjectsOf((*ffv1.Cluster)(nil), 2)(cmd, args, toComplete)
+	}
+	return completion.ObjectsOf((*ffv1.Cluster)(nil), 1)(cmd, args, toComplete)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package open
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Console URL check", func() {
+	DescribeTable(
+		"Accepts only web URLs",
+		func(text string, expected bool) {
+			Expect(isWebUrl(text)).To(Equal(expected))
+		},
+		Entry("HTTPS", "https://console.my-cluster.example.com", true),
+		Entry("HTTP", "http://console.my-cluster.example.com:8080/path", true),
+		Entry("Upper case scheme", "HTTPS://console.my-cluster.example.com", true),
+		Entry("File", "file:///etc/passwd", false),
+		Entry("Local path", "/usr/bin/xterm", false),
+		Entry("Relative path", "console.sh", false),
+		Entry("Other scheme", "ssh://console.my-cluster.example.com", false),
+		Entry("JavaScript", "javascript:alert(1)", false),
+		Entry("Windows path", `C:\Windows\System32\calc.exe`, false),
+		Entry("No host", "https:///etc/passwd", false),
+		Entry("Empty", "", false),
+	)
+})
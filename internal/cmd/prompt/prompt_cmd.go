@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// DefaultWarn is the default time before the expiration of the token when the prompt starts to show a warning.
+const DefaultWarn = 10 * time.Minute
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "prompt [OPTION]...",
+		Short: "Print a short description of the current server for shell prompts",
+		Long: "Print a short description of the server that the next command will use, intended to be " +
+			"embedded in shell prompts, for example in the 'PS1' variable of Bash or in a custom command " +
+			"of Starship. The description contains the name of the server, markers if the server is " +
+			"marked as production or the configuration is read only, and a warning when the credentials " +
+			"have expired or are about to expire. The command doesn't connect to the server, and it prints " +
+			"nothing if there is no configuration, so that it is safe to run it for every prompt.",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.args.format,
+		"format",
+		"",
+		"Go template used to render the prompt. The available fields are '.Server', '.Address', "+
+			"'.ReadOnly', '.Production', '.Expiry' and '.Warning'. For example, "+
+			"'{{ .Server }}{{ if .ReadOnly }} (ro){{ end }}'.",
+	)
+	flags.DurationVar(
+		&runner.args.warn,
+		"warn",
+		DefaultWarn,
+		"How long before the expiration of the credentials the prompt starts to show a warning.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		format string
+		warn   time.Duration
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+// Info contains the details of the configuration that are available to the prompt template.
+type Info struct {
+	Server     string    `json:"server"`
+	Address    string    `json:"address"`
+	ReadOnly   bool      `json:"read_only,omitempty"`
+	Production bool      `json:"production,omitempty"`
+	Expiry     time.Time `json:"expiry,omitzero"`
+	Warning    string    `json:"warning,omitempty"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Parse the template before anything else, so that mistakes are reported even if there is no configuration:
+	var tmpl *template.Template
+	if c.args.format != "" {
+		var err error
+		tmpl, err = template.New("prompt").Parse(c.args.format)
+		if err != nil {
+			return exit.Usagef("failed to parse format: %v", err)
+		}
+	}
+
+	// The prompt is evaluated by the shell very frequently, so a broken or missing configuration shouldn't result
+	// in error messages, just in an empty prompt:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		c.logger.DebugContext(
+			ctx,
+			"Failed to load configuration for prompt",
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	if cfg == nil || cfg.Address == "" {
+		return nil
+	}
+	info := NewInfo(cfg, time.Now(), c.args.warn)
+
+	// Render the result:
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, info)
+		return nil
+	}
+	var text string
+	if tmpl != nil {
+		buffer := &bytes.Buffer{}
+		err = tmpl.Execute(buffer, info)
+		if err != nil {
+			return exit.Usagef("failed to execute format: %v", err)
+		}
+		text = buffer.String()
+	} else {
+		text = info.String()
+	}
+	c.console.Printf(ctx, "%s\n", text)
+	return nil
+}
+
+// NewInfo extracts from the configuration the information shown in the prompt. The warning is set when the
+// credentials can't be renewed without user intervention and they have expired, or will expire in less than the
+// given time.
+func NewInfo(cfg *config.Config, now time.Time, warn time.Duration) *Info {
+	result := &Info{
+		Server:     cfg.ServerName(),
+		Address:    cfg.Address,
+		ReadOnly:   cfg.ReadOnly,
+		Production: cfg.Production,
+		Expiry:     cfg.TokenExpiry,
+	}
+
+	// When there is a refresh token, a token script or a client secret the access token is renewed automatically,
+	// so there is no need to warn the user about its expiration:
+	renewable := cfg.RefreshToken != "" || cfg.TokenScript != "" || cfg.OAuthClientSecret != ""
+	if renewable || cfg.TokenExpiry.IsZero() {
+		return result
+	}
+	left := cfg.TokenExpiry.Sub(now)
+	switch {
+	case left <= 0:
+		result.Warning = "expired"
+	case left < warn:
+		result.Warning = fmt.Sprintf("expires in %s", left.Round(time.Minute))
+	}
+	return result
+}
+
+// String returns the default representation of the prompt, for example 'api.example.com [prod] (expires in 5m0s)'.
+func (i *Info) String() string {
+	buffer := &strings.Builder{}
+	buffer.WriteString(i.Server)
+	if i.Production {
+		buffer.WriteString(" [prod]")
+	}
+	if i.ReadOnly {
+		buffer.WriteString(" [ro]")
+	}
+	if i.Warning != "" {
+		fmt.Fprintf(buffer, " (%s)", i.Warning)
+	}
+	return buffer.String()
+}
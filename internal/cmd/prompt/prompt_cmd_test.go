@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package prompt
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+)
+
+var _ = Describe("Prompt", func() {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable(
+		"Server name",
+		func(address, expected string) {
+			info := NewInfo(&config.Config{Address: address}, now, DefaultWarn)
+			Expect(info.Server).To(Equal(expected))
+			Expect(info.Address).To(Equal(address))
+		},
+		Entry("Host only", "api.example.com", "api.example.com"),
+		Entry("Host and port", "api.example.com:8443", "api.example.com"),
+		Entry("URL", "https://api.example.com:443/", "api.example.com"),
+		Entry("IPv6", "[::1]:8000", "::1"),
+	)
+
+	It("Shows the read only marker", func() {
+		info := NewInfo(&config.Config{Address: "api.example.com", ReadOnly: true}, now, DefaultWarn)
+		Expect(info.String()).To(Equal("api.example.com [ro]"))
+	})
+
+	It("Shows the production marker", func() {
+		info := NewInfo(&config.Config{Address: "api.example.com", Production: true}, now, DefaultWarn)
+		Expect(info.String()).To(Equal("api.example.com [prod]"))
+	})
+
+	DescribeTable(
+		"Expiry warning",
+		func(cfg *config.Config, expected string) {
+			cfg.Address = "api.example.com"
+			info := NewInfo(cfg, now, DefaultWarn)
+			Expect(info.Warning).To(Equal(expected))
+		},
+		Entry(
+			"No expiry",
+			&config.Config{},
+			"",
+		),
+		Entry(
+			"Far from expiry",
+			&config.Config{TokenExpiry: now.Add(time.Hour)},
+			"",
+		),
+		Entry(
+			"Close to expiry",
+			&config.Config{TokenExpiry: now.Add(5 * time.Minute)},
+			"expires in 5m0s",
+		),
+		Entry(
+			"Expired",
+			&config.Config{TokenExpiry: now.Add(-time.Minute)},
+			"expired",
+		),
+		Entry(
+			"Expired but with refresh token",
+			&config.Config{TokenExpiry: now.Add(-time.Minute), RefreshToken: "my-refresh"},
+			"",
+		),
+		Entry(
+			"Expired but with token script",
+			&config.Config{TokenExpiry: now.Add(-time.Minute), TokenScript: "my-script"},
+			"",
+		),
+	)
+
+	It("Includes the warning in the default representation", func() {
+		cfg := &config.Config{
+			Address:     "api.example.com",
+			ReadOnly:    true,
+			TokenExpiry: now.Add(-time.Minute),
+		}
+		Expect(NewInfo(cfg, now, DefaultWarn).String()).To(Equal("api.example.com [ro] (expired)"))
+	})
+})
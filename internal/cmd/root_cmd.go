@@ -14,8 +14,9 @@ language governing permissions and limitations under the License.
 package cmd
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 
@@ -23,16 +24,48 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/osac-project/fulfillment-cli/internal/cmd/annotate"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/check"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/clean"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/completion"
+	configcmd "github.com/osac-project/fulfillment-cli/internal/cmd/config"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/convert"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/create"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/delete"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/describe"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/diff"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/edit"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/explain"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/explainerror"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/favorite"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/filters"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/generate"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/get"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/get/events"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/graph"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/importcmd"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/label"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/lint"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/login"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/logout"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/open"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/prompt"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/settemplate"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/status"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/top"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/tree"
+	"github.com/osac-project/fulfillment-cli/internal/cmd/verifybinary"
 	"github.com/osac-project/fulfillment-cli/internal/cmd/version"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/help"
+	"github.com/osac-project/fulfillment-cli/internal/logrotation"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/packages"
+	"github.com/osac-project/fulfillment-cli/internal/recording"
+	"github.com/osac-project/fulfillment-cli/internal/telemetry"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/timing"
+	"github.com/osac-project/fulfillment-cli/internal/tracing"
 )
 
 func Root() *cobra.Command {
@@ -48,70 +81,308 @@ func Root() *cobra.Command {
 
 	// Add flags:
 	logging.AddFlags(result.PersistentFlags())
+	output.AddFlags(result.PersistentFlags())
+	packages.AddFlags(result.PersistentFlags())
+	terminal.AddFlags(result.PersistentFlags())
+	terminal.AddUnitsFlags(result.PersistentFlags())
+	terminal.AddTimeZoneFlags(result.PersistentFlags())
+	timing.AddFlags(result.PersistentFlags())
+	tracing.AddFlags(result.PersistentFlags())
+	recording.AddFlags(result.PersistentFlags())
+	config.AddProductionFlags(result.PersistentFlags())
+
+	// Replace the help function with one that can also generate machine readable output. Note that the help flag
+	// needs to be explicitly added here because otherwise it is added after looking up the command, and then in
+	// '--help -o json' the '-o' would be considered the value of '--help'.
+	result.SetHelpFunc(help.Func(result.HelpFunc()))
+	result.InitDefaultHelpFlag()
 
 	// Add commands:
 	result.AddCommand(annotate.Cmd())
+	result.AddCommand(check.Cmd())
+	result.AddCommand(clean.Cmd())
+	result.AddCommand(completion.Cmd())
+	result.AddCommand(configcmd.Cmd())
+	result.AddCommand(convert.Cmd())
 	result.AddCommand(create.Cmd())
 	result.AddCommand(delete.Cmd())
 	result.AddCommand(describe.Cmd())
+	result.AddCommand(diff.Cmd())
 	result.AddCommand(edit.Cmd())
+	result.AddCommand(events.Cmd())
+	result.AddCommand(explain.Cmd())
+	result.AddCommand(explainerror.Cmd())
+	result.AddCommand(favorite.Cmd())
+	result.AddCommand(filters.Cmd())
+	result.AddCommand(generate.Cmd())
 	result.AddCommand(get.Cmd())
+	result.AddCommand(graph.Cmd())
+	result.AddCommand(importcmd.Cmd())
 	result.AddCommand(label.Cmd())
+	result.AddCommand(lint.Cmd())
 	result.AddCommand(login.Cmd())
 	result.AddCommand(logout.Cmd())
+	result.AddCommand(open.Cmd())
+	result.AddCommand(prompt.Cmd())
+	result.AddCommand(settemplate.Cmd())
+	result.AddCommand(status.Cmd())
+	result.AddCommand(top.Cmd())
+	result.AddCommand(tree.Cmd())
+	result.AddCommand(verifybinary.Cmd())
 	result.AddCommand(version.Cmd())
 
+	// Make sure that wrong flags and arguments result in the usage exit code. The flag error function is inherited by
+	// the sub-commands, but the argument validators need to be replaced in each of them.
+	result.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return exit.WithCode(exit.Usage, err)
+	})
+	wrapArgs(result)
+
 	return result
 }
 
+// wrapArgs replaces the argument validators of the given command and of all its sub-commands with validators that
+// return errors with the usage exit code.
+func wrapArgs(cmd *cobra.Command) {
+	if cmd.Args != nil {
+		validator := cmd.Args
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			return exit.WithCode(exit.Usage, validator(cmd, args))
+		}
+	}
+	for _, child := range cmd.Commands() {
+		wrapArgs(child)
+	}
+}
+
 type runnerContext struct {
 }
 
 func (c *runnerContext) persistentPreRun(cmd *cobra.Command, args []string) error {
-	// In order to avoid mixing log messages with output we configure the log to go by default to a file in the user
-	// cache directory.
-	//
-	// The path of the cache directory and of the log file are calculated from the name from the name of the binary.
-	// For example, if the name of the binary is `fulfillment-cli` then the cache directory will be
-	// `~/.cache/fulfillment-cli` and the log file will be `~/.cache/fufillment-cli/fulfillment-cli.log`.
-	baseName := filepath.Base(os.Args[0])
-	userCacheDir, err := os.UserCacheDir()
+	// Load the settings of the configuration file once, as most of the following steps need them. This doesn't load
+	// the tokens or create the CA pool, that is done only by the commands that connect to the server.
+	settings, err := config.LoadFile()
 	if err != nil {
 		return err
 	}
-	cacheDir := filepath.Join(userCacheDir, baseName)
-	err = os.MkdirAll(cacheDir, 0700)
-	if errors.Is(err, os.ErrExist) {
-		err = nil
-	}
+
+	// In order to avoid mixing log messages with output we configure the log to go by default to a file in the user
+	// state directory.
+	//
+	// The path of the state directory and of the log file are calculated from the name from the name of the binary.
+	// For example, if the name of the binary is `fulfillment-cli` then the state directory will be
+	// `~/.local/state/fulfillment-cli` and the log file will be `~/.local/state/fufillment-cli/fulfillment-cli.log`.
+	// The state directory can be changed with the `XDG_STATE_HOME` environment variable or with the `state_dir`
+	// setting of the configuration file.
+	stateDir, err := settings.StateDirectory()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get state directory: %w", err)
+	}
+	logFile := filepath.Join(stateDir, filepath.Base(os.Args[0])+".log")
+
+	// Rotate the default log file if it is too large or too old, unless the user redirected the log somewhere else
+	// with the `--log-file` flag. Failing to rotate shouldn't prevent the command from running, so the error is saved
+	// and reported once the logger is created.
+	var rotationErr error
+	if !cmd.Flags().Changed("log-file") {
+		var policy logrotation.Policy
+		policy, rotationErr = settings.LogRotation()
+		if rotationErr == nil {
+			_, rotationErr = logrotation.Rotate(logFile, policy)
+		}
 	}
-	logFile := filepath.Join(cacheDir, baseName+".log")
 
 	// By the default the logger is configured to write to the log file, and only errors. This Will be overriden by
-	// the command line flags.
-	logger, err := logging.NewLogger().
-		SetFile(logFile).
+	// the command line flags. When tracing of calls is enabled the default level is 'info' instead, as otherwise the
+	// traces would not be written.
+	tracingEnabled, tracingMessages := tracing.EnabledFromFlags(cmd.Flags())
+	loggerBuilder := logging.NewLogger().
+		SetFile(logFile)
+	if tracingEnabled {
+		loggerBuilder.SetLevel(slog.LevelInfo.String())
+	}
+	logger, err := loggerBuilder.
 		SetFlags(cmd.Flags()).
 		Build()
 	if err != nil {
 		return fmt.Errorf("failed to create logger: %w", err)
 	}
+	if rotationErr != nil {
+		logger.WarnContext(
+			cmd.Context(),
+			"Failed to rotate log file",
+			slog.String("file", logFile),
+			slog.Any("error", rotationErr),
+		)
+	}
+
+	// Commands that modify objects in the server aren't allowed if the configuration is read only:
+	err = settings.CheckWritable(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the output format. When it is the machine readable format the messages intended for humans are written to
+	// the standard error, so that the standard output contains only the results.
+	format, err := output.FormatFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the color mode and theme. An error in the theme shouldn't prevent the command from running, so in that case
+	// the default theme is used.
+	color, err := terminal.ColorFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	theme, err := settings.ColorTheme()
+	if err != nil {
+		logger.WarnContext(
+			cmd.Context(),
+			"Failed to get color theme, will use the default",
+			slog.Any("error", err),
+		)
+		theme = nil
+	}
+
+	// Get the system of units used to display sizes. As for the theme, an error in the configuration file shouldn't
+	// prevent the command from running, but an error in the flags should.
+	configuredUnits, err := settings.SizeUnits()
+	if err != nil {
+		logger.WarnContext(
+			cmd.Context(),
+			"Failed to get units, will use the default",
+			slog.Any("error", err),
+		)
+		configuredUnits = ""
+	}
+	units, err := terminal.UnitsFromFlags(cmd.Flags(), configuredUnits)
+	if err != nil {
+		return exit.WithCode(exit.Usage, err)
+	}
+
+	// Get the time zone used to display timestamps, with the same treatment of errors than the units:
+	configuredTimeZone, err := settings.TimeZoneName()
+	if err != nil {
+		logger.WarnContext(
+			cmd.Context(),
+			"Failed to get time zone, will use the default",
+			slog.Any("error", err),
+		)
+		configuredTimeZone = ""
+	}
+	timeZone, err := terminal.TimeZoneFromFlags(cmd.Flags(), configuredTimeZone)
+	if err != nil {
+		return exit.WithCode(exit.Usage, err)
+	}
+
+	// Get the file where the calls to the server should be recorded, or from where they should be replayed:
+	recordFile, replayFile, err := recording.FilesFromFlags(cmd.Flags())
+	if err != nil {
+		return exit.WithCode(exit.Usage, err)
+	}
 
 	// Create the console:
-	console, err := terminal.NewConsole().
+	consoleBuilder := terminal.NewConsole().
 		SetLogger(logger).
-		Build()
+		SetColor(color).
+		SetTheme(theme).
+		SetUnits(units).
+		SetTimeZone(timeZone)
+	if format == output.FormatJson {
+		consoleBuilder.SetMessageWriter(os.Stderr)
+	}
+	console, err := consoleBuilder.Build()
 	if err != nil {
 		return fmt.Errorf("failed to create console: %w", err)
 	}
 
-	// Replace the default context with one that contains the logger and the console:
+	// Commands that modify objects in the server need an explicit confirmation if the configuration is marked as
+	// production:
+	err = settings.CheckProduction(cmd.Context(), cmd, console, os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	// Get the packages override, if any:
+	packagesOverride, err := packages.OverrideFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Replace the default context with one that contains the logger, the console, the output format, the statistics,
+	// the tracer, the recorder or replayer, the OpenTelemetry instrumentation and the packages override:
 	ctx := cmd.Context()
 	ctx = logging.LoggerIntoContext(ctx, logger)
 	ctx = terminal.ConsoleIntoContext(ctx, console)
+	ctx = output.FormatIntoContext(ctx, format)
+	ctx = timing.StatsIntoContext(ctx, timing.NewStats())
+	if tracingEnabled {
+		tracer, err := tracing.NewTracer().
+			SetLogger(logger).
+			SetMessages(tracingMessages).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to create tracer: %w", err)
+		}
+		ctx = tracing.TracerIntoContext(ctx, tracer)
+	}
+	if recordFile != "" {
+		recorder, err := recording.NewRecorder().
+			SetLogger(logger).
+			SetFile(recordFile).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to create recorder: %w", err)
+		}
+		ctx = recording.RecorderIntoContext(ctx, recorder)
+	}
+	if replayFile != "" {
+		replayer, err := recording.NewReplayer().
+			SetLogger(logger).
+			SetFile(replayFile).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to create replayer: %w", err)
+		}
+		ctx = recording.ReplayerIntoContext(ctx, replayer)
+	}
+	ctx, err = c.startTelemetry(ctx, cmd, logger, settings)
+	if err != nil {
+		return err
+	}
+	if packagesOverride != nil {
+		logger.DebugContext(
+			ctx,
+			"Overriding packages",
+			slog.Any("packages", packagesOverride),
+		)
+		ctx = packages.OverrideIntoContext(ctx, packagesOverride)
+	}
 	cmd.SetContext(ctx)
 
 	return nil
 }
+
+// startTelemetry creates the OpenTelemetry instrumentation, if it is enabled in the configuration file or in the
+// environment, and starts the span of the command.
+func (c *runnerContext) startTelemetry(ctx context.Context, cmd *cobra.Command, logger *slog.Logger,
+	settings *config.Config) (result context.Context, err error) {
+	result = ctx
+	endpoint, headers := settings.Telemetry()
+	if !telemetry.Enabled(endpoint) {
+		return
+	}
+	instrumentation, err := telemetry.NewInstrumentation().
+		SetLogger(logger).
+		SetEndpoint(endpoint).
+		SetHeaders(headers).
+		Build()
+	if err != nil {
+		err = fmt.Errorf("failed to create OpenTelemetry instrumentation: %w", err)
+		return
+	}
+	result = telemetry.InstrumentationIntoContext(ctx, instrumentation)
+	result = instrumentation.Start(result, cmd)
+	return
+}
@@ -0,0 +1,456 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package settemplate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Names of the fields used to find the template of objects:
+const (
+	specFieldName               = protoreflect.Name("spec")
+	templateFieldName           = protoreflect.Name("template")
+	templateParametersFieldName = protoreflect.Name("template_parameters")
+	parametersFieldName         = protoreflect.Name("parameters")
+)
+
+// Cmd creates and returns the command that changes the template of objects.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "set-template OBJECT ID|NAME... --template TEMPLATE",
+		Short: "Change the template of objects",
+		Long: "Change the template of objects, keeping the values of the template parameters that the new " +
+			"template also has with the same type, and checking that all its required parameters have values.",
+		Example: "  # Change the template of a compute instance:\n" +
+			"  fulfillment-cli set-template computeinstance my-instance --template my-new-template\n\n" +
+			"  # Change the template of two compute instances, giving a value for a new required parameter:\n" +
+			"  fulfillment-cli set-template computeinstance my-instance your-instance --template my-new-template " +
+			"-p disk_size=100",
+		Annotations: map[string]string{
+			config.MutatingAnnotation: "true",
+		},
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
+	}
+	flags := result.Flags()
+	flags.StringVarP(
+		&runner.args.template,
+		"template",
+		"t",
+		"",
+		"Identifier or name of the new template.",
+	)
+	templateparams.AddFlags(flags, &runner.args.templateParameters)
+	refs.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		template           string
+		templateParameters templateparams.Args
+	}
+	logger         *slog.Logger
+	console        *terminal.Console
+	conn           *grpc.ClientConn
+	helper         *reflection.Helper
+	objectHelper   *reflection.ObjectHelper
+	templateHelper *reflection.ObjectHelper
+	refMode        refs.Mode
+}
+
+// pendingChange contains the details of the change of the template of one object.
+type pendingChange struct {
+	object  proto.Message
+	id      string
+	old     string
+	mapping templateparams.Mapping
+	values  map[string]*anypb.Any
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and the console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper:
+	c.helper, err = reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+	c.console.SetHelper(c.helper)
+
+	// Check that the object type has been specified, and that it uses templates:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Types": c.templatedTypes(),
+		})
+		return exit.Usage
+	}
+	c.objectHelper = c.helper.Lookup(args[0])
+	if c.objectHelper != nil {
+		c.templateHelper = c.lookupTemplateHelper(c.objectHelper)
+	}
+	if c.templateHelper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Object": args[0],
+			"Types":  c.templatedTypes(),
+		})
+		return exit.Usage
+	}
+
+	// Check that the objects and the template have been specified:
+	if len(args) < 2 {
+		c.console.Render(ctx, "no_id.txt", nil)
+		return exit.Usage
+	}
+	if c.args.template == "" {
+		c.console.Render(ctx, "no_template.txt", map[string]any{
+			"Object":    c.objectHelper.Singular(),
+			"Ref":       args[1],
+			"Templates": c.templateHelper.Plural(),
+		})
+		return exit.Usage
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Find the new template and get the definitions of its parameters:
+	template, err := c.findTemplate(ctx)
+	if err != nil {
+		return err
+	}
+	templateId := c.templateHelper.GetId(template)
+	definitions := c.templateDefinitions(template)
+
+	// Calculate all the changes before updating any object, so that problems with the parameters of one of them
+	// don't result in only some of them updated:
+	changes := make([]*pendingChange, 0, len(args)-1)
+	for _, ref := range args[1:] {
+		var object proto.Message
+		object, err = c.findObject(ctx, ref)
+		if err != nil {
+			return err
+		}
+		change := c.prepareChange(object, definitions)
+		var parser *templateparams.Parser
+		parser, err = templateparams.NewParser().
+			SetLogger(c.logger).
+			AddDefinitions(definitions...).
+			SetInitialValues(change.mapping.Values).
+			SetFileEncoding(c.args.templateParameters.FileEncoding).
+			SetFileMaxSize(c.args.templateParameters.FileMaxSize).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to create template parameters parser: %w", err)
+		}
+		var issues []string
+		change.values, issues = parser.Parse(
+			ctx,
+			c.args.templateParameters.Values,
+			c.args.templateParameters.Files,
+		)
+		if len(issues) > 0 {
+			c.console.Render(ctx, "template_parameter_issues.txt", map[string]any{
+				"Id":           change.id,
+				"Issues":       issues,
+				"Object":       c.objectHelper.Singular(),
+				"Parameters":   parser.Valid(),
+				"Template":     templateId,
+				"TemplateType": c.templateHelper.Singular(),
+			})
+			return exit.Usage
+		}
+		changes = append(changes, change)
+	}
+
+	// Apply the changes:
+	updated := make([]proto.Message, 0, len(changes))
+	for _, change := range changes {
+		for _, name := range change.mapping.Dropped {
+			c.console.Printf(
+				ctx,
+				"Warning: template '%s' doesn't have parameter '%s', it will be removed from %s '%s'.\n",
+				templateId, name, c.objectHelper.Singular(), change.id,
+			)
+		}
+		for _, name := range change.mapping.Incompatible {
+			if _, ok := change.values[name]; ok {
+				continue
+			}
+			c.console.Printf(
+				ctx,
+				"Warning: parameter '%s' of template '%s' has a different type, it will be removed from "+
+					"%s '%s'.\n",
+				name, templateId, c.objectHelper.Singular(), change.id,
+			)
+		}
+		// Save the result, sending only the changed fields so that concurrent changes to other fields aren't lost. If
+		// nothing changed don't send anything, as an update without fields would replace the complete object.
+		original := proto.Clone(change.object)
+		c.setTemplate(change.object, templateId, change.values)
+		paths := reflection.ChangedPaths(original, change.object)
+		if len(paths) == 0 {
+			c.console.Printf(
+				ctx,
+				"No changes to the template of %s '%s', it already uses template '%s' with the same parameters.\n",
+				c.objectHelper.Singular(), change.id, templateId,
+			)
+			continue
+		}
+		object, err := c.objectHelper.Update(ctx, change.object, paths...)
+		if err != nil {
+			return fmt.Errorf("failed to change template of %s '%s': %w", c.objectHelper.Singular(), change.id, err)
+		}
+		c.console.Printf(
+			ctx,
+			"Changed template of %s '%s' from '%s' to '%s'.\n",
+			c.objectHelper.Singular(), change.id, change.old, templateId,
+		)
+		updated = append(updated, object)
+	}
+
+	// Write the updated objects if the machine readable output format was requested:
+	return output.WriteObjects(ctx, updated...)
+}
+
+// templatedTypes returns the singular names of the object types that use templates.
+func (c *runnerContext) templatedTypes() []string {
+	var result []string
+	for _, name := range c.helper.Singulars() {
+		objectHelper := c.helper.Lookup(name)
+		if objectHelper != nil && c.lookupTemplateHelper(objectHelper) != nil {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// lookupTemplateHelper returns the helper for the templates of the given object type, or nil if the object doesn't
+// use templates. Objects use templates when their spec has the 'template' and 'template_parameters' fields, and there
+// is a type with the same name and the 'Template' suffix, for example 'ComputeInstanceTemplate'.
+func (c *runnerContext) lookupTemplateHelper(objectHelper *reflection.ObjectHelper) *reflection.ObjectHelper {
+	specField := objectHelper.Descriptor().Fields().ByName(specFieldName)
+	if specField == nil || specField.Message() == nil {
+		return nil
+	}
+	specFields := specField.Message().Fields()
+	if specFields.ByName(templateFieldName) == nil || specFields.ByName(templateParametersFieldName) == nil {
+		return nil
+	}
+	templateType := objectHelper.FullName() + "Template"
+	return c.helper.Lookup(string(templateType))
+}
+
+// templateDefinitions returns the definitions of the parameters of the given template.
+func (c *runnerContext) templateDefinitions(template proto.Message) []templateparams.Definition {
+	message := template.ProtoReflect()
+	field := message.Descriptor().Fields().ByName(parametersFieldName)
+	if field == nil || !field.IsList() {
+		return nil
+	}
+	list := message.Get(field).List()
+	result := make([]templateparams.Definition, 0, list.Len())
+	for i := range list.Len() {
+		definition, ok := list.Get(i).Message().Interface().(templateparams.Definition)
+		if ok {
+			result = append(result, definition)
+		}
+	}
+	return result
+}
+
+// prepareChange extracts the current template and parameters of the object, and maps the parameters to the
+// definitions of the new template.
+func (c *runnerContext) prepareChange(object proto.Message, definitions []templateparams.Definition) *pendingChange {
+	spec := c.spec(object)
+	specFields := spec.Descriptor().Fields()
+	values := map[string]*anypb.Any{}
+	spec.Get(specFields.ByName(templateParametersFieldName)).Map().Range(
+		func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			values[key.String()] = value.Message().Interface().(*anypb.Any)
+			return true
+		},
+	)
+	return &pendingChange{
+		object:  object,
+		id:      c.objectHelper.GetId(object),
+		old:     spec.Get(specFields.ByName(templateFieldName)).String(),
+		mapping: templateparams.Map(values, definitions),
+	}
+}
+
+// setTemplate replaces the template and the template parameters of the object.
+func (c *runnerContext) setTemplate(object proto.Message, template string, values map[string]*anypb.Any) {
+	spec := c.spec(object)
+	specFields := spec.Descriptor().Fields()
+	spec.Set(specFields.ByName(templateFieldName), protoreflect.ValueOfString(template))
+	parametersField := specFields.ByName(templateParametersFieldName)
+	spec.Clear(parametersField)
+	parameters := spec.Mutable(parametersField).Map()
+	for name, value := range values {
+		parameters.Set(
+			protoreflect.ValueOfString(name).MapKey(),
+			protoreflect.ValueOfMessage(value.ProtoReflect()),
+		)
+	}
+}
+
+// spec returns the mutable spec of the object.
+func (c *runnerContext) spec(object proto.Message) protoreflect.Message {
+	message := object.ProtoReflect()
+	return message.Mutable(message.Descriptor().Fields().ByName(specFieldName)).Message()
+}
+
+// findTemplate finds the new template by identifier or name, preferring the template whose identifier matches exactly.
+// If there is no match or multiple matches it explains the problem to the user and returns an error that contains the
+// exit code.
+func (c *runnerContext) findTemplate(ctx context.Context) (result proto.Message, err error) {
+	ref := c.args.template
+	response, err := c.templateHelper.List(ctx, reflection.ListOptions{
+		Filter: refs.Any.Filter(ref),
+		Limit:  10,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find template '%s': %w", ref, err)
+		return
+	}
+	matches := refs.Resolve(refs.Any, ref, response.Items, c.templateHelper.GetId, c.templateHelper.GetName)
+	switch len(matches) {
+	case 1:
+		result = matches[0]
+		return
+	case 0:
+		var examples reflection.ListResult
+		examples, err = c.templateHelper.List(ctx, reflection.ListOptions{
+			Limit: 10,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to list templates: %w", err)
+			return
+		}
+		c.console.Render(ctx, "template_not_found.txt", map[string]any{
+			"Examples":  examples.Items,
+			"Ref":       ref,
+			"Templates": c.templateHelper.Plural(),
+		})
+		err = exit.NotFound
+		return
+	default:
+		c.console.Render(ctx, "template_conflict.txt", map[string]any{
+			"Matches": matches,
+			"Object":  c.objectHelper.Singular(),
+			"Ref":     ref,
+			"Total":   response.Total,
+		})
+		err = exit.General
+		return
+	}
+}
+
+// findObject tries to find an object by identifier or name, preferring the object whose identifier matches exactly. If
+// there is no match or multiple matches it explains the problem to the user and returns an error that contains the
+// exit code.
+func (c *runnerContext) findObject(ctx context.Context, ref string) (result proto.Message, err error) {
+	filter := c.refMode.Filter(ref)
+	response, err := c.objectHelper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+		Limit:  10,
+	})
+	if err != nil {
+		err = fmt.Errorf(
+			"failed to find object of type '%s' with identifier or name '%s': %w",
+			c.objectHelper, ref, err,
+		)
+		return
+	}
+	matches := refs.Resolve(c.refMode, ref, response.Items, c.objectHelper.GetId, c.objectHelper.GetName)
+	switch len(matches) {
+	case 0:
+		c.console.Render(ctx, "no_matches.txt", map[string]any{
+			"Object": c.objectHelper.Singular(),
+			"Ref":    ref,
+		})
+		err = exit.NotFound
+		return
+	case 1:
+		result = matches[0]
+		return
+	default:
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Matches": matches,
+			"Object":  c.objectHelper.Singular(),
+			"Ref":     ref,
+			"Total":   response.Total,
+		})
+		err = exit.General
+		return
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package settemplate
+
+import (
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Set template command", func() {
+	var runner *runnerContext
+
+	BeforeEach(func() {
+		logger := slog.New(slog.NewTextHandler(GinkgoWriter, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))
+
+		// The descriptors are compiled into the binary, so the server doesn't need to implement anything:
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+		helper, err := reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(conn).
+			AddPackage("fulfillment.v1", 0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		runner = &runnerContext{
+			logger: logger,
+			helper: helper,
+		}
+	})
+
+	// pack wraps a value so that it can be used as a template parameter.
+	pack := func(value proto.Message) *anypb.Any {
+		result, err := anypb.New(value)
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	It("Finds the types that use templates", func() {
+		Expect(runner.templatedTypes()).To(ConsistOf("cluster", "computeinstance"))
+		instances := runner.helper.Lookup("computeinstance")
+		Expect(runner.lookupTemplateHelper(instances).FullName()).To(
+			BeEquivalentTo("fulfillment.v1.ComputeInstanceTemplate"),
+		)
+		Expect(runner.lookupTemplateHelper(runner.helper.Lookup("host"))).To(BeNil())
+	})
+
+	It("Maps the parameters and replaces the template", func() {
+		runner.objectHelper = runner.helper.Lookup("computeinstance")
+		instance := ffv1.ComputeInstance_builder{
+			Id: "123",
+			Spec: ffv1.ComputeInstanceSpec_builder{
+				Template: "old",
+				TemplateParameters: map[string]*anypb.Any{
+					"kept":    pack(wrapperspb.String("a")),
+					"removed": pack(wrapperspb.Bool(true)),
+				},
+			}.Build(),
+		}.Build()
+		definitions := templateparams.Definitions([]*ffv1.ComputeInstanceTemplateParameterDefinition{
+			ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+				Name: "kept",
+				Type: "type.googleapis.com/google.protobuf.StringValue",
+			}.Build(),
+		})
+
+		change := runner.prepareChange(instance, definitions)
+		Expect(change.id).To(Equal("123"))
+		Expect(change.old).To(Equal("old"))
+		Expect(change.mapping.Values).To(HaveKey("kept"))
+		Expect(change.mapping.Dropped).To(Equal([]string{"removed"}))
+
+		runner.setTemplate(instance, "new", change.mapping.Values)
+		Expect(instance.GetSpec().GetTemplate()).To(Equal("new"))
+		Expect(instance.GetSpec().GetTemplateParameters()).To(HaveLen(1))
+		Expect(instance.GetSpec().GetTemplateParameters()).To(HaveKey("kept"))
+	})
+
+	It("Extracts the parameter definitions of the template", func() {
+		template := ffv1.ComputeInstanceTemplate_builder{
+			Id: "my-template",
+			Parameters: []*ffv1.ComputeInstanceTemplateParameterDefinition{
+				ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+					Name:     "disk_size",
+					Required: true,
+				}.Build(),
+			},
+		}.Build()
+		definitions := runner.templateDefinitions(template)
+		Expect(definitions).To(HaveLen(1))
+		Expect(definitions[0].GetName()).To(Equal("disk_size"))
+		Expect(definitions[0].GetRequired()).To(BeTrue())
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package settemplate
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+	"github.com/osac-project/fulfillment-cli/internal/templateparams"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		types := []string{"cluster", "computeinstance"}
+		templates := []*ffv1.ComputeInstanceTemplate{
+			ffv1.ComputeInstanceTemplate_builder{Id: "small"}.Build(),
+			ffv1.ComputeInstanceTemplate_builder{Id: "large"}.Build(),
+		}
+		err = checker.Check(consoletest.Cases{
+			"multiple_matches.txt": {
+				map[string]any{
+					"Matches": []*ffv1.ComputeInstance{
+						ffv1.ComputeInstance_builder{Id: "123"}.Build(),
+						ffv1.ComputeInstance_builder{Id: "456"}.Build(),
+					},
+					"Object": "computeinstance",
+					"Ref":    "my",
+					"Total":  int32(10),
+				},
+			},
+			"no_id.txt": {
+				nil,
+			},
+			"no_matches.txt": {
+				map[string]any{
+					"Object": "computeinstance",
+					"Ref":    "my",
+				},
+			},
+			"no_object.txt": {
+				map[string]any{
+					"Types": types,
+				},
+			},
+			"no_template.txt": {
+				map[string]any{
+					"Object":    "computeinstance",
+					"Ref":       "my",
+					"Templates": "computeinstancetemplates",
+				},
+			},
+			"object_list.txt": {
+				map[string]any{
+					"Types": types,
+				},
+			},
+			"template_conflict.txt": {
+				map[string]any{
+					"Matches": templates,
+					"Object":  "computeinstance",
+					"Ref":     "my",
+					"Total":   int32(2),
+				},
+				map[string]any{
+					"Matches": templates,
+					"Object":  "computeinstance",
+					"Ref":     "my",
+					"Total":   int32(10),
+				},
+			},
+			"template_not_found.txt": {
+				map[string]any{
+					"Examples":  templates,
+					"Ref":       "medium",
+					"Templates": "computeinstancetemplates",
+				},
+				map[string]any{
+					"Examples":  []*ffv1.ComputeInstanceTemplate{},
+					"Ref":       "medium",
+					"Templates": "computeinstancetemplates",
+				},
+			},
+			"template_parameter_issues.txt": {
+				map[string]any{
+					"Id":     "123",
+					"Issues": []string{"unknown parameter 'junk'"},
+					"Object": "computeinstance",
+					"Parameters": []templateparams.ValidParameter{
+						{
+							Name:  "cores",
+							Type:  "int32",
+							Title: "Cores",
+						},
+						{
+							Name: "memory",
+							Type: "string",
+						},
+					},
+					"Template":     "small",
+					"TemplateType": "computeinstancetemplate",
+				},
+				map[string]any{
+					"Id":           "123",
+					"Issues":       []string{"unknown parameter 'junk'"},
+					"Object":       "computeinstance",
+					"Parameters":   []templateparams.ValidParameter{},
+					"Template":     "small",
+					"TemplateType": "computeinstancetemplate",
+				},
+			},
+			"wrong_object.txt": {
+				map[string]any{
+					"Object": "junk",
+					"Types":  types,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
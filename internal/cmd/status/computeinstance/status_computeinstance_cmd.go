@@ -0,0 +1,320 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package computeinstance
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Cmd creates the command to display the status of a compute instance.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "computeinstance ID|NAME [OPTION]...",
+		Short: "Display the status of a compute instance",
+		Long: "Display the state, the IP address and the conditions of a compute instance. With the " +
+			"'--follow' option the command keeps running and prints the changes, with a timestamp, until " +
+			"it is interrupted. This is a lighter alternative to 'get --watch' when only the status of one " +
+			"compute instance is interesting.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              runner.run,
+		ValidArgsFunction: completion.ObjectsOf((*ffv1.ComputeInstance)(nil), 1),
+	}
+	flags := result.Flags()
+	flags.BoolVarP(
+		&runner.args.follow,
+		"follow",
+		"f",
+		false,
+		"Keep running and print the changes of the status.",
+	)
+	flags.DurationVar(
+		&runner.args.interval,
+		"interval",
+		defaultInterval,
+		"Time between checks of the status when the '--follow' option is used.",
+	)
+	refs.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		follow   bool
+		interval time.Duration
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	client  ffv1.ComputeInstancesClient
+	refMode refs.Mode
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Check the flags:
+	if c.args.interval <= 0 {
+		return exit.Usagef("interval should be positive, but it is %s", c.args.interval)
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the client:
+	c.client = ffv1.NewComputeInstancesClient(conn)
+
+	// Find the compute instance:
+	instance, err := c.find(ctx, args[0])
+	if err != nil || instance == nil {
+		return err
+	}
+
+	// Display the current status, and then the changes if requested:
+	c.report(ctx, Changes(nil, instance))
+	if !c.args.follow {
+		return nil
+	}
+	return c.follow(ctx, instance)
+}
+
+// find finds the compute instance that has the given identifier or name, preferring the instance whose identifier
+// matches exactly. If there is no such instance, or if there are multiple, it displays the corresponding message and
+// returns an error.
+func (c *runnerContext) find(ctx context.Context, key string) (result *ffv1.ComputeInstance, err error) {
+	response, err := c.client.List(ctx, ffv1.ComputeInstancesListRequest_builder{
+		Filter: proto.String(c.refMode.Filter(key)),
+		Limit:  proto.Int32(10),
+	}.Build())
+	if err != nil {
+		err = fmt.Errorf("failed to list compute instances: %w", err)
+		return
+	}
+	total := response.GetTotal()
+	items := refs.Resolve(c.refMode, key, response.GetItems(), (*ffv1.ComputeInstance).GetId, instanceName)
+	switch len(items) {
+	case 0:
+		c.console.Render(ctx, "no_match.txt", map[string]any{
+			"Key": key,
+		})
+		err = exit.NotFound
+	case 1:
+		result = items[0]
+	default:
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = item.GetId()
+		}
+		sort.Strings(ids)
+		ids = slices.Compact(ids)
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Ids":   ids,
+			"Key":   key,
+			"Total": total,
+		})
+		err = exit.General
+	}
+	return
+}
+
+// follow polls the compute instance and prints the changes of the status until the context is cancelled or the
+// instance is deleted. When the server can't be reached the failure is reported as a change, and polling continues.
+func (c *runnerContext) follow(ctx context.Context, previous *ffv1.ComputeInstance) error {
+	id := previous.GetId()
+	unreachable := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.args.interval):
+		}
+		response, err := c.client.Get(ctx, ffv1.ComputeInstancesGetRequest_builder{
+			Id: id,
+		}.Build())
+		if ctx.Err() != nil {
+			return nil
+		}
+		if grpcstatus.Code(err) == codes.NotFound {
+			c.report(ctx, []string{deletedChange})
+			return nil
+		}
+		if err != nil {
+			c.logger.DebugContext(
+				ctx,
+				"Failed to get compute instance, will retry",
+				slog.String("id", id),
+				slog.Duration("interval", c.args.interval),
+				slog.Any("error", err),
+			)
+			if !unreachable {
+				c.report(ctx, []string{fmt.Sprintf("%s (%s)", unreachableChange, grpcstatus.Code(err))})
+				unreachable = true
+			}
+			continue
+		}
+		if unreachable {
+			c.report(ctx, []string{reachableChange})
+			unreachable = false
+		}
+		current := response.GetObject()
+		c.report(ctx, Changes(previous, current))
+		previous = current
+	}
+}
+
+// report prints the given changes, each in a line with the current time.
+func (c *runnerContext) report(ctx context.Context, changes []string) {
+	now := c.console.Time(time.Now())
+	for _, change := range changes {
+		c.console.Printf(ctx, "%s  %s\n", now, change)
+	}
+}
+
+// Changes returns the descriptions of the changes of the state, the IP address and the conditions between the previous
+// and the current versions of the compute instance. When there is no previous version the result describes the
+// current status.
+func Changes(previous, current *ffv1.ComputeInstance) []string {
+	var result []string
+
+	// State:
+	state := trimEnum(current.GetStatus().GetState().String(), "COMPUTE_INSTANCE_STATE_")
+	if previous == nil {
+		result = append(result, fmt.Sprintf("state: %s", state))
+	} else {
+		previousState := trimEnum(previous.GetStatus().GetState().String(), "COMPUTE_INSTANCE_STATE_")
+		if state != previousState {
+			result = append(result, fmt.Sprintf("state: %s -> %s", previousState, state))
+		}
+	}
+
+	// IP address:
+	ip := dashIfEmpty(current.GetStatus().GetIpAddress())
+	if previous == nil {
+		result = append(result, fmt.Sprintf("ip: %s", ip))
+	} else {
+		previousIp := dashIfEmpty(previous.GetStatus().GetIpAddress())
+		if ip != previousIp {
+			result = append(result, fmt.Sprintf("ip: %s -> %s", previousIp, ip))
+		}
+	}
+
+	// Conditions, in the order returned by the server:
+	previousConditions := map[ffv1.ComputeInstanceConditionType]*ffv1.ComputeInstanceCondition{}
+	for _, condition := range previous.GetStatus().GetConditions() {
+		previousConditions[condition.GetType()] = condition
+	}
+	for _, condition := range current.GetStatus().GetConditions() {
+		name := trimEnum(condition.GetType().String(), "COMPUTE_INSTANCE_CONDITION_TYPE_")
+		status := trimEnum(condition.GetStatus().String(), "CONDITION_STATUS_")
+		var text string
+		previousCondition, ok := previousConditions[condition.GetType()]
+		switch {
+		case previous == nil:
+			text = fmt.Sprintf("condition %s: %s", name, status)
+		case !ok:
+			text = fmt.Sprintf("condition %s: - -> %s", name, status)
+		case previousCondition.GetStatus() != condition.GetStatus():
+			previousStatus := trimEnum(previousCondition.GetStatus().String(), "CONDITION_STATUS_")
+			text = fmt.Sprintf("condition %s: %s -> %s", name, previousStatus, status)
+		default:
+			continue
+		}
+		if condition.GetMessage() != "" {
+			text = fmt.Sprintf("%s (%s)", text, condition.GetMessage())
+		}
+		result = append(result, text)
+	}
+
+	return result
+}
+
+// trimEnum removes the prefix from the name of an enum value.
+func trimEnum(value, prefix string) string {
+	return strings.TrimPrefix(value, prefix)
+}
+
+// dashIfEmpty returns a dash if the given value is empty, so that empty values are visible.
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// Texts of the changes that don't come from the compute instance itself:
+const (
+	deletedChange     = "deleted"
+	unreachableChange = "unreachable"
+	reachableChange   = "reachable"
+)
+
+// defaultInterval is the default time between checks of the status.
+const defaultInterval = 5 * time.Second
+
+// instanceName returns the name of the given compute instance.
+func instanceName(instance *ffv1.ComputeInstance) string {
+	return instance.GetMetadata().GetName()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package computeinstance
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Changes", func() {
+	makeInstance := func(state ffv1.ComputeInstanceState, ip string,
+		conditions ...*ffv1.ComputeInstanceCondition) *ffv1.ComputeInstance {
+		return ffv1.ComputeInstance_builder{
+			Id: "123",
+			Status: ffv1.ComputeInstanceStatus_builder{
+				State:      state,
+				IpAddress:  ip,
+				Conditions: conditions,
+			}.Build(),
+		}.Build()
+	}
+
+	makeCondition := func(kind ffv1.ComputeInstanceConditionType, status sharedv1.ConditionStatus,
+		message string) *ffv1.ComputeInstanceCondition {
+		builder := ffv1.ComputeInstanceCondition_builder{
+			Type:   kind,
+			Status: status,
+		}
+		if message != "" {
+			builder.Message = proto.String(message)
+		}
+		return builder.Build()
+	}
+
+	It("Describes the current status when there is no previous version", func() {
+		current := makeInstance(
+			ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_STARTING,
+			"",
+			makeCondition(
+				ffv1.ComputeInstanceConditionType_COMPUTE_INSTANCE_CONDITION_TYPE_AVAILABLE,
+				sharedv1.ConditionStatus_CONDITION_STATUS_FALSE,
+				"Waiting for the virtual machine",
+			),
+		)
+		Expect(Changes(nil, current)).To(Equal([]string{
+			"state: STARTING",
+			"ip: -",
+			"condition AVAILABLE: FALSE (Waiting for the virtual machine)",
+		}))
+	})
+
+	It("Describes the changes of the state, the IP address and the conditions", func() {
+		previous := makeInstance(
+			ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_STARTING,
+			"",
+			makeCondition(
+				ffv1.ComputeInstanceConditionType_COMPUTE_INSTANCE_CONDITION_TYPE_AVAILABLE,
+				sharedv1.ConditionStatus_CONDITION_STATUS_FALSE,
+				"",
+			),
+		)
+		current := makeInstance(
+			ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
+			"10.0.0.5",
+			makeCondition(
+				ffv1.ComputeInstanceConditionType_COMPUTE_INSTANCE_CONDITION_TYPE_AVAILABLE,
+				sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+				"The compute instance is available",
+			),
+		)
+		Expect(Changes(previous, current)).To(Equal([]string{
+			"state: STARTING -> RUNNING",
+			"ip: - -> 10.0.0.5",
+			"condition AVAILABLE: FALSE -> TRUE (The compute instance is available)",
+		}))
+	})
+
+	It("Describes conditions that didn't exist before", func() {
+		previous := makeInstance(ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING, "10.0.0.5")
+		current := makeInstance(
+			ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
+			"10.0.0.5",
+			makeCondition(
+				ffv1.ComputeInstanceConditionType_COMPUTE_INSTANCE_CONDITION_TYPE_AVAILABLE,
+				sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+				"",
+			),
+		)
+		Expect(Changes(previous, current)).To(Equal([]string{
+			"condition AVAILABLE: - -> TRUE",
+		}))
+	})
+
+	It("Returns nothing when nothing changed", func() {
+		previous := makeInstance(
+			ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
+			"10.0.0.5",
+			makeCondition(
+				ffv1.ComputeInstanceConditionType_COMPUTE_INSTANCE_CONDITION_TYPE_AVAILABLE,
+				sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+				"The compute instance is available",
+			),
+		)
+		current := proto.Clone(previous).(*ffv1.ComputeInstance)
+		Expect(Changes(previous, current)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/cmd/status/computeinstance"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "status [OPTION]...",
+		Short: "Display the health of the server",
+		Long: "Display the health of the server, as reported by the standard gRPC health service. With the " +
+			"'--watch' option the command keeps running and prints the changes of the status, with a " +
+			"timestamp, until it is interrupted. This is useful to follow the server while it is being " +
+			"upgraded.",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	result.AddCommand(computeinstance.Cmd())
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.args.service,
+		"service",
+		"",
+		"Name of the service to check. The default is to check the overall health of the server.",
+	)
+	flags.BoolVarP(
+		&runner.args.watch,
+		"watch",
+		"w",
+		false,
+		"Watch for changes of the health of the server.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		service string
+		watch   bool
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	client  healthv1.HealthClient
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the client:
+	c.client = healthv1.NewHealthClient(conn)
+
+	if c.args.watch {
+		return c.watch(ctx)
+	}
+	return c.check(ctx)
+}
+
+// check checks the health once, and returns an error if the server isn't serving.
+func (c *runnerContext) check(ctx context.Context) error {
+	response, err := c.client.Check(ctx, &healthv1.HealthCheckRequest{
+		Service: c.args.service,
+	})
+	if grpcstatus.Code(err) == codes.Unimplemented {
+		c.console.Printf(ctx, "The server doesn't support health checks.\n")
+		return exit.Error(1)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check health: %w", err)
+	}
+	status := response.GetStatus()
+	c.console.Printf(ctx, "%s\n", status)
+	if status != healthv1.HealthCheckResponse_SERVING {
+		return exit.Error(1)
+	}
+	return nil
+}
+
+// watch prints the changes of the health until the context is cancelled. When the stream fails, for example because
+// the server is being restarted, the failure is reported as a status change and the stream is opened again after a
+// short delay.
+func (c *runnerContext) watch(ctx context.Context) error {
+	last := ""
+	report := func(text string) {
+		if text == last {
+			return
+		}
+		c.console.Printf(ctx, "%s  %s\n", c.console.Time(time.Now()), text)
+		last = text
+	}
+	for {
+		err := c.watchStream(ctx, report)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if grpcstatus.Code(err) == codes.Unimplemented {
+			c.console.Printf(ctx, "The server doesn't support health checks.\n")
+			return exit.Error(1)
+		}
+		c.logger.DebugContext(
+			ctx,
+			"Health watch stream failed, will retry",
+			slog.Duration("delay", watchRetryDelay),
+			slog.Any("error", err),
+		)
+		report(fmt.Sprintf("%s (%s)", unreachableStatus, grpcstatus.Code(err)))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchRetryDelay):
+		}
+	}
+}
+
+// watchStream opens the health watch stream and reports the received status until the stream fails.
+func (c *runnerContext) watchStream(ctx context.Context, report func(string)) error {
+	stream, err := c.client.Watch(ctx, &healthv1.HealthCheckRequest{
+		Service: c.args.service,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return grpcstatus.Error(codes.Unavailable, "stream closed by the server")
+		}
+		if err != nil {
+			return err
+		}
+		report(response.GetStatus().String())
+	}
+}
+
+// unreachableStatus is the text reported when the server can't be reached.
+const unreachableStatus = "UNREACHABLE"
+
+// watchRetryDelay is the time to wait before opening the watch stream again after a failure.
+const watchRetryDelay = 2 * time.Second
@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package hostclasses
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/utilization"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "hostclasses",
+		Aliases: []string{"hostclass"},
+		Short:   "Summarize the utilization of host classes",
+		Long: "Summarize for each host class how many hosts are requested by the host sets of all the host pools " +
+			"and how many are currently allocated. The allocated hosts of a class can only be calculated when " +
+			"the server reports them for each host set, or when the pools that use the class don't use other " +
+			"classes. Otherwise they are displayed as '-'.",
+		Example: "  # Show the utilization of all the host classes:\n" +
+			"  fulfillment-cli top hostclasses",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Get the host pools that aren't being deleted:
+	poolsHelper := helper.Lookup("hostpool")
+	if poolsHelper == nil {
+		return fmt.Errorf("the server doesn't support host pools")
+	}
+	response, err := poolsHelper.List(ctx, reflection.ListOptions{
+		Filter: "!has(this.metadata.deletion_timestamp)",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list host pools: %w", err)
+	}
+	pools := utilization.CalculatePools(response.Items)
+
+	// Get the names of the host classes. This is optional, as the identifiers used by the host pools are enough to
+	// summarize, so failures are only reported as warnings.
+	var names map[string]string
+	classesHelper := helper.Lookup("hostclass")
+	if classesHelper != nil {
+		response, err = classesHelper.List(ctx, reflection.ListOptions{})
+		if err != nil {
+			c.logger.WarnContext(
+				ctx,
+				"Failed to list host classes",
+				slog.Any("error", err),
+			)
+		} else {
+			names = utilization.ClassNames(response.Items)
+		}
+	}
+	classes := utilization.CalculateClasses(pools, names)
+	c.logger.DebugContext(
+		ctx,
+		"Calculated host class utilization",
+		slog.Int("pools", len(pools)),
+		slog.Int("classes", len(classes)),
+	)
+
+	// Write the result:
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, classes)
+		return nil
+	}
+	if len(classes) == 0 {
+		c.console.Printf(ctx, "There are no host pools requesting hosts.\n")
+		return nil
+	}
+	return c.writeTable(ctx, classes)
+}
+
+// writeTable writes the utilization of the host classes as a table.
+func (c *runnerContext) writeTable(ctx context.Context, classes []*utilization.Class) error {
+	buffer := &bytes.Buffer{}
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "HOST CLASS\tNAME\tPOOLS\tREQUESTED\tALLOCATED\tUSAGE\n")
+	for _, class := range classes {
+		name := class.Name
+		if name == "" {
+			name = "-"
+		}
+		allocated := "-"
+		usage := "-"
+		if class.Allocated != nil {
+			allocated = strconv.Itoa(*class.Allocated)
+			usage = utilization.Usage(*class.Allocated, class.Requested)
+		}
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%d\t%d\t%s\t%s\n",
+			class.Id, name, class.Pools, class.Requested, allocated, usage,
+		)
+	}
+	err := writer.Flush()
+	if err != nil {
+		return err
+	}
+	c.console.Printf(ctx, "%s", buffer.String())
+	return nil
+}
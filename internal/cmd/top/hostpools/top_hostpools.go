@@ -0,0 +1,223 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package hostpools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/pricing"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/utilization"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "hostpools",
+		Aliases: []string{"hostpool"},
+		Short:   "Summarize the utilization of host pools",
+		Long: "Summarize for each host pool how many hosts are requested by its host sets and how many are " +
+			"currently allocated. The numbers are calculated from the list of host pools, so they reflect what " +
+			"the server reports at the time the command runs. When the 'pricing' setting of the configuration " +
+			"file is present, the estimated monthly cost of the requested hosts is displayed as well.",
+		Example: "  # Show the utilization of all the host pools:\n" +
+			"  fulfillment-cli top hostpools",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+
+	// Get the host pools that aren't being deleted:
+	poolsHelper := helper.Lookup("hostpool")
+	if poolsHelper == nil {
+		return fmt.Errorf("the server doesn't support host pools")
+	}
+	response, err := poolsHelper.List(ctx, reflection.ListOptions{
+		Filter: "!has(this.metadata.deletion_timestamp)",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list host pools: %w", err)
+	}
+	pools := utilization.CalculatePools(response.Items)
+	c.logger.DebugContext(
+		ctx,
+		"Calculated host pool utilization",
+		slog.Int("pools", len(pools)),
+	)
+
+	// Estimate the costs, if there are prices configured. This is optional, so a failure is reported but doesn't
+	// prevent displaying the utilization.
+	prices, err := cfg.Prices(ctx, c.logger)
+	if err != nil {
+		c.console.Printf(ctx, "Warning: %v.\n", err)
+	}
+	var costs []*pricing.Estimate
+	if prices != nil {
+		costs = make([]*pricing.Estimate, len(pools))
+		for i, pool := range pools {
+			hosts := map[string]int{}
+			for class, counts := range pool.Classes {
+				hosts[class] = counts.Requested
+			}
+			costs[i] = prices.Estimate(hosts)
+		}
+	}
+
+	// Write the result:
+	if output.IsJson(ctx) {
+		items := make([]*poolItem, len(pools))
+		for i, pool := range pools {
+			items[i] = &poolItem{
+				Pool: pool,
+			}
+			if costs != nil {
+				items[i].Cost = costs[i]
+			}
+		}
+		c.console.RenderJson(ctx, items)
+		return nil
+	}
+	if len(pools) == 0 {
+		c.console.Printf(ctx, "There are no host pools.\n")
+		return nil
+	}
+	return c.writeTable(ctx, pools, costs)
+}
+
+// poolItem is the JSON representation of the utilization of a pool, with the estimated cost when there are prices
+// configured.
+type poolItem struct {
+	*utilization.Pool
+	Cost *pricing.Estimate `json:"cost,omitempty"`
+}
+
+// writeTable writes the utilization of the pools as a table, with a final row containing the totals when there is
+// more than one pool. The costs are optional, when present they are added as an additional column.
+func (c *runnerContext) writeTable(ctx context.Context, pools []*utilization.Pool, costs []*pricing.Estimate) error {
+	buffer := &bytes.Buffer{}
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tREQUESTED\tALLOCATED\tUSAGE")
+	if costs != nil {
+		fmt.Fprintf(writer, "\tMONTHLY COST")
+	}
+	fmt.Fprintf(writer, "\n")
+	requested := 0
+	allocated := 0
+	total := &pricing.Estimate{}
+	for i, pool := range pools {
+		name := pool.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%d\t%d\t%s",
+			pool.Id, name, pool.State, pool.Requested, pool.Allocated,
+			utilization.Usage(pool.Allocated, pool.Requested),
+		)
+		if costs != nil {
+			fmt.Fprintf(writer, "\t%s", costAmount(costs[i]))
+			total.Currency = costs[i].Currency
+			total.Add(costs[i])
+		}
+		fmt.Fprintf(writer, "\n")
+		requested += pool.Requested
+		allocated += pool.Allocated
+	}
+	if len(pools) > 1 {
+		fmt.Fprintf(
+			writer,
+			"TOTAL\t\t\t%d\t%d\t%s",
+			requested, allocated, utilization.Usage(allocated, requested),
+		)
+		if costs != nil {
+			fmt.Fprintf(writer, "\t%s", costAmount(total))
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+	err := writer.Flush()
+	if err != nil {
+		return err
+	}
+	c.console.Printf(ctx, "%s", buffer.String())
+	if len(total.Unpriced) > 0 {
+		c.console.Printf(
+			ctx,
+			"Costs marked with '*' don't include the hosts of classes without price: %s.\n",
+			strings.Join(total.Unpriced, ", "),
+		)
+	}
+	return nil
+}
+
+// costAmount returns the amount of the given estimate, followed by an asterisk when it doesn't include the hosts of
+// some class that has no price.
+func costAmount(estimate *pricing.Estimate) string {
+	result := estimate.Amount()
+	if len(estimate.Unpriced) > 0 {
+		result += "*"
+	}
+	return result
+}
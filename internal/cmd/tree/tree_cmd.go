@@ -0,0 +1,262 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/completion"
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/refs"
+	"github.com/osac-project/fulfillment-cli/internal/relations"
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "tree OBJECT ID|NAME...",
+		Short: "Display the tree of related objects",
+		Long: "Display each of the given objects followed by the indented tree of the objects that it references, " +
+			"like the template, node sets, host pools and hosts of a cluster, or the template of a compute " +
+			"instance, together with their states. This is useful to find which of the related objects is " +
+			"blocking the provisioning of the object.",
+		Example: "  # Display the tree of a cluster:\n" +
+			"  fulfillment-cli tree cluster my-cluster\n\n" +
+			"  # Display the tree of a compute instance, resolving only the directly referenced objects:\n" +
+			"  fulfillment-cli tree computeinstance my-vm --depth 1",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.Objects(-1),
+	}
+	flags := result.Flags()
+	flags.IntVar(
+		&runner.args.depth,
+		"depth",
+		rendering.DefaultTreeDepth,
+		"Number of levels of related objects to retrieve.",
+	)
+	refs.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		depth int
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+	conn    *grpc.ClientConn
+	helper  *reflection.ObjectHelper
+	refMode refs.Mode
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and the console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Check the flags:
+	if c.args.depth < 1 {
+		return exit.Usagef("depth should be at least one, but it is %d", c.args.depth)
+	}
+	c.refMode, err = refs.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Get the configuration:
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer c.conn.Close()
+
+	// Create the reflection helper:
+	helper, err := reflection.NewHelper().
+		SetLogger(c.logger).
+		SetConnection(c.conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create reflection tool: %w", err)
+	}
+	c.console.SetHelper(helper)
+
+	// Check that the object type has been specified:
+	if len(args) == 0 {
+		c.console.Render(ctx, "no_object.txt", map[string]any{
+			"Helper": helper,
+		})
+		return exit.Usage
+	}
+
+	// Get the object helper:
+	c.helper = helper.Lookup(args[0])
+	if c.helper == nil {
+		c.console.Render(ctx, "wrong_object.txt", map[string]any{
+			"Helper": helper,
+			"Object": args[0],
+		})
+		return exit.Usage
+	}
+
+	// Check that at least one identifier or name has been specified:
+	if len(args) < 2 {
+		c.console.Render(ctx, "no_id.txt", nil)
+		return exit.Usage
+	}
+
+	// Find the objects:
+	var objects []proto.Message
+	for _, ref := range args[1:] {
+		var object proto.Message
+		object, err = c.findObject(ctx, ref)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+
+	// In machine readable format write the nodes and links of the graph of related objects, as the tree is only
+	// intended for humans:
+	if output.IsJson(ctx) {
+		var graph *relations.Graph
+		graph, err = relations.NewGraph().
+			SetLogger(c.logger).
+			SetHelper(helper).
+			SetMaxDepth(c.args.depth).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to create graph: %w", err)
+		}
+		for _, object := range objects {
+			graph.Add(ctx, object)
+		}
+		c.console.RenderJson(ctx, map[string]any{
+			"nodes": graph.Nodes(),
+			"links": graph.Links(),
+		})
+		return nil
+	}
+
+	// Write the tree:
+	renderer, err := rendering.NewTreeRenderer().
+		SetLogger(c.logger).
+		SetHelper(helper).
+		SetWriter(c.console).
+		SetMaxDepth(c.args.depth).
+		SetTheme(c.console.Theme()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create tree renderer: %w", err)
+	}
+	return renderer.Render(ctx, objects)
+}
+
+// findObject tries to find an object by identifier or name, preferring the object whose identifier matches exactly. If
+// there are multiple matches and the console is interactive it asks the user to pick one. If there are no matches, or
+// the ambiguity can't be resolved, it explains the problem to the user and returns an error that contains the exit
+// code.
+func (c *runnerContext) findObject(ctx context.Context, ref string) (result proto.Message, err error) {
+	// Find the objects matching the reference (identifier or name):
+	filter := c.refMode.Filter(ref)
+	response, err := c.helper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+		Limit:  10,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find object of type '%s' with identifier or name '%s': %w", c.helper, ref, err)
+		return
+	}
+
+	// Prepare the response based on the number of objects found:
+	items := refs.Resolve(c.refMode, ref, response.Items, c.helper.GetId, c.helper.GetName)
+	switch len(items) {
+	case 0:
+		c.console.Render(ctx, "no_matches.txt", map[string]any{
+			"Object": c.helper.Singular(),
+			"Ref":    ref,
+		})
+		err = exit.NotFound
+		return
+	case 1:
+		result = items[0]
+		return
+	default:
+		if c.console.CanPick() {
+			var index int
+			index, err = c.console.PickObject(
+				ctx,
+				fmt.Sprintf("Name or identifier '%s' is ambiguous, select the %s to display", ref, c.helper.Singular()),
+				items,
+			)
+			if err == nil {
+				result = items[index]
+				return
+			}
+			if !errors.Is(err, terminal.ErrPickCancelled) {
+				c.logger.ErrorContext(
+					ctx,
+					"Failed to pick object",
+					slog.String("ref", ref),
+					slog.Any("error", err),
+				)
+			}
+		}
+		c.console.Render(ctx, "multiple_matches.txt", map[string]any{
+			"Matches": items,
+			"Object":  c.helper.Singular(),
+			"Ref":     ref,
+			"Total":   response.Total,
+		})
+		err = exit.General
+		return
+	}
+}
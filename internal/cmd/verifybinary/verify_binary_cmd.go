@@ -0,0 +1,295 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package verifybinary
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/fetch"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+	"github.com/osac-project/fulfillment-cli/internal/version"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// repository is the GitHub repository where the releases are published.
+const repository = "osac-project/fulfillment-cli"
+
+// checksumsUrlTemplate is the template of the URL of the checksums file of a release, generated by the release
+// process. The parameter is the version, without the 'v' prefix.
+const checksumsUrlTemplate = "https://github.com/" + repository + "/releases/download/v%[1]s/" +
+	"fulfillment-cli_%[1]s_checksums.txt"
+
+// Cmd creates and returns the command that verifies the running binary.
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "verify-binary",
+		Short: "Verify the running binary against the published release",
+		Long: "Verify that the checksum of the running binary matches one of the artifacts published for its " +
+			"release, and display the provenance details embedded in the binary: commit, build time and the " +
+			"system that built it. Binaries built from source, for example the RPM packages, don't match the " +
+			"published artifacts.",
+		Example: "  # Verify the binary against the checksums published in GitHub:\n" +
+			"  fulfillment-cli verify-binary\n\n" +
+			"  # Verify the binary in an environment without internet access, using a downloaded checksums file:\n" +
+			"  fulfillment-cli verify-binary --checksums fulfillment-cli_0.0.42_checksums.txt\n\n" +
+			"  # Verify also the signed build provenance, using the GitHub CLI:\n" +
+			"  fulfillment-cli verify-binary --attestation",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.args.checksums,
+		"checksums",
+		"",
+		"URL or local file name of the checksums file of the release. The default is the file published in "+
+			"GitHub for the version of the binary.",
+	)
+	flags.BoolVar(
+		&runner.args.attestation,
+		"attestation",
+		false,
+		"Verify also the signed build provenance attestation published for the binary, using the "+
+			"'gh attestation verify' command of the GitHub CLI, which needs to be installed.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	args struct {
+		checksums   string
+		attestation bool
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+
+	// executable is the path of the binary to verify. This is intended for tests, and when it is empty the running
+	// binary is used.
+	executable string
+}
+
+// verifyResult contains the results of the verification, and is what is rendered when the output is JSON.
+type verifyResult struct {
+	*version.Build
+	Path      string `json:"path"`
+	Sha256    string `json:"sha256"`
+	Checksums string `json:"checksums,omitempty"`
+	Artifact  string `json:"artifact,omitempty"`
+	Verified  bool   `json:"verified"`
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	var err error
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
+
+	// Load the templates for the console messages:
+	err = c.console.AddTemplates(templatesFS, "templates")
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	// Calculate the checksum of the binary:
+	result := &verifyResult{
+		Build: version.GetBuild(),
+	}
+	result.Path, err = c.findExecutable()
+	if err != nil {
+		return err
+	}
+	result.Sha256, err = fileChecksum(result.Path)
+	if err != nil {
+		return err
+	}
+
+	// Binaries that aren't built for a release have no published checksums, unless the user gives them explicitly:
+	result.Checksums = c.args.checksums
+	if result.Checksums == "" && result.Release {
+		result.Checksums = fmt.Sprintf(checksumsUrlTemplate, result.Version)
+	}
+	if result.Checksums == "" {
+		c.report(ctx, result)
+		return fmt.Errorf(
+			"version '%s' isn't a release, so there are no published checksums to verify it, use "+
+				"'--checksums' to give them explicitly",
+			result.Version,
+		)
+	}
+
+	// Load the checksums and find the one that matches the binary:
+	checksums, err := c.loadChecksums(ctx, result.Checksums)
+	if err != nil {
+		return err
+	}
+	for artifact, checksum := range checksums {
+		if checksum == result.Sha256 {
+			result.Artifact = artifact
+			result.Verified = true
+			break
+		}
+	}
+	c.report(ctx, result)
+	if !result.Verified {
+		return exit.WithCode(exit.Integrity, fmt.Errorf(
+			"checksum of binary '%s' doesn't match any of the artifacts listed in '%s'",
+			result.Path, result.Checksums,
+		))
+	}
+
+	// Verify the attestation if requested:
+	if c.args.attestation {
+		err = c.verifyAttestation(ctx, result.Path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findExecutable returns the absolute path of the binary to verify, resolving symbolic links.
+func (c *runnerContext) findExecutable() (result string, err error) {
+	result = c.executable
+	if result == "" {
+		result, err = os.Executable()
+		if err != nil {
+			err = fmt.Errorf("failed to find the path of the binary: %w", err)
+			return
+		}
+	}
+	result, err = filepath.EvalSymlinks(result)
+	if err != nil {
+		err = fmt.Errorf("failed to resolve the path of the binary: %w", err)
+	}
+	return
+}
+
+// report writes the results of the verification.
+func (c *runnerContext) report(ctx context.Context, result *verifyResult) {
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, result)
+		return
+	}
+	c.console.Render(ctx, "verify_result.txt", map[string]any{
+		"Result":     result,
+		"Repository": repository,
+	})
+}
+
+// loadChecksums loads the checksums file from the given URL or local file, and returns a map where the keys are the
+// names of the artifacts and the values the hexadecimal SHA-256 checksums.
+func (c *runnerContext) loadChecksums(ctx context.Context, source string) (result map[string]string, err error) {
+	reader, err := fetch.Open(ctx, c.logger, source, "checksums file")
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+	result, err = parseChecksums(reader)
+	if err != nil {
+		err = fmt.Errorf("failed to parse checksums from '%s': %w", source, err)
+	}
+	return
+}
+
+// verifyAttestation runs the 'gh attestation verify' command to verify the signed build provenance of the binary.
+func (c *runnerContext) verifyAttestation(ctx context.Context, path string) error {
+	gh, err := exec.LookPath("gh")
+	if err != nil {
+		return fmt.Errorf("option '--attestation' requires the GitHub CLI, but the 'gh' command isn't available")
+	}
+	c.logger.DebugContext(
+		ctx,
+		"Verifying attestation",
+		slog.String("gh", gh),
+		slog.String("path", path),
+	)
+	cmd := exec.CommandContext(ctx, gh, "attestation", "verify", path, "--repo", repository)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		return exit.WithCode(exit.Integrity, fmt.Errorf("failed to verify the attestation of '%s': %w", path, err))
+	}
+	return nil
+}
+
+// parseChecksums parses a checksums file in the format generated by the 'sha256sum' command, where each line
+// contains the hexadecimal checksum and the name of the file, separated by spaces.
+func parseChecksums(reader io.Reader) (result map[string]string, err error) {
+	result = map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			err = fmt.Errorf("line %d should contain a checksum and a file name, but it is '%s'", line, text)
+			return
+		}
+		checksum := strings.ToLower(fields[0])
+		_, decodeErr := hex.DecodeString(checksum)
+		if decodeErr != nil || len(checksum) != 2*sha256.Size {
+			err = fmt.Errorf("line %d contains an invalid SHA-256 checksum '%s'", line, fields[0])
+			return
+		}
+		result[strings.TrimPrefix(fields[1], "*")] = checksum
+	}
+	err = scanner.Err()
+	return
+}
+
+// fileChecksum calculates the hexadecimal SHA-256 checksum of the given file.
+func fileChecksum(path string) (result string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		err = fmt.Errorf("failed to open binary '%s': %w", path, err)
+		return
+	}
+	defer file.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		err = fmt.Errorf("failed to read binary '%s': %w", path, err)
+		return
+	}
+	result = hex.EncodeToString(hash.Sum(nil))
+	return
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package verifybinary
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+var _ = Describe("Verify binary command", func() {
+	var (
+		ctx    context.Context
+		buffer *bytes.Buffer
+		tmp    string
+		binary string
+	)
+
+	// The checksum of the fake binary, that contains 'my-binary':
+	const binaryChecksum = "d7ea6fbdadbc70351885b422832a431524e8a8936f1e28001674d55492ee71df"
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+		console, err := terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		ctx = logging.LoggerIntoContext(context.Background(), logger)
+		ctx = terminal.ConsoleIntoContext(ctx, console)
+		tmp = GinkgoT().TempDir()
+		binary = filepath.Join(tmp, "fulfillment-cli")
+		Expect(os.WriteFile(binary, []byte("my-binary"), 0700)).To(Succeed())
+	})
+
+	// verify runs the command for the fake binary with the given checksums.
+	verify := func(checksums string) error {
+		runner := &runnerContext{
+			executable: binary,
+		}
+		runner.args.checksums = checksums
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+		return runner.run(cmd, nil)
+	}
+
+	// writeChecksums writes a checksums file with the given lines.
+	writeChecksums := func(lines ...string) string {
+		file := filepath.Join(tmp, "checksums.txt")
+		Expect(os.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0600)).To(Succeed())
+		return file
+	}
+
+	It("Calculates the checksum of the binary", func() {
+		checksum, err := fileChecksum(binary)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(checksum).To(Equal(binaryChecksum))
+	})
+
+	It("Succeeds when the checksum is in a local file", func() {
+		file := writeChecksums(
+			strings.Repeat("0", 64)+"  fulfillment-cli_Darwin_arm64",
+			binaryChecksum+"  fulfillment-cli_Linux_x86_64",
+		)
+		err := verify(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(ContainSubstring("SHA-256:    " + binaryChecksum))
+		Expect(buffer.String()).To(ContainSubstring(
+			"The checksum matches the artifact 'fulfillment-cli_Linux_x86_64'",
+		))
+	})
+
+	It("Downloads the checksums", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s  fulfillment-cli_Linux_x86_64\n", binaryChecksum)
+		}))
+		DeferCleanup(server.Close)
+		err := verify(server.URL + "/checksums.txt")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(ContainSubstring("fulfillment-cli_Linux_x86_64"))
+	})
+
+	It("Fails if the download fails", func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		DeferCleanup(server.Close)
+		err := verify(server.URL + "/checksums.txt")
+		Expect(err).To(MatchError(ContainSubstring("status 404")))
+	})
+
+	It("Fails when the checksum doesn't match", func() {
+		file := writeChecksums(strings.Repeat("0", 64) + "  fulfillment-cli_Linux_x86_64")
+		err := verify(file)
+		Expect(err).To(MatchError(ContainSubstring("doesn't match any of the artifacts")))
+		code, ok := exit.CodeOf(err)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(exit.Integrity))
+	})
+
+	It("Rejects malformed checksums files", func() {
+		file := writeChecksums("junk")
+		err := verify(file)
+		Expect(err).To(MatchError(ContainSubstring("line 1 should contain a checksum and a file name")))
+	})
+
+	It("Rejects invalid checksums", func() {
+		file := writeChecksums("xyz  fulfillment-cli_Linux_x86_64")
+		err := verify(file)
+		Expect(err).To(MatchError(ContainSubstring("line 1 contains an invalid SHA-256 checksum 'xyz'")))
+	})
+
+	It("Accepts the binary mode marker of 'sha256sum'", func() {
+		checksums, err := parseChecksums(strings.NewReader(binaryChecksum + " *fulfillment-cli_Linux_x86_64\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(checksums).To(Equal(map[string]string{
+			"fulfillment-cli_Linux_x86_64": binaryChecksum,
+		}))
+	})
+
+	It("Generates the URL of the checksums of a release", func() {
+		Expect(fmt.Sprintf(checksumsUrlTemplate, "0.0.42")).To(Equal(
+			"https://github.com/osac-project/fulfillment-cli/releases/download/v0.0.42/" +
+				"fulfillment-cli_0.0.42_checksums.txt",
+		))
+	})
+})
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package verifybinary
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/consoletest"
+	"github.com/osac-project/fulfillment-cli/internal/version"
+)
+
+var _ = Describe("Templates", func() {
+	It("Renders all the templates", func() {
+		checker, err := consoletest.NewChecker().
+			SetLogger(logger).
+			SetTemplates(templatesFS, "templates").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(checker.Close)
+		build := &version.Build{
+			Version:    "v1.2.3",
+			Release:    true,
+			Commit:     "0123456789abcdef",
+			CommitTime: "2025-11-04T10:58:02Z",
+			Builder:    "github-actions",
+			GoVersion:  "go1.24.0",
+			Os:         "linux",
+			Arch:       "amd64",
+		}
+		err = checker.Check(consoletest.Cases{
+			"verify_result.txt": {
+				map[string]any{
+					"Result": &verifyResult{
+						Build:     build,
+						Path:      "/usr/bin/fulfillment-cli",
+						Sha256:    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+						Checksums: "checksums.txt",
+						Artifact:  "fulfillment-cli_Linux_x86_64",
+						Verified:  true,
+					},
+					"Repository": repository,
+				},
+				map[string]any{
+					"Result": &verifyResult{
+						Build: &version.Build{
+							Version:  "v0.0.0-dev",
+							Modified: true,
+						},
+						Path:   "/usr/bin/fulfillment-cli",
+						Sha256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					},
+					"Repository": repository,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
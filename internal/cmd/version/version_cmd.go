@@ -14,8 +14,18 @@ language governing permissions and limitations under the License.
 package version
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	metadatav1 "github.com/osac-project/fulfillment-common/api/metadata/v1"
+	"github.com/osac-project/fulfillment-common/logging"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/output"
 	"github.com/osac-project/fulfillment-cli/internal/terminal"
 	"github.com/osac-project/fulfillment-cli/internal/version"
 )
@@ -25,23 +35,112 @@ func Cmd() *cobra.Command {
 	result := &cobra.Command{
 		Use:   "version",
 		Short: "Display version details",
-		RunE:  runner.run,
+		Long: "Display the version of the client and, if there is a configuration, the version advertised by the " +
+			"server, with a warning if they are known to be incompatible.",
+		RunE: runner.run,
 	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.args.client,
+		"client",
+		false,
+		"Display only the version of the client, without connecting to the server.",
+	)
 	return result
 }
 
 type runnerContext struct {
+	args struct {
+		client bool
+	}
+	logger  *slog.Logger
+	console *terminal.Console
+}
+
+// versionInfo contains the version details, and is what is rendered when the output is JSON.
+type versionInfo struct {
+	Version       string `json:"version"`
+	ServerAddress string `json:"server_address,omitempty"`
+	ServerVersion string `json:"server_version,omitempty"`
+	Warning       string `json:"warning,omitempty"`
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Get the context:
 	ctx := cmd.Context()
 
-	// Get the console:
-	console := terminal.ConsoleFromContext(ctx)
+	// Get the logger and console:
+	c.logger = logging.LoggerFromContext(ctx)
+	c.console = terminal.ConsoleFromContext(ctx)
 
-	// Print the version:
-	console.Printf(ctx, "%s\n", version.Get())
+	// Get the version of the server, unless the user asked only for the client or there is no configuration:
+	info := &versionInfo{
+		Version: version.Get(),
+	}
+	var serverErr error
+	if !c.args.client {
+		cfg, err := config.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if cfg != nil && cfg.Address != "" {
+			info.ServerAddress = cfg.Address
+			info.ServerVersion, serverErr = c.serverVersion(ctx, cmd, cfg)
+			if serverErr == nil {
+				info.Warning = version.CheckCompatibility(info.Version, info.ServerVersion)
+			}
+		}
+	}
 
+	// Print the version details:
+	if output.IsJson(ctx) {
+		c.console.RenderJson(ctx, info)
+	} else {
+		c.writeText(ctx, info, serverErr)
+	}
+	if serverErr != nil {
+		return fmt.Errorf("failed to get the version of server '%s': %w", info.ServerAddress, serverErr)
+	}
 	return nil
 }
+
+// serverVersion calls the metadata service of the server and returns the version that it advertises in the response
+// header, or 'unknown' if it doesn't advertise it.
+func (c *runnerContext) serverVersion(ctx context.Context, cmd *cobra.Command,
+	cfg *config.Config) (result string, err error) {
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	var header metadata.MD
+	client := metadatav1.NewMetadataClient(conn)
+	_, err = client.Get(ctx, metadatav1.MetadataGetRequest_builder{}.Build(), grpc.Header(&header))
+	if err != nil {
+		return
+	}
+	result = version.ServerFromHeader(header)
+	if result == "" {
+		c.logger.DebugContext(
+			ctx,
+			"Server doesn't advertise its version",
+			slog.String("address", cfg.Address),
+		)
+		result = version.Unknown
+	}
+	return
+}
+
+// writeText writes the version details in text format. When there is no server only the client version is written,
+// in the same format that was used before the server version was added, so that existing scripts continue working.
+func (c *runnerContext) writeText(ctx context.Context, info *versionInfo, serverErr error) {
+	if info.ServerAddress == "" || serverErr != nil {
+		c.console.Printf(ctx, "%s\n", info.Version)
+		return
+	}
+	c.console.Printf(ctx, "Client version: %s\n", info.Version)
+	c.console.Printf(ctx, "Server version: %s (%s)\n", info.ServerVersion, info.ServerAddress)
+	if info.Warning != "" {
+		c.console.Printf(ctx, "Warning: %s.\n", info.Warning)
+	}
+}
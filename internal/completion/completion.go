@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package completion
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/config"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+)
+
+// Objects returns a completion function for commands that receive the object type as the first positional argument
+// and then identifiers or names of objects of that type. The max parameter is the maximum number of identifiers or
+// names that the command accepts, a negative value means that there is no limit.
+func Objects(max int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion,
+		cobra.ShellCompDirective) {
+		if max >= 0 && len(args) > max {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, func(ctx context.Context, helper *reflection.Helper) []cobra.Completion {
+			if len(args) == 0 {
+				return Types(helper, toComplete)
+			}
+			objectHelper := helper.Lookup(args[0])
+			if objectHelper == nil {
+				return nil
+			}
+			return Keys(ctx, objectHelper, toComplete)
+		})
+	}
+}
+
+// ObjectsOf returns a completion function for commands where the object type is fixed, and that receive only the
+// identifiers or names of the objects as positional arguments. The max parameter has the same meaning than in the
+// Objects function.
+func ObjectsOf(object proto.Message, max int) cobra.CompletionFunc {
+	objectType := string(proto.MessageName(object))
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion,
+		cobra.ShellCompDirective) {
+		if max >= 0 && len(args) >= max {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, func(ctx context.Context, helper *reflection.Helper) []cobra.Completion {
+			objectHelper := helper.Lookup(objectType)
+			if objectHelper == nil {
+				return nil
+			}
+			return Keys(ctx, objectHelper, toComplete)
+		})
+	}
+}
+
+// ObjectTypes is a completion function for flags whose value is an object type.
+func ObjectTypes(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion,
+	cobra.ShellCompDirective) {
+	return complete(cmd, func(ctx context.Context, helper *reflection.Helper) []cobra.Completion {
+		return Types(helper, toComplete)
+	})
+}
+
+// Types returns the object type names, singular and plural, that start with the given prefix.
+func Types(helper *reflection.Helper, toComplete string) []cobra.Completion {
+	var results []cobra.Completion
+	for _, name := range slices.Concat(helper.Singulars(), helper.Plurals()) {
+		if strings.HasPrefix(name, strings.ToLower(toComplete)) {
+			results = append(results, name)
+		}
+	}
+	slices.Sort(results)
+	return slices.Compact(results)
+}
+
+// Keys returns the identifiers and names of the objects that start with the given prefix. The identifiers are
+// described with the name of the object, and the names with the identifier, so that the user can see both.
+func Keys(ctx context.Context, helper *reflection.ObjectHelper, toComplete string) []cobra.Completion {
+	logger := logging.LoggerFromContext(ctx)
+	filter := "!has(this.metadata.deletion_timestamp)"
+	if toComplete != "" {
+		filter = fmt.Sprintf(
+			"%s && (this.id.startsWith(%[2]q) || this.metadata.name.startsWith(%[2]q))",
+			filter, toComplete,
+		)
+	}
+	response, err := helper.List(ctx, reflection.ListOptions{
+		Filter: filter,
+		Limit:  keysLimit,
+	})
+	if err != nil {
+		logger.DebugContext(
+			ctx,
+			"Failed to list objects for completion",
+			slog.String("type", helper.String()),
+			slog.String("filter", filter),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	var results []cobra.Completion
+	for _, object := range response.Items {
+		id := helper.GetId(object)
+		name := helper.GetName(object)
+		if strings.HasPrefix(id, toComplete) {
+			results = append(results, cobra.CompletionWithDesc(id, name))
+		}
+		if name != "" && strings.HasPrefix(name, toComplete) {
+			results = append(results, cobra.CompletionWithDesc(name, id))
+		}
+	}
+	return results
+}
+
+// complete loads the configuration, creates the reflection helper and then calls the given function to calculate the
+// completions. Any error is written to the log and results in no completions, as there is no good way to report it
+// to the user in the middle of typing a command.
+func complete(cmd *cobra.Command, calculate func(context.Context, *reflection.Helper) []cobra.Completion) (
+	[]cobra.Completion, cobra.ShellCompDirective) {
+	directive := cobra.ShellCompDirectiveNoFileComp
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+	logger := logging.LoggerFromContext(ctx)
+	cfg, err := config.Load(ctx)
+	if err != nil || cfg.Address == "" {
+		logger.DebugContext(
+			ctx,
+			"Can't complete without configuration",
+			slog.Any("error", err),
+		)
+		return nil, directive
+	}
+	conn, err := cfg.Connect(ctx, cmd.Flags())
+	if err != nil {
+		logger.DebugContext(
+			ctx,
+			"Failed to create gRPC connection for completion",
+			slog.Any("error", err),
+		)
+		return nil, directive
+	}
+	defer conn.Close()
+	helper, err := reflection.NewHelper().
+		SetLogger(logger).
+		SetConnection(conn).
+		AddPackages(cfg.Packages()).
+		Build()
+	if err != nil {
+		logger.DebugContext(
+			ctx,
+			"Failed to create reflection helper for completion",
+			slog.Any("error", err),
+		)
+		return nil, directive
+	}
+	return calculate(ctx, helper), directive
+}
+
+// keysLimit is the maximum number of objects that will be requested from the server to complete identifiers and names.
+// This is intentionally small because completion needs to be fast, and long lists aren't useful in the shell anyhow.
+const keysLimit = 20
+
+// timeout is the maximum time that we will wait for the server when calculating completions.
+const timeout = 5 * time.Second
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package completion
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Completion", func() {
+	var (
+		ctx    context.Context
+		server *testing.Server
+		helper *reflection.Helper
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		// Create a context:
+		ctx = logging.LoggerIntoContext(context.Background(), logger)
+
+		// Create the server:
+		server = testing.NewServer()
+		DeferCleanup(server.Stop)
+
+		// Create the client connection:
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+
+		// Create the reflection helper:
+		helper, err = reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Types", func() {
+		It("Returns all types if prefix is empty", func() {
+			results := Types(helper, "")
+			Expect(results).To(ContainElements("cluster", "clusters", "host", "hosts"))
+		})
+
+		It("Returns only the types that match the prefix", func() {
+			results := Types(helper, "hostp")
+			Expect(results).To(ConsistOf("hostpool", "hostpools"))
+		})
+
+		It("Ignores case of the prefix", func() {
+			results := Types(helper, "HOSTP")
+			Expect(results).To(ConsistOf("hostpool", "hostpools"))
+		})
+	})
+
+	Describe("Keys", func() {
+		It("Returns identifiers and names with descriptions", func() {
+			// Register a clusters server that verifies the filter and returns one object:
+			ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+				ListFunc: func(ctx context.Context, request *ffv1.ClustersListRequest,
+				) (response *ffv1.ClustersListResponse, err error) {
+					defer GinkgoRecover()
+					Expect(request.GetFilter()).To(ContainSubstring(`this.id.startsWith("my")`))
+					Expect(request.GetFilter()).To(ContainSubstring(`this.metadata.name.startsWith("my")`))
+					Expect(request.GetLimit()).To(BeNumerically("==", keysLimit))
+					response = ffv1.ClustersListResponse_builder{
+						Size:  proto.Int32(1),
+						Total: proto.Int32(1),
+						Items: []*ffv1.Cluster{
+							ffv1.Cluster_builder{
+								Id: "my-id",
+								Metadata: sharedv1.Metadata_builder{
+									Name: "my-name",
+								}.Build(),
+							}.Build(),
+						},
+					}.Build()
+					return
+				},
+			})
+			server.Start()
+
+			// Calculate the completions:
+			results := Keys(ctx, helper.Lookup("cluster"), "my")
+			Expect(results).To(ConsistOf(
+				cobra.CompletionWithDesc("my-id", "my-name"),
+				cobra.CompletionWithDesc("my-name", "my-id"),
+			))
+		})
+
+		It("Returns nothing if the server fails", func() {
+			server.Start()
+			results := Keys(ctx, helper.Lookup("cluster"), "my")
+			Expect(results).To(BeEmpty())
+		})
+	})
+})
@@ -33,30 +33,56 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/osac-project/fulfillment-cli/internal/packages"
+	"github.com/osac-project/fulfillment-cli/internal/recording"
+	"github.com/osac-project/fulfillment-cli/internal/telemetry"
+	"github.com/osac-project/fulfillment-cli/internal/timing"
+	"github.com/osac-project/fulfillment-cli/internal/tokenscript"
+	"github.com/osac-project/fulfillment-cli/internal/tracing"
 	"github.com/osac-project/fulfillment-cli/internal/version"
 )
 
 // Config is the type used to store the configuration of the client.
 type Config struct {
-	TokenScript       string     `json:"token_script,omitempty"`
-	Plaintext         bool       `json:"plaintext,omitempty"`
-	Insecure          bool       `json:"insecure,omitempty"`
-	CaFiles           []CaFile   `json:"ca_files,omitempty"`
-	Address           string     `json:"address,omitempty"`
-	Private           bool       `json:"packages,omitempty"`
-	AccessToken       string     `json:"access_token,omitempty"`
-	RefreshToken      string     `json:"refresh_token,omitempty"`
-	TokenExpiry       time.Time  `json:"token_expiry,omitempty"`
-	OAuthFlow         oauth.Flow `json:"oauth_flow,omitempty"`
-	OauthIssuer       string     `json:"oauth_issuer,omitempty"`
-	OAuthClientId     string     `json:"oauth_client_id,omitempty"`
-	OAuthClientSecret string     `json:"oauth_client_secret,omitempty"`
-	OAuthScopes       []string   `json:"oauth_scopes,omitempty"`
-	OAuthRedirectUri  string     `json:"oauth_redirect_uri,omitempty"`
-	OAuthUser         string     `json:"oauth_user,omitempty"`
-	OAuthPassword     string     `json:"oauth_password,omitempty"`
-
-	caPool *x509.CertPool
+	TokenScript        string            `json:"token_script,omitempty"`
+	TokenScriptTimeout string            `json:"token_script_timeout,omitempty"`
+	TokenScriptShell   string            `json:"token_script_shell,omitempty"`
+	Plaintext          bool              `json:"plaintext,omitempty"`
+	Insecure           bool              `json:"insecure,omitempty"`
+	CaFiles            []CaFile          `json:"ca_files,omitempty"`
+	Address            string            `json:"address,omitempty"`
+	Private            bool              `json:"packages,omitempty"`
+	AccessToken        string            `json:"access_token,omitempty"`
+	RefreshToken       string            `json:"refresh_token,omitempty"`
+	TokenExpiry        time.Time         `json:"token_expiry,omitempty"`
+	OAuthFlow          oauth.Flow        `json:"oauth_flow,omitempty"`
+	OauthIssuer        string            `json:"oauth_issuer,omitempty"`
+	OAuthClientId      string            `json:"oauth_client_id,omitempty"`
+	OAuthClientSecret  string            `json:"oauth_client_secret,omitempty"`
+	OAuthScopes        []string          `json:"oauth_scopes,omitempty"`
+	OAuthRedirectUri   string            `json:"oauth_redirect_uri,omitempty"`
+	OAuthUser          string            `json:"oauth_user,omitempty"`
+	OAuthPassword      string            `json:"oauth_password,omitempty"`
+	TokenStorage       string            `json:"token_storage,omitempty"`
+	StateDir           string            `json:"state_dir,omitempty"`
+	CacheDir           string            `json:"cache_dir,omitempty"`
+	LogMaxSize         string            `json:"log_max_size,omitempty"`
+	LogMaxAge          string            `json:"log_max_age,omitempty"`
+	LogMaxFiles        *int              `json:"log_max_files,omitempty"`
+	ReadOnly           bool              `json:"read_only,omitempty"`
+	Production         bool              `json:"production,omitempty"`
+	Favorites          []Favorite        `json:"favorites,omitempty"`
+	Theme              string            `json:"theme,omitempty"`
+	Units              string            `json:"units,omitempty"`
+	TimeZone           string            `json:"time_zone,omitempty"`
+	OtelEndpoint       string            `json:"otel_endpoint,omitempty"`
+	OtelHeaders        map[string]string `json:"otel_headers,omitempty"`
+	Macros             map[string]string `json:"macros,omitempty"`
+	Hooks              map[string]string `json:"hooks,omitempty"`
+	HooksShell         string            `json:"hooks_shell,omitempty"`
+	Pricing            string            `json:"pricing,omitempty"`
+
+	caPool           *x509.CertPool
+	packagesOverride []string
 }
 
 // CaFile represents a CA certificate file with its name and optionally its content. The content is stored for relative
@@ -68,7 +94,58 @@ type CaFile struct {
 
 // Load loads the configuration from the configuration file.
 func Load(ctx context.Context) (cfg *Config, err error) {
+	// The list of packages may have been overridden in the command line, and that needs to be applied regardless of
+	// how the configuration was loaded:
+	defer func() {
+		if cfg != nil {
+			cfg.packagesOverride = packages.OverrideFromContext(ctx)
+		}
+	}()
+
 	// Load the file:
+	cfg, err = LoadFile()
+	if err != nil {
+		return
+	}
+
+	// When replaying calls there is no need for a server, so if there is no configuration use a placeholder address,
+	// as otherwise most commands would ask to run the 'login' command first:
+	if cfg.Address == "" && recording.ReplayerFromContext(ctx) != nil {
+		cfg.Address = recording.ReplayAddress
+		cfg.Plaintext = true
+	}
+
+	// Load the tokens from the keyring if needed. If the file still contains tokens then move them to the keyring,
+	// so that they aren't kept in plain text.
+	if cfg.TokenStorage == TokenStorageKeyring {
+		var pending bool
+		pending, err = cfg.loadKeyringTokens()
+		if err != nil {
+			return
+		}
+		if pending {
+			err = Save(cfg)
+			if err != nil {
+				err = fmt.Errorf("failed to move tokens to the keyring: %w", err)
+				return
+			}
+		}
+	}
+
+	// Create the CA pool:
+	err = cfg.createCaPool(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to create CA pool: %w", err)
+		return
+	}
+
+	return
+}
+
+// LoadFile reads and parses the configuration file, without loading the tokens from the keyring or creating the CA pool.
+// If the file doesn't exist it returns an empty configuration. This is cheap enough to call it before running any
+// command, to get the settings that don't need the connection to the server, like the color theme or the directories.
+func LoadFile() (cfg *Config, err error) {
 	file, err := Location()
 	if err != nil {
 		return
@@ -97,23 +174,32 @@ func Load(ctx context.Context) (cfg *Config, err error) {
 		err = fmt.Errorf("failed to parse config file '%s': %v", file, err)
 		return
 	}
-
-	// Create the CA pool:
-	err = cfg.createCaPool(ctx)
-	if err != nil {
-		err = fmt.Errorf("failed to create CA pool: %w", err)
-		return
-	}
-
 	return
 }
 
-// Save saves the given configuration to the configuration file.
+// Save saves the given configuration to the configuration file. If the tokens are stored in the keyring they are saved
+// there, and removed from the file. If they were stored in the keyring but no longer are, they are removed from the
+// keyring.
 func Save(cfg *Config) error {
 	file, err := Location()
 	if err != nil {
 		return err
 	}
+	if cfg.TokenStorage == TokenStorageKeyring {
+		cfg, err = cfg.saveKeyringTokens()
+		if err != nil {
+			return err
+		}
+	} else {
+		// The previous file will be replaced, so it doesn't matter if it can't be read:
+		previous, loadErr := LoadFile()
+		if loadErr == nil && previous.TokenStorage == TokenStorageKeyring {
+			err = deleteKeyringTokens()
+			if err != nil {
+				return err
+			}
+		}
+	}
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %v", err)
@@ -173,10 +259,20 @@ func (c *Config) TokenSource(ctx context.Context) (result auth.TokenSource, err
 
 	// If a token script has been configured, then use it to create a script token source:
 	if c.TokenScript != "" {
-		result, err = auth.NewScriptTokenSource().
+		timeout := tokenscript.DefaultTimeout
+		if c.TokenScriptTimeout != "" {
+			timeout, err = time.ParseDuration(c.TokenScriptTimeout)
+			if err != nil {
+				err = fmt.Errorf("failed to parse token script timeout '%s': %w", c.TokenScriptTimeout, err)
+				return
+			}
+		}
+		result, err = tokenscript.NewTokenSource().
 			SetLogger(logger).
 			SetScript(c.TokenScript).
+			SetShell(c.TokenScriptShell).
 			SetStore(tokenStore).
+			SetTimeout(timeout).
 			Build()
 		if err != nil {
 			err = fmt.Errorf("failed to create script token source: %w", err)
@@ -225,8 +321,18 @@ func (c *Config) Connect(ctx context.Context, flags *pflag.FlagSet) (result *grp
 		return
 	}
 
-	// Create the gRPC client:
-	result, err = network.NewGrpcClient().
+	// Create the hooks interceptor:
+	hooksInterceptor, err := c.HooksInterceptor(logger)
+	if err != nil {
+		return
+	}
+
+	// Create the gRPC client. If the context contains statistics then add the interceptors that count the calls, if it
+	// contains a tracer then add the interceptors that write the calls to the log, if it contains the OpenTelemetry
+	// instrumentation then add the interceptors that create the spans and propagate the trace context, and if it
+	// contains a recorder or a replayer then add the interceptors that save or replay the calls. Those need to be the
+	// last ones, so that the replayed calls go through all the others.
+	clientBuilder := network.NewGrpcClient().
 		SetLogger(logger).
 		SetPlaintext(c.Plaintext).
 		SetInsecure(c.Insecure).
@@ -235,7 +341,33 @@ func (c *Config) Connect(ctx context.Context, flags *pflag.FlagSet) (result *grp
 		SetAddress(c.Address).
 		AddUnaryInterceptor(versionInterceptor.UnaryClient).
 		AddStreamInterceptor(versionInterceptor.StreamClient).
-		Build()
+		AddUnaryInterceptor(hooksInterceptor.UnaryClient)
+	stats := timing.StatsFromContext(ctx)
+	if stats != nil {
+		clientBuilder.AddUnaryInterceptor(stats.UnaryClient)
+		clientBuilder.AddStreamInterceptor(stats.StreamClient)
+	}
+	tracer := tracing.TracerFromContext(ctx)
+	if tracer != nil {
+		clientBuilder.AddUnaryInterceptor(tracer.UnaryClient)
+		clientBuilder.AddStreamInterceptor(tracer.StreamClient)
+	}
+	instrumentation := telemetry.InstrumentationFromContext(ctx)
+	if instrumentation != nil {
+		clientBuilder.AddUnaryInterceptor(instrumentation.UnaryClient)
+		clientBuilder.AddStreamInterceptor(instrumentation.StreamClient)
+	}
+	recorder := recording.RecorderFromContext(ctx)
+	if recorder != nil {
+		clientBuilder.AddUnaryInterceptor(recorder.UnaryClient)
+		clientBuilder.AddStreamInterceptor(recorder.StreamClient)
+	}
+	replayer := recording.ReplayerFromContext(ctx)
+	if replayer != nil {
+		clientBuilder.AddUnaryInterceptor(replayer.UnaryClient)
+		clientBuilder.AddStreamInterceptor(replayer.StreamClient)
+	}
+	result, err = clientBuilder.Build()
 	if err != nil {
 		err = fmt.Errorf("failed to create gRPC client: %w", err)
 		return
@@ -251,8 +383,16 @@ func (c *Config) Connect(ctx context.Context, flags *pflag.FlagSet) (result *grp
 // the relative order of the types of the package order of the package when presented to the user. For example, if the
 // package 'private.v1' has order 1 and package 'fulfillment.v1' has order 2, then the types of the 'private.v1' should
 // be presented first, even if the alphabetical order would put the 'fulfillment.v1' types first.
+//
+// If the packages have been overridden in the command line then only those are returned, in the order given.
 func (c *Config) Packages() map[string]int {
 	result := map[string]int{}
+	if c.packagesOverride != nil {
+		for i, name := range c.packagesOverride {
+			result[name] = i
+		}
+		return result
+	}
 	for _, name := range packages.Public {
 		result[name] = 1
 	}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// StateDirectory returns the directory where the tool stores data that should persist between executions but that
+// isn't configuration, for example the log files. It is the value of the 'state_dir' setting of the configuration
+// file if it is set. Otherwise it is the sub-directory named like the binary inside '$XDG_STATE_HOME', which is by
+// default '~/.local/state'. In Windows, where there is no such convention, it is inside the local application data
+// directory. The directory is created if it doesn't exist.
+func (c *Config) StateDirectory() (result string, err error) {
+	result = c.StateDir
+	if result == "" {
+		var base string
+		base, err = userStateDir()
+		if err != nil {
+			return
+		}
+		result = filepath.Join(base, appName())
+	}
+	err = createDir(result)
+	return
+}
+
+// CacheDirectory returns the directory where the tool stores data that can be safely removed at any time, as it can be
+// recreated when needed. It is the value of the 'cache_dir' setting of the configuration file if it is set.
+// Otherwise it is the sub-directory named like the binary inside the user cache directory, which is by default
+// '$XDG_CACHE_HOME' or '~/.cache' in Linux. The directory is created if it doesn't exist.
+func (c *Config) CacheDirectory() (result string, err error) {
+	result = c.CacheDir
+	if result == "" {
+		var base string
+		base, err = os.UserCacheDir()
+		if err != nil {
+			return
+		}
+		result = filepath.Join(base, appName())
+	}
+	err = createDir(result)
+	return
+}
+
+// userStateDir returns the base directory for state files, following the same rules that the os.UserCacheDir
+// function uses for the cache.
+func userStateDir() (result string, err error) {
+	result = os.Getenv("XDG_STATE_HOME")
+	if result != "" {
+		if !filepath.IsAbs(result) {
+			err = errors.New("path in '$XDG_STATE_HOME' is relative")
+		}
+		return
+	}
+	if runtime.GOOS == "windows" {
+		result, err = os.UserCacheDir()
+		return
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(home, ".local", "state")
+	return
+}
+
+// appName returns the name of the binary, which is used as the name of the directories. For example, if the binary
+// is 'fulfillment-cli' the log files will be in '~/.local/state/fulfillment-cli'.
+func appName() string {
+	return filepath.Base(os.Args[0])
+}
+
+func createDir(dir string) error {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("failed to create directory '%s': %w", dir, err)
+	}
+	return nil
+}
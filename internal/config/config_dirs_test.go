@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Directories", func() {
+	var tmp string
+
+	BeforeEach(func() {
+		tmp = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tmp)
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+		GinkgoT().Setenv("XDG_CACHE_HOME", filepath.Join(tmp, "cache"))
+		GinkgoT().Setenv("XDG_STATE_HOME", "")
+	})
+
+	It("Uses '~/.local/state' by default for the state", func() {
+		dir, err := loadSettings().StateDirectory()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dir).To(Equal(filepath.Join(tmp, ".local", "state", appName())))
+		Expect(dir).To(BeADirectory())
+	})
+
+	It("Honors the 'XDG_STATE_HOME' environment variable", func() {
+		GinkgoT().Setenv("XDG_STATE_HOME", filepath.Join(tmp, "state"))
+		dir, err := loadSettings().StateDirectory()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dir).To(Equal(filepath.Join(tmp, "state", appName())))
+	})
+
+	It("Rejects a relative 'XDG_STATE_HOME'", func() {
+		GinkgoT().Setenv("XDG_STATE_HOME", "state")
+		_, err := loadSettings().StateDirectory()
+		Expect(err).To(MatchError(ContainSubstring("relative")))
+	})
+
+	It("Honors the 'XDG_CACHE_HOME' environment variable", func() {
+		dir, err := loadSettings().CacheDirectory()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dir).To(Equal(filepath.Join(tmp, "cache", appName())))
+		Expect(dir).To(BeADirectory())
+	})
+
+	It("Uses the directories of the configuration file", func() {
+		err := Save(&Config{
+			StateDir: filepath.Join(tmp, "my-state"),
+			CacheDir: filepath.Join(tmp, "my-cache"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := loadSettings().StateDirectory()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dir).To(Equal(filepath.Join(tmp, "my-state")))
+		dir, err = loadSettings().CacheDirectory()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dir).To(Equal(filepath.Join(tmp, "my-cache")))
+		Expect(dir).To(BeADirectory())
+	})
+})
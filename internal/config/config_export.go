@@ -0,0 +1,333 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/osac-project/fulfillment-common/oauth"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportVersion is the version of the format of the files generated by the Export function.
+const ExportVersion = 1
+
+// PassphraseEnv is the name of the environment variable that contains the passphrase of the exported secrets when it
+// isn't given with the '--passphrase' flag.
+const PassphraseEnv = "FULFILLMENT_CONFIG_PASSPHRASE"
+
+// Parameters of the derivation of the encryption key from the passphrase:
+const (
+	exportKeyIterations = 600000
+	exportKeyLength     = 32
+	exportSaltLength    = 16
+)
+
+// ErrPassphraseRequired is returned by Import when the exported file contains encrypted secrets and no passphrase
+// has been provided.
+var ErrPassphraseRequired = errors.New("the file contains encrypted secrets, a passphrase is required")
+
+// ErrWrongPassphrase is returned by Import when the secrets of the exported file can't be decrypted with the given
+// passphrase.
+var ErrWrongPassphrase = errors.New("failed to decrypt secrets, the passphrase is probably wrong")
+
+// exportFile is the structure of the files generated by the Export function. It contains the settings needed to
+// connect to the server, but never the tokens. The OAuth client secret, user and password are only included when a
+// passphrase is provided, and then they are encrypted.
+type exportFile struct {
+	Version            int               `yaml:"version"`
+	Address            string            `yaml:"address,omitempty"`
+	Plaintext          bool              `yaml:"plaintext,omitempty"`
+	Insecure           bool              `yaml:"insecure,omitempty"`
+	CaFiles            []exportCaFile    `yaml:"ca_files,omitempty"`
+	Private            bool              `yaml:"packages,omitempty"`
+	TokenScript        string            `yaml:"token_script,omitempty"`
+	TokenScriptTimeout string            `yaml:"token_script_timeout,omitempty"`
+	TokenScriptShell   string            `yaml:"token_script_shell,omitempty"`
+	TokenStorage       string            `yaml:"token_storage,omitempty"`
+	ReadOnly           bool              `yaml:"read_only,omitempty"`
+	Production         bool              `yaml:"production,omitempty"`
+	Hooks              map[string]string `yaml:"hooks,omitempty"`
+	HooksShell         string            `yaml:"hooks_shell,omitempty"`
+	Pricing            string            `yaml:"pricing,omitempty"`
+	OAuth              *exportOAuth      `yaml:"oauth,omitempty"`
+	Secrets            *exportSecrets    `yaml:"secrets,omitempty"`
+}
+
+type exportCaFile struct {
+	Name    string `yaml:"name"`
+	Content string `yaml:"content"`
+}
+
+type exportOAuth struct {
+	Flow        string   `yaml:"flow,omitempty"`
+	Issuer      string   `yaml:"issuer,omitempty"`
+	ClientId    string   `yaml:"client_id,omitempty"`
+	Scopes      []string `yaml:"scopes,omitempty"`
+	RedirectUri string   `yaml:"redirect_uri,omitempty"`
+}
+
+// exportSecrets contains the encrypted secrets. The data is the result of encrypting the JSON representation of the
+// plainSecrets type with AES-GCM, using a key derived from the passphrase and the salt with PBKDF2. All the values are
+// encoded with base64.
+type exportSecrets struct {
+	Salt  string `yaml:"salt"`
+	Nonce string `yaml:"nonce"`
+	Data  string `yaml:"data"`
+}
+
+type plainSecrets struct {
+	OAuthClientSecret string `json:"oauth_client_secret,omitempty"`
+	OAuthUser         string `json:"oauth_user,omitempty"`
+	OAuthPassword     string `json:"oauth_password,omitempty"`
+}
+
+// Export generates a file containing the settings of the configuration that are needed to connect to the server, so
+// that it can be shared with other users and then loaded with the Import function. The tokens are never exported. The
+// OAuth client secret, user and password are exported only if the passphrase isn't empty, and then they are encrypted
+// with that passphrase. The content of the CA files is always included, so that the file can be used in other machines.
+// The hooks are included as well, as they usually enforce policies of the organization that runs the server, but the
+// scripts that they run aren't, so those need to be distributed separately. The location of the pricing file is also
+// included, but not its content.
+func Export(cfg *Config, passphrase string) (result []byte, err error) {
+	file := &exportFile{
+		Version:            ExportVersion,
+		Address:            cfg.Address,
+		Plaintext:          cfg.Plaintext,
+		Insecure:           cfg.Insecure,
+		Private:            cfg.Private,
+		TokenScript:        cfg.TokenScript,
+		TokenScriptTimeout: cfg.TokenScriptTimeout,
+		TokenScriptShell:   cfg.TokenScriptShell,
+		TokenStorage:       cfg.TokenStorage,
+		ReadOnly:           cfg.ReadOnly,
+		Production:         cfg.Production,
+		Hooks:              cfg.Hooks,
+		HooksShell:         cfg.HooksShell,
+		Pricing:            cfg.Pricing,
+	}
+	for _, caFile := range cfg.CaFiles {
+		content := caFile.Content
+		if content == "" {
+			var data []byte
+			data, err = os.ReadFile(caFile.Name)
+			if err != nil {
+				err = fmt.Errorf("failed to read CA file '%s': %w", caFile.Name, err)
+				return
+			}
+			content = string(data)
+		}
+		file.CaFiles = append(file.CaFiles, exportCaFile{
+			Name:    caFile.Name,
+			Content: content,
+		})
+	}
+	if cfg.OAuthFlow != "" || cfg.OauthIssuer != "" {
+		file.OAuth = &exportOAuth{
+			Flow:        string(cfg.OAuthFlow),
+			Issuer:      cfg.OauthIssuer,
+			ClientId:    cfg.OAuthClientId,
+			Scopes:      cfg.OAuthScopes,
+			RedirectUri: cfg.OAuthRedirectUri,
+		}
+	}
+	if passphrase != "" && cfg.HasSecrets() {
+		file.Secrets, err = encryptSecrets(&plainSecrets{
+			OAuthClientSecret: cfg.OAuthClientSecret,
+			OAuthUser:         cfg.OAuthUser,
+			OAuthPassword:     cfg.OAuthPassword,
+		}, passphrase)
+		if err != nil {
+			return
+		}
+	}
+	result, err = yaml.Marshal(file)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal exported configuration: %w", err)
+	}
+	return
+}
+
+// HasSecrets returns true if the configuration contains secrets that the Export function will only include when a
+// passphrase is given.
+func (c *Config) HasSecrets() bool {
+	return c.OAuthClientSecret != "" || c.OAuthUser != "" || c.OAuthPassword != ""
+}
+
+// Import parses a file generated by the Export function and returns the corresponding configuration. If the file
+// contains encrypted secrets the passphrase is required to decrypt them.
+func Import(data []byte, passphrase string) (result *Config, err error) {
+	var file exportFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&file)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("failed to parse exported configuration: %w", err)
+		return
+	}
+	if file.Version != ExportVersion {
+		err = fmt.Errorf(
+			"unsupported exported configuration version %d, expected %d",
+			file.Version, ExportVersion,
+		)
+		return
+	}
+	if file.TokenStorage != "" && file.TokenStorage != TokenStorageFile && file.TokenStorage != TokenStorageKeyring {
+		err = fmt.Errorf(
+			"unsupported token storage '%s', should be '%s' or '%s'",
+			file.TokenStorage, TokenStorageFile, TokenStorageKeyring,
+		)
+		return
+	}
+	result = &Config{
+		Address:            file.Address,
+		Plaintext:          file.Plaintext,
+		Insecure:           file.Insecure,
+		Private:            file.Private,
+		TokenScript:        file.TokenScript,
+		TokenScriptTimeout: file.TokenScriptTimeout,
+		TokenScriptShell:   file.TokenScriptShell,
+		TokenStorage:       file.TokenStorage,
+		ReadOnly:           file.ReadOnly,
+		Production:         file.Production,
+		Hooks:              file.Hooks,
+		HooksShell:         file.HooksShell,
+		Pricing:            file.Pricing,
+	}
+	for _, caFile := range file.CaFiles {
+		result.CaFiles = append(result.CaFiles, CaFile{
+			Name:    caFile.Name,
+			Content: caFile.Content,
+		})
+	}
+	if file.OAuth != nil {
+		result.OAuthFlow = oauth.Flow(file.OAuth.Flow)
+		result.OauthIssuer = file.OAuth.Issuer
+		result.OAuthClientId = file.OAuth.ClientId
+		result.OAuthScopes = file.OAuth.Scopes
+		result.OAuthRedirectUri = file.OAuth.RedirectUri
+	}
+	if file.Secrets != nil {
+		if passphrase == "" {
+			err = ErrPassphraseRequired
+			return
+		}
+		var secrets *plainSecrets
+		secrets, err = decryptSecrets(file.Secrets, passphrase)
+		if err != nil {
+			return
+		}
+		result.OAuthClientSecret = secrets.OAuthClientSecret
+		result.OAuthUser = secrets.OAuthUser
+		result.OAuthPassword = secrets.OAuthPassword
+	}
+	return
+}
+
+func encryptSecrets(secrets *plainSecrets, passphrase string) (result *exportSecrets, err error) {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal secrets: %w", err)
+		return
+	}
+	salt := make([]byte, exportSaltLength)
+	_, err = rand.Read(salt)
+	if err != nil {
+		err = fmt.Errorf("failed to generate salt: %w", err)
+		return
+	}
+	aead, err := exportCipher(passphrase, salt)
+	if err != nil {
+		return
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		err = fmt.Errorf("failed to generate nonce: %w", err)
+		return
+	}
+	result = &exportSecrets{
+		Salt:  base64.StdEncoding.EncodeToString(salt),
+		Nonce: base64.StdEncoding.EncodeToString(nonce),
+		Data:  base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, plain, nil)),
+	}
+	return
+}
+
+func decryptSecrets(secrets *exportSecrets, passphrase string) (result *plainSecrets, err error) {
+	var salt, nonce, data []byte
+	for _, value := range []struct {
+		name   string
+		text   string
+		result *[]byte
+	}{
+		{"salt", secrets.Salt, &salt},
+		{"nonce", secrets.Nonce, &nonce},
+		{"data", secrets.Data, &data},
+	} {
+		*value.result, err = base64.StdEncoding.DecodeString(value.text)
+		if err != nil {
+			err = fmt.Errorf("failed to decode secrets %s: %w", value.name, err)
+			return
+		}
+	}
+	aead, err := exportCipher(passphrase, salt)
+	if err != nil {
+		return
+	}
+	if len(nonce) != aead.NonceSize() {
+		err = fmt.Errorf("invalid nonce length %d, expected %d", len(nonce), aead.NonceSize())
+		return
+	}
+	plain, err := aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		err = ErrWrongPassphrase
+		return
+	}
+	result = &plainSecrets{}
+	err = json.Unmarshal(plain, result)
+	if err != nil {
+		err = fmt.Errorf("failed to parse decrypted secrets: %w", err)
+	}
+	return
+}
+
+func exportCipher(passphrase string, salt []byte) (result cipher.AEAD, err error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, exportKeyIterations, exportKeyLength)
+	if err != nil {
+		err = fmt.Errorf("failed to derive key from passphrase: %w", err)
+		return
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		err = fmt.Errorf("failed to create cipher: %w", err)
+		return
+	}
+	result, err = cipher.NewGCM(block)
+	if err != nil {
+		err = fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/osac-project/fulfillment-common/oauth"
+)
+
+var _ = Describe("Export and import", func() {
+	var cfg *Config
+
+	BeforeEach(func() {
+		cfg = &Config{
+			Address:           "api.example.com:443",
+			Insecure:          true,
+			Private:           true,
+			TokenStorage:      TokenStorageKeyring,
+			AccessToken:       "my-access",
+			RefreshToken:      "my-refresh",
+			OAuthFlow:         oauth.CredentialsFlow,
+			OauthIssuer:       "https://sso.example.com",
+			OAuthClientId:     "my-client",
+			OAuthClientSecret: "my-secret",
+			OAuthScopes:       []string{"openid"},
+			OAuthUser:         "my-user",
+			OAuthPassword:     "my-password",
+			CaFiles: []CaFile{{
+				Name:    "ca.pem",
+				Content: "my-ca",
+			}},
+		}
+	})
+
+	It("Never exports the tokens", func() {
+		data, err := Export(cfg, "my-passphrase")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).ToNot(ContainSubstring("my-access"))
+		Expect(string(data)).ToNot(ContainSubstring("my-refresh"))
+	})
+
+	It("Excludes the secrets when there is no passphrase", func() {
+		data, err := Export(cfg, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).ToNot(ContainSubstring("my-secret"))
+		Expect(string(data)).ToNot(ContainSubstring("my-user"))
+		Expect(string(data)).ToNot(ContainSubstring("my-password"))
+
+		result, err := Import(data, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Address).To(Equal("api.example.com:443"))
+		Expect(result.Insecure).To(BeTrue())
+		Expect(result.Private).To(BeTrue())
+		Expect(result.TokenStorage).To(Equal(TokenStorageKeyring))
+		Expect(result.OAuthFlow).To(Equal(oauth.CredentialsFlow))
+		Expect(result.OauthIssuer).To(Equal("https://sso.example.com"))
+		Expect(result.OAuthClientId).To(Equal("my-client"))
+		Expect(result.OAuthScopes).To(ConsistOf("openid"))
+		Expect(result.OAuthClientSecret).To(BeEmpty())
+		Expect(result.OAuthUser).To(BeEmpty())
+		Expect(result.OAuthPassword).To(BeEmpty())
+		Expect(result.AccessToken).To(BeEmpty())
+		Expect(result.CaFiles).To(ConsistOf(CaFile{
+			Name:    "ca.pem",
+			Content: "my-ca",
+		}))
+	})
+
+	It("Encrypts the secrets when there is a passphrase", func() {
+		data, err := Export(cfg, "my-passphrase")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).ToNot(ContainSubstring("my-secret"))
+		Expect(string(data)).ToNot(ContainSubstring("my-password"))
+
+		result, err := Import(data, "my-passphrase")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.OAuthClientSecret).To(Equal("my-secret"))
+		Expect(result.OAuthUser).To(Equal("my-user"))
+		Expect(result.OAuthPassword).To(Equal("my-password"))
+	})
+
+	It("Requires the passphrase when the file contains secrets", func() {
+		data, err := Export(cfg, "my-passphrase")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = Import(data, "")
+		Expect(err).To(MatchError(ErrPassphraseRequired))
+	})
+
+	It("Rejects a wrong passphrase", func() {
+		data, err := Export(cfg, "my-passphrase")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = Import(data, "your-passphrase")
+		Expect(err).To(MatchError(ErrWrongPassphrase))
+	})
+
+	It("Includes the content of CA files given with absolute paths", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+		err := os.WriteFile(file, []byte("my-other-ca"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.CaFiles = []CaFile{{
+			Name: file,
+		}}
+		data, err := Export(cfg, "")
+		Expect(err).ToNot(HaveOccurred())
+		result, err := Import(data, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.CaFiles).To(ConsistOf(CaFile{
+			Name:    file,
+			Content: "my-other-ca",
+		}))
+	})
+
+	It("Includes the hooks", func() {
+		cfg.Hooks = map[string]string{
+			"pre_delete": "./confirm-change.sh",
+		}
+		cfg.HooksShell = "pwsh"
+		data, err := Export(cfg, "")
+		Expect(err).ToNot(HaveOccurred())
+		result, err := Import(data, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Hooks).To(Equal(map[string]string{
+			"pre_delete": "./confirm-change.sh",
+		}))
+		Expect(result.HooksShell).To(Equal("pwsh"))
+	})
+
+	It("Includes the location of the pricing file", func() {
+		cfg.Pricing = "https://finance.example.com/prices.yaml"
+		data, err := Export(cfg, "")
+		Expect(err).ToNot(HaveOccurred())
+		result, err := Import(data, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Pricing).To(Equal("https://finance.example.com/prices.yaml"))
+	})
+
+	It("Rejects unknown fields", func() {
+		_, err := Import([]byte("version: 1\naddres: api.example.com:443\n"), "")
+		Expect(err).To(MatchError(ContainSubstring("addres")))
+	})
+
+	It("Rejects unsupported versions", func() {
+		_, err := Import([]byte("version: 2\n"), "")
+		Expect(err).To(MatchError(ContainSubstring("unsupported exported configuration version 2")))
+	})
+})
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"slices"
+)
+
+// Favorite is an object that the user bookmarked with the 'favorite add' command, so that its state can be checked
+// quickly with the 'get favorites' command.
+type Favorite struct {
+	// Type is the fully qualified name of the object type, for example 'fulfillment.v1.Cluster'.
+	Type string `json:"type,omitempty"`
+
+	// Id is the identifier of the object.
+	Id string `json:"id,omitempty"`
+
+	// Name is the name of the object when it was added, only used to display it and to remove it by name.
+	Name string `json:"name,omitempty"`
+}
+
+// AddFavorite adds the given object to the list of favorites. It returns false if it was already there, and in that
+// case only the name is updated.
+func (c *Config) AddFavorite(value Favorite) bool {
+	for i, favorite := range c.Favorites {
+		if favorite.Type == value.Type && favorite.Id == value.Id {
+			c.Favorites[i].Name = value.Name
+			return false
+		}
+	}
+	c.Favorites = append(c.Favorites, value)
+	return true
+}
+
+// RemoveFavorites removes from the list of favorites the objects of the given type that have the given identifier or
+// name. It returns the removed favorites.
+func (c *Config) RemoveFavorites(objectType, key string) (result []Favorite) {
+	c.Favorites = slices.DeleteFunc(c.Favorites, func(favorite Favorite) bool {
+		if favorite.Type != objectType || (favorite.Id != key && favorite.Name != key) {
+			return false
+		}
+		result = append(result, favorite)
+		return true
+	})
+	return
+}
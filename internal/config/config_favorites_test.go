@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Favorites", func() {
+	const clusterType = "fulfillment.v1.Cluster"
+
+	It("Adds a favorite only once", func() {
+		cfg := &Config{}
+		Expect(cfg.AddFavorite(Favorite{Type: clusterType, Id: "123", Name: "my-cluster"})).To(BeTrue())
+		Expect(cfg.AddFavorite(Favorite{Type: clusterType, Id: "123", Name: "your-cluster"})).To(BeFalse())
+		Expect(cfg.Favorites).To(Equal([]Favorite{{
+			Type: clusterType,
+			Id:   "123",
+			Name: "your-cluster",
+		}}))
+	})
+
+	It("Removes favorites by identifier or name", func() {
+		cfg := &Config{}
+		cfg.AddFavorite(Favorite{Type: clusterType, Id: "123", Name: "my-cluster"})
+		cfg.AddFavorite(Favorite{Type: clusterType, Id: "456", Name: "your-cluster"})
+		cfg.AddFavorite(Favorite{Type: "fulfillment.v1.Host", Id: "123", Name: "my-host"})
+		Expect(cfg.RemoveFavorites(clusterType, "123")).To(HaveLen(1))
+		Expect(cfg.RemoveFavorites(clusterType, "your-cluster")).To(HaveLen(1))
+		Expect(cfg.RemoveFavorites(clusterType, "junk")).To(BeEmpty())
+		Expect(cfg.Favorites).To(Equal([]Favorite{{
+			Type: "fulfillment.v1.Host",
+			Id:   "123",
+			Name: "my-host",
+		}}))
+	})
+
+	It("Preserves the favorites only for the same server", func() {
+		tmp := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tmp)
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+		cfg := &Config{
+			Address: "api.example.com:443",
+		}
+		cfg.AddFavorite(Favorite{Type: clusterType, Id: "123"})
+		Expect(Save(cfg)).To(Succeed())
+
+		settings, err := CurrentSettings("api.example.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.Favorites).To(HaveLen(1))
+
+		settings, err = CurrentSettings("api.other.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.Favorites).To(BeEmpty())
+	})
+})
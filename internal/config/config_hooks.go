@@ -0,0 +1,45 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-cli/internal/hooks"
+)
+
+// HooksInterceptor returns the gRPC interceptor that runs the scripts configured with the 'hooks' setting of the
+// configuration file before and after creating, updating or deleting objects. For example, with this configuration
+// the 'confirm-change.sh' script receives the object in the standard input before it is deleted, and the deletion is
+// cancelled if the script fails. The scripts run with the shell of the operating system, unless a different one is
+// selected with the 'hooks_shell' setting:
+//
+//	{
+//	  "hooks": {
+//	    "pre_delete": "./confirm-change.sh"
+//	  },
+//	  "hooks_shell": "pwsh"
+//	}
+func (c *Config) HooksInterceptor(logger *slog.Logger) (result *hooks.Interceptor, err error) {
+	result, err = hooks.NewInterceptor().
+		SetLogger(logger).
+		SetHooks(c.Hooks).
+		SetShell(c.HooksShell).
+		Build()
+	if err != nil {
+		err = fmt.Errorf("invalid 'hooks' setting in the configuration file: %w", err)
+	}
+	return
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Places where the tokens can be stored:
+const (
+	// TokenStorageFile means that the tokens are stored in the configuration file, in plain text. This is the
+	// default.
+	TokenStorageFile = "file"
+
+	// TokenStorageKeyring means that the tokens are stored in the keyring of the operating system: the Secret Service
+	// in Linux, the Keychain in macOS and the Credential Manager in Windows.
+	TokenStorageKeyring = "keyring"
+)
+
+// keyringService is the service name used to store the tokens in the keyring.
+const keyringService = "fulfillment-cli"
+
+// keyringUser returns the user name used to store the tokens in the keyring. It is the location of the configuration
+// file, so that different configuration files, for example for different servers, don't share the tokens.
+func keyringUser() (result string, err error) {
+	result, err = Location()
+	if err != nil {
+		err = fmt.Errorf("failed to get the keyring entry for the tokens: %w", err)
+	}
+	return
+}
+
+// keyringTokens is the structure that is stored, in JSON format, in the keyring.
+type keyringTokens struct {
+	AccessToken  string    `json:"access_token,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenExpiry  time.Time `json:"token_expiry,omitempty"`
+}
+
+// KeyringAvailable checks if the keyring of the operating system can be used to store tokens, writing and then removing
+// a test item. When it isn't available it returns the error that explains why.
+func KeyringAvailable() error {
+	err := keyring.Set(keyringService, "probe", "probe")
+	if err != nil {
+		return err
+	}
+	return keyring.Delete(keyringService, "probe")
+}
+
+// loadKeyringTokens copies the tokens stored in the keyring into the configuration. If the configuration file still
+// contains tokens, for example because they were saved before the storage was changed to the keyring, they are
+// returned as pending, so that the caller can move them to the keyring.
+func (c *Config) loadKeyringTokens() (pending bool, err error) {
+	if c.AccessToken != "" || c.RefreshToken != "" {
+		pending = true
+		return
+	}
+	user, err := keyringUser()
+	if err != nil {
+		return
+	}
+	data, err := keyring.Get(keyringService, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to load tokens from the keyring: %w", err)
+		return
+	}
+	var tokens keyringTokens
+	err = json.Unmarshal([]byte(data), &tokens)
+	if err != nil {
+		err = fmt.Errorf("failed to parse tokens loaded from the keyring: %w", err)
+		return
+	}
+	c.AccessToken = tokens.AccessToken
+	c.RefreshToken = tokens.RefreshToken
+	c.TokenExpiry = tokens.TokenExpiry
+	return
+}
+
+// saveKeyringTokens saves the tokens of the configuration to the keyring, or removes them from the keyring if they are
+// empty. It returns a copy of the configuration without the tokens, which is what should be written to the file.
+func (c *Config) saveKeyringTokens() (result *Config, err error) {
+	if c.AccessToken == "" && c.RefreshToken == "" {
+		err = deleteKeyringTokens()
+		if err != nil {
+			return
+		}
+	} else {
+		var user string
+		user, err = keyringUser()
+		if err != nil {
+			return
+		}
+		var data []byte
+		data, err = json.Marshal(&keyringTokens{
+			AccessToken:  c.AccessToken,
+			RefreshToken: c.RefreshToken,
+			TokenExpiry:  c.TokenExpiry,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to marshal tokens: %w", err)
+			return
+		}
+		err = keyring.Set(keyringService, user, string(data))
+		if err != nil {
+			err = fmt.Errorf("failed to save tokens to the keyring: %w", err)
+			return
+		}
+	}
+	stripped := *c
+	stripped.AccessToken = ""
+	stripped.RefreshToken = ""
+	stripped.TokenExpiry = time.Time{}
+	result = &stripped
+	return
+}
+
+// deleteKeyringTokens removes the tokens of the configuration file from the keyring. It isn't an error if there are no
+// tokens in the keyring.
+func deleteKeyringTokens() error {
+	user, err := keyringUser()
+	if err != nil {
+		return err
+	}
+	err = keyring.Delete(keyringService, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove tokens from the keyring: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/zalando/go-keyring"
+)
+
+var _ = Describe("Keyring token storage", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = logging.LoggerIntoContext(context.Background(), logger)
+		keyring.MockInit()
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+	})
+
+	user := func() string {
+		result, err := keyringUser()
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	readFile := func() string {
+		file, err := Location()
+		Expect(err).ToNot(HaveOccurred())
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("Saves the tokens to the keyring instead of the file", func() {
+		expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		err := Save(&Config{
+			Address:      "api.example.com:443",
+			TokenStorage: TokenStorageKeyring,
+			AccessToken:  "my-access",
+			RefreshToken: "my-refresh",
+			TokenExpiry:  expiry,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		text := readFile()
+		Expect(text).ToNot(ContainSubstring("my-access"))
+		Expect(text).ToNot(ContainSubstring("my-refresh"))
+		Expect(text).To(ContainSubstring("api.example.com:443"))
+
+		cfg, err := Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccessToken).To(Equal("my-access"))
+		Expect(cfg.RefreshToken).To(Equal("my-refresh"))
+		Expect(cfg.TokenExpiry.Equal(expiry)).To(BeTrue())
+	})
+
+	It("Saves the tokens to the file by default", func() {
+		err := Save(&Config{
+			AccessToken: "my-access",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile()).To(ContainSubstring("my-access"))
+		_, err = keyring.Get(keyringService, user())
+		Expect(err).To(MatchError(keyring.ErrNotFound))
+	})
+
+	It("Removes the tokens from the keyring when they are empty", func() {
+		cfg := &Config{
+			TokenStorage: TokenStorageKeyring,
+			AccessToken:  "my-access",
+		}
+		err := Save(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.AccessToken = ""
+		err = Save(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = keyring.Get(keyringService, user())
+		Expect(err).To(MatchError(keyring.ErrNotFound))
+	})
+
+	It("Moves tokens from the file to the keyring", func() {
+		file, err := Location()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Dir(file), 0700)).To(Succeed())
+		err = os.WriteFile(file, []byte(`{
+			"token_storage": "keyring",
+			"access_token": "my-access"
+		}`), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		cfg, err := Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccessToken).To(Equal("my-access"))
+		Expect(readFile()).ToNot(ContainSubstring("my-access"))
+		_, err = keyring.Get(keyringService, user())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Uses a different keyring entry for each configuration file", func() {
+		first := GinkgoT().TempDir()
+		GinkgoT().Setenv("XDG_CONFIG_HOME", first)
+		err := Save(&Config{
+			TokenStorage: TokenStorageKeyring,
+			AccessToken:  "first-access",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		GinkgoT().Setenv("XDG_CONFIG_HOME", GinkgoT().TempDir())
+		err = Save(&Config{
+			TokenStorage: TokenStorageKeyring,
+			AccessToken:  "second-access",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		cfg, err := Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccessToken).To(Equal("second-access"))
+		GinkgoT().Setenv("XDG_CONFIG_HOME", first)
+		cfg, err = Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccessToken).To(Equal("first-access"))
+	})
+
+	It("Removes the tokens from the keyring when the storage changes to the file", func() {
+		cfg := &Config{
+			TokenStorage: TokenStorageKeyring,
+			AccessToken:  "my-access",
+		}
+		err := Save(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.TokenStorage = TokenStorageFile
+		err = Save(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile()).To(ContainSubstring("my-access"))
+		_, err = keyring.Get(keyringService, user())
+		Expect(err).To(MatchError(keyring.ErrNotFound))
+	})
+
+	It("Reports that the keyring is unavailable", func() {
+		keyring.MockInitWithError(keyring.ErrUnsupportedPlatform)
+		Expect(KeyringAvailable()).To(MatchError(keyring.ErrUnsupportedPlatform))
+	})
+})
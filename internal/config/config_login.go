@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"time"
+)
+
+// CurrentSettings returns the settings of the current configuration file without the details of the connection and
+// of the authentication. The settings that describe the server, like the favorites, the hooks, the pricing file and
+// the production mark, are kept only if the file is for the given address. This is intended for the 'login' command,
+// so that it only needs to replace the connection and authentication details, and logging in again doesn't lose the
+// rest of the settings.
+func CurrentSettings(address string) (result *Config, err error) {
+	result, err = LoadFile()
+	if err != nil {
+		return
+	}
+	if result.Address != address {
+		result.Favorites = nil
+		result.Hooks = nil
+		result.HooksShell = ""
+		result.Pricing = ""
+		result.Production = false
+	}
+	result.TokenScript = ""
+	result.TokenScriptTimeout = ""
+	result.TokenScriptShell = ""
+	result.Plaintext = false
+	result.Insecure = false
+	result.CaFiles = nil
+	result.Address = ""
+	result.Private = false
+	result.AccessToken = ""
+	result.RefreshToken = ""
+	result.TokenExpiry = time.Time{}
+	result.OAuthFlow = ""
+	result.OauthIssuer = ""
+	result.OAuthClientId = ""
+	result.OAuthClientSecret = ""
+	result.OAuthScopes = nil
+	result.OAuthRedirectUri = ""
+	result.OAuthUser = ""
+	result.OAuthPassword = ""
+	result.TokenStorage = ""
+	return
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Current settings", func() {
+	BeforeEach(func() {
+		tmp := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tmp)
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+	})
+
+	It("Returns an empty configuration if there is no file", func() {
+		settings, err := CurrentSettings("api.example.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(Equal(&Config{}))
+	})
+
+	It("Removes the connection and authentication details", func() {
+		err := Save(&Config{
+			TokenScript:       "my-script",
+			Plaintext:         true,
+			Insecure:          true,
+			CaFiles:           []CaFile{{Name: "/my/ca.pem"}},
+			Address:           "api.example.com:443",
+			Private:           true,
+			AccessToken:       "my-access",
+			RefreshToken:      "my-refresh",
+			TokenExpiry:       time.Now(),
+			OauthIssuer:       "https://issuer.example.com",
+			OAuthClientId:     "my-client",
+			OAuthClientSecret: "my-secret",
+			OAuthPassword:     "my-password",
+			TokenStorage:      TokenStorageFile,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		settings, err := CurrentSettings("api.example.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(Equal(&Config{}))
+	})
+
+	It("Preserves the local settings for any server", func() {
+		logMaxFiles := 3
+		err := Save(&Config{
+			Address:      "api.example.com:443",
+			StateDir:     "/my/state",
+			CacheDir:     "/my/cache",
+			LogMaxSize:   "10MiB",
+			LogMaxAge:    "24h",
+			LogMaxFiles:  &logMaxFiles,
+			ReadOnly:     true,
+			Theme:        "dark",
+			Units:        "decimal",
+			TimeZone:     "Europe/Madrid",
+			OtelEndpoint: "https://otel.example.com",
+			OtelHeaders: map[string]string{
+				"Authorization": "Bearer my-token",
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		settings, err := CurrentSettings("api.other.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(Equal(&Config{
+			StateDir:     "/my/state",
+			CacheDir:     "/my/cache",
+			LogMaxSize:   "10MiB",
+			LogMaxAge:    "24h",
+			LogMaxFiles:  &logMaxFiles,
+			ReadOnly:     true,
+			Theme:        "dark",
+			Units:        "decimal",
+			TimeZone:     "Europe/Madrid",
+			OtelEndpoint: "https://otel.example.com",
+			OtelHeaders: map[string]string{
+				"Authorization": "Bearer my-token",
+			},
+		}))
+	})
+
+	It("Preserves the settings of the server only for the same server", func() {
+		err := Save(&Config{
+			Address:    "api.example.com:443",
+			Production: true,
+			Hooks: map[string]string{
+				"pre_delete": "./confirm-change.sh",
+			},
+			HooksShell: "pwsh",
+			Pricing:    "https://finance.example.com/prices.yaml",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		settings, err := CurrentSettings("api.example.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.Production).To(BeTrue())
+		Expect(settings.Hooks).To(HaveKey("pre_delete"))
+		Expect(settings.HooksShell).To(Equal("pwsh"))
+		Expect(settings.Pricing).To(Equal("https://finance.example.com/prices.yaml"))
+		settings, err = CurrentSettings("api.other.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.Production).To(BeFalse())
+		Expect(settings.Hooks).To(BeEmpty())
+		Expect(settings.HooksShell).To(BeEmpty())
+		Expect(settings.Pricing).To(BeEmpty())
+	})
+})
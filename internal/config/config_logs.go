@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/osac-project/fulfillment-cli/internal/logrotation"
+)
+
+// LogRotation returns the policy used to rotate the log file. It is the default policy, modified by the
+// 'log_max_size', 'log_max_age' and 'log_max_files' settings of the configuration file, if present. For example, to
+// rotate the file when it reaches 100 MiB or one day, keeping three rotated files:
+//
+//	{
+//	  "log_max_size": "100MiB",
+//	  "log_max_age": "24h",
+//	  "log_max_files": 3
+//	}
+//
+// A size or age of zero disables the corresponding check.
+func (c *Config) LogRotation() (result logrotation.Policy, err error) {
+	result = logrotation.DefaultPolicy
+	if c.LogMaxSize != "" {
+		var size uint64
+		size, err = humanize.ParseBytes(c.LogMaxSize)
+		if err != nil {
+			err = fmt.Errorf("failed to parse log maximum size '%s': %w", c.LogMaxSize, err)
+			return
+		}
+		result.MaxSize = int64(size)
+	}
+	if c.LogMaxAge != "" {
+		result.MaxAge, err = time.ParseDuration(c.LogMaxAge)
+		if err != nil {
+			err = fmt.Errorf("failed to parse log maximum age '%s': %w", c.LogMaxAge, err)
+			return
+		}
+	}
+	if c.LogMaxFiles != nil {
+		if *c.LogMaxFiles < 0 {
+			err = fmt.Errorf("log maximum number of files should be zero or positive, but it is %d", *c.LogMaxFiles)
+			return
+		}
+		result.MaxFiles = *c.LogMaxFiles
+	}
+	return
+}
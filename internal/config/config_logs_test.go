@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/logrotation"
+)
+
+var _ = Describe("Log rotation", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(GinkgoT().TempDir(), "config"))
+	})
+
+	It("Returns the default policy when there are no settings", func() {
+		policy, err := loadSettings().LogRotation()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(Equal(logrotation.DefaultPolicy))
+	})
+
+	It("Applies the settings of the configuration file", func() {
+		files := 0
+		err := Save(&Config{
+			LogMaxSize:  "1MiB",
+			LogMaxAge:   "24h",
+			LogMaxFiles: &files,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		policy, err := loadSettings().LogRotation()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(Equal(logrotation.Policy{
+			MaxSize:  1024 * 1024,
+			MaxAge:   24 * time.Hour,
+			MaxFiles: 0,
+		}))
+	})
+
+	It("Rejects an invalid age", func() {
+		err := Save(&Config{
+			LogMaxAge: "junk",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = loadSettings().LogRotation()
+		Expect(err).To(MatchError(ContainSubstring("failed to parse log maximum age 'junk'")))
+	})
+})
@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-cli/internal/macros"
+)
+
+// Expander returns the object that expands the CEL macros defined with the 'macros' setting of the configuration
+// file in filters and in the expressions of the columns. For example, with this configuration the '--filter ready'
+// option selects the objects that are ready:
+//
+//	{
+//	  "macros": {
+//	    "ready": "this.status.state == fulfillment.v1.ClusterState.CLUSTER_STATE_READY"
+//	  }
+//	}
+func (c *Config) Expander(logger *slog.Logger) (result *macros.Expander, err error) {
+	result, err = macros.NewExpander().
+		SetLogger(logger).
+		SetMacros(c.Macros).
+		Build()
+	if err != nil {
+		err = fmt.Errorf("invalid 'macros' setting in the configuration file: %w", err)
+	}
+	return
+}
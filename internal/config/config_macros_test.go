@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Macros", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(GinkgoT().TempDir(), "config"))
+	})
+
+	It("Returns the macros of the configuration file", func() {
+		err := Save(&Config{
+			Macros: map[string]string{
+				"ready": "this.status.state == fulfillment.v1.ClusterState.CLUSTER_STATE_READY",
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		settings, err := CurrentSettings("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.Macros).To(HaveKeyWithValue(
+			"ready", "this.status.state == fulfillment.v1.ClusterState.CLUSTER_STATE_READY",
+		))
+	})
+
+	It("Creates an expander that uses the macros", func() {
+		cfg := &Config{
+			Macros: map[string]string{
+				"ready": "this.status.state == fulfillment.v1.ClusterState.CLUSTER_STATE_READY",
+			},
+		}
+		expander, err := cfg.Expander(logger)
+		Expect(err).ToNot(HaveOccurred())
+		result, err := expander.Expand("ready && this.metadata.name == 'my'")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(
+			"(this.status.state == fulfillment.v1.ClusterState.CLUSTER_STATE_READY) && this.metadata.name == 'my'",
+		))
+	})
+
+	It("Rejects invalid macros", func() {
+		cfg := &Config{
+			Macros: map[string]string{
+				"ready": "",
+			},
+		}
+		_, err := cfg.Expander(logger)
+		Expect(err).To(MatchError(
+			"invalid 'macros' setting in the configuration file: expression of macro 'ready' is empty",
+		))
+	})
+})
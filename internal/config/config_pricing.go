@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/osac-project/fulfillment-cli/internal/pricing"
+)
+
+// Prices loads the pricing file selected with the 'pricing' setting of the configuration file, which can be a local
+// file or a URL. It returns nil if the setting isn't present, so callers should check that before estimating costs. For
+// example:
+//
+//	{
+//	  "pricing": "https://finance.example.com/prices.yaml"
+//	}
+func (c *Config) Prices(ctx context.Context, logger *slog.Logger) (result *pricing.Prices, err error) {
+	if c.Pricing == "" {
+		return
+	}
+	result, err = pricing.Load(ctx, logger, c.Pricing)
+	return
+}
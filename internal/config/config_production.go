@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// confirmProductionFlagName is the name of the flag that confirms that a command that modifies objects should run
+// against a server that is marked as production.
+const confirmProductionFlagName = "confirm-production"
+
+// AddProductionFlags adds to the given flag set the flag that confirms that commands that create, modify or delete
+// objects should run when the configuration is marked as production. This is intended for the persistent flags of the
+// root command.
+func AddProductionFlags(flags *pflag.FlagSet) {
+	flags.Bool(
+		confirmProductionFlagName,
+		false,
+		"Confirm that a command that creates, modifies or deletes objects should run even if the server is "+
+			"marked as production. Without this flag the name of the server needs to be typed.",
+	)
+}
+
+// ServerName returns the host name of the server, without the scheme and without the port. This is what the user
+// needs to type to confirm changes in production servers, and what the 'prompt' command displays.
+func (c *Config) ServerName() string {
+	result := c.Address
+	if index := strings.Index(result, "://"); index >= 0 {
+		result = result[index+3:]
+	}
+	result, _, _ = strings.Cut(result, "/")
+	host, _, err := net.SplitHostPort(result)
+	if err == nil {
+		result = host
+	}
+	return result
+}
+
+// CheckProduction returns an error if the given command modifies objects in the server, the configuration is marked
+// as production and the change hasn't been confirmed. The change is confirmed with the '--confirm-production' flag or,
+// if the input is a terminal, typing the name of the server. Like CheckWritable it only needs the settings returned by
+// the LoadFile function, so it can be called before running any command.
+func (c *Config) CheckProduction(ctx context.Context, cmd *cobra.Command, console *terminal.Console,
+	input *os.File) error {
+	if !IsMutating(cmd) {
+		return nil
+	}
+	if !c.Production {
+		return nil
+	}
+	flag := cmd.Flags().Lookup(confirmProductionFlagName)
+	if flag != nil && flag.Value.String() == "true" {
+		return nil
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if !isatty.IsTerminal(input.Fd()) {
+		return fmt.Errorf(
+			"the '%s' command needs confirmation because server '%s' is marked as production, use "+
+				"'--confirm-production' to run it",
+			command, c.ServerName(),
+		)
+	}
+	return confirmProduction(ctx, console, input, command, c.ServerName())
+}
+
+// confirmProduction asks the user to type the name of the server, and returns an error if the answer doesn't match.
+func confirmProduction(ctx context.Context, console *terminal.Console, input io.Reader, command string,
+	name string) error {
+	console.Printf(
+		ctx,
+		"Server '%s' is marked as production, to run the '%s' command type the name of the server: ",
+		name, command,
+	)
+	line, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer := strings.TrimSpace(line)
+	if answer != name {
+		return fmt.Errorf(
+			"the '%s' command wasn't confirmed, expected '%s' but got '%s'",
+			command, name, answer,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+var _ = Describe("Production", func() {
+	var (
+		ctx     context.Context
+		buffer  *bytes.Buffer
+		console *terminal.Console
+		root    *cobra.Command
+		create  *cobra.Command
+		get     *cobra.Command
+		input   *os.File
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		tmp := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tmp)
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+
+		ctx = context.Background()
+		buffer = &bytes.Buffer{}
+		console, err = terminal.NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Prepare a command tree like the real one, with the confirmation flag in the persistent flags of the root
+		// command and where the 'create' command is mutating:
+		root = &cobra.Command{
+			Use: "fulfillment-cli",
+		}
+		AddProductionFlags(root.PersistentFlags())
+		create = &cobra.Command{
+			Use: "create",
+			Annotations: map[string]string{
+				MutatingAnnotation: "true",
+			},
+			Run: func(cmd *cobra.Command, args []string) {},
+		}
+		get = &cobra.Command{
+			Use: "get",
+			Run: func(cmd *cobra.Command, args []string) {},
+		}
+		root.AddCommand(create, get)
+
+		// The input is a pipe, so it isn't a terminal:
+		reader, writer, err := os.Pipe()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(reader.Close)
+		DeferCleanup(writer.Close)
+		input = reader
+	})
+
+	// parse parses the given command line, so that the flags of the command are populated.
+	parse := func(args ...string) *cobra.Command {
+		cmd, flags, err := root.Find(args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmd.ParseFlags(flags)).To(Succeed())
+		return cmd
+	}
+
+	It("Allows everything when there is no configuration", func() {
+		Expect(loadSettings().CheckProduction(ctx, parse("create"), console, input)).To(Succeed())
+	})
+
+	It("Allows everything when the server isn't marked as production", func() {
+		Expect(Save(&Config{Address: "api.example.com:443"})).To(Succeed())
+		Expect(loadSettings().CheckProduction(ctx, parse("create"), console, input)).To(Succeed())
+	})
+
+	It("Requires confirmation only for mutating commands", func() {
+		Expect(Save(&Config{Address: "api.example.com:443", Production: true})).To(Succeed())
+		Expect(loadSettings().CheckProduction(ctx, parse("get"), console, input)).To(Succeed())
+		err := loadSettings().CheckProduction(ctx, parse("create"), console, input)
+		Expect(err).To(MatchError(
+			"the 'create' command needs confirmation because server 'api.example.com' is marked as " +
+				"production, use '--confirm-production' to run it",
+		))
+	})
+
+	It("Accepts the confirmation flag", func() {
+		Expect(Save(&Config{Address: "api.example.com:443", Production: true})).To(Succeed())
+		cmd := parse("create", "--confirm-production")
+		Expect(loadSettings().CheckProduction(ctx, cmd, console, input)).To(Succeed())
+	})
+
+	It("Accepts the typed name of the server", func() {
+		err := confirmProduction(ctx, console, strings.NewReader("api.example.com\n"), "create", "api.example.com")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(ContainSubstring("type the name of the server"))
+	})
+
+	It("Rejects a wrong name", func() {
+		err := confirmProduction(ctx, console, strings.NewReader("api.example\n"), "create", "api.example.com")
+		Expect(err).To(MatchError(
+			"the 'create' command wasn't confirmed, expected 'api.example.com' but got 'api.example'",
+		))
+	})
+
+	It("Preserves the setting only for the same server", func() {
+		Expect(Save(&Config{Address: "api.example.com:443", Production: true})).To(Succeed())
+		settings, err := CurrentSettings("api.example.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.Production).To(BeTrue())
+		settings, err = CurrentSettings("other.example.com:443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.Production).To(BeFalse())
+	})
+
+	It("Preserves the setting in exported configurations", func() {
+		data, err := Export(&Config{
+			Address:    "api.example.com:443",
+			Production: true,
+		}, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("production: true"))
+		cfg, err := Import(data, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Production).To(BeTrue())
+	})
+})
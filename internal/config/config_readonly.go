@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// MutatingAnnotation is the annotation that commands that create, modify or delete objects in the server add, so that
+// they can be blocked when the configuration is read only. Sub-commands inherit it from their parents.
+const MutatingAnnotation = "config.mutating"
+
+// IsMutating checks if the given command, or any of its parents, is annotated as modifying objects in the server.
+func IsMutating(cmd *cobra.Command) bool {
+	for current := cmd; current != nil; current = current.Parent() {
+		if current.Annotations[MutatingAnnotation] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckWritable returns an error if the given command modifies objects in the server and the configuration is read
+// only. It only needs the settings returned by the LoadFile function, so it can be called before running any command.
+func (c *Config) CheckWritable(cmd *cobra.Command) error {
+	if !IsMutating(cmd) {
+		return nil
+	}
+	if c.ReadOnly {
+		return fmt.Errorf(
+			"the '%s' command isn't allowed because the configuration is read only, use 'login --read-only=false' "+
+				"to change it",
+			strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Read only", func() {
+	var (
+		root   *cobra.Command
+		create *cobra.Command
+		get    *cobra.Command
+	)
+
+	BeforeEach(func() {
+		tmp := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tmp)
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+
+		// Prepare a command tree where the 'create' command is mutating, and therefore also its 'cluster'
+		// sub-command:
+		root = &cobra.Command{
+			Use: "fulfillment-cli",
+		}
+		create = &cobra.Command{
+			Use: "create",
+			Annotations: map[string]string{
+				MutatingAnnotation: "true",
+			},
+		}
+		create.AddCommand(&cobra.Command{
+			Use: "cluster",
+		})
+		get = &cobra.Command{
+			Use: "get",
+		}
+		root.AddCommand(create, get)
+	})
+
+	It("Detects mutating commands and sub-commands", func() {
+		Expect(IsMutating(create)).To(BeTrue())
+		Expect(IsMutating(create.Commands()[0])).To(BeTrue())
+		Expect(IsMutating(get)).To(BeFalse())
+		Expect(IsMutating(root)).To(BeFalse())
+	})
+
+	It("Allows everything when there is no configuration", func() {
+		settings := loadSettings()
+		Expect(settings.ReadOnly).To(BeFalse())
+		Expect(settings.CheckWritable(create)).To(Succeed())
+	})
+
+	It("Blocks only mutating commands when the configuration is read only", func() {
+		err := Save(&Config{
+			Address:  "api.example.com:443",
+			ReadOnly: true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loadSettings().CheckWritable(get)).To(Succeed())
+		err = loadSettings().CheckWritable(create.Commands()[0])
+		Expect(err).To(MatchError(
+			"the 'create cluster' command isn't allowed because the configuration is read only, use " +
+				"'login --read-only=false' to change it",
+		))
+	})
+
+	It("Preserves the setting in exported configurations", func() {
+		data, err := Export(&Config{
+			Address:  "api.example.com:443",
+			ReadOnly: true,
+		}, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("read_only: true"))
+		cfg, err := Import(data, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.ReadOnly).To(BeTrue())
+	})
+})
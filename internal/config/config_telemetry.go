@@ -0,0 +1,32 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+// Telemetry returns the URL of the OTLP endpoint where the OpenTelemetry spans should be sent, and the headers that
+// should be added to the requests, from the 'otel_endpoint' and 'otel_headers' settings of the configuration file. For
+// example:
+//
+//	{
+//	  "otel_endpoint": "https://collector.example.com:4318/v1/traces",
+//	  "otel_headers": {
+//	    "Authorization": "Bearer ..."
+//	  }
+//	}
+//
+// The endpoint is empty if the setting isn't present.
+func (c *Config) Telemetry() (endpoint string, headers map[string]string) {
+	endpoint = c.OtelEndpoint
+	headers = c.OtelHeaders
+	return
+}
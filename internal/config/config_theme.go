@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+// ColorTheme returns the color theme selected with the 'theme' setting of the configuration file, or the default theme if
+// none was selected. For example:
+//
+//	{
+//	  "theme": "high-contrast"
+//	}
+func (c *Config) ColorTheme() (result *rendering.Theme, err error) {
+	name := c.Theme
+	if name == "" {
+		name = rendering.DefaultThemeName
+	}
+	result = rendering.LookupTheme(name)
+	if result == nil {
+		err = fmt.Errorf(
+			"unknown color theme '%s', should be %s",
+			name, rendering.QuoteList(rendering.ThemeNames()),
+		)
+	}
+	return
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+var _ = Describe("Time zone", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(GinkgoT().TempDir(), "config"))
+	})
+
+	It("Returns the default time zone when there is no setting", func() {
+		timeZone, err := loadSettings().TimeZoneName()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(timeZone).To(Equal(rendering.DefaultTimeZone))
+	})
+
+	It("Returns the time zone selected in the configuration file", func() {
+		err := Save(&Config{
+			TimeZone: "Europe/Madrid",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		timeZone, err := loadSettings().TimeZoneName()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(timeZone).To(Equal("Europe/Madrid"))
+	})
+
+	It("Rejects unknown time zones", func() {
+		err := Save(&Config{
+			TimeZone: "junk",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = loadSettings().TimeZoneName()
+		Expect(err).To(MatchError(ContainSubstring("unknown time zone 'junk'")))
+	})
+})
@@ -0,0 +1,33 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+// SizeUnits returns the system of units used to display sizes selected with the 'units' setting of the configuration
+// file, or the default if none was selected. For example:
+//
+//	{
+//	  "units": "decimal"
+//	}
+func (c *Config) SizeUnits() (result string, err error) {
+	result = c.Units
+	if result == "" {
+		result = rendering.DefaultUnits
+	}
+	err = rendering.CheckUnits(result)
+	return
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+var _ = Describe("Units", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(GinkgoT().TempDir(), "config"))
+	})
+
+	It("Returns the default units when there is no setting", func() {
+		units, err := loadSettings().SizeUnits()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(units).To(Equal(rendering.UnitsIEC))
+	})
+
+	It("Returns the units selected in the configuration file", func() {
+		err := Save(&Config{
+			Units: "decimal",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		units, err := loadSettings().SizeUnits()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(units).To(Equal(rendering.UnitsDecimal))
+	})
+
+	It("Rejects unknown units", func() {
+		err := Save(&Config{
+			Units: "junk",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = loadSettings().SizeUnits()
+		Expect(err).To(MatchError("unknown units 'junk', should be 'iec', 'decimal' or 'raw'"))
+	})
+})
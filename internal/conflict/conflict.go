@@ -0,0 +1,188 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package conflict contains the logic used to resolve conflicts that happen when an object is modified by the server,
+// or by other users, while it is being modified locally.
+package conflict
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Conflict describes a field that was modified both locally and in the server with different values.
+type Conflict struct {
+	// Path is the dot separated list of field names, for example 'spec.node_sets.workers.size'.
+	Path string
+
+	// Base is the original value, before any of the modifications. It is nil if the field wasn't set.
+	Base any
+
+	// Mine is the value after the local modification. It is nil if the field was removed.
+	Mine any
+
+	// Theirs is the value currently in the server. It is nil if the field was removed.
+	Theirs any
+}
+
+// String returns a short description of the conflict, including the local and the server values.
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: yours %s, current %s", c.Path, formatValue(c.Mine), formatValue(c.Theirs))
+}
+
+// IsConflict checks if the given error, returned by the server, indicates that the object couldn't be saved because it
+// was modified concurrently.
+func IsConflict(err error) bool {
+	return grpcstatus.Code(err) == codes.Aborted
+}
+
+// Merge performs a three way merge of the given objects: the base is the object as it was before the local changes,
+// mine is the object containing the local changes, and theirs is the object as it is currently in the server. The
+// result contains the changes from both sides. When a field was changed in both sides with different values the local
+// value is used, and the field is included in the returned list of conflicts so that the caller can ask the user to
+// review it. Maps are merged key by key, but lists and scalar values are always treated as a whole.
+func Merge(base, mine, theirs proto.Message) (result proto.Message, conflicts []Conflict, err error) {
+	baseValue, err := toValue(base)
+	if err != nil {
+		return
+	}
+	mineValue, err := toValue(mine)
+	if err != nil {
+		return
+	}
+	theirsValue, err := toValue(theirs)
+	if err != nil {
+		return
+	}
+	merged := mergeValues(nil, baseValue, mineValue, theirsValue, &conflicts)
+	data, err := json.Marshal(merged)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal merged object: %w", err)
+		return
+	}
+	object := mine.ProtoReflect().New().Interface()
+	err = protojson.Unmarshal(data, object)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal merged object: %w", err)
+		return
+	}
+	result = object
+	return
+}
+
+// absentType is used to represent fields that aren't present in one of the versions of the object.
+type absentType struct{}
+
+var absent = absentType{}
+
+func mergeValues(path []string, base, mine, theirs any, conflicts *[]Conflict) any {
+	// If one side didn't change the value then the result is the value of the other side:
+	switch {
+	case reflect.DeepEqual(mine, theirs):
+		return mine
+	case reflect.DeepEqual(base, mine):
+		return theirs
+	case reflect.DeepEqual(base, theirs):
+		return mine
+	}
+
+	// If both sides are maps then merge them key by key. Note that the base may not be a map if the field was added
+	// in both sides.
+	mineMap, mineOk := mine.(map[string]any)
+	theirsMap, theirsOk := theirs.(map[string]any)
+	if mineOk && theirsOk {
+		baseMap, _ := base.(map[string]any)
+		keys := slices.Concat(
+			slices.Collect(maps.Keys(baseMap)),
+			slices.Collect(maps.Keys(mineMap)),
+			slices.Collect(maps.Keys(theirsMap)),
+		)
+		slices.Sort(keys)
+		keys = slices.Compact(keys)
+		result := map[string]any{}
+		for _, key := range keys {
+			value := mergeValues(
+				append(slices.Clone(path), key),
+				lookupValue(baseMap, key),
+				lookupValue(mineMap, key),
+				lookupValue(theirsMap, key),
+				conflicts,
+			)
+			if value != absent {
+				result[key] = value
+			}
+		}
+		return result
+	}
+
+	// Otherwise this is a conflict, and the local value wins:
+	*conflicts = append(*conflicts, Conflict{
+		Path:   strings.Join(path, "."),
+		Base:   presentValue(base),
+		Mine:   presentValue(mine),
+		Theirs: presentValue(theirs),
+	})
+	return mine
+}
+
+func lookupValue(values map[string]any, key string) any {
+	value, ok := values[key]
+	if !ok {
+		return absent
+	}
+	return value
+}
+
+func presentValue(value any) any {
+	if value == absent {
+		return nil
+	}
+	return value
+}
+
+func formatValue(value any) string {
+	if value == nil {
+		return "(not set)"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func toValue(object proto.Message) (result any, err error) {
+	data, err := marshalOptions.Marshal(object)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal object: %w", err)
+		return
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+	return
+}
+
+var marshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
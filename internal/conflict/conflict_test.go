@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package conflict
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Conflict", func() {
+	// makeCluster creates a cluster with the given name, labels and state.
+	makeCluster := func(name string, labels map[string]string, state ffv1.ClusterState) *ffv1.Cluster {
+		return ffv1.Cluster_builder{
+			Id: "123",
+			Metadata: sharedv1.Metadata_builder{
+				Name:   name,
+				Labels: labels,
+			}.Build(),
+			Status: ffv1.ClusterStatus_builder{
+				State: state,
+			}.Build(),
+		}.Build()
+	}
+
+	It("Applies local changes on top of the server changes", func() {
+		base := makeCluster("old", nil, ffv1.ClusterState_CLUSTER_STATE_PROGRESSING)
+		mine := makeCluster("new", nil, ffv1.ClusterState_CLUSTER_STATE_PROGRESSING)
+		theirs := makeCluster("old", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		result, conflicts, err := Merge(base, mine, theirs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conflicts).To(BeEmpty())
+		expected := makeCluster("new", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		Expect(proto.Equal(result, expected)).To(BeTrue())
+	})
+
+	It("Merges maps key by key", func() {
+		base := makeCluster("my", map[string]string{
+			"a": "1",
+			"b": "2",
+		}, ffv1.ClusterState_CLUSTER_STATE_READY)
+		mine := makeCluster("my", map[string]string{
+			"a": "1",
+			"c": "3",
+		}, ffv1.ClusterState_CLUSTER_STATE_READY)
+		theirs := makeCluster("my", map[string]string{
+			"a": "1",
+			"b": "2",
+			"d": "4",
+		}, ffv1.ClusterState_CLUSTER_STATE_READY)
+		result, conflicts, err := Merge(base, mine, theirs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conflicts).To(BeEmpty())
+		Expect(result.(*ffv1.Cluster).GetMetadata().GetLabels()).To(Equal(map[string]string{
+			"a": "1",
+			"c": "3",
+			"d": "4",
+		}))
+	})
+
+	It("Reports fields changed in both sides and keeps the local value", func() {
+		base := makeCluster("old", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		mine := makeCluster("mine", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		theirs := makeCluster("theirs", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		result, conflicts, err := Merge(base, mine, theirs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.(*ffv1.Cluster).GetMetadata().GetName()).To(Equal("mine"))
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Path).To(Equal("metadata.name"))
+		Expect(conflicts[0].Base).To(Equal("old"))
+		Expect(conflicts[0].Mine).To(Equal("mine"))
+		Expect(conflicts[0].Theirs).To(Equal("theirs"))
+		Expect(conflicts[0].String()).To(Equal(`metadata.name: yours "mine", current "theirs"`))
+	})
+
+	It("Reports fields removed in one side and changed in the other", func() {
+		base := makeCluster("old", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		mine := makeCluster("", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		theirs := makeCluster("theirs", nil, ffv1.ClusterState_CLUSTER_STATE_READY)
+		_, conflicts, err := Merge(base, mine, theirs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Mine).To(BeNil())
+		Expect(conflicts[0].String()).To(Equal(`metadata.name: yours (not set), current "theirs"`))
+	})
+
+	It("Recognizes conflict errors", func() {
+		Expect(IsConflict(grpcstatus.Error(codes.Aborted, "modified"))).To(BeTrue())
+		Expect(IsConflict(grpcstatus.Error(codes.NotFound, "not found"))).To(BeFalse())
+		Expect(IsConflict(errors.New("other"))).To(BeFalse())
+	})
+})
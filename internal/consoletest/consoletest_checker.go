@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package consoletest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osac-project/fulfillment-cli/internal/packages"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// noValue is the text that the Go template engine writes when a map doesn't have the requested key.
+const noValue = "<no value>"
+
+// Cases contains, for each template name, the data values that the template will be rendered with. These should be
+// representative of what the command passes to the template, including the optional parts.
+type Cases map[string][]any
+
+// CheckerBuilder contains the data and logic needed to create a checker. Don't create instances of this type directly,
+// use the NewChecker function instead.
+type CheckerBuilder struct {
+	logger *slog.Logger
+	fsys   fs.FS
+	dir    string
+}
+
+// Checker renders all the templates of a command with the data of the test cases, and checks that they don't fail.
+// This is intended for unit tests, because when the templates fail at runtime the problem is only written to the log.
+type Checker struct {
+	logger  *slog.Logger
+	conn    *grpc.ClientConn
+	helper  *reflection.Helper
+	console *terminal.Console
+}
+
+// NewChecker creates a builder that can then be used to configure and create a checker.
+func NewChecker() *CheckerBuilder {
+	return &CheckerBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *CheckerBuilder) SetLogger(value *slog.Logger) *CheckerBuilder {
+	b.logger = value
+	return b
+}
+
+// SetTemplates sets the file system and the directory inside it that contain the templates, the same that the
+// command passes to the AddTemplates method of the console. This is mandatory.
+func (b *CheckerBuilder) SetTemplates(fsys fs.FS, dir string) *CheckerBuilder {
+	b.fsys = fsys
+	b.dir = dir
+	return b
+}
+
+// Build uses the data stored in the builder to create a new checker.
+func (b *CheckerBuilder) Build() (result *Checker, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.fsys == nil {
+		err = errors.New("templates are mandatory")
+		return
+	}
+
+	// Create the reflection helper needed by the 'table' function. The descriptors are compiled into the binary, so
+	// the connection is never used as long as the objects passed to the templates don't reference others.
+	conn, err := grpc.NewClient("127.0.0.1:0", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		err = fmt.Errorf("failed to create connection: %w", err)
+		return
+	}
+	helperBuilder := reflection.NewHelper().
+		SetLogger(b.logger).
+		SetConnection(conn)
+	for _, name := range packages.Public {
+		helperBuilder.AddPackage(name, 1)
+	}
+	for _, name := range packages.Private {
+		helperBuilder.AddPackage(name, 0)
+	}
+	helper, err := helperBuilder.Build()
+	if err != nil {
+		conn.Close()
+		err = fmt.Errorf("failed to create reflection helper: %w", err)
+		return
+	}
+
+	// Create the console:
+	console, err := terminal.NewConsole().
+		SetLogger(b.logger).
+		SetWriter(io.Discard).
+		SetHelper(helper).
+		SetColor(terminal.ColorNever).
+		Build()
+	if err != nil {
+		conn.Close()
+		err = fmt.Errorf("failed to create console: %w", err)
+		return
+	}
+	err = console.AddTemplates(b.fsys, b.dir)
+	if err != nil {
+		conn.Close()
+		err = fmt.Errorf("failed to load templates: %w", err)
+		return
+	}
+
+	// Create and populate the object:
+	result = &Checker{
+		logger:  b.logger,
+		conn:    conn,
+		helper:  helper,
+		console: console,
+	}
+	return
+}
+
+// Helper returns the reflection helper that the checker uses. Tests can pass it to the templates that need it, like the
+// ones that list the object types.
+func (c *Checker) Helper() *reflection.Helper {
+	return c.helper
+}
+
+// Check renders all the templates with all the given test cases, and returns an error describing all the problems
+// found. It is a problem if a template fails, if the result contains the '<no value>' text that the template engine
+// writes for missing keys, if a template doesn't have test cases, or if there are test cases for templates that don't
+// exist.
+func (c *Checker) Check(cases Cases) error {
+	var errs []error
+	names := c.console.Templates()
+	slices.Sort(names)
+	for _, name := range names {
+		values := cases[name]
+		if len(values) == 0 {
+			errs = append(errs, fmt.Errorf("template '%s' has no test cases", name))
+			continue
+		}
+		for i, data := range values {
+			err := c.checkCase(name, i, data)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for name := range cases {
+		if !slices.Contains(names, name) {
+			errs = append(errs, fmt.Errorf("there are test cases for template '%s', but it doesn't exist", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Checker) checkCase(name string, i int, data any) error {
+	text, err := c.console.Execute(name, data)
+	if err != nil {
+		return fmt.Errorf("case %d of template '%s' failed: %w", i, name, err)
+	}
+	for number, line := range strings.Split(text, "\n") {
+		if strings.Contains(line, noValue) {
+			return fmt.Errorf(
+				"case %d of template '%s' contains '%s' in line %d, probably because of a missing key: %s",
+				i, name, noValue, number+1, line,
+			)
+		}
+	}
+	return nil
+}
+
+// Close releases the resources used by the checker.
+func (c *Checker) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,45 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package describe
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// Names of the command line flags:
+const (
+	SortFlagName     = "sort"
+	MaxItemsFlagName = "max-items"
+)
+
+// DefaultMaxItems is the number of items of each list that are displayed when the flag isn't used.
+const DefaultMaxItems = 20
+
+// AddFlags adds to the given flag set the flags that control how the lists of items are displayed. All the describe
+// commands that display lists should use this, so that the flags are the same.
+func AddFlags(flags *pflag.FlagSet, options *ListOptions) {
+	flags.StringVar(
+		&options.Sort,
+		SortFlagName,
+		SortByName,
+		"Order of the items of lists, like the hosts of a pool or the conditions of a cluster. Can be 'name' "+
+			"or 'time', with a '-' prefix to reverse it, for example '-time' to display the most recent first.",
+	)
+	flags.IntVar(
+		&options.MaxItems,
+		MaxItemsFlagName,
+		DefaultMaxItems,
+		"Maximum number of items displayed for each list. Use zero to display all of them.",
+	)
+}
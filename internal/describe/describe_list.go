@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package describe contains helpers used by the describe commands to display the lists of items that objects
+// contain, like the hosts of a pool or the conditions of a cluster.
+package describe
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Sort orders supported for the items of lists:
+const (
+	SortByName = "name"
+	SortByTime = "time"
+)
+
+// ListOptions controls how lists of items are displayed.
+type ListOptions struct {
+	// Sort is the order of the items, 'name' or 'time', optionally with a '-' prefix to reverse it.
+	Sort string
+
+	// MaxItems is the maximum number of items displayed, the rest are summarized in one line. Zero means that all the
+	// items are displayed.
+	MaxItems int
+}
+
+// Item is an item of a list.
+type Item struct {
+	// Name is used to sort the items by name.
+	Name string
+
+	// Time is used to sort the items by time. Items without time are displayed after the rest.
+	Time time.Time
+
+	// Line is the text displayed for the item. It can contain tabs, to align columns with a tab writer.
+	Line string
+}
+
+// Check verifies that the options are valid.
+func (o ListOptions) Check() error {
+	order := strings.TrimPrefix(o.Sort, "-")
+	if order != SortByName && order != SortByTime {
+		return fmt.Errorf(
+			"unknown sort order '%s', should be '%s', '-%s', '%s' or '-%s'",
+			o.Sort, SortByName, SortByName, SortByTime, SortByTime,
+		)
+	}
+	if o.MaxItems < 0 {
+		return fmt.Errorf("maximum number of items should be zero or positive, but it is %d", o.MaxItems)
+	}
+	return nil
+}
+
+// WriteList writes the title followed by the sorted items, indented. If there are more items than the maximum the
+// rest are replaced by a line that says how many were omitted. Nothing is written if there are no items.
+func WriteList(writer io.Writer, title string, items []Item, options ListOptions) {
+	if len(items) == 0 {
+		return
+	}
+	items = SortItems(items, options.Sort)
+	fmt.Fprintf(writer, "\n%s:\n", title)
+	shown := items
+	if options.MaxItems > 0 && len(items) > options.MaxItems {
+		shown = items[:options.MaxItems]
+	}
+	for _, item := range shown {
+		fmt.Fprintf(writer, "  %s\n", item.Line)
+	}
+	if omitted := len(items) - len(shown); omitted > 0 {
+		fmt.Fprintf(writer, "  … and %d more\n", omitted)
+	}
+}
+
+// SortItems returns a sorted copy of the given items.
+func SortItems(items []Item, order string) []Item {
+	result := slices.Clone(items)
+	reverse := strings.HasPrefix(order, "-")
+	byName := func(a, b Item) int {
+		return cmp.Compare(a.Name, b.Name)
+	}
+	byTime := func(a, b Item) int {
+		// Items without time always go last, regardless of the direction:
+		switch {
+		case a.Time.IsZero() && b.Time.IsZero():
+			return byName(a, b)
+		case a.Time.IsZero():
+			return 1
+		case b.Time.IsZero():
+			return -1
+		}
+		result := a.Time.Compare(b.Time)
+		if reverse {
+			result = -result
+		}
+		if result == 0 {
+			result = byName(a, b)
+		}
+		return result
+	}
+	switch strings.TrimPrefix(order, "-") {
+	case SortByTime:
+		slices.SortStableFunc(result, byTime)
+	default:
+		slices.SortStableFunc(result, func(a, b Item) int {
+			if reverse {
+				return byName(b, a)
+			}
+			return byName(a, b)
+		})
+	}
+	return result
+}
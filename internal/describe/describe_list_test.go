@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package describe
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("List", func() {
+	// Times used for the items, in increasing order:
+	t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	// Items used for the tests, in no particular order:
+	items := []Item{
+		{Name: "b", Time: t1, Line: "b"},
+		{Name: "c", Line: "c"},
+		{Name: "a", Time: t3, Line: "a"},
+		{Name: "d", Time: t2, Line: "d"},
+	}
+
+	names := func(items []Item) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.Name
+		}
+		return result
+	}
+
+	DescribeTable(
+		"Sorts items",
+		func(order string, expected []string) {
+			Expect(names(SortItems(items, order))).To(Equal(expected))
+		},
+		Entry("By name", "name", []string{"a", "b", "c", "d"}),
+		Entry("By name reversed", "-name", []string{"d", "c", "b", "a"}),
+		Entry("By time, without time last", "time", []string{"b", "d", "a", "c"}),
+		Entry("By time reversed, without time last", "-time", []string{"a", "d", "b", "c"}),
+	)
+
+	It("Doesn't modify the original items", func() {
+		SortItems(items, SortByName)
+		Expect(names(items)).To(Equal([]string{"b", "c", "a", "d"}))
+	})
+
+	It("Writes all the items when there is no maximum", func() {
+		buffer := &bytes.Buffer{}
+		WriteList(buffer, "Things", items, ListOptions{
+			Sort: SortByName,
+		})
+		Expect(buffer.String()).To(Equal("\nThings:\n  a\n  b\n  c\n  d\n"))
+	})
+
+	It("Summarizes the items beyond the maximum", func() {
+		buffer := &bytes.Buffer{}
+		WriteList(buffer, "Things", items, ListOptions{
+			Sort:     SortByName,
+			MaxItems: 2,
+		})
+		Expect(buffer.String()).To(Equal("\nThings:\n  a\n  b\n  … and 2 more\n"))
+	})
+
+	It("Writes nothing when there are no items", func() {
+		buffer := &bytes.Buffer{}
+		WriteList(buffer, "Things", nil, ListOptions{
+			Sort: SortByName,
+		})
+		Expect(buffer.Len()).To(BeZero())
+	})
+
+	DescribeTable(
+		"Checks options",
+		func(options ListOptions, expected string) {
+			err := options.Check()
+			if expected == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expected))
+			}
+		},
+		Entry("Name", ListOptions{Sort: "name"}, ""),
+		Entry("Reversed time with maximum", ListOptions{Sort: "-time", MaxItems: 5}, ""),
+		Entry(
+			"Unknown order",
+			ListOptions{Sort: "size"},
+			"unknown sort order 'size', should be 'name', '-name', 'time' or '-time'",
+		),
+		Entry(
+			"Negative maximum",
+			ListOptions{Sort: "name", MaxItems: -1},
+			"maximum number of items should be zero or positive, but it is -1",
+		),
+	)
+})
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package diff contains functions to compare versions of objects and to describe the fields that are different.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Change describes a field that has different values in two versions of an object.
+type Change struct {
+	// Path is the dot separated list of field names, for example 'status.state'.
+	Path string `json:"path"`
+
+	// Before is the value in the previous version. It is nil if the field wasn't set.
+	Before any `json:"before"`
+
+	// After is the value in the current version. It is nil if the field was removed.
+	After any `json:"after"`
+}
+
+// String returns a short description of the change, with the previous and the current values.
+func (c *Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, FormatValue(c.Before), FormatValue(c.After))
+}
+
+// Compare compares the given versions of an object and returns the list of fields that have different values, sorted
+// by path. Maps and messages are compared field by field, but lists and scalar values are compared as a whole. Any of
+// the versions can be nil, and then all the fields of the other version are considered changed.
+func Compare(before, after proto.Message) (result []*Change, err error) {
+	beforeValue, err := toValue(before)
+	if err != nil {
+		return
+	}
+	afterValue, err := toValue(after)
+	if err != nil {
+		return
+	}
+	compareValues(nil, beforeValue, afterValue, &result)
+	return
+}
+
+// FormatValue formats the given value, as returned in the Before and After fields of a change, using the JSON format.
+// Values that aren't set are formatted as '(not set)'.
+func FormatValue(value any) string {
+	if value == nil {
+		return "(not set)"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func compareValues(path []string, before, after any, changes *[]*Change) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	beforeMap, beforeOk := before.(map[string]any)
+	afterMap, afterOk := after.(map[string]any)
+
+	// The top level values are always compared field by field, even if one of them is missing, so that the creation
+	// or deletion of an object is described as changes to each of its fields:
+	if len(path) == 0 {
+		beforeOk = beforeOk || before == nil
+		afterOk = afterOk || after == nil
+	}
+	if beforeOk && afterOk {
+		keys := slices.Concat(
+			slices.Collect(maps.Keys(beforeMap)),
+			slices.Collect(maps.Keys(afterMap)),
+		)
+		slices.Sort(keys)
+		keys = slices.Compact(keys)
+		for _, key := range keys {
+			compareValues(append(slices.Clone(path), key), beforeMap[key], afterMap[key], changes)
+		}
+		return
+	}
+	*changes = append(*changes, &Change{
+		Path:   strings.Join(path, "."),
+		Before: before,
+		After:  after,
+	})
+}
+
+func toValue(object proto.Message) (result any, err error) {
+	if object == nil {
+		return
+	}
+	data, err := marshalOptions.Marshal(object)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal object: %w", err)
+		return
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+	return
+}
+
+var marshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
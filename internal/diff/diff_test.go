@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package diff
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Compare", func() {
+	It("Returns the fields that changed", func() {
+		before := &ffv1.Cluster{
+			Id: "123",
+			Status: &ffv1.ClusterStatus{
+				State: ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+			},
+		}
+		after := &ffv1.Cluster{
+			Id: "123",
+			Status: &ffv1.ClusterStatus{
+				State:  ffv1.ClusterState_CLUSTER_STATE_READY,
+				ApiUrl: "https://api.my.example.com:6443",
+			},
+		}
+		changes, err := Compare(before, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].String()).To(Equal(
+			`status.api_url: (not set) -> "https://api.my.example.com:6443"`,
+		))
+		Expect(changes[1].String()).To(Equal(
+			`status.state: "CLUSTER_STATE_PROGRESSING" -> "CLUSTER_STATE_READY"`,
+		))
+	})
+
+	It("Reports removed fields", func() {
+		before := &ffv1.Cluster{
+			Id: "123",
+			Status: &ffv1.ClusterStatus{
+				ApiUrl: "https://api.my.example.com:6443",
+			},
+		}
+		after := &ffv1.Cluster{
+			Id: "123",
+		}
+		changes, err := Compare(before, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].String()).To(Equal(
+			`status: {"api_url":"https://api.my.example.com:6443"} -> (not set)`,
+		))
+	})
+
+	It("Returns nothing when the objects are equal", func() {
+		object := &ffv1.Cluster{
+			Id: "123",
+		}
+		changes, err := Compare(object, proto.Clone(object))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("Reports all the top level fields when there is no previous version", func() {
+		after := &ffv1.Cluster{
+			Id: "123",
+			Status: &ffv1.ClusterStatus{
+				State: ffv1.ClusterState_CLUSTER_STATE_READY,
+			},
+		}
+		changes, err := Compare(nil, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].String()).To(Equal(`id: (not set) -> "123"`))
+		Expect(changes[1].String()).To(Equal(`status: (not set) -> {"state":"CLUSTER_STATE_READY"}`))
+	})
+
+	It("Reports all the top level fields when there is no current version", func() {
+		before := &ffv1.Cluster{
+			Id: "123",
+		}
+		changes, err := Compare(before, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].String()).To(Equal(`id: "123" -> (not set)`))
+	})
+})
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unified formats the given changes using the syntax of unified diffs, so that the result can be colored or processed
+// by the tools that understand that syntax. Each change is a hunk whose header is the path of the field, followed by
+// the previous value, prefixed with '-', and the current value, prefixed with '+'. Values that aren't set are omitted,
+// and maps and lists are written in YAML format, one line per element. The labels are used in the file header.
+func Unified(beforeLabel, afterLabel string, changes []*Change) string {
+	buffer := &strings.Builder{}
+	fmt.Fprintf(buffer, "--- %s\n", beforeLabel)
+	fmt.Fprintf(buffer, "+++ %s\n", afterLabel)
+	for _, change := range changes {
+		fmt.Fprintf(buffer, "@@ %s @@\n", change.Path)
+		writeUnifiedValue(buffer, "-", change.Before)
+		writeUnifiedValue(buffer, "+", change.After)
+	}
+	return buffer.String()
+}
+
+func writeUnifiedValue(buffer *strings.Builder, prefix string, value any) {
+	if value == nil {
+		return
+	}
+	text := FormatValue(value)
+	switch value.(type) {
+	case map[string]any, []any:
+		data, err := yaml.Marshal(value)
+		if err == nil {
+			text = strings.TrimSuffix(string(data), "\n")
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(buffer, "%s%s\n", prefix, line)
+	}
+}
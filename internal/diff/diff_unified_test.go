@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package diff
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unified", func() {
+	It("Formats scalar values", func() {
+		text := Unified("server", "file", []*Change{
+			{
+				Path:   "spec.template",
+				Before: "small",
+				After:  "large",
+			},
+			{
+				Path:  "metadata.name",
+				After: "my-cluster",
+			},
+		})
+		Expect(text).To(Equal(
+			"--- server\n" +
+				"+++ file\n" +
+				"@@ spec.template @@\n" +
+				"-\"small\"\n" +
+				"+\"large\"\n" +
+				"@@ metadata.name @@\n" +
+				"+\"my-cluster\"\n",
+		))
+	})
+
+	It("Formats maps and lists in multiple lines", func() {
+		text := Unified("server", "file", []*Change{{
+			Path: "spec.node_sets",
+			Before: map[string]any{
+				"workers": map[string]any{
+					"size": 3,
+				},
+			},
+			After: []any{"a", "b"},
+		}})
+		Expect(text).To(Equal(
+			"--- server\n" +
+				"+++ file\n" +
+				"@@ spec.node_sets @@\n" +
+				"-workers:\n" +
+				"-    size: 3\n" +
+				"+- a\n" +
+				"+- b\n",
+		))
+	})
+})
@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package exit
+
+// Exit codes used for the different classes of errors, so that scripts can react to them without parsing the error
+// messages.
+const (
+	// General is the exit code used for errors that don't belong to any of the other classes.
+	General Error = 1
+
+	// Usage is the exit code used when the command line is wrong, for example when a required argument is missing,
+	// when a flag is unknown or has a wrong value, or when two options can't be used together. These errors are
+	// detected before sending any request to the server.
+	Usage Error = 2
+
+	// NotFound is the exit code used when the requested object doesn't exist.
+	NotFound Error = 3
+
+	// Auth is the exit code used when the server rejects the credentials, or when they don't grant permission to
+	// perform the operation.
+	Auth Error = 4
+
+	// Conflict is the exit code used when the object was modified by someone else at the same time, or when an
+	// object with the same name or identifier already exists.
+	Conflict Error = 5
+
+	// Timeout is the exit code used when the server or the operation doesn't finish in time, for example when
+	// waiting for an object to be ready.
+	Timeout Error = 6
+
+	// Validation is the exit code used when the server rejects the request because it is invalid.
+	Validation Error = 7
+
+	// Unavailable is the exit code used when the server can't be reached.
+	Unavailable Error = 8
+
+	// Integrity is the exit code used when the binary of the CLI can't be verified, because its checksum doesn't
+	// match the published ones or because its build attestation is rejected.
+	Integrity Error = 9
+
+	// Interrupted is the exit code used when the command is stopped with an interrupt or termination signal, for
+	// example with Ctrl+C. It is the code that shells use for processes killed by the interrupt signal.
+	Interrupted Error = 130
+)
@@ -13,7 +13,10 @@ language governing permissions and limitations under the License.
 
 package exit
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error is an error type that contains a process exit code. This is itended for situations where/ you want to call
 // os.Exit only in one place, but also want some deeply nested functions to decide what should be the exit code.
@@ -28,3 +31,48 @@ func (e Error) Error() string {
 func (e Error) Code() int {
 	return int(e)
 }
+
+// WithCode returns an error that wraps the given one and that results in the given exit code. Unlike the errors of
+// type Error, that are used when the problem has already been reported, the message of the wrapped error is still
+// written to the user.
+func WithCode(code Error, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{
+		code: code,
+		err:  err,
+	}
+}
+
+// Usagef creates an error for a wrong command line, that results in the Usage exit code.
+func Usagef(format string, args ...any) error {
+	return WithCode(Usage, fmt.Errorf(format, args...))
+}
+
+// CodeOf returns the exit code that was attached to the error, or to any of the errors that it wraps, with the
+// WithCode function. The boolean result will be false if there is no such code.
+func CodeOf(err error) (result Error, ok bool) {
+	var coded *codedError
+	ok = errors.As(err, &coded)
+	if ok {
+		result = coded.code
+	}
+	return
+}
+
+// codedError is the error returned by the WithCode function.
+type codedError struct {
+	code Error
+	err  error
+}
+
+// Error is the implementation of the error interface.
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *codedError) Unwrap() error {
+	return e.err
+}
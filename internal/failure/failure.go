@@ -0,0 +1,265 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package failure contains the logic used to present to the user the errors returned by the commands, in particular
+// the errors returned by the server, that contain a gRPC status code and may contain additional details.
+package failure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+// Render writes to the given writer the description of the error, and returns the exit code that should be used for
+// it. For errors returned by the server the text generated by the gRPC library is replaced by the description sent by
+// the server, and the field violations, retry information and localized messages included in the details are also
+// written.
+func Render(writer io.Writer, err error) exit.Error {
+	// Errors that already contain an exit code have already been reported to the user:
+	var exitErr exit.Error
+	if errors.As(err, &exitErr) {
+		return exitErr
+	}
+
+	// Write the message and the details:
+	report := analyze(err)
+	fmt.Fprintf(writer, "Error: %s\n", report.Message)
+	if len(report.FieldViolations) > 0 {
+		fmt.Fprintf(writer, "Invalid fields:\n")
+		for _, violation := range report.FieldViolations {
+			fmt.Fprintf(writer, "  - %s: %s\n", violation.Field, violation.Description)
+		}
+	}
+	if report.RetryAfter != "" {
+		fmt.Fprintf(writer, "Retry after: %s\n", report.RetryAfter)
+	}
+	if report.Hint != "" {
+		fmt.Fprintf(writer, "%s\n", report.Hint)
+	}
+	return exit.Error(report.ExitCode)
+}
+
+// RenderJson is like Render, but it writes the description of the error as a single line JSON document, intended for
+// tools that run the CLI. For errors that already contain an exit code the messages have already been written, and
+// the document contains only the exit code and a generic message.
+func RenderJson(writer io.Writer, err error) exit.Error {
+	report := summarize(err)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encodeErr := encoder.Encode(map[string]any{
+		"error": report,
+	})
+	if encodeErr != nil {
+		fmt.Fprintf(writer, "Error: %s\n", report.Message)
+	}
+	return exit.Error(report.ExitCode)
+}
+
+// errorReport contains the information extracted from an error that is presented to the user.
+type errorReport struct {
+	Message         string            `json:"message"`
+	Status          string            `json:"status,omitempty"`
+	ExitCode        int               `json:"exit_code"`
+	FieldViolations []*fieldViolation `json:"field_violations,omitempty"`
+	RetryAfter      string            `json:"retry_after,omitempty"`
+	Hint            string            `json:"hint,omitempty"`
+}
+
+// fieldViolation describes a field of the request that the server considered invalid.
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// summarize is like analyze, but for errors that already contain an exit code, and whose messages have already been
+// written, it returns only the exit code and a generic message.
+func summarize(err error) *errorReport {
+	var exitErr exit.Error
+	if errors.As(err, &exitErr) {
+		return &errorReport{
+			Message:  "command failed",
+			ExitCode: exitErr.Code(),
+		}
+	}
+	return analyze(err)
+}
+
+// analyze extracts from the error the information that is presented to the user.
+func analyze(err error) *errorReport {
+	// Find the status error. If there is no status then this isn't an error returned by the server.
+	var statusErr interface {
+		error
+		GRPCStatus() *grpcstatus.Status
+	}
+	if !errors.As(err, &statusErr) || statusErr.GRPCStatus() == nil {
+		return &errorReport{
+			Message:  err.Error(),
+			ExitCode: codeOf(err).Code(),
+		}
+	}
+	status := statusErr.GRPCStatus()
+	class := classOf(status.Code())
+	report := &errorReport{
+		Status:   status.Code().String(),
+		ExitCode: class.code.Code(),
+		Hint:     class.hint,
+	}
+	if code, ok := exit.CodeOf(err); ok {
+		report.ExitCode = code.Code()
+	}
+
+	// Extract the details that we know how to present:
+	var localized *errdetails.LocalizedMessage
+	for _, detail := range status.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.LocalizedMessage:
+			localized = detail
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				report.FieldViolations = append(report.FieldViolations, &fieldViolation{
+					Field:       violation.GetField(),
+					Description: violation.GetDescription(),
+				})
+			}
+		case *errdetails.RetryInfo:
+			if detail.GetRetryDelay() != nil {
+				report.RetryAfter = detail.GetRetryDelay().AsDuration().String()
+			}
+		}
+	}
+
+	// Replace the 'rpc error: code = ... desc = ...' text generated by the gRPC library with the description, keeping
+	// the context added by the command. The localized message is preferred because it is intended for humans, and
+	// when the server doesn't send any description we use the generic one for the code.
+	description := status.Message()
+	if localized.GetMessage() != "" {
+		description = localized.GetMessage()
+	}
+	if description == "" {
+		description = class.summary
+	}
+	report.Message = strings.Replace(err.Error(), statusErr.Error(), description, 1)
+	return report
+}
+
+// codeOf returns the exit code for an error that wasn't returned by the server. The code attached by the command
+// with the exit.WithCode function is preferred. Otherwise errors caused by exceeding a deadline are considered
+// timeouts, and the rest are general errors.
+func codeOf(err error) exit.Error {
+	code, ok := exit.CodeOf(err)
+	if ok {
+		return code
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return exit.Timeout
+	}
+	return exit.General
+}
+
+// errorClass describes how errors with a gRPC status code are presented.
+type errorClass struct {
+	// code is the exit code.
+	code exit.Error
+
+	// summary is the description used when the server doesn't send one.
+	summary string
+
+	// hint is an optional sentence telling the user what can be done about the error.
+	hint string
+}
+
+func classOf(code codes.Code) errorClass {
+	class, ok := errorClasses[code]
+	if !ok {
+		class = errorClass{
+			code:    exit.General,
+			summary: fmt.Sprintf("the server returned an unexpected error (%s)", code),
+		}
+	}
+	return class
+}
+
+var errorClasses = map[codes.Code]errorClass{
+	codes.Unauthenticated: {
+		code:    exit.Auth,
+		summary: "the credentials were rejected",
+		hint:    "Run the 'login' command to obtain new credentials.",
+	},
+	codes.PermissionDenied: {
+		code:    exit.Auth,
+		summary: "permission denied",
+		hint:    "Your credentials don't grant permission to perform this operation.",
+	},
+	codes.NotFound: {
+		code:    exit.NotFound,
+		summary: "the object doesn't exist",
+	},
+	codes.InvalidArgument: {
+		code:    exit.Validation,
+		summary: "the request is invalid",
+	},
+	codes.FailedPrecondition: {
+		code:    exit.Validation,
+		summary: "the object isn't in a state that allows this operation",
+	},
+	codes.OutOfRange: {
+		code:    exit.Validation,
+		summary: "a value of the request is out of range",
+	},
+	codes.AlreadyExists: {
+		code:    exit.Conflict,
+		summary: "the object already exists",
+	},
+	codes.Unavailable: {
+		code:    exit.Unavailable,
+		summary: "the server is unavailable",
+		hint:    "Check the address of the server and your network connection, or try again later.",
+	},
+	codes.DeadlineExceeded: {
+		code:    exit.Timeout,
+		summary: "the server didn't respond in time",
+		hint:    "Try again later.",
+	},
+	codes.ResourceExhausted: {
+		code:    exit.General,
+		summary: "the server is overloaded or a quota was exceeded",
+	},
+	codes.Aborted: {
+		code:    exit.Conflict,
+		summary: "the object was modified concurrently",
+		hint:    "Try again.",
+	},
+	codes.Unimplemented: {
+		code:    exit.General,
+		summary: "the server doesn't support this operation",
+		hint:    "The server may be older than the CLI.",
+	},
+	codes.Canceled: {
+		code:    exit.General,
+		summary: "the operation was canceled",
+	},
+	codes.Internal: {
+		code:    exit.General,
+		summary: "the server failed to process the request",
+	},
+}
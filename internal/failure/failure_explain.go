@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package failure
+
+import (
+	"slices"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+// Explanation describes an exit code, with the most likely causes and the things that the user can do about them.
+type Explanation struct {
+	Code        int      `json:"code"`
+	Summary     string   `json:"summary"`
+	Causes      []string `json:"causes"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// Explain returns the explanation for the given exit code, or nil if the CLI doesn't use that exit code.
+func Explain(code int) *Explanation {
+	for _, explanation := range explanations {
+		if explanation.Code == code {
+			return explanation
+		}
+	}
+	return nil
+}
+
+// Explanations returns the explanations of all the exit codes used by the CLI, sorted by code.
+func Explanations() []*Explanation {
+	return slices.Clone(explanations)
+}
+
+var explanations = []*Explanation{
+	{
+		Code:    0,
+		Summary: "The command succeeded.",
+	},
+	{
+		Code:    exit.General.Code(),
+		Summary: "The command failed for a reason that doesn't belong to any of the other classes.",
+		Causes: []string{
+			"The input file is wrong.",
+			"There is no configuration because the 'login' command hasn't been run.",
+			"The server failed to process the request or doesn't support the operation.",
+			"The command reports problems with its exit code, like 'diff' when there are differences.",
+		},
+		Suggestions: []string{
+			"Read the error message written before the command finished.",
+			"Check the usage of the command with the '--help' option.",
+			"Run the command again with '--log-level debug' and check the log file.",
+		},
+	},
+	{
+		Code:    exit.Usage.Code(),
+		Summary: "The command line is wrong, so no request was sent to the server.",
+		Causes: []string{
+			"A required argument is missing, or there are too many arguments.",
+			"A flag is unknown or has a wrong value.",
+			"Two options that can't be used together were used.",
+			"The type of object isn't supported by the command.",
+		},
+		Suggestions: []string{
+			"Check the usage of the command with the '--help' option.",
+		},
+	},
+	{
+		Code:    exit.NotFound.Code(),
+		Summary: "The object doesn't exist.",
+		Causes: []string{
+			"The identifier or the name of the object is wrong.",
+			"The object has been deleted.",
+			"The object belongs to a different tenant.",
+		},
+		Suggestions: []string{
+			"List the objects with the 'get' command to find the right identifier or name.",
+		},
+	},
+	{
+		Code:    exit.Auth.Code(),
+		Summary: "The credentials were rejected, or they don't grant permission to perform the operation.",
+		Causes: []string{
+			"The token has expired.",
+			"The token was issued for a different server.",
+			"Your user doesn't have permission to access the object or to perform the operation.",
+		},
+		Suggestions: []string{
+			"Run the 'login' command to obtain new credentials.",
+			"Ask the administrator of the server to grant you the required permissions.",
+		},
+	},
+	{
+		Code:    exit.Conflict.Code(),
+		Summary: "The object was modified by someone else at the same time, or it already exists.",
+		Causes: []string{
+			"Someone else updated the object between the time it was read and the time it was saved.",
+			"An object with the same name or identifier already exists.",
+		},
+		Suggestions: []string{
+			"Run the command again, so that it uses the current version of the object.",
+			"Use a different name, or check the existing object with the 'get' command.",
+		},
+	},
+	{
+		Code:    exit.Timeout.Code(),
+		Summary: "The server or the operation didn't finish in time.",
+		Causes: []string{
+			"The server is overloaded, or the network connection is slow.",
+			"The object didn't become ready before the '--timeout' of the '--wait' option.",
+		},
+		Suggestions: []string{
+			"Check the state of the object with the 'get' or 'status' commands.",
+			"Try again later, or with a longer '--timeout'.",
+		},
+	},
+	{
+		Code:    exit.Validation.Code(),
+		Summary: "The server rejected the request because it is invalid.",
+		Causes: []string{
+			"A field has a wrong value, for example a template that doesn't exist.",
+			"The object isn't in a state that allows the operation.",
+		},
+		Suggestions: []string{
+			"Check the list of invalid fields written with the error.",
+			"Check the fields of the object with the 'explain' command.",
+		},
+	},
+	{
+		Code:    exit.Unavailable.Code(),
+		Summary: "The server is unavailable.",
+		Causes: []string{
+			"The address of the server is wrong.",
+			"There is a problem with the network connection or with a proxy.",
+			"The server is being restarted or upgraded.",
+		},
+		Suggestions: []string{
+			"Check the health of the server with the 'status' command.",
+			"Check the address of the server with the 'config export' command.",
+			"Try again later.",
+		},
+	},
+	{
+		Code:    exit.Integrity.Code(),
+		Summary: "The binary of the CLI doesn't match the published checksums or attestations.",
+		Causes: []string{
+			"The binary was built from source, for example by a distribution package.",
+			"The binary was modified or corrupted after it was downloaded.",
+			"The checksums file belongs to a different release.",
+		},
+		Suggestions: []string{
+			"Download the binary again from the releases page.",
+			"Pass the checksums file of the right release with the '--checksums' option.",
+		},
+	},
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package failure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RecordFileName is the name of the file, inside the state directory, where the last error is recorded.
+const RecordFileName = "last-error.json"
+
+// SkipRecordAnnotation is the annotation that commands can add to indicate that their errors shouldn't be recorded,
+// for example because they are used to explain the recorded error.
+const SkipRecordAnnotation = "failure.skip-record"
+
+// Record contains the details of an error that are saved so that they can be explained later.
+type Record struct {
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Message  string    `json:"message"`
+	Status   string    `json:"status,omitempty"`
+	ExitCode int       `json:"exit_code"`
+}
+
+// Save writes to the given file the record of the error returned by the given command.
+func Save(file string, command string, err error) error {
+	report := summarize(err)
+	record := &Record{
+		Time:     time.Now().UTC(),
+		Command:  command,
+		Message:  report.Message,
+		Status:   report.Status,
+		ExitCode: report.ExitCode,
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode error record: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for error record: %w", err)
+	}
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write error record to '%s': %w", file, err)
+	}
+	return nil
+}
+
+// Load reads the error record from the given file. It returns nil if the file doesn't exist.
+func Load(file string) (result *Record, err error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read error record from '%s': %w", file, err)
+		return
+	}
+	record := &Record{}
+	err = json.Unmarshal(data, record)
+	if err != nil {
+		err = fmt.Errorf("failed to decode error record from '%s': %w", file, err)
+		return
+	}
+	result = record
+	return
+}
+
+// Hint returns the sentence telling the user what can be done about the error, if there is one for the status.
+func (r *Record) Hint() string {
+	for code, class := range errorClasses {
+		if code.String() == r.Status {
+			return class.hint
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package failure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+var _ = Describe("Record", func() {
+	var file string
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "state", RecordFileName)
+	})
+
+	It("Returns nil if no error has been recorded", func() {
+		record, err := Load(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(record).To(BeNil())
+	})
+
+	It("Saves and loads errors returned by the server", func() {
+		err := Save(
+			file,
+			"fulfillment-cli get clusters",
+			fmt.Errorf("failed to list clusters: %w", grpcstatus.Error(codes.Unauthenticated, "")),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		record, err := Load(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(record).ToNot(BeNil())
+		Expect(record.Command).To(Equal("fulfillment-cli get clusters"))
+		Expect(record.Message).To(Equal("failed to list clusters: the credentials were rejected"))
+		Expect(record.Status).To(Equal("Unauthenticated"))
+		Expect(record.ExitCode).To(Equal(exit.Auth.Code()))
+		Expect(record.Time).ToNot(BeZero())
+		Expect(record.Hint()).To(Equal("Run the 'login' command to obtain new credentials."))
+	})
+
+	It("Saves only the exit code for errors that have already been reported", func() {
+		err := Save(file, "fulfillment-cli diff", exit.Error(1))
+		Expect(err).ToNot(HaveOccurred())
+		record, err := Load(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(record.Message).To(Equal("command failed"))
+		Expect(record.Status).To(BeEmpty())
+		Expect(record.ExitCode).To(Equal(1))
+		Expect(record.Hint()).To(BeEmpty())
+	})
+
+	It("Fails if the file is corrupted", func() {
+		Expect(os.MkdirAll(filepath.Dir(file), 0700)).To(Succeed())
+		Expect(os.WriteFile(file, []byte("junk"), 0600)).To(Succeed())
+		_, err := Load(file)
+		Expect(err).To(MatchError(ContainSubstring("failed to decode error record")))
+	})
+})
+
+var _ = Describe("Explain", func() {
+	It("Explains all the exit codes", func() {
+		for _, code := range []exit.Error{
+			exit.General,
+			exit.Usage,
+			exit.Auth,
+			exit.NotFound,
+			exit.Validation,
+			exit.Unavailable,
+			exit.Conflict,
+		} {
+			explanation := Explain(code.Code())
+			Expect(explanation).ToNot(BeNil(), "exit code %d", code)
+			Expect(explanation.Summary).ToNot(BeEmpty())
+			Expect(explanation.Suggestions).ToNot(BeEmpty())
+		}
+	})
+
+	It("Uses the exit codes of the error classes", func() {
+		for code, class := range errorClasses {
+			Expect(Explain(class.code.Code())).ToNot(BeNil(), "status %s", code)
+		}
+	})
+
+	It("Returns nil for exit codes that aren't used", func() {
+		Expect(Explain(42)).To(BeNil())
+	})
+})
@@ -0,0 +1,256 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package failure
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+var _ = Describe("Render", func() {
+	// withDetails creates a status error with the given code, message and details.
+	withDetails := func(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+		status, err := grpcstatus.New(code, message).WithDetails(details...)
+		Expect(err).ToNot(HaveOccurred())
+		return status.Err()
+	}
+
+	It("Writes errors that aren't returned by the server as they are", func() {
+		buffer := &bytes.Buffer{}
+		code := Render(buffer, errors.New("something failed"))
+		Expect(code).To(Equal(exit.General))
+		Expect(buffer.String()).To(Equal("Error: something failed\n"))
+	})
+
+	It("Uses the exit code attached to errors that aren't returned by the server", func() {
+		buffer := &bytes.Buffer{}
+		code := Render(buffer, fmt.Errorf("failed to parse: %w", exit.Usagef("option '--junk' is wrong")))
+		Expect(code).To(Equal(exit.Usage))
+		Expect(buffer.String()).To(Equal("Error: failed to parse: option '--junk' is wrong\n"))
+	})
+
+	It("Uses the exit code attached to errors returned by the server", func() {
+		buffer := &bytes.Buffer{}
+		err := exit.WithCode(exit.NotFound, grpcstatus.Error(codes.Unknown, "gone"))
+		code := Render(buffer, err)
+		Expect(code).To(Equal(exit.NotFound))
+		Expect(buffer.String()).To(Equal("Error: gone\n"))
+	})
+
+	It("Considers exceeded deadlines timeouts", func() {
+		buffer := &bytes.Buffer{}
+		code := Render(buffer, fmt.Errorf("failed to probe: %w", context.DeadlineExceeded))
+		Expect(code).To(Equal(exit.Timeout))
+	})
+
+	It("Doesn't write errors that already contain an exit code", func() {
+		buffer := &bytes.Buffer{}
+		code := Render(buffer, fmt.Errorf("failed: %w", exit.Error(7)))
+		Expect(code).To(Equal(exit.Error(7)))
+		Expect(buffer.String()).To(BeEmpty())
+	})
+
+	It("Replaces the gRPC text with the description keeping the context", func() {
+		buffer := &bytes.Buffer{}
+		err := fmt.Errorf(
+			"failed to get cluster: %w",
+			grpcstatus.Error(codes.NotFound, "cluster 'my' doesn't exist"),
+		)
+		code := Render(buffer, err)
+		Expect(code).To(Equal(exit.NotFound))
+		Expect(buffer.String()).To(Equal("Error: failed to get cluster: cluster 'my' doesn't exist\n"))
+	})
+
+	It("Uses the generic description when the server doesn't send one", func() {
+		buffer := &bytes.Buffer{}
+		Render(buffer, fmt.Errorf("failed to delete: %w", grpcstatus.Error(codes.AlreadyExists, "")))
+		Expect(buffer.String()).To(Equal("Error: failed to delete: the object already exists\n"))
+	})
+
+	It("Writes the field violations", func() {
+		buffer := &bytes.Buffer{}
+		err := withDetails(
+			codes.InvalidArgument,
+			"the cluster is invalid",
+			&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{
+						Field:       "spec.template",
+						Description: "template 'junk' doesn't exist",
+					},
+					{
+						Field:       "spec.node_sets.workers.size",
+						Description: "should be positive",
+					},
+				},
+			},
+		)
+		code := Render(buffer, err)
+		Expect(code).To(Equal(exit.Validation))
+		Expect(buffer.String()).To(Equal(
+			"Error: the cluster is invalid\n" +
+				"Invalid fields:\n" +
+				"  - spec.template: template 'junk' doesn't exist\n" +
+				"  - spec.node_sets.workers.size: should be positive\n",
+		))
+	})
+
+	It("Writes the retry delay", func() {
+		buffer := &bytes.Buffer{}
+		err := withDetails(
+			codes.Unavailable,
+			"the server is restarting",
+			&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(5 * time.Second),
+			},
+		)
+		code := Render(buffer, err)
+		Expect(code).To(Equal(exit.Unavailable))
+		Expect(buffer.String()).To(Equal(
+			"Error: the server is restarting\n" +
+				"Retry after: 5s\n" +
+				"Check the address of the server and your network connection, or try again later.\n",
+		))
+	})
+
+	It("Prefers the localized message", func() {
+		buffer := &bytes.Buffer{}
+		err := withDetails(
+			codes.FailedPrecondition,
+			"state != READY",
+			&errdetails.LocalizedMessage{
+				Locale:  "en-US",
+				Message: "The cluster isn't ready yet",
+			},
+		)
+		Render(buffer, fmt.Errorf("failed to get kubeconfig: %w", err))
+		Expect(buffer.String()).To(Equal("Error: failed to get kubeconfig: The cluster isn't ready yet\n"))
+	})
+
+	DescribeTable(
+		"Exit code contract",
+		func(code exit.Error, expected int) {
+			Expect(code.Code()).To(Equal(expected))
+		},
+		Entry("General", exit.General, 1),
+		Entry("Usage", exit.Usage, 2),
+		Entry("Not found", exit.NotFound, 3),
+		Entry("Auth", exit.Auth, 4),
+		Entry("Conflict", exit.Conflict, 5),
+		Entry("Timeout", exit.Timeout, 6),
+		Entry("Validation", exit.Validation, 7),
+		Entry("Unavailable", exit.Unavailable, 8),
+		Entry("Integrity", exit.Integrity, 9),
+		Entry("Interrupted", exit.Interrupted, 130),
+	)
+
+	DescribeTable(
+		"Exit codes",
+		func(code codes.Code, expected exit.Error) {
+			buffer := &bytes.Buffer{}
+			actual := Render(buffer, grpcstatus.Error(code, "failed"))
+			Expect(actual).To(Equal(expected))
+		},
+		Entry("Unauthenticated", codes.Unauthenticated, exit.Auth),
+		Entry("Permission denied", codes.PermissionDenied, exit.Auth),
+		Entry("Not found", codes.NotFound, exit.NotFound),
+		Entry("Invalid argument", codes.InvalidArgument, exit.Validation),
+		Entry("Failed precondition", codes.FailedPrecondition, exit.Validation),
+		Entry("Unavailable", codes.Unavailable, exit.Unavailable),
+		Entry("Deadline exceeded", codes.DeadlineExceeded, exit.Timeout),
+		Entry("Already exists", codes.AlreadyExists, exit.Conflict),
+		Entry("Aborted", codes.Aborted, exit.Conflict),
+		Entry("Internal", codes.Internal, exit.General),
+		Entry("Unknown", codes.Unknown, exit.General),
+	)
+
+	Describe("JSON", func() {
+		It("Writes errors that aren't returned by the server", func() {
+			buffer := &bytes.Buffer{}
+			code := RenderJson(buffer, errors.New("something failed"))
+			Expect(code).To(Equal(exit.General))
+			Expect(buffer.String()).To(MatchJSON(`{
+				"error": {
+					"message": "something failed",
+					"exit_code": 1
+				}
+			}`))
+		})
+
+		It("Writes a generic message for errors that already contain an exit code", func() {
+			buffer := &bytes.Buffer{}
+			code := RenderJson(buffer, exit.Error(7))
+			Expect(code).To(Equal(exit.Error(7)))
+			Expect(buffer.String()).To(MatchJSON(`{
+				"error": {
+					"message": "command failed",
+					"exit_code": 7
+				}
+			}`))
+		})
+
+		It("Writes the status and the details", func() {
+			buffer := &bytes.Buffer{}
+			err := withDetails(
+				codes.InvalidArgument,
+				"the cluster is invalid",
+				&errdetails.BadRequest{
+					FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+						Field:       "spec.template",
+						Description: "template 'junk' doesn't exist",
+					}},
+				},
+				&errdetails.RetryInfo{
+					RetryDelay: durationpb.New(time.Minute),
+				},
+			)
+			code := RenderJson(buffer, fmt.Errorf("failed to create cluster: %w", err))
+			Expect(code).To(Equal(exit.Validation))
+			Expect(buffer.String()).To(MatchJSON(`{
+				"error": {
+					"message": "failed to create cluster: the cluster is invalid",
+					"status": "InvalidArgument",
+					"exit_code": 7,
+					"field_violations": [{
+						"field": "spec.template",
+						"description": "template 'junk' doesn't exist"
+					}],
+					"retry_after": "1m0s"
+				}
+			}`))
+		})
+
+		It("Writes a single line", func() {
+			buffer := &bytes.Buffer{}
+			RenderJson(buffer, grpcstatus.Error(codes.Unauthenticated, "token expired"))
+			Expect(buffer.String()).To(HaveSuffix("\n"))
+			Expect(strings.Count(buffer.String(), "\n")).To(Equal(1))
+		})
+	})
+})
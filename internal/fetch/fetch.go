@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package fetch contains functions that read files that can be given either as an URL or as the path of a local
+// file, like the pricing file or the checksums of a release.
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Timeout is the maximum time that a download can take.
+const Timeout = 30 * time.Second
+
+// Open opens the given source for reading. If it starts with 'http://' or 'https://' it is downloaded, otherwise it
+// is the path of a local file. The description is used in log and error messages, for example 'pricing file'. The
+// caller is responsible for closing the returned reader.
+func Open(ctx context.Context, logger *slog.Logger, source string, description string) (result io.ReadCloser,
+	err error) {
+	if !isUrl(source) {
+		result, err = os.Open(source)
+		if err != nil {
+			err = fmt.Errorf("failed to open %s '%s': %w", description, source, err)
+		}
+		return
+	}
+	logger.DebugContext(
+		ctx,
+		"Downloading file",
+		slog.String("description", description),
+		slog.String("url", source),
+	)
+	client := &http.Client{
+		Timeout: Timeout,
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		err = fmt.Errorf("failed to create request for '%s': %w", source, err)
+		return
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		err = fmt.Errorf("failed to download %s from '%s': %w", description, source, err)
+		return
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		err = fmt.Errorf("failed to download %s from '%s': status %d", description, source, response.StatusCode)
+		return
+	}
+	result = response.Body
+	return
+}
+
+// isUrl checks if the given source is an URL that should be downloaded instead of a local file.
+func isUrl(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package fetch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Open", func() {
+	It("Opens a local file", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "my.txt")
+		err := os.WriteFile(file, []byte("my data"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		reader, err := Open(context.Background(), logger, file, "my file")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(reader.Close)
+		data, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("my data"))
+	})
+
+	It("Downloads a file from a URL", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("my data"))
+		}))
+		DeferCleanup(server.Close)
+		reader, err := Open(context.Background(), logger, server.URL, "my file")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(reader.Close)
+		data, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("my data"))
+	})
+
+	It("Fails if the server returns an error", func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		DeferCleanup(server.Close)
+		_, err := Open(context.Background(), logger, server.URL, "my file")
+		Expect(err).To(MatchError(ContainSubstring("failed to download my file from '" + server.URL + "'")))
+		Expect(err).To(MatchError(ContainSubstring("status 404")))
+	})
+
+	It("Fails if the file doesn't exist", func() {
+		_, err := Open(context.Background(), logger, "/does/not/exist.txt", "my file")
+		Expect(err).To(MatchError(ContainSubstring("failed to open my file '/does/not/exist.txt'")))
+	})
+})
@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package help
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Names of the flag that selects the help format and of the supported structured format. The flag itself is the global
+// output flag added by the output package.
+const (
+	outputFlagName   = "output"
+	outputFormatJson = "json"
+)
+
+// Command contains the description of a command, in a format that is intended for consumption by other tools, like
+// documentation generators or IDE integrations.
+type Command struct {
+	Name           string     `json:"name"`
+	Path           string     `json:"path"`
+	Use            string     `json:"use"`
+	Aliases        []string   `json:"aliases,omitempty"`
+	Short          string     `json:"short,omitempty"`
+	Long           string     `json:"long,omitempty"`
+	Example        string     `json:"example,omitempty"`
+	Deprecated     string     `json:"deprecated,omitempty"`
+	ValidArgs      []string   `json:"valid_args,omitempty"`
+	Flags          []*Flag    `json:"flags,omitempty"`
+	InheritedFlags []*Flag    `json:"inherited_flags,omitempty"`
+	Commands       []*Command `json:"commands,omitempty"`
+}
+
+// Flag contains the description of a command line flag.
+type Flag struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type"`
+	Default   string `json:"default,omitempty"`
+	Usage     string `json:"usage,omitempty"`
+}
+
+// Func returns a help function that writes the structured description of the command when the user explicitly asks
+// for the JSON output format, and that calls the given default function otherwise.
+func Func(defaultFunc func(*cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		flag := cmd.Flags().Lookup(outputFlagName)
+		if flag == nil || !flag.Changed || flag.Value.String() != outputFormatJson {
+			defaultFunc(cmd, args)
+			return
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		err := encoder.Encode(Explain(cmd))
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to write help: %v\n", err)
+		}
+	}
+}
+
+// Explain returns the description of the given command, including all its available sub-commands. Hidden and
+// deprecated flags are excluded, as they aren't intended for users.
+func Explain(cmd *cobra.Command) *Command {
+	result := &Command{
+		Name:           cmd.Name(),
+		Path:           cmd.CommandPath(),
+		Use:            cmd.UseLine(),
+		Aliases:        cmd.Aliases,
+		Short:          cmd.Short,
+		Long:           cmd.Long,
+		Example:        cmd.Example,
+		Deprecated:     cmd.Deprecated,
+		ValidArgs:      cmd.ValidArgs,
+		Flags:          describeFlags(cmd.LocalFlags()),
+		InheritedFlags: describeFlags(cmd.InheritedFlags()),
+	}
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() {
+			continue
+		}
+		result.Commands = append(result.Commands, Explain(child))
+	}
+	return result
+}
+
+func describeFlags(flags *pflag.FlagSet) []*Flag {
+	var results []*Flag
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Deprecated != "" {
+			return
+		}
+		results = append(results, &Flag{
+			Name:      flag.Name,
+			Shorthand: flag.Shorthand,
+			Type:      flag.Value.Type(),
+			Default:   flag.DefValue,
+			Usage:     flag.Usage,
+		})
+	})
+	return results
+}
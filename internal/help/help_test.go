@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package help
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/osac-project/fulfillment-cli/internal/output"
+)
+
+var _ = Describe("Help", func() {
+	var (
+		root   *cobra.Command
+		buffer *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		// Create a small command tree similar to the real one:
+		root = &cobra.Command{
+			Use:   "root",
+			Short: "Root command",
+		}
+		root.PersistentFlags().Bool("verbose", false, "Verbose output.")
+		root.PersistentFlags().String("secret", "", "Secret flag.")
+		root.PersistentFlags().MarkHidden("secret")
+		output.AddFlags(root.PersistentFlags())
+		root.SetHelpFunc(Func(root.HelpFunc()))
+		root.InitDefaultHelpFlag()
+		child := &cobra.Command{
+			Use:     "child NAME",
+			Short:   "Child command",
+			Example: "root child my-name",
+			Aliases: []string{"kid"},
+			Run:     func(cmd *cobra.Command, args []string) {},
+		}
+		child.Flags().IntP("count", "c", 1, "Number of things.")
+		child.Flags().String("old", "", "Old flag.")
+		child.Flags().MarkDeprecated("old", "don't use it")
+		root.AddCommand(child)
+		hidden := &cobra.Command{
+			Use:    "hidden",
+			Hidden: true,
+			Run:    func(cmd *cobra.Command, args []string) {},
+		}
+		root.AddCommand(hidden)
+
+		// Capture the output:
+		buffer = &bytes.Buffer{}
+		root.SetOut(buffer)
+		root.SetErr(buffer)
+	})
+
+	It("Writes text help by default", func() {
+		root.SetArgs([]string{"child", "--help"})
+		err := root.Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(ContainSubstring("Usage:"))
+	})
+
+	It("Writes JSON help when requested", func() {
+		root.SetArgs([]string{"child", "--help", "-o", "json"})
+		err := root.Execute()
+		Expect(err).ToNot(HaveOccurred())
+		var result Command
+		err = json.Unmarshal(buffer.Bytes(), &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Name).To(Equal("child"))
+		Expect(result.Path).To(Equal("root child"))
+		Expect(result.Short).To(Equal("Child command"))
+		Expect(result.Example).To(Equal("root child my-name"))
+		Expect(result.Aliases).To(ConsistOf("kid"))
+		Expect(result.Flags).To(ContainElement(&Flag{
+			Name:      "count",
+			Shorthand: "c",
+			Type:      "int",
+			Default:   "1",
+			Usage:     "Number of things.",
+		}))
+		Expect(result.InheritedFlags).To(ContainElement(&Flag{
+			Name:    "verbose",
+			Type:    "bool",
+			Default: "false",
+			Usage:   "Verbose output.",
+		}))
+	})
+
+	It("Excludes hidden and deprecated flags", func() {
+		result := Explain(root.Commands()[0])
+		for _, flag := range result.Flags {
+			Expect(flag.Name).ToNot(Equal("old"))
+		}
+		for _, flag := range result.InheritedFlags {
+			Expect(flag.Name).ToNot(Equal("secret"))
+		}
+	})
+
+	It("Includes available sub-commands only", func() {
+		root.SetArgs([]string{"--help", "-o", "json"})
+		err := root.Execute()
+		Expect(err).ToNot(HaveOccurred())
+		var result Command
+		err = json.Unmarshal(buffer.Bytes(), &result)
+		Expect(err).ToNot(HaveOccurred())
+		var names []string
+		for _, command := range result.Commands {
+			names = append(names, command.Name)
+		}
+		Expect(names).To(ContainElement("child"))
+		Expect(names).ToNot(ContainElement("hidden"))
+	})
+})
@@ -0,0 +1,460 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package hooks contains the gRPC interceptor that runs the scripts that the user configured to be executed before and
+// after objects are created, updated or deleted, so that organizations can enforce their own policies in the CLI.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/shell"
+)
+
+// Names of the operations that can have hooks:
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Phases of the hooks:
+const (
+	PhasePre  = "pre"
+	PhasePost = "post"
+)
+
+// Names of the environment variables passed to the hook scripts:
+const (
+	hookEnv       = "FULFILLMENT_HOOK"
+	operationEnv  = "FULFILLMENT_OPERATION"
+	objectTypeEnv = "FULFILLMENT_OBJECT_TYPE"
+	objectIdEnv   = "FULFILLMENT_OBJECT_ID"
+)
+
+// DefaultTimeout is the time that a hook script can run when no other timeout is configured.
+const DefaultTimeout = 30 * time.Second
+
+// waitDelay is the time that we wait for the output of a script to be closed after it has been killed, as it may have
+// started sub-processes that keep it open.
+const waitDelay = time.Second
+
+// Names returns the names of all the hooks that can be configured, like 'pre_delete' or 'post_create'.
+func Names() []string {
+	var result []string
+	for _, phase := range []string{PhasePre, PhasePost} {
+		for _, operation := range []string{OperationCreate, OperationUpdate, OperationDelete} {
+			result = append(result, hookName(phase, operation))
+		}
+	}
+	return result
+}
+
+// InterceptorBuilder contains the data and logic needed to build an interceptor that runs the hook scripts. Don't
+// create instances of this type directly, use the NewInterceptor function instead.
+type InterceptorBuilder struct {
+	logger  *slog.Logger
+	hooks   map[string]string
+	shell   string
+	output  io.Writer
+	timeout time.Duration
+}
+
+// Interceptor is a gRPC client interceptor that runs the hook scripts before and after the calls to the 'Create',
+// 'Update' and 'Delete' methods. The scripts receive in the standard input a JSON document containing the operation and
+// the object. If a 'pre' script fails the call isn't sent to the server. Failures of 'post' scripts are only reported,
+// as the change has already been applied. If a 'pre' script is configured but the object can't be determined the call
+// isn't sent either, as that would bypass the policy that the script enforces.
+type Interceptor struct {
+	logger  *slog.Logger
+	hooks   map[string]string
+	shell   string
+	output  io.Writer
+	timeout time.Duration
+}
+
+// hookInput is the JSON document that the hook scripts receive in the standard input.
+type hookInput struct {
+	Hook      string          `json:"hook"`
+	Operation string          `json:"operation"`
+	Type      string          `json:"type"`
+	Object    json.RawMessage `json:"object,omitempty"`
+}
+
+// NewInterceptor creates a builder that can then be used to configure and create an interceptor.
+func NewInterceptor() *InterceptorBuilder {
+	return &InterceptorBuilder{
+		timeout: DefaultTimeout,
+	}
+}
+
+// SetLogger sets the logger that will be used by the interceptor. This is mandatory.
+func (b *InterceptorBuilder) SetLogger(value *slog.Logger) *InterceptorBuilder {
+	b.logger = value
+	return b
+}
+
+// SetHooks sets the hook scripts, indexed by hook name, for example 'pre_delete'. Scripts are optional, and hooks
+// without a script are ignored.
+func (b *InterceptorBuilder) SetHooks(value map[string]string) *InterceptorBuilder {
+	b.hooks = value
+	return b
+}
+
+// SetShell sets the shell used to run the scripts. It can be a POSIX shell like 'bash', 'cmd.exe' or PowerShell. The
+// default is the shell of the operating system, see the shell.System function for details.
+func (b *InterceptorBuilder) SetShell(value string) *InterceptorBuilder {
+	b.shell = value
+	return b
+}
+
+// SetOutput sets the writer where the standard output and standard error of the scripts will be written. The
+// default is the standard error of the process, so that the messages of the scripts don't mix with the output of the
+// commands.
+func (b *InterceptorBuilder) SetOutput(value io.Writer) *InterceptorBuilder {
+	b.output = value
+	return b
+}
+
+// SetTimeout sets the maximum time that a hook script can run. The default is 30 seconds. Zero means no limit.
+func (b *InterceptorBuilder) SetTimeout(value time.Duration) *InterceptorBuilder {
+	b.timeout = value
+	return b
+}
+
+// Build uses the data stored in the builder to create and configure a new interceptor.
+func (b *InterceptorBuilder) Build() (result *Interceptor, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.timeout < 0 {
+		err = fmt.Errorf("hook timeout should be zero or positive, but it is %s", b.timeout)
+		return
+	}
+	names := Names()
+	for _, name := range slices.Sorted(maps.Keys(b.hooks)) {
+		if !slices.Contains(names, name) {
+			err = fmt.Errorf(
+				"unknown hook '%s', valid hooks are '%s'",
+				name, strings.Join(names, "', '"),
+			)
+			return
+		}
+	}
+
+	// Set defaults:
+	selected := b.shell
+	if selected == "" {
+		selected = shell.System()
+	}
+	output := b.output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	// Create and populate the object:
+	result = &Interceptor{
+		logger:  b.logger,
+		hooks:   maps.Clone(b.hooks),
+		shell:   selected,
+		output:  output,
+		timeout: b.timeout,
+	}
+	return
+}
+
+// UnaryClient is the unary client interceptor function that runs the hooks.
+func (i *Interceptor) UnaryClient(ctx context.Context, method string, request, response any,
+	conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	// Do nothing if this isn't an operation that has hooks configured:
+	operation := operationOf(method)
+	pre := i.hooks[hookName(PhasePre, operation)]
+	post := i.hooks[hookName(PhasePost, operation)]
+	if pre == "" && post == "" {
+		return invoker(ctx, method, request, response, conn, opts...)
+	}
+
+	// Find the object that will be changed. For creates and updates it is in the request, but for deletes the
+	// request contains only the identifier, so we need to get it from the server. If that isn't possible and there is
+	// a pre hook we refuse to send the request, otherwise the policy that the hook enforces would be silently skipped.
+	var object proto.Message
+	var err error
+	switch operation {
+	case OperationCreate, OperationUpdate:
+		object = objectField(request)
+		if object == nil {
+			err = errors.New("the request doesn't contain the object")
+		}
+	case OperationDelete:
+		object, err = i.getObject(ctx, method, request, conn, invoker, opts...)
+	}
+	if err != nil {
+		if pre != "" {
+			return fmt.Errorf(
+				"the %s request wasn't sent because the '%s' hook can't run without the object: %w",
+				operation, hookName(PhasePre, operation), err,
+			)
+		}
+		i.logger.DebugContext(
+			ctx,
+			"Skipping post hook because the object can't be determined",
+			slog.String("method", method),
+			slog.Any("error", err),
+		)
+		return invoker(ctx, method, request, response, conn, opts...)
+	}
+
+	// Run the pre hook, and don't send the request if it fails:
+	if pre != "" {
+		err := i.runHook(ctx, PhasePre, operation, pre, object)
+		if err != nil {
+			return exit.WithCode(exit.Validation, fmt.Errorf(
+				"the '%s' hook rejected the %s of %s: %w",
+				hookName(PhasePre, operation), operation, describeObject(object), err,
+			))
+		}
+	}
+
+	// Send the request:
+	err = invoker(ctx, method, request, response, conn, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Run the post hook. For creates and updates we pass the object returned by the server, as it contains the
+	// identifier and other values that the server calculates.
+	if post != "" {
+		if operation != OperationDelete {
+			returned := objectField(response)
+			if returned != nil {
+				object = returned
+			}
+		}
+		err = i.runHook(ctx, PhasePost, operation, post, object)
+		if err != nil {
+			i.logger.WarnContext(
+				ctx,
+				"Post hook failed",
+				slog.String("hook", hookName(PhasePost, operation)),
+				slog.Any("error", err),
+			)
+			fmt.Fprintf(
+				i.output,
+				"Warning: the '%s' hook failed after the %s of %s: %v\n",
+				hookName(PhasePost, operation), operation, describeObject(object), err,
+			)
+		}
+	}
+	return nil
+}
+
+// getObject gets from the server the object that the given delete request will delete. It returns an error if that
+// isn't possible, for example if the service doesn't have a 'Get' method or if the object doesn't exist.
+func (i *Interceptor) getObject(ctx context.Context, method string, request any, conn *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (result proto.Message, err error) {
+	// Find the descriptor of the 'Get' method of the same service:
+	requestMessage, ok := request.(proto.Message)
+	if !ok {
+		err = fmt.Errorf("request of method '%s' isn't a protocol buffers message", method)
+		return
+	}
+	id := stringField(requestMessage, "id")
+	if id == "" {
+		err = errors.New("the request doesn't contain the identifier of the object")
+		return
+	}
+	serviceName := strings.TrimPrefix(method[:strings.LastIndex(method, "/")], "/")
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		err = fmt.Errorf("failed to find service '%s': %w", serviceName, err)
+		return
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		err = fmt.Errorf("'%s' isn't a service", serviceName)
+		return
+	}
+	getDescriptor := serviceDescriptor.Methods().ByName("Get")
+	if getDescriptor == nil {
+		err = fmt.Errorf("service '%s' doesn't have a 'Get' method", serviceName)
+		return
+	}
+	getRequestType, err := protoregistry.GlobalTypes.FindMessageByName(getDescriptor.Input().FullName())
+	if err != nil {
+		err = fmt.Errorf("failed to find type '%s': %w", getDescriptor.Input().FullName(), err)
+		return
+	}
+	getResponseType, err := protoregistry.GlobalTypes.FindMessageByName(getDescriptor.Output().FullName())
+	if err != nil {
+		err = fmt.Errorf("failed to find type '%s': %w", getDescriptor.Output().FullName(), err)
+		return
+	}
+
+	// Send the request:
+	getRequest := getRequestType.New()
+	idField := getRequest.Descriptor().Fields().ByName("id")
+	if idField == nil {
+		err = fmt.Errorf("type '%s' doesn't have an 'id' field", getDescriptor.Input().FullName())
+		return
+	}
+	getRequest.Set(idField, protoreflect.ValueOfString(id))
+	getResponse := getResponseType.New().Interface()
+	getMethod := fmt.Sprintf("/%s/%s", serviceName, getDescriptor.Name())
+	err = invoker(ctx, getMethod, getRequest.Interface(), getResponse, conn, opts...)
+	if err != nil {
+		i.logger.DebugContext(
+			ctx,
+			"Failed to get object for hooks",
+			slog.String("method", getMethod),
+			slog.String("id", id),
+			slog.Any("error", err),
+		)
+		err = fmt.Errorf("failed to get object '%s': %w", id, err)
+		return
+	}
+	result = objectField(getResponse)
+	if result == nil {
+		err = fmt.Errorf("the server didn't return object '%s'", id)
+	}
+	return
+}
+
+// runHook runs the given hook script, passing the object in the standard input.
+func (i *Interceptor) runHook(ctx context.Context, phase, operation, script string, object proto.Message) error {
+	// Prepare the input:
+	name := hookName(phase, operation)
+	objectData, err := protojson.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to marshal object: %w", err)
+	}
+	objectType := string(object.ProtoReflect().Descriptor().FullName())
+	inputData, err := json.Marshal(&hookInput{
+		Hook:      name,
+		Operation: operation,
+		Type:      objectType,
+		Object:    objectData,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook input: %w", err)
+	}
+
+	// Prepare the command:
+	if i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+	cmd := shell.Command(ctx, i.shell, script)
+	cmd.Env = append(
+		cmd.Env,
+		hookEnv+"="+name,
+		operationEnv+"="+operation,
+		objectTypeEnv+"="+objectType,
+		objectIdEnv+"="+stringField(object, "id"),
+	)
+	cmd.Stdin = bytes.NewReader(inputData)
+	cmd.Stdout = i.output
+	cmd.Stderr = i.output
+	cmd.WaitDelay = waitDelay
+
+	// Run it:
+	i.logger.DebugContext(
+		ctx,
+		"Running hook",
+		slog.String("hook", name),
+		slog.String("script", script),
+		slog.String("shell", i.shell),
+		slog.String("type", objectType),
+	)
+	err = cmd.Run()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("script didn't finish in %s, check that it doesn't wait for input", i.timeout)
+	}
+	return err
+}
+
+// hookName returns the name of the hook for the given phase and operation, for example 'pre_delete'.
+func hookName(phase, operation string) string {
+	return phase + "_" + operation
+}
+
+// operationOf returns the operation corresponding to the given full method name, or an empty string if it isn't one of
+// the operations that support hooks.
+func operationOf(method string) string {
+	switch method[strings.LastIndex(method, "/")+1:] {
+	case "Create":
+		return OperationCreate
+	case "Update":
+		return OperationUpdate
+	case "Delete":
+		return OperationDelete
+	default:
+		return ""
+	}
+}
+
+// objectField returns the value of the 'object' field of the given request or response message, or nil if there is
+// no such field.
+func objectField(message any) proto.Message {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return nil
+	}
+	reflectMessage := protoMessage.ProtoReflect()
+	field := reflectMessage.Descriptor().Fields().ByName("object")
+	if field == nil || field.Message() == nil || !reflectMessage.Has(field) {
+		return nil
+	}
+	return reflectMessage.Get(field).Message().Interface()
+}
+
+// stringField returns the value of the given string field of the message, or an empty string if there is no such
+// field.
+func stringField(message proto.Message, name protoreflect.Name) string {
+	reflectMessage := message.ProtoReflect()
+	field := reflectMessage.Descriptor().Fields().ByName(name)
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return reflectMessage.Get(field).String()
+}
+
+// describeObject returns a short description of the object for messages, like 'Cluster 123'.
+func describeObject(object proto.Message) string {
+	result := string(object.ProtoReflect().Descriptor().Name())
+	id := stringField(object, "id")
+	if id != "" {
+		result = fmt.Sprintf("%s '%s'", result, id)
+	}
+	return result
+}
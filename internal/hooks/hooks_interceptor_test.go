@@ -0,0 +1,391 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+var _ = Describe("Interceptor", func() {
+	var (
+		ctx    context.Context
+		tmp    string
+		output *bytes.Buffer
+		calls  []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tmp = GinkgoT().TempDir()
+		output = &bytes.Buffer{}
+		calls = nil
+	})
+
+	// invoker simulates the server, recording the methods called and returning the cluster for the 'Get' and
+	// 'Create' methods.
+	invoker := func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		opts ...grpc.CallOption) error {
+		calls = append(calls, method)
+		cluster := ffv1.Cluster_builder{
+			Id: "123",
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+			}.Build(),
+		}.Build()
+		switch typed := response.(type) {
+		case *ffv1.ClustersGetResponse:
+			typed.SetObject(cluster)
+		case *ffv1.ClustersCreateResponse:
+			typed.SetObject(cluster)
+		}
+		return nil
+	}
+
+	// readInput reads the JSON document that a hook script saved to the given file.
+	readInput := func(file string) map[string]any {
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		var result map[string]any
+		err = json.Unmarshal(data, &result)
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	It("Can't be created without a logger", func() {
+		_, err := NewInterceptor().Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Rejects unknown hooks", func() {
+		_, err := NewInterceptor().
+			SetLogger(logger).
+			SetHooks(map[string]string{
+				"pre_explode": "true",
+			}).
+			Build()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown hook 'pre_explode'"))
+		Expect(err.Error()).To(ContainSubstring("'pre_delete'"))
+	})
+
+	It("Passes the operation and the object to the pre create hook", func() {
+		file := filepath.Join(tmp, "input.json")
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"pre_create": "cat > " + file,
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersCreateRequest_builder{
+			Object: ffv1.Cluster_builder{
+				Metadata: sharedv1.Metadata_builder{
+					Name: "my-cluster",
+				}.Build(),
+			}.Build(),
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Create", request, &ffv1.ClustersCreateResponse{}, nil, invoker,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{"/fulfillment.v1.Clusters/Create"}))
+		input := readInput(file)
+		Expect(input).To(HaveKeyWithValue("hook", "pre_create"))
+		Expect(input).To(HaveKeyWithValue("operation", "create"))
+		Expect(input).To(HaveKeyWithValue("type", "fulfillment.v1.Cluster"))
+		Expect(input).To(HaveKeyWithValue("object", HaveKeyWithValue("metadata", HaveKeyWithValue(
+			"name", "my-cluster",
+		))))
+	})
+
+	It("Doesn't send the request if the pre hook fails", func() {
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"pre_delete": "echo 'Not allowed'; exit 1",
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersDeleteRequest_builder{
+			Id: "123",
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Delete", request, &ffv1.ClustersDeleteResponse{}, nil, invoker,
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the 'pre_delete' hook rejected the delete of Cluster '123'"))
+		code, ok := exit.CodeOf(err)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(exit.Validation))
+		Expect(calls).To(Equal([]string{"/fulfillment.v1.Clusters/Get"}))
+		Expect(output.String()).To(Equal("Not allowed\n"))
+	})
+
+	It("Doesn't send the request if the object for the pre hook can't be retrieved", func() {
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"pre_delete": "true",
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		failure := errors.New("get failed")
+		request := ffv1.ClustersDeleteRequest_builder{
+			Id: "123",
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Delete", request, &ffv1.ClustersDeleteResponse{}, nil,
+			func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+				opts ...grpc.CallOption) error {
+				calls = append(calls, method)
+				return failure
+			},
+		)
+		Expect(err).To(MatchError(failure))
+		Expect(err.Error()).To(ContainSubstring(
+			"the delete request wasn't sent because the 'pre_delete' hook can't run without the object",
+		))
+		Expect(calls).To(Equal([]string{"/fulfillment.v1.Clusters/Get"}))
+	})
+
+	It("Sends the request if the object for the post hook can't be retrieved", func() {
+		file := filepath.Join(tmp, "input.json")
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"post_delete": "cat > " + file,
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersDeleteRequest_builder{
+			Id: "123",
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Delete", request, &ffv1.ClustersDeleteResponse{}, nil,
+			func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+				opts ...grpc.CallOption) error {
+				calls = append(calls, method)
+				if method == "/fulfillment.v1.Clusters/Get" {
+					return errors.New("get failed")
+				}
+				return nil
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{
+			"/fulfillment.v1.Clusters/Get",
+			"/fulfillment.v1.Clusters/Delete",
+		}))
+		Expect(file).ToNot(BeAnExistingFile())
+	})
+
+	It("Stops the pre hook if it doesn't finish in time", func() {
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetTimeout(100 * time.Millisecond).
+			SetHooks(map[string]string{
+				"pre_create": "sleep 10",
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersCreateRequest_builder{
+			Object: &ffv1.Cluster{},
+		}.Build()
+		start := time.Now()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Create", request, &ffv1.ClustersCreateResponse{}, nil, invoker,
+		)
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("script didn't finish in 100ms"))
+		Expect(calls).To(BeEmpty())
+	})
+
+	It("Rejects negative timeouts", func() {
+		_, err := NewInterceptor().
+			SetLogger(logger).
+			SetTimeout(-time.Second).
+			Build()
+		Expect(err).To(MatchError("hook timeout should be zero or positive, but it is -1s"))
+	})
+
+	It("Gets the object before deleting it", func() {
+		file := filepath.Join(tmp, "input.json")
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"pre_delete": "cat > " + file + "; test \"$FULFILLMENT_OBJECT_ID\" = 123",
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersDeleteRequest_builder{
+			Id: "123",
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Delete", request, &ffv1.ClustersDeleteResponse{}, nil, invoker,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{
+			"/fulfillment.v1.Clusters/Get",
+			"/fulfillment.v1.Clusters/Delete",
+		}))
+		input := readInput(file)
+		Expect(input).To(HaveKeyWithValue("operation", "delete"))
+		Expect(input).To(HaveKeyWithValue("object", HaveKeyWithValue("id", "123")))
+	})
+
+	It("Doesn't pass the variables of the tool to the scripts", func() {
+		GinkgoT().Setenv("FULFILLMENT_CLI_PASSPHRASE", "secret")
+		file := filepath.Join(tmp, "env.txt")
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"pre_delete": "echo \"${FULFILLMENT_CLI_PASSPHRASE:-none} $FULFILLMENT_HOOK\" > " + file,
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersDeleteRequest_builder{
+			Id: "123",
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Delete", request, &ffv1.ClustersDeleteResponse{}, nil, invoker,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("none pre_delete\n"))
+	})
+
+	It("Passes the object returned by the server to the post hook", func() {
+		file := filepath.Join(tmp, "input.json")
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"post_create": "cat > " + file,
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersCreateRequest_builder{
+			Object: &ffv1.Cluster{},
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Create", request, &ffv1.ClustersCreateResponse{}, nil, invoker,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		input := readInput(file)
+		Expect(input).To(HaveKeyWithValue("hook", "post_create"))
+		Expect(input).To(HaveKeyWithValue("object", HaveKeyWithValue("id", "123")))
+	})
+
+	It("Only warns if the post hook fails", func() {
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"post_create": "exit 1",
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request := ffv1.ClustersCreateRequest_builder{
+			Object: &ffv1.Cluster{},
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Create", request, &ffv1.ClustersCreateResponse{}, nil, invoker,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output.String()).To(ContainSubstring(
+			"Warning: the 'post_create' hook failed after the create of Cluster '123'",
+		))
+	})
+
+	It("Doesn't run the post hook if the request fails", func() {
+		file := filepath.Join(tmp, "input.json")
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"post_update": "cat > " + file,
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		failure := errors.New("update failed")
+		request := ffv1.ClustersUpdateRequest_builder{
+			Object: ffv1.Cluster_builder{
+				Id: "123",
+			}.Build(),
+		}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/Update", request, &ffv1.ClustersUpdateResponse{}, nil,
+			func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+				opts ...grpc.CallOption) error {
+				return failure
+			},
+		)
+		Expect(err).To(MatchError(failure))
+		Expect(file).ToNot(BeAnExistingFile())
+	})
+
+	It("Ignores methods that aren't changes", func() {
+		interceptor, err := NewInterceptor().
+			SetLogger(logger).
+			SetShell("/bin/sh").
+			SetOutput(output).
+			SetHooks(map[string]string{
+				"pre_create": "exit 1",
+				"pre_update": "exit 1",
+				"pre_delete": "exit 1",
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var request proto.Message = ffv1.ClustersListRequest_builder{}.Build()
+		err = interceptor.UnaryClient(
+			ctx, "/fulfillment.v1.Clusters/List", request, &ffv1.ClustersListResponse{}, nil, invoker,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{"/fulfillment.v1.Clusters/List"}))
+	})
+})
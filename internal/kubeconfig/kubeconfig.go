@@ -0,0 +1,236 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the subset of the kubeconfig file format that we need in order to merge files. Fields that we don't use
+// are preserved in the inline maps, so that saving a file that we loaded doesn't lose information.
+type Config struct {
+	APIVersion     string          `yaml:"apiVersion,omitempty"`
+	Kind           string          `yaml:"kind,omitempty"`
+	Clusters       []*NamedCluster `yaml:"clusters"`
+	Users          []*NamedUser    `yaml:"users"`
+	Contexts       []*NamedContext `yaml:"contexts"`
+	CurrentContext string          `yaml:"current-context"`
+	Extra          map[string]any  `yaml:",inline"`
+}
+
+// NamedCluster contains the connection details of a cluster and the name used to reference it from contexts.
+type NamedCluster struct {
+	Name    string         `yaml:"name"`
+	Cluster map[string]any `yaml:"cluster"`
+}
+
+// NamedUser contains the credentials of a user and the name used to reference them from contexts.
+type NamedUser struct {
+	Name string         `yaml:"name"`
+	User map[string]any `yaml:"user"`
+}
+
+// NamedContext contains a context and its name.
+type NamedContext struct {
+	Name    string          `yaml:"name"`
+	Context *ContextDetails `yaml:"context"`
+}
+
+// ContextDetails references the cluster and user that should be used together.
+type ContextDetails struct {
+	Cluster   string         `yaml:"cluster"`
+	User      string         `yaml:"user"`
+	Namespace string         `yaml:"namespace,omitempty"`
+	Extra     map[string]any `yaml:",inline"`
+}
+
+// Parse parses the given kubeconfig data.
+func Parse(data []byte) (result *Config, err error) {
+	config := &Config{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		err = fmt.Errorf("failed to parse kubeconfig: %w", err)
+		return
+	}
+	result = config
+	return
+}
+
+// Load loads the kubeconfig from the given file. If the file doesn't exist it returns an empty configuration.
+func Load(file string) (result *Config, err error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		result = &Config{
+			APIVersion: "v1",
+			Kind:       "Config",
+		}
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read kubeconfig file '%s': %w", file, err)
+		return
+	}
+	result, err = Parse(data)
+	if err != nil {
+		err = fmt.Errorf("failed to load kubeconfig file '%s': %w", file, err)
+	}
+	return
+}
+
+// Save writes the kubeconfig to the given file, creating the directory if needed. The file is only readable by the
+// current user because it usually contains credentials.
+func Save(file string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for kubeconfig file '%s': %w", file, err)
+	}
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig file '%s': %w", file, err)
+	}
+	return nil
+}
+
+// Marshal converts the configuration to YAML.
+func (c *Config) Marshal() (result []byte, err error) {
+	result, err = yaml.Marshal(c)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+	return
+}
+
+// Merge adds to this configuration the current context of the source configuration, together with the cluster and
+// user that it references. All of them are renamed to the given name, replacing any existing entries with that name,
+// and the new context becomes the current context.
+func (c *Config) Merge(source *Config, name string) error {
+	context, cluster, user, err := source.Current()
+	if err != nil {
+		return err
+	}
+
+	// Add the renamed copies, replacing existing entries with the same name:
+	c.Clusters = replaceNamed(c.Clusters, &NamedCluster{
+		Name:    name,
+		Cluster: cluster.Cluster,
+	}, func(cluster *NamedCluster) string {
+		return cluster.Name
+	})
+	c.Users = replaceNamed(c.Users, &NamedUser{
+		Name: name,
+		User: user.User,
+	}, func(user *NamedUser) string {
+		return user.Name
+	})
+	c.Contexts = replaceNamed(c.Contexts, &NamedContext{
+		Name: name,
+		Context: &ContextDetails{
+			Cluster:   name,
+			User:      name,
+			Namespace: context.Context.Namespace,
+			Extra:     context.Context.Extra,
+		},
+	}, func(context *NamedContext) string {
+		return context.Name
+	})
+	c.CurrentContext = name
+	if c.APIVersion == "" {
+		c.APIVersion = "v1"
+	}
+	if c.Kind == "" {
+		c.Kind = "Config"
+	}
+	return nil
+}
+
+// Current returns the current context, or the first one if there is no current context, together with the cluster
+// and user that it references.
+func (c *Config) Current() (context *NamedContext, cluster *NamedCluster, user *NamedUser, err error) {
+	// Find the context:
+	for _, candidate := range c.Contexts {
+		if candidate.Name == c.CurrentContext {
+			context = candidate
+			break
+		}
+	}
+	if context == nil && len(c.Contexts) > 0 {
+		context = c.Contexts[0]
+	}
+	if context == nil || context.Context == nil {
+		err = fmt.Errorf("kubeconfig doesn't contain any context")
+		return
+	}
+
+	// Find the referenced cluster and user:
+	clusterIndex := slices.IndexFunc(c.Clusters, func(cluster *NamedCluster) bool {
+		return cluster.Name == context.Context.Cluster
+	})
+	if clusterIndex == -1 {
+		err = fmt.Errorf(
+			"kubeconfig context '%s' references cluster '%s', but it doesn't exist",
+			context.Name, context.Context.Cluster,
+		)
+		return
+	}
+	userIndex := slices.IndexFunc(c.Users, func(user *NamedUser) bool {
+		return user.Name == context.Context.User
+	})
+	if userIndex == -1 {
+		err = fmt.Errorf(
+			"kubeconfig context '%s' references user '%s', but it doesn't exist",
+			context.Name, context.Context.User,
+		)
+		return
+	}
+	cluster = c.Clusters[clusterIndex]
+	user = c.Users[userIndex]
+	return
+}
+
+// replaceNamed replaces the item of the slice that has the same name than the given one, or appends it if there is
+// no such item.
+func replaceNamed[T any](items []T, item T, name func(T) string) []T {
+	index := slices.IndexFunc(items, func(existing T) bool {
+		return name(existing) == name(item)
+	})
+	if index == -1 {
+		return append(items, item)
+	}
+	items[index] = item
+	return items
+}
+
+// DefaultFile returns the file that kubectl uses by default: the first file in the KUBECONFIG environment variable if
+// it is set, or '~/.kube/config' otherwise.
+func DefaultFile() (result string, err error) {
+	files := filepath.SplitList(os.Getenv("KUBECONFIG"))
+	if len(files) > 0 && files[0] != "" {
+		result = files[0]
+		return
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		err = fmt.Errorf("failed to find home directory: %w", err)
+		return
+	}
+	result = filepath.Join(home, ".kube", "config")
+	return
+}
@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ExecAPIVersion is the version of the exec credential API used when kubectl doesn't say which one it wants.
+const ExecAPIVersion = "client.authentication.k8s.io/v1"
+
+// execInfoEnv is the environment variable that kubectl uses to pass to the credential plugin the exec credential
+// document that describes the request, including the version of the API that it expects.
+const execInfoEnv = "KUBERNETES_EXEC_INFO"
+
+// ExecCredential is the document that credential plugins write to the standard output so that kubectl, or any other
+// program based on client-go, can use the credentials.
+type ExecCredential struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Status     *ExecCredentialStatus `json:"status"`
+}
+
+// ExecCredentialStatus contains the credentials. The certificate and the key are PEM encoded, not base64 encoded like
+// in the kubeconfig.
+type ExecCredentialStatus struct {
+	ExpirationTimestamp string `json:"expirationTimestamp,omitempty"`
+	Token               string `json:"token,omitempty"`
+	ClientCertificate   string `json:"clientCertificateData,omitempty"`
+	ClientKey           string `json:"clientKeyData,omitempty"`
+}
+
+// NewExecCredential creates an exec credential with the version of the API requested by kubectl in the
+// KUBERNETES_EXEC_INFO environment variable, or ExecAPIVersion if it isn't set.
+func NewExecCredential() (result *ExecCredential, err error) {
+	apiVersion := ExecAPIVersion
+	info := os.Getenv(execInfoEnv)
+	if info != "" {
+		var request struct {
+			APIVersion string `json:"apiVersion"`
+		}
+		err = json.Unmarshal([]byte(info), &request)
+		if err != nil {
+			err = fmt.Errorf("failed to parse the '%s' environment variable: %w", execInfoEnv, err)
+			return
+		}
+		if request.APIVersion != "" {
+			apiVersion = request.APIVersion
+		}
+	}
+	result = &ExecCredential{
+		APIVersion: apiVersion,
+		Kind:       "ExecCredential",
+		Status:     &ExecCredentialStatus{},
+	}
+	return
+}
+
+// SetExpiration sets the time when kubectl should discard the credentials and run the plugin again. The zero time
+// means that the credentials don't expire.
+func (c *ExecCredential) SetExpiration(value time.Time) {
+	if value.IsZero() {
+		c.Status.ExpirationTimestamp = ""
+		return
+	}
+	c.Status.ExpirationTimestamp = value.UTC().Format(time.RFC3339)
+}
+
+// Marshal converts the exec credential to JSON.
+func (c *ExecCredential) Marshal() (result []byte, err error) {
+	result, err = json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("failed to marshal exec credential: %w", err)
+	}
+	return
+}
+
+// ExecCredential returns an exec credential containing the static credentials of the user of the current context:
+// the token, or the client certificate and key. When the credentials are a client certificate the expiration is the
+// end of the validity of the certificate. The result is nil when the user doesn't have any of those credentials, for
+// example when it uses an exec plugin itself.
+func (c *Config) ExecCredential() (result *ExecCredential, err error) {
+	_, _, user, err := c.Current()
+	if err != nil {
+		return
+	}
+	token, err := probeToken(user)
+	if err != nil {
+		return
+	}
+	certData, err := probeData(user.User, "client-certificate-data", "client-certificate")
+	if err != nil {
+		return
+	}
+	keyData, err := probeData(user.User, "client-key-data", "client-key")
+	if err != nil {
+		return
+	}
+	if token == "" && (certData == nil || keyData == nil) {
+		return
+	}
+	credential, err := NewExecCredential()
+	if err != nil {
+		return
+	}
+	if token != "" {
+		credential.Status.Token = token
+	} else {
+		credential.Status.ClientCertificate = string(certData)
+		credential.Status.ClientKey = string(keyData)
+		block, _ := pem.Decode(certData)
+		if block == nil {
+			err = fmt.Errorf("kubeconfig user '%s' doesn't contain a valid client certificate", user.Name)
+			return
+		}
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			err = fmt.Errorf("kubeconfig user '%s' doesn't contain a valid client certificate: %w", user.Name, err)
+			return
+		}
+		credential.SetExpiration(cert.NotAfter)
+	}
+	result = credential
+	return
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exec credential", func() {
+	// makeConfig creates a kubeconfig where the current user has the given fields.
+	makeConfig := func(user string) *Config {
+		config, err := Parse([]byte(fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: my-cluster
+  cluster:
+    server: https://api.example.com:6443
+users:
+- name: admin
+  user:
+%s
+contexts:
+- name: admin
+  context:
+    cluster: my-cluster
+    user: admin
+current-context: admin
+`, user)))
+		Expect(err).ToNot(HaveOccurred())
+		return config
+	}
+
+	It("Uses the token of the user", func() {
+		credential, err := makeConfig("    token: my-token").ExecCredential()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credential).ToNot(BeNil())
+		Expect(credential.APIVersion).To(Equal(ExecAPIVersion))
+		Expect(credential.Kind).To(Equal("ExecCredential"))
+		Expect(credential.Status.Token).To(Equal("my-token"))
+		Expect(credential.Status.ExpirationTimestamp).To(BeEmpty())
+	})
+
+	It("Uses the token file of the user", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "token")
+		err := os.WriteFile(file, []byte("my-token\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		credential, err := makeConfig("    tokenFile: " + file).ExecCredential()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credential).ToNot(BeNil())
+		Expect(credential.Status.Token).To(Equal("my-token"))
+	})
+
+	It("Uses the client certificate of the user and its expiration", func() {
+		// Generate the certificate and the key:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject: pkix.Name{
+				CommonName: "admin",
+			},
+			NotBefore: notAfter.Add(-time.Hour),
+			NotAfter:  notAfter,
+		}
+		certDer, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).ToNot(HaveOccurred())
+		keyDer, err := x509.MarshalECPrivateKey(key)
+		Expect(err).ToNot(HaveOccurred())
+		certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer})
+		keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+		// Check that they are returned PEM encoded:
+		credential, err := makeConfig(fmt.Sprintf(
+			"    client-certificate-data: %s\n    client-key-data: %s",
+			base64.StdEncoding.EncodeToString(certPem),
+			base64.StdEncoding.EncodeToString(keyPem),
+		)).ExecCredential()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credential).ToNot(BeNil())
+		Expect(credential.Status.Token).To(BeEmpty())
+		Expect(credential.Status.ClientCertificate).To(Equal(string(certPem)))
+		Expect(credential.Status.ClientKey).To(Equal(string(keyPem)))
+		Expect(credential.Status.ExpirationTimestamp).To(Equal("2030-01-02T03:04:05Z"))
+	})
+
+	It("Returns nil when the user doesn't have static credentials", func() {
+		credential, err := makeConfig("    exec:\n      command: my-plugin").ExecCredential()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credential).To(BeNil())
+	})
+
+	It("Uses the version of the API requested by kubectl", func() {
+		GinkgoT().Setenv(
+			"KUBERNETES_EXEC_INFO",
+			`{"apiVersion":"client.authentication.k8s.io/v1beta1","kind":"ExecCredential"}`,
+		)
+		credential, err := NewExecCredential()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credential.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
+	})
+
+	It("Fails if the information passed by kubectl is malformed", func() {
+		GinkgoT().Setenv("KUBERNETES_EXEC_INFO", "junk")
+		_, err := NewExecCredential()
+		Expect(err).To(MatchError(ContainSubstring("failed to parse the 'KUBERNETES_EXEC_INFO' environment variable")))
+	})
+
+	It("Generates the document expected by kubectl", func() {
+		credential, err := NewExecCredential()
+		Expect(err).ToNot(HaveOccurred())
+		credential.Status.Token = "my-token"
+		credential.SetExpiration(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC))
+		data, err := credential.Marshal()
+		Expect(err).ToNot(HaveOccurred())
+		var document map[string]any
+		err = json.Unmarshal(data, &document)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(document).To(Equal(map[string]any{
+			"apiVersion": "client.authentication.k8s.io/v1",
+			"kind":       "ExecCredential",
+			"status": map[string]any{
+				"token":               "my-token",
+				"expirationTimestamp": "2030-01-02T03:04:05Z",
+			},
+		}))
+	})
+})
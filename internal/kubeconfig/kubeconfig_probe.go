@@ -0,0 +1,275 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// AuthStatus indicates if the API server accepted the credentials of the kubeconfig.
+type AuthStatus string
+
+const (
+	// AuthStatusAccepted means that the API server accepted the credentials.
+	AuthStatusAccepted AuthStatus = "accepted"
+
+	// AuthStatusRejected means that the API server rejected the credentials, or that there are no credentials and
+	// the server doesn't allow anonymous access.
+	AuthStatusRejected AuthStatus = "rejected"
+
+	// AuthStatusUnknown means that it wasn't possible to check the credentials, for example because the server
+	// wasn't reachable or because it doesn't support self subject reviews.
+	AuthStatusUnknown AuthStatus = "unknown"
+)
+
+// ProbeResult contains the result of probing the API server of a kubeconfig.
+type ProbeResult struct {
+	// Server is the URL of the API server.
+	Server string
+
+	// Reachable indicates if the API server responded to the version request.
+	Reachable bool
+
+	// Error is the reason why the API server isn't reachable.
+	Error error
+
+	// Version is the version of Kubernetes reported by the API server.
+	Version string
+
+	// Auth indicates if the API server accepted the credentials.
+	Auth AuthStatus
+
+	// User is the name of the user, as reported by the API server, when the credentials were accepted.
+	User string
+
+	// Code is the HTTP status code returned by the API server for the authentication request.
+	Code int
+}
+
+// Probe sends a request to the version endpoint of the API server of the current context, to check that it is
+// reachable, and then a self subject review request to check that the credentials are accepted. This is intentionally
+// lightweight, and doesn't need any permission other than what the default roles grant to any authenticated user.
+//
+// Only static credentials are supported: tokens, client certificates, and user names and passwords. The returned
+// error indicates that the kubeconfig itself isn't usable, problems talking to the API server are reported in the
+// result instead.
+func (c *Config) Probe(ctx context.Context) (result *ProbeResult, err error) {
+	_, cluster, user, err := c.Current()
+	if err != nil {
+		return
+	}
+	server, _ := cluster.Cluster["server"].(string)
+	if server == "" {
+		err = fmt.Errorf("kubeconfig cluster '%s' doesn't have a server", cluster.Name)
+		return
+	}
+	server = strings.TrimRight(server, "/")
+	client, header, err := probeClient(cluster, user)
+	if err != nil {
+		return
+	}
+	result = &ProbeResult{
+		Server: server,
+		Auth:   AuthStatusUnknown,
+	}
+
+	// Check that the server is reachable and get the version:
+	var version struct {
+		GitVersion string `json:"gitVersion"`
+	}
+	code, err := probeRequest(ctx, client, header, http.MethodGet, server+"/version", nil, &version)
+	if err != nil {
+		result.Error = err
+		err = nil
+		return
+	}
+	result.Reachable = true
+	result.Version = version.GitVersion
+
+	// Check that the credentials are accepted:
+	var review struct {
+		Status struct {
+			UserInfo struct {
+				Username string `json:"username"`
+			} `json:"userInfo"`
+		} `json:"status"`
+	}
+	code, err = probeRequest(
+		ctx, client, header, http.MethodPost, server+"/apis/authentication.k8s.io/v1/selfsubjectreviews",
+		[]byte(`{"apiVersion":"authentication.k8s.io/v1","kind":"SelfSubjectReview"}`), &review,
+	)
+	if err != nil {
+		result.Error = err
+		result.Reachable = false
+		err = nil
+		return
+	}
+	result.Code = code
+	switch code {
+	case http.StatusOK, http.StatusCreated:
+		result.Auth = AuthStatusAccepted
+		result.User = review.Status.UserInfo.Username
+	case http.StatusUnauthorized, http.StatusForbidden:
+		result.Auth = AuthStatusRejected
+	}
+	return
+}
+
+// probeClient creates the HTTP client and the authorization header for the given cluster and user.
+func probeClient(cluster *NamedCluster, user *NamedUser) (client *http.Client, header string, err error) {
+	// Prepare the TLS configuration:
+	tlsConfig := &tls.Config{}
+	if insecure, _ := cluster.Cluster["insecure-skip-tls-verify"].(bool); insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	caData, err := probeData(cluster.Cluster, "certificate-authority-data", "certificate-authority")
+	if err != nil {
+		return
+	}
+	if caData != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			err = fmt.Errorf("kubeconfig cluster '%s' doesn't contain valid CA certificates", cluster.Name)
+			return
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if name, _ := cluster.Cluster["tls-server-name"].(string); name != "" {
+		tlsConfig.ServerName = name
+	}
+
+	// Add the client certificate, if any:
+	certData, err := probeData(user.User, "client-certificate-data", "client-certificate")
+	if err != nil {
+		return
+	}
+	keyData, err := probeData(user.User, "client-key-data", "client-key")
+	if err != nil {
+		return
+	}
+	if certData != nil && keyData != nil {
+		var cert tls.Certificate
+		cert, err = tls.X509KeyPair(certData, keyData)
+		if err != nil {
+			err = fmt.Errorf("kubeconfig user '%s' doesn't contain a valid client certificate: %w", user.Name, err)
+			return
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	// Calculate the authorization header:
+	token, err := probeToken(user)
+	if err != nil {
+		return
+	}
+	username, _ := user.User["username"].(string)
+	password, _ := user.User["password"].(string)
+	switch {
+	case token != "":
+		header = "Bearer " + token
+	case username != "":
+		header = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+
+	client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	return
+}
+
+// probeToken returns the token of the given user, from the 'token' field or else from the file given in the 'tokenFile'
+// field. It returns an empty string if neither is set.
+func probeToken(user *NamedUser) (result string, err error) {
+	result, _ = user.User["token"].(string)
+	if result != "" {
+		return
+	}
+	if file, _ := user.User["tokenFile"].(string); file != "" {
+		var data []byte
+		data, err = os.ReadFile(file)
+		if err != nil {
+			err = fmt.Errorf("failed to read token file '%s': %w", file, err)
+			return
+		}
+		result = strings.TrimSpace(string(data))
+	}
+	return
+}
+
+// probeData returns the data from the given base64 encoded field, or else from the file given in the other field. It
+// returns nil if neither is set.
+func probeData(fields map[string]any, dataField, fileField string) (result []byte, err error) {
+	if text, _ := fields[dataField].(string); text != "" {
+		result, err = base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			err = fmt.Errorf("failed to decode kubeconfig field '%s': %w", dataField, err)
+		}
+		return
+	}
+	if file, _ := fields[fileField].(string); file != "" {
+		result, err = os.ReadFile(file)
+		if err != nil {
+			err = fmt.Errorf("failed to read kubeconfig file '%s' from field '%s': %w", file, fileField, err)
+		}
+	}
+	return
+}
+
+// probeRequest sends a request and decodes the response body, if it is successful, into the given value. It returns
+// the HTTP status code.
+func probeRequest(ctx context.Context, client *http.Client, header, method, url string, body []byte,
+	value any) (code int, err error) {
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if header != "" {
+		request.Header.Set("Authorization", header)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+	code = response.StatusCode
+	if code/100 != 2 {
+		return
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, value)
+	if err != nil {
+		err = fmt.Errorf("failed to decode response from '%s': %w", url, err)
+	}
+	return
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Probe", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /version", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"gitVersion":"v1.31.2"}`))
+		})
+		mux.HandleFunc(
+			"POST /apis/authentication.k8s.io/v1/selfsubjectreviews",
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer good-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"status":{"userInfo":{"username":"system:admin"}}}`))
+			},
+		)
+		server = httptest.NewTLSServer(mux)
+		DeferCleanup(server.Close)
+	})
+
+	// makeConfig creates a kubeconfig for the test server, trusting its certificate and using the given token.
+	makeConfig := func(token string) *Config {
+		caData := pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: server.Certificate().Raw,
+		})
+		config, err := Parse([]byte(fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: my-cluster
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+users:
+- name: admin
+  user:
+    token: %s
+contexts:
+- name: admin
+  context:
+    cluster: my-cluster
+    user: admin
+current-context: admin
+`, server.URL, base64.StdEncoding.EncodeToString(caData), token)))
+		Expect(err).ToNot(HaveOccurred())
+		return config
+	}
+
+	It("Reports version and user when the credentials are accepted", func() {
+		result, err := makeConfig("good-token").Probe(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Server).To(Equal(server.URL))
+		Expect(result.Reachable).To(BeTrue())
+		Expect(result.Version).To(Equal("v1.31.2"))
+		Expect(result.Auth).To(Equal(AuthStatusAccepted))
+		Expect(result.User).To(Equal("system:admin"))
+	})
+
+	It("Reports rejected credentials", func() {
+		result, err := makeConfig("bad-token").Probe(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Reachable).To(BeTrue())
+		Expect(result.Auth).To(Equal(AuthStatusRejected))
+		Expect(result.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("Reports unreachable server", func() {
+		config := makeConfig("good-token")
+		server.Close()
+		result, err := config.Probe(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(result.Error).To(HaveOccurred())
+		Expect(result.Auth).To(Equal(AuthStatusUnknown))
+	})
+
+	It("Fails if the cluster doesn't have a server", func() {
+		config, err := Parse([]byte(`
+clusters:
+- name: my-cluster
+  cluster: {}
+users:
+- name: admin
+  user: {}
+contexts:
+- name: admin
+  context:
+    cluster: my-cluster
+    user: admin
+`))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = config.Probe(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("doesn't have a server")))
+	})
+})
@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kubeconfig", func() {
+	const source = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: api-my-cluster
+  cluster:
+    server: https://api.my-cluster.example.com:6443
+users:
+- name: admin
+  user:
+    token: my-token
+contexts:
+- name: admin
+  context:
+    cluster: api-my-cluster
+    user: admin
+current-context: admin
+`
+
+	It("Merges into an empty configuration", func() {
+		sourceConfig, err := Parse([]byte(source))
+		Expect(err).ToNot(HaveOccurred())
+		target := &Config{}
+		err = target.Merge(sourceConfig, "my-cluster")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.APIVersion).To(Equal("v1"))
+		Expect(target.Kind).To(Equal("Config"))
+		Expect(target.CurrentContext).To(Equal("my-cluster"))
+		Expect(target.Clusters).To(HaveLen(1))
+		Expect(target.Clusters[0].Name).To(Equal("my-cluster"))
+		Expect(target.Clusters[0].Cluster).To(HaveKeyWithValue(
+			"server", "https://api.my-cluster.example.com:6443",
+		))
+		Expect(target.Users).To(HaveLen(1))
+		Expect(target.Users[0].Name).To(Equal("my-cluster"))
+		Expect(target.Users[0].User).To(HaveKeyWithValue("token", "my-token"))
+		Expect(target.Contexts).To(HaveLen(1))
+		Expect(target.Contexts[0].Name).To(Equal("my-cluster"))
+		Expect(target.Contexts[0].Context.Cluster).To(Equal("my-cluster"))
+		Expect(target.Contexts[0].Context.User).To(Equal("my-cluster"))
+	})
+
+	It("Preserves existing entries and replaces the ones with the same name", func() {
+		target, err := Parse([]byte(`
+apiVersion: v1
+kind: Config
+preferences: {}
+clusters:
+- name: other
+  cluster:
+    server: https://other.example.com
+- name: my-cluster
+  cluster:
+    server: https://old.example.com
+users:
+- name: other
+  user:
+    token: other-token
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other
+current-context: other
+`))
+		Expect(err).ToNot(HaveOccurred())
+		sourceConfig, err := Parse([]byte(source))
+		Expect(err).ToNot(HaveOccurred())
+		err = target.Merge(sourceConfig, "my-cluster")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.CurrentContext).To(Equal("my-cluster"))
+		Expect(target.Clusters).To(HaveLen(2))
+		Expect(target.Clusters[0].Name).To(Equal("other"))
+		Expect(target.Clusters[1].Cluster).To(HaveKeyWithValue(
+			"server", "https://api.my-cluster.example.com:6443",
+		))
+		Expect(target.Users).To(HaveLen(2))
+		Expect(target.Contexts).To(HaveLen(2))
+		Expect(target.Extra).To(HaveKey("preferences"))
+	})
+
+	It("Fails if the source has no context", func() {
+		sourceConfig, err := Parse([]byte("apiVersion: v1\nkind: Config\n"))
+		Expect(err).ToNot(HaveOccurred())
+		err = (&Config{}).Merge(sourceConfig, "my-cluster")
+		Expect(err).To(MatchError("kubeconfig doesn't contain any context"))
+	})
+
+	It("Fails if the context references a missing user", func() {
+		sourceConfig, err := Parse([]byte(`
+contexts:
+- name: admin
+  context:
+    cluster: api
+    user: admin
+clusters:
+- name: api
+  cluster:
+    server: https://api.example.com
+`))
+		Expect(err).ToNot(HaveOccurred())
+		err = (&Config{}).Merge(sourceConfig, "my-cluster")
+		Expect(err).To(MatchError(ContainSubstring("references user 'admin'")))
+	})
+
+	It("Saves and loads files", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "kube", "config")
+		config, err := Load(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Clusters).To(BeEmpty())
+		sourceConfig, err := Parse([]byte(source))
+		Expect(err).ToNot(HaveOccurred())
+		err = config.Merge(sourceConfig, "my-cluster")
+		Expect(err).ToNot(HaveOccurred())
+		data, err := config.Marshal()
+		Expect(err).ToNot(HaveOccurred())
+		err = Save(file, data)
+		Expect(err).ToNot(HaveOccurred())
+		info, err := os.Stat(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		loaded, err := Load(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded.CurrentContext).To(Equal("my-cluster"))
+		Expect(loaded.Clusters).To(HaveLen(1))
+	})
+})
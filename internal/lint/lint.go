@@ -0,0 +1,340 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package lint contains the checks that find problems in the objects of an installation that the server doesn't
+// reject, but that usually indicate mistakes: objects of the same type with the same name, objects that don't have
+// the labels required by the policy of the team, and references to objects that don't exist.
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+)
+
+// Kinds of problems:
+const (
+	DuplicateName     = "duplicate name"
+	MissingLabel      = "missing label"
+	DanglingReference = "dangling reference"
+)
+
+// Inventory contains the objects of the installation, indexed by the full name of their type.
+type Inventory map[protoreflect.FullName][]proto.Message
+
+// Problem describes a problem found in an object.
+type Problem struct {
+	// Type is the full name of the type of the object, for example 'fulfillment.v1.Cluster'.
+	Type protoreflect.FullName `json:"type"`
+
+	// Id is the identifier of the object.
+	Id string `json:"id"`
+
+	// Name is the name of the object, may be empty.
+	Name string `json:"name,omitempty"`
+
+	// Kind is the kind of problem, one of DuplicateName, MissingLabel or DanglingReference.
+	Kind string `json:"kind"`
+
+	// Message is the description of the problem.
+	Message string `json:"message"`
+}
+
+// Reference describes a field of an object that contains the identifier or name of another object.
+type Reference struct {
+	// Source is the full name of the type that contains the field.
+	Source protoreflect.FullName
+
+	// Path is the list of field names that lead to the field, where '*' means all the values of a map.
+	Path []string
+
+	// Target is the full name of the type of the referenced objects.
+	Target protoreflect.FullName
+}
+
+// References is the list of fields that contain references to other objects, checked to find dangling references.
+var References = []Reference{
+	{
+		Source: "fulfillment.v1.Cluster",
+		Path:   []string{"spec", "template"},
+		Target: "fulfillment.v1.ClusterTemplate",
+	},
+	{
+		Source: "fulfillment.v1.Cluster",
+		Path:   []string{"spec", "node_sets", "*", "host_class"},
+		Target: "fulfillment.v1.HostClass",
+	},
+	{
+		Source: "fulfillment.v1.ClusterTemplate",
+		Path:   []string{"node_sets", "*", "host_class"},
+		Target: "fulfillment.v1.HostClass",
+	},
+	{
+		Source: "fulfillment.v1.ComputeInstance",
+		Path:   []string{"spec", "template"},
+		Target: "fulfillment.v1.ComputeInstanceTemplate",
+	},
+	{
+		Source: "fulfillment.v1.HostPool",
+		Path:   []string{"spec", "host_sets", "*", "host_class"},
+		Target: "fulfillment.v1.HostClass",
+	},
+}
+
+// CheckerBuilder contains the data and logic needed to create a checker. Don't create objects of this type directly,
+// use the NewChecker function instead.
+type CheckerBuilder struct {
+	logger *slog.Logger
+	rules  *Rules
+}
+
+// Checker finds problems in the objects of an installation. Don't create objects of this type directly, use the
+// NewChecker function instead.
+type Checker struct {
+	logger *slog.Logger
+	rules  *Rules
+}
+
+// NewChecker creates a builder that can then be used to configure and create a checker.
+func NewChecker() *CheckerBuilder {
+	return &CheckerBuilder{}
+}
+
+// SetLogger sets the logger that the checker will use to write messages to the log. This is mandatory.
+func (b *CheckerBuilder) SetLogger(value *slog.Logger) *CheckerBuilder {
+	b.logger = value
+	return b
+}
+
+// SetRules sets the rules that the checker will enforce, in addition to the built-in checks. This is optional. Note
+// that the types of the rules should be already resolved to full names.
+func (b *CheckerBuilder) SetRules(value *Rules) *CheckerBuilder {
+	b.rules = value
+	return b
+}
+
+// Build uses the configuration stored in the builder to create a new checker.
+func (b *CheckerBuilder) Build() (result *Checker, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+
+	// Use empty rules if none were given:
+	rules := b.rules
+	if rules == nil {
+		rules = &Rules{}
+	}
+
+	// Create and populate the object:
+	result = &Checker{
+		logger: b.logger,
+		rules:  rules,
+	}
+	return
+}
+
+// Check checks the objects of the given types. The inventory should contain the objects of those types, and also the
+// objects of the types that they reference, otherwise references to those types aren't checked. The problems are
+// returned in the order of the types, and then in the order of the objects.
+func (c *Checker) Check(ctx context.Context, inventory Inventory, types []protoreflect.FullName) []*Problem {
+	problems := []*Problem{}
+	for _, objectType := range types {
+		// Index the identifiers of the objects by name, so that finding duplicates doesn't require comparing all the
+		// pairs of objects:
+		names := map[string][]string{}
+		for _, object := range inventory[objectType] {
+			name := getName(object)
+			if name != "" {
+				names[name] = append(names[name], getId(object))
+			}
+		}
+
+		// Check the objects:
+		for _, object := range inventory[objectType] {
+			problems = append(problems, c.checkName(objectType, object, names)...)
+			problems = append(problems, c.checkLabels(objectType, object)...)
+			problems = append(problems, c.checkReferences(ctx, objectType, object, inventory)...)
+		}
+	}
+	return problems
+}
+
+func (c *Checker) checkName(objectType protoreflect.FullName, object proto.Message,
+	names map[string][]string) []*Problem {
+	name := getName(object)
+	if name == "" {
+		return nil
+	}
+	id := getId(object)
+	var others []string
+	for _, otherId := range names[name] {
+		if otherId != id {
+			others = append(others, fmt.Sprintf("'%s'", otherId))
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return []*Problem{{
+		Type:    objectType,
+		Id:      id,
+		Name:    name,
+		Kind:    DuplicateName,
+		Message: fmt.Sprintf("name is also used by %s", strings.Join(others, ", ")),
+	}}
+}
+
+func (c *Checker) checkLabels(objectType protoreflect.FullName, object proto.Message) []*Problem {
+	var problems []*Problem
+	var labels map[string]string
+	metadata := getMetadata(object)
+	if metadata != nil {
+		labels = metadata.GetLabels()
+	}
+	for _, rule := range c.rules.RequiredLabels {
+		if len(rule.Types) > 0 && !slices.Contains(rule.Types, string(objectType)) {
+			continue
+		}
+		_, ok := labels[rule.Key]
+		if ok {
+			continue
+		}
+		problems = append(problems, &Problem{
+			Type:    objectType,
+			Id:      getId(object),
+			Name:    getName(object),
+			Kind:    MissingLabel,
+			Message: fmt.Sprintf("doesn't have the required label '%s'", rule.Key),
+		})
+	}
+	return problems
+}
+
+func (c *Checker) checkReferences(ctx context.Context, objectType protoreflect.FullName, object proto.Message,
+	inventory Inventory) []*Problem {
+	var problems []*Problem
+	for _, reference := range References {
+		if reference.Source != objectType {
+			continue
+		}
+		targets, ok := inventory[reference.Target]
+		if !ok {
+			c.logger.DebugContext(
+				ctx,
+				"Skipping reference because the target type isn't in the inventory",
+				slog.String("source", string(reference.Source)),
+				slog.String("target", string(reference.Target)),
+			)
+			continue
+		}
+		values := map[string]string{}
+		collectReferences(object.ProtoReflect(), reference.Path, nil, values)
+		for _, path := range slices.Sorted(maps.Keys(values)) {
+			value := values[path]
+			if containsReference(targets, value) {
+				continue
+			}
+			problems = append(problems, &Problem{
+				Type: objectType,
+				Id:   getId(object),
+				Name: getName(object),
+				Kind: DanglingReference,
+				Message: fmt.Sprintf(
+					"field '%s' references %s '%s' that doesn't exist",
+					path, reference.Target.Name(), value,
+				),
+			})
+		}
+	}
+	return problems
+}
+
+// collectReferences walks the given path and adds to the result the non empty values found, indexed by the actual
+// path, where the map wildcards have been replaced by the keys.
+func collectReferences(message protoreflect.Message, path []string, actual []string, result map[string]string) {
+	field := message.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if field == nil || !message.Has(field) {
+		return
+	}
+	actual = append(slices.Clone(actual), path[0])
+	value := message.Get(field)
+	rest := path[1:]
+	if field.IsMap() && len(rest) > 0 && rest[0] == "*" {
+		value.Map().Range(func(key protoreflect.MapKey, item protoreflect.Value) bool {
+			itemPath := append(slices.Clone(actual), key.String())
+			if len(rest) == 1 {
+				result[strings.Join(itemPath, ".")] = item.String()
+			} else if field.MapValue().Message() != nil {
+				collectReferences(item.Message(), rest[1:], itemPath, result)
+			}
+			return true
+		})
+		return
+	}
+	if len(rest) == 0 {
+		if field.Kind() == protoreflect.StringKind && value.String() != "" {
+			result[strings.Join(actual, ".")] = value.String()
+		}
+		return
+	}
+	if field.Message() != nil && !field.IsList() && !field.IsMap() {
+		collectReferences(value.Message(), rest, actual, result)
+	}
+}
+
+func containsReference(objects []proto.Message, value string) bool {
+	for _, object := range objects {
+		if getId(object) == value || getName(object) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func getId(object proto.Message) string {
+	message := object.ProtoReflect()
+	field := message.Descriptor().Fields().ByName("id")
+	if field == nil {
+		return ""
+	}
+	return message.Get(field).String()
+}
+
+func getName(object proto.Message) string {
+	metadata := getMetadata(object)
+	if metadata == nil {
+		return ""
+	}
+	return metadata.GetName()
+}
+
+func getMetadata(object proto.Message) reflection.Metadata {
+	message := object.ProtoReflect()
+	field := message.Descriptor().Fields().ByName("metadata")
+	if field == nil || field.Message() == nil || !message.Has(field) {
+		return nil
+	}
+	metadata, _ := message.Get(field).Message().Interface().(reflection.Metadata)
+	return metadata
+}
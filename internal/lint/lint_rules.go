@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rules contains the policy rules that the checker enforces in addition to the built-in checks. They are usually
+// loaded from a YAML file like this:
+//
+//	required_labels:
+//	- key: team
+//	- key: cost-center
+//	  types:
+//	  - clusters
+//	  - computeinstances
+type Rules struct {
+	// RequiredLabels is the list of labels that objects must have.
+	RequiredLabels []*RequiredLabel `yaml:"required_labels"`
+}
+
+// RequiredLabel describes a label that objects must have.
+type RequiredLabel struct {
+	// Key is the key of the label.
+	Key string `yaml:"key"`
+
+	// Types is the list of object types that must have the label. If empty all the types must have it. In the file
+	// the types can be given in singular, plural or with the full name, but the command replaces them with the full
+	// names before using the rules.
+	Types []string `yaml:"types"`
+}
+
+// LoadRules loads the rules from the given YAML file. It fails if the file contains unknown fields or labels without
+// a key.
+func LoadRules(file string) (result *Rules, err error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		err = fmt.Errorf("failed to read rules file '%s': %w", file, err)
+		return
+	}
+	rules := &Rules{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(rules)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("failed to parse rules file '%s': %w", file, err)
+		return
+	}
+	for i, label := range rules.RequiredLabels {
+		if label == nil || label.Key == "" {
+			err = fmt.Errorf("required label %d of rules file '%s' doesn't have a key", i, file)
+			return
+		}
+	}
+	result = rules
+	err = nil
+	return
+}
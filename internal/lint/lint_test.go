@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var _ = Describe("Checker", func() {
+	var ctx context.Context
+
+	// Full names of the types used in the tests:
+	var (
+		clusterType  = (*ffv1.Cluster)(nil).ProtoReflect().Descriptor().FullName()
+		templateType = (*ffv1.ClusterTemplate)(nil).ProtoReflect().Descriptor().FullName()
+		classType    = (*ffv1.HostClass)(nil).ProtoReflect().Descriptor().FullName()
+	)
+
+	// makeCluster creates a cluster with the given identifier, name, labels and template.
+	makeCluster := func(id, name string, labels map[string]string, template string) *ffv1.Cluster {
+		return ffv1.Cluster_builder{
+			Id: id,
+			Metadata: sharedv1.Metadata_builder{
+				Name:   name,
+				Labels: labels,
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				Template: template,
+			}.Build(),
+		}.Build()
+	}
+
+	// makeTemplate creates a cluster template with the given identifier.
+	makeTemplate := func(id string) *ffv1.ClusterTemplate {
+		return ffv1.ClusterTemplate_builder{
+			Id: id,
+		}.Build()
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("Can't be created without a logger", func() {
+		checker, err := NewChecker().Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+		Expect(checker).To(BeNil())
+	})
+
+	It("Finds duplicate names", func() {
+		checker, err := NewChecker().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		inventory := Inventory{
+			clusterType: {
+				makeCluster("1", "my", nil, ""),
+				makeCluster("2", "my", nil, ""),
+				makeCluster("3", "your", nil, ""),
+			},
+		}
+		problems := checker.Check(ctx, inventory, []protoreflect.FullName{clusterType})
+		Expect(problems).To(ConsistOf(
+			&Problem{
+				Type:    clusterType,
+				Id:      "1",
+				Name:    "my",
+				Kind:    DuplicateName,
+				Message: "name is also used by '2'",
+			},
+			&Problem{
+				Type:    clusterType,
+				Id:      "2",
+				Name:    "my",
+				Kind:    DuplicateName,
+				Message: "name is also used by '1'",
+			},
+		))
+	})
+
+	It("Finds missing labels", func() {
+		checker, err := NewChecker().
+			SetLogger(logger).
+			SetRules(&Rules{
+				RequiredLabels: []*RequiredLabel{
+					{
+						Key: "team",
+					},
+					{
+						Key:   "cost-center",
+						Types: []string{string(templateType)},
+					},
+				},
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		inventory := Inventory{
+			clusterType: {
+				makeCluster("1", "a", map[string]string{"team": "blue"}, ""),
+				makeCluster("2", "b", nil, ""),
+			},
+		}
+		problems := checker.Check(ctx, inventory, []protoreflect.FullName{clusterType})
+		Expect(problems).To(ConsistOf(
+			&Problem{
+				Type:    clusterType,
+				Id:      "2",
+				Name:    "b",
+				Kind:    MissingLabel,
+				Message: "doesn't have the required label 'team'",
+			},
+		))
+	})
+
+	It("Finds dangling references", func() {
+		checker, err := NewChecker().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		inventory := Inventory{
+			clusterType: {
+				makeCluster("1", "a", nil, "small"),
+				makeCluster("2", "b", nil, "junk"),
+			},
+			templateType: {
+				makeTemplate("small"),
+			},
+		}
+		problems := checker.Check(ctx, inventory, []protoreflect.FullName{clusterType})
+		Expect(problems).To(ConsistOf(
+			&Problem{
+				Type:    clusterType,
+				Id:      "2",
+				Name:    "b",
+				Kind:    DanglingReference,
+				Message: "field 'spec.template' references ClusterTemplate 'junk' that doesn't exist",
+			},
+		))
+	})
+
+	It("Finds dangling references inside maps", func() {
+		checker, err := NewChecker().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		cluster := ffv1.Cluster_builder{
+			Id: "1",
+			Spec: ffv1.ClusterSpec_builder{
+				NodeSets: map[string]*ffv1.ClusterNodeSet{
+					"workers": ffv1.ClusterNodeSet_builder{
+						HostClass: "junk",
+					}.Build(),
+					"masters": ffv1.ClusterNodeSet_builder{
+						HostClass: "large",
+					}.Build(),
+				},
+			}.Build(),
+		}.Build()
+		inventory := Inventory{
+			clusterType: {cluster},
+			classType: {
+				ffv1.HostClass_builder{
+					Id: "large",
+				}.Build(),
+			},
+		}
+		problems := checker.Check(ctx, inventory, []protoreflect.FullName{clusterType})
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(Equal(
+			"field 'spec.node_sets.workers.host_class' references HostClass 'junk' that doesn't exist",
+		))
+	})
+
+	It("Accepts references by name", func() {
+		checker, err := NewChecker().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		template := ffv1.ClusterTemplate_builder{
+			Id: "123",
+			Metadata: sharedv1.Metadata_builder{
+				Name: "small",
+			}.Build(),
+		}.Build()
+		inventory := Inventory{
+			clusterType:  {makeCluster("1", "a", nil, "small")},
+			templateType: {template},
+		}
+		problems := checker.Check(ctx, inventory, []protoreflect.FullName{clusterType})
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Doesn't check references when the target type isn't in the inventory", func() {
+		checker, err := NewChecker().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		inventory := Inventory{
+			clusterType: {makeCluster("1", "a", nil, "junk")},
+		}
+		problems := checker.Check(ctx, inventory, []protoreflect.FullName{clusterType})
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Only checks the requested types", func() {
+		checker, err := NewChecker().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		inventory := Inventory{
+			clusterType: {
+				makeCluster("1", "my", nil, ""),
+				makeCluster("2", "my", nil, ""),
+			},
+		}
+		problems := checker.Check(ctx, inventory, []protoreflect.FullName{templateType})
+		Expect(problems).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Rules", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	// writeRules writes the given text to a rules file and returns its path.
+	writeRules := func(text string) string {
+		file := filepath.Join(dir, "rules.yaml")
+		err := os.WriteFile(file, []byte(text), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		return file
+	}
+
+	It("Loads the required labels", func() {
+		file := writeRules("required_labels:\n- key: team\n- key: cost-center\n  types: [clusters]\n")
+		rules, err := LoadRules(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules.RequiredLabels).To(HaveLen(2))
+		Expect(rules.RequiredLabels[0].Key).To(Equal("team"))
+		Expect(rules.RequiredLabels[0].Types).To(BeEmpty())
+		Expect(rules.RequiredLabels[1].Key).To(Equal("cost-center"))
+		Expect(rules.RequiredLabels[1].Types).To(Equal([]string{"clusters"}))
+	})
+
+	It("Accepts an empty file", func() {
+		rules, err := LoadRules(writeRules(""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules.RequiredLabels).To(BeEmpty())
+	})
+
+	It("Rejects unknown fields", func() {
+		_, err := LoadRules(writeRules("required_label:\n- key: team\n"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field required_label not found"))
+	})
+
+	It("Rejects labels without key", func() {
+		_, err := LoadRules(writeRules("required_labels:\n- types: [clusters]\n"))
+		Expect(err).To(MatchError(ContainSubstring("required label 0 of rules file")))
+	})
+})
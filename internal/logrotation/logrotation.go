@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package logrotation contains the logic used to rotate the log file of the tool, so that it doesn't grow forever.
+package logrotation
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy describes when the log file should be rotated, and how many rotated files should be kept.
+type Policy struct {
+	// MaxSize is the size, in bytes, that causes the rotation of the file. Zero means that the size is not checked.
+	MaxSize int64
+
+	// MaxAge is the age of the first message of the file that causes the rotation of the file. Zero means that the
+	// age is not checked.
+	MaxAge time.Duration
+
+	// MaxFiles is the number of rotated files that are kept. The rotated files have the same name than the log file
+	// with a numeric suffix, for example 'fulfillment-cli.log.1' is the most recent.
+	MaxFiles int
+}
+
+// DefaultPolicy is the policy used when the configuration doesn't change it.
+var DefaultPolicy = Policy{
+	MaxSize:  10 * 1024 * 1024,
+	MaxAge:   7 * 24 * time.Hour,
+	MaxFiles: 5,
+}
+
+// Rotate checks if the given log file should be rotated according to the given policy, and if so renames it adding
+// the '.1' suffix, shifting the suffixes of the files rotated before, and removing the ones that exceed the number of
+// files to keep. It returns a flag indicating if the file was rotated. It is intended to be called before the log file
+// is opened.
+func Rotate(file string, policy Policy) (rotated bool, err error) {
+	// Check if the file needs to be rotated:
+	info, err := os.Stat(file)
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to check log file '%s': %w", file, err)
+		return
+	}
+	if info.Size() == 0 {
+		return
+	}
+	expired := false
+	if policy.MaxAge > 0 {
+		var start time.Time
+		start, err = firstTime(file)
+		if err != nil {
+			return
+		}
+		expired = !start.IsZero() && time.Since(start) >= policy.MaxAge
+	}
+	tooLarge := policy.MaxSize > 0 && info.Size() >= policy.MaxSize
+	if !expired && !tooLarge {
+		return
+	}
+
+	// Remove the rotated files that exceed the limit, including the one that will be displaced by the shift:
+	err = removeExcess(file, policy.MaxFiles)
+	if err != nil {
+		return
+	}
+
+	// Shift the suffixes of the rotated files and then rename the current one:
+	for i := policy.MaxFiles - 1; i >= 1; i-- {
+		err = os.Rename(rotatedName(file, i), rotatedName(file, i+1))
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to rename rotated log file: %w", err)
+			return
+		}
+	}
+	if policy.MaxFiles > 0 {
+		err = os.Rename(file, rotatedName(file, 1))
+	} else {
+		err = os.Remove(file)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to rotate log file '%s': %w", file, err)
+		return
+	}
+	rotated = true
+	return
+}
+
+// removeExcess removes the rotated files whose suffix is greater or equal than the given limit, as those would exceed
+// the number of files to keep after the shift.
+func removeExcess(file string, limit int) error {
+	matches, err := filepath.Glob(file + ".*")
+	if err != nil {
+		return err
+	}
+	prefix := file + "."
+	for _, match := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(match, prefix))
+		if err != nil || index < limit {
+			continue
+		}
+		err = os.Remove(match)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove rotated log file '%s': %w", match, err)
+		}
+	}
+	return nil
+}
+
+// firstTime returns the time of the first message of the log file. The messages are JSON objects, one per line, with
+// the time in the 'time' field. If the first message can't be parsed it returns the zero time.
+func firstTime(file string) (result time.Time, err error) {
+	reader, err := os.Open(file)
+	if err != nil {
+		err = fmt.Errorf("failed to open log file '%s': %w", file, err)
+		return
+	}
+	defer reader.Close()
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, 1024*1024)
+	if !scanner.Scan() {
+		return
+	}
+	var message struct {
+		Time time.Time `json:"time"`
+	}
+	if json.Unmarshal(scanner.Bytes(), &message) == nil {
+		result = message.Time
+	}
+	return
+}
+
+func rotatedName(file string, index int) string {
+	return fmt.Sprintf("%s.%d", file, index)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package logrotation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rotate", func() {
+	var (
+		dir  string
+		file string
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		file = filepath.Join(dir, "my.log")
+	})
+
+	// writeLog writes a log file containing one message with the given time followed by the given number of bytes.
+	writeLog := func(path string, start time.Time, size int) {
+		line := fmt.Sprintf("{\"time\":%q}\n", start.Format(time.RFC3339))
+		data := append([]byte(line), make([]byte, size)...)
+		err := os.WriteFile(path, data, 0600)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	readLog := func(path string) string {
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("Does nothing if the file doesn't exist", func() {
+		rotated, err := Rotate(file, DefaultPolicy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rotated).To(BeFalse())
+	})
+
+	It("Does nothing if the file is small and recent", func() {
+		writeLog(file, time.Now(), 10)
+		rotated, err := Rotate(file, DefaultPolicy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rotated).To(BeFalse())
+		Expect(file).To(BeAnExistingFile())
+	})
+
+	It("Rotates the file when it is too large", func() {
+		writeLog(file, time.Now(), 100)
+		rotated, err := Rotate(file, Policy{
+			MaxSize:  50,
+			MaxFiles: 2,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rotated).To(BeTrue())
+		Expect(file).ToNot(BeAnExistingFile())
+		Expect(file + ".1").To(BeAnExistingFile())
+	})
+
+	It("Rotates the file when the first message is too old", func() {
+		writeLog(file, time.Now().Add(-2*time.Hour), 0)
+		rotated, err := Rotate(file, Policy{
+			MaxAge:   time.Hour,
+			MaxFiles: 2,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rotated).To(BeTrue())
+		Expect(file + ".1").To(BeAnExistingFile())
+	})
+
+	It("Shifts the rotated files and keeps only the given number", func() {
+		for i, name := range []string{file, file + ".1", file + ".2", file + ".3"} {
+			writeLog(name, time.Now().Add(-time.Duration(i)*time.Minute), 100)
+		}
+		current := readLog(file)
+		previous := readLog(file + ".1")
+		rotated, err := Rotate(file, Policy{
+			MaxSize:  50,
+			MaxFiles: 2,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rotated).To(BeTrue())
+		Expect(file).ToNot(BeAnExistingFile())
+		Expect(readLog(file + ".1")).To(Equal(current))
+		Expect(readLog(file + ".2")).To(Equal(previous))
+		Expect(file + ".3").ToNot(BeAnExistingFile())
+	})
+
+	It("Removes the file when no rotated files should be kept", func() {
+		writeLog(file, time.Now(), 100)
+		rotated, err := Rotate(file, Policy{
+			MaxSize: 50,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rotated).To(BeTrue())
+		entries, err := os.ReadDir(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+})
@@ -0,0 +1,305 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package macros contains the logic used to expand the CEL macros defined by the user in the configuration file. A
+// macro is a name associated to a CEL expression, and the occurrences of that name in filters and in the expressions
+// of the columns are replaced by the expression, so that frequently used conditions don't need to be repeated.
+package macros
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ExpanderBuilder contains the data and logic needed to create an expander. Don't create objects of this type
+// directly, use the NewExpander function instead.
+type ExpanderBuilder struct {
+	logger *slog.Logger
+	macros map[string]string
+}
+
+// Expander replaces the names of macros in CEL expressions with the expressions that they stand for. Don't create
+// objects of this type directly, use the NewExpander function instead.
+type Expander struct {
+	logger *slog.Logger
+	macros map[string]string
+}
+
+// NewExpander creates a builder that can then be used to configure and create an expander.
+func NewExpander() *ExpanderBuilder {
+	return &ExpanderBuilder{}
+}
+
+// SetLogger sets the logger that the expander will use to write to the log. This is mandatory.
+func (b *ExpanderBuilder) SetLogger(value *slog.Logger) *ExpanderBuilder {
+	b.logger = value
+	return b
+}
+
+// SetMacros sets the macros, where the keys of the map are the names and the values are the CEL expressions. The
+// expressions can use other macros. This is optional, without macros expressions are returned unchanged.
+func (b *ExpanderBuilder) SetMacros(value map[string]string) *ExpanderBuilder {
+	b.macros = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new expander.
+func (b *ExpanderBuilder) Build() (result *Expander, err error) {
+	// Check the parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(b.macros)) {
+		err = checkName(name)
+		if err != nil {
+			return
+		}
+		if strings.TrimSpace(b.macros[name]) == "" {
+			err = fmt.Errorf("expression of macro '%s' is empty", name)
+			return
+		}
+	}
+
+	// Create the expander:
+	expander := &Expander{
+		logger: b.logger,
+		macros: maps.Clone(b.macros),
+	}
+
+	// Expand all the macros once, so that cycles are detected here and not when they are first used:
+	for _, name := range slices.Sorted(maps.Keys(expander.macros)) {
+		_, err = expander.expand(expander.macros[name], []string{name})
+		if err != nil {
+			err = fmt.Errorf("failed to expand macro '%s': %w", name, err)
+			return
+		}
+	}
+
+	result = expander
+	return
+}
+
+// Names returns the names of the macros, sorted alphabetically.
+func (e *Expander) Names() []string {
+	return slices.Sorted(maps.Keys(e.macros))
+}
+
+// Expand replaces the names of the macros that appear in the given CEL expression with the expressions that they
+// stand for, surrounded by parenthesis. Names that are used to select fields, like 'ready' in 'this.status.ready',
+// names that are called as functions, and the content of string literals are never replaced.
+func (e *Expander) Expand(expr string) (result string, err error) {
+	if e == nil || len(e.macros) == 0 {
+		result = expr
+		return
+	}
+	result, err = e.expand(expr, nil)
+	if err != nil {
+		return
+	}
+	if result != expr {
+		e.logger.Debug(
+			"Expanded macros",
+			slog.String("expression", expr),
+			slog.String("result", result),
+		)
+	}
+	return
+}
+
+// expand does the actual expansion. The stack contains the names of the macros that are being expanded, and is used
+// to detect cycles.
+func (e *Expander) expand(expr string, stack []string) (result string, err error) {
+	buffer := &strings.Builder{}
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := skipString(expr, i)
+			buffer.WriteString(expr[i:end])
+			i = end
+		case c == '/' && i+1 < len(expr) && expr[i+1] == '/':
+			end := strings.IndexByte(expr[i:], '\n')
+			if end == -1 {
+				end = len(expr)
+			} else {
+				end += i
+			}
+			buffer.WriteString(expr[i:end])
+			i = end
+		case isDigit(c):
+			end := i
+			for end < len(expr) && (isIdentChar(expr[end]) || expr[end] == '.') {
+				end++
+			}
+			buffer.WriteString(expr[i:end])
+			i = end
+		case isIdentStart(c):
+			end := i
+			for end < len(expr) && isIdentChar(expr[end]) {
+				end++
+			}
+			name := expr[i:end]
+			macro, ok := e.macros[name]
+			if !ok || isSelection(expr, i) || isCall(expr, end) || isStringPrefix(expr, name, end) {
+				buffer.WriteString(name)
+				i = end
+				continue
+			}
+			if slices.Contains(stack, name) {
+				err = fmt.Errorf(
+					"macro '%s' uses itself: %s",
+					name, strings.Join(append(stack, name), " -> "),
+				)
+				return
+			}
+			var expanded string
+			expanded, err = e.expand(macro, append(stack, name))
+			if err != nil {
+				return
+			}
+			buffer.WriteString("(")
+			buffer.WriteString(expanded)
+			buffer.WriteString(")")
+			i = end
+		default:
+			buffer.WriteByte(c)
+			i++
+		}
+	}
+	result = buffer.String()
+	return
+}
+
+// skipString returns the position right after the end of the string literal that starts at the given position,
+// taking into account triple quoted strings and escape sequences. If the literal isn't terminated it returns the
+// length of the expression, and the error will be reported by the CEL compiler.
+func skipString(expr string, start int) int {
+	quote := expr[start : start+1]
+	if strings.HasPrefix(expr[start:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	i := start + len(quote)
+	for i < len(expr) {
+		if expr[i] == '\\' {
+			i += 2
+			continue
+		}
+		if strings.HasPrefix(expr[i:], quote) {
+			return i + len(quote)
+		}
+		i++
+	}
+	return len(expr)
+}
+
+// isSelection checks if the identifier that starts at the given position is preceded by a dot, and is therefore the
+// name of a field or of a method.
+func isSelection(expr string, start int) bool {
+	i := start - 1
+	for i >= 0 && isSpace(expr[i]) {
+		i--
+	}
+	return i >= 0 && expr[i] == '.'
+}
+
+// isCall checks if the identifier that ends at the given position is followed by an opening parenthesis, and is
+// therefore the name of a function.
+func isCall(expr string, end int) bool {
+	i := end
+	for i < len(expr) && isSpace(expr[i]) {
+		i++
+	}
+	return i < len(expr) && expr[i] == '('
+}
+
+// isStringPrefix checks if the identifier is one of the prefixes of raw and bytes string literals, like the 'r' in
+// r'...'.
+func isStringPrefix(expr string, name string, end int) bool {
+	if end >= len(expr) || (expr[end] != '"' && expr[end] != '\'') {
+		return false
+	}
+	switch strings.ToLower(name) {
+	case "r", "b", "rb", "br":
+		return true
+	default:
+		return false
+	}
+}
+
+// checkName checks that the given name can be used for a macro.
+func checkName(name string) error {
+	if !nameRE.MatchString(name) {
+		return fmt.Errorf(
+			"name of macro '%s' isn't valid, it should start with a letter and contain only letters, digits "+
+				"and underscores",
+			name,
+		)
+	}
+	if slices.Contains(reservedNames, name) {
+		return fmt.Errorf("name of macro '%s' is reserved", name)
+	}
+	return nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+// nameRE is the regular expression that the names of the macros should match.
+var nameRE = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// reservedNames are the names that can't be used for macros because they are CEL keywords, or because they are the
+// variables that the CLI defines for the expressions.
+var reservedNames = []string{
+	"as",
+	"break",
+	"const",
+	"continue",
+	"else",
+	"false",
+	"for",
+	"function",
+	"if",
+	"import",
+	"in",
+	"let",
+	"loop",
+	"null",
+	"package",
+	"namespace",
+	"return",
+	"this",
+	"true",
+	"var",
+	"void",
+	"while",
+}
@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package macros
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expander", func() {
+	var expander *Expander
+
+	BeforeEach(func() {
+		var err error
+		expander, err = NewExpander().
+			SetLogger(logger).
+			SetMacros(map[string]string{
+				"ready":      "this.status.state == CLUSTER_STATE_READY",
+				"prod":       "this.metadata.labels['env'] == 'prod'",
+				"ready_prod": "ready && prod",
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable(
+		"Expands expressions",
+		func(expr string, expected string) {
+			actual, err := expander.Expand(expr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+		Entry(
+			"No macros",
+			"this.metadata.name == 'my'",
+			"this.metadata.name == 'my'",
+		),
+		Entry(
+			"One macro",
+			"ready",
+			"(this.status.state == CLUSTER_STATE_READY)",
+		),
+		Entry(
+			"Macro combined with other conditions",
+			"!ready && this.metadata.name == 'my'",
+			"!(this.status.state == CLUSTER_STATE_READY) && this.metadata.name == 'my'",
+		),
+		Entry(
+			"Macro that uses other macros",
+			"ready_prod",
+			"((this.status.state == CLUSTER_STATE_READY) && (this.metadata.labels['env'] == 'prod'))",
+		),
+		Entry(
+			"Field with the name of a macro",
+			"this.status.ready",
+			"this.status.ready",
+		),
+		Entry(
+			"Field with the name of a macro after spaces",
+			"this.status. ready",
+			"this.status. ready",
+		),
+		Entry(
+			"Function with the name of a macro",
+			"ready(this)",
+			"ready(this)",
+		),
+		Entry(
+			"Single quoted string",
+			"this.metadata.name == 'ready'",
+			"this.metadata.name == 'ready'",
+		),
+		Entry(
+			"Double quoted string with escaped quote",
+			`this.metadata.name == "\"ready\"" || ready`,
+			`this.metadata.name == "\"ready\"" || (this.status.state == CLUSTER_STATE_READY)`,
+		),
+		Entry(
+			"Triple quoted string",
+			`this.metadata.name == '''it's ready''' || ready`,
+			`this.metadata.name == '''it's ready''' || (this.status.state == CLUSTER_STATE_READY)`,
+		),
+		Entry(
+			"Raw string",
+			`this.metadata.name == r'ready'`,
+			`this.metadata.name == r'ready'`,
+		),
+		Entry(
+			"Comment",
+			"ready // ready",
+			"(this.status.state == CLUSTER_STATE_READY) // ready",
+		),
+		Entry(
+			"Identifier containing the name of a macro",
+			"already",
+			"already",
+		),
+	)
+
+	It("Returns the expression unchanged when there are no macros", func() {
+		expander, err := NewExpander().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		actual, err := expander.Expand("ready")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(Equal("ready"))
+	})
+
+	It("Returns the names sorted", func() {
+		Expect(expander.Names()).To(Equal([]string{"prod", "ready", "ready_prod"}))
+	})
+
+	DescribeTable(
+		"Rejects invalid macros",
+		func(macros map[string]string, expected string) {
+			_, err := NewExpander().
+				SetLogger(logger).
+				SetMacros(macros).
+				Build()
+			Expect(err).To(MatchError(expected))
+		},
+		Entry(
+			"Invalid name",
+			map[string]string{
+				"my-macro": "true",
+			},
+			"name of macro 'my-macro' isn't valid, it should start with a letter and contain only letters, "+
+				"digits and underscores",
+		),
+		Entry(
+			"Reserved name",
+			map[string]string{
+				"this": "true",
+			},
+			"name of macro 'this' is reserved",
+		),
+		Entry(
+			"Empty expression",
+			map[string]string{
+				"ready": " ",
+			},
+			"expression of macro 'ready' is empty",
+		),
+		Entry(
+			"Macro that uses itself",
+			map[string]string{
+				"ready": "ready && true",
+			},
+			"failed to expand macro 'ready': macro 'ready' uses itself: ready -> ready",
+		),
+		Entry(
+			"Macros that use each other",
+			map[string]string{
+				"a": "b",
+				"b": "a",
+			},
+			"failed to expand macro 'a': macro 'a' uses itself: a -> b -> a",
+		),
+	)
+
+	It("Can't be created without a logger", func() {
+		_, err := NewExpander().Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+})
@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats supported by the Encode function:
+const (
+	FormatYaml = "yaml"
+	FormatJson = "json"
+)
+
+// FlagName is the name of the command line flag that enables saving manifests.
+const FlagName = "save-manifest"
+
+// AddFlag adds to the given flag set the flag that specifies the directory where manifests will be saved.
+func AddFlag(flags *pflag.FlagSet, value *string) {
+	flags.StringVar(
+		value,
+		FlagName,
+		"",
+		"Directory where the object sent to the server will be saved as a YAML manifest, so that it can be "+
+			"created again later with 'create --filename'.",
+	)
+}
+
+// Save writes the given object to a YAML file in the given directory, creating the directory if needed. The content
+// is the same that the 'create --filename' command accepts. The name of the file is calculated from the type of the
+// object and its name, or the given identifier if the object has no name. This is intended for objects that have
+// just been created, as the identifier is assigned by the server and isn't part of the object that was sent.
+func Save(dir string, object proto.Message, id string) (result string, err error) {
+	// Convert the object to YAML:
+	buffer := &bytes.Buffer{}
+	err = Encode(buffer, []proto.Message{object}, FormatYaml)
+	if err != nil {
+		return
+	}
+	data := buffer.Bytes()
+
+	// Write the file. Note that the permissions are restrictive because some objects contain sensitive data, like
+	// the kubeconfig of hubs.
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		err = fmt.Errorf("failed to create manifest directory '%s': %w", dir, err)
+		return
+	}
+	file := filepath.Join(dir, fileName(object, id))
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		err = fmt.Errorf("failed to write manifest file '%s': %w", file, err)
+		return
+	}
+	result = file
+	return
+}
+
+// Encode writes the given objects in the given format, using the representation that the Decode function accepts. In
+// the YAML format each object is a separate document. In the JSON format a single object is written as is, and
+// multiple objects are written as a list.
+func Encode(writer io.Writer, objects []proto.Message, format string) error {
+	// Convert the objects to generic values:
+	values := make([]any, len(objects))
+	for i, object := range objects {
+		value, err := toValue(object)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+
+	// Write the values:
+	switch format {
+	case FormatYaml:
+		encoder := yaml.NewEncoder(writer)
+		for _, value := range values {
+			err := encoder.Encode(value)
+			if err != nil {
+				return fmt.Errorf("failed to convert object to YAML: %w", err)
+			}
+		}
+		return encoder.Close()
+	case FormatJson:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		var err error
+		if len(values) == 1 {
+			err = encoder.Encode(values[0])
+		} else {
+			err = encoder.Encode(values)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to convert objects to JSON: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format '%s', should be '%s' or '%s'", format, FormatYaml, FormatJson)
+	}
+}
+
+// toValue converts the object to a generic value. This goes via the any type so that the result contains the '@type'
+// field that is needed to decode it later.
+func toValue(object proto.Message) (result any, err error) {
+	wrapper, err := anypb.New(object)
+	if err != nil {
+		err = fmt.Errorf("failed to wrap object: %w", err)
+		return
+	}
+	data, err := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}.Marshal(wrapper)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal object: %w", err)
+		return
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal object: %w", err)
+		return
+	}
+	return
+}
+
+// fileName calculates the name of the manifest file, for example 'cluster-my-cluster.yaml'.
+func fileName(object proto.Message, id string) string {
+	message := object.ProtoReflect()
+	name := ""
+	metadataField := message.Descriptor().Fields().ByName("metadata")
+	if metadataField != nil && metadataField.Message() != nil && message.Has(metadataField) {
+		metadata := message.Get(metadataField).Message()
+		nameField := metadata.Descriptor().Fields().ByName("name")
+		if nameField != nil {
+			name = metadata.Get(nameField).String()
+		}
+	}
+	if name == "" {
+		name = id
+	}
+	name = strings.ReplaceAll(name, string(filepath.Separator), "_")
+	kind := strings.ToLower(string(message.Descriptor().Name()))
+	return fmt.Sprintf("%s-%s.yaml", kind, name)
+}
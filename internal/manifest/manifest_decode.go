@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
+)
+
+// Decode reads the given input, which may contain multiple YAML or JSON documents, each of them being a single object
+// or a list, and returns the corresponding list of protocol buffers messages. This is the format that the 'create
+// --filename' command accepts, and that the Save function generates.
+func Decode(input io.Reader) (result []proto.Message, err error) {
+	// Parse the input file assuming it is a YAML file. As JSON is a subset of YAML, this will also work for JSON.
+	decoder := yaml.NewDecoder(input)
+	var items []any
+	for {
+		var item any
+		err = decoder.Decode(&item)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return
+		}
+		items = append(items, item)
+	}
+
+	// Items may be a single object or a list of objects. Those that are a list need to be converted to single
+	// objects.
+	list := make([]any, 0, len(items))
+	for _, item := range items {
+		switch item := item.(type) {
+		case []any:
+			list = append(list, item...)
+		default:
+			list = append(list, item)
+		}
+	}
+
+	// We assume that input objects are protocol buffers any objects, and we need to convert them to the
+	// appropriate type.
+	objects := make([]proto.Message, len(list))
+	for i, item := range list {
+		var data []byte
+		data, err = json.Marshal(item)
+		if err != nil {
+			err = fmt.Errorf("failed to convert item at index %d to JSON: %w", i, err)
+			return
+		}
+		value := &anypb.Any{}
+		err = protojson.Unmarshal(data, value)
+		if err != nil {
+			err = fmt.Errorf(
+				"failed to unmarshal item at index %d to a protocol buffers any: %w",
+				i, err,
+			)
+			return
+		}
+		var object proto.Message
+		object, err = value.UnmarshalNew()
+		if err != nil {
+			err = fmt.Errorf(
+				"failed to unmarshal object at index %d to a protocol buffers object: %w",
+				i, err,
+			)
+			return
+		}
+		objects[i] = object
+	}
+
+	result = objects
+	return
+}
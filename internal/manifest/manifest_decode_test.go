@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var _ = Describe("Decode", func() {
+	It("Decodes multiple documents and lists", func() {
+		objects, err := Decode(strings.NewReader(`
+"@type": type.googleapis.com/fulfillment.v1.Cluster
+id: "123"
+---
+- "@type": type.googleapis.com/fulfillment.v1.ClusterTemplate
+  id: my-template
+- "@type": type.googleapis.com/fulfillment.v1.HostClass
+  id: my-class
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objects).To(HaveLen(3))
+		Expect(proto.MessageName(objects[0])).To(BeEquivalentTo("fulfillment.v1.Cluster"))
+		Expect(proto.MessageName(objects[1])).To(BeEquivalentTo("fulfillment.v1.ClusterTemplate"))
+		Expect(proto.MessageName(objects[2])).To(BeEquivalentTo("fulfillment.v1.HostClass"))
+	})
+
+	It("Decodes the files generated by Save", func() {
+		cluster := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+			}.Build(),
+		}.Build()
+		file, err := Save(filepath.Join(GinkgoT().TempDir(), "manifests"), cluster, "123")
+		Expect(err).ToNot(HaveOccurred())
+		reader, err := os.Open(file)
+		Expect(err).ToNot(HaveOccurred())
+		defer reader.Close()
+		objects, err := Decode(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+		Expect(objects[0]).To(BeComparableTo(cluster, protocmp.Transform()))
+	})
+
+	It("Fails if the type is missing", func() {
+		_, err := Decode(strings.NewReader(`id: "123"`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	privatev1 "github.com/osac-project/fulfillment-common/api/private/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
+)
+
+var _ = Describe("Manifest", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = filepath.Join(GinkgoT().TempDir(), "manifests")
+	})
+
+	It("Saves the object with its type so that it can be decoded again", func() {
+		cluster := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "my-template",
+			}.Build(),
+		}.Build()
+		file, err := Save(dir, cluster, "123")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file).To(Equal(filepath.Join(dir, "cluster-my-cluster.yaml")))
+
+		// Check the permissions:
+		info, err := os.Stat(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		// Decode the file the same way that the create command does:
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		var value map[string]any
+		err = yaml.Unmarshal(data, &value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(HaveKeyWithValue("@type", "type.googleapis.com/fulfillment.v1.Cluster"))
+		Expect(value).ToNot(HaveKey("id"))
+		wrapper := &anypb.Any{}
+		data, err = yamlToJson(data)
+		Expect(err).ToNot(HaveOccurred())
+		err = protojson.Unmarshal(data, wrapper)
+		Expect(err).ToNot(HaveOccurred())
+		decoded, err := wrapper.UnmarshalNew()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proto.Equal(decoded, cluster)).To(BeTrue())
+	})
+
+	It("Uses the identifier when the object has no name", func() {
+		hub := privatev1.Hub_builder{
+			Id:        "my-hub",
+			Namespace: "my-ns",
+		}.Build()
+		file, err := Save(dir, hub, "my-hub")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filepath.Base(file)).To(Equal("hub-my-hub.yaml"))
+	})
+
+	It("Encodes objects so that they can be decoded again", func() {
+		objects := []proto.Message{
+			ffv1.Cluster_builder{
+				Metadata: sharedv1.Metadata_builder{
+					Name: "my-cluster",
+				}.Build(),
+			}.Build(),
+			ffv1.Host_builder{
+				Metadata: sharedv1.Metadata_builder{
+					Name: "my-host",
+				}.Build(),
+			}.Build(),
+		}
+		for _, format := range []string{FormatYaml, FormatJson} {
+			buffer := &bytes.Buffer{}
+			Expect(Encode(buffer, objects, format)).To(Succeed())
+			decoded, err := Decode(buffer)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decoded).To(HaveLen(2))
+			for i := range objects {
+				Expect(proto.Equal(decoded[i], objects[i])).To(BeTrue(), "format %s, object %d", format, i)
+			}
+		}
+	})
+
+	It("Rejects unknown formats", func() {
+		err := Encode(&bytes.Buffer{}, nil, "xml")
+		Expect(err).To(MatchError("unknown format 'xml', should be 'yaml' or 'json'"))
+	})
+})
+
+func yamlToJson(data []byte) (result []byte, err error) {
+	var value any
+	err = yaml.Unmarshal(data, &value)
+	if err != nil {
+		return
+	}
+	result, err = json.Marshal(value)
+	return
+}
@@ -0,0 +1,45 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"context"
+)
+
+// contextKey is the type used to store the output format in the context.
+type contextKey int
+
+const (
+	contextFormatKey contextKey = iota
+)
+
+// FormatFromContext returns the output format from the context, or the text format if the context doesn't contain
+// it.
+func FormatFromContext(ctx context.Context) string {
+	format, ok := ctx.Value(contextFormatKey).(string)
+	if !ok {
+		return FormatText
+	}
+	return format
+}
+
+// FormatIntoContext creates a new context that contains the given output format.
+func FormatIntoContext(ctx context.Context, format string) context.Context {
+	return context.WithValue(ctx, contextFormatKey, format)
+}
+
+// IsJson checks if the output format stored in the context is the machine readable JSON format.
+func IsJson(ctx context.Context) bool {
+	return FormatFromContext(ctx) == FormatJson
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package output contains the global flag that selects the output format, and the functions used by the commands to
+// write their results in the machine readable format.
+package output
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// flagName is the name of the flag that selects the output format.
+const flagName = "output"
+
+// Output formats supported by all the commands:
+const (
+	// FormatText is the default format, intended for humans.
+	FormatText = "text"
+
+	// FormatJson writes the results of the command as JSON to the standard output, and the errors as JSON to the
+	// standard error. Messages intended for humans are written to the standard error.
+	FormatJson = "json"
+)
+
+// AddFlags adds the flag that selects the output format to the given flag set. This is intended for the persistent
+// flags of the root command. Commands that have their own 'output' flag, like 'get', override this one, and then the
+// format is machine readable when the value of that flag is 'json'.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringP(
+		flagName,
+		"o",
+		FormatText,
+		fmt.Sprintf(
+			"Output format, '%s' for humans or '%s' to write results and errors in machine readable format.",
+			FormatText, FormatJson,
+		),
+	)
+}
+
+// FormatFromCommand returns the output format selected in the command line of the given command. Commands that have
+// their own 'output' flag may accept other values, and for those the value is returned without checking it.
+func FormatFromCommand(cmd *cobra.Command) (result string, err error) {
+	flag := cmd.Flags().Lookup(flagName)
+	if flag == nil {
+		result = FormatText
+		return
+	}
+	value := flag.Value.String()
+	if cmd.LocalNonPersistentFlags().Lookup(flagName) == nil && value != FormatText && value != FormatJson {
+		err = fmt.Errorf(
+			"unknown output format '%s', should be '%s' or '%s'",
+			value, FormatText, FormatJson,
+		)
+		return
+	}
+	result = value
+	return
+}
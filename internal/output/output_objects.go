@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"context"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// WriteObjects writes the given objects, the result of the command, to the console in JSON format, but only if that
+// is the output format stored in the context. Otherwise it does nothing, as commands report their results to humans
+// with messages.
+func WriteObjects(ctx context.Context, objects ...proto.Message) error {
+	if !IsJson(ctx) {
+		return nil
+	}
+	renderer, err := rendering.DefaultRegistry.Create(rendering.FormatJson, &rendering.Options{
+		Logger: logging.LoggerFromContext(ctx),
+		Writer: terminal.ConsoleFromContext(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	return renderer.Render(ctx, objects)
+}
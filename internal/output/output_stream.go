@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+// ErrInterrupted is the cause of the cancellation of the contexts created with InterruptContext when the process
+// receives an interrupt or termination signal.
+var ErrInterrupted = errors.New("interrupted")
+
+// TruncationMarker is the beginning of the line written at the end of a stream of JSON or YAML documents when it
+// doesn't end normally, because the command was interrupted or failed. The '@' character can't start a JSON value or
+// a YAML node, so tools that parse the stream fail instead of silently accepting a partial result.
+const TruncationMarker = "@@ truncated"
+
+// InterruptContext returns a context that is cancelled, with ErrInterrupted as the cause, when the process receives an
+// interrupt or termination signal. This is intended for commands that write streams of results, like the watch modes,
+// so that they finish writing the current document instead of being killed in the middle of it. The returned function
+// must be called to stop listening for the signals.
+func InterruptContext(ctx context.Context) (result context.Context, stop context.CancelFunc) {
+	result, stop = signalContext(ctx, os.Interrupt, syscall.SIGTERM)
+	return
+}
+
+// signalContext returns a context that is cancelled, with ErrInterrupted as the cause, when the process receives one
+// of the given signals.
+func signalContext(ctx context.Context, sigs ...os.Signal) (result context.Context, stop context.CancelFunc) {
+	result, cancel := context.WithCancelCause(ctx)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, sigs...)
+	go func() {
+		select {
+		case <-signals:
+			cancel(ErrInterrupted)
+		case <-result.Done():
+		}
+	}()
+	stop = func() {
+		signal.Stop(signals)
+		cancel(context.Canceled)
+	}
+	return
+}
+
+// Interrupted checks if the given context was cancelled because the process received a signal.
+func Interrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrInterrupted)
+}
+
+// terminalWriter is implemented by writers that know if they write to a terminal, like the console.
+type terminalWriter interface {
+	IsTerminal() bool
+}
+
+// MarkTruncated writes the truncation marker, followed by the reason, to the given writer. It does so only when the
+// format is JSON or YAML and the writer isn't a terminal, as humans looking at the terminal don't need it.
+func MarkTruncated(writer io.Writer, format string, reason error) error {
+	if format != rendering.FormatJson && format != rendering.FormatYaml {
+		return nil
+	}
+	if writer, ok := writer.(terminalWriter); ok && writer.IsTerminal() {
+		return nil
+	}
+	text := "unknown reason"
+	if reason != nil {
+		text = strings.ReplaceAll(reason.Error(), "\n", " ")
+	}
+	_, err := fmt.Fprintf(writer, "%s: %s\n", TruncationMarker, text)
+	return err
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+// fakeTerminal is a buffer that pretends to be a terminal.
+type fakeTerminal struct {
+	bytes.Buffer
+}
+
+func (t *fakeTerminal) IsTerminal() bool {
+	return true
+}
+
+var _ = Describe("Stream", func() {
+	Describe("Mark truncated", func() {
+		It("Writes the marker for JSON", func() {
+			buffer := &bytes.Buffer{}
+			err := MarkTruncated(buffer, rendering.FormatJson, errors.New("my error"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal("@@ truncated: my error\n"))
+		})
+
+		It("Writes the marker for YAML", func() {
+			buffer := &bytes.Buffer{}
+			err := MarkTruncated(buffer, rendering.FormatYaml, ErrInterrupted)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal("@@ truncated: interrupted\n"))
+		})
+
+		It("Writes the reason in one line", func() {
+			buffer := &bytes.Buffer{}
+			err := MarkTruncated(buffer, rendering.FormatJson, errors.New("first\nsecond"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal("@@ truncated: first second\n"))
+		})
+
+		It("Doesn't write the marker for tables", func() {
+			buffer := &bytes.Buffer{}
+			err := MarkTruncated(buffer, rendering.FormatTable, errors.New("my error"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.Len()).To(BeZero())
+		})
+
+		It("Doesn't write the marker to a terminal", func() {
+			terminal := &fakeTerminal{}
+			err := MarkTruncated(terminal, rendering.FormatJson, errors.New("my error"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(terminal.Len()).To(BeZero())
+		})
+	})
+
+	Describe("Interrupt context", func() {
+		It("Isn't interrupted when stopped", func() {
+			ctx, stop := InterruptContext(context.Background())
+			stop()
+			Expect(ctx.Err()).To(HaveOccurred())
+			Expect(Interrupted(ctx)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,36 @@
+//go:build !windows
+
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"context"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream signals", func() {
+	It("Is interrupted when the process receives a signal", func() {
+		// The test framework handles the interrupt and termination signals itself, so use a different one:
+		ctx, stop := signalContext(context.Background(), syscall.SIGUSR1)
+		defer stop()
+		Expect(syscall.Kill(os.Getpid(), syscall.SIGUSR1)).To(Succeed())
+		Eventually(ctx.Done()).Should(BeClosed())
+		Expect(Interrupted(ctx)).To(BeTrue())
+	})
+})
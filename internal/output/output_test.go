@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Output", func() {
+	// makeCommands creates a root command with the global flag, and two sub-commands, one of them with its own
+	// output flag.
+	makeCommands := func() (root, plain, custom *cobra.Command) {
+		root = &cobra.Command{
+			Use: "root",
+		}
+		AddFlags(root.PersistentFlags())
+		plain = &cobra.Command{
+			Use: "plain",
+			Run: func(cmd *cobra.Command, args []string) {},
+		}
+		root.AddCommand(plain)
+		custom = &cobra.Command{
+			Use: "custom",
+			Run: func(cmd *cobra.Command, args []string) {},
+		}
+		custom.Flags().StringP(flagName, "o", "table", "Custom format.")
+		root.AddCommand(custom)
+		return
+	}
+
+	Describe("Format from command", func() {
+		It("Returns text by default", func() {
+			root, plain, _ := makeCommands()
+			root.SetArgs([]string{"plain"})
+			Expect(root.Execute()).To(Succeed())
+			format, err := FormatFromCommand(plain)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(format).To(Equal(FormatText))
+		})
+
+		It("Returns JSON when requested", func() {
+			root, plain, _ := makeCommands()
+			root.SetArgs([]string{"plain", "-o", "json"})
+			Expect(root.Execute()).To(Succeed())
+			format, err := FormatFromCommand(plain)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(format).To(Equal(FormatJson))
+		})
+
+		It("Rejects unknown formats for commands without their own flag", func() {
+			root, plain, _ := makeCommands()
+			root.SetArgs([]string{"plain", "-o", "yaml"})
+			Expect(root.Execute()).To(Succeed())
+			_, err := FormatFromCommand(plain)
+			Expect(err).To(MatchError("unknown output format 'yaml', should be 'text' or 'json'"))
+		})
+
+		It("Accepts any format for commands with their own flag", func() {
+			root, _, custom := makeCommands()
+			root.SetArgs([]string{"custom", "-o", "yaml"})
+			Expect(root.Execute()).To(Succeed())
+			format, err := FormatFromCommand(custom)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(format).To(Equal("yaml"))
+		})
+	})
+
+	Describe("Context", func() {
+		It("Returns text if the context doesn't contain the format", func() {
+			Expect(FormatFromContext(context.Background())).To(Equal(FormatText))
+			Expect(IsJson(context.Background())).To(BeFalse())
+		})
+
+		It("Returns the format stored in the context", func() {
+			ctx := FormatIntoContext(context.Background(), FormatJson)
+			Expect(FormatFromContext(ctx)).To(Equal(FormatJson))
+			Expect(IsJson(ctx)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package packages
+
+import (
+	"context"
+)
+
+// contextKey is the type used to store the packages override in the context.
+type contextKey int
+
+const (
+	contextOverrideKey contextKey = iota
+)
+
+// OverrideFromContext returns the list of packages that should be used instead of the ones enabled in the
+// configuration, or nil if the context doesn't contain such list.
+func OverrideFromContext(ctx context.Context) []string {
+	override, _ := ctx.Value(contextOverrideKey).([]string)
+	return override
+}
+
+// OverrideIntoContext creates a new context that contains the given list of packages that should be used instead of
+// the ones enabled in the configuration.
+func OverrideIntoContext(ctx context.Context, override []string) context.Context {
+	return context.WithValue(ctx, contextOverrideKey, override)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package packages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// overrideFlagName is the name of the flag that overrides the packages enabled in the configuration.
+const overrideFlagName = "packages"
+
+// AddFlags adds to the given flag set the hidden flag that overrides, for a single invocation, the packages enabled in
+// the configuration. This is intended for developers that need to test new API packages, or exclude temporarily
+// the private packages.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringSlice(
+		overrideFlagName,
+		nil,
+		"Comma separated list of API packages to use instead of the ones enabled in the configuration, for "+
+			"example 'fulfillment.v1,private.v1'. Types of packages that appear first are presented first.",
+	)
+	flags.MarkHidden(overrideFlagName)
+}
+
+// OverrideFromFlags returns the list of packages given in the command line, or nil if the flag wasn't used.
+func OverrideFromFlags(flags *pflag.FlagSet) (result []string, err error) {
+	flag := flags.Lookup(overrideFlagName)
+	if flag == nil || !flag.Changed {
+		return
+	}
+	values, err := flags.GetStringSlice(overrideFlagName)
+	if err != nil {
+		return
+	}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			err = fmt.Errorf("value of flag '--%s' contains an empty package name", overrideFlagName)
+			return
+		}
+		result = append(result, value)
+	}
+	if len(result) == 0 {
+		err = fmt.Errorf("flag '--%s' requires at least one package name", overrideFlagName)
+	}
+	return
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package packages
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Override", func() {
+	var flags *pflag.FlagSet
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddFlags(flags)
+	})
+
+	It("Returns nil if the flag isn't used", func() {
+		err := flags.Parse([]string{})
+		Expect(err).ToNot(HaveOccurred())
+		override, err := OverrideFromFlags(flags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(override).To(BeNil())
+	})
+
+	It("Returns the packages in the given order", func() {
+		err := flags.Parse([]string{"--packages", "private.v1, fulfillment.v1"})
+		Expect(err).ToNot(HaveOccurred())
+		override, err := OverrideFromFlags(flags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(override).To(Equal([]string{PrivateV1, FulfillmentV1}))
+	})
+
+	It("Rejects empty package names", func() {
+		err := flags.Parse([]string{"--packages", "fulfillment.v1,,private.v1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = OverrideFromFlags(flags)
+		Expect(err).To(MatchError(ContainSubstring("empty package name")))
+	})
+
+	It("Stores the override in the context", func() {
+		ctx := context.Background()
+		Expect(OverrideFromContext(ctx)).To(BeNil())
+		ctx = OverrideIntoContext(ctx, []string{FulfillmentV1})
+		Expect(OverrideFromContext(ctx)).To(Equal([]string{FulfillmentV1}))
+	})
+})
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package pricing contains the functions that estimate the monthly cost of host pools and clusters, using a pricing
+// file that maps host classes to the monthly cost of each host. The file is written in YAML or JSON, for example:
+//
+//	currency: USD
+//	classes:
+//	  fc430: 850
+//	  h100: 4200.50
+//
+// The keys of the classes are the values that host pools and clusters use in their 'host_class' fields.
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/osac-project/fulfillment-cli/internal/fetch"
+)
+
+// Prices contains the monthly cost of each host, indexed by host class.
+type Prices struct {
+	// Currency is the currency of the costs, for example 'USD'. It is only used to display them.
+	Currency string `yaml:"currency,omitempty"`
+
+	// Classes contains the monthly cost of a host of each class, indexed by host class.
+	Classes map[string]float64 `yaml:"classes"`
+}
+
+// Estimate is the estimated monthly cost of a set of hosts.
+type Estimate struct {
+	// Currency is the currency of the cost, copied from the pricing file.
+	Currency string `json:"currency,omitempty"`
+
+	// Monthly is the estimated monthly cost of the hosts that have a price.
+	Monthly float64 `json:"monthly"`
+
+	// Unpriced contains the host classes that have no price in the pricing file, sorted alphabetically. The hosts of
+	// these classes aren't included in the monthly cost, so it is a lower bound when this isn't empty.
+	Unpriced []string `json:"unpriced,omitempty"`
+}
+
+// Load loads the pricing file from the given URL or local file.
+func Load(ctx context.Context, logger *slog.Logger, source string) (result *Prices, err error) {
+	reader, err := fetch.Open(ctx, logger, source, "pricing file")
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+	result, err = Parse(reader)
+	if err != nil {
+		err = fmt.Errorf("failed to parse prices from '%s': %w", source, err)
+	}
+	return
+}
+
+// Parse parses a pricing file in YAML or JSON format.
+func Parse(reader io.Reader) (result *Prices, err error) {
+	prices := &Prices{}
+	err = yaml.NewDecoder(reader).Decode(prices)
+	if errors.Is(err, io.EOF) {
+		err = errors.New("file is empty")
+	}
+	if err != nil {
+		return
+	}
+	for _, class := range slices.Sorted(maps.Keys(prices.Classes)) {
+		if prices.Classes[class] < 0 {
+			err = fmt.Errorf("cost of host class '%s' is negative", class)
+			return
+		}
+	}
+	result = prices
+	return
+}
+
+// Estimate calculates the monthly cost of the given hosts. The keys of the map are the host classes and the values the
+// number of hosts of each class.
+func (p *Prices) Estimate(hosts map[string]int) *Estimate {
+	result := &Estimate{
+		Currency: p.Currency,
+	}
+	for _, class := range slices.Sorted(maps.Keys(hosts)) {
+		count := hosts[class]
+		if count == 0 {
+			continue
+		}
+		cost, ok := p.Classes[class]
+		if !ok {
+			result.Unpriced = append(result.Unpriced, class)
+			continue
+		}
+		result.Monthly += cost * float64(count)
+	}
+	return result
+}
+
+// Add adds the cost of the given estimate to this one, merging the host classes that have no price.
+func (e *Estimate) Add(other *Estimate) {
+	e.Monthly += other.Monthly
+	for _, class := range other.Unpriced {
+		if !slices.Contains(e.Unpriced, class) {
+			e.Unpriced = append(e.Unpriced, class)
+		}
+	}
+	slices.Sort(e.Unpriced)
+}
+
+// Amount returns the monthly cost with two decimals and the currency, for example '1700.00 USD'.
+func (e *Estimate) Amount() string {
+	if e.Currency == "" {
+		return fmt.Sprintf("%.2f", e.Monthly)
+	}
+	return fmt.Sprintf("%.2f %s", e.Monthly, e.Currency)
+}
+
+// String returns a description of the estimate intended for humans, for example '1700.00 USD per month', followed by
+// the host classes that have no price, if any.
+func (e *Estimate) String() string {
+	result := fmt.Sprintf("%s per month", e.Amount())
+	if len(e.Unpriced) > 0 {
+		result = fmt.Sprintf(
+			"%s (no price for %s)",
+			result, strings.Join(quoted(e.Unpriced), ", "),
+		)
+	}
+	return result
+}
+
+// quoted returns a copy of the given strings surrounded by single quotes.
+func quoted(values []string) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = fmt.Sprintf("'%s'", value)
+	}
+	return result
+}
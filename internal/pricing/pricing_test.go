@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parse", func() {
+	It("Parses YAML", func() {
+		prices, err := Parse(strings.NewReader(
+			"currency: USD\n" +
+				"classes:\n" +
+				"  fc430: 850\n" +
+				"  h100: 4200.50\n",
+		))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(prices.Currency).To(Equal("USD"))
+		Expect(prices.Classes).To(Equal(map[string]float64{
+			"fc430": 850,
+			"h100":  4200.50,
+		}))
+	})
+
+	It("Parses JSON", func() {
+		prices, err := Parse(strings.NewReader(`{"currency": "EUR", "classes": {"fc430": 700}}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(prices.Currency).To(Equal("EUR"))
+		Expect(prices.Classes).To(Equal(map[string]float64{
+			"fc430": 700,
+		}))
+	})
+
+	It("Rejects empty files", func() {
+		_, err := Parse(strings.NewReader(""))
+		Expect(err).To(MatchError("file is empty"))
+	})
+
+	It("Rejects negative costs", func() {
+		_, err := Parse(strings.NewReader("classes:\n  fc430: -1\n"))
+		Expect(err).To(MatchError("cost of host class 'fc430' is negative"))
+	})
+})
+
+var _ = Describe("Load", func() {
+	It("Loads a local file", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "prices.yaml")
+		err := os.WriteFile(file, []byte("classes:\n  fc430: 850\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		prices, err := Load(context.Background(), logger, file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(prices.Classes).To(HaveKeyWithValue("fc430", 850.0))
+	})
+
+	It("Downloads a file from a URL", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("classes:\n  fc430: 850\n"))
+		}))
+		DeferCleanup(server.Close)
+		prices, err := Load(context.Background(), logger, server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(prices.Classes).To(HaveKeyWithValue("fc430", 850.0))
+	})
+
+	It("Fails if the server returns an error", func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		DeferCleanup(server.Close)
+		_, err := Load(context.Background(), logger, server.URL)
+		Expect(err).To(MatchError(ContainSubstring("status 404")))
+	})
+
+	It("Fails if the file doesn't exist", func() {
+		_, err := Load(context.Background(), logger, "/does/not/exist.yaml")
+		Expect(err).To(MatchError(ContainSubstring("failed to open pricing file '/does/not/exist.yaml'")))
+	})
+})
+
+var _ = Describe("Estimate", func() {
+	var prices *Prices
+
+	BeforeEach(func() {
+		prices = &Prices{
+			Currency: "USD",
+			Classes: map[string]float64{
+				"fc430": 850,
+				"h100":  4200.50,
+			},
+		}
+	})
+
+	It("Adds the cost of all the hosts", func() {
+		estimate := prices.Estimate(map[string]int{
+			"fc430": 2,
+			"h100":  1,
+		})
+		Expect(estimate.Monthly).To(Equal(5900.50))
+		Expect(estimate.Unpriced).To(BeEmpty())
+		Expect(estimate.String()).To(Equal("5900.50 USD per month"))
+	})
+
+	It("Reports the classes without price", func() {
+		estimate := prices.Estimate(map[string]int{
+			"fc430": 2,
+			"zz":    1,
+			"aa":    3,
+		})
+		Expect(estimate.Monthly).To(Equal(1700.0))
+		Expect(estimate.Unpriced).To(Equal([]string{"aa", "zz"}))
+		Expect(estimate.String()).To(Equal("1700.00 USD per month (no price for 'aa', 'zz')"))
+	})
+
+	It("Ignores classes without hosts", func() {
+		estimate := prices.Estimate(map[string]int{
+			"zz": 0,
+		})
+		Expect(estimate.Monthly).To(BeZero())
+		Expect(estimate.Unpriced).To(BeEmpty())
+	})
+
+	It("Omits the currency if there is none", func() {
+		prices.Currency = ""
+		estimate := prices.Estimate(map[string]int{
+			"fc430": 1,
+		})
+		Expect(estimate.Amount()).To(Equal("850.00"))
+	})
+
+	It("Adds estimates", func() {
+		total := prices.Estimate(map[string]int{
+			"fc430": 1,
+			"zz":    1,
+		})
+		total.Add(prices.Estimate(map[string]int{
+			"h100": 1,
+			"aa":   1,
+			"zz":   1,
+		}))
+		Expect(total.Monthly).To(Equal(5050.50))
+		Expect(total.Unpriced).To(Equal([]string{"aa", "zz"}))
+	})
+})
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package recording
+
+import (
+	"context"
+)
+
+// contextKey is the type used to store the recorder and the replayer in the context.
+type contextKey int
+
+const (
+	contextRecorderKey contextKey = iota
+	contextReplayerKey
+)
+
+// RecorderFromContext returns the recorder from the context, or nil if the context doesn't contain it.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(contextRecorderKey).(*Recorder)
+	return recorder
+}
+
+// RecorderIntoContext creates a new context that contains the given recorder.
+func RecorderIntoContext(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, contextRecorderKey, recorder)
+}
+
+// ReplayerFromContext returns the replayer from the context, or nil if the context doesn't contain it.
+func ReplayerFromContext(ctx context.Context) *Replayer {
+	replayer, _ := ctx.Value(contextReplayerKey).(*Replayer)
+	return replayer
+}
+
+// ReplayerIntoContext creates a new context that contains the given replayer.
+func ReplayerIntoContext(ctx context.Context, replayer *Replayer) context.Context {
+	return context.WithValue(ctx, contextReplayerKey, replayer)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package recording contains the interceptors that save the remote procedure calls to a file, and that replay them
+// later from that file without a server. This is intended for deterministic integration tests of the CLI, and to
+// reproduce problems reported by users.
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fileVersion is the version of the format of the recording files.
+const fileVersion = 1
+
+// File is the content of a recording file.
+type File struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a call to the server, unary or stream, with the messages that were sent and received, and the
+// resulting status.
+type Interaction struct {
+	// Method is the full name of the method, for example '/fulfillment.v1.Clusters/Get'.
+	Method string `json:"method"`
+
+	// Stream indicates if the method is a stream.
+	Stream bool `json:"stream,omitempty"`
+
+	// Refused indicates that the stream couldn't be started.
+	Refused bool `json:"refused,omitempty"`
+
+	// Requests and Responses are the messages sent and received, in order.
+	Requests  []*Message `json:"requests,omitempty"`
+	Responses []*Message `json:"responses,omitempty"`
+
+	// Header and Trailer are the metadata sent by the server.
+	Header  metadata.MD `json:"header,omitempty"`
+	Trailer metadata.MD `json:"trailer,omitempty"`
+
+	// Code is the name of the resulting status code. This is only informative, the status that is replayed is the
+	// complete one, including the details.
+	Code string `json:"code,omitempty"`
+
+	// Status is the resulting status, serialized as a 'google.rpc.Status' message. It is nil when the call finished
+	// successfully.
+	Status *Message `json:"status,omitempty"`
+
+	// Finished indicates if the call finished. Streams that were still open when the command ended, for example
+	// because it was interrupted, aren't finished, and when replayed they stay open till the command ends.
+	Finished bool `json:"finished"`
+}
+
+// Message is a serialized protocol buffers message together with the full name of its type.
+type Message struct {
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+// encodeMessage serializes the given message. The serialization is deterministic, so that the same request results in
+// the same data when it is sent again during a replay.
+func encodeMessage(value any) (result *Message, err error) {
+	message, ok := value.(proto.Message)
+	if !ok {
+		err = fmt.Errorf("value of type '%T' isn't a protocol buffers message", value)
+		return
+	}
+	data, err := proto.MarshalOptions{
+		Deterministic: true,
+	}.Marshal(message)
+	if err != nil {
+		err = fmt.Errorf("failed to serialize message of type '%T': %w", value, err)
+		return
+	}
+	result = &Message{
+		Type: string(message.ProtoReflect().Descriptor().FullName()),
+		Data: data,
+	}
+	return
+}
+
+// decodeMessage deserializes the given message into the given value, checking that it has the same type.
+func decodeMessage(message *Message, value any) error {
+	target, ok := value.(proto.Message)
+	if !ok {
+		return fmt.Errorf("value of type '%T' isn't a protocol buffers message", value)
+	}
+	name := string(target.ProtoReflect().Descriptor().FullName())
+	if name != message.Type {
+		return fmt.Errorf("recorded message has type '%s', but '%s' was expected", message.Type, name)
+	}
+	err := proto.Unmarshal(message.Data, target)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize message of type '%s': %w", name, err)
+	}
+	return nil
+}
+
+// encodeStatus serializes the status of the given error. The result is nil if the error is nil.
+func encodeStatus(err error) (message *Message, code string) {
+	if err == nil {
+		return
+	}
+	status := grpcstatus.Convert(err)
+	code = status.Code().String()
+	message, _ = encodeMessage(status.Proto())
+	return
+}
+
+// decodeStatus returns the error corresponding to the given serialized status, or nil if there is no status.
+func decodeStatus(message *Message) error {
+	if message == nil {
+		return nil
+	}
+	status := grpcstatus.New(0, "").Proto()
+	err := decodeMessage(message, status)
+	if err != nil {
+		return err
+	}
+	return grpcstatus.FromProto(status).Err()
+}
+
+// loadFile loads the recording file.
+func loadFile(file string) (result *File, err error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		err = fmt.Errorf("failed to read recording file '%s': %w", file, err)
+		return
+	}
+	content := &File{}
+	err = json.Unmarshal(data, content)
+	if err != nil {
+		err = fmt.Errorf("failed to parse recording file '%s': %w", file, err)
+		return
+	}
+	if content.Version != fileVersion {
+		err = fmt.Errorf(
+			"recording file '%s' has version %d, but only version %d is supported",
+			file, content.Version, fileVersion,
+		)
+		return
+	}
+	result = content
+	return
+}
+
+// saveFile saves the recording file. The file is only readable by the current user because it contains all the data
+// returned by the server, including secrets like kubeconfigs.
+func saveFile(file string, content *File) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize recording: %w", err)
+	}
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write recording file '%s': %w", file, err)
+	}
+	return nil
+}
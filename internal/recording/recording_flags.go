@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package recording
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+)
+
+// Names of the flags that enable recording and replaying:
+const (
+	recordFlagName = "record"
+	replayFlagName = "replay"
+)
+
+// AddFlags adds the flags that enable recording and replaying of remote procedure calls to the given flag set.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.String(
+		recordFlagName,
+		"",
+		"Save all the calls to the server, with the request and response messages, to this file, so that "+
+			"they can be replayed later with the '--"+replayFlagName+"' option. Note that the file contains "+
+			"all the data returned by the server, including secrets like kubeconfigs and passwords.",
+	)
+	flags.String(
+		replayFlagName,
+		"",
+		"Don't connect to the server, instead replay the calls saved to this file with the '--"+
+			recordFlagName+"' option.",
+	)
+}
+
+// FilesFromFlags returns the names of the files where the calls should be recorded or from where they should be
+// replayed. At most one of them will be non empty.
+func FilesFromFlags(flags *pflag.FlagSet) (record, replay string, err error) {
+	record, _ = flags.GetString(recordFlagName)
+	replay, _ = flags.GetString(replayFlagName)
+	if record != "" && replay != "" {
+		err = errors.New("options '--" + recordFlagName + "' and '--" + replayFlagName + "' can't be used together")
+	}
+	return
+}
@@ -0,0 +1,240 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package recording
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"sync"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RecorderBuilder contains the data and logic needed to create a recorder. Don't create instances of this type
+// directly, use the NewRecorder function instead.
+type RecorderBuilder struct {
+	logger *slog.Logger
+	file   string
+}
+
+// Recorder saves the remote procedure calls to a file. The calls are kept in memory and written when the Save method
+// is called. Don't create instances of this type directly, use the NewRecorder function instead.
+type Recorder struct {
+	logger       *slog.Logger
+	file         string
+	lock         *sync.Mutex
+	interactions []*Interaction
+}
+
+// NewRecorder creates a builder that can then be used to configure and create a recorder.
+func NewRecorder() *RecorderBuilder {
+	return &RecorderBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *RecorderBuilder) SetLogger(value *slog.Logger) *RecorderBuilder {
+	b.logger = value
+	return b
+}
+
+// SetFile sets the name of the file where the calls will be saved. This is mandatory.
+func (b *RecorderBuilder) SetFile(value string) *RecorderBuilder {
+	b.file = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new recorder. It writes an empty recording to the file, so
+// that problems like missing directories or permissions are detected before any call is made.
+func (b *RecorderBuilder) Build() (result *Recorder, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.file == "" {
+		err = errors.New("file is mandatory")
+		return
+	}
+
+	// Create and populate the object:
+	recorder := &Recorder{
+		logger: b.logger,
+		file:   b.file,
+		lock:   &sync.Mutex{},
+	}
+	err = recorder.Save()
+	if err != nil {
+		return
+	}
+	result = recorder
+	return
+}
+
+// UnaryClient is the unary client interceptor function that records the calls.
+func (r *Recorder) UnaryClient(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var header, trailer metadata.MD
+	opts = append(opts, grpc.Header(&header), grpc.Trailer(&trailer))
+	err := invoker(ctx, method, request, response, conn, opts...)
+	interaction := &Interaction{
+		Method:   method,
+		Header:   header,
+		Trailer:  trailer,
+		Finished: true,
+	}
+	interaction.Requests = r.appendMessage(ctx, interaction.Requests, request)
+	if err == nil {
+		interaction.Responses = r.appendMessage(ctx, interaction.Responses, response)
+	}
+	interaction.Status, interaction.Code = encodeStatus(err)
+	r.lock.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.lock.Unlock()
+	return err
+}
+
+// StreamClient is the stream client interceptor function that records the calls. The stream is added to the
+// recording when it starts, and the messages are added as they are sent or received.
+func (r *Recorder) StreamClient(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, conn, method, opts...)
+	interaction := &Interaction{
+		Method: method,
+		Stream: true,
+	}
+	if err != nil {
+		interaction.Refused = true
+		interaction.Finished = true
+		interaction.Status, interaction.Code = encodeStatus(err)
+	}
+	r.lock.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &recordedStream{
+		ClientStream: stream,
+		recorder:     r,
+		ctx:          ctx,
+		interaction:  interaction,
+	}, nil
+}
+
+// Save writes the recorded calls to the file.
+func (r *Recorder) Save() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	interactions := r.interactions
+	if interactions == nil {
+		interactions = []*Interaction{}
+	}
+	return saveFile(r.file, &File{
+		Version:      fileVersion,
+		Interactions: interactions,
+	})
+}
+
+// appendMessage serializes the given message and adds it to the given list. Failing to serialize a message shouldn't
+// break the call, so in that case the error is written to the log and the message is skipped.
+func (r *Recorder) appendMessage(ctx context.Context, messages []*Message, value any) []*Message {
+	message, err := encodeMessage(value)
+	if err != nil {
+		r.logger.WarnContext(
+			ctx,
+			"Failed to record message",
+			slog.Any("error", err),
+		)
+		return messages
+	}
+	return append(messages, message)
+}
+
+// recordedStream wraps a client stream to record the messages and the end of the stream.
+type recordedStream struct {
+	grpc.ClientStream
+	recorder    *Recorder
+	ctx         context.Context
+	interaction *Interaction
+}
+
+func (s *recordedStream) SendMsg(message any) error {
+	err := s.ClientStream.SendMsg(message)
+	if err == nil {
+		s.recorder.lock.Lock()
+		s.interaction.Requests = s.recorder.appendMessage(s.ctx, s.interaction.Requests, message)
+		s.recorder.lock.Unlock()
+	}
+	return err
+}
+
+func (s *recordedStream) RecvMsg(message any) error {
+	err := s.ClientStream.RecvMsg(message)
+	s.recorder.lock.Lock()
+	defer s.recorder.lock.Unlock()
+	if err == nil {
+		s.interaction.Responses = s.recorder.appendMessage(s.ctx, s.interaction.Responses, message)
+		return nil
+	}
+	if s.interaction.Finished {
+		return err
+	}
+
+	// The end of the stream is signaled by an io.EOF error, which means that the call finished successfully. Streams
+	// that are cancelled because the command is ending are left unfinished, so that they stay open when replayed.
+	if s.ctx.Err() != nil {
+		return err
+	}
+	s.interaction.Header, _ = s.ClientStream.Header()
+	s.interaction.Trailer = s.ClientStream.Trailer()
+	if !errors.Is(err, io.EOF) {
+		s.interaction.Status, s.interaction.Code = encodeStatus(err)
+	}
+	s.interaction.Finished = true
+	return err
+}
+
+// Finish saves the calls recorded by the given command, if recording is enabled. Failing to save the calls doesn't
+// change the result of the command, but it is reported to the given writer.
+func Finish(writer io.Writer, cmd *cobra.Command) {
+	if cmd == nil {
+		return
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		return
+	}
+	recorder := RecorderFromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	err := recorder.Save()
+	if err != nil {
+		logger := logging.LoggerFromContext(ctx)
+		logger.ErrorContext(
+			ctx,
+			"Failed to save recording",
+			slog.String("file", recorder.file),
+			slog.Any("error", err),
+		)
+		fmt.Fprintf(writer, "Failed to save recording: %v\n", err)
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package recording
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// ReplayAddress is the address of the server used when replaying calls and there is no configuration. It is never
+// used to connect, as the replayed calls don't reach the network.
+const ReplayAddress = "replay.invalid:443"
+
+// ReplayerBuilder contains the data and logic needed to create a replayer. Don't create instances of this type
+// directly, use the NewReplayer function instead.
+type ReplayerBuilder struct {
+	logger *slog.Logger
+	file   string
+}
+
+// Replayer replays the remote procedure calls saved to a file by a recorder, without sending them to the server.
+// Each call is answered with the first recorded call to the same method, that hasn't been used yet, and that has the
+// same request. If there is no call with the same request then the first unused call to the same method is used, so
+// that requests that contain values that change in each execution, like the current time, can still be replayed.
+// Don't create instances of this type directly, use the NewReplayer function instead.
+type Replayer struct {
+	logger       *slog.Logger
+	file         string
+	lock         *sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewReplayer creates a builder that can then be used to configure and create a replayer.
+func NewReplayer() *ReplayerBuilder {
+	return &ReplayerBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *ReplayerBuilder) SetLogger(value *slog.Logger) *ReplayerBuilder {
+	b.logger = value
+	return b
+}
+
+// SetFile sets the name of the file that contains the recorded calls. This is mandatory.
+func (b *ReplayerBuilder) SetFile(value string) *ReplayerBuilder {
+	b.file = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new replayer.
+func (b *ReplayerBuilder) Build() (result *Replayer, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.file == "" {
+		err = errors.New("file is mandatory")
+		return
+	}
+
+	// Load the file:
+	content, err := loadFile(b.file)
+	if err != nil {
+		return
+	}
+
+	// Create and populate the object:
+	result = &Replayer{
+		logger:       b.logger,
+		file:         b.file,
+		lock:         &sync.Mutex{},
+		interactions: content.Interactions,
+		used:         make([]bool, len(content.Interactions)),
+	}
+	return
+}
+
+// UnaryClient is the unary client interceptor function that replays the calls. It never calls the server.
+func (r *Replayer) UnaryClient(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	interaction, err := r.find(ctx, method, request)
+	if err != nil {
+		return err
+	}
+	replayMetadata(interaction, opts)
+	if len(interaction.Responses) > 0 {
+		err = decodeMessage(interaction.Responses[0], response)
+		if err != nil {
+			return grpcstatus.Errorf(codes.Internal, "failed to replay call to method '%s': %v", method, err)
+		}
+	}
+	return decodeStatus(interaction.Status)
+}
+
+// StreamClient is the stream client interceptor function that replays the streams. It never calls the server. The
+// requests sent to the stream are ignored, and the recorded responses are returned in order.
+func (r *Replayer) StreamClient(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	interaction, err := r.find(ctx, method, nil)
+	if err != nil {
+		return nil, err
+	}
+	if interaction.Refused {
+		return nil, decodeStatus(interaction.Status)
+	}
+	replayMetadata(interaction, opts)
+	return &replayedStream{
+		ctx:         ctx,
+		interaction: interaction,
+	}, nil
+}
+
+// find finds the recorded call that should be used to answer the given call, and marks it as used. When the request is
+// nil only the method is compared.
+func (r *Replayer) find(ctx context.Context, method string, request any) (result *Interaction, err error) {
+	var data []byte
+	compare := false
+	if request != nil {
+		message, _ := encodeMessage(request)
+		if message != nil {
+			data = message.Data
+			compare = true
+		}
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	index := -1
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Method != method {
+			continue
+		}
+		if index == -1 {
+			index = i
+		}
+		if !compare {
+			break
+		}
+		if len(interaction.Requests) > 0 && bytes.Equal(interaction.Requests[0].Data, data) {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		err = fmt.Errorf("there are no more recorded calls to method '%s' in file '%s'", method, r.file)
+		return
+	}
+	r.used[index] = true
+	result = r.interactions[index]
+	r.logger.DebugContext(
+		ctx,
+		"Replaying call",
+		slog.String("method", method),
+		slog.Int("index", index),
+	)
+	return
+}
+
+// replayMetadata copies the recorded header and trailer to the call options that request them.
+func replayMetadata(interaction *Interaction, opts []grpc.CallOption) {
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case grpc.HeaderCallOption:
+			*opt.HeaderAddr = interaction.Header.Copy()
+		case grpc.TrailerCallOption:
+			*opt.TrailerAddr = interaction.Trailer.Copy()
+		}
+	}
+}
+
+// replayedStream is the client stream that returns the recorded responses.
+type replayedStream struct {
+	ctx         context.Context
+	interaction *Interaction
+	next        int
+}
+
+func (s *replayedStream) Header() (metadata.MD, error) {
+	return s.interaction.Header.Copy(), nil
+}
+
+func (s *replayedStream) Trailer() metadata.MD {
+	return s.interaction.Trailer.Copy()
+}
+
+func (s *replayedStream) CloseSend() error {
+	return nil
+}
+
+func (s *replayedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *replayedStream) SendMsg(message any) error {
+	return nil
+}
+
+// RecvMsg returns the next recorded response. When there are no more responses it returns the recorded status, or
+// waits till the context is cancelled if the stream wasn't finished when it was recorded.
+func (s *replayedStream) RecvMsg(message any) error {
+	if s.next < len(s.interaction.Responses) {
+		response := s.interaction.Responses[s.next]
+		s.next++
+		err := decodeMessage(response, message)
+		if err != nil {
+			return grpcstatus.Errorf(
+				codes.Internal,
+				"failed to replay stream of method '%s': %v",
+				s.interaction.Method, err,
+			)
+		}
+		return nil
+	}
+	if !s.interaction.Finished {
+		<-s.ctx.Done()
+		return grpcstatus.FromContextError(s.ctx.Err()).Err()
+	}
+	err := decodeStatus(s.interaction.Status)
+	if err == nil {
+		err = io.EOF
+	}
+	return err
+}
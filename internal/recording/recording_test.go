@@ -0,0 +1,242 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package recording
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Recording", func() {
+	var (
+		ctx  context.Context
+		file string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		file = filepath.Join(GinkgoT().TempDir(), "recording.json")
+	})
+
+	// record starts a server with the clusters and events services, creates a connection that records the calls to
+	// the file, runs the given function with that connection, and then saves the recording.
+	record := func(events []*testing.ScenarioEvent, function func(conn *grpc.ClientConn)) {
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+			GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest) (*ffv1.ClustersGetResponse,
+				error) {
+				if request.GetId() == "missing" {
+					return nil, grpcstatus.Errorf(codes.NotFound, "cluster doesn't exist")
+				}
+				grpc.SetHeader(ctx, metadata.Pairs("my-header", "my-value"))
+				return ffv1.ClustersGetResponse_builder{
+					Object: ffv1.Cluster_builder{
+						Id: request.GetId(),
+					}.Build(),
+				}.Build(), nil
+			},
+		})
+		eventsv1.RegisterEventsServer(
+			server.Registrar(),
+			testing.NewMockEventsServerBuilder().
+				WithScenario(&testing.EventScenario{
+					Name:   "my",
+					Events: events,
+				}).
+				Build(),
+		)
+		server.Start()
+		recorder, err := NewRecorder().
+			SetLogger(logger).
+			SetFile(file).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(recorder.UnaryClient),
+			grpc.WithStreamInterceptor(recorder.StreamClient),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		function(conn)
+		err = conn.Close()
+		Expect(err).ToNot(HaveOccurred())
+		err = recorder.Save()
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	// replay creates a connection that replays the calls from the file. The address doesn't correspond to any server,
+	// so any call that isn't replayed fails.
+	replay := func() *grpc.ClientConn {
+		replayer, err := NewReplayer().
+			SetLogger(logger).
+			SetFile(file).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		conn, err := grpc.NewClient(
+			ReplayAddress,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(replayer.UnaryClient),
+			grpc.WithStreamInterceptor(replayer.StreamClient),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+		return conn
+	}
+
+	It("Requires a logger", func() {
+		_, err := NewRecorder().SetFile(file).Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+		_, err = NewReplayer().SetFile(file).Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Fails if the file to replay doesn't exist", func() {
+		_, err := NewReplayer().
+			SetLogger(logger).
+			SetFile(file).
+			Build()
+		Expect(err).To(MatchError(ContainSubstring("failed to read recording file")))
+	})
+
+	It("Replays responses, errors and headers of unary calls", func() {
+		record(nil, func(conn *grpc.ClientConn) {
+			client := ffv1.NewClustersClient(conn)
+			_, err := client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "cluster-1"}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			_, err = client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "missing"}.Build())
+			Expect(err).To(HaveOccurred())
+		})
+		client := ffv1.NewClustersClient(replay())
+		var header metadata.MD
+		response, err := client.Get(
+			ctx,
+			ffv1.ClustersGetRequest_builder{Id: "cluster-1"}.Build(),
+			grpc.Header(&header),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetObject().GetId()).To(Equal("cluster-1"))
+		Expect(header.Get("my-header")).To(Equal([]string{"my-value"}))
+		_, err = client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "missing"}.Build())
+		Expect(grpcstatus.Code(err)).To(Equal(codes.NotFound))
+		Expect(grpcstatus.Convert(err).Message()).To(Equal("cluster doesn't exist"))
+	})
+
+	It("Prefers the calls that have the same request", func() {
+		record(nil, func(conn *grpc.ClientConn) {
+			client := ffv1.NewClustersClient(conn)
+			_, err := client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "cluster-1"}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			_, err = client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "cluster-2"}.Build())
+			Expect(err).ToNot(HaveOccurred())
+		})
+		client := ffv1.NewClustersClient(replay())
+		response, err := client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "cluster-2"}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetObject().GetId()).To(Equal("cluster-2"))
+		response, err = client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "cluster-3"}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetObject().GetId()).To(Equal("cluster-1"))
+		_, err = client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "cluster-1"}.Build())
+		Expect(err).To(MatchError(ContainSubstring(
+			"there are no more recorded calls to method '/fulfillment.v1.Clusters/Get'",
+		)))
+	})
+
+	It("Replays streams that were finished", func() {
+		record(
+			[]*testing.ScenarioEvent{
+				{
+					ID:   "event-1",
+					Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+					Cluster: &testing.ClusterEventData{
+						ID: "cluster-1",
+					},
+				},
+				{
+					Failure: &testing.ScenarioFailure{
+						Code:    codes.Unavailable,
+						Message: "server is restarting",
+					},
+				},
+			},
+			func(conn *grpc.ClientConn) {
+				stream, err := eventsv1.NewEventsClient(conn).Watch(ctx, &eventsv1.EventsWatchRequest{})
+				Expect(err).ToNot(HaveOccurred())
+				_, err = stream.Recv()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = stream.Recv()
+				Expect(grpcstatus.Code(err)).To(Equal(codes.Unavailable))
+			},
+		)
+		stream, err := eventsv1.NewEventsClient(replay()).Watch(ctx, &eventsv1.EventsWatchRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		response, err := stream.Recv()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetEvent().GetId()).To(Equal("event-1"))
+		_, err = stream.Recv()
+		Expect(grpcstatus.Code(err)).To(Equal(codes.Unavailable))
+		Expect(grpcstatus.Convert(err).Message()).To(Equal("server is restarting"))
+	})
+
+	It("Keeps open the streams that weren't finished", func() {
+		record(
+			[]*testing.ScenarioEvent{{
+				ID:   "event-1",
+				Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				Cluster: &testing.ClusterEventData{
+					ID: "cluster-1",
+				},
+			}},
+			func(conn *grpc.ClientConn) {
+				streamCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				stream, err := eventsv1.NewEventsClient(conn).Watch(streamCtx, &eventsv1.EventsWatchRequest{})
+				Expect(err).ToNot(HaveOccurred())
+				_, err = stream.Recv()
+				Expect(err).ToNot(HaveOccurred())
+				cancel()
+				_, err = stream.Recv()
+				Expect(err).To(HaveOccurred())
+			},
+		)
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := eventsv1.NewEventsClient(replay()).Watch(streamCtx, &eventsv1.EventsWatchRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).ToNot(HaveOccurred())
+		done := make(chan error, 1)
+		go func() {
+			_, err := stream.Recv()
+			done <- err
+		}()
+		Consistently(done, "100ms").ShouldNot(Receive())
+		cancel()
+		Eventually(done).Should(Receive(MatchError(ContainSubstring("canceled"))))
+	})
+})
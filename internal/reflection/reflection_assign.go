@@ -0,0 +1,318 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListSeparator is the separator used for the values of list fields in the text given to the Assign method.
+const ListSeparator = ";"
+
+// CheckPath checks that the given path can be used with the Assign method. The path is a sequence of field names
+// separated by dots, for example 'spec.power_state'. Each name can be the protocol buffers name or the JSON name. For
+// map fields the segment that follows the name of the field is the key, for example 'metadata.labels.env'.
+func (h *ObjectHelper) CheckPath(path string) error {
+	_, err := h.assign(h.Instance().ProtoReflect(), path, "", false)
+	return err
+}
+
+// Assign parses the given text according to the type of the field identified by the path, and sets the value of that
+// field in the given object, creating the intermediate messages and map entries as needed. See the CheckPath method
+// for the syntax of the path.
+//
+// Scalar values use the usual text representation, enum values can be given with or without the prefix common to all
+// the values of the type, timestamps use RFC 3339 format, durations use the format of the Go time package, and bytes
+// use base64. The values of list fields are separated by semicolons.
+func (h *ObjectHelper) Assign(object proto.Message, path string, text string) error {
+	_, err := h.assign(object.ProtoReflect(), path, text, true)
+	return err
+}
+
+// assign walks the path, and if the set flag is true it also parses the text and sets the value. It returns the
+// descriptor of the field that the path points to.
+func (h *ObjectHelper) assign(message protoreflect.Message, path string, text string,
+	set bool) (result protoreflect.FieldDescriptor, err error) {
+	if path == "" {
+		err = fmt.Errorf("field path is empty")
+		return
+	}
+	segments := strings.Split(path, ".")
+	for i := 0; i < len(segments); i++ {
+		name := segments[i]
+		walked := strings.Join(segments[:i], ".")
+		field := explainLookup(message.Descriptor(), name)
+		if field == nil {
+			if walked == "" {
+				err = fmt.Errorf("type '%s' has no field '%s'", message.Descriptor().Name(), name)
+			} else {
+				err = fmt.Errorf("field '%s' has no field '%s'", walked, name)
+			}
+			return
+		}
+		last := i == len(segments)-1
+		current := strings.Join(segments[:i+1], ".")
+		switch {
+		case field.IsMap():
+			if last {
+				err = fmt.Errorf(
+					"field '%s' is a map, the path should contain the key, for example '%s.my-key'",
+					current, current,
+				)
+				return
+			}
+			i++
+			var key protoreflect.Value
+			key, err = assignParse(field.MapKey(), current, segments[i])
+			if err != nil {
+				return
+			}
+			entries := message.Mutable(field).Map()
+			value := field.MapValue()
+			if value.Message() != nil && !isAssignableMessage(value.Message()) {
+				if i == len(segments)-1 {
+					err = fmt.Errorf(
+						"values of map '%s' are messages, the path should contain one of their fields",
+						current,
+					)
+					return
+				}
+				message = entries.Mutable(key.MapKey()).Message()
+				continue
+			}
+			if i != len(segments)-1 {
+				err = fmt.Errorf("values of map '%s' have no fields", current)
+				return
+			}
+			result = field
+			if set {
+				var parsed protoreflect.Value
+				parsed, err = assignParse(value, current, text)
+				if err != nil {
+					return
+				}
+				entries.Set(key.MapKey(), parsed)
+			}
+			return
+		case field.IsList():
+			if !last {
+				err = fmt.Errorf("field '%s' is a list, it can only be the last part of the path", current)
+				return
+			}
+			if field.Message() != nil && !isAssignableMessage(field.Message()) {
+				err = fmt.Errorf("field '%s' is a list of messages, which isn't supported", current)
+				return
+			}
+			result = field
+			if set {
+				list := message.Mutable(field).List()
+				list.Truncate(0)
+				for _, item := range strings.Split(text, ListSeparator) {
+					var parsed protoreflect.Value
+					parsed, err = assignParse(field, current, strings.TrimSpace(item))
+					if err != nil {
+						return
+					}
+					list.Append(parsed)
+				}
+			}
+			return
+		case field.Message() != nil && !isAssignableMessage(field.Message()):
+			if last {
+				err = fmt.Errorf("field '%s' is a message, the path should contain one of its fields", current)
+				return
+			}
+			message = message.Mutable(field).Message()
+		default:
+			if !last {
+				err = fmt.Errorf("field '%s' of type '%s' has no fields", current, explainType(field))
+				return
+			}
+			result = field
+			if set {
+				var parsed protoreflect.Value
+				parsed, err = assignParse(field, current, text)
+				if err != nil {
+					return
+				}
+				message.Set(field, parsed)
+			}
+			return
+		}
+	}
+	return
+}
+
+// isAssignableMessage checks if the given message type can be assigned from a single text value. That is the case
+// for timestamps and durations.
+func isAssignableMessage(message protoreflect.MessageDescriptor) bool {
+	switch message.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration":
+		return true
+	default:
+		return false
+	}
+}
+
+// assignParse parses the given text according to the kind of the given field. The path is only used for error
+// messages.
+func assignParse(field protoreflect.FieldDescriptor, path string, text string) (result protoreflect.Value,
+	err error) {
+	fail := func(cause error) {
+		err = fmt.Errorf("value '%s' isn't valid for field '%s' of type '%s': %w", text, path,
+			explainKind(field), cause)
+	}
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		result = protoreflect.ValueOfString(text)
+	case protoreflect.BytesKind:
+		var data []byte
+		data, err = base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfBytes(data)
+	case protoreflect.BoolKind:
+		var value bool
+		value, err = strconv.ParseBool(text)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfBool(value)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var value int64
+		value, err = strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfInt32(int32(value))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var value int64
+		value, err = strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfInt64(value)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var value uint64
+		value, err = strconv.ParseUint(text, 10, 32)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfUint32(uint32(value))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var value uint64
+		value, err = strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfUint64(value)
+	case protoreflect.FloatKind:
+		var value float64
+		value, err = strconv.ParseFloat(text, 32)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfFloat32(float32(value))
+	case protoreflect.DoubleKind:
+		var value float64
+		value, err = strconv.ParseFloat(text, 64)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfFloat64(value)
+	case protoreflect.EnumKind:
+		var number protoreflect.EnumNumber
+		number, err = assignEnum(field.Enum(), text)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result = protoreflect.ValueOfEnum(number)
+	case protoreflect.MessageKind:
+		switch field.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			var value time.Time
+			value, err = time.Parse(time.RFC3339, text)
+			if err != nil {
+				fail(err)
+				return
+			}
+			result = protoreflect.ValueOfMessage(timestamppb.New(value).ProtoReflect())
+		case "google.protobuf.Duration":
+			var value time.Duration
+			value, err = time.ParseDuration(text)
+			if err != nil {
+				fail(err)
+				return
+			}
+			result = protoreflect.ValueOfMessage(durationpb.New(value).ProtoReflect())
+		default:
+			fail(fmt.Errorf("messages can't be assigned from text"))
+		}
+	default:
+		fail(fmt.Errorf("kind '%s' isn't supported", field.Kind()))
+	}
+	return
+}
+
+// assignEnum finds the enum value that matches the given text. The text can be the complete name of the value, the
+// name without the prefix that is common to all the values of the type, or the number.
+func assignEnum(enum protoreflect.EnumDescriptor, text string) (result protoreflect.EnumNumber, err error) {
+	values := enum.Values()
+	var matches []protoreflect.EnumValueDescriptor
+	for i := range values.Len() {
+		value := values.Get(i)
+		name := string(value.Name())
+		if strings.EqualFold(name, text) {
+			result = value.Number()
+			return
+		}
+		if strings.HasSuffix(strings.ToUpper(name), "_"+strings.ToUpper(text)) {
+			matches = append(matches, value)
+		}
+	}
+	if len(matches) == 1 {
+		result = matches[0].Number()
+		return
+	}
+	number, parseErr := strconv.ParseInt(text, 10, 32)
+	if parseErr == nil && values.ByNumber(protoreflect.EnumNumber(number)) != nil {
+		result = protoreflect.EnumNumber(number)
+		return
+	}
+	names := make([]string, values.Len())
+	for i := range values.Len() {
+		names[i] = string(values.Get(i).Name())
+	}
+	err = fmt.Errorf("should be one of '%s'", strings.Join(names, "', '"))
+	return
+}
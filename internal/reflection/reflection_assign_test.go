@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Assign", func() {
+	var clusters *ObjectHelper
+
+	BeforeEach(func() {
+		// Create the server:
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+
+		// Create the client connection:
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+
+		// Create the helper:
+		helper, err := NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusters = helper.Lookup("cluster")
+		Expect(clusters).ToNot(BeNil())
+	})
+
+	It("Assigns nested string fields", func() {
+		cluster := &ffv1.Cluster{}
+		Expect(clusters.Assign(cluster, "metadata.name", "my-cluster")).To(Succeed())
+		Expect(clusters.Assign(cluster, "spec.template", "my-template")).To(Succeed())
+		Expect(cluster.GetMetadata().GetName()).To(Equal("my-cluster"))
+		Expect(cluster.GetSpec().GetTemplate()).To(Equal("my-template"))
+	})
+
+	It("Accepts JSON names", func() {
+		cluster := &ffv1.Cluster{}
+		Expect(clusters.Assign(cluster, "metadata.creationTimestamp", "2025-06-01T10:00:00Z")).To(Succeed())
+		Expect(cluster.GetMetadata().GetCreationTimestamp().AsTime()).To(
+			BeTemporally("==", time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)),
+		)
+	})
+
+	It("Assigns map entries", func() {
+		cluster := &ffv1.Cluster{}
+		Expect(clusters.Assign(cluster, "metadata.labels.env", "prod")).To(Succeed())
+		Expect(clusters.Assign(cluster, "metadata.labels.team", "infra")).To(Succeed())
+		Expect(cluster.GetMetadata().GetLabels()).To(Equal(map[string]string{
+			"env":  "prod",
+			"team": "infra",
+		}))
+	})
+
+	It("Assigns fields of messages inside maps", func() {
+		cluster := &ffv1.Cluster{}
+		Expect(clusters.Assign(cluster, "spec.node_sets.workers.size", "3")).To(Succeed())
+		Expect(clusters.Assign(cluster, "spec.node_sets.workers.host_class", "large")).To(Succeed())
+		workers := cluster.GetSpec().GetNodeSets()["workers"]
+		Expect(workers.GetSize()).To(BeEquivalentTo(3))
+		Expect(workers.GetHostClass()).To(Equal("large"))
+	})
+
+	It("Assigns lists separated by semicolons", func() {
+		cluster := &ffv1.Cluster{}
+		Expect(clusters.Assign(cluster, "metadata.tenants", "a; b;c")).To(Succeed())
+		Expect(cluster.GetMetadata().GetTenants()).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("Assigns enums with and without prefix", func() {
+		cluster := &ffv1.Cluster{}
+		Expect(clusters.Assign(cluster, "status.state", "ready")).To(Succeed())
+		Expect(cluster.GetStatus().GetState()).To(Equal(ffv1.ClusterState_CLUSTER_STATE_READY))
+		Expect(clusters.Assign(cluster, "status.state", "CLUSTER_STATE_FAILED")).To(Succeed())
+		Expect(cluster.GetStatus().GetState()).To(Equal(ffv1.ClusterState_CLUSTER_STATE_FAILED))
+	})
+
+	It("Rejects invalid values", func() {
+		cluster := &ffv1.Cluster{}
+		err := clusters.Assign(cluster, "spec.node_sets.workers.size", "three")
+		Expect(err).To(MatchError(ContainSubstring(
+			"value 'three' isn't valid for field 'spec.node_sets.workers.size' of type 'int32'",
+		)))
+		err = clusters.Assign(cluster, "status.state", "sleeping")
+		Expect(err).To(MatchError(ContainSubstring("should be one of 'CLUSTER_STATE_UNSPECIFIED'")))
+	})
+
+	It("Checks paths", func() {
+		Expect(clusters.CheckPath("metadata.name")).To(Succeed())
+		Expect(clusters.CheckPath("metadata.labels.env")).To(Succeed())
+		Expect(clusters.CheckPath("metadata.nombre")).To(MatchError("field 'metadata' has no field 'nombre'"))
+		Expect(clusters.CheckPath("junk")).To(MatchError("type 'Cluster' has no field 'junk'"))
+		Expect(clusters.CheckPath("metadata")).To(MatchError(
+			"field 'metadata' is a message, the path should contain one of its fields",
+		))
+		Expect(clusters.CheckPath("metadata.labels")).To(MatchError(ContainSubstring(
+			"field 'metadata.labels' is a map, the path should contain the key",
+		)))
+		Expect(clusters.CheckPath("metadata.name.first")).To(MatchError(
+			"field 'metadata.name' of type 'string' has no fields",
+		))
+	})
+})
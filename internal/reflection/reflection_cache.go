@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// scanCache contains the results of scanning the protocol buffers registry, indexed by the set of packages that were
+// scanned. The registry doesn't change once the program has started, so the results can be shared by all the helpers
+// created for the same packages. That matters for completion and for scripts, where the same process may create many
+// helpers.
+var (
+	scanCache     = map[string][]ObjectHelper{}
+	scanCacheLock = &sync.Mutex{}
+)
+
+// Reset discards the cached results of scanning the protocol buffers registry, so that the next helper created will
+// scan it again. This is intended for tests that register additional descriptors.
+func Reset() {
+	scanCacheLock.Lock()
+	defer scanCacheLock.Unlock()
+	scanCache = map[string][]ObjectHelper{}
+}
+
+// scanKey calculates the key used to store in the cache the results of scanning the given packages. It includes the
+// order of the packages because that changes the order of the results.
+func scanKey(packages map[protoreflect.FullName]int) string {
+	items := make([]string, 0, len(packages))
+	for name, order := range packages {
+		items = append(items, fmt.Sprintf("%s=%d", name, order))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// cachedScan returns the object helpers for the packages of this helper, scanning the registry only if the results
+// aren't already in the cache. The returned helpers are copies whose parent is this helper.
+func (h *Helper) cachedScan() []ObjectHelper {
+	key := scanKey(h.packages)
+	scanCacheLock.Lock()
+	defer scanCacheLock.Unlock()
+	helpers, ok := scanCache[key]
+	if ok {
+		h.logger.Debug(
+			"Using cached descriptors",
+			slog.String("packages", key),
+			slog.Int("objects", len(helpers)),
+		)
+	} else {
+		h.scan()
+		helpers = slices.Clone(h.helpers)
+		for i := range helpers {
+			helpers[i].parent = nil
+		}
+		scanCache[key] = helpers
+	}
+	result := slices.Clone(helpers)
+	for i := range result {
+		result[i].parent = h
+	}
+	return result
+}
+
+// indexHelpers builds the maps used to find object helpers by full name, singular or plural. When several types have
+// the same singular or plural, like 'fulfillment.v1.Cluster' and 'private.v1.Cluster', the one that comes first in the
+// order of the packages wins.
+func (h *Helper) indexHelpers() {
+	h.byFullName = make(map[string]int, len(h.helpers))
+	h.byName = make(map[string]int, 2*len(h.helpers))
+	for i, helper := range h.helpers {
+		h.byFullName[string(helper.descriptor.FullName())] = i
+		for _, name := range []string{helper.singular, helper.plural} {
+			name = strings.ToLower(name)
+			_, ok := h.byName[name]
+			if !ok {
+				h.byName[name] = i
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var _ = Describe("Descriptors cache", func() {
+	var connection *grpc.ClientConn
+
+	BeforeEach(func() {
+		var err error
+		connection, err = grpc.NewClient(
+			"localhost:0",
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+		Reset()
+		DeferCleanup(Reset)
+	})
+
+	makeHelper := func(packages map[string]int) *Helper {
+		helper, err := NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackages(packages).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return helper
+	}
+
+	It("Shares the scan results between helpers for the same packages", func() {
+		first := makeHelper(map[string]int{"fulfillment.v1": 1})
+		Expect(first.Names()).ToNot(BeEmpty())
+		Expect(scanCache).To(HaveLen(1))
+		second := makeHelper(map[string]int{"fulfillment.v1": 1})
+		Expect(second.Names()).To(Equal(first.Names()))
+		Expect(scanCache).To(HaveLen(1))
+
+		// Each helper should still be the parent of its own object helpers:
+		Expect(first.Lookup("cluster").parent).To(BeIdenticalTo(first))
+		Expect(second.Lookup("cluster").parent).To(BeIdenticalTo(second))
+	})
+
+	It("Doesn't share the scan results between different packages or orders", func() {
+		makeHelper(map[string]int{"fulfillment.v1": 1}).Names()
+		makeHelper(map[string]int{"fulfillment.v1": 1, "private.v1": 0}).Names()
+		makeHelper(map[string]int{"fulfillment.v1": 0, "private.v1": 1}).Names()
+		Expect(scanCache).To(HaveLen(3))
+	})
+
+	It("Prefers the type of the first package when the names are the same", func() {
+		helper := makeHelper(map[string]int{"fulfillment.v1": 1, "private.v1": 0})
+		Expect(helper.Lookup("cluster").FullName()).To(BeEquivalentTo("private.v1.Cluster"))
+		Expect(helper.Lookup("Clusters").FullName()).To(BeEquivalentTo("private.v1.Cluster"))
+		Expect(helper.Lookup("fulfillment.v1.Cluster").FullName()).To(BeEquivalentTo("fulfillment.v1.Cluster"))
+		Expect(helper.Lookup("junk")).To(BeNil())
+	})
+
+	It("Scans again after reset", func() {
+		makeHelper(map[string]int{"fulfillment.v1": 1}).Names()
+		Expect(scanCache).To(HaveLen(1))
+		Reset()
+		Expect(scanCache).To(BeEmpty())
+	})
+})
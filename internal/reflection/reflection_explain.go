@@ -0,0 +1,196 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Field contains the documentation of a field, extracted from the protocol buffers descriptors.
+type Field struct {
+	// Name is the protocol buffers name of the field, for example 'node_sets'.
+	Name string
+
+	// Type is the description of the type of the field, for example 'string', '[]string' or
+	// 'map[string]ClusterNodeSet'.
+	Type string
+
+	// Cardinality is 'optional', 'repeated' or 'map' for fields that have explicit presence, lists and maps
+	// respectively. It is empty for the rest of the fields.
+	Cardinality string
+
+	// Description is the text of the comments of the field in the source file. It will be empty if the descriptors
+	// don't include source information.
+	Description string
+
+	// Values contains the names of the values of enum fields.
+	Values []string
+
+	// Fields contains the fields of the message type of the field, or of the type of the values if it is a list or
+	// a map. It is only populated up to the depth requested.
+	Fields []*Field
+}
+
+// Explain returns the documentation of the field with the given path. The path is a sequence of field names separated
+// by dots, for example 'spec.node_sets'. An empty path means the object itself. Each name can be the protocol buffers
+// name or the JSON name. Lists and maps are traversed using the type of their values.
+//
+// The depth parameter indicates how many levels of nested fields will be populated. Zero means only the field itself,
+// and a negative value means all the levels. Messages that contain themselves are only expanded once, and well known
+// types like timestamps aren't expanded.
+func (h *ObjectHelper) Explain(path string, depth int) (result *Field, err error) {
+	// Find the field:
+	result = &Field{
+		Name: string(h.descriptor.Name()),
+		Type: string(h.descriptor.Name()),
+	}
+	current := h.descriptor
+	var field protoreflect.FieldDescriptor
+	var walked []string
+	if path != "" {
+		for _, name := range strings.Split(path, ".") {
+			if current == nil {
+				err = fmt.Errorf(
+					"field '%s' is of type '%s' and has no field '%s'",
+					strings.Join(walked, "."), explainType(field), name,
+				)
+				return
+			}
+			field = explainLookup(current, name)
+			if field == nil {
+				if len(walked) == 0 {
+					err = fmt.Errorf("type '%s' has no field '%s'", h.descriptor.Name(), name)
+				} else {
+					err = fmt.Errorf("field '%s' has no field '%s'", strings.Join(walked, "."), name)
+				}
+				return
+			}
+			walked = append(walked, string(field.Name()))
+			current = explainMessage(field)
+		}
+		result = explainField(field)
+	} else {
+		result.Description = explainComments(h.descriptor)
+	}
+
+	// Populate the nested fields:
+	if current != nil && depth != 0 {
+		result.Fields = explainFields(current, depth, map[protoreflect.FullName]bool{})
+	}
+	return
+}
+
+func explainLookup(message protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := message.Fields()
+	field := fields.ByName(protoreflect.Name(name))
+	if field == nil {
+		field = fields.ByJSONName(name)
+	}
+	return field
+}
+
+func explainFields(message protoreflect.MessageDescriptor, depth int,
+	visited map[protoreflect.FullName]bool) []*Field {
+	visited[message.FullName()] = true
+	defer delete(visited, message.FullName())
+	fields := message.Fields()
+	result := make([]*Field, fields.Len())
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		result[i] = explainField(field)
+		nested := explainMessage(field)
+		if nested != nil && depth != 1 && !visited[nested.FullName()] {
+			result[i].Fields = explainFields(nested, depth-1, visited)
+		}
+	}
+	return result
+}
+
+func explainField(field protoreflect.FieldDescriptor) *Field {
+	result := &Field{
+		Name:        string(field.Name()),
+		Type:        explainType(field),
+		Description: explainComments(field),
+	}
+	switch {
+	case field.IsMap():
+		result.Cardinality = "map"
+	case field.IsList():
+		result.Cardinality = "repeated"
+	case field.HasOptionalKeyword():
+		result.Cardinality = "optional"
+	}
+	enum := field.Enum()
+	if field.IsMap() {
+		enum = field.MapValue().Enum()
+	}
+	if enum != nil {
+		values := enum.Values()
+		result.Values = make([]string, values.Len())
+		for i := range values.Len() {
+			result.Values[i] = string(values.Get(i).Name())
+		}
+	}
+	return result
+}
+
+// explainMessage returns the descriptor of the message that should be used to explain the nested fields of the given
+// field. That is the type of the field, or of the values in the case of lists and maps. Returns nil if the field
+// has no nested fields, or if it is a well known type.
+func explainMessage(field protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if field.IsMap() {
+		field = field.MapValue()
+	}
+	message := field.Message()
+	if message == nil || message.ParentFile().Package() == "google.protobuf" {
+		return nil
+	}
+	return message
+}
+
+func explainType(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return fmt.Sprintf("map[%s]%s", explainKind(field.MapKey()), explainKind(field.MapValue()))
+	case field.IsList():
+		return "[]" + explainKind(field)
+	default:
+		return explainKind(field)
+	}
+}
+
+func explainKind(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().Name())
+	case protoreflect.EnumKind:
+		return string(field.Enum().Name())
+	default:
+		return field.Kind().String()
+	}
+}
+
+func explainComments(desc protoreflect.Descriptor) string {
+	location := desc.ParentFile().SourceLocations().ByDescriptor(desc)
+	return strings.TrimSpace(location.LeadingComments)
+}
+
+// FieldType returns the description of the type of the given field, in the same format used by the Explain method,
+// for example '[]string' or 'map[string]string'.
+func FieldType(field protoreflect.FieldDescriptor) string {
+	return explainType(field)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Explain", func() {
+	var clusters *ObjectHelper
+
+	BeforeEach(func() {
+		// Create the server:
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+
+		// Create the client connection:
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+
+		// Create the helper:
+		helper, err := NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusters = helper.Lookup("cluster")
+		Expect(clusters).ToNot(BeNil())
+	})
+
+	It("Explains the object itself", func() {
+		field, err := clusters.Explain("", 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(field.Name).To(Equal("Cluster"))
+		var names []string
+		for _, nested := range field.Fields {
+			names = append(names, nested.Name)
+			Expect(nested.Fields).To(BeEmpty())
+		}
+		Expect(names).To(Equal([]string{"id", "metadata", "spec", "status"}))
+	})
+
+	It("Explains a map field", func() {
+		field, err := clusters.Explain("spec.node_sets", 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(field.Name).To(Equal("node_sets"))
+		Expect(field.Type).To(Equal("map[string]ClusterNodeSet"))
+		Expect(field.Cardinality).To(Equal("map"))
+		Expect(field.Fields).To(HaveLen(2))
+		Expect(field.Fields[0].Name).To(Equal("host_class"))
+		Expect(field.Fields[0].Type).To(Equal("string"))
+		Expect(field.Fields[1].Name).To(Equal("size"))
+		Expect(field.Fields[1].Type).To(Equal("int32"))
+	})
+
+	It("Accepts JSON names", func() {
+		field, err := clusters.Explain("spec.nodeSets", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(field.Name).To(Equal("node_sets"))
+		Expect(field.Fields).To(BeEmpty())
+	})
+
+	It("Explains list and enum fields", func() {
+		field, err := clusters.Explain("status.conditions", 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(field.Type).To(Equal("[]ClusterCondition"))
+		Expect(field.Cardinality).To(Equal("repeated"))
+		Expect(field.Fields[0].Name).To(Equal("type"))
+		Expect(field.Fields[0].Values).To(ContainElement("CLUSTER_CONDITION_TYPE_READY"))
+		Expect(field.Fields[3].Name).To(Equal("reason"))
+		Expect(field.Fields[3].Cardinality).To(Equal("optional"))
+	})
+
+	It("Expands all the levels when depth is negative", func() {
+		field, err := clusters.Explain("spec", -1)
+		Expect(err).ToNot(HaveOccurred())
+		var nodeSets *Field
+		for _, nested := range field.Fields {
+			if nested.Name == "node_sets" {
+				nodeSets = nested
+			}
+		}
+		Expect(nodeSets).ToNot(BeNil())
+		Expect(nodeSets.Fields).To(HaveLen(2))
+	})
+
+	It("Doesn't expand well known types", func() {
+		field, err := clusters.Explain("metadata.creation_timestamp", -1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(field.Type).To(Equal("Timestamp"))
+		Expect(field.Fields).To(BeEmpty())
+	})
+
+	It("Fails if the field doesn't exist", func() {
+		_, err := clusters.Explain("spec.junk", 1)
+		Expect(err).To(MatchError("field 'spec' has no field 'junk'"))
+	})
+
+	It("Fails if the field isn't a message", func() {
+		_, err := clusters.Explain("id.junk", 1)
+		Expect(err).To(MatchError("field 'id' is of type 'string' and has no field 'junk'"))
+	})
+})
@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FilterExample is an example of a CEL filter expression for a field of an object, generated from the protocol
+// buffers descriptors.
+type FilterExample struct {
+	// Path is the path of the field as it is used in filters, for example 'this.metadata.name'.
+	Path string `json:"path"`
+
+	// Type is the description of the type of the field, in the same format used by the Explain method.
+	Type string `json:"type"`
+
+	// Expression is the example filter expression.
+	Expression string `json:"expression"`
+}
+
+// FilterExamples generates examples of CEL filter expressions for the fields of the object. The depth parameter
+// indicates how many levels of nested messages are traversed, for example with a depth of two the examples will
+// include 'this.metadata.name' but not 'this.spec.node_sets.host_class'. Message fields in the last level, and those
+// of well known types other than timestamps and durations, get an example that checks their presence.
+func (h *ObjectHelper) FilterExamples(depth int) []FilterExample {
+	return filterExamples(h.descriptor, "this", depth, map[protoreflect.FullName]bool{})
+}
+
+func filterExamples(message protoreflect.MessageDescriptor, prefix string, depth int,
+	visited map[protoreflect.FullName]bool) (result []FilterExample) {
+	visited[message.FullName()] = true
+	defer delete(visited, message.FullName())
+	fields := message.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		path := fmt.Sprintf("%s.%s", prefix, field.Name())
+		nested := explainMessage(field)
+		if nested != nil && !field.IsMap() && !field.IsList() && depth > 1 && !visited[nested.FullName()] {
+			result = append(result, filterExamples(nested, path, depth-1, visited)...)
+			continue
+		}
+		for _, expression := range filterExpressions(field, path) {
+			result = append(result, FilterExample{
+				Path:       path,
+				Type:       explainType(field),
+				Expression: expression,
+			})
+		}
+	}
+	return
+}
+
+// filterExpressions returns the example expressions for the given field.
+func filterExpressions(field protoreflect.FieldDescriptor, path string) []string {
+	switch {
+	case field.IsMap():
+		if field.MapValue().Message() != nil {
+			return []string{
+				fmt.Sprintf(`"my-key" in %s`, path),
+			}
+		}
+		return []string{
+			fmt.Sprintf(`"my-key" in %s`, path),
+			fmt.Sprintf(`%s["my-key"] == %s`, path, filterValue(field.MapValue())),
+		}
+	case field.IsList():
+		if field.Message() != nil {
+			return []string{
+				fmt.Sprintf(`size(%s) > 0`, path),
+			}
+		}
+		return []string{
+			fmt.Sprintf(`%s in %s`, filterValue(field), path),
+		}
+	}
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		if field.Name() == "name" {
+			return []string{
+				fmt.Sprintf(`%s == "my-name"`, path),
+				fmt.Sprintf(`%s.startsWith("prod-")`, path),
+			}
+		}
+		return []string{
+			fmt.Sprintf(`%s == %s`, path, filterValue(field)),
+		}
+	case protoreflect.BoolKind:
+		return []string{
+			path,
+			fmt.Sprintf(`!%s`, path),
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch field.Message().FullName() {
+		case "google.protobuf.Timestamp", "google.protobuf.Duration":
+			return []string{
+				fmt.Sprintf(`%s > %s`, path, filterValue(field)),
+			}
+		}
+		return []string{
+			fmt.Sprintf(`has(%s)`, path),
+		}
+	case protoreflect.EnumKind:
+		return []string{
+			fmt.Sprintf(`%s == %s`, path, filterValue(field)),
+		}
+	default:
+		return []string{
+			fmt.Sprintf(`%s > %s`, path, filterValue(field)),
+		}
+	}
+}
+
+// filterValue returns an example literal for the type of the given field, ignoring the cardinality.
+func filterValue(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		if field.Name() == "id" {
+			return `"123"`
+		}
+		return `"my-value"`
+	case protoreflect.BoolKind:
+		return "true"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "1.5"
+	case protoreflect.BytesKind:
+		return `b"my-value"`
+	case protoreflect.EnumKind:
+		// Use the first value that isn't the unspecified one, as that is rarely useful in filters:
+		values := field.Enum().Values()
+		value := values.Get(0)
+		if values.Len() > 1 {
+			value = values.Get(1)
+		}
+		return string(field.Enum().FullName().Append(value.Name()))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch field.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			return `timestamp("2025-01-01T00:00:00Z")`
+		case "google.protobuf.Duration":
+			return `duration("1h")`
+		}
+		return "{}"
+	default:
+		return "1"
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Filter examples", func() {
+	var clusters *ObjectHelper
+
+	BeforeEach(func() {
+		// Create the server:
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+
+		// Create the client connection:
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+
+		// Create the helper:
+		helper, err := NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusters = helper.Lookup("cluster")
+		Expect(clusters).ToNot(BeNil())
+	})
+
+	// expressions returns the expressions of the examples, to simplify the checks.
+	expressions := func(examples []FilterExample) []string {
+		var result []string
+		for _, example := range examples {
+			result = append(result, example.Expression)
+		}
+		return result
+	}
+
+	It("Generates presence checks for the top level messages", func() {
+		examples := clusters.FilterExamples(1)
+		Expect(expressions(examples)).To(ContainElements(
+			`this.id == "123"`,
+			`has(this.metadata)`,
+			`has(this.spec)`,
+			`has(this.status)`,
+		))
+	})
+
+	It("Generates examples for the nested fields", func() {
+		examples := clusters.FilterExamples(2)
+		Expect(expressions(examples)).To(ContainElements(
+			`this.metadata.name == "my-name"`,
+			`this.metadata.name.startsWith("prod-")`,
+			`"my-key" in this.metadata.labels`,
+			`this.metadata.labels["my-key"] == "my-value"`,
+			`this.metadata.creation_timestamp > timestamp("2025-01-01T00:00:00Z")`,
+			`this.spec.template == "my-value"`,
+			`"my-key" in this.spec.node_sets`,
+			`size(this.status.conditions) > 0`,
+		))
+		Expect(expressions(examples)).ToNot(ContainElement(`has(this.metadata)`))
+	})
+
+	It("Uses the fully qualified name of enum values", func() {
+		examples := clusters.FilterExamples(2)
+		var state *FilterExample
+		for i := range examples {
+			if examples[i].Path == "this.status.state" {
+				state = &examples[i]
+			}
+		}
+		Expect(state).ToNot(BeNil())
+		Expect(state.Type).To(Equal("ClusterState"))
+		Expect(state.Expression).To(MatchRegexp(
+			`^this\.status\.state == fulfillment\.v1\.ClusterState\.CLUSTER_STATE_[A-Z]+$`,
+		))
+		Expect(state.Expression).ToNot(HaveSuffix("UNSPECIFIED"))
+	})
+})
@@ -51,6 +51,7 @@ const (
 	limitFieldName    = protoreflect.Name("limit")
 	metadataFieldName = protoreflect.Name("metadata")
 	objectFieldName   = protoreflect.Name("object")
+	pathsFieldName    = protoreflect.Name("paths")
 	totalFieldName    = protoreflect.Name("total")
 )
 
@@ -75,6 +76,8 @@ type Helper struct {
 	scanOnce   *sync.Once
 	pluralizer *pluralize.Client
 	helpers    []ObjectHelper
+	byFullName map[string]int
+	byName     map[string]int
 }
 
 // NewHelper creates a builder that can then be used to configure a reflection helper.
@@ -155,7 +158,8 @@ func (b *HelperBuilder) Build() (result *Helper, err error) {
 
 func (h *Helper) scanIfNeeded() {
 	h.scanOnce.Do(func() {
-		h.scan()
+		h.helpers = h.cachedScan()
+		h.indexHelpers()
 	})
 }
 
@@ -288,6 +292,9 @@ func (h *Helper) scanService(serviceDesc protoreflect.ServiceDescriptor) {
 		return
 	}
 
+	// The request of the `Update` method may have a field mask field:
+	updateRequestMaskFieldDesc := h.getMaskField(updateDesc.Input())
+
 	// The request of the `Delete` method must have an `id` string field:
 	deleteRequestIdFieldDesc := h.getIdField(deleteDesc.Input())
 	if deleteRequestIdFieldDesc == nil {
@@ -349,8 +356,9 @@ func (h *Helper) scanService(serviceDesc protoreflect.ServiceDescriptor) {
 				request:  createRequestTemplate,
 				response: createResponseTemplate,
 			},
-			in:  createRequestObjectFieldDesc,
-			out: createResponseObjectFieldDesc,
+			method: createDesc,
+			in:     createRequestObjectFieldDesc,
+			out:    createResponseObjectFieldDesc,
 		},
 		update: updateInfo{
 			methodInfo: methodInfo{
@@ -358,8 +366,9 @@ func (h *Helper) scanService(serviceDesc protoreflect.ServiceDescriptor) {
 				request:  updateRequestTemplate,
 				response: updateResponseTemplate,
 			},
-			in:  updateRequestObjectFieldDesc,
-			out: updateResponseObjectFieldDesc,
+			in:   updateRequestObjectFieldDesc,
+			out:  updateResponseObjectFieldDesc,
+			mask: updateRequestMaskFieldDesc,
 		},
 		delete: deleteInfo{
 			methodInfo: methodInfo{
@@ -457,6 +466,22 @@ func (h *Helper) getTotalField(messageDesc protoreflect.MessageDescriptor) proto
 	return fieldDesc
 }
 
+// getMaskField returns the first field of the given message that is a field mask, or nil if there is no such field.
+func (h *Helper) getMaskField(messageDesc protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	fields := messageDesc.Fields()
+	for i := range fields.Len() {
+		fieldDesc := fields.Get(i)
+		if fieldDesc.Cardinality() == protoreflect.Repeated {
+			continue
+		}
+		if fieldDesc.Kind() != protoreflect.MessageKind || fieldDesc.Message().FullName() != fieldMaskName {
+			continue
+		}
+		return fieldDesc
+	}
+	return nil
+}
+
 // Names returns the full names of the object types. The results are sorted by the order of the packages, and
 // alphabetically within each package.
 func (h *Helper) Names() []string {
@@ -495,18 +520,14 @@ func (h *Helper) Plurals() []string {
 // Lookup returns the helper for the given object type. Returns nil if there is no such object.
 func (h *Helper) Lookup(objectType string) *ObjectHelper {
 	h.scanIfNeeded()
-	for i, objectInfo := range h.helpers {
-		if objectType == string(objectInfo.descriptor.FullName()) {
-			return &h.helpers[i]
-		}
-		if strings.EqualFold(objectType, objectInfo.singular) {
-			return &h.helpers[i]
-		}
-		if strings.EqualFold(objectType, objectInfo.plural) {
-			return &h.helpers[i]
-		}
+	i, ok := h.byFullName[objectType]
+	if !ok {
+		i, ok = h.byName[strings.ToLower(objectType)]
 	}
-	return nil
+	if !ok {
+		return nil
+	}
+	return &h.helpers[i]
 }
 
 func (h *Helper) makeMethodPath(methodDesc protoreflect.MethodDescriptor) string {
@@ -566,14 +587,16 @@ type listInfo struct {
 
 type createInfo struct {
 	methodInfo
-	in  protoreflect.FieldDescriptor
-	out protoreflect.FieldDescriptor
+	method protoreflect.MethodDescriptor
+	in     protoreflect.FieldDescriptor
+	out    protoreflect.FieldDescriptor
 }
 
 type updateInfo struct {
 	methodInfo
-	in  protoreflect.FieldDescriptor
-	out protoreflect.FieldDescriptor
+	in   protoreflect.FieldDescriptor
+	out  protoreflect.FieldDescriptor
+	mask protoreflect.FieldDescriptor
 }
 
 type deleteInfo struct {
@@ -677,9 +700,17 @@ func (h *ObjectHelper) Create(ctx context.Context, object proto.Message) (result
 	return
 }
 
-func (h *ObjectHelper) Update(ctx context.Context, object proto.Message) (result proto.Message, err error) {
+// Update saves the given object. When paths are given, and the update request supports a field mask, only those
+// paths are sent in the mask, so that the server changes only those fields and doesn't overwrite changes made
+// concurrently to other fields. Use the ChangedPaths function to calculate the paths. Without paths the complete object
+// is replaced, so when ChangedPaths returns no paths callers should skip the update instead.
+func (h *ObjectHelper) Update(ctx context.Context, object proto.Message, paths ...string) (result proto.Message,
+	err error) {
 	request := proto.Clone(h.update.request)
 	h.setObject(request, h.update.in, object)
+	if len(paths) > 0 && h.update.mask != nil {
+		h.setMask(request, h.update.mask, paths)
+	}
 	response := proto.Clone(h.update.response)
 	err = h.parent.connection.Invoke(ctx, h.update.path, request, response)
 	if err != nil {
@@ -704,6 +735,14 @@ func (h *ObjectHelper) setObject(message proto.Message, field protoreflect.Field
 	message.ProtoReflect().Set(field, protoreflect.ValueOfMessage(value.ProtoReflect()))
 }
 
+func (h *ObjectHelper) setMask(message proto.Message, field protoreflect.FieldDescriptor, paths []string) {
+	mask := message.ProtoReflect().Mutable(field).Message()
+	list := mask.Mutable(mask.Descriptor().Fields().ByName(pathsFieldName)).List()
+	for _, path := range paths {
+		list.Append(protoreflect.ValueOfString(path))
+	}
+}
+
 func (h *ObjectHelper) getObject(message proto.Message, field protoreflect.FieldDescriptor) proto.Message {
 	return message.ProtoReflect().Get(field).Message().Interface()
 }
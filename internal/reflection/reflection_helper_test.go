@@ -503,6 +503,58 @@ var _ = Describe("Reflection helper", func() {
 			)).To(BeTrue())
 		})
 
+		It("Sends the field mask when paths are given to the update method", func() {
+			// Register a clusters server that checks the mask of the update request:
+			ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+				UpdateFunc: func(ctx context.Context, request *ffv1.ClustersUpdateRequest,
+				) (response *ffv1.ClustersUpdateResponse, err error) {
+					defer GinkgoRecover()
+					Expect(request.GetUpdateMask().GetPaths()).To(ConsistOf("metadata.labels"))
+					response = ffv1.ClustersUpdateResponse_builder{
+						Object: request.GetObject(),
+					}.Build()
+					return
+				},
+			})
+
+			// Start the server:
+			server.Start()
+
+			// Use the helper to send the request:
+			objectHelper := helper.Lookup("cluster")
+			Expect(objectHelper).ToNot(BeNil())
+			_, err := objectHelper.Update(ctx, ffv1.Cluster_builder{
+				Id: "123",
+			}.Build(), "metadata.labels")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Doesn't send the field mask when no paths are given to the update method", func() {
+			// Register a clusters server that checks the mask of the update request:
+			ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+				UpdateFunc: func(ctx context.Context, request *ffv1.ClustersUpdateRequest,
+				) (response *ffv1.ClustersUpdateResponse, err error) {
+					defer GinkgoRecover()
+					Expect(request.HasUpdateMask()).To(BeFalse())
+					response = ffv1.ClustersUpdateResponse_builder{
+						Object: request.GetObject(),
+					}.Build()
+					return
+				},
+			})
+
+			// Start the server:
+			server.Start()
+
+			// Use the helper to send the request:
+			objectHelper := helper.Lookup("cluster")
+			Expect(objectHelper).ToNot(BeNil())
+			_, err := objectHelper.Update(ctx, ffv1.Cluster_builder{
+				Id: "123",
+			}.Build())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("Invokes delete method", func() {
 			// Register a clusters server that responds to the delete request:
 			ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
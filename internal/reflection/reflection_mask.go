@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldMaskName is the full name of the message type used by update requests to indicate the fields to change.
+const fieldMaskName = protoreflect.FullName("google.protobuf.FieldMask")
+
+// wellKnownPackage is the package of the well known types, like timestamps and durations. Those are compared as a
+// whole, because a field mask can't select their internal fields.
+const wellKnownPackage = protoreflect.FullName("google.protobuf")
+
+// ChangedPaths compares two versions of an object and returns the field mask paths of the fields that are different,
+// sorted. Messages are compared field by field, but lists, maps, scalars and well known types are compared as a whole,
+// as field masks can't select individual elements of lists or maps. For example, adding a label returns only the
+// 'metadata.labels' path.
+func ChangedPaths(before, after proto.Message) []string {
+	var result []string
+	changedPaths(nil, before.ProtoReflect(), after.ProtoReflect(), &result)
+	slices.Sort(result)
+	return result
+}
+
+func changedPaths(prefix []string, before, after protoreflect.Message, paths *[]string) {
+	fields := before.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		path := append(slices.Clone(prefix), string(field.Name()))
+		beforeSet := before.Has(field)
+		afterSet := after.Has(field)
+		if !beforeSet && !afterSet {
+			continue
+		}
+		if beforeSet && afterSet && isNestedMessage(field) {
+			changedPaths(path, before.Get(field).Message(), after.Get(field).Message(), paths)
+			continue
+		}
+		if beforeSet != afterSet || !before.Get(field).Equal(after.Get(field)) {
+			*paths = append(*paths, strings.Join(path, "."))
+		}
+	}
+}
+
+func isNestedMessage(field protoreflect.FieldDescriptor) bool {
+	if field.IsList() || field.IsMap() || field.Message() == nil {
+		return false
+	}
+	return field.Message().ParentFile().Package() != wellKnownPackage
+}
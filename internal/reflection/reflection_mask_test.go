@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("Changed paths", func() {
+	It("Returns nothing if the objects are equal", func() {
+		cluster := ffv1.Cluster_builder{
+			Id: "123",
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+			}.Build(),
+		}.Build()
+		Expect(ChangedPaths(cluster, cluster)).To(BeEmpty())
+	})
+
+	It("Returns the path of a changed nested scalar field", func() {
+		before := ffv1.Cluster_builder{
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "before",
+			}.Build(),
+		}.Build()
+		after := ffv1.Cluster_builder{
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "after",
+			}.Build(),
+		}.Build()
+		Expect(ChangedPaths(before, after)).To(Equal([]string{"spec.template"}))
+	})
+
+	It("Returns the path of the map, not of the changed key", func() {
+		before := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+				Labels: map[string]string{
+					"a": "1",
+				},
+			}.Build(),
+		}.Build()
+		after := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+				Labels: map[string]string{
+					"a": "1",
+					"b": "2",
+				},
+			}.Build(),
+		}.Build()
+		Expect(ChangedPaths(before, after)).To(Equal([]string{"metadata.labels"}))
+	})
+
+	It("Returns the path of a removed message", func() {
+		before := ffv1.Cluster_builder{
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "my-template",
+			}.Build(),
+		}.Build()
+		after := ffv1.Cluster_builder{}.Build()
+		Expect(ChangedPaths(before, after)).To(Equal([]string{"spec"}))
+	})
+
+	It("Compares well known types as a whole", func() {
+		before := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				CreationTimestamp: timestamppb.New(time.Unix(1, 0)),
+			}.Build(),
+		}.Build()
+		after := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				CreationTimestamp: timestamppb.New(time.Unix(2, 0)),
+			}.Build(),
+		}.Build()
+		Expect(ChangedPaths(before, after)).To(Equal([]string{"metadata.creation_timestamp"}))
+	})
+
+	It("Returns multiple paths sorted", func() {
+		before := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "before",
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "before",
+			}.Build(),
+		}.Build()
+		after := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "after",
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "after",
+			}.Build(),
+		}.Build()
+		Expect(ChangedPaths(before, after)).To(Equal([]string{"metadata.name", "spec.template"}))
+	})
+})
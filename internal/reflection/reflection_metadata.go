@@ -13,6 +13,10 @@ language governing permissions and limitations under the License.
 
 package reflection
 
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
 // Metadata is an interface that provides access to common metadata fields in protobuf messages.
 type Metadata interface {
 	GetName() string
@@ -20,4 +24,5 @@ type Metadata interface {
 	SetLabels(map[string]string)
 	GetAnnotations() map[string]string
 	SetAnnotations(map[string]string)
+	GetDeletionTimestamp() *timestamppb.Timestamp
 }
@@ -0,0 +1,265 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// CompatibilityError is returned by the Probe method when the server doesn't support the type of the object, the
+// method used to create it, or some of the fields that are set in the object. This usually means that the server
+// uses an older version of the API than the one compiled into the CLI.
+type CompatibilityError struct {
+	// Type is the full name of the type of the object.
+	Type protoreflect.FullName
+
+	// Method is the full name of the create method, only when the server doesn't have it.
+	Method protoreflect.FullName
+
+	// Fields contains the paths of the fields that are set in the object but that the server doesn't know.
+	Fields []string
+}
+
+// Error is the implementation of the error interface.
+func (e *CompatibilityError) Error() string {
+	var buffer strings.Builder
+	switch {
+	case e.Method != "":
+		fmt.Fprintf(
+			&buffer,
+			"server can't create '%s' objects because it doesn't have the '%s' method",
+			e.Type, e.Method,
+		)
+	case len(e.Fields) > 0:
+		quoted := make([]string, len(e.Fields))
+		for i, field := range e.Fields {
+			quoted[i] = fmt.Sprintf("'%s'", field)
+		}
+		fmt.Fprintf(
+			&buffer,
+			"server doesn't support fields %s of '%s' objects",
+			strings.Join(quoted, ", "), e.Type,
+		)
+	default:
+		fmt.Fprintf(&buffer, "server doesn't support '%s' objects", e.Type)
+	}
+	buffer.WriteString(", this usually means that the server uses an older version of the API than the CLI")
+	return buffer.String()
+}
+
+// Probe checks that the server can create the given object before actually trying to create it. To do so it uses the
+// gRPC reflection service to fetch the descriptors that the server uses, and verifies that the create method exists
+// and that all the fields that are set in the object are known by the server. It returns a *CompatibilityError if
+// that isn't the case. Servers that don't support reflection can't be checked, and then the probe always succeeds.
+func (h *ObjectHelper) Probe(ctx context.Context, object proto.Message) error {
+	logger := h.parent.logger
+	methodName := h.create.method.FullName()
+	serviceName := methodName.Parent()
+	typeName := h.descriptor.FullName()
+
+	// Fetch the descriptors from the server:
+	files, missing, err := h.parent.fetchDescriptors(ctx, serviceName, typeName)
+	if err != nil {
+		// Failing to fetch the descriptors doesn't mean that the object can't be created, the server may just not
+		// have enabled reflection, or may not allow it to this user, so we skip the check.
+		level := slog.LevelWarn
+		if status.Code(err) == codes.Unimplemented {
+			level = slog.LevelDebug
+		}
+		logger.LogAttrs(
+			ctx,
+			level,
+			"Skipping compatibility check because the server descriptors aren't available",
+			slog.String("type", string(typeName)),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	if slices.Contains(missing, serviceName) {
+		return &CompatibilityError{
+			Type:   typeName,
+			Method: methodName,
+		}
+	}
+	if slices.Contains(missing, typeName) {
+		return &CompatibilityError{
+			Type: typeName,
+		}
+	}
+
+	// Check that the server has the create method:
+	_, err = files.FindDescriptorByName(methodName)
+	if errors.Is(err, protoregistry.NotFound) {
+		return &CompatibilityError{
+			Type:   typeName,
+			Method: methodName,
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find method '%s' in server descriptors: %w", methodName, err)
+	}
+
+	// Check that the server knows all the fields that are set in the object:
+	typeDesc, err := files.FindDescriptorByName(typeName)
+	if err != nil {
+		return fmt.Errorf("failed to find type '%s' in server descriptors: %w", typeName, err)
+	}
+	messageDesc, ok := typeDesc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return &CompatibilityError{
+			Type: typeName,
+		}
+	}
+	fields := map[string]bool{}
+	probeFields(object.ProtoReflect(), messageDesc, "", fields)
+	if len(fields) > 0 {
+		paths := make([]string, 0, len(fields))
+		for path := range fields {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		return &CompatibilityError{
+			Type:   typeName,
+			Fields: paths,
+		}
+	}
+	logger.DebugContext(
+		ctx,
+		"Server supports object",
+		slog.String("type", string(typeName)),
+		slog.String("method", string(methodName)),
+	)
+	return nil
+}
+
+// probeFields compares the fields that are set in the given local message with the fields of the descriptor that the
+// server uses, and adds to the given set the paths of the fields that the server doesn't know. Fields with the same
+// number but a different name or kind are also considered unknown, as the server would interpret them differently.
+func probeFields(local protoreflect.Message, remote protoreflect.MessageDescriptor, prefix string,
+	unknown map[string]bool) {
+	local.Range(func(localField protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		path := prefix + string(localField.Name())
+		remoteField := remote.Fields().ByNumber(localField.Number())
+		if remoteField == nil || remoteField.Name() != localField.Name() || remoteField.Kind() != localField.Kind() {
+			unknown[path] = true
+			return true
+		}
+		switch {
+		case localField.IsMap():
+			if localField.MapValue().Message() == nil || remoteField.MapValue().Message() == nil {
+				break
+			}
+			value.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+				probeFields(item.Message(), remoteField.MapValue().Message(), path+".", unknown)
+				return true
+			})
+		case localField.IsList():
+			if localField.Message() == nil || remoteField.Message() == nil {
+				break
+			}
+			items := value.List()
+			for i := range items.Len() {
+				probeFields(items.Get(i).Message(), remoteField.Message(), path+".", unknown)
+			}
+		case localField.Message() != nil && remoteField.Message() != nil:
+			probeFields(value.Message(), remoteField.Message(), path+".", unknown)
+		}
+		return true
+	})
+}
+
+// fetchDescriptors uses the gRPC reflection service of the server to fetch the files that contain the given symbols,
+// including their dependencies. It returns the registry built from those files and the list of symbols that the
+// server doesn't know.
+func (h *Helper) fetchDescriptors(ctx context.Context,
+	symbols ...protoreflect.FullName) (files *protoregistry.Files, missing []protoreflect.FullName, err error) {
+	client := reflectionv1.NewServerReflectionClient(h.connection)
+	stream, err := client.ServerReflectionInfo(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		closeErr := stream.CloseSend()
+		if closeErr != nil {
+			h.logger.DebugContext(
+				ctx,
+				"Failed to close reflection stream",
+				slog.Any("error", closeErr),
+			)
+		}
+	}()
+
+	// Note that the server sends each file only once per stream, so we need to accumulate the files received for
+	// all the symbols before building the registry.
+	fileProtos := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, symbol := range symbols {
+		err = stream.Send(&reflectionv1.ServerReflectionRequest{
+			MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{
+				FileContainingSymbol: string(symbol),
+			},
+		})
+		if err != nil {
+			return
+		}
+		var response *reflectionv1.ServerReflectionResponse
+		response, err = stream.Recv()
+		if err != nil {
+			return
+		}
+		errorResponse := response.GetErrorResponse()
+		if errorResponse != nil {
+			code := codes.Code(errorResponse.GetErrorCode())
+			if code == codes.NotFound {
+				missing = append(missing, symbol)
+				continue
+			}
+			err = status.Error(code, errorResponse.GetErrorMessage())
+			return
+		}
+		for _, data := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fileProto := &descriptorpb.FileDescriptorProto{}
+			err = proto.Unmarshal(data, fileProto)
+			if err != nil {
+				err = fmt.Errorf("failed to unmarshal file descriptor: %w", err)
+				return
+			}
+			fileProtos[fileProto.GetName()] = fileProto
+		}
+	}
+
+	// Build the registry:
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	for _, fileProto := range fileProtos {
+		fileSet.File = append(fileSet.File, fileProto)
+	}
+	files, err = protodesc.NewFiles(fileSet)
+	if err != nil {
+		err = fmt.Errorf("failed to build server descriptors: %w", err)
+	}
+	return
+}
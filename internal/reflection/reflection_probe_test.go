@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcreflection "google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Compatibility probe", func() {
+	var (
+		ctx     context.Context
+		server  *testing.Server
+		cluster *ffv1.Cluster
+	)
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the server:
+		server = testing.NewServer()
+		DeferCleanup(server.Stop)
+
+		// Prepare a cluster that uses nested fields:
+		cluster = ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				Template: "my-template",
+				NodeSets: map[string]*ffv1.ClusterNodeSet{
+					"compute": ffv1.ClusterNodeSet_builder{
+						HostClass: "acme_1tb",
+						Size:      3,
+					}.Build(),
+				},
+			}.Build(),
+		}.Build()
+	})
+
+	// serveDescriptors registers a reflection service that returns the descriptors compiled into the binary,
+	// after applying the given modification. This simulates a server that uses a different version of the API.
+	serveDescriptors := func(modify func(files map[string]*descriptorpb.FileDescriptorProto)) {
+		files := map[string]*descriptorpb.FileDescriptorProto{}
+		protoregistry.GlobalFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+			files[file.Path()] = protodesc.ToFileDescriptorProto(file)
+			return true
+		})
+		if modify != nil {
+			modify(files)
+		}
+		set := &descriptorpb.FileDescriptorSet{}
+		for _, file := range files {
+			set.File = append(set.File, file)
+		}
+		resolver, err := protodesc.NewFiles(set)
+		Expect(err).ToNot(HaveOccurred())
+		reflectionv1.RegisterServerReflectionServer(
+			server.Registrar(),
+			grpcreflection.NewServerV1(grpcreflection.ServerOptions{
+				DescriptorResolver: resolver,
+			}),
+		)
+	}
+
+	// removeField removes a field from a message of the given file.
+	removeField := func(file *descriptorpb.FileDescriptorProto, message, field string) {
+		for _, messageProto := range file.MessageType {
+			if messageProto.GetName() != message {
+				continue
+			}
+			messageProto.Field = slices.DeleteFunc(
+				messageProto.Field,
+				func(fieldProto *descriptorpb.FieldDescriptorProto) bool {
+					return fieldProto.GetName() == field
+				},
+			)
+			return
+		}
+		Fail("message not found")
+	}
+
+	// probe starts the server and runs the probe for the given object.
+	probe := func(object *ffv1.Cluster) error {
+		server.Start()
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+		helper, err := NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		objectHelper := helper.Lookup("cluster")
+		Expect(objectHelper).ToNot(BeNil())
+		return objectHelper.Probe(ctx, object)
+	}
+
+	It("Succeeds if the server doesn't support reflection", func() {
+		err := probe(cluster)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Succeeds if the server uses the same descriptors", func() {
+		serveDescriptors(nil)
+		err := probe(cluster)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Fails if the server doesn't have the service", func() {
+		serveDescriptors(func(files map[string]*descriptorpb.FileDescriptorProto) {
+			files["fulfillment/v1/clusters_service.proto"].Service = nil
+		})
+		err := probe(cluster)
+		var compatibilityErr *CompatibilityError
+		Expect(errors.As(err, &compatibilityErr)).To(BeTrue())
+		Expect(compatibilityErr.Type).To(BeEquivalentTo("fulfillment.v1.Cluster"))
+		Expect(compatibilityErr.Method).To(BeEquivalentTo("fulfillment.v1.Clusters.Create"))
+		Expect(err.Error()).To(Equal(
+			"server can't create 'fulfillment.v1.Cluster' objects because it doesn't have the " +
+				"'fulfillment.v1.Clusters.Create' method, this usually means that the server uses an older " +
+				"version of the API than the CLI",
+		))
+	})
+
+	It("Fails if the server doesn't know a field that is set", func() {
+		serveDescriptors(func(files map[string]*descriptorpb.FileDescriptorProto) {
+			removeField(files["fulfillment/v1/cluster_type.proto"], "ClusterSpec", "node_sets")
+		})
+		err := probe(cluster)
+		var compatibilityErr *CompatibilityError
+		Expect(errors.As(err, &compatibilityErr)).To(BeTrue())
+		Expect(compatibilityErr.Fields).To(ConsistOf("spec.node_sets"))
+		Expect(err.Error()).To(Equal(
+			"server doesn't support fields 'spec.node_sets' of 'fulfillment.v1.Cluster' objects, this " +
+				"usually means that the server uses an older version of the API than the CLI",
+		))
+	})
+
+	It("Fails if the server doesn't know a nested field that is set", func() {
+		serveDescriptors(func(files map[string]*descriptorpb.FileDescriptorProto) {
+			removeField(files["fulfillment/v1/cluster_type.proto"], "ClusterNodeSet", "host_class")
+		})
+		err := probe(cluster)
+		var compatibilityErr *CompatibilityError
+		Expect(errors.As(err, &compatibilityErr)).To(BeTrue())
+		Expect(compatibilityErr.Fields).To(ConsistOf("spec.node_sets.host_class"))
+	})
+
+	It("Succeeds if the field that the server doesn't know isn't set", func() {
+		serveDescriptors(func(files map[string]*descriptorpb.FileDescriptorProto) {
+			removeField(files["fulfillment/v1/cluster_type.proto"], "ClusterSpec", "node_sets")
+		})
+		cluster.GetSpec().SetNodeSets(nil)
+		err := probe(cluster)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package refs contains the logic used to find objects using the references given by the user in the command line,
+// that can be identifiers or names.
+package refs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Mode determines how references are matched against the identifiers and names of the objects.
+type Mode int
+
+const (
+	// Any matches references against both identifiers and names. When a reference matches the identifier of an
+	// object and the name of others the object with that identifier is selected, because identifiers are unique
+	// and the user is unlikely to have typed a complete identifier by accident.
+	Any Mode = iota
+
+	// ById matches references only against identifiers.
+	ById
+
+	// ByName matches references only against names.
+	ByName
+)
+
+// Filter returns the CEL filter that matches the objects that can be selected by the given references.
+func (m Mode) Filter(refs ...string) string {
+	var terms []string
+	if m != ByName {
+		terms = append(terms, term("this.id", refs))
+	}
+	if m != ById {
+		terms = append(terms, term("this.metadata.name", refs))
+	}
+	return strings.Join(terms, " || ")
+}
+
+// term returns the CEL expression that checks if the given field has one of the references as value.
+func term(field string, refs []string) string {
+	if len(refs) == 1 {
+		return fmt.Sprintf("%s == %q", field, refs[0])
+	}
+	quoted := make([]string, len(refs))
+	for i, ref := range refs {
+		quoted[i] = strconv.Quote(ref)
+	}
+	return fmt.Sprintf("%s in [%s]", field, strings.Join(quoted, ", "))
+}
+
+// Matches checks if the object with the given identifier and name can be selected by the reference.
+func (m Mode) Matches(ref string, id string, name string) bool {
+	switch m {
+	case ById:
+		return id == ref
+	case ByName:
+		return name == ref
+	default:
+		return id == ref || name == ref
+	}
+}
+
+// Resolve returns the objects selected by the reference. The id and name functions extract the identifier and the
+// name of an object. In the Any mode an object whose identifier matches the reference is preferred over objects whose
+// name matches, so the result contains more than one object only when the reference is really ambiguous.
+func Resolve[T any](mode Mode, ref string, objects []T, id, name func(T) string) []T {
+	var matches []T
+	for _, object := range objects {
+		if mode.Matches(ref, id(object), name(object)) {
+			matches = append(matches, object)
+		}
+	}
+	if mode == Any && len(matches) > 1 {
+		for _, match := range matches {
+			if id(match) == ref {
+				return []T{match}
+			}
+		}
+	}
+	return matches
+}
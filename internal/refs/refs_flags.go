@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package refs
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+// ByIdFlagName is the name of the command line flag that forces matching references only against identifiers.
+const ByIdFlagName = "by-id"
+
+// ByNameFlagName is the name of the command line flag that forces matching references only against names.
+const ByNameFlagName = "by-name"
+
+// AddFlags adds to the given flag set the flags that force matching references only against identifiers or only
+// against names. All the commands that find objects by identifier or name should use this, so that the flags are
+// the same.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.Bool(
+		ByIdFlagName,
+		false,
+		"Match the given references only against the identifiers of the objects.",
+	)
+	flags.Bool(
+		ByNameFlagName,
+		false,
+		"Match the given references only against the names of the objects. By default they are matched "+
+			"against identifiers and names, preferring the object whose identifier matches exactly.",
+	)
+}
+
+// ModeFromFlags returns the mode selected with the flags added by the AddFlags function. It returns the Any mode if
+// the flags weren't added or weren't used.
+func ModeFromFlags(flags *pflag.FlagSet) (result Mode, err error) {
+	byId, err := boolFlag(flags, ByIdFlagName)
+	if err != nil {
+		return
+	}
+	byName, err := boolFlag(flags, ByNameFlagName)
+	if err != nil {
+		return
+	}
+	switch {
+	case byId && byName:
+		err = exit.Usagef("options '--%s' and '--%s' can't be used together", ByIdFlagName, ByNameFlagName)
+	case byId:
+		result = ById
+	case byName:
+		result = ByName
+	default:
+		result = Any
+	}
+	return
+}
+
+func boolFlag(flags *pflag.FlagSet, name string) (result bool, err error) {
+	if flags.Lookup(name) == nil {
+		return
+	}
+	return flags.GetBool(name)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package refs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/osac-project/fulfillment-common/logging"
+
+	"github.com/osac-project/fulfillment-cli/internal/terminal"
+)
+
+// Pick asks the user to select one of the objects that match an ambiguous reference, when the console is a terminal.
+// The objects are displayed as the rows of a table with the given titles, and the columns function returns the values
+// of the columns for each object. The ok result is false if the console isn't a terminal or if the user cancels the
+// selection, and then the caller should report the ambiguity as usual.
+func Pick[T any](ctx context.Context, console *terminal.Console, prompt string, titles []string, objects []T,
+	columns func(T) []string) (result T, ok bool) {
+	if !console.CanPick() {
+		return
+	}
+
+	// Prepare the rows, aligning the columns:
+	buffer := &bytes.Buffer{}
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "%s\n", strings.Join(titles, "\t"))
+	for _, object := range objects {
+		values := columns(object)
+		for i, value := range values {
+			if value == "" {
+				values[i] = "-"
+			}
+		}
+		fmt.Fprintf(writer, "%s\n", strings.Join(values, "\t"))
+	}
+	writer.Flush()
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+
+	// Ask the user:
+	index, err := console.Pick(ctx, prompt, lines[0], lines[1:])
+	if err != nil {
+		if !errors.Is(err, terminal.ErrPickCancelled) {
+			logging.LoggerFromContext(ctx).ErrorContext(
+				ctx,
+				"Failed to pick object",
+				slog.String("prompt", prompt),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+	result = objects[index]
+	ok = true
+	return
+}
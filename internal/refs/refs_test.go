@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package refs
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+// object is a simplified object used for the tests.
+type object struct {
+	id   string
+	name string
+}
+
+func resolve(mode Mode, ref string, objects ...object) []object {
+	return Resolve(
+		mode,
+		ref,
+		objects,
+		func(o object) string { return o.id },
+		func(o object) string { return o.name },
+	)
+}
+
+var _ = Describe("References", func() {
+	DescribeTable(
+		"Filter",
+		func(mode Mode, refs []string, expected string) {
+			Expect(mode.Filter(refs...)).To(Equal(expected))
+		},
+		Entry(
+			"Any mode with one reference",
+			Any,
+			[]string{"my"},
+			`this.id == "my" || this.metadata.name == "my"`,
+		),
+		Entry(
+			"Any mode with multiple references",
+			Any,
+			[]string{"a", "b"},
+			`this.id in ["a", "b"] || this.metadata.name in ["a", "b"]`,
+		),
+		Entry(
+			"Identifier mode",
+			ById,
+			[]string{"my"},
+			`this.id == "my"`,
+		),
+		Entry(
+			"Name mode",
+			ByName,
+			[]string{"a", "b"},
+			`this.metadata.name in ["a", "b"]`,
+		),
+		Entry(
+			"Reference with quotes",
+			ById,
+			[]string{`my"id`},
+			`this.id == "my\"id"`,
+		),
+	)
+
+	It("Prefers the object whose identifier matches exactly", func() {
+		matches := resolve(
+			Any,
+			"abc",
+			object{id: "123", name: "abc"},
+			object{id: "abc", name: "xyz"},
+		)
+		Expect(matches).To(Equal([]object{{id: "abc", name: "xyz"}}))
+	})
+
+	It("Reports ambiguity when several objects have the same name", func() {
+		matches := resolve(
+			Any,
+			"abc",
+			object{id: "123", name: "abc"},
+			object{id: "456", name: "abc"},
+		)
+		Expect(matches).To(HaveLen(2))
+	})
+
+	It("Ignores objects that don't match", func() {
+		matches := resolve(
+			Any,
+			"abc",
+			object{id: "123", name: "abc"},
+			object{id: "456", name: "xyz"},
+		)
+		Expect(matches).To(Equal([]object{{id: "123", name: "abc"}}))
+	})
+
+	It("Matches only names when requested", func() {
+		matches := resolve(
+			ByName,
+			"abc",
+			object{id: "123", name: "abc"},
+			object{id: "abc", name: "xyz"},
+		)
+		Expect(matches).To(Equal([]object{{id: "123", name: "abc"}}))
+	})
+
+	It("Matches only identifiers when requested", func() {
+		matches := resolve(
+			ById,
+			"abc",
+			object{id: "123", name: "abc"},
+			object{id: "abc", name: "xyz"},
+		)
+		Expect(matches).To(Equal([]object{{id: "abc", name: "xyz"}}))
+	})
+
+	Describe("Flags", func() {
+		var flags *pflag.FlagSet
+
+		BeforeEach(func() {
+			flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+			AddFlags(flags)
+		})
+
+		It("Returns the default mode when no flag is used", func() {
+			Expect(flags.Parse(nil)).To(Succeed())
+			mode, err := ModeFromFlags(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mode).To(Equal(Any))
+		})
+
+		It("Returns the identifier mode", func() {
+			Expect(flags.Parse([]string{"--by-id"})).To(Succeed())
+			mode, err := ModeFromFlags(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mode).To(Equal(ById))
+		})
+
+		It("Returns the name mode", func() {
+			Expect(flags.Parse([]string{"--by-name"})).To(Succeed())
+			mode, err := ModeFromFlags(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mode).To(Equal(ByName))
+		})
+
+		It("Rejects both flags together", func() {
+			Expect(flags.Parse([]string{"--by-id", "--by-name"})).To(Succeed())
+			_, err := ModeFromFlags(flags)
+			Expect(err).To(MatchError("options '--by-id' and '--by-name' can't be used together"))
+			code, ok := exit.CodeOf(err)
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(exit.Usage))
+		})
+
+		It("Returns the default mode when the flags weren't added", func() {
+			mode, err := ModeFromFlags(pflag.NewFlagSet("empty", pflag.ContinueOnError))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mode).To(Equal(Any))
+		})
+	})
+})
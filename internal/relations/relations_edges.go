@@ -0,0 +1,242 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package relations contains the knowledge of how objects reference other objects, like a cluster that references its
+// template, or a host pool that references its hosts, and the logic needed to retrieve the referenced objects. This is
+// used to render trees and graphs of related objects.
+package relations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultDepth is the number of levels of related objects that are retrieved when the depth isn't explicitly set. It
+// is enough to go from a cluster to its node sets, to the host pools that provide them, and to the hosts of those
+// pools.
+const DefaultDepth = 3
+
+// Edge describes a relationship between an object and other objects, or between an object and a group of nested
+// messages, like the node sets of a cluster.
+type Edge struct {
+	// Path is the dot separated path of the field that contains the reference, for example 'spec.template'. The
+	// field can contain one identifier or a list of identifiers. For groups it is a map or list of messages.
+	Path string
+
+	// Target is the name of the referenced type, without the package, for example 'ClusterTemplate'. The type is
+	// looked up in the package of the object that contains the reference, and if it isn't available the edge is
+	// ignored. This is empty for groups.
+	Target protoreflect.Name
+
+	// Reverse is the dot separated path of a field of the target objects. When it is set the edge selects the target
+	// objects where that field contains the value of the field of the source, instead of the target objects whose
+	// identifier is that value. For example, the host pools that provide the host class of a node set.
+	Reverse string
+
+	// Label is the text that precedes the key of each entry of a group, for example 'node set'.
+	Label string
+
+	// Edges are the relationships of the entries of a group.
+	Edges []Edge
+}
+
+// IsGroup checks if the edge goes to a group of nested messages instead of to other objects.
+func (e Edge) IsGroup() bool {
+	return e.Label != ""
+}
+
+// Name returns a short human friendly name for the edge, calculated from the last segment of the path, for example
+// 'template' for 'spec.template' or 'host class' for 'host_class'.
+func (e Edge) Name() string {
+	name := e.Path
+	index := strings.LastIndex(name, ".")
+	if index != -1 {
+		name = name[index+1:]
+	}
+	return strings.ReplaceAll(name, "_", " ")
+}
+
+// edges are the known relationships, indexed by the name of the type without the package, so that they apply to the
+// public and private variants of the types. Fields that don't exist in one of the variants are ignored.
+var edges = map[protoreflect.Name][]Edge{
+	"Cluster": {
+		{Path: "status.hub", Target: "Hub"},
+		{Path: "spec.template", Target: "ClusterTemplate"},
+		{Path: "spec.node_sets", Label: "node set", Edges: []Edge{
+			{Path: "host_class", Target: "HostClass"},
+			{Path: "host_class", Target: "HostPool", Reverse: "spec.host_sets.host_class"},
+		}},
+	},
+	"ComputeInstance": {
+		{Path: "status.hub", Target: "Hub"},
+		{Path: "spec.template", Target: "ComputeInstanceTemplate"},
+	},
+	"HostPool": {
+		{Path: "status.hub", Target: "Hub"},
+		{Path: "spec.host_sets", Label: "host set", Edges: []Edge{
+			{Path: "host_class", Target: "HostClass"},
+		}},
+		{Path: "status.hosts", Target: "Host"},
+	},
+	"Host": {
+		{Path: "status.host_pool", Target: "HostPool"},
+	},
+}
+
+// EdgesOf returns the known relationships of the given type, or nil if it doesn't have any.
+func EdgesOf(desc protoreflect.MessageDescriptor) []Edge {
+	return edges[desc.Name()]
+}
+
+// Entry is an entry of a group, with its key and its message.
+type Entry struct {
+	Key     string
+	Message protoreflect.Message
+}
+
+// Details returns the values of the scalar fields of the entry that aren't references of the given group edge, for
+// example 'size 3' for a node set of a cluster.
+func (e Entry) Details(edge Edge) []string {
+	var result []string
+	fields := e.Message.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.Kind() == protoreflect.MessageKind || field.IsList() || field.IsMap() {
+			continue
+		}
+		if !e.Message.Has(field) {
+			continue
+		}
+		reference := slices.ContainsFunc(edge.Edges, func(child Edge) bool {
+			return child.Path == string(field.Name())
+		})
+		if reference {
+			continue
+		}
+		name := strings.ReplaceAll(string(field.Name()), "_", " ")
+		result = append(result, fmt.Sprintf("%s %v", name, e.Message.Get(field).Interface()))
+	}
+	return result
+}
+
+// Entries returns the entries of the map or list of messages with the given path, sorted by key. It returns nil if the
+// field doesn't exist or doesn't contain messages.
+func Entries(message protoreflect.Message, path string) []Entry {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		field := message.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil || field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+			return nil
+		}
+		message = message.Get(field).Message()
+	}
+	field := message.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1]))
+	if field == nil {
+		return nil
+	}
+	var result []Entry
+	switch {
+	case field.IsMap() && field.MapValue().Kind() == protoreflect.MessageKind:
+		message.Get(field).Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			result = append(result, Entry{
+				Key:     key.String(),
+				Message: value.Message(),
+			})
+			return true
+		})
+		slices.SortFunc(result, func(a, b Entry) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+	case field.IsList() && field.Kind() == protoreflect.MessageKind:
+		list := message.Get(field).List()
+		for i := range list.Len() {
+			result = append(result, Entry{
+				Key:     fmt.Sprintf("%d", i),
+				Message: list.Get(i).Message(),
+			})
+		}
+	}
+	return result
+}
+
+// Strings returns the non empty strings of the field with the given path. When the path goes through lists or maps of
+// messages the values of all the entries are returned. It returns nil if the field doesn't exist.
+func Strings(message protoreflect.Message, path string) []string {
+	name, rest, _ := strings.Cut(path, ".")
+	field := message.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if field == nil {
+		return nil
+	}
+	var values []protoreflect.Value
+	switch {
+	case field.IsMap():
+		message.Get(field).Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+			values = append(values, value)
+			return true
+		})
+	case field.IsList():
+		list := message.Get(field).List()
+		for i := range list.Len() {
+			values = append(values, list.Get(i))
+		}
+	default:
+		values = append(values, message.Get(field))
+	}
+	var result []string
+	for _, value := range values {
+		if rest != "" {
+			inner, ok := value.Interface().(protoreflect.Message)
+			if ok {
+				result = append(result, Strings(inner, rest)...)
+			}
+			continue
+		}
+		text, ok := value.Interface().(string)
+		if ok && text != "" {
+			result = append(result, text)
+		}
+	}
+	return result
+}
+
+// State returns the name of the value of the 'status.state' field of the given message, without the prefix of the enum
+// type, or an empty string if the message doesn't have that field or it is unspecified.
+func State(message protoreflect.Message) string {
+	status := message.Descriptor().Fields().ByName("status")
+	if status == nil || status.Kind() != protoreflect.MessageKind || !message.Has(status) {
+		return ""
+	}
+	message = message.Get(status).Message()
+	state := message.Descriptor().Fields().ByName("state")
+	if state == nil || state.Kind() != protoreflect.EnumKind {
+		return ""
+	}
+	number := message.Get(state).Enum()
+	if number == 0 {
+		return ""
+	}
+	value := state.Enum().Values().ByNumber(number)
+	if value == nil {
+		return fmt.Sprintf("UNKNOWN:%d", number)
+	}
+	text := string(value.Name())
+	unspecified := string(state.Enum().Values().ByNumber(0).Name())
+	index := strings.LastIndex(unspecified, "_")
+	if index != -1 && strings.HasPrefix(text, unspecified[:index+1]) {
+		text = text[index+1:]
+	}
+	return text
+}
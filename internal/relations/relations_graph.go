@@ -0,0 +1,274 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package relations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+)
+
+// GraphBuilder contains the data and logic needed to create a graph. Don't create instances of this type directly, use
+// the NewGraph function instead.
+type GraphBuilder struct {
+	logger   *slog.Logger
+	helper   *reflection.Helper
+	maxDepth int
+}
+
+// Graph contains objects and the relationships between them. Each object appears only once, even if it is referenced
+// by several objects, so the result may contain cycles. Don't create instances of this type directly, use the NewGraph
+// function instead.
+type Graph struct {
+	logger   *slog.Logger
+	helper   *reflection.Helper
+	resolver *Resolver
+	maxDepth int
+	nodes    []*Node
+	links    []*Link
+	index    map[Key]*Node
+}
+
+// Node is an object of the graph, or an entry of a group of nested messages, like a node set of a cluster.
+type Node struct {
+	// Id is the identifier of the node inside the graph, like 'n1'. It is not the identifier of the object.
+	Id string `json:"id"`
+
+	// Kind is the singular name of the type of the object, like 'cluster', or the label of the group, like 'node
+	// set'.
+	Kind string `json:"kind"`
+
+	// Name is the name of the object, or its identifier if it doesn't have a name. For groups it is the key of the
+	// entry.
+	Name string `json:"name"`
+
+	// ObjectId is the identifier of the object. Empty for groups.
+	ObjectId string `json:"object_id,omitempty"`
+
+	// State is the state of the object without the prefix of the enum type, like 'READY'. Empty if the object
+	// doesn't have a state.
+	State string `json:"state,omitempty"`
+
+	// Details are the values of the scalar fields of group entries, like 'size 3'.
+	Details []string `json:"details,omitempty"`
+
+	// Problem explains why the object couldn't be retrieved, like 'not found'.
+	Problem string `json:"problem,omitempty"`
+
+	// Group is true for entries of groups.
+	Group bool `json:"group,omitempty"`
+
+	// Truncated is true when the object references other objects that weren't retrieved because of the depth
+	// limit.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Link is a relationship between two nodes.
+type Link struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label"`
+}
+
+// NewGraph creates a builder that can then be used to configure and create a graph.
+func NewGraph() *GraphBuilder {
+	return &GraphBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *GraphBuilder) SetLogger(value *slog.Logger) *GraphBuilder {
+	b.logger = value
+	return b
+}
+
+// SetHelper sets the reflection helper that will be used to find the types and to retrieve the objects. This is
+// mandatory.
+func (b *GraphBuilder) SetHelper(value *reflection.Helper) *GraphBuilder {
+	b.helper = value
+	return b
+}
+
+// SetMaxDepth sets the number of levels of related objects that will be retrieved from each object added to the
+// graph. Zero means the default.
+func (b *GraphBuilder) SetMaxDepth(value int) *GraphBuilder {
+	b.maxDepth = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new empty graph.
+func (b *GraphBuilder) Build() (result *Graph, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.helper == nil {
+		err = errors.New("helper is mandatory")
+		return
+	}
+	if b.maxDepth < 0 {
+		err = fmt.Errorf("maximum depth should be zero or positive, but it is %d", b.maxDepth)
+		return
+	}
+
+	// Set the default depth:
+	maxDepth := b.maxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultDepth
+	}
+
+	// Create the resolver:
+	resolver, err := NewResolver().
+		SetLogger(b.logger).
+		SetHelper(b.helper).
+		Build()
+	if err != nil {
+		return
+	}
+
+	// Create and populate the object:
+	result = &Graph{
+		logger:   b.logger,
+		helper:   b.helper,
+		resolver: resolver,
+		maxDepth: maxDepth,
+		index:    map[Key]*Node{},
+	}
+	return
+}
+
+// Add adds the given object to the graph, together with the objects that it references, up to the depth limit.
+func (g *Graph) Add(ctx context.Context, object proto.Message) {
+	node, added := g.objectNode(object)
+	if !added {
+		return
+	}
+	g.walk(ctx, node, object.ProtoReflect(), EdgesOf(object.ProtoReflect().Descriptor()), 0)
+}
+
+// Nodes returns the nodes of the graph, in the order that they were added.
+func (g *Graph) Nodes() []*Node {
+	return g.nodes
+}
+
+// Links returns the links of the graph, in the order that they were added.
+func (g *Graph) Links() []*Link {
+	return g.links
+}
+
+// walk adds the nodes and links for the given edges of the given message, which is the object of the given node, or
+// the entry of a group.
+func (g *Graph) walk(ctx context.Context, node *Node, message protoreflect.Message, edges []Edge, depth int) {
+	pkg := message.Descriptor().ParentFile().Package()
+	for _, edge := range edges {
+		if edge.IsGroup() {
+			for _, entry := range Entries(message, edge.Path) {
+				group := g.addNode(&Node{
+					Kind:    edge.Label,
+					Name:    entry.Key,
+					Details: entry.Details(edge),
+					Group:   true,
+				})
+				g.addLink(node, group, edge.Name())
+				g.walk(ctx, group, entry.Message, edge.Edges, depth+1)
+			}
+			continue
+		}
+		if depth+1 > g.maxDepth {
+			node.Truncated = node.Truncated || g.resolver.Pending(pkg, message, edge)
+			continue
+		}
+		for _, target := range g.resolver.Resolve(ctx, pkg, message, edge) {
+			if target.Object == nil {
+				child, ok := g.index[target.Key]
+				if !ok {
+					child = g.addNode(&Node{
+						Kind:     g.singular(target.Key.Type),
+						Name:     target.Key.Id,
+						ObjectId: target.Key.Id,
+						Problem:  target.Problem,
+					})
+					g.index[target.Key] = child
+				}
+				g.addLink(node, child, edge.Name())
+				continue
+			}
+			child, added := g.objectNode(target.Object)
+			g.addLink(node, child, edge.Name())
+			if added {
+				objectMessage := target.Object.ProtoReflect()
+				g.walk(ctx, child, objectMessage, EdgesOf(objectMessage.Descriptor()), depth+1)
+			}
+		}
+	}
+}
+
+// objectNode returns the node for the given object, creating it if it doesn't exist yet. The second result is true if
+// the node was created.
+func (g *Graph) objectNode(object proto.Message) (result *Node, added bool) {
+	key, ok := g.resolver.KeyOf(object)
+	if ok {
+		result, ok = g.index[key]
+		if ok {
+			return
+		}
+	}
+	desc := object.ProtoReflect().Descriptor()
+	result = &Node{
+		Kind:  string(desc.Name()),
+		State: State(object.ProtoReflect()),
+	}
+	helper := g.helper.Lookup(string(desc.FullName()))
+	if helper != nil {
+		result.Kind = helper.Singular()
+		result.ObjectId = helper.GetId(object)
+		result.Name = helper.GetName(object)
+		if result.Name == "" {
+			result.Name = result.ObjectId
+		}
+		g.index[key] = result
+	}
+	g.addNode(result)
+	added = true
+	return
+}
+
+func (g *Graph) addNode(node *Node) *Node {
+	node.Id = fmt.Sprintf("n%d", len(g.nodes)+1)
+	g.nodes = append(g.nodes, node)
+	return node
+}
+
+func (g *Graph) addLink(from, to *Node, label string) {
+	g.links = append(g.links, &Link{
+		From:  from.Id,
+		To:    to.Id,
+		Label: label,
+	})
+}
+
+// singular returns the singular name of the given type, as used in the command line.
+func (g *Graph) singular(typ protoreflect.FullName) string {
+	helper := g.helper.Lookup(string(typ))
+	if helper == nil {
+		return string(typ.Name())
+	}
+	return helper.Singular()
+}
@@ -0,0 +1,229 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package relations
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Graph", func() {
+	var (
+		ctx    context.Context
+		helper *reflection.Helper
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the server with one host pool that provides the 'fc430' host class with two hosts, one of them
+		// missing:
+		pool := ffv1.HostPool_builder{
+			Id: "pool-1",
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-pool",
+			}.Build(),
+			Spec: ffv1.HostPoolSpec_builder{
+				HostSets: map[string]*ffv1.HostPoolHostSet{
+					"workers": ffv1.HostPoolHostSet_builder{
+						HostClass: "fc430",
+						Size:      2,
+					}.Build(),
+				},
+			}.Build(),
+			Status: ffv1.HostPoolStatus_builder{
+				State: ffv1.HostPoolState_HOST_POOL_STATE_READY,
+				Hosts: []string{"host-1", "host-2"},
+			}.Build(),
+		}.Build()
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterHostPoolsServer(server.Registrar(), &testing.HostPoolsServerFuncs{
+			ListFunc: func(ctx context.Context, request *ffv1.HostPoolsListRequest) (*ffv1.HostPoolsListResponse,
+				error) {
+				return ffv1.HostPoolsListResponse_builder{
+					Items: []*ffv1.HostPool{pool},
+				}.Build(), nil
+			},
+		})
+		ffv1.RegisterHostsServer(server.Registrar(), &testing.HostsServerFuncs{
+			GetFunc: func(ctx context.Context, request *ffv1.HostsGetRequest) (*ffv1.HostsGetResponse, error) {
+				if request.GetId() != "host-1" {
+					return nil, grpcstatus.Errorf(codes.NotFound, "host '%s' doesn't exist", request.GetId())
+				}
+				return ffv1.HostsGetResponse_builder{
+					Object: ffv1.Host_builder{
+						Id: request.GetId(),
+						Metadata: sharedv1.Metadata_builder{
+							Name: "my-host",
+						}.Build(),
+					}.Build(),
+				}.Build(), nil
+			},
+		})
+		server.Start()
+
+		// Create the client connection:
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+
+		// Create the reflection helper:
+		helper, err = reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	makeCluster := func(id, name string) *ffv1.Cluster {
+		return ffv1.Cluster_builder{
+			Id: id,
+			Metadata: sharedv1.Metadata_builder{
+				Name: name,
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				NodeSets: map[string]*ffv1.ClusterNodeSet{
+					"compute": ffv1.ClusterNodeSet_builder{
+						HostClass: "fc430",
+						Size:      3,
+					}.Build(),
+				},
+			}.Build(),
+			Status: ffv1.ClusterStatus_builder{
+				State: ffv1.ClusterState_CLUSTER_STATE_READY,
+			}.Build(),
+		}.Build()
+	}
+
+	It("Can't be created without a logger", func() {
+		graph, err := NewGraph().
+			SetHelper(helper).
+			Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+		Expect(graph).To(BeNil())
+	})
+
+	It("Can't be created without a helper", func() {
+		graph, err := NewGraph().
+			SetLogger(logger).
+			Build()
+		Expect(err).To(MatchError("helper is mandatory"))
+		Expect(graph).To(BeNil())
+	})
+
+	It("Can't be created with a negative depth", func() {
+		graph, err := NewGraph().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetMaxDepth(-1).
+			Build()
+		Expect(err).To(MatchError("maximum depth should be zero or positive, but it is -1"))
+		Expect(graph).To(BeNil())
+	})
+
+	It("Contains the node sets, host pools and hosts of a cluster", func() {
+		graph, err := NewGraph().
+			SetLogger(logger).
+			SetHelper(helper).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		graph.Add(ctx, makeCluster("cluster-1", "my-cluster"))
+
+		nodes := graph.Nodes()
+		Expect(nodes).To(HaveLen(7))
+		Expect(nodes[0].Kind).To(Equal("cluster"))
+		Expect(nodes[0].Name).To(Equal("my-cluster"))
+		Expect(nodes[0].ObjectId).To(Equal("cluster-1"))
+		Expect(nodes[0].State).To(Equal("READY"))
+		Expect(nodes[1].Kind).To(Equal("node set"))
+		Expect(nodes[1].Name).To(Equal("compute"))
+		Expect(nodes[1].Details).To(ConsistOf("size 3"))
+		Expect(nodes[1].Group).To(BeTrue())
+		Expect(nodes[2].Kind).To(Equal("hostclass"))
+		Expect(nodes[2].Problem).To(Equal("unavailable"))
+		Expect(nodes[3].Kind).To(Equal("hostpool"))
+		Expect(nodes[3].Name).To(Equal("my-pool"))
+		Expect(nodes[4].Kind).To(Equal("host set"))
+		Expect(nodes[4].Truncated).To(BeTrue())
+		Expect(nodes[5].Kind).To(Equal("host"))
+		Expect(nodes[5].Name).To(Equal("my-host"))
+		Expect(nodes[5].Problem).To(BeEmpty())
+		Expect(nodes[6].Kind).To(Equal("host"))
+		Expect(nodes[6].Name).To(Equal("host-2"))
+		Expect(nodes[6].Problem).To(Equal("not found"))
+
+		links := graph.Links()
+		Expect(links).To(HaveLen(6))
+		Expect(*links[0]).To(Equal(Link{From: "n1", To: "n2", Label: "node sets"}))
+		Expect(*links[1]).To(Equal(Link{From: "n2", To: "n3", Label: "host class"}))
+		Expect(*links[2]).To(Equal(Link{From: "n2", To: "n4", Label: "host class"}))
+		Expect(*links[3]).To(Equal(Link{From: "n4", To: "n5", Label: "host sets"}))
+		Expect(*links[4]).To(Equal(Link{From: "n4", To: "n6", Label: "hosts"}))
+		Expect(*links[5]).To(Equal(Link{From: "n4", To: "n7", Label: "hosts"}))
+	})
+
+	It("Adds shared objects only once", func() {
+		graph, err := NewGraph().
+			SetLogger(logger).
+			SetHelper(helper).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		graph.Add(ctx, makeCluster("cluster-1", "first"))
+		graph.Add(ctx, makeCluster("cluster-2", "second"))
+
+		pools := 0
+		for _, node := range graph.Nodes() {
+			if node.Kind == "hostpool" {
+				pools++
+			}
+		}
+		Expect(pools).To(Equal(1))
+		Expect(graph.Nodes()).To(HaveLen(9))
+		Expect(graph.Links()).To(HaveLen(9))
+	})
+
+	It("Marks objects truncated by the depth limit", func() {
+		graph, err := NewGraph().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetMaxDepth(2).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		graph.Add(ctx, makeCluster("cluster-1", "my-cluster"))
+
+		nodes := graph.Nodes()
+		Expect(nodes).To(HaveLen(5))
+		Expect(nodes[3].Kind).To(Equal("hostpool"))
+		Expect(nodes[3].Truncated).To(BeTrue())
+	})
+})
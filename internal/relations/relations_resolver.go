@@ -0,0 +1,220 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package relations
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+)
+
+// ResolverBuilder contains the data and logic needed to create a resolver. Don't create instances of this type
+// directly, use the NewResolver function instead.
+type ResolverBuilder struct {
+	logger *slog.Logger
+	helper *reflection.Helper
+}
+
+// Resolver retrieves the objects referenced by the edges. Each object is retrieved only once, and the complete lists
+// needed by reverse edges are also retrieved only once. Don't create instances of this type directly, use the
+// NewResolver function instead.
+type Resolver struct {
+	logger  *slog.Logger
+	helper  *reflection.Helper
+	objects map[Key]*Target
+	lists   map[protoreflect.FullName][]proto.Message
+}
+
+// Key identifies an object.
+type Key struct {
+	Type protoreflect.FullName
+	Id   string
+}
+
+// Target is an object referenced by an edge. The object is nil when it couldn't be retrieved, and then the problem
+// contains a short explanation, like 'not found'.
+type Target struct {
+	Key     Key
+	Object  proto.Message
+	Problem string
+}
+
+// NewResolver creates a builder that can then be used to configure and create a resolver.
+func NewResolver() *ResolverBuilder {
+	return &ResolverBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *ResolverBuilder) SetLogger(value *slog.Logger) *ResolverBuilder {
+	b.logger = value
+	return b
+}
+
+// SetHelper sets the reflection helper that will be used to find the types and to retrieve the objects. This is
+// mandatory.
+func (b *ResolverBuilder) SetHelper(value *reflection.Helper) *ResolverBuilder {
+	b.helper = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new resolver.
+func (b *ResolverBuilder) Build() (result *Resolver, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.helper == nil {
+		err = errors.New("helper is mandatory")
+		return
+	}
+
+	// Create and populate the object:
+	result = &Resolver{
+		logger:  b.logger,
+		helper:  b.helper,
+		objects: map[Key]*Target{},
+		lists:   map[protoreflect.FullName][]proto.Message{},
+	}
+	return
+}
+
+// KeyOf returns the key of the given object. The second result is false if the type of the object isn't known.
+func (r *Resolver) KeyOf(object proto.Message) (result Key, ok bool) {
+	desc := object.ProtoReflect().Descriptor()
+	helper := r.helper.Lookup(string(desc.FullName()))
+	if helper == nil {
+		return
+	}
+	result = Key{
+		Type: desc.FullName(),
+		Id:   helper.GetId(object),
+	}
+	ok = true
+	return
+}
+
+// Pending checks if the given edge of the given message references objects of a type that is available, without
+// retrieving them. This is used to tell if there is something beyond the depth limit.
+func (r *Resolver) Pending(pkg protoreflect.FullName, message protoreflect.Message, edge Edge) bool {
+	if edge.IsGroup() {
+		return false
+	}
+	return len(Strings(message, edge.Path)) > 0 && r.helper.Lookup(string(pkg.Append(edge.Target))) != nil
+}
+
+// Resolve retrieves the objects referenced by the given edge of the given message. The package is the package of the
+// object that contains the message, and is used to find the referenced type. It returns nil if the edge is a group,
+// if the field is empty, or if the referenced type isn't available.
+func (r *Resolver) Resolve(ctx context.Context, pkg protoreflect.FullName, message protoreflect.Message,
+	edge Edge) []*Target {
+	if edge.IsGroup() {
+		return nil
+	}
+	values := Strings(message, edge.Path)
+	if len(values) == 0 {
+		return nil
+	}
+	helper := r.helper.Lookup(string(pkg.Append(edge.Target)))
+	if helper == nil {
+		return nil
+	}
+	var result []*Target
+	for _, value := range values {
+		if edge.Reverse != "" {
+			for _, object := range r.reverse(ctx, helper, edge.Reverse, value) {
+				result = append(result, &Target{
+					Key: Key{
+						Type: helper.FullName(),
+						Id:   helper.GetId(object),
+					},
+					Object: object,
+				})
+			}
+			continue
+		}
+		result = append(result, r.get(ctx, helper, value))
+	}
+	return result
+}
+
+// get retrieves the object with the given identifier, using the cache if it was already retrieved.
+func (r *Resolver) get(ctx context.Context, helper *reflection.ObjectHelper, id string) *Target {
+	key := Key{
+		Type: helper.FullName(),
+		Id:   id,
+	}
+	result, ok := r.objects[key]
+	if ok {
+		return result
+	}
+	result = &Target{
+		Key: key,
+	}
+	r.objects[key] = result
+	object, err := helper.Get(ctx, id)
+	switch {
+	case err == nil:
+		result.Object = object
+	case grpcstatus.Code(err) == codes.NotFound:
+		result.Problem = "not found"
+	default:
+		r.logger.ErrorContext(
+			ctx,
+			"Failed to get related object",
+			slog.String("type", string(helper.FullName())),
+			slog.String("id", id),
+			slog.Any("error", err),
+		)
+		result.Problem = "unavailable"
+	}
+	return result
+}
+
+// reverse returns the objects of the type of the given helper where the field with the given path contains the given
+// value. The complete list of objects of each type is retrieved only once.
+func (r *Resolver) reverse(ctx context.Context, helper *reflection.ObjectHelper, path string,
+	value string) []proto.Message {
+	objects, ok := r.lists[helper.FullName()]
+	if !ok {
+		listResult, err := helper.List(ctx, reflection.ListOptions{
+			Filter: "!has(this.metadata.deletion_timestamp)",
+		})
+		if err != nil {
+			r.logger.ErrorContext(
+				ctx,
+				"Failed to list related objects",
+				slog.String("type", string(helper.FullName())),
+				slog.Any("error", err),
+			)
+		}
+		objects = listResult.Items
+		r.lists[helper.FullName()] = objects
+	}
+	var result []proto.Message
+	for _, object := range objects {
+		if slices.Contains(Strings(object.ProtoReflect(), path), value) {
+			result = append(result, object)
+		}
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Names of the output formats that write the objects as JSON or YAML documents:
+const (
+	FormatJson = "json"
+	FormatYaml = "yaml"
+)
+
+func init() {
+	Register(FormatJson, "JSON document, intended for tools.", func(options *Options) (Renderer, error) {
+		return &documentRenderer{
+			writer: options.Writer,
+			format: FormatJson,
+		}, nil
+	})
+	Register(FormatYaml, "YAML document, the same format accepted by 'create --filename'.",
+		func(options *Options) (Renderer, error) {
+			return &documentRenderer{
+				writer: options.Writer,
+				format: FormatYaml,
+			}, nil
+		},
+	)
+}
+
+// jsonWriter is implemented by writers that know how to write JSON documents, for example adding colors.
+type jsonWriter interface {
+	RenderJson(ctx context.Context, data any)
+}
+
+// yamlWriter is implemented by writers that know how to write YAML documents, for example adding colors.
+type yamlWriter interface {
+	RenderYaml(ctx context.Context, data any)
+}
+
+// documentRenderer writes the objects as a single JSON or YAML document. When there is only one object the document is
+// that object, otherwise it is a list.
+type documentRenderer struct {
+	writer io.Writer
+	format string
+}
+
+// Render is the implementation of the Renderer interface.
+func (r *documentRenderer) Render(ctx context.Context, objects []proto.Message) error {
+	value, err := EncodeObjects(objects)
+	if err != nil {
+		return err
+	}
+	switch r.format {
+	case FormatYaml:
+		if writer, ok := r.writer.(yamlWriter); ok {
+			writer.RenderYaml(ctx, value)
+			return nil
+		}
+		encoder := yaml.NewEncoder(r.writer)
+		encoder.SetIndent(2)
+		err = encoder.Encode(value)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		if writer, ok := r.writer.(jsonWriter); ok {
+			writer.RenderJson(ctx, value)
+			return nil
+		}
+		encoder := json.NewEncoder(r.writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// EncodeObjects converts the given objects to values that can be written with the JSON or YAML encoders, including
+// the '@type' field with the type of each object. When there is only one object the result is that object, otherwise
+// it is a list.
+func EncodeObjects(objects []proto.Message) (result any, err error) {
+	values := make([]any, len(objects))
+	for i, object := range objects {
+		values[i], err = EncodeObject(object)
+		if err != nil {
+			return
+		}
+	}
+	if len(values) == 1 {
+		result = values[0]
+	} else {
+		result = values
+	}
+	return
+}
+
+// EncodeObject converts the given object to a value that can be written with the JSON or YAML encoders, including the
+// '@type' field with the type of the object.
+func EncodeObject(object proto.Message) (result any, err error) {
+	wrapper, err := anypb.New(object)
+	if err != nil {
+		err = fmt.Errorf("failed to wrap object: %w", err)
+		return
+	}
+	data, err := marshalOptions.Marshal(wrapper)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal object: %w", err)
+		return
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+	return
+}
+
+var marshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
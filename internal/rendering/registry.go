@@ -0,0 +1,206 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/macros"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+)
+
+// Renderer is the interface implemented by the types that write objects in one output format.
+type Renderer interface {
+	// Render writes the given objects.
+	Render(ctx context.Context, objects []proto.Message) error
+}
+
+// RendererFunc is an adapter that allows the use of ordinary functions as renderers.
+type RendererFunc func(ctx context.Context, objects []proto.Message) error
+
+// Render is the implementation of the Renderer interface.
+func (f RendererFunc) Render(ctx context.Context, objects []proto.Message) error {
+	return f(ctx, objects)
+}
+
+// Options contains the settings passed to the factories that create renderers. Each format uses only the settings
+// that make sense for it, and ignores the rest.
+type Options struct {
+	// Logger is the logger that the renderer will use to write messages to the log. This is mandatory.
+	Logger *slog.Logger
+
+	// Helper is the reflection helper used to introspect objects.
+	Helper *reflection.Helper
+
+	// Writer is where the objects will be written. If it also implements the RenderJson or RenderYaml methods, like
+	// the console does, then those will be used, so that the output is colored when it goes to a terminal.
+	Writer io.Writer
+
+	// IncludeDeleted indicates if the objects that are being deleted should be marked as such.
+	IncludeDeleted bool
+
+	// GroupBy is an optional CEL expression used to partition the objects into groups.
+	GroupBy string
+
+	// MaxWidth is the maximum width of the output, zero means no limit.
+	MaxWidth int
+
+	// Columns are the headers of the table columns to render, in order. Empty means all the columns.
+	Columns []string
+
+	// HideColumns are the headers of the table columns that will not be rendered.
+	HideColumns []string
+
+	// Theme is the theme used to highlight values. Nil means that values aren't highlighted.
+	Theme *Theme
+
+	// TreeDepth is the number of levels of related objects resolved by the tree format. Zero means the default.
+	TreeDepth int
+
+	// Units is the system of units used to display sizes, like UnitsIEC or UnitsDecimal. Empty means the default.
+	Units string
+
+	// TimeZone is the name of the time zone used to display timestamps, like TimeZoneUTC or 'Europe/Madrid'. Empty
+	// means the default.
+	TimeZone string
+
+	// Warnings is where the renderer writes warnings intended for the user, like malformed table layouts. Nil means
+	// that they are only written to the log.
+	Warnings io.Writer
+
+	// DebugLayouts indicates if the table layout used for each type should be written to the warnings writer.
+	DebugLayouts bool
+
+	// Macros expands the CEL macros defined by the user in the expressions used by the renderer. Nil means that
+	// macros aren't expanded.
+	Macros *macros.Expander
+}
+
+// Factory is a function that creates a renderer with the given options.
+type Factory func(options *Options) (Renderer, error)
+
+// Registry contains the output formats that are available, and the factories that create the corresponding renderers.
+// Formats register themselves in the default registry when the package is initialized, so that all the commands that
+// write objects support all the formats.
+type Registry struct {
+	lock    sync.RWMutex
+	formats map[string]*registryEntry
+}
+
+// registryEntry contains the details of one output format.
+type registryEntry struct {
+	description string
+	factory     Factory
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		formats: map[string]*registryEntry{},
+	}
+}
+
+// DefaultRegistry is the registry where the output formats supported by the CLI register themselves.
+var DefaultRegistry = NewRegistry()
+
+// Register adds the given output format to the default registry.
+func Register(name, description string, factory Factory) {
+	DefaultRegistry.Register(name, description, factory)
+}
+
+// Register adds an output format. It panics if the format is already registered, as that is a programming error.
+func (r *Registry) Register(name, description string, factory Factory) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.formats[name]; ok {
+		panic(fmt.Sprintf("output format '%s' is already registered", name))
+	}
+	r.formats[name] = &registryEntry{
+		description: description,
+		factory:     factory,
+	}
+}
+
+// Names returns the names of the registered output formats, sorted alphabetically.
+func (r *Registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	result := make([]string, 0, len(r.formats))
+	for name := range r.formats {
+		result = append(result, name)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// Description returns the description of the given output format, or an empty string if it isn't registered.
+func (r *Registry) Description(name string) string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	entry, ok := r.formats[name]
+	if !ok {
+		return ""
+	}
+	return entry.description
+}
+
+// Contains checks if the given output format is registered.
+func (r *Registry) Contains(name string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	_, ok := r.formats[name]
+	return ok
+}
+
+// Create creates a renderer for the given output format.
+func (r *Registry) Create(name string, options *Options) (result Renderer, err error) {
+	r.lock.RLock()
+	entry, ok := r.formats[name]
+	r.lock.RUnlock()
+	if !ok {
+		err = exit.Usagef("unknown output format '%s', should be %s", name, QuoteList(r.Names()))
+		return
+	}
+	if options.Logger == nil {
+		err = fmt.Errorf("logger is mandatory")
+		return
+	}
+	result, err = entry.factory(options)
+	if err != nil {
+		err = fmt.Errorf("failed to create renderer for output format '%s': %w", name, err)
+	}
+	return
+}
+
+// QuoteList returns a text containing the given values in single quotes and separated by commas, except the last one
+// that is separated by 'or'. For example, for 'json', 'table' and 'yaml' it returns "'json', 'table' or 'yaml'".
+func QuoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Registry", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("Contains the built-in formats", func() {
+		Expect(DefaultRegistry.Names()).To(ContainElements(FormatJson, FormatTable, FormatYaml))
+		for _, name := range DefaultRegistry.Names() {
+			Expect(DefaultRegistry.Description(name)).ToNot(BeEmpty(), "format '%s'", name)
+		}
+	})
+
+	It("Returns the names sorted", func() {
+		registry := NewRegistry()
+		factory := func(options *Options) (Renderer, error) {
+			return nil, nil
+		}
+		registry.Register("yaml", "YAML", factory)
+		registry.Register("csv", "CSV", factory)
+		registry.Register("json", "JSON", factory)
+		Expect(registry.Names()).To(Equal([]string{"csv", "json", "yaml"}))
+	})
+
+	It("Panics if a format is registered twice", func() {
+		registry := NewRegistry()
+		factory := func(options *Options) (Renderer, error) {
+			return nil, nil
+		}
+		registry.Register("json", "JSON", factory)
+		Expect(func() {
+			registry.Register("json", "JSON", factory)
+		}).To(PanicWith("output format 'json' is already registered"))
+	})
+
+	It("Rejects unknown formats", func() {
+		registry := NewRegistry()
+		registry.Register("json", "JSON", func(options *Options) (Renderer, error) {
+			return nil, nil
+		})
+		registry.Register("yaml", "YAML", func(options *Options) (Renderer, error) {
+			return nil, nil
+		})
+		_, err := registry.Create("junk", &Options{
+			Logger: logger,
+		})
+		Expect(err).To(MatchError("unknown output format 'junk', should be 'json' or 'yaml'"))
+	})
+
+	It("Reports errors of the factory", func() {
+		registry := NewRegistry()
+		registry.Register("csv", "CSV", func(options *Options) (Renderer, error) {
+			return nil, errors.New("no columns")
+		})
+		_, err := registry.Create("csv", &Options{
+			Logger: logger,
+		})
+		Expect(err).To(MatchError("failed to create renderer for output format 'csv': no columns"))
+	})
+
+	It("Writes a single object as a JSON document", func() {
+		buffer := &bytes.Buffer{}
+		renderer, err := DefaultRegistry.Create(FormatJson, &Options{
+			Logger: logger,
+			Writer: buffer,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []proto.Message{
+			ffv1.Cluster_builder{
+				Id: "123",
+			}.Build(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(MatchJSON(`{
+			"@type": "type.googleapis.com/fulfillment.v1.Cluster",
+			"id": "123"
+		}`))
+	})
+
+	It("Writes multiple objects as a YAML list", func() {
+		buffer := &bytes.Buffer{}
+		renderer, err := DefaultRegistry.Create(FormatYaml, &Options{
+			Logger: logger,
+			Writer: buffer,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []proto.Message{
+			ffv1.Cluster_builder{
+				Id: "123",
+			}.Build(),
+			ffv1.Cluster_builder{
+				Id: "456",
+			}.Build(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(MatchYAML(`
+- '@type': type.googleapis.com/fulfillment.v1.Cluster
+  id: "123"
+- '@type': type.googleapis.com/fulfillment.v1.Cluster
+  id: "456"
+`))
+	})
+
+	It("Quotes lists of formats", func() {
+		Expect(QuoteList(nil)).To(BeEmpty())
+		Expect(QuoteList([]string{"json"})).To(Equal("'json'"))
+		Expect(QuoteList([]string{"json", "yaml"})).To(Equal("'json' or 'yaml'"))
+		Expect(QuoteList([]string{"json", "table", "yaml"})).To(Equal("'json', 'table' or 'yaml'"))
+	})
+})
+
+var _ = Describe("Encode objects", func() {
+	It("Returns a single object when there is only one", func() {
+		value, err := EncodeObjects([]proto.Message{
+			ffv1.Cluster_builder{
+				Id: "123",
+			}.Build(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(map[string]any{
+			"@type": "type.googleapis.com/fulfillment.v1.Cluster",
+			"id":    "123",
+		}))
+	})
+
+	It("Returns a list when there are multiple objects", func() {
+		value, err := EncodeObjects([]proto.Message{
+			ffv1.Cluster_builder{
+				Id: "123",
+			}.Build(),
+			ffv1.Cluster_builder{
+				Id: "456",
+			}.Build(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// celFunctions returns the CEL functions that are available to the expressions of the columns, in addition to the
+// standard ones:
+//
+//   - age(timestamp) returns the time elapsed since the given timestamp, like '5m' or '3d'.
+//   - humanizeDuration(duration) returns the given duration in the same short format.
+//   - humanizeBytes(int) returns the given number of bytes using the system of units selected by the user.
+//   - formatTime(timestamp) returns the date and time of the given timestamp in the time zone selected by the user.
+//
+// Timestamps that haven't been set are rendered as '-'.
+func (r *TableRenderer) celFunctions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function(
+			"age",
+			cel.Overload(
+				"age_timestamp",
+				[]*cel.Type{cel.TimestampType},
+				cel.StringType,
+				cel.UnaryBinding(r.celAge),
+			),
+		),
+		cel.Function(
+			"humanizeDuration",
+			cel.Overload(
+				"humanizeDuration_duration",
+				[]*cel.Type{cel.DurationType},
+				cel.StringType,
+				cel.UnaryBinding(r.celHumanizeDuration),
+			),
+		),
+		cel.Function(
+			"humanizeBytes",
+			cel.Overload(
+				"humanizeBytes_int",
+				[]*cel.Type{cel.IntType},
+				cel.StringType,
+				cel.UnaryBinding(r.celHumanizeBytes),
+			),
+			cel.Overload(
+				"humanizeBytes_uint",
+				[]*cel.Type{cel.UintType},
+				cel.StringType,
+				cel.UnaryBinding(r.celHumanizeBytes),
+			),
+		),
+		cel.Function(
+			"formatTime",
+			cel.Overload(
+				"formatTime_timestamp",
+				[]*cel.Type{cel.TimestampType},
+				cel.StringType,
+				cel.UnaryBinding(r.celFormatTime),
+			),
+		),
+	}
+}
+
+func (r *TableRenderer) celAge(val ref.Val) ref.Val {
+	timestamp, ok := val.(types.Timestamp)
+	if !ok {
+		return types.NoSuchOverloadErr()
+	}
+	if timestamp.Time.IsZero() || timestamp.Time.Unix() == 0 {
+		return types.String("-")
+	}
+	return types.String(FormatAge(r.now().Sub(timestamp.Time)))
+}
+
+func (r *TableRenderer) celFormatTime(val ref.Val) ref.Val {
+	timestamp, ok := val.(types.Timestamp)
+	if !ok {
+		return types.NoSuchOverloadErr()
+	}
+	return types.String(FormatTime(timestamp.Time, r.location))
+}
+
+func (r *TableRenderer) celHumanizeDuration(val ref.Val) ref.Val {
+	duration, ok := val.(types.Duration)
+	if !ok {
+		return types.NoSuchOverloadErr()
+	}
+	return types.String(FormatAge(duration.Duration))
+}
+
+func (r *TableRenderer) celHumanizeBytes(val ref.Val) ref.Val {
+	var size int64
+	switch val := val.(type) {
+	case types.Int:
+		size = int64(val)
+	case types.Uint:
+		if val > math.MaxInt64 {
+			return types.NewErr("size %d is too large", uint64(val))
+		}
+		size = int64(val)
+	default:
+		return types.NoSuchOverloadErr()
+	}
+	return types.String(FormatSize(size, "B", r.units))
+}
+
+// FormatAge returns a short text for the given duration, using only the largest unit, like '45s', '5m', '3h', '12d' or
+// '2y'. Negative durations, that can happen when the clocks of the client and the server aren't synchronized, are
+// rendered as '0s'.
+func FormatAge(value time.Duration) string {
+	seconds := int64(value.Seconds())
+	switch {
+	case seconds < 0:
+		return "0s"
+	case seconds < 60:
+		return fmt.Sprintf("%ds", seconds)
+	}
+	minutes := seconds / 60
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	hours := minutes / 60
+	switch {
+	case hours < 24:
+		return fmt.Sprintf("%dh", hours)
+	case hours < 24*365:
+		return fmt.Sprintf("%dd", hours/24)
+	default:
+		return fmt.Sprintf("%dy", hours/(24*365))
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"time"
+
+	"github.com/google/cel-go/cel"
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("Table functions", func() {
+	DescribeTable(
+		"Formats ages",
+		func(value time.Duration, expected string) {
+			Expect(FormatAge(value)).To(Equal(expected))
+		},
+		Entry("Negative", -time.Minute, "0s"),
+		Entry("Seconds", 45*time.Second, "45s"),
+		Entry("Minutes", 5*time.Minute+30*time.Second, "5m"),
+		Entry("Hours", 3*time.Hour+59*time.Minute, "3h"),
+		Entry("Days", 12*24*time.Hour, "12d"),
+		Entry("Years", 800*24*time.Hour, "2y"),
+	)
+
+	// eval evaluates the given expression with the table functions of a renderer that uses the given system of
+	// units and a clock fixed at 2025-01-01 12:00:00 UTC.
+	eval := func(units string, expr string, vars map[string]any) string {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		renderer := &TableRenderer{
+			units: units,
+			now: func() time.Time {
+				return now
+			},
+		}
+		opts := []cel.EnvOption{
+			cel.Variable("ts", cel.TimestampType),
+		}
+		opts = append(opts, renderer.celFunctions()...)
+		env, err := cel.NewEnv(opts...)
+		Expect(err).ToNot(HaveOccurred())
+		ast, issues := env.Compile(expr)
+		Expect(issues.Err()).ToNot(HaveOccurred())
+		prg, err := env.Program(ast)
+		Expect(err).ToNot(HaveOccurred())
+		out, _, err := prg.Eval(vars)
+		Expect(err).ToNot(HaveOccurred())
+		return out.Value().(string)
+	}
+
+	It("Calculates the age of a timestamp", func() {
+		ts := timestamppb.New(time.Date(2025, 1, 1, 11, 55, 0, 0, time.UTC))
+		Expect(eval(UnitsIEC, "age(ts)", map[string]any{"ts": ts})).To(Equal("5m"))
+	})
+
+	It("Renders a dash for timestamps that haven't been set", func() {
+		ts := &timestamppb.Timestamp{}
+		Expect(eval(UnitsIEC, "age(ts)", map[string]any{"ts": ts})).To(Equal("-"))
+	})
+
+	It("Humanizes durations", func() {
+		Expect(eval(UnitsIEC, "humanizeDuration(duration('90m'))", nil)).To(Equal("1h"))
+	})
+
+	It("Humanizes bytes using the selected system of units", func() {
+		Expect(eval(UnitsIEC, "humanizeBytes(1536)", nil)).To(Equal("1.5 KiB"))
+		Expect(eval(UnitsDecimal, "humanizeBytes(1536u)", nil)).To(Equal("1.5 KB"))
+		Expect(eval(UnitsRaw, "humanizeBytes(1536)", nil)).To(Equal("1536"))
+	})
+})
@@ -14,17 +14,21 @@ language governing permissions and limitations under the License.
 package rendering
 
 import (
+	"bytes"
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"log/slog"
+	"math"
 	"path"
 	"reflect"
 	"slices"
 	"strings"
-	"text/tabwriter"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
@@ -36,12 +40,57 @@ import (
 	"google.golang.org/protobuf/types/dynamicpb"
 	"gopkg.in/yaml.v3"
 
+	"github.com/osac-project/fulfillment-cli/internal/macros"
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
 )
 
 //go:embed tables
 var tablesFS embed.FS
 
+// tablesDir is the directory of the embedded file system that contains the table layouts.
+const tablesDir = "tables"
+
+// FormatTable is the name of the output format that writes the objects as a table, intended for humans.
+const FormatTable = "table"
+
+func init() {
+	Register(FormatTable, "Table intended for humans, with the columns described in the table layouts.",
+		func(options *Options) (Renderer, error) {
+			renderer, err := NewTableRenderer().
+				SetLogger(options.Logger).
+				SetHelper(options.Helper).
+				SetWriter(options.Writer).
+				SetIncludeDeleted(options.IncludeDeleted).
+				SetGroupBy(options.GroupBy).
+				SetMaxWidth(options.MaxWidth).
+				SetColumns(options.Columns...).
+				SetHideColumns(options.HideColumns...).
+				SetTheme(options.Theme).
+				SetUnits(options.Units).
+				SetTimeZone(options.TimeZone).
+				SetWarnings(options.Warnings).
+				SetDebugLayouts(options.DebugLayouts).
+				SetMacros(options.Macros).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			return RendererFunc(func(ctx context.Context, objects []proto.Message) error {
+				return renderer.Render(ctx, objects)
+			}), nil
+		},
+	)
+}
+
+// columnPadding is the number of spaces between columns.
+const columnPadding = 2
+
+// minColumnWidth is the minimum width of a column when truncating values, regardless of the width of the header.
+const minColumnWidth = 5
+
+// ellipsis is the text that replaces the end of truncated values.
+const ellipsis = "…"
+
 // tableLayout describes how to render protocol buffers messages in tabular form.
 type tableLayout struct {
 	// Columns describes how fields of the message are mapped to columns.
@@ -74,6 +123,15 @@ type columnLayout struct {
 	// type to use for the lookup. For example, if the result of the expression is a cluster, then the 'type'
 	// should be 'fulfillment.v1.Cluster'.
 	Lookup bool `yaml:"lookup,omitempty"`
+
+	// Fixed indicates that the values of the column should never be truncated to fit the width of the terminal.
+	// This is intended for values like identifiers, that are only useful when complete.
+	Fixed bool `yaml:"fixed,omitempty"`
+
+	// Unit indicates that the result of the expression is a size measured in this unit, for example 'GiB' for
+	// fields like 'memory_gib'. The value will be converted to the system of units selected by the user, and zero
+	// will be rendered as '-', as for these fields it means that the size hasn't been specified.
+	Unit string `yaml:"unit,omitempty"`
 }
 
 // TableRendererBuilder is used to create table renderers. Don't create instances of this type directly, use the
@@ -83,6 +141,16 @@ type TableRendererBuilder struct {
 	helper         *reflection.Helper
 	writer         io.Writer
 	includeDeleted bool
+	groupBy        string
+	maxWidth       int
+	columns        []string
+	hideColumns    []string
+	theme          *Theme
+	units          string
+	timeZone       string
+	warnings       io.Writer
+	debugLayouts   bool
+	macros         *macros.Expander
 }
 
 // TableRenderer is responsible for rendering protocol buffer messages as tables. Don't create instances of this type
@@ -90,9 +158,22 @@ type TableRendererBuilder struct {
 type TableRenderer struct {
 	logger         *slog.Logger
 	helper         *reflection.Helper
-	writer         *tabwriter.Writer
+	writer         io.Writer
 	cache          map[protoreflect.FullName]map[string]string
 	includeDeleted bool
+	groupBy        string
+	maxWidth       int
+	columns        []string
+	hideColumns    []string
+	theme          *Theme
+	units          string
+	location       *time.Location
+	warnings       io.Writer
+	debugLayouts   bool
+	macros         *macros.Expander
+	layouts        fs.FS
+	reported       map[protoreflect.FullName]bool
+	now            func() time.Time
 }
 
 // NewTableRenderer creates a new builder for table renderers.
@@ -124,6 +205,81 @@ func (b *TableRendererBuilder) SetIncludeDeleted(value bool) *TableRendererBuild
 	return b
 }
 
+// SetGroupBy sets a CEL expression that will be used to partition the objects into groups. The expression can access
+// the object via the `this` built-in variable, and the result is converted to a string. Each group is rendered as a
+// separate table preceded by a line containing the value of the expression and the number of objects in the group.
+// Groups are rendered in the order of the first object of each group. The default is to not group the objects.
+func (b *TableRendererBuilder) SetGroupBy(value string) *TableRendererBuilder {
+	b.groupBy = value
+	return b
+}
+
+// SetMaxWidth sets the maximum width of the rendered table. When the table is wider than this the values of the cells
+// will be truncated, replacing the last visible character with an ellipsis. The default is zero, which means that
+// values are never truncated.
+func (b *TableRendererBuilder) SetMaxWidth(value int) *TableRendererBuilder {
+	b.maxWidth = value
+	return b
+}
+
+// SetColumns sets the headers of the columns that will be rendered, in the order that they will be rendered. Headers
+// are compared ignoring case, and underscores and dashes are equivalent to spaces, so 'api-url' selects the 'API URL'
+// column. The default is to render all the columns of the table layout.
+func (b *TableRendererBuilder) SetColumns(values ...string) *TableRendererBuilder {
+	b.columns = values
+	return b
+}
+
+// SetHideColumns sets the headers of the columns that will not be rendered. Headers are compared in the same way than
+// for the SetColumns method.
+func (b *TableRendererBuilder) SetHideColumns(values ...string) *TableRendererBuilder {
+	b.hideColumns = values
+	return b
+}
+
+// SetTheme sets the theme used to highlight the values of the columns that contain states. The default is to not
+// highlight anything.
+func (b *TableRendererBuilder) SetTheme(value *Theme) *TableRendererBuilder {
+	b.theme = value
+	return b
+}
+
+// SetUnits sets the system of units used to display the values of the columns that contain sizes, one of UnitsIEC,
+// UnitsDecimal or UnitsRaw. The default is UnitsIEC.
+func (b *TableRendererBuilder) SetUnits(value string) *TableRendererBuilder {
+	b.units = value
+	return b
+}
+
+// SetTimeZone sets the name of the time zone used to display the values of the columns that contain timestamps, like
+// TimeZoneUTC or 'Europe/Madrid'. The default is TimeZoneLocal.
+func (b *TableRendererBuilder) SetTimeZone(value string) *TableRendererBuilder {
+	b.timeZone = value
+	return b
+}
+
+// SetWarnings sets the writer where the renderer writes the warnings intended for the user, like when the table layout
+// of a type is malformed and the default layout is used instead. These warnings are always written to the log as well.
+// The default is to write them only to the log.
+func (b *TableRendererBuilder) SetWarnings(value io.Writer) *TableRendererBuilder {
+	b.warnings = value
+	return b
+}
+
+// SetDebugLayouts enables writing to the warnings writer the table layout used for each type. This is intended to
+// troubleshoot layouts. The default is false.
+func (b *TableRendererBuilder) SetDebugLayouts(value bool) *TableRendererBuilder {
+	b.debugLayouts = value
+	return b
+}
+
+// SetMacros sets the object that expands the CEL macros defined by the user in the expressions of the columns and in
+// the group by expression. The default is to not expand macros.
+func (b *TableRendererBuilder) SetMacros(value *macros.Expander) *TableRendererBuilder {
+	b.macros = value
+	return b
+}
+
 // Build uses the data stored in the builder to create a new table renderer.
 func (b *TableRendererBuilder) Build() (result *TableRenderer, err error) {
 	// Check parameters:
@@ -139,9 +295,26 @@ func (b *TableRendererBuilder) Build() (result *TableRenderer, err error) {
 		err = fmt.Errorf("writer is mandatory")
 		return
 	}
-
-	// Create a tab writer for proper column alignment of output:
-	writer := tabwriter.NewWriter(b.writer, 0, 0, 2, ' ', 0)
+	if b.maxWidth < 0 {
+		err = fmt.Errorf("max width should be zero or positive, but it is %d", b.maxWidth)
+		return
+	}
+	units := b.units
+	if units == "" {
+		units = DefaultUnits
+	}
+	err = CheckUnits(units)
+	if err != nil {
+		return
+	}
+	timeZone := b.timeZone
+	if timeZone == "" {
+		timeZone = DefaultTimeZone
+	}
+	location, err := LoadTimeZone(timeZone)
+	if err != nil {
+		return
+	}
 
 	// Create the cache:
 	cache := map[protoreflect.FullName]map[string]string{}
@@ -150,9 +323,22 @@ func (b *TableRendererBuilder) Build() (result *TableRenderer, err error) {
 	result = &TableRenderer{
 		logger:         b.logger,
 		helper:         b.helper,
-		writer:         writer,
+		writer:         b.writer,
 		cache:          cache,
 		includeDeleted: b.includeDeleted,
+		groupBy:        b.groupBy,
+		maxWidth:       b.maxWidth,
+		columns:        slices.Clone(b.columns),
+		hideColumns:    slices.Clone(b.hideColumns),
+		theme:          b.theme,
+		units:          units,
+		location:       location,
+		warnings:       b.warnings,
+		debugLayouts:   b.debugLayouts,
+		macros:         b.macros,
+		layouts:        tablesFS,
+		reported:       map[protoreflect.FullName]bool{},
+		now:            time.Now,
 	}
 	return
 }
@@ -185,33 +371,36 @@ func (r *TableRenderer) Render(ctx context.Context, objects any) error {
 		return fmt.Errorf("failed to find object helper for type %q", descriptor.FullName())
 	}
 
-	// Try to load the table definition for this object type:
-	table, err := r.loadTable(helper)
-	if err != nil {
-		return err
-	}
-	if table == nil {
-		table = r.defaultTable()
-	}
+	// Load the table definition for this object type:
+	table := r.selectTable(ctx, helper)
 
 	// If the user has asked to include deleted objects then add the deletion timestamp column:
 	if r.includeDeleted {
 		deletedCol := &columnLayout{
 			Header: "DELETED",
-			Value:  "has(this.metadata.deletion_timestamp)? string(this.metadata.deletion_timestamp): '-'",
+			Value:  "formatTime(this.metadata.deletion_timestamp)",
 		}
 		table.Columns = slices.Insert(table.Columns, 1, deletedCol)
 	}
 
+	// Select the columns requested by the user:
+	var err error
+	table.Columns, err = r.selectColumns(table.Columns, helper)
+	if err != nil {
+		return err
+	}
+
 	// Get the descriptor for the object type:
 	thisDesc := helper.Descriptor()
 
 	// Build CEL environment:
-	celEnv, err := cel.NewEnv(
+	celOpts := []cel.EnvOption{
 		cel.Types(dynamicpb.NewMessage(thisDesc)),
 		cel.Variable("this", cel.ObjectType(string(thisDesc.FullName()))),
 		ext.Strings(),
-	)
+	}
+	celOpts = append(celOpts, r.celFunctions()...)
+	celEnv, err := cel.NewEnv(celOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create CEL environment: %w", err)
 	}
@@ -219,7 +408,15 @@ func (r *TableRenderer) Render(ctx context.Context, objects any) error {
 	// Compile the CEL expressions for the columns:
 	prgs := make([]cel.Program, len(table.Columns))
 	for i, col := range table.Columns {
-		ast, issues := celEnv.Compile(col.Value)
+		var expr string
+		expr, err = r.macros.Expand(col.Value)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to expand macros in CEL expression %q for column %q of type %q: %w",
+				col.Value, col.Header, helper, err,
+			)
+		}
+		ast, issues := celEnv.Compile(expr)
 		err = issues.Err()
 		if err != nil {
 			return fmt.Errorf(
@@ -237,94 +434,417 @@ func (r *TableRenderer) Render(ctx context.Context, objects any) error {
 		prgs[i] = prg
 	}
 
-	// Render the table and remember to flush the writer when done:
-	defer r.writer.Flush()
-	err = r.renderHeader(table.Columns)
+	// Render the table:
+	if r.groupBy != "" {
+		return r.renderGroups(ctx, celEnv, table.Columns, prgs, messages, helper)
+	}
+	return r.renderTable(ctx, table.Columns, prgs, messages, helper)
+}
+
+// renderTable renders the header and then one row for each of the given messages.
+func (r *TableRenderer) renderTable(ctx context.Context, cols []*columnLayout, prgs []cel.Program,
+	messages []proto.Message, helper *reflection.ObjectHelper) error {
+	// Calculate the text of all the cells first, as that is needed to decide how to truncate them:
+	rows := make([][]string, 0, len(messages)+1)
+	rows = append(rows, r.renderHeader(cols))
+	for _, message := range messages {
+		row, err := r.renderRow(ctx, cols, prgs, message, helper)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	// Truncate the cells so that the table fits in the maximum width:
+	if r.maxWidth > 0 {
+		r.truncateRows(cols, rows)
+	}
+
+	// Write the rows:
+	return r.writeRows(cols, rows)
+}
+
+// writeRows writes the rows aligning the columns. The values are padded with spaces to the width of the widest value
+// of the column, instead of using a tab writer, because the escape sequences used to highlight values would otherwise
+// be counted as part of the width.
+func (r *TableRenderer) writeRows(cols []*columnLayout, rows [][]string) error {
+	// Calculate the widths of the columns:
+	widths := make([]int, len(cols))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	// Write the rows, highlighting the values of the state columns but not the header:
+	var buffer strings.Builder
+	for i, row := range rows {
+		buffer.Reset()
+		for j, cell := range row {
+			text := cell
+			if i > 0 && r.isStateColumn(cols[j]) {
+				text = r.theme.State(cell)
+			}
+			buffer.WriteString(text)
+			if j < len(row)-1 {
+				padding := widths[j] - utf8.RuneCountInString(cell) + columnPadding
+				buffer.WriteString(strings.Repeat(" ", padding))
+			}
+		}
+		buffer.WriteString("\n")
+		_, err := io.WriteString(r.writer, buffer.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isStateColumn checks if the values of the given column are the names of enum values, and therefore they can be
+// highlighted using the theme.
+func (r *TableRenderer) isStateColumn(col *columnLayout) bool {
+	return r.theme != nil && col.Type != "" && !col.Lookup
+}
+
+// truncateRows truncates the values of the cells so that the table fits in the maximum width. It repeatedly shortens
+// the widest column that isn't fixed, until the table fits or all the columns have reached their minimum width. The
+// minimum width of a column is the width of its header, so headers are never truncated.
+func (r *TableRenderer) truncateRows(cols []*columnLayout, rows [][]string) {
+	// Calculate the current and minimum widths of the columns:
+	widths := make([]int, len(cols))
+	minimums := make([]int, len(cols))
+	for i, col := range cols {
+		minimums[i] = max(utf8.RuneCountInString(col.Header), minColumnWidth)
+		if col.Fixed {
+			minimums[i] = math.MaxInt
+		}
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	total := (len(cols) - 1) * columnPadding
+	for _, width := range widths {
+		total += width
+	}
+
+	// Shorten the widest column till the table fits:
+	for total > r.maxWidth {
+		widest := -1
+		for i, width := range widths {
+			if width > minimums[i] && (widest == -1 || width > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	// Truncate the cells that are wider than their column:
+	for _, row := range rows {
+		for i, cell := range row {
+			if utf8.RuneCountInString(cell) > widths[i] {
+				row[i] = string([]rune(cell)[:widths[i]-1]) + ellipsis
+			}
+		}
+	}
+}
+
+// renderGroups partitions the messages using the group by expression, and then renders a separate table for each
+// group.
+func (r *TableRenderer) renderGroups(ctx context.Context, celEnv *cel.Env, cols []*columnLayout, prgs []cel.Program,
+	messages []proto.Message, helper *reflection.ObjectHelper) error {
+	// Compile the group by expression:
+	expr, err := r.macros.Expand(r.groupBy)
 	if err != nil {
-		return err
+		return fmt.Errorf(
+			"failed to expand macros in group by CEL expression %q for type %q: %w",
+			r.groupBy, helper, err,
+		)
 	}
+	ast, issues := celEnv.Compile(expr)
+	err = issues.Err()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to compile group by CEL expression %q for type %q: %w",
+			r.groupBy, helper, err,
+		)
+	}
+	prg, err := celEnv.Program(ast)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to create CEL program from group by expression %q for type %q: %w",
+			r.groupBy, helper, err,
+		)
+	}
+
+	// Calculate the groups, preserving the order in which they first appear:
+	var keys []string
+	groups := map[string][]proto.Message{}
 	for _, message := range messages {
-		err := r.renderRow(ctx, table.Columns, prgs, message, helper)
+		var key string
+		key, err = r.evalGroupKey(prg, message, helper)
 		if err != nil {
 			return err
 		}
+		group, ok := groups[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(group, message)
 	}
 
+	// Render the groups:
+	for i, key := range keys {
+		if i > 0 {
+			fmt.Fprintf(r.writer, "\n")
+		}
+		group := groups[key]
+		fmt.Fprintf(r.writer, "%s (%d)\n", key, len(group))
+		err = r.renderTable(ctx, cols, prgs, group, helper)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// loadTable loads the table definition for the given object type from the embedded filesystem.
-func (r *TableRenderer) loadTable(helper *reflection.ObjectHelper) (result *tableLayout, err error) {
+// evalGroupKey evaluates the group by expression for the given message and returns the result as a string.
+func (r *TableRenderer) evalGroupKey(prg cel.Program, message proto.Message,
+	helper *reflection.ObjectHelper) (result string, err error) {
+	out, _, err := prg.Eval(map[string]any{
+		"this": message,
+	})
+	if err != nil {
+		err = fmt.Errorf(
+			"failed to evaluate group by CEL expression %q for type %q: %w",
+			r.groupBy, helper, err,
+		)
+		return
+	}
+	result = fmt.Sprintf("%v", out.Value())
+	if result == "" {
+		result = "-"
+	}
+	return
+}
+
+// selectTable returns the table layout for the given object type. If the type doesn't have a layout file, or if it is
+// malformed, it returns the default layout. Malformed layouts are reported as warnings, only once for each type.
+func (r *TableRenderer) selectTable(ctx context.Context, helper *reflection.ObjectHelper) *tableLayout {
+	file := path.Join(tablesDir, fmt.Sprintf("%s.yaml", helper.FullName()))
+	table, err := r.loadTable(file)
+	reported := r.reported[helper.FullName()]
+	r.reported[helper.FullName()] = true
+	switch {
+	case err != nil:
+		if !reported {
+			r.logger.WarnContext(
+				ctx,
+				"Table layout is malformed, will use the default layout",
+				slog.String("type", string(helper.FullName())),
+				slog.String("file", file),
+				slog.Any("error", err),
+			)
+			r.warn(
+				"Warning: table layout of type '%s' is malformed, using the default layout: %v.\n",
+				helper.FullName(), err,
+			)
+		}
+		return r.defaultTable()
+	case table == nil:
+		if !reported && r.debugLayouts {
+			r.warn("Layout of type '%s': default, there is no file '%s'.\n", helper.FullName(), file)
+		}
+		return r.defaultTable()
+	default:
+		if !reported && r.debugLayouts {
+			r.warn(
+				"Layout of type '%s': file '%s' with columns %s.\n",
+				helper.FullName(), file, strings.Join(r.renderHeader(table.Columns), ", "),
+			)
+		}
+		return table
+	}
+}
+
+// warn writes a message to the warnings writer, if there is one.
+func (r *TableRenderer) warn(format string, args ...any) {
+	if r.warnings == nil {
+		return
+	}
+	fmt.Fprintf(r.warnings, format, args...)
+}
+
+// loadTable loads a table layout file. It returns nil without error if the file doesn't exist, and an error if it
+// exists but can't be read, isn't valid YAML, contains unknown fields or has columns without values.
+func (r *TableRenderer) loadTable(file string) (result *tableLayout, err error) {
 	// Try to read the table definition file:
-	file := fmt.Sprintf("%s.yaml", helper.FullName())
-	data, err := fs.ReadFile(tablesFS, path.Join("tables", file))
+	data, err := fs.ReadFile(r.layouts, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+		return
+	}
 	if err != nil {
-		// If the file doesn't exist, that's okay - we'll use the default table.
+		err = fmt.Errorf("failed to read file '%s': %w", file, err)
 		return
 	}
 
-	// Unmarshal the table definition:
+	// Unmarshal the table definition, rejecting unknown fields as they are usually typos:
 	var table tableLayout
-	err = yaml.Unmarshal(data, &table)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&table)
+	if errors.Is(err, io.EOF) {
+		err = fmt.Errorf("file '%s' is empty", file)
+		return
+	}
 	if err != nil {
-		err = fmt.Errorf(
-			"failed to unmarshal table definition file %q: %w",
-			file, err,
-		)
+		err = fmt.Errorf("failed to parse file '%s': %w", file, err)
+		return
+	}
+
+	// Check the columns:
+	if len(table.Columns) == 0 {
+		err = fmt.Errorf("file '%s' doesn't contain any column", file)
 		return
 	}
+	for i, col := range table.Columns {
+		switch {
+		case col == nil:
+			err = fmt.Errorf("column %d of file '%s' is empty", i, file)
+		case col.Header == "":
+			err = fmt.Errorf("column %d of file '%s' doesn't have a header", i, file)
+		case col.Value == "":
+			err = fmt.Errorf("column '%s' of file '%s' doesn't have a value", col.Header, file)
+		case col.Lookup && col.Type == "":
+			err = fmt.Errorf("column '%s' of file '%s' uses lookup but doesn't have a type", col.Header, file)
+		}
+		if err != nil {
+			return
+		}
+	}
 	result = &table
 	return
 }
 
-// defaultTable returns a default table definition with ID and NAME columns.
+// selectColumns returns the columns that should be rendered, according to the columns selected and hidden by the user.
+func (r *TableRenderer) selectColumns(cols []*columnLayout, helper *reflection.ObjectHelper) (result []*columnLayout,
+	err error) {
+	// Index the columns by normalized header:
+	index := map[string]*columnLayout{}
+	for _, col := range cols {
+		index[normalizeHeader(col.Header)] = col
+	}
+	find := func(header string) (col *columnLayout, err error) {
+		col, ok := index[normalizeHeader(header)]
+		if !ok {
+			headers := make([]string, len(cols))
+			for i, col := range cols {
+				headers[i] = col.Header
+			}
+			err = fmt.Errorf(
+				"type '%s' doesn't have a column '%s', valid columns are %s",
+				helper, header, QuoteList(headers),
+			)
+		}
+		return
+	}
+
+	// Start with the selected columns, in the order given, or with all the columns if none was selected:
+	result = cols
+	if len(r.columns) > 0 {
+		result = make([]*columnLayout, 0, len(r.columns))
+		for _, header := range r.columns {
+			var col *columnLayout
+			col, err = find(header)
+			if err != nil {
+				return
+			}
+			if !slices.Contains(result, col) {
+				result = append(result, col)
+			}
+		}
+	}
+
+	// Remove the hidden columns:
+	for _, header := range r.hideColumns {
+		var col *columnLayout
+		col, err = find(header)
+		if err != nil {
+			return
+		}
+		result = slices.DeleteFunc(slices.Clone(result), func(item *columnLayout) bool {
+			return item == col
+		})
+	}
+	if len(result) == 0 {
+		err = fmt.Errorf("at least one column should be rendered")
+		return
+	}
+	return
+}
+
+// normalizeHeader converts the given column header to upper case and replaces underscores and dashes with spaces, so
+// that headers can be compared.
+func normalizeHeader(header string) string {
+	header = strings.ToUpper(strings.TrimSpace(header))
+	return strings.NewReplacer("_", " ", "-", " ").Replace(header)
+}
+
+// defaultTable returns a default table definition with ID, NAME and AGE columns.
 func (r *TableRenderer) defaultTable() *tableLayout {
 	return &tableLayout{
 		Columns: []*columnLayout{
 			{
 				Header: "ID",
 				Value:  "this.id",
+				Fixed:  true,
 			},
 			{
 				Header: "NAME",
 				Value:  "has(this.metadata.name)? this.metadata.name: '-'",
 			},
+			{
+				Header: "AGE",
+				Value:  "age(this.metadata.creation_timestamp)",
+			},
 		},
 	}
 }
 
-// renderHeader renders the table header with column names.
-func (r *TableRenderer) renderHeader(cols []*columnLayout) error {
+// renderHeader returns the texts of the header of the table.
+func (r *TableRenderer) renderHeader(cols []*columnLayout) []string {
+	result := make([]string, len(cols))
 	for i, col := range cols {
-		if i > 0 {
-			fmt.Fprint(r.writer, "\t")
-		}
-		fmt.Fprintf(r.writer, "%s", col.Header)
+		result[i] = col.Header
 	}
-	fmt.Fprintf(r.writer, "\n")
-	return nil
+	return result
 }
 
-// renderRow renders a single row of the table.
+// renderRow returns the texts of the cells of a single row of the table.
 func (r *TableRenderer) renderRow(ctx context.Context, cols []*columnLayout, prgs []cel.Program, object proto.Message,
-	helper *reflection.ObjectHelper) error {
+	helper *reflection.ObjectHelper) (result []string, err error) {
 	// Wrap the object in a top-level "this" field to avoid conflicts with reserved words:
 	in := map[string]any{
 		"this": object,
 	}
 	celVars, err := cel.PartialVars(in)
 	if err != nil {
-		return fmt.Errorf(
+		err = fmt.Errorf(
 			"failed to set variables for CEL expression for type %q: %w",
 			helper, err,
 		)
+		return
 	}
 
 	// Render each column:
+	cells := make([]string, len(cols))
 	for i := range len(cols) {
-		if i > 0 {
-			fmt.Fprintf(r.writer, "\t")
-		}
 		col := cols[i]
 		prg := prgs[i]
 
@@ -332,60 +852,82 @@ func (r *TableRenderer) renderRow(ctx context.Context, cols []*columnLayout, prg
 		var out ref.Val
 		out, _, err = prg.Eval(celVars)
 		if err != nil {
-			return fmt.Errorf(
+			err = fmt.Errorf(
 				"failed to evaluate CEL expression %q for column %q of type %q: %w",
 				col.Value, col.Header, helper, err,
 			)
+			return
 		}
 
 		// Render the cell value:
-		err = r.renderCell(ctx, col, out)
+		var buffer strings.Builder
+		err = r.renderCell(ctx, &buffer, col, out)
 		if err != nil {
-			return fmt.Errorf(
+			err = fmt.Errorf(
 				"failed to render value %q for column %q of type %q: %w",
 				out, col.Header, helper, err,
 			)
+			return
 		}
+		cells[i] = buffer.String()
 	}
-	fmt.Fprintf(r.writer, "\n")
-	return nil
+	result = cells
+	return
 }
 
 // renderCell renders a single cell in the table.
-func (r *TableRenderer) renderCell(ctx context.Context, col *columnLayout, val ref.Val) error {
+func (r *TableRenderer) renderCell(ctx context.Context, w io.Writer, col *columnLayout, val ref.Val) error {
 	switch val := val.(type) {
 	case types.Int:
+		if col.Unit != "" {
+			return r.renderCellSize(w, int64(val), col.Unit)
+		}
 		if col.Type != "" {
 			enumType, _ := protoregistry.GlobalTypes.FindEnumByName(col.Type)
 			if enumType != nil {
-				return r.renderCellEnum(val, enumType.Descriptor())
+				return r.renderCellEnum(w, val, enumType.Descriptor())
 			}
 			r.logger.Error(
 				"Failed to find enum type",
 				slog.String("type", string(col.Type)),
 			)
 		}
+	case types.Uint:
+		if col.Unit != "" && val <= math.MaxInt64 {
+			return r.renderCellSize(w, int64(val), col.Unit)
+		}
+	case types.Timestamp:
+		_, err := io.WriteString(w, FormatTime(val.Time, r.location))
+		return err
 	case types.String:
 		if col.Lookup && col.Type != "" {
 			messageType, _ := protoregistry.GlobalTypes.FindMessageByName(col.Type)
 			if messageType != nil {
-				return r.renderCellLookup(ctx, val, messageType.Descriptor())
+				return r.renderCellLookup(ctx, w, val, messageType.Descriptor())
 			}
 		}
 	}
-	return r.renderCellAny(val)
+	return r.renderCellAny(w, val)
+}
+
+// renderCellSize renders a size measured in the given unit using the system of units of the renderer.
+func (r *TableRenderer) renderCellSize(w io.Writer, val int64, unit string) error {
+	text := "-"
+	if val != 0 {
+		text = FormatSize(val, unit, r.units)
+	}
+	_, err := io.WriteString(w, text)
+	return err
 }
 
 // renderCellEnum renders an enum value as a string.
-func (r *TableRenderer) renderCellEnum(val types.Int, enumDesc protoreflect.EnumDescriptor) error {
+func (r *TableRenderer) renderCellEnum(w io.Writer, val types.Int, enumDesc protoreflect.EnumDescriptor) error {
 	// Get the text of the name of the enum value:
 	valueDescs := enumDesc.Values()
 	valueDesc := valueDescs.ByNumber(protoreflect.EnumNumber(val))
 	if valueDesc == nil {
-		_, err := fmt.Fprintf(r.writer, "UNKNOWN:%d", val)
-		if err != nil {
-			return err
-		}
+		_, err := fmt.Fprintf(w, "UNKNOWN:%d", val)
+		return err
 	}
 	valueTxt := string(valueDesc.Name())
 
@@ -403,12 +945,12 @@ func (r *TableRenderer) renderCellEnum(val types.Int, enumDesc protoreflect.Enum
 		}
 	}
 
-	_, err := fmt.Fprintf(r.writer, "%s", valueTxt)
+	_, err := fmt.Fprintf(w, "%s", valueTxt)
 	return err
 }
 
 // renderCellLookup renders a lookup value (identifier to name translation).
-func (r *TableRenderer) renderCellLookup(ctx context.Context, val types.String,
+func (r *TableRenderer) renderCellLookup(ctx context.Context, w io.Writer, val types.String,
 	messageDesc protoreflect.MessageDescriptor) error {
 	key := string(val)
 	var text string
@@ -417,7 +959,7 @@ func (r *TableRenderer) renderCellLookup(ctx context.Context, val types.String,
 	} else {
 		text = "-"
 	}
-	_, err := fmt.Fprintf(r.writer, "%s", text)
+	_, err := fmt.Fprintf(w, "%s", text)
 	return err
 }
 
@@ -485,7 +1027,7 @@ func (r *TableRenderer) lookupName(ctx context.Context, messageFullName protoref
 }
 
 // renderCellAny renders any value type as a string.
-func (r *TableRenderer) renderCellAny(val ref.Val) error {
-	_, err := fmt.Fprintf(r.writer, "%s", val)
+func (r *TableRenderer) renderCellAny(w io.Writer, val ref.Val) error {
+	_, err := fmt.Fprintf(w, "%s", val)
 	return err
 }
@@ -0,0 +1,675 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"path"
+	"testing/fstest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/osac-project/fulfillment-cli/internal/macros"
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Table renderer", func() {
+	var (
+		ctx    context.Context
+		helper *reflection.Helper
+		buffer *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the server:
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		server.Start()
+
+		// Create the client connection:
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+
+		// Create the reflection helper:
+		helper, err = reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Create the buffer where the output will be written:
+		buffer = &bytes.Buffer{}
+	})
+
+	makeHost := func(id, name string, power ffv1.HostPowerState) *ffv1.Host {
+		return ffv1.Host_builder{
+			Id: id,
+			Metadata: sharedv1.Metadata_builder{
+				Name: name,
+			}.Build(),
+			Status: ffv1.HostStatus_builder{
+				PowerState: power,
+			}.Build(),
+		}.Build()
+	}
+
+	It("Renders a single table without grouping", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"ID   NAME     POWER STATE  AGE\n" +
+				"123  my-host  ON           -\n",
+		))
+	})
+
+	It("Renders one table per group", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetGroupBy("this.metadata.name.startsWith('a')? 'first': 'second'").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("1", "b-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+			makeHost("2", "a-host", ffv1.HostPowerState_HOST_POWER_STATE_OFF),
+			makeHost("3", "c-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"second (2)\n" +
+				"ID  NAME    POWER STATE  AGE\n" +
+				"1   b-host  ON           -\n" +
+				"3   c-host  ON           -\n" +
+				"\n" +
+				"first (1)\n" +
+				"ID  NAME    POWER STATE  AGE\n" +
+				"2   a-host  OFF          -\n",
+		))
+	})
+
+	It("Uses dash for empty group keys", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetGroupBy("this.id in {'1': 'pool-a'}? {'1': 'pool-a'}[this.id]: ''").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("1", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+			makeHost("2", "your-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"pool-a (1)\n" +
+				"ID  NAME     POWER STATE  AGE\n" +
+				"1   my-host  ON           -\n" +
+				"\n" +
+				"- (1)\n" +
+				"ID  NAME       POWER STATE  AGE\n" +
+				"2   your-host  ON           -\n",
+		))
+	})
+
+	It("Truncates the widest column to fit the maximum width", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetMaxWidth(35).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("123", "my-host-with-a-very-long-name", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"ID   NAME          POWER STATE  AGE\n" +
+				"123  my-host-wit…  ON           -\n",
+		))
+	})
+
+	It("Doesn't truncate fixed columns", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetMaxWidth(10).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("0ad55e76-fefb-451d-a812-21ce39c3ed06", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"ID                                    NAME   POWER STATE  AGE\n" +
+				"0ad55e76-fefb-451d-a812-21ce39c3ed06  my-h…  ON           -\n",
+		))
+	})
+
+	It("Doesn't truncate if the maximum width isn't set", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("123", "my-host-with-a-very-long-name", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(ContainSubstring("my-host-with-a-very-long-name"))
+	})
+
+	It("Fails if the group by expression is wrong", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetGroupBy("this.junk").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("1", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("group by"))
+	})
+
+	It("Renders only the selected columns in the given order", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetColumns("power_state", "id").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"POWER STATE  ID\n" +
+				"ON           123\n",
+		))
+	})
+
+	It("Doesn't render the hidden columns", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetHideColumns("Power-State", "age").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"ID   NAME\n" +
+				"123  my-host\n",
+		))
+	})
+
+	It("Fails if a selected column doesn't exist", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetColumns("ID", "JUNK").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).To(MatchError(ContainSubstring(
+			"doesn't have a column 'JUNK', valid columns are 'ID', 'NAME', 'POWER STATE' or 'AGE'",
+		)))
+	})
+
+	It("Fails if all the columns are hidden", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetColumns("ID").
+			SetHideColumns("ID").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.Host{
+			makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Highlights the states and preserves the alignment", func() {
+		renderer, err := NewTableRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetTheme(LookupTheme(DefaultThemeName)).
+			SetColumns("ID", "STATE", "NAME").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []*ffv1.ComputeInstance{
+			ffv1.ComputeInstance_builder{
+				Id: "1",
+				Metadata: sharedv1.Metadata_builder{
+					Name: "my-instance",
+				}.Build(),
+				Status: ffv1.ComputeInstanceStatus_builder{
+					State: ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
+				}.Build(),
+			}.Build(),
+			ffv1.ComputeInstance_builder{
+				Id: "2",
+				Metadata: sharedv1.Metadata_builder{
+					Name: "your-instance",
+				}.Build(),
+				Status: ffv1.ComputeInstanceStatus_builder{
+					State: ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_FAILED,
+				}.Build(),
+			}.Build(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"ID  STATE    NAME\n" +
+				"1   \x1b[32mRUNNING\x1b[0m  my-instance\n" +
+				"2   \x1b[31mFAILED\x1b[0m   your-instance\n",
+		))
+	})
+
+	Describe("Sizes", func() {
+		makeInstances := func() []*ffv1.ComputeInstance {
+			return []*ffv1.ComputeInstance{
+				ffv1.ComputeInstance_builder{
+					Id: "1",
+					Spec: ffv1.ComputeInstanceSpec_builder{
+						MemoryGib: proto.Int32(16),
+						BootDisk: ffv1.ComputeInstanceDisk_builder{
+							SizeGib: 1536,
+						}.Build(),
+					}.Build(),
+				}.Build(),
+				ffv1.ComputeInstance_builder{
+					Id: "2",
+				}.Build(),
+			}
+		}
+
+		It("Uses IEC units by default", func() {
+			renderer, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetColumns("ID", "MEMORY", "DISK").
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = renderer.Render(ctx, makeInstances())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal(
+				"ID  MEMORY  DISK\n" +
+					"1   16 GiB  1.5 TiB\n" +
+					"2   -       -\n",
+			))
+		})
+
+		It("Uses decimal units if requested", func() {
+			renderer, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetColumns("ID", "MEMORY", "DISK").
+				SetUnits(UnitsDecimal).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = renderer.Render(ctx, makeInstances())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal(
+				"ID  MEMORY   DISK\n" +
+					"1   17.2 GB  1.6 TB\n" +
+					"2   -        -\n",
+			))
+		})
+
+		It("Writes the exact numbers in raw mode", func() {
+			renderer, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetColumns("ID", "MEMORY", "DISK").
+				SetUnits(UnitsRaw).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = renderer.Render(ctx, makeInstances())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal(
+				"ID  MEMORY  DISK\n" +
+					"1   16      1536\n" +
+					"2   -       -\n",
+			))
+		})
+
+		It("Rejects unknown units", func() {
+			_, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetUnits("junk").
+				Build()
+			Expect(err).To(MatchError("unknown units 'junk', should be 'iec', 'decimal' or 'raw'"))
+		})
+	})
+
+	Describe("Layouts", func() {
+		var warnings *bytes.Buffer
+
+		BeforeEach(func() {
+			warnings = &bytes.Buffer{}
+		})
+
+		makeRenderer := func(debug bool, files fstest.MapFS) *TableRenderer {
+			renderer, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetWarnings(warnings).
+				SetDebugLayouts(debug).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			if files != nil {
+				renderer.layouts = files
+			}
+			return renderer
+		}
+
+		layoutFile := func(text string) fstest.MapFS {
+			return fstest.MapFS{
+				"tables/fulfillment.v1.Host.yaml": &fstest.MapFile{
+					Data: []byte(text),
+				},
+			}
+		}
+
+		It("Accepts all the embedded layouts", func() {
+			renderer := makeRenderer(false, nil)
+			files, err := fs.ReadDir(tablesFS, tablesDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).ToNot(BeEmpty())
+			for _, file := range files {
+				table, err := renderer.loadTable(path.Join(tablesDir, file.Name()))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(table).ToNot(BeNil())
+			}
+		})
+
+		It("Uses the default layout when there is no file", func() {
+			renderer := makeRenderer(false, fstest.MapFS{})
+			err := renderer.Render(ctx, []*ffv1.Host{
+				makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal(
+				"ID   NAME     AGE\n" +
+					"123  my-host  -\n",
+			))
+			Expect(warnings.String()).To(BeEmpty())
+		})
+
+		It("Warns once and uses the default layout when the file is malformed", func() {
+			renderer := makeRenderer(false, layoutFile(
+				"columns:\n"+
+					"- header: ID\n"+
+					"  vaule: this.id\n",
+			))
+			hosts := []*ffv1.Host{
+				makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+			}
+			err := renderer.Render(ctx, hosts)
+			Expect(err).ToNot(HaveOccurred())
+			err = renderer.Render(ctx, hosts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(HavePrefix("ID   NAME     AGE\n"))
+			Expect(warnings.String()).To(Equal(
+				"Warning: table layout of type 'fulfillment.v1.Host' is malformed, using the default layout: " +
+					"failed to parse file 'tables/fulfillment.v1.Host.yaml': yaml: unmarshal errors:\n" +
+					"  line 3: field vaule not found in type rendering.columnLayout.\n",
+			))
+		})
+
+		DescribeTable(
+			"Detects malformed files",
+			func(text string, expected string) {
+				renderer := makeRenderer(false, layoutFile(text))
+				_, err := renderer.loadTable("tables/fulfillment.v1.Host.yaml")
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			},
+			Entry(
+				"Empty",
+				"",
+				"file 'tables/fulfillment.v1.Host.yaml' is empty",
+			),
+			Entry(
+				"Not YAML",
+				"columns: [",
+				"failed to parse file",
+			),
+			Entry(
+				"No columns",
+				"columns: []",
+				"doesn't contain any column",
+			),
+			Entry(
+				"Column without header",
+				"columns:\n- value: this.id\n",
+				"column 0 of file 'tables/fulfillment.v1.Host.yaml' doesn't have a header",
+			),
+			Entry(
+				"Column without value",
+				"columns:\n- header: ID\n",
+				"column 'ID' of file 'tables/fulfillment.v1.Host.yaml' doesn't have a value",
+			),
+			Entry(
+				"Lookup without type",
+				"columns:\n- header: POOL\n  value: this.pool\n  lookup: true\n",
+				"column 'POOL' of file 'tables/fulfillment.v1.Host.yaml' uses lookup but doesn't have a type",
+			),
+		)
+
+		It("Writes the layout used for each type in debug mode", func() {
+			renderer := makeRenderer(true, layoutFile(
+				"columns:\n"+
+					"- header: ID\n"+
+					"  value: this.id\n",
+			))
+			err := renderer.Render(ctx, []*ffv1.Host{
+				makeHost("123", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			err = renderer.Render(ctx, []*ffv1.HostClass{
+				ffv1.HostClass_builder{Id: "456"}.Build(),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings.String()).To(Equal(
+				"Layout of type 'fulfillment.v1.Host': file 'tables/fulfillment.v1.Host.yaml' with columns ID.\n" +
+					"Layout of type 'fulfillment.v1.HostClass': default, there is no file " +
+					"'tables/fulfillment.v1.HostClass.yaml'.\n",
+			))
+		})
+	})
+
+	Describe("Macros", func() {
+		var expander *macros.Expander
+
+		BeforeEach(func() {
+			var err error
+			expander, err = macros.NewExpander().
+				SetLogger(logger).
+				SetMacros(map[string]string{
+					"short": "this.metadata.name.size() < 7",
+					"upper": "this.metadata.name.upperAscii()",
+				}).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Expands macros in the group by expression", func() {
+			renderer, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetGroupBy("short? 'short': 'long'").
+				SetMacros(expander).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = renderer.Render(ctx, []*ffv1.Host{
+				makeHost("1", "a-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+				makeHost("2", "long-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(HavePrefix("short (1)\n"))
+			Expect(buffer.String()).To(ContainSubstring("long (1)\n"))
+		})
+
+		It("Expands macros in the expressions of the columns", func() {
+			renderer, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetMacros(expander).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			renderer.layouts = fstest.MapFS{
+				"tables/fulfillment.v1.Host.yaml": &fstest.MapFile{
+					Data: []byte(
+						"columns:\n" +
+							"- header: NAME\n" +
+							"  value: upper\n",
+					),
+				},
+			}
+			err = renderer.Render(ctx, []*ffv1.Host{
+				makeHost("1", "my-host", ffv1.HostPowerState_HOST_POWER_STATE_ON),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buffer.String()).To(Equal(
+				"NAME\n" +
+					"MY-HOST\n",
+			))
+		})
+	})
+
+	Describe("Time zones", func() {
+		makeTimedHost := func(name string, creation, deletion time.Time) *ffv1.Host {
+			host := makeHost("1", name, ffv1.HostPowerState_HOST_POWER_STATE_ON)
+			host.GetMetadata().SetCreationTimestamp(timestamppb.New(creation))
+			if !deletion.IsZero() {
+				host.GetMetadata().SetDeletionTimestamp(timestamppb.New(deletion))
+			}
+			return host
+		}
+
+		render := func(timeZone string, host *ffv1.Host) string {
+			renderer, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetTimeZone(timeZone).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			renderer.layouts = fstest.MapFS{
+				"tables/fulfillment.v1.Host.yaml": &fstest.MapFile{
+					Data: []byte(
+						"columns:\n" +
+							"- header: CREATED\n" +
+							"  value: this.metadata.creation_timestamp\n" +
+							"- header: DELETED\n" +
+							"  value: formatTime(this.metadata.deletion_timestamp)\n",
+					),
+				},
+			}
+			err = renderer.Render(ctx, []*ffv1.Host{host})
+			Expect(err).ToNot(HaveOccurred())
+			return buffer.String()
+		}
+
+		It("Renders timestamps in UTC", func() {
+			created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			deleted := time.Date(2025, 6, 2, 8, 30, 0, 0, time.UTC)
+			Expect(render(TimeZoneUTC, makeTimedHost("my-host", created, deleted))).To(Equal(
+				"CREATED                  DELETED\n" +
+					"2025-06-01 12:00:00 UTC  2025-06-02 08:30:00 UTC\n",
+			))
+		})
+
+		It("Renders timestamps in a named time zone", func() {
+			created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			deleted := time.Date(2025, 6, 2, 8, 30, 0, 0, time.UTC)
+			Expect(render("Europe/Madrid", makeTimedHost("my-host", created, deleted))).To(Equal(
+				"CREATED                   DELETED\n" +
+					"2025-06-01 14:00:00 CEST  2025-06-02 10:30:00 CEST\n",
+			))
+		})
+
+		It("Renders a dash for timestamps that aren't set", func() {
+			created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			Expect(render(TimeZoneUTC, makeTimedHost("my-host", created, time.Time{}))).To(Equal(
+				"CREATED                  DELETED\n" +
+					"2025-06-01 12:00:00 UTC  -\n",
+			))
+		})
+
+		It("Rejects unknown time zones", func() {
+			_, err := NewTableRenderer().
+				SetLogger(logger).
+				SetHelper(helper).
+				SetWriter(buffer).
+				SetTimeZone("junk").
+				Build()
+			Expect(err).To(MatchError(ContainSubstring("unknown time zone 'junk'")))
+		})
+	})
+})
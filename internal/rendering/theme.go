@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"slices"
+	"strings"
+)
+
+// Theme describes the colors used to highlight values in the output intended for humans. Colors are given as the
+// parameters of ANSI select graphic rendition sequences, for example '32' for green or '1;31' for bold red. An empty
+// color means that the values are written without highlighting.
+type Theme struct {
+	// Name is the name of the theme, as used in the configuration.
+	Name string
+
+	// Description is a short explanation of the theme, for humans.
+	Description string
+
+	// Success is the color of states that indicate that the object is ready to be used, like 'READY'.
+	Success string
+
+	// Warning is the color of states that indicate that something is still changing, like 'PROGRESSING'.
+	Warning string
+
+	// Error is the color of states that indicate a failure, like 'FAILED'.
+	Error string
+
+	// Style is the name of the chroma style used to highlight JSON, YAML and diffs.
+	Style string
+}
+
+// DefaultThemeName is the name of the theme used when the configuration doesn't select one.
+const DefaultThemeName = "default"
+
+// themes contains the themes supported by the CLI.
+var themes = []*Theme{
+	{
+		Name:        DefaultThemeName,
+		Description: "Green, yellow and red, for terminals with dark or light background.",
+		Success:     "32",
+		Warning:     "33",
+		Error:       "31",
+		Style:       "friendly",
+	},
+	{
+		Name:        "high-contrast",
+		Description: "Bold and bright colors, easier to read in terminals with dark background.",
+		Success:     "1;92",
+		Warning:     "1;93",
+		Error:       "1;91",
+		Style:       "monokai",
+	},
+	{
+		Name:        "monochrome",
+		Description: "No colors, only failures are highlighted in bold.",
+		Error:       "1",
+		Style:       "bw",
+	},
+}
+
+// Themes returns the themes supported by the CLI, in the order that they should be presented to the user.
+func Themes() []*Theme {
+	return slices.Clone(themes)
+}
+
+// LookupTheme returns the theme with the given name, or nil if there is no such theme.
+func LookupTheme(name string) *Theme {
+	for _, theme := range themes {
+		if theme.Name == name {
+			return theme
+		}
+	}
+	return nil
+}
+
+// ThemeNames returns the names of the supported themes.
+func ThemeNames() []string {
+	result := make([]string, len(themes))
+	for i, theme := range themes {
+		result[i] = theme.Name
+	}
+	return result
+}
+
+// Names of the states that are highlighted, without the prefix of the enum type. For example, 'READY' instead of
+// 'CLUSTER_STATE_READY'.
+var (
+	successStates = []string{"READY", "RUNNING", "FULFILLED"}
+	warningStates = []string{"PENDING", "PROGRESSING", "STARTING", "STOPPING", "DELETING"}
+	errorStates   = []string{"FAILED", "ERROR"}
+)
+
+// State returns the given state highlighted with the color that corresponds to it. States that aren't known are
+// returned unchanged. The comparison ignores case and the prefix of the enum type, so 'CLUSTER_STATE_READY' and
+// 'ready' are both highlighted as success.
+func (t *Theme) State(text string) string {
+	if t == nil {
+		return text
+	}
+	state := strings.ToUpper(text)
+	if index := strings.LastIndex(state, "_STATE_"); index != -1 {
+		state = state[index+len("_STATE_"):]
+	}
+	switch {
+	case slices.Contains(successStates, state):
+		return t.Paint(t.Success, text)
+	case slices.Contains(warningStates, state):
+		return t.Paint(t.Warning, text)
+	case slices.Contains(errorStates, state):
+		return t.Paint(t.Error, text)
+	default:
+		return text
+	}
+}
+
+// Paint wraps the given text with the escape sequences that select the given color and then reset it. If the color is
+// empty the text is returned unchanged.
+func (t *Theme) Paint(color, text string) string {
+	if color == "" || text == "" {
+		return text
+	}
+	return "\x1b[" + color + "m" + text + "\x1b[0m"
+}
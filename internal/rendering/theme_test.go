@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Theme", func() {
+	var theme *Theme
+
+	BeforeEach(func() {
+		theme = LookupTheme(DefaultThemeName)
+		Expect(theme).ToNot(BeNil())
+	})
+
+	It("Highlights states according to their meaning", func() {
+		Expect(theme.State("READY")).To(Equal("\x1b[32mREADY\x1b[0m"))
+		Expect(theme.State("PROGRESSING")).To(Equal("\x1b[33mPROGRESSING\x1b[0m"))
+		Expect(theme.State("FAILED")).To(Equal("\x1b[31mFAILED\x1b[0m"))
+	})
+
+	It("Ignores case and the prefix of the enum type", func() {
+		Expect(theme.State("ready")).To(Equal("\x1b[32mready\x1b[0m"))
+		Expect(theme.State("CLUSTER_STATE_FAILED")).To(Equal("\x1b[31mCLUSTER_STATE_FAILED\x1b[0m"))
+	})
+
+	It("Doesn't change unknown states", func() {
+		Expect(theme.State("ON")).To(Equal("ON"))
+		Expect(theme.State("")).To(Equal(""))
+	})
+
+	It("Doesn't change anything when there is no theme", func() {
+		theme = nil
+		Expect(theme.State("READY")).To(Equal("READY"))
+	})
+
+	It("Doesn't highlight when the color is empty", func() {
+		theme = LookupTheme("monochrome")
+		Expect(theme.State("READY")).To(Equal("READY"))
+		Expect(theme.State("FAILED")).To(Equal("\x1b[1mFAILED\x1b[0m"))
+	})
+
+	It("Returns nil for unknown themes", func() {
+		Expect(LookupTheme("junk")).To(BeNil())
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Embed the time zone database, so that names like 'Europe/Madrid' work also in machines that don't have it:
+	_ "time/tzdata"
+)
+
+// Special names of time zones, in addition to the names of the time zone database like 'Europe/Madrid':
+const (
+	// TimeZoneLocal is the time zone of the machine where the CLI runs.
+	TimeZoneLocal = "local"
+
+	// TimeZoneUTC is the coordinated universal time.
+	TimeZoneUTC = "utc"
+)
+
+// DefaultTimeZone is the time zone used when none is explicitly selected.
+const DefaultTimeZone = TimeZoneLocal
+
+// TimeLayout is the layout used to display timestamps to humans. It always includes the time zone, so that users in
+// different time zones can tell which one is used.
+const TimeLayout = "2006-01-02 15:04:05 MST"
+
+// LoadTimeZone returns the location for the given name of time zone, that can be TimeZoneLocal, TimeZoneUTC or a name
+// of the time zone database, like 'Europe/Madrid' or 'America/New_York'. The special names are case insensitive.
+func LoadTimeZone(name string) (result *time.Location, err error) {
+	switch strings.ToLower(name) {
+	case TimeZoneLocal:
+		result = time.Local
+		return
+	case TimeZoneUTC:
+		result = time.UTC
+		return
+	}
+	result, err = time.LoadLocation(name)
+	if err != nil || name == "" {
+		result = nil
+		err = fmt.Errorf(
+			"unknown time zone '%s', should be '%s', '%s' or a name of the time zone database like "+
+				"'Europe/Madrid'",
+			name, TimeZoneLocal, TimeZoneUTC,
+		)
+	}
+	return
+}
+
+// FormatTime returns the text that represents the given timestamp in the given time zone, using TimeLayout. Zero
+// timestamps, and timestamps that correspond to the Unix epoch, are usually fields that haven't been set, so they
+// are rendered as '-'.
+func FormatTime(value time.Time, location *time.Location) string {
+	if value.IsZero() || value.Unix() == 0 {
+		return "-"
+	}
+	if location == nil {
+		location = time.Local
+	}
+	return value.In(location).Format(TimeLayout)
+}
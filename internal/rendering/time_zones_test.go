@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Time zones", func() {
+	DescribeTable(
+		"Loads time zones",
+		func(name string, expected string) {
+			location, err := LoadTimeZone(name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(location.String()).To(Equal(expected))
+		},
+		Entry("Local", "local", time.Local.String()),
+		Entry("UTC", "utc", "UTC"),
+		Entry("Upper case UTC", "UTC", "UTC"),
+		Entry("Named", "Europe/Madrid", "Europe/Madrid"),
+	)
+
+	DescribeTable(
+		"Rejects unknown time zones",
+		func(name string) {
+			_, err := LoadTimeZone(name)
+			Expect(err).To(MatchError(
+				"unknown time zone '" + name + "', should be 'local', 'utc' or a name of the time zone " +
+					"database like 'Europe/Madrid'",
+			))
+		},
+		Entry("Empty", ""),
+		Entry("Junk", "junk"),
+	)
+
+	DescribeTable(
+		"Formats timestamps",
+		func(value time.Time, name string, expected string) {
+			location, err := LoadTimeZone(name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(FormatTime(value, location)).To(Equal(expected))
+		},
+		Entry("UTC", time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), "utc", "2025-06-01 12:00:00 UTC"),
+		Entry(
+			"Summer time",
+			time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), "Europe/Madrid", "2025-06-01 14:00:00 CEST",
+		),
+		Entry(
+			"Winter time",
+			time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), "Europe/Madrid", "2025-01-01 13:00:00 CET",
+		),
+		Entry("Zero", time.Time{}, "utc", "-"),
+		Entry("Epoch", time.Unix(0, 0), "utc", "-"),
+	)
+})
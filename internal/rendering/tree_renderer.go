@@ -0,0 +1,328 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/relations"
+)
+
+// FormatTree is the name of the output format that writes each object followed by the tree of the objects that it
+// references, intended for humans.
+const FormatTree = "tree"
+
+// DefaultTreeDepth is the number of levels of related objects that the tree renderer resolves when the depth isn't
+// explicitly set.
+const DefaultTreeDepth = relations.DefaultDepth
+
+func init() {
+	Register(FormatTree, "Tree of related objects, like the node sets, host pools and hosts of a cluster.",
+		func(options *Options) (Renderer, error) {
+			renderer, err := NewTreeRenderer().
+				SetLogger(options.Logger).
+				SetHelper(options.Helper).
+				SetWriter(options.Writer).
+				SetMaxDepth(options.TreeDepth).
+				SetTheme(options.Theme).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			return renderer, nil
+		},
+	)
+}
+
+// Prefixes used to draw the branches of the tree:
+const (
+	treeBranch     = "├── "
+	treeLastBranch = "└── "
+	treeLine       = "│   "
+	treeSpace      = "    "
+)
+
+// TreeRendererBuilder is used to create tree renderers. Don't create instances of this type directly, use the
+// NewTreeRenderer function instead.
+type TreeRendererBuilder struct {
+	logger   *slog.Logger
+	helper   *reflection.Helper
+	writer   io.Writer
+	maxDepth int
+	theme    *Theme
+}
+
+// TreeRenderer writes each object followed by the tree of the objects that it references. Related objects are
+// retrieved lazily, only when the level where they appear is within the depth limit, and each object is retrieved
+// only once. Don't create instances of this type directly, use the NewTreeRenderer function instead.
+type TreeRenderer struct {
+	logger   *slog.Logger
+	helper   *reflection.Helper
+	resolver *relations.Resolver
+	writer   io.Writer
+	maxDepth int
+	theme    *Theme
+}
+
+// treeNode is a line of the tree, and the nodes below it.
+type treeNode struct {
+	text     string
+	children []*treeNode
+}
+
+// NewTreeRenderer creates a new builder for tree renderers.
+func NewTreeRenderer() *TreeRendererBuilder {
+	return &TreeRendererBuilder{}
+}
+
+// SetLogger sets the logger that the renderer will use to write messages to the log. This is mandatory.
+func (b *TreeRendererBuilder) SetLogger(value *slog.Logger) *TreeRendererBuilder {
+	b.logger = value
+	return b
+}
+
+// SetHelper sets the reflection helper that will be used to introspect objects and to retrieve the related objects.
+// This is mandatory.
+func (b *TreeRendererBuilder) SetHelper(value *reflection.Helper) *TreeRendererBuilder {
+	b.helper = value
+	return b
+}
+
+// SetWriter sets the writer where the tree will be written. This is mandatory.
+func (b *TreeRendererBuilder) SetWriter(value io.Writer) *TreeRendererBuilder {
+	b.writer = value
+	return b
+}
+
+// SetMaxDepth sets the number of levels of related objects that will be resolved. Levels beyond that are replaced by
+// an ellipsis. Zero means the default, which is three levels.
+func (b *TreeRendererBuilder) SetMaxDepth(value int) *TreeRendererBuilder {
+	b.maxDepth = value
+	return b
+}
+
+// SetTheme sets the theme used to highlight the states of the objects. The default is to not highlight them.
+func (b *TreeRendererBuilder) SetTheme(value *Theme) *TreeRendererBuilder {
+	b.theme = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new tree renderer.
+func (b *TreeRendererBuilder) Build() (result *TreeRenderer, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.helper == nil {
+		err = errors.New("helper is mandatory")
+		return
+	}
+	if b.writer == nil {
+		err = errors.New("writer is mandatory")
+		return
+	}
+	if b.maxDepth < 0 {
+		err = fmt.Errorf("maximum depth should be zero or positive, but it is %d", b.maxDepth)
+		return
+	}
+
+	// Set the default depth:
+	maxDepth := b.maxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultTreeDepth
+	}
+
+	// Create the resolver that retrieves the related objects:
+	resolver, err := relations.NewResolver().
+		SetLogger(b.logger).
+		SetHelper(b.helper).
+		Build()
+	if err != nil {
+		return
+	}
+
+	// Create and populate the object:
+	result = &TreeRenderer{
+		logger:   b.logger,
+		helper:   b.helper,
+		resolver: resolver,
+		writer:   b.writer,
+		maxDepth: maxDepth,
+		theme:    b.theme,
+	}
+	return
+}
+
+// Render is the implementation of the Renderer interface. Each object is written as the root of a separate tree, and
+// trees are separated by empty lines.
+func (r *TreeRenderer) Render(ctx context.Context, objects []proto.Message) error {
+	for i, object := range objects {
+		if i > 0 {
+			_, err := fmt.Fprintln(r.writer)
+			if err != nil {
+				return err
+			}
+		}
+		root := r.objectNode(ctx, object, 0, nil)
+		_, err := fmt.Fprintln(r.writer, root.text)
+		if err != nil {
+			return err
+		}
+		err = r.writeChildren(root, "")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeChildren writes the children of the given node, each preceded by the given prefix and the branch.
+func (r *TreeRenderer) writeChildren(node *treeNode, prefix string) error {
+	for i, child := range node.children {
+		branch, indent := treeBranch, treeLine
+		if i == len(node.children)-1 {
+			branch, indent = treeLastBranch, treeSpace
+		}
+		_, err := fmt.Fprintln(r.writer, prefix+branch+child.text)
+		if err != nil {
+			return err
+		}
+		err = r.writeChildren(child, prefix+indent)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectNode creates the node for the given object, and the nodes of the objects that it references if they are within
+// the depth limit. The path contains the keys of the ancestors, and is used to avoid following cycles, like the one
+// between a host pool and its hosts.
+func (r *TreeRenderer) objectNode(ctx context.Context, object proto.Message, depth int,
+	path []relations.Key) *treeNode {
+	desc := object.ProtoReflect().Descriptor()
+	result := &treeNode{
+		text: r.objectText(object),
+	}
+	key, ok := r.resolver.KeyOf(object)
+	if ok {
+		if slices.Contains(path, key) {
+			result.text += " (see above)"
+			return result
+		}
+		path = append(slices.Clone(path), key)
+	}
+	result.children = r.edgeNodes(ctx, desc.ParentFile().Package(), object.ProtoReflect(),
+		relations.EdgesOf(desc), depth, path)
+	return result
+}
+
+// edgeNodes creates the nodes for the given edges of the given message. The package is used to find the types of the
+// referenced objects.
+func (r *TreeRenderer) edgeNodes(ctx context.Context, pkg protoreflect.FullName, message protoreflect.Message,
+	edges []relations.Edge, depth int, path []relations.Key) []*treeNode {
+	var result []*treeNode
+	truncated := false
+	for _, edge := range edges {
+		// Groups don't need to retrieve anything, so they are expanded even if they are at the limit:
+		if edge.IsGroup() {
+			for _, entry := range relations.Entries(message, edge.Path) {
+				result = append(result, r.groupNode(ctx, pkg, edge, entry, depth+1, path))
+			}
+			continue
+		}
+
+		// Beyond the limit don't retrieve the objects, just indicate that there are more:
+		if depth+1 > r.maxDepth {
+			truncated = truncated || r.resolver.Pending(pkg, message, edge)
+			continue
+		}
+
+		// Retrieve the objects and create the nodes:
+		for _, target := range r.resolver.Resolve(ctx, pkg, message, edge) {
+			if target.Object == nil {
+				result = append(result, &treeNode{
+					text: fmt.Sprintf("%s %s (%s)", r.singular(target.Key.Type), target.Key.Id, target.Problem),
+				})
+				continue
+			}
+			result = append(result, r.objectNode(ctx, target.Object, depth+1, path))
+		}
+	}
+	if truncated {
+		result = append(result, &treeNode{
+			text: ellipsis,
+		})
+	}
+	return result
+}
+
+// groupNode creates the node for an entry of a group, like a node set of a cluster. The text contains the label, the
+// key and the values of the scalar fields that aren't references, for example 'node set compute (size 3)'.
+func (r *TreeRenderer) groupNode(ctx context.Context, pkg protoreflect.FullName, edge relations.Edge,
+	entry relations.Entry, depth int, path []relations.Key) *treeNode {
+	text := fmt.Sprintf("%s %s", edge.Label, entry.Key)
+	details := entry.Details(edge)
+	if len(details) > 0 {
+		text = fmt.Sprintf("%s (%s)", text, strings.Join(details, ", "))
+	}
+	return &treeNode{
+		text:     text,
+		children: r.edgeNodes(ctx, pkg, entry.Message, edge.Edges, depth, path),
+	}
+}
+
+// objectText returns the text of the line of an object: the type, the name, the identifier and the state, for example
+// 'cluster my-cluster (123) READY'.
+func (r *TreeRenderer) objectText(object proto.Message) string {
+	desc := object.ProtoReflect().Descriptor()
+	helper := r.helper.Lookup(string(desc.FullName()))
+	if helper == nil {
+		return string(desc.Name())
+	}
+	id := helper.GetId(object)
+	name := helper.GetName(object)
+	var result string
+	switch {
+	case name == "" || name == id:
+		result = fmt.Sprintf("%s %s", helper.Singular(), id)
+	default:
+		result = fmt.Sprintf("%s %s (%s)", helper.Singular(), name, id)
+	}
+	state := relations.State(object.ProtoReflect())
+	if state != "" {
+		result = fmt.Sprintf("%s %s", result, r.theme.State(state))
+	}
+	return result
+}
+
+// singular returns the singular name of the given type, as used in the command line.
+func (r *TreeRenderer) singular(typ protoreflect.FullName) string {
+	helper := r.helper.Lookup(string(typ))
+	if helper == nil {
+		return string(typ.Name())
+	}
+	return helper.Singular()
+}
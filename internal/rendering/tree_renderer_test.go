@@ -0,0 +1,225 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"bytes"
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/reflection"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Tree renderer", func() {
+	var (
+		ctx    context.Context
+		helper *reflection.Helper
+		buffer *bytes.Buffer
+		gets   int
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the server with one host pool that provides the 'fc430' host class with two hosts, one of them
+		// missing:
+		gets = 0
+		pool := ffv1.HostPool_builder{
+			Id: "pool-1",
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-pool",
+			}.Build(),
+			Spec: ffv1.HostPoolSpec_builder{
+				HostSets: map[string]*ffv1.HostPoolHostSet{
+					"workers": ffv1.HostPoolHostSet_builder{
+						HostClass: "fc430",
+						Size:      2,
+					}.Build(),
+				},
+			}.Build(),
+			Status: ffv1.HostPoolStatus_builder{
+				State: ffv1.HostPoolState_HOST_POOL_STATE_READY,
+				Hosts: []string{"host-1", "host-2"},
+			}.Build(),
+		}.Build()
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterHostPoolsServer(server.Registrar(), &testing.HostPoolsServerFuncs{
+			ListFunc: func(ctx context.Context, request *ffv1.HostPoolsListRequest) (*ffv1.HostPoolsListResponse,
+				error) {
+				return ffv1.HostPoolsListResponse_builder{
+					Items: []*ffv1.HostPool{pool},
+				}.Build(), nil
+			},
+		})
+		ffv1.RegisterHostsServer(server.Registrar(), &testing.HostsServerFuncs{
+			GetFunc: func(ctx context.Context, request *ffv1.HostsGetRequest) (*ffv1.HostsGetResponse, error) {
+				gets++
+				if request.GetId() != "host-1" {
+					return nil, grpcstatus.Errorf(codes.NotFound, "host '%s' doesn't exist", request.GetId())
+				}
+				return ffv1.HostsGetResponse_builder{
+					Object: ffv1.Host_builder{
+						Id: request.GetId(),
+						Metadata: sharedv1.Metadata_builder{
+							Name: "my-host",
+						}.Build(),
+					}.Build(),
+				}.Build(), nil
+			},
+		})
+		server.Start()
+
+		// Create the client connection:
+		connection, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(connection.Close)
+
+		// Create the reflection helper:
+		helper, err = reflection.NewHelper().
+			SetLogger(logger).
+			SetConnection(connection).
+			AddPackage("fulfillment.v1", 1).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Create the buffer where the output will be written:
+		buffer = &bytes.Buffer{}
+	})
+
+	makeCluster := func(id, name string) *ffv1.Cluster {
+		return ffv1.Cluster_builder{
+			Id: id,
+			Metadata: sharedv1.Metadata_builder{
+				Name: name,
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				NodeSets: map[string]*ffv1.ClusterNodeSet{
+					"compute": ffv1.ClusterNodeSet_builder{
+						HostClass: "fc430",
+						Size:      3,
+					}.Build(),
+				},
+			}.Build(),
+			Status: ffv1.ClusterStatus_builder{
+				State: ffv1.ClusterState_CLUSTER_STATE_READY,
+			}.Build(),
+		}.Build()
+	}
+
+	It("Renders a cluster with its node sets, host pools and hosts", func() {
+		renderer, err := NewTreeRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []proto.Message{
+			makeCluster("123", "my-cluster"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"cluster my-cluster (123) READY\n" +
+				"└── node set compute (size 3)\n" +
+				"    ├── hostclass fc430 (unavailable)\n" +
+				"    └── hostpool my-pool (pool-1) READY\n" +
+				"        ├── host set workers (size 2)\n" +
+				"        │   └── …\n" +
+				"        ├── host my-host (host-1)\n" +
+				"        └── host host-2 (not found)\n",
+		))
+	})
+
+	It("Replaces the levels beyond the limit with an ellipsis", func() {
+		renderer, err := NewTreeRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetMaxDepth(2).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []proto.Message{
+			makeCluster("123", "my-cluster"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal(
+			"cluster my-cluster (123) READY\n" +
+				"└── node set compute (size 3)\n" +
+				"    ├── hostclass fc430 (unavailable)\n" +
+				"    └── hostpool my-pool (pool-1) READY\n" +
+				"        ├── host set workers (size 2)\n" +
+				"        │   └── …\n" +
+				"        └── …\n",
+		))
+		Expect(gets).To(BeZero())
+	})
+
+	It("Retrieves each related object only once", func() {
+		renderer, err := NewTreeRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []proto.Message{
+			makeCluster("123", "my-cluster"),
+			makeCluster("456", "your-cluster"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gets).To(Equal(2))
+		Expect(buffer.String()).To(ContainSubstring("\n\ncluster your-cluster (456) READY\n"))
+	})
+
+	It("Renders objects without references as a single line", func() {
+		renderer, err := NewTreeRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = renderer.Render(ctx, []proto.Message{
+			ffv1.Host_builder{
+				Id: "host-1",
+			}.Build(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal("host host-1\n"))
+	})
+
+	It("Rejects a negative depth", func() {
+		_, err := NewTreeRenderer().
+			SetLogger(logger).
+			SetHelper(helper).
+			SetWriter(buffer).
+			SetMaxDepth(-1).
+			Build()
+		Expect(err).To(MatchError("maximum depth should be zero or positive, but it is -1"))
+	})
+})
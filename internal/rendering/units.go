@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Systems of units used to display sizes, like the memory or the disks of compute instances:
+const (
+	// UnitsIEC uses binary multiples, like 'GiB', where each unit is 1024 times the previous one. This is the
+	// default, as it is what most of the API fields use.
+	UnitsIEC = "iec"
+
+	// UnitsDecimal uses decimal multiples, like 'GB', where each unit is 1000 times the previous one.
+	UnitsDecimal = "decimal"
+
+	// UnitsRaw displays the exact number stored in the field, without converting it or adding the unit. This is
+	// intended for scripts.
+	UnitsRaw = "raw"
+)
+
+// DefaultUnits is the system of units used when none is explicitly selected.
+const DefaultUnits = UnitsIEC
+
+// UnitsNames returns the names of the supported systems of units.
+func UnitsNames() []string {
+	return []string{UnitsIEC, UnitsDecimal, UnitsRaw}
+}
+
+// CheckUnits checks that the given name is a supported system of units.
+func CheckUnits(name string) error {
+	switch name {
+	case UnitsIEC, UnitsDecimal, UnitsRaw:
+		return nil
+	default:
+		return fmt.Errorf("unknown units '%s', should be %s", name, QuoteList(UnitsNames()))
+	}
+}
+
+// unitFactors contains the number of bytes of each of the units that can be used in the 'unit' attribute of table
+// columns.
+var unitFactors = map[string]float64{
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// Suffixes used for each system of units, from smallest to largest:
+var (
+	iecSuffixes     = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	decimalSuffixes = []string{"B", "KB", "MB", "GB", "TB", "PB"}
+)
+
+// FormatSize returns the text that represents the given value, measured in the given unit, in the given system of
+// units. For example, 8 'GiB' is '8 GiB' in the IEC system, '8.6 GB' in the decimal system and '8' in the raw
+// system. Values are rounded to one decimal, and the decimal is omitted when it is zero. An empty or unknown system
+// is treated as the default, and an unknown unit as bytes.
+func FormatSize(value int64, unit string, units string) string {
+	if units == UnitsRaw {
+		return strconv.FormatInt(value, 10)
+	}
+	factor, ok := unitFactors[unit]
+	if !ok {
+		factor = 1
+	}
+	base := 1024.0
+	suffixes := iecSuffixes
+	if units == UnitsDecimal {
+		base = 1000.0
+		suffixes = decimalSuffixes
+	}
+	size := float64(value) * factor
+	magnitude := math.Abs(size)
+	index := 0
+	for index < len(suffixes)-1 && magnitude >= base {
+		size /= base
+		magnitude /= base
+		index++
+	}
+	text := strconv.FormatFloat(math.Round(size*10)/10, 'f', 1, 64)
+	text = strings.TrimSuffix(text, ".0")
+	return fmt.Sprintf("%s %s", text, suffixes[index])
+}
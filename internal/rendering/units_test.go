@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package rendering
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Units", func() {
+	DescribeTable(
+		"Formats sizes",
+		func(value int64, unit, units, expected string) {
+			Expect(FormatSize(value, unit, units)).To(Equal(expected))
+		},
+		Entry("Zero", int64(0), "GiB", UnitsIEC, "0 B"),
+		Entry("Bytes", int64(512), "B", UnitsIEC, "512 B"),
+		Entry("Whole GiB", int64(8), "GiB", UnitsIEC, "8 GiB"),
+		Entry("Fraction of TiB", int64(1536), "GiB", UnitsIEC, "1.5 TiB"),
+		Entry("GiB in decimal", int64(8), "GiB", UnitsDecimal, "8.6 GB"),
+		Entry("GB in decimal", int64(500), "GB", UnitsDecimal, "500 GB"),
+		Entry("GB in IEC", int64(500), "GB", UnitsIEC, "465.7 GiB"),
+		Entry("Raw", int64(1536), "GiB", UnitsRaw, "1536"),
+		Entry("Default system", int64(2048), "MiB", "", "2 GiB"),
+		Entry("Unknown unit", int64(2048), "junk", UnitsIEC, "2 KiB"),
+	)
+
+	It("Accepts the supported systems", func() {
+		for _, name := range UnitsNames() {
+			Expect(CheckUnits(name)).To(Succeed())
+		}
+	})
+
+	It("Rejects unknown systems", func() {
+		Expect(CheckUnits("junk")).To(MatchError("unknown units 'junk', should be 'iec', 'decimal' or 'raw'"))
+	})
+})
@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Token type hints defined in RFC 7009.
+const (
+	AccessTokenHint  = "access_token"
+	RefreshTokenHint = "refresh_token"
+)
+
+// ErrNotSupported is returned when the issuer doesn't advertise a revocation endpoint.
+var ErrNotSupported = errors.New("token issuer doesn't support revocation")
+
+// RevokerBuilder contains the data and logic needed to create a token revoker. Don't create instances of this type
+// directly, use the NewRevoker function instead.
+type RevokerBuilder struct {
+	logger       *slog.Logger
+	issuer       string
+	clientId     string
+	clientSecret string
+	insecure     bool
+	caPool       *x509.CertPool
+}
+
+// Revoker knows how to revoke tokens using the revocation endpoint defined in RFC 7009. The endpoint is obtained from
+// the 'revocation_endpoint' field of the OAuth or OpenID Connect metadata of the issuer. Don't create instances of this
+// type directly, use the NewRevoker function instead.
+type Revoker struct {
+	logger       *slog.Logger
+	issuer       string
+	clientId     string
+	clientSecret string
+	client       *http.Client
+}
+
+// NewRevoker creates a builder that can then be used to configure and create a token revoker.
+func NewRevoker() *RevokerBuilder {
+	return &RevokerBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *RevokerBuilder) SetLogger(value *slog.Logger) *RevokerBuilder {
+	b.logger = value
+	return b
+}
+
+// SetIssuer sets the URL of the token issuer. This is mandatory.
+func (b *RevokerBuilder) SetIssuer(value string) *RevokerBuilder {
+	b.issuer = value
+	return b
+}
+
+// SetClientId sets the OAuth client identifier that will be sent with the revocation request. This is mandatory.
+func (b *RevokerBuilder) SetClientId(value string) *RevokerBuilder {
+	b.clientId = value
+	return b
+}
+
+// SetClientSecret sets the OAuth client secret. This is optional, and only needed for confidential clients.
+func (b *RevokerBuilder) SetClientSecret(value string) *RevokerBuilder {
+	b.clientSecret = value
+	return b
+}
+
+// SetInsecure sets a flag that indicates if the TLS certificates of the issuer should not be verified. This is
+// optional, the default is to verify them.
+func (b *RevokerBuilder) SetInsecure(value bool) *RevokerBuilder {
+	b.insecure = value
+	return b
+}
+
+// SetCaPool sets the pool of CA certificates that will be used to verify the TLS certificates of the issuer. This is
+// optional, the default is to use the system pool.
+func (b *RevokerBuilder) SetCaPool(value *x509.CertPool) *RevokerBuilder {
+	b.caPool = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new token revoker.
+func (b *RevokerBuilder) Build() (result *Revoker, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.issuer == "" {
+		err = errors.New("issuer is mandatory")
+		return
+	}
+	if b.clientId == "" {
+		err = errors.New("client identifier is mandatory")
+		return
+	}
+	_, err = url.Parse(b.issuer)
+	if err != nil {
+		err = fmt.Errorf("invalid issuer URL '%s': %w", b.issuer, err)
+		return
+	}
+
+	// Create the HTTP client:
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:            b.caPool,
+				InsecureSkipVerify: b.insecure,
+			},
+		},
+	}
+
+	// Create and populate the object:
+	result = &Revoker{
+		logger:       b.logger,
+		issuer:       strings.TrimSuffix(b.issuer, "/"),
+		clientId:     b.clientId,
+		clientSecret: b.clientSecret,
+		client:       client,
+	}
+	return
+}
+
+// Revoke asks the issuer to revoke the given token. The hint should be AccessTokenHint or RefreshTokenHint. Note that
+// according to the specification the issuer responds with success also when the token is already invalid. Returns
+// ErrNotSupported if the issuer doesn't have a revocation endpoint.
+func (r *Revoker) Revoke(ctx context.Context, token, hint string) error {
+	// Find the endpoint:
+	endpoint, err := r.discover(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Send the request:
+	form := url.Values{}
+	form.Set("token", token)
+	if hint != "" {
+		form.Set("token_type_hint", hint)
+	}
+	if r.clientSecret == "" {
+		form.Set("client_id", r.clientId)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if r.clientSecret != "" {
+		request.SetBasicAuth(url.QueryEscape(r.clientId), url.QueryEscape(r.clientSecret))
+	}
+	response, err := r.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send revocation request to '%s': %w", endpoint, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		var body struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.NewDecoder(response.Body).Decode(&body)
+		if body.Error != "" {
+			return fmt.Errorf(
+				"revocation request to '%s' failed with status %d: %s %s",
+				endpoint, response.StatusCode, body.Error, body.Description,
+			)
+		}
+		return fmt.Errorf("revocation request to '%s' failed with status %d", endpoint, response.StatusCode)
+	}
+	r.logger.DebugContext(
+		ctx,
+		"Revoked token",
+		slog.String("endpoint", endpoint),
+		slog.String("hint", hint),
+	)
+	return nil
+}
+
+// discover finds the revocation endpoint trying first the OAuth metadata and then the OpenID Connect metadata.
+func (r *Revoker) discover(ctx context.Context) (result string, err error) {
+	found := false
+	for _, name := range []string{"oauth-authorization-server", "openid-configuration"} {
+		var metadata struct {
+			RevocationEndpoint string `json:"revocation_endpoint"`
+		}
+		address := fmt.Sprintf("%s/.well-known/%s", r.issuer, name)
+		err = r.fetch(ctx, address, &metadata)
+		if err != nil {
+			r.logger.DebugContext(
+				ctx,
+				"Failed to fetch issuer metadata",
+				slog.String("url", address),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		found = true
+		if metadata.RevocationEndpoint != "" {
+			result = metadata.RevocationEndpoint
+			err = nil
+			return
+		}
+	}
+	if !found {
+		err = fmt.Errorf("failed to fetch metadata of token issuer '%s': %w", r.issuer, err)
+		return
+	}
+	err = ErrNotSupported
+	return
+}
+
+func (r *Revoker) fetch(ctx context.Context, address string, metadata any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return err
+	}
+	response, err := r.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return json.NewDecoder(response.Body).Decode(metadata)
+}
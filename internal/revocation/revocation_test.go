@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revoker", func() {
+	var (
+		ctx     context.Context
+		mux     *http.ServeMux
+		server  *httptest.Server
+		revoked url.Values
+		user    string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		revoked = nil
+		user = ""
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+		mux.HandleFunc("POST /revoke", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.ParseForm()).To(Succeed())
+			revoked = r.PostForm
+			user, _, _ = r.BasicAuth()
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	advertise := func(name string, endpoint string) {
+		mux.HandleFunc("GET /.well-known/"+name, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issuer":              server.URL,
+				"revocation_endpoint": endpoint,
+			})
+		})
+	}
+
+	It("Can't be created without an issuer", func() {
+		_, err := NewRevoker().
+			SetLogger(logger).
+			SetClientId("my-client").
+			Build()
+		Expect(err).To(MatchError("issuer is mandatory"))
+	})
+
+	It("Revokes the token using the OAuth metadata", func() {
+		advertise("oauth-authorization-server", server.URL+"/revoke")
+		revoker, err := NewRevoker().
+			SetLogger(logger).
+			SetIssuer(server.URL).
+			SetClientId("my-client").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = revoker.Revoke(ctx, "my-token", RefreshTokenHint)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revoked.Get("token")).To(Equal("my-token"))
+		Expect(revoked.Get("token_type_hint")).To(Equal("refresh_token"))
+		Expect(revoked.Get("client_id")).To(Equal("my-client"))
+	})
+
+	It("Revokes the token using the OpenID Connect metadata", func() {
+		advertise("openid-configuration", server.URL+"/revoke")
+		revoker, err := NewRevoker().
+			SetLogger(logger).
+			SetIssuer(server.URL + "/").
+			SetClientId("my-client").
+			SetClientSecret("my-secret").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = revoker.Revoke(ctx, "my-token", AccessTokenHint)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revoked.Get("token")).To(Equal("my-token"))
+		Expect(revoked.Has("client_id")).To(BeFalse())
+		Expect(user).To(Equal("my-client"))
+	})
+
+	It("Returns not supported if there is no revocation endpoint", func() {
+		advertise("oauth-authorization-server", "")
+		revoker, err := NewRevoker().
+			SetLogger(logger).
+			SetIssuer(server.URL).
+			SetClientId("my-client").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = revoker.Revoke(ctx, "my-token", RefreshTokenHint)
+		Expect(err).To(MatchError(ErrNotSupported))
+		Expect(revoked).To(BeNil())
+	})
+
+	It("Fails if there is no metadata", func() {
+		revoker, err := NewRevoker().
+			SetLogger(logger).
+			SetIssuer(server.URL).
+			SetClientId("my-client").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = revoker.Revoke(ctx, "my-token", RefreshTokenHint)
+		Expect(err).To(MatchError(ContainSubstring("failed to fetch metadata")))
+		Expect(err).ToNot(MatchError(ErrNotSupported))
+	})
+
+	It("Reports the error returned by the endpoint", func() {
+		advertise("oauth-authorization-server", server.URL+"/fail")
+		mux.HandleFunc("POST /fail", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"Unknown client"}`))
+		})
+		revoker, err := NewRevoker().
+			SetLogger(logger).
+			SetIssuer(server.URL).
+			SetClientId("my-client").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = revoker.Revoke(ctx, "my-token", RefreshTokenHint)
+		Expect(err).To(MatchError(ContainSubstring("status 400: invalid_client Unknown client")))
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package selector translates label selectors, with the syntax used by Kubernetes, into CEL filters.
+package selector
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// labelsExpr is the CEL expression that returns the labels of the object.
+const labelsExpr = "this.metadata.labels"
+
+// Filter translates the given label selector into a CEL filter. The selector is a comma separated list of
+// requirements, all of which must be satisfied. The supported requirements are the same that Kubernetes supports:
+//
+//	key=value         The label exists and has the given value. Can also be written 'key==value'.
+//	key!=value        The label doesn't exist or has a different value.
+//	key in (v1,v2)    The label exists and has one of the given values.
+//	key notin (v1,v2) The label doesn't exist or has a value different to all the given values.
+//	key               The label exists, with any value.
+//	!key              The label doesn't exist.
+//
+// For example, the selector 'env=prod,tier!=frontend' is translated into a filter that matches the objects that have
+// the 'env' label with value 'prod', and don't have the 'tier' label with value 'frontend'.
+func Filter(selector string) (result string, err error) {
+	requirements, err := split(selector)
+	if err != nil {
+		return
+	}
+	if len(requirements) == 0 {
+		err = fmt.Errorf("label selector '%s' is empty", selector)
+		return
+	}
+	terms := make([]string, len(requirements))
+	for i, requirement := range requirements {
+		terms[i], err = translate(requirement)
+		if err != nil {
+			err = fmt.Errorf("invalid label selector '%s': %w", selector, err)
+			return
+		}
+	}
+	result = strings.Join(terms, " && ")
+	return
+}
+
+// split splits the selector into requirements, separated by commas that aren't inside parenthesis.
+func split(selector string) (result []string, err error) {
+	depth := 0
+	start := 0
+	add := func(end int) {
+		requirement := strings.TrimSpace(selector[start:end])
+		if requirement != "" {
+			result = append(result, requirement)
+		}
+	}
+	for i, char := range selector {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				err = fmt.Errorf("invalid label selector '%s': unbalanced parenthesis", selector)
+				return
+			}
+		case ',':
+			if depth == 0 {
+				add(i)
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		err = fmt.Errorf("invalid label selector '%s': unbalanced parenthesis", selector)
+		return
+	}
+	add(len(selector))
+	return
+}
+
+// Regular expressions used to recognize the requirements:
+var (
+	setRE      = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+	equalityRE = regexp.MustCompile(`^([^=!]+)(==|=|!=)(.*)$`)
+	keyRE      = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?)$`)
+	valueRE    = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+)
+
+func translate(requirement string) (result string, err error) {
+	// Set based requirements:
+	if matches := setRE.FindStringSubmatch(requirement); matches != nil {
+		key := matches[1]
+		err = checkKey(key)
+		if err != nil {
+			return
+		}
+		var values []string
+		for _, value := range strings.Split(matches[3], ",") {
+			value = strings.TrimSpace(value)
+			err = checkValue(value)
+			if err != nil {
+				return
+			}
+			values = append(values, strconv.Quote(value))
+		}
+		list := "[" + strings.Join(values, ", ") + "]"
+		if matches[2] == "in" {
+			result = fmt.Sprintf("(%s && %s in %s)", hasExpr(key), valueExpr(key), list)
+		} else {
+			result = fmt.Sprintf("(!(%s) || !(%s in %s))", hasExpr(key), valueExpr(key), list)
+		}
+		return
+	}
+
+	// Equality based requirements:
+	if matches := equalityRE.FindStringSubmatch(requirement); matches != nil {
+		key := strings.TrimSpace(matches[1])
+		value := strings.TrimSpace(matches[3])
+		err = checkKey(key)
+		if err != nil {
+			return
+		}
+		err = checkValue(value)
+		if err != nil {
+			return
+		}
+		if matches[2] == "!=" {
+			result = fmt.Sprintf("(!(%s) || %s != %s)", hasExpr(key), valueExpr(key), strconv.Quote(value))
+		} else {
+			result = fmt.Sprintf("(%s && %s == %s)", hasExpr(key), valueExpr(key), strconv.Quote(value))
+		}
+		return
+	}
+
+	// Existence requirements:
+	key, negated := strings.CutPrefix(requirement, "!")
+	key = strings.TrimSpace(key)
+	err = checkKey(key)
+	if err != nil {
+		return
+	}
+	if negated {
+		result = fmt.Sprintf("!(%s)", hasExpr(key))
+	} else {
+		result = hasExpr(key)
+	}
+	return
+}
+
+func checkKey(key string) error {
+	if !keyRE.MatchString(key) {
+		return fmt.Errorf("'%s' isn't a valid label name", key)
+	}
+	return nil
+}
+
+func checkValue(value string) error {
+	if !valueRE.MatchString(value) {
+		return fmt.Errorf("'%s' isn't a valid label value", value)
+	}
+	return nil
+}
+
+func hasExpr(key string) string {
+	return fmt.Sprintf("%s in %s", strconv.Quote(key), labelsExpr)
+}
+
+func valueExpr(key string) string {
+	return fmt.Sprintf("%s[%s]", labelsExpr, strconv.Quote(key))
+}
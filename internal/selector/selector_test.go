@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package selector
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	DescribeTable(
+		"Translates valid selectors",
+		func(selector string, expected string) {
+			actual, err := Filter(selector)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+		Entry(
+			"Equality",
+			"env=prod",
+			`("env" in this.metadata.labels && this.metadata.labels["env"] == "prod")`,
+		),
+		Entry(
+			"Double equality",
+			"env==prod",
+			`("env" in this.metadata.labels && this.metadata.labels["env"] == "prod")`,
+		),
+		Entry(
+			"Inequality",
+			"env!=prod",
+			`(!("env" in this.metadata.labels) || this.metadata.labels["env"] != "prod")`,
+		),
+		Entry(
+			"Empty value",
+			"env=",
+			`("env" in this.metadata.labels && this.metadata.labels["env"] == "")`,
+		),
+		Entry(
+			"Set",
+			"env in (prod, staging)",
+			`("env" in this.metadata.labels && this.metadata.labels["env"] in ["prod", "staging"])`,
+		),
+		Entry(
+			"Negated set",
+			"env notin (prod,staging)",
+			`(!("env" in this.metadata.labels) || !(this.metadata.labels["env"] in ["prod", "staging"]))`,
+		),
+		Entry(
+			"Existence",
+			"env",
+			`"env" in this.metadata.labels`,
+		),
+		Entry(
+			"Non existence",
+			"!env",
+			`!("env" in this.metadata.labels)`,
+		),
+		Entry(
+			"Prefixed key",
+			"example.com/team=blue",
+			`("example.com/team" in this.metadata.labels && this.metadata.labels["example.com/team"] == "blue")`,
+		),
+		Entry(
+			"Multiple requirements",
+			"env=prod, tier in (a,b),!canary",
+			`("env" in this.metadata.labels && this.metadata.labels["env"] == "prod") && `+
+				`("tier" in this.metadata.labels && this.metadata.labels["tier"] in ["a", "b"]) && `+
+				`!("canary" in this.metadata.labels)`,
+		),
+	)
+
+	DescribeTable(
+		"Rejects invalid selectors",
+		func(selector string, expected string) {
+			_, err := Filter(selector)
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("Empty", "", "label selector '' is empty"),
+		Entry("Only commas", " , ", "label selector ' , ' is empty"),
+		Entry("Unbalanced", "env in (a,b", "invalid label selector 'env in (a,b': unbalanced parenthesis"),
+		Entry("Invalid key", "en v=prod", "invalid label selector 'en v=prod': 'en v' isn't a valid label name"),
+		Entry("Invalid value", "env=a b", "invalid label selector 'env=a b': 'a b' isn't a valid label value"),
+		Entry(
+			"Quotes in key",
+			`"env"`,
+			`invalid label selector '"env"': '"env"' isn't a valid label name`,
+		),
+	)
+})
@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package shell contains the functions that run the scripts configured by the user, like the token script, the hooks
+// or the command of the '--exec' option of watches, with the shell of the operating system and an environment that
+// doesn't contain the secrets of the tool itself.
+package shell
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// scrubbedPrefix is the prefix of the environment variables that aren't passed to the scripts, as they may contain
+// secrets of the tool itself, like the access token or the passphrase of the configuration.
+const scrubbedPrefix = "FULFILLMENT_"
+
+// shellKind indicates how a shell expects to receive the script to run.
+type shellKind int
+
+const (
+	// posixShell is a shell like 'sh' or 'bash', that receives the script with the '-c' option.
+	posixShell shellKind = iota
+
+	// cmdShell is the Windows command interpreter, that receives the script with the '/c' option.
+	cmdShell
+
+	// powerShell is PowerShell, either the Windows version or the portable one, that receives the script with the
+	// '-Command' option.
+	powerShell
+)
+
+// System returns the shell of the operating system, used when none has been configured. In Windows it is the command
+// interpreter given by the 'ComSpec' environment variable, and in other systems the shell given by the 'SHELL'
+// environment variable, or '/bin/sh' if not set.
+func System() string {
+	return defaultShell(runtime.GOOS, os.Getenv)
+}
+
+// Command creates a command that runs the given script with the given shell, or with the default shell if it is empty.
+// The script is passed in the way that the kind of shell expects, '-c' for POSIX shells, '/c' for the Windows command
+// interpreter and '-Command' for PowerShell. The environment of the command is the one returned by the Environ
+// function, and callers can add their own variables to it.
+func Command(ctx context.Context, shell string, script string) *exec.Cmd {
+	if shell == "" {
+		shell = System()
+	}
+	kind := shellKindOf(shell)
+	result := exec.CommandContext(ctx, shell, shellArgs(kind, script)...)
+	setCommandLine(result, shell, kind, script)
+	result.Env = Environ()
+	return result
+}
+
+// Environ returns the environment of the process without the variables of the tool itself, as they may contain
+// secrets like the access token or the passphrase of the configuration.
+func Environ() []string {
+	return scrubEnv(os.Environ())
+}
+
+// scrubEnv returns a copy of the given environment without the variables of the tool itself.
+func scrubEnv(env []string) []string {
+	result := make([]string, 0, len(env))
+	for _, item := range env {
+		if strings.HasPrefix(item, scrubbedPrefix) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// defaultShell returns the shell used when none has been configured. In Windows it is the command interpreter given by
+// the 'ComSpec' environment variable, and in other systems the shell given by the 'SHELL' environment variable, or
+// '/bin/sh' if not set.
+func defaultShell(goos string, getenv func(string) string) string {
+	if goos == "windows" {
+		result := getenv("ComSpec")
+		if result == "" {
+			result = "cmd.exe"
+		}
+		return result
+	}
+	result := getenv("SHELL")
+	if result == "" {
+		result = "/bin/sh"
+	}
+	return result
+}
+
+// shellKindOf determines the kind of the given shell from the name of its executable.
+func shellKindOf(shell string) shellKind {
+	name := strings.ToLower(filepath.Base(strings.ReplaceAll(shell, `\`, "/")))
+	name = strings.TrimSuffix(name, ".exe")
+	switch name {
+	case "cmd":
+		return cmdShell
+	case "powershell", "pwsh":
+		return powerShell
+	default:
+		return posixShell
+	}
+}
+
+// shellArgs returns the arguments that should be passed to a shell of the given kind so that it runs the script.
+func shellArgs(kind shellKind, script string) []string {
+	switch kind {
+	case cmdShell:
+		return []string{"/d", "/s", "/c", script}
+	case powerShell:
+		return []string{"-NoProfile", "-NonInteractive", "-Command", script}
+	default:
+		return []string{"-c", script}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package shell
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shell", func() {
+	DescribeTable(
+		"Selects the default shell",
+		func(goos string, env map[string]string, expected string) {
+			getenv := func(name string) string {
+				return env[name]
+			}
+			Expect(defaultShell(goos, getenv)).To(Equal(expected))
+		},
+		Entry("Linux with shell", "linux", map[string]string{"SHELL": "/bin/bash"}, "/bin/bash"),
+		Entry("Linux without shell", "linux", map[string]string{}, "/bin/sh"),
+		Entry(
+			"Windows with command interpreter",
+			"windows",
+			map[string]string{"ComSpec": `C:\Windows\system32\cmd.exe`},
+			`C:\Windows\system32\cmd.exe`,
+		),
+		Entry("Windows without command interpreter", "windows", map[string]string{}, "cmd.exe"),
+		Entry("Windows ignores shell", "windows", map[string]string{"SHELL": "/bin/bash"}, "cmd.exe"),
+	)
+
+	DescribeTable(
+		"Passes the script to the shell",
+		func(shell string, expected []string) {
+			Expect(shellArgs(shellKindOf(shell), "my-script")).To(Equal(expected))
+		},
+		Entry("POSIX shell", "/bin/bash", []string{"-c", "my-script"}),
+		Entry("Command interpreter", `C:\Windows\system32\cmd.exe`, []string{"/d", "/s", "/c", "my-script"}),
+		Entry("Command interpreter without path", "CMD", []string{"/d", "/s", "/c", "my-script"}),
+		Entry(
+			"Windows PowerShell",
+			"powershell.exe",
+			[]string{"-NoProfile", "-NonInteractive", "-Command", "my-script"},
+		),
+		Entry(
+			"Portable PowerShell",
+			"/usr/bin/pwsh",
+			[]string{"-NoProfile", "-NonInteractive", "-Command", "my-script"},
+		),
+	)
+	It("Removes the variables of the tool from the environment", func() {
+		Expect(scrubEnv([]string{
+			"FULFILLMENT_CLI_PASSPHRASE=secret",
+			"FULFILLMENT_SERVICE_TOKEN=secret",
+			"MY_VARIABLE=my-value",
+		})).To(Equal([]string{
+			"MY_VARIABLE=my-value",
+		}))
+	})
+
+	It("Runs the script with the scrubbed environment", func() {
+		if runtime.GOOS == "windows" {
+			Skip("The script uses POSIX shell syntax")
+		}
+		GinkgoT().Setenv("FULFILLMENT_SERVICE_TOKEN", "secret")
+		GinkgoT().Setenv("MY_VARIABLE", "my-value")
+		cmd := Command(context.Background(), "/bin/sh", `echo "${FULFILLMENT_SERVICE_TOKEN:-none}-${MY_VARIABLE}"`)
+		stdout := &bytes.Buffer{}
+		cmd.Stdout = stdout
+		Expect(cmd.Run()).To(Succeed())
+		Expect(stdout.String()).To(Equal("none-my-value\n"))
+	})
+})
@@ -0,0 +1,34 @@
+//go:build windows
+
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package shell
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+)
+
+// setCommandLine sets the raw command line of the process when the shell is the Windows command interpreter, because
+// it doesn't understand the escaping that Go uses for the arguments, and would otherwise receive the quotes of the
+// script mangled.
+func setCommandLine(cmd *exec.Cmd, shell string, kind shellKind, script string) {
+	if kind != cmdShell {
+		return
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine: fmt.Sprintf(`%s /d /s /c "%s"`, syscall.EscapeArg(shell), script),
+	}
+}
@@ -0,0 +1,397 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package telemetry contains the optional OpenTelemetry instrumentation of the CLI. When it is enabled each invocation
+// of the CLI generates a trace, with a span for the command and one child span for each remote procedure call. The
+// trace context is sent to the server using the W3C 'traceparent' metadata, so that operators can correlate the
+// actions of the CLI with the traces of the server, and the spans are exported to an OTLP endpoint.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/version"
+)
+
+// ServiceName is the default name of the service that is reported in the resource of the spans. It can be changed
+// with the standard 'OTEL_SERVICE_NAME' environment variable.
+const ServiceName = "fulfillment-cli"
+
+// shutdownTimeout is the maximum time that the CLI waits for the spans to be exported when it finishes, so that an
+// unreachable collector doesn't block the user.
+const shutdownTimeout = 5 * time.Second
+
+// Environment variables that enable or disable the instrumentation. These are the ones defined by the OpenTelemetry
+// specification, the rest of the standard variables, like 'OTEL_EXPORTER_OTLP_HEADERS', are also honored.
+const (
+	disabledEnv       = "OTEL_SDK_DISABLED"
+	endpointEnv       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	tracesEndpointEnv = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	headersEnv        = "OTEL_EXPORTER_OTLP_HEADERS"
+	tracesHeadersEnv  = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+)
+
+// Enabled checks if the instrumentation should be enabled. It is enabled when there is an endpoint, either the given
+// one, usually from the configuration file, or one from the standard environment variables, and it hasn't been
+// explicitly disabled with the 'OTEL_SDK_DISABLED' environment variable.
+func Enabled(endpoint string) bool {
+	disabled, _ := strconv.ParseBool(os.Getenv(disabledEnv))
+	if disabled {
+		return false
+	}
+	return endpoint != "" || os.Getenv(endpointEnv) != "" || os.Getenv(tracesEndpointEnv) != ""
+}
+
+// InstrumentationBuilder contains the data and logic needed to create the instrumentation. Don't create instances of
+// this type directly, use the NewInstrumentation function instead.
+type InstrumentationBuilder struct {
+	logger   *slog.Logger
+	endpoint string
+	headers  map[string]string
+	exporter sdktrace.SpanExporter
+}
+
+// Instrumentation generates the spans of an invocation of the CLI and exports them. Don't create instances of this type
+// directly, use the NewInstrumentation function instead.
+type Instrumentation struct {
+	logger     *slog.Logger
+	provider   *sdktrace.TracerProvider
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	span       trace.Span
+}
+
+// NewInstrumentation creates a builder that can then be used to configure and create the instrumentation.
+func NewInstrumentation() *InstrumentationBuilder {
+	return &InstrumentationBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *InstrumentationBuilder) SetLogger(value *slog.Logger) *InstrumentationBuilder {
+	b.logger = value
+	return b
+}
+
+// SetEndpoint sets the URL of the OTLP endpoint where the spans will be sent, for example
+// 'https://collector.example.com:4318/v1/traces'. The 'OTEL_EXPORTER_OTLP_ENDPOINT' and
+// 'OTEL_EXPORTER_OTLP_TRACES_ENDPOINT' environment variables take precedence over this.
+func (b *InstrumentationBuilder) SetEndpoint(value string) *InstrumentationBuilder {
+	b.endpoint = value
+	return b
+}
+
+// SetHeaders sets the headers that will be added to the requests sent to the OTLP endpoint, typically to authenticate.
+// The 'OTEL_EXPORTER_OTLP_HEADERS' and 'OTEL_EXPORTER_OTLP_TRACES_HEADERS' environment variables take precedence over
+// this.
+func (b *InstrumentationBuilder) SetHeaders(value map[string]string) *InstrumentationBuilder {
+	b.headers = value
+	return b
+}
+
+// SetExporter sets the exporter that will be used to send the spans. This is intended for unit tests, when it isn't
+// set an OTLP exporter is created using the endpoint and the headers.
+func (b *InstrumentationBuilder) SetExporter(value sdktrace.SpanExporter) *InstrumentationBuilder {
+	b.exporter = value
+	return b
+}
+
+// Build uses the data stored in the builder to create the instrumentation.
+func (b *InstrumentationBuilder) Build() (result *Instrumentation, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+
+	// Errors exporting spans are reported by the SDK with a global handler that by default writes to the standard
+	// error. That would mix them with the output of the CLI, so we send them to the log instead.
+	logger := b.logger
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn(
+			"OpenTelemetry error",
+			slog.Any("error", err),
+		)
+	}))
+
+	// Create the exporter:
+	exporter := b.exporter
+	if exporter == nil {
+		exporter, err = b.createExporter()
+		if err != nil {
+			return
+		}
+	}
+
+	// Create the resource that describes the CLI. The standard environment variables, like 'OTEL_SERVICE_NAME', are
+	// applied after the defaults, so they can override them.
+	res, err := resource.New(
+		context.Background(),
+		resource.WithAttributes(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version.Get()),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		err = fmt.Errorf("failed to create OpenTelemetry resource: %w", err)
+		return
+	}
+
+	// Create the provider:
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	// Create and populate the object:
+	result = &Instrumentation{
+		logger:     b.logger,
+		provider:   provider,
+		tracer:     provider.Tracer("github.com/osac-project/fulfillment-cli"),
+		propagator: propagation.TraceContext{},
+	}
+	return
+}
+
+// createExporter creates the OTLP exporter. The exporter reads the standard environment variables itself, so the
+// endpoint and headers of the builder are only used when those variables aren't set.
+func (b *InstrumentationBuilder) createExporter() (result sdktrace.SpanExporter, err error) {
+	var options []otlptracehttp.Option
+	if b.endpoint != "" && os.Getenv(endpointEnv) == "" && os.Getenv(tracesEndpointEnv) == "" {
+		options = append(options, otlptracehttp.WithEndpointURL(b.endpoint))
+	}
+	if len(b.headers) > 0 && os.Getenv(headersEnv) == "" && os.Getenv(tracesHeadersEnv) == "" {
+		options = append(options, otlptracehttp.WithHeaders(b.headers))
+	}
+	result, err = otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		err = fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	return
+}
+
+// Start starts the span of the given command, and returns a context that contains it, so that the spans of the calls
+// done by the command will be its children.
+func (i *Instrumentation) Start(ctx context.Context, cmd *cobra.Command) context.Context {
+	ctx, i.span = i.tracer.Start(
+		ctx,
+		cmd.CommandPath(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+	)
+	i.logger.DebugContext(
+		ctx,
+		"Started trace",
+		slog.String("trace_id", i.span.SpanContext().TraceID().String()),
+	)
+	return ctx
+}
+
+// TraceId returns the identifier of the trace of the command, or an empty string if the command span hasn't been
+// started.
+func (i *Instrumentation) TraceId() string {
+	if i.span == nil {
+		return ""
+	}
+	return i.span.SpanContext().TraceID().String()
+}
+
+// Finish ends the span of the given command, and waits a limited time for the pending spans to be exported. It does
+// nothing if the context of the command doesn't contain the instrumentation, which happens when it is disabled or when
+// the command fails before it is created.
+func Finish(cmd *cobra.Command, err error) {
+	if cmd == nil {
+		return
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		return
+	}
+	instrumentation := InstrumentationFromContext(ctx)
+	if instrumentation == nil {
+		return
+	}
+	instrumentation.finish(ctx, err)
+}
+
+func (i *Instrumentation) finish(ctx context.Context, err error) {
+	if i.span != nil {
+		if err != nil {
+			i.span.RecordError(err)
+			i.span.SetStatus(otelcodes.Error, err.Error())
+		}
+		i.span.End()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	err = i.provider.Shutdown(shutdownCtx)
+	if err != nil {
+		i.logger.WarnContext(
+			ctx,
+			"Failed to export OpenTelemetry spans",
+			slog.String("trace_id", i.TraceId()),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// UnaryClient is the unary client interceptor function that creates a span for each call and sends the trace context
+// to the server.
+func (i *Instrumentation) UnaryClient(ctx context.Context, method string, request, response any,
+	conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := i.startCall(ctx, method)
+	err := invoker(ctx, method, request, response, conn, opts...)
+	i.endCall(span, err)
+	return err
+}
+
+// StreamClient is the stream client interceptor function that creates a span for each stream and sends the trace
+// context to the server. The span ends when the stream finishes or when its context is cancelled.
+func (i *Instrumentation) StreamClient(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, span := i.startCall(ctx, method)
+	stream, err := streamer(ctx, desc, conn, method, opts...)
+	if err != nil {
+		i.endCall(span, err)
+		return nil, err
+	}
+	result := &instrumentedStream{
+		ClientStream:    stream,
+		instrumentation: i,
+		span:            span,
+	}
+	result.stop = context.AfterFunc(ctx, func() {
+		result.end(grpcstatus.FromContextError(ctx.Err()).Err())
+	})
+	return result, nil
+}
+
+// startCall starts the span of a call and adds the trace context to the outgoing metadata.
+func (i *Instrumentation) startCall(ctx context.Context, method string) (context.Context, trace.Span) {
+	service, name := splitMethod(method)
+	ctx, span := i.tracer.Start(
+		ctx,
+		strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(name),
+		),
+	)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	i.propagator.Inject(ctx, metadataCarrier(md))
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	return ctx, span
+}
+
+// endCall ends the span of a call, saving the resulting status code.
+func (i *Instrumentation) endCall(span trace.Span, err error) {
+	code := grpcstatus.Code(err)
+	span.SetAttributes(attribute.Int(string(semconv.RPCGRPCStatusCodeKey), int(code)))
+	if code != codes.OK {
+		span.SetStatus(otelcodes.Error, grpcstatus.Convert(err).Message())
+	}
+	span.End()
+}
+
+// splitMethod splits a full method name like '/fulfillment.v1.Clusters/Get' into the service and the method names.
+func splitMethod(method string) (service, name string) {
+	method = strings.TrimPrefix(method, "/")
+	index := strings.LastIndex(method, "/")
+	if index < 0 {
+		name = method
+		return
+	}
+	service = method[:index]
+	name = method[index+1:]
+	return
+}
+
+// instrumentedStream wraps a client stream so that the span ends when the stream finishes.
+type instrumentedStream struct {
+	grpc.ClientStream
+	instrumentation *Instrumentation
+	span            trace.Span
+	stop            func() bool
+	once            sync.Once
+}
+
+func (s *instrumentedStream) RecvMsg(message any) error {
+	err := s.ClientStream.RecvMsg(message)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.end(nil)
+		} else {
+			s.end(err)
+		}
+	}
+	return err
+}
+
+func (s *instrumentedStream) end(err error) {
+	s.once.Do(func() {
+		if s.stop != nil {
+			s.stop()
+		}
+		s.instrumentation.endCall(s.span, err)
+	})
+}
+
+// metadataCarrier adapts the gRPC metadata so that the propagator can write the trace context to it.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	result := make([]string, 0, len(c))
+	for key := range c {
+		result = append(result, key)
+	}
+	return result
+}
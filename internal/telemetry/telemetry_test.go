@@ -0,0 +1,235 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"github.com/spf13/cobra"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Enabled", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv(disabledEnv, "")
+		GinkgoT().Setenv(endpointEnv, "")
+		GinkgoT().Setenv(tracesEndpointEnv, "")
+	})
+
+	It("Is disabled when there is no endpoint", func() {
+		Expect(Enabled("")).To(BeFalse())
+	})
+
+	It("Is enabled when there is an endpoint in the configuration", func() {
+		Expect(Enabled("http://localhost:4318")).To(BeTrue())
+	})
+
+	It("Is enabled when there is an endpoint in the environment", func() {
+		GinkgoT().Setenv(endpointEnv, "http://localhost:4318")
+		Expect(Enabled("")).To(BeTrue())
+	})
+
+	It("Is enabled when there is a traces endpoint in the environment", func() {
+		GinkgoT().Setenv(tracesEndpointEnv, "http://localhost:4318/v1/traces")
+		Expect(Enabled("")).To(BeTrue())
+	})
+
+	It("Is disabled explicitly even if there is an endpoint", func() {
+		GinkgoT().Setenv(disabledEnv, "true")
+		Expect(Enabled("http://localhost:4318")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Instrumentation", func() {
+	var (
+		ctx             context.Context
+		exporter        *tracetest.InMemoryExporter
+		instrumentation *Instrumentation
+		cmd             *cobra.Command
+		conn            *grpc.ClientConn
+		received        chan metadata.MD
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		// Create the instrumentation with an exporter that keeps the spans in memory:
+		exporter = tracetest.NewInMemoryExporter()
+		instrumentation, err = NewInstrumentation().
+			SetLogger(logger).
+			SetExporter(keepingExporter{exporter}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Create a command with a context that contains the instrumentation, and start its span:
+		cmd = &cobra.Command{
+			Use: "get",
+		}
+		root := &cobra.Command{
+			Use: "fulfillment-cli",
+		}
+		root.AddCommand(cmd)
+		ctx = InstrumentationIntoContext(context.Background(), instrumentation)
+		ctx = instrumentation.Start(ctx, cmd)
+		cmd.SetContext(ctx)
+
+		// Start a server that saves the metadata that it receives:
+		received = make(chan metadata.MD, 10)
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+			GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest) (*ffv1.ClustersGetResponse,
+				error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				received <- md
+				if request.GetId() == "missing" {
+					return nil, grpcstatus.Errorf(codes.NotFound, "cluster doesn't exist")
+				}
+				return ffv1.ClustersGetResponse_builder{
+					Object: ffv1.Cluster_builder{
+						Id: request.GetId(),
+					}.Build(),
+				}.Build(), nil
+			},
+		})
+		eventsv1.RegisterEventsServer(
+			server.Registrar(),
+			testing.NewMockEventsServerBuilder().
+				WithScenario(&testing.EventScenario{
+					Name: "one",
+					Events: []*testing.ScenarioEvent{{
+						ID:   "event-1",
+						Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+						Cluster: &testing.ClusterEventData{
+							ID: "cluster-1",
+						},
+					}},
+				}).
+				Build(),
+		)
+		server.Start()
+
+		// Create the connection:
+		conn, err = grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(instrumentation.UnaryClient),
+			grpc.WithStreamInterceptor(instrumentation.StreamClient),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+	})
+
+	// spans returns the exported spans indexed by name.
+	spans := func() map[string]tracetest.SpanStub {
+		result := map[string]tracetest.SpanStub{}
+		for _, span := range exporter.GetSpans() {
+			result[span.Name] = span
+		}
+		return result
+	}
+
+	It("Requires a logger", func() {
+		_, err := NewInstrumentation().
+			SetExporter(exporter).
+			Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Sends the trace context to the server", func() {
+		_, err := ffv1.NewClustersClient(conn).Get(ctx, ffv1.ClustersGetRequest_builder{
+			Id: "cluster-1",
+		}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		var md metadata.MD
+		Eventually(received).Should(Receive(&md))
+		Expect(md.Get("traceparent")).To(HaveLen(1))
+		Expect(md.Get("traceparent")[0]).To(HavePrefix("00-" + instrumentation.TraceId() + "-"))
+	})
+
+	It("Exports the spans of the command and of the calls when it finishes", func() {
+		client := ffv1.NewClustersClient(conn)
+		_, err := client.Get(ctx, ffv1.ClustersGetRequest_builder{
+			Id: "cluster-1",
+		}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.Get(ctx, ffv1.ClustersGetRequest_builder{
+			Id: "missing",
+		}.Build())
+		Expect(err).To(HaveOccurred())
+		Expect(exporter.GetSpans()).To(BeEmpty())
+
+		Finish(cmd, errors.New("my error"))
+		all := exporter.GetSpans()
+		Expect(all).To(HaveLen(3))
+		command := spans()["fulfillment-cli get"]
+		Expect(command.Status.Code).To(Equal(otelcodes.Error))
+		Expect(command.Status.Description).To(Equal("my error"))
+		for _, span := range all {
+			Expect(span.SpanContext.TraceID().String()).To(Equal(instrumentation.TraceId()))
+			if span.Name != command.Name {
+				Expect(span.Parent.SpanID()).To(Equal(command.SpanContext.SpanID()))
+				Expect(span.Name).To(Equal("fulfillment.v1.Clusters/Get"))
+			}
+		}
+		Expect(all[1].Status.Code).To(Equal(otelcodes.Error))
+		Expect(all[1].Status.Description).To(Equal("cluster doesn't exist"))
+	})
+
+	It("Ends the span of a stream when its context is cancelled", func() {
+		streamCtx, cancel := context.WithCancel(ctx)
+		stream, err := eventsv1.NewEventsClient(conn).Watch(streamCtx, &eventsv1.EventsWatchRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).ToNot(HaveOccurred())
+		cancel()
+		_, err = stream.Recv()
+		Expect(grpcstatus.Code(err)).To(Equal(codes.Canceled))
+
+		Finish(cmd, nil)
+		watch, ok := spans()["events.v1.Events/Watch"]
+		Expect(ok).To(BeTrue())
+		Expect(watch.Status.Code).To(Equal(otelcodes.Error))
+		Expect(spans()["fulfillment-cli get"].Status.Code).To(Equal(otelcodes.Unset))
+	})
+
+	It("Does nothing if the command doesn't have the instrumentation", func() {
+		Finish(&cobra.Command{}, nil)
+		Finish(nil, nil)
+		Expect(exporter.GetSpans()).To(BeEmpty())
+	})
+})
+
+// keepingExporter is an in memory exporter that doesn't discard the spans when it is shut down, so that they can be
+// checked after calling the Finish function.
+type keepingExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (e keepingExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
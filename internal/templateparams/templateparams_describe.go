@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// DetailedDefinition is the interface implemented by the parameter definitions of all the types of templates,
+// including the description and the default value that the parser doesn't need.
+type DetailedDefinition interface {
+	Definition
+	GetDescription() string
+	GetDefault() *anypb.Any
+}
+
+// Details contains the information about a template parameter that is displayed to users, with the type and the
+// default value already converted to text.
+type Details struct {
+	// Name is the name of the parameter.
+	Name string
+
+	// Title is the title of the parameter.
+	Title string
+
+	// Type is the type of the parameter, like 'string' or 'int32'.
+	Type string
+
+	// Required indicates if the parameter must be given when creating objects.
+	Required bool
+
+	// Default is the text representation of the default value, or empty if there is no default.
+	Default string
+
+	// Description is the first paragraph of the description of the parameter.
+	Description string
+}
+
+// DetailsOf converts the given parameter definitions into the details that are displayed to users, sorted by name.
+func DetailsOf[T DetailedDefinition](definitions []T) []Details {
+	results := make([]Details, len(definitions))
+	for i, definition := range definitions {
+		results[i] = Details{
+			Name:        definition.GetName(),
+			Title:       definition.GetTitle(),
+			Type:        TypeName(definition.GetType()),
+			Required:    definition.GetRequired(),
+			Default:     FormatValue(definition.GetDefault()),
+			Description: firstParagraph(definition.GetDescription()),
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// TypeName returns the short name of the given parameter type, for example 'string' for the URL of the protobuf string
+// wrapper type. It returns 'unknown' if the type isn't supported.
+func TypeName(typeUrl string) string {
+	switch typeUrl {
+	case typeString:
+		return "string"
+	case typeBool:
+		return "boolean"
+	case typeInt32:
+		return "int32"
+	case typeInt64:
+		return "int64"
+	case typeFloat:
+		return "float"
+	case typeDouble:
+		return "double"
+	case typeBytes:
+		return "bytes"
+	case typeTimestamp:
+		return "timestamp"
+	case typeDuration:
+		return "duration"
+	default:
+		return "unknown"
+	}
+}
+
+// FormatValue returns the text representation of a parameter value, in the same format that is accepted by the
+// '--template-parameter' flag. Strings are quoted, so that empty strings are visible, and bytes are summarized with
+// their size. It returns an empty string if the value is nil.
+func FormatValue(value *anypb.Any) string {
+	if value == nil {
+		return ""
+	}
+	message, err := value.UnmarshalNew()
+	if err != nil {
+		return fmt.Sprintf("<%s>", value.GetTypeUrl())
+	}
+	switch typed := message.(type) {
+	case *wrapperspb.StringValue:
+		return strconv.Quote(typed.GetValue())
+	case *wrapperspb.BoolValue:
+		return strconv.FormatBool(typed.GetValue())
+	case *wrapperspb.Int32Value:
+		return strconv.FormatInt(int64(typed.GetValue()), 10)
+	case *wrapperspb.Int64Value:
+		return strconv.FormatInt(typed.GetValue(), 10)
+	case *wrapperspb.FloatValue:
+		return strconv.FormatFloat(float64(typed.GetValue()), 'g', -1, 32)
+	case *wrapperspb.DoubleValue:
+		return strconv.FormatFloat(typed.GetValue(), 'g', -1, 64)
+	case *wrapperspb.BytesValue:
+		return fmt.Sprintf("<%d bytes>", len(typed.GetValue()))
+	case *timestamppb.Timestamp:
+		return typed.AsTime().UTC().Format(time.RFC3339)
+	case *durationpb.Duration:
+		return typed.AsDuration().String()
+	default:
+		data, err := protojson.Marshal(message)
+		if err != nil {
+			return fmt.Sprintf("<%s>", value.GetTypeUrl())
+		}
+		return string(data)
+	}
+}
+
+// firstParagraph returns the first paragraph of the given text, with the lines joined, as descriptions of parameters
+// can be long and the rest of the paragraphs usually contain details that don't fit in a table.
+func firstParagraph(text string) string {
+	text = strings.TrimSpace(text)
+	paragraph, _, _ := strings.Cut(text, "\n\n")
+	return strings.Join(strings.Fields(paragraph), " ")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var _ = Describe("Details", func() {
+	It("Converts the definitions and sorts them by name", func() {
+		value, err := anypb.New(wrapperspb.Int32(3))
+		Expect(err).ToNot(HaveOccurred())
+		definitions := []*ffv1.ClusterTemplateParameterDefinition{
+			ffv1.ClusterTemplateParameterDefinition_builder{
+				Name:        "replicas",
+				Title:       "Replicas",
+				Type:        typeInt32,
+				Default:     value,
+				Description: "Number of\nreplicas.\n\nMore details.",
+			}.Build(),
+			ffv1.ClusterTemplateParameterDefinition_builder{
+				Name:     "pull_secret",
+				Title:    "Pull secret",
+				Type:     typeString,
+				Required: true,
+			}.Build(),
+		}
+		details := DetailsOf(definitions)
+		Expect(details).To(Equal([]Details{
+			{
+				Name:     "pull_secret",
+				Title:    "Pull secret",
+				Type:     "string",
+				Required: true,
+			},
+			{
+				Name:        "replicas",
+				Title:       "Replicas",
+				Type:        "int32",
+				Default:     "3",
+				Description: "Number of replicas.",
+			},
+		}))
+	})
+
+	DescribeTable(
+		"Formats values",
+		func(value proto.Message, expected string) {
+			wrapped, err := anypb.New(value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(FormatValue(wrapped)).To(Equal(expected))
+		},
+		Entry("String", wrapperspb.String("my-value"), `"my-value"`),
+		Entry("Empty string", wrapperspb.String(""), `""`),
+		Entry("Boolean", wrapperspb.Bool(true), "true"),
+		Entry("Int32", wrapperspb.Int32(-1), "-1"),
+		Entry("Int64", wrapperspb.Int64(1234567890123), "1234567890123"),
+		Entry("Float", wrapperspb.Float(1.5), "1.5"),
+		Entry("Double", wrapperspb.Double(0.25), "0.25"),
+		Entry("Bytes", wrapperspb.Bytes([]byte("abc")), "<3 bytes>"),
+		Entry(
+			"Timestamp",
+			timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+			"2026-01-02T03:04:05Z",
+		),
+		Entry("Duration", durationpb.New(90*time.Second), "1m30s"),
+	)
+
+	It("Returns an empty string for nil values", func() {
+		Expect(FormatValue(nil)).To(BeEmpty())
+	})
+
+	It("Returns the type for unknown values", func() {
+		value := &anypb.Any{
+			TypeUrl: "type.googleapis.com/my.Unknown",
+		}
+		Expect(FormatValue(value)).To(Equal("<type.googleapis.com/my.Unknown>"))
+	})
+})
@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Supported encodings for the content of template parameter files:
+const (
+	// FileEncodingNone means that the content of the file is used as is.
+	FileEncodingNone = "none"
+
+	// FileEncodingGzipBase64 means that the content of the file is compressed with gzip and then encoded with
+	// base64. This is only applied to parameters of type bytes.
+	FileEncodingGzipBase64 = "gzip+base64"
+)
+
+// DefaultMaxFileSize is the default maximum size of the content of a template parameter file. This is the default
+// maximum size of the messages that gRPC servers accept, so larger files would be rejected anyhow.
+const DefaultMaxFileSize = "4MiB"
+
+// FileTooLargeError is the error returned when a template parameter file is larger than the limit.
+type FileTooLargeError struct {
+	// File is the name of the file.
+	File string
+
+	// Size is the size of the file. This will be zero when the file was being compressed, as in that case the
+	// reading stops as soon as the limit is exceeded.
+	Size int64
+
+	// Limit is the maximum allowed size.
+	Limit int64
+}
+
+// Error is the implementation of the error interface.
+func (e *FileTooLargeError) Error() string {
+	if e.Size > 0 {
+		return fmt.Sprintf(
+			"file '%s' has %s, which exceeds the limit of %s",
+			e.File, humanize.IBytes(uint64(e.Size)), humanize.IBytes(uint64(e.Limit)),
+		)
+	}
+	return fmt.Sprintf(
+		"file '%s' exceeds the limit of %s even after compressing it",
+		e.File, humanize.IBytes(uint64(e.Limit)),
+	)
+}
+
+// ParseFileEncoding checks that the given text is one of the supported file encodings.
+func ParseFileEncoding(text string) (result string, err error) {
+	switch text {
+	case "", FileEncodingNone:
+		result = FileEncodingNone
+	case FileEncodingGzipBase64:
+		result = FileEncodingGzipBase64
+	default:
+		err = fmt.Errorf(
+			"unsupported file encoding '%s', valid values are '%s' and '%s'",
+			text, FileEncodingNone, FileEncodingGzipBase64,
+		)
+	}
+	return
+}
+
+// ParseMaxFileSize parses a size like '4MiB' or '1024'.
+func ParseMaxFileSize(text string) (result int64, err error) {
+	value, err := humanize.ParseBytes(text)
+	if err != nil {
+		err = fmt.Errorf("failed to parse maximum file size '%s': %w", text, err)
+		return
+	}
+	result = int64(value)
+	return
+}
+
+// ReadFile reads the content of a template parameter file, applying the given encoding and checking that the result
+// doesn't exceed the given limit. The check is done before reading the file when possible, and otherwise the content
+// is processed as a stream and the reading stops as soon as the limit is exceeded, so that large files are never
+// completely loaded in memory.
+func ReadFile(file string, encoding string, limit int64) (result []byte, err error) {
+	reader, err := os.Open(file)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+	info, err := reader.Stat()
+	if err != nil {
+		return
+	}
+	if encoding == FileEncodingNone && info.Size() > limit {
+		err = &FileTooLargeError{
+			File:  file,
+			Size:  info.Size(),
+			Limit: limit,
+		}
+		return
+	}
+	buffer := &limitedBuffer{
+		limit: limit,
+	}
+	switch encoding {
+	case FileEncodingGzipBase64:
+		err = copyGzipBase64(buffer, reader)
+	default:
+		_, err = io.Copy(buffer, reader)
+	}
+	if errors.Is(err, errLimitExceeded) {
+		tooLarge := &FileTooLargeError{
+			File:  file,
+			Limit: limit,
+		}
+		if encoding == FileEncodingNone {
+			tooLarge.Size = buffer.total
+		}
+		err = tooLarge
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read file '%s': %w", file, err)
+		return
+	}
+	result = buffer.data.Bytes()
+	return
+}
+
+// copyGzipBase64 compresses the content of the reader and writes it to the writer encoded with base64.
+func copyGzipBase64(writer io.Writer, reader io.Reader) error {
+	encoder := base64.NewEncoder(base64.StdEncoding, writer)
+	compressor := gzip.NewWriter(encoder)
+	_, err := io.Copy(compressor, reader)
+	if err != nil {
+		return err
+	}
+	err = compressor.Close()
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// errLimitExceeded is returned by the limited buffer when the limit is exceeded.
+var errLimitExceeded = errors.New("limit exceeded")
+
+// limitedBuffer is a buffer that fails when the total amount of data written exceeds a limit. Note that the bytes
+// buffer isn't embedded because then its ReadFrom method would be used by io.Copy, bypassing the limit.
+type limitedBuffer struct {
+	data  bytes.Buffer
+	limit int64
+	total int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (n int, err error) {
+	b.total += int64(len(p))
+	if b.total > b.limit {
+		err = errLimitExceeded
+		return
+	}
+	return b.data.Write(p)
+}
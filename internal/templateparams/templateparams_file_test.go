@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parameter files", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name string, content string) string {
+		file := filepath.Join(dir, name)
+		err := os.WriteFile(file, []byte(content), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		return file
+	}
+
+	It("Reads small files as is", func() {
+		file := writeFile("small.txt", "hello")
+		data, err := ReadFile(file, FileEncodingNone, 1024)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("hello"))
+	})
+
+	It("Rejects files larger than the limit before reading them", func() {
+		file := writeFile("large.txt", strings.Repeat("x", 2048))
+		_, err := ReadFile(file, FileEncodingNone, 1024)
+		var tooLarge *FileTooLargeError
+		Expect(errors.As(err, &tooLarge)).To(BeTrue())
+		Expect(tooLarge.Size).To(BeNumerically("==", 2048))
+		Expect(tooLarge.Limit).To(BeNumerically("==", 1024))
+		Expect(err.Error()).To(Equal("file '" + file + "' has 2.0 KiB, which exceeds the limit of 1.0 KiB"))
+	})
+
+	It("Compresses and encodes files", func() {
+		content := strings.Repeat("compressible ", 1000)
+		file := writeFile("large.txt", content)
+		data, err := ReadFile(file, FileEncodingGzipBase64, 1024)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(data)).To(BeNumerically("<", 1024))
+
+		// Decode and check that the original content is restored:
+		compressed, err := base64.StdEncoding.DecodeString(string(data))
+		Expect(err).ToNot(HaveOccurred())
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		Expect(err).ToNot(HaveOccurred())
+		decompressed, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(decompressed)).To(Equal(content))
+	})
+
+	It("Rejects files that are too large even after compression", func() {
+		random := make([]byte, 4096)
+		for i := range random {
+			random[i] = byte(i * 7919 % 251)
+		}
+		file := writeFile("random.bin", base64.StdEncoding.EncodeToString(random))
+		_, err := ReadFile(file, FileEncodingGzipBase64, 64)
+		var tooLarge *FileTooLargeError
+		Expect(errors.As(err, &tooLarge)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("even after compressing it"))
+	})
+
+	It("Returns not found error if the file doesn't exist", func() {
+		_, err := ReadFile(filepath.Join(dir, "junk"), FileEncodingNone, 1024)
+		Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+	})
+
+	DescribeTable("Parsing of encodings",
+		func(text string, expected string, fails bool) {
+			result, err := ParseFileEncoding(text)
+			if fails {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal(expected))
+			}
+		},
+		Entry("Empty", "", FileEncodingNone, false),
+		Entry("None", "none", FileEncodingNone, false),
+		Entry("Gzip and base64", "gzip+base64", FileEncodingGzipBase64, false),
+		Entry("Unsupported", "zip", "", true),
+	)
+
+	DescribeTable("Parsing of sizes",
+		func(text string, expected int64) {
+			result, err := ParseMaxFileSize(text)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("Plain number", "1024", int64(1024)),
+		Entry("Binary unit", "4MiB", int64(4*1024*1024)),
+		Entry("Decimal unit", "1MB", int64(1000*1000)),
+	)
+})
@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// Args contains the values of the command line flags used to give template parameters.
+type Args struct {
+	Values       []string
+	Files        []string
+	FileEncoding string
+	FileMaxSize  string
+}
+
+// AddFlags adds to the given flag set the flags used to give template parameters, storing their values in the given
+// arguments. All the commands that create objects from templates should use this, so that the flags are the same.
+func AddFlags(flags *pflag.FlagSet, args *Args) {
+	flags.StringSliceVarP(
+		&args.Values,
+		"template-parameter",
+		"p",
+		[]string{},
+		"Template parameter in the format 'name=value'.",
+	)
+	flags.StringSliceVarP(
+		&args.Files,
+		"template-parameter-file",
+		"f",
+		[]string{},
+		"Template parameter from file in the format 'name=filename'.",
+	)
+	flags.StringVar(
+		&args.FileEncoding,
+		"template-parameter-file-encoding",
+		FileEncodingNone,
+		fmt.Sprintf(
+			"Encoding applied to the content of files for parameters of type bytes, one of '%s' or '%s'.",
+			FileEncodingNone, FileEncodingGzipBase64,
+		),
+	)
+	flags.StringVar(
+		&args.FileMaxSize,
+		"template-parameter-file-max-size",
+		DefaultMaxFileSize,
+		"Maximum size of the content of template parameter files, after applying the encoding. The "+
+			"default is the default limit of the server.",
+	)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Mapping is the result of mapping the parameter values of an object created from one template to the parameters of
+// another template.
+type Mapping struct {
+	// Values contains the values that can be kept, because the new template has a parameter with the same name and
+	// type.
+	Values map[string]*anypb.Any
+
+	// Dropped contains the names of the parameters that don't exist in the new template, sorted by name.
+	Dropped []string
+
+	// Incompatible contains the names of the parameters that exist in the new template, but with a different type,
+	// sorted by name.
+	Incompatible []string
+}
+
+// Map maps the given parameter values, for example the values of an existing object, to the given definitions, for
+// example the parameters of a different template. Values are kept when there is a definition with the same name and
+// type, and the rest are reported as dropped or incompatible.
+func Map(values map[string]*anypb.Any, definitions []Definition) (result Mapping) {
+	types := make(map[string]string, len(definitions))
+	for _, definition := range definitions {
+		types[definition.GetName()] = definition.GetType()
+	}
+	result.Values = map[string]*anypb.Any{}
+	for name, value := range values {
+		kind, ok := types[name]
+		switch {
+		case !ok:
+			result.Dropped = append(result.Dropped, name)
+		case kind != value.GetTypeUrl():
+			result.Incompatible = append(result.Incompatible, name)
+		default:
+			result.Values[name] = value
+		}
+	}
+	sort.Strings(result.Dropped)
+	sort.Strings(result.Incompatible)
+	return
+}
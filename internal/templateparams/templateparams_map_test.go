@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var _ = Describe("Map", func() {
+	It("Keeps compatible values and reports the rest", func() {
+		stringValue, err := anypb.New(wrapperspb.String("a"))
+		Expect(err).ToNot(HaveOccurred())
+		intValue, err := anypb.New(wrapperspb.Int32(1))
+		Expect(err).ToNot(HaveOccurred())
+		boolValue, err := anypb.New(wrapperspb.Bool(true))
+		Expect(err).ToNot(HaveOccurred())
+		definitions := []*ffv1.ComputeInstanceTemplateParameterDefinition{
+			ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+				Name: "kept",
+				Type: typeString,
+			}.Build(),
+			ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+				Name: "changed",
+				Type: typeString,
+			}.Build(),
+		}
+		mapping := Map(
+			map[string]*anypb.Any{
+				"kept":    stringValue,
+				"changed": intValue,
+				"removed": boolValue,
+			},
+			Definitions(definitions),
+		)
+		Expect(mapping.Values).To(HaveLen(1))
+		Expect(mapping.Values).To(HaveKeyWithValue("kept", stringValue))
+		Expect(mapping.Dropped).To(Equal([]string{"removed"}))
+		Expect(mapping.Incompatible).To(Equal([]string{"changed"}))
+	})
+
+	It("Accepts empty values", func() {
+		mapping := Map(nil, nil)
+		Expect(mapping.Values).To(BeEmpty())
+		Expect(mapping.Dropped).To(BeEmpty())
+		Expect(mapping.Incompatible).To(BeEmpty())
+	})
+})
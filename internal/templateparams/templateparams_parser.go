@@ -0,0 +1,594 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Supported parameter types:
+const (
+	typeBool      = "type.googleapis.com/google.protobuf.BoolValue"
+	typeBytes     = "type.googleapis.com/google.protobuf.BytesValue"
+	typeDouble    = "type.googleapis.com/google.protobuf.DoubleValue"
+	typeDuration  = "type.googleapis.com/google.protobuf.Duration"
+	typeFloat     = "type.googleapis.com/google.protobuf.FloatValue"
+	typeInt32     = "type.googleapis.com/google.protobuf.Int32Value"
+	typeInt64     = "type.googleapis.com/google.protobuf.Int64Value"
+	typeString    = "type.googleapis.com/google.protobuf.StringValue"
+	typeTimestamp = "type.googleapis.com/google.protobuf.Timestamp"
+)
+
+// Definition is the interface implemented by the parameter definitions of all the types of templates.
+type Definition interface {
+	GetName() string
+	GetTitle() string
+	GetType() string
+	GetRequired() bool
+}
+
+// Definitions converts a slice of concrete parameter definitions, like the result of the 'GetParameters' method of a
+// cluster template, into a slice of the generic interface.
+func Definitions[T Definition](values []T) []Definition {
+	result := make([]Definition, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
+
+// ValidParameter contains the information about a valid template parameter, for use in the error messages that
+// display them.
+type ValidParameter struct {
+	// Name is the name of the parameter.
+	Name string
+
+	// Type is the type of the parameter.
+	Type string
+
+	// Title is the title of the parameter.
+	Title string
+
+	// Default is the text representation of the default value, or empty if there is no default or the definition
+	// doesn't implement DetailedDefinition.
+	Default string
+}
+
+// ParserBuilder contains the data and logic needed to create a template parameters parser. Don't create instances of
+// this type directly, use the NewParser function instead.
+type ParserBuilder struct {
+	logger       *slog.Logger
+	definitions  []Definition
+	initial      map[string]*anypb.Any
+	defaults     bool
+	fileEncoding string
+	fileMaxSize  string
+}
+
+// Parser converts the template parameters given in the command line into the values that are sent to the server,
+// checking them against the definitions of the template.
+type Parser struct {
+	logger       *slog.Logger
+	definitions  []Definition
+	initial      map[string]*anypb.Any
+	defaults     bool
+	fileEncoding string
+	fileMaxSize  string
+}
+
+// NewParser creates a builder that can then be used to configure and create a template parameters parser.
+func NewParser() *ParserBuilder {
+	return &ParserBuilder{
+		fileEncoding: FileEncodingNone,
+		fileMaxSize:  DefaultMaxFileSize,
+	}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *ParserBuilder) SetLogger(value *slog.Logger) *ParserBuilder {
+	b.logger = value
+	return b
+}
+
+// AddDefinitions adds the definitions of the parameters of the template.
+func (b *ParserBuilder) AddDefinitions(values ...Definition) *ParserBuilder {
+	b.definitions = append(b.definitions, values...)
+	return b
+}
+
+// SetInitialValues sets the values that the parameters have before parsing the command line, for example the values
+// that an existing object already has. Values given in the command line replace them, and they count when checking
+// that the required parameters have a value.
+func (b *ParserBuilder) SetInitialValues(values map[string]*anypb.Any) *ParserBuilder {
+	b.initial = values
+	return b
+}
+
+// SetDefaults sets whether the default values of the definitions are applied to the parameters that don't have a
+// value after parsing the command line. This only works for definitions that also implement DetailedDefinition, and
+// the defaults are checked against the type of the parameter like the values given by the user. The default is to
+// not apply them, and let the server do it.
+func (b *ParserBuilder) SetDefaults(value bool) *ParserBuilder {
+	b.defaults = value
+	return b
+}
+
+// SetFileEncoding sets the encoding applied to the content of files for parameters of type bytes. The default is to not
+// apply any encoding.
+func (b *ParserBuilder) SetFileEncoding(value string) *ParserBuilder {
+	b.fileEncoding = value
+	return b
+}
+
+// SetFileMaxSize sets the maximum size of the content of parameter files, for example '4MiB'. The default is the
+// default limit of the server.
+func (b *ParserBuilder) SetFileMaxSize(value string) *ParserBuilder {
+	b.fileMaxSize = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new parser. Note that the file encoding and size aren't
+// checked here, problems with them are reported as issues by the Parse method, together with the rest of the
+// problems with the parameters.
+func (b *ParserBuilder) Build() (result *Parser, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+
+	// Create and populate the object:
+	result = &Parser{
+		logger:       b.logger,
+		definitions:  b.definitions,
+		initial:      b.initial,
+		defaults:     b.defaults,
+		fileEncoding: b.fileEncoding,
+		fileMaxSize:  b.fileMaxSize,
+	}
+	return
+}
+
+// Parse parses the parameters given with the 'name=value' format and the files given with the 'name=filename' format
+// into a map of parameter name to value, and a list of issues found. The issues are intended for display to the user.
+func (p *Parser) Parse(ctx context.Context, values, files []string) (result map[string]*anypb.Any, issues []string) {
+	// Prepare the results, starting with the initial values, and empty issues:
+	result = make(map[string]*anypb.Any, len(p.initial))
+	for name, value := range p.initial {
+		result[name] = value
+	}
+
+	// Make a map of parameter definitions indexed by name for quick lookup:
+	definitions := map[string]Definition{}
+	for _, definition := range p.definitions {
+		definitions[definition.GetName()] = definition
+	}
+
+	// Parse the values given directly:
+	for _, flag := range values {
+		parts := strings.SplitN(flag, "=", 2)
+		if len(parts) != 2 {
+			name := strings.TrimSpace(flag)
+			definition := definitions[name]
+			if definition == nil {
+				issues = append(
+					issues,
+					fmt.Sprintf(
+						"In '%s' parameter '%s' doesn't exist, and if it existed the value "+
+							"would be missing",
+						flag, name,
+					),
+				)
+			} else {
+				issues = append(
+					issues,
+					fmt.Sprintf(
+						"In '%s' parameter value is missing",
+						flag,
+					),
+				)
+			}
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"In '%s' parameter name is missing",
+					flag,
+				),
+			)
+			continue
+		}
+		definition := definitions[name]
+		if definition == nil {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"In '%s' parameter '%s' doesn't exist",
+					flag, name,
+				),
+			)
+			continue
+		}
+		text := strings.TrimSpace(parts[1])
+		value, issue := p.convert(ctx, text, definition.GetType())
+		if issue != "" {
+			issues = append(issues, fmt.Sprintf("In '%s' %s", flag, issue))
+			continue
+		}
+		result[name] = value
+	}
+
+	// Check the encoding and size limit of template parameter files:
+	encoding, err := ParseFileEncoding(p.fileEncoding)
+	if err != nil {
+		issues = append(issues, err.Error())
+		return
+	}
+	maxSize, err := ParseMaxFileSize(p.fileMaxSize)
+	if err != nil {
+		issues = append(issues, err.Error())
+		return
+	}
+
+	// Parse the values given in files:
+	for _, flag := range files {
+		parts := strings.SplitN(flag, "=", 2)
+		if len(parts) != 2 {
+			name := strings.TrimSpace(flag)
+			definition := definitions[name]
+			if definition == nil {
+				issues = append(issues, fmt.Sprintf(
+					"In '%s' parameter '%s' doesn't exist, and if existed the file would be "+
+						"missing",
+					flag, name,
+				))
+			} else {
+				issues = append(
+					issues,
+					fmt.Sprintf(
+						"In '%s' file is missing",
+						flag,
+					))
+			}
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"In '%s' parameter name is missing",
+					flag,
+				),
+			)
+			continue
+		}
+		definition := definitions[name]
+		if definition == nil {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"In '%s' parameter '%s' doesn't exist",
+					flag, name,
+				),
+			)
+			continue
+		}
+		file := strings.TrimSpace(parts[1])
+		if file == "" {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"In '%s' file is missing",
+					flag,
+				),
+			)
+			continue
+		}
+		fileEncoding := FileEncodingNone
+		if definition.GetType() == typeBytes {
+			fileEncoding = encoding
+		}
+		data, err := ReadFile(file, fileEncoding, maxSize)
+		var tooLarge *FileTooLargeError
+		if errors.As(err, &tooLarge) {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"In '%s' %v",
+					flag, err,
+				),
+			)
+			continue
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			issues = append(
+				issues, fmt.Sprintf(
+					"In '%s' file '%s' doesn't exist",
+					flag, file,
+				),
+			)
+			continue
+		}
+		if err != nil {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"In '%s' failed to read file '%s': %v",
+					flag, file, err,
+				),
+			)
+			continue
+		}
+		text := string(data)
+		value, issue := p.convert(ctx, text, definition.GetType())
+		if issue != "" {
+			issues = append(
+				issues,
+				fmt.Sprintf("In '%s' %s", flag, issue),
+			)
+			continue
+		}
+		result[name] = value
+	}
+
+	// Apply the defaults to the parameters that still don't have a value:
+	if p.defaults {
+		issues = append(issues, p.applyDefaults(ctx, result)...)
+	}
+
+	// Add issues for missing required parameters, at the end of the list and sorted by parameter name:
+	var missing []Definition
+	for _, definition := range p.definitions {
+		if definition.GetRequired() && result[definition.GetName()] == nil {
+			missing = append(missing, definition)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].GetName() < missing[j].GetName()
+	})
+	for _, definition := range missing {
+		issues = append(
+			issues,
+			fmt.Sprintf("Parameter '%s' is required", definition.GetName()),
+		)
+	}
+
+	return
+}
+
+// applyDefaults sets the default values of the parameters that don't have a value in the given map. It returns the
+// issues found, for example a default whose type doesn't match the type of the parameter, sorted by parameter name.
+func (p *Parser) applyDefaults(ctx context.Context, values map[string]*anypb.Any) (issues []string) {
+	definitions := slices.Clone(p.definitions)
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].GetName() < definitions[j].GetName()
+	})
+	for _, definition := range definitions {
+		name := definition.GetName()
+		if values[name] != nil {
+			continue
+		}
+		detailed, ok := definition.(DetailedDefinition)
+		if !ok || detailed.GetDefault() == nil {
+			continue
+		}
+		value := detailed.GetDefault()
+		if value.GetTypeUrl() != definition.GetType() {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"Default value of parameter '%s' should be of type '%s', but it is of type '%s'",
+					name, TypeName(definition.GetType()), TypeName(value.GetTypeUrl()),
+				),
+			)
+			continue
+		}
+		_, err := value.UnmarshalNew()
+		if err != nil {
+			issues = append(
+				issues,
+				fmt.Sprintf("Default value of parameter '%s' isn't valid: %v", name, err),
+			)
+			continue
+		}
+		p.logger.DebugContext(
+			ctx,
+			"Applied default value",
+			slog.String("name", name),
+			slog.String("value", FormatValue(value)),
+		)
+		values[name] = value
+	}
+	return
+}
+
+// Valid returns the list of valid parameters, sorted by name.
+func (p *Parser) Valid() []ValidParameter {
+	// Prepare the results:
+	results := []ValidParameter{}
+	for _, parameter := range p.definitions {
+		result := ValidParameter{
+			Name:  parameter.GetName(),
+			Title: parameter.GetTitle(),
+			Type:  TypeName(parameter.GetType()),
+		}
+		if detailed, ok := parameter.(DetailedDefinition); ok {
+			result.Default = FormatValue(detailed.GetDefault())
+		}
+		results = append(results, result)
+	}
+
+	// Sort the result by name so that the output will be predictable:
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	return results
+}
+
+// convert converts a string value to the appropriate protobuf type based on the kind. It returns the value and a string
+// descibing the issue if the conversion fails.
+func (p *Parser) convert(ctx context.Context, text, kind string) (result *anypb.Any, issue string) {
+	var wrapper proto.Message
+	switch kind {
+	case typeString:
+		wrapper = &wrapperspb.StringValue{Value: text}
+	case typeBool:
+		text = strings.TrimSpace(text)
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			p.logger.DebugContext(
+				ctx,
+				"Failed to parse boolean",
+				slog.String("text", text),
+				slog.Any("error", err),
+			)
+			issue = fmt.Sprintf(
+				"value '%s' isn't a valid boolean, valid values are 'true' and 'false'",
+				text,
+			)
+			return
+		}
+		wrapper = &wrapperspb.BoolValue{Value: value}
+	case typeInt32:
+		text = strings.TrimSpace(text)
+		var value int64
+		value, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			p.logger.DebugContext(
+				ctx,
+				"Failed to parse 32-bit integer number",
+				slog.String("text", text),
+				slog.Any("error", err),
+			)
+			issue = fmt.Sprintf("value '%s' isn't a valid 32-bit integer", text)
+			return
+		}
+		wrapper = &wrapperspb.Int32Value{Value: int32(value)}
+	case typeInt64:
+		text = strings.TrimSpace(text)
+		var value int64
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			p.logger.DebugContext(
+				ctx,
+				"Failed to parse 64-bit integer number",
+				slog.String("text", text),
+				slog.Any("error", err),
+			)
+			issue = fmt.Sprintf("value '%s' isn't a valid 64-bit integer", text)
+			return
+		}
+		wrapper = &wrapperspb.Int64Value{Value: value}
+	case typeFloat:
+		text = strings.TrimSpace(text)
+		var value float64
+		value, err := strconv.ParseFloat(text, 32)
+		if err != nil {
+			p.logger.DebugContext(
+				ctx,
+				"Failed to parse 32-bit floating point number",
+				slog.String("text", text),
+				slog.Any("error", err),
+			)
+			issue = fmt.Sprintf("value '%s' isn't a valid 32-bit floating point number", text)
+			return
+		}
+		wrapper = &wrapperspb.FloatValue{Value: float32(value)}
+	case typeDouble:
+		text = strings.TrimSpace(text)
+		var value float64
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			p.logger.DebugContext(
+				ctx,
+				"Failed to parse 64-bit floating point number",
+				slog.String("text", text),
+				slog.Any("error", err),
+			)
+			issue = fmt.Sprintf("value '%s' isn't a valid 64-bit floating point number", text)
+			return
+		}
+		wrapper = &wrapperspb.DoubleValue{Value: value}
+	case typeBytes:
+		wrapper = &wrapperspb.BytesValue{Value: []byte(text)}
+	case typeTimestamp:
+		text = strings.TrimSpace(text)
+		var value time.Time
+		value, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			p.logger.DebugContext(
+				ctx,
+				"Failed to parse RFC3339 timestamp",
+				slog.String("text", text),
+				slog.Any("error", err),
+			)
+			issue = fmt.Sprintf("value '%s' isn't a valid RFC3339 timestamp", text)
+			return
+		}
+		wrapper = timestamppb.New(value)
+	case typeDuration:
+		var value time.Duration
+		value, err := time.ParseDuration(text)
+		if err != nil {
+			p.logger.DebugContext(
+				ctx,
+				"Failed to parse duration",
+				slog.String("text", text),
+				slog.Any("error", err),
+			)
+			issue = fmt.Sprintf("value '%s' isn't a valid duration", text)
+			return
+		}
+		wrapper = durationpb.New(value)
+	default:
+		issue = fmt.Sprintf("parameter is of an unsupported type '%s'", kind)
+		return
+	}
+	if issue != "" {
+		return
+	}
+	result, err := anypb.New(wrapper)
+	if err != nil {
+		p.logger.DebugContext(
+			ctx,
+			"Failed to create protobuf value for template parameter",
+			slog.String("text", text),
+			slog.String("kind", kind),
+			slog.Any("error", err),
+		)
+		issue = fmt.Sprintf("failed to create protobuf value for template parameter: %v", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,260 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package templateparams
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var _ = Describe("Parser", func() {
+	var (
+		ctx    context.Context
+		parser *Parser
+	)
+
+	BeforeEach(func() {
+		var err error
+		ctx = context.Background()
+		logger := slog.New(slog.NewTextHandler(GinkgoWriter, nil))
+		definitions := []*ffv1.ClusterTemplateParameterDefinition{
+			ffv1.ClusterTemplateParameterDefinition_builder{
+				Name:     "my_string",
+				Title:    "My string",
+				Type:     typeString,
+				Required: true,
+			}.Build(),
+			ffv1.ClusterTemplateParameterDefinition_builder{
+				Name: "my_bool",
+				Type: typeBool,
+			}.Build(),
+			ffv1.ClusterTemplateParameterDefinition_builder{
+				Name: "my_int",
+				Type: typeInt32,
+			}.Build(),
+			ffv1.ClusterTemplateParameterDefinition_builder{
+				Name: "my_duration",
+				Type: typeDuration,
+			}.Build(),
+		}
+		parser, err = NewParser().
+			SetLogger(logger).
+			AddDefinitions(Definitions(definitions)...).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	// unpack extracts the wrapped value from a parameter value.
+	unpack := func(value *anypb.Any) proto.Message {
+		message, err := value.UnmarshalNew()
+		Expect(err).ToNot(HaveOccurred())
+		return message
+	}
+
+	It("Fails if logger isn't set", func() {
+		_, err := NewParser().Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Converts values to the type of the definition", func() {
+		result, issues := parser.Parse(ctx, []string{
+			"my_string=hello",
+			"my_bool=true",
+			"my_int=42",
+			"my_duration=1m",
+		}, nil)
+		Expect(issues).To(BeEmpty())
+		Expect(result).To(HaveLen(4))
+		Expect(proto.Equal(unpack(result["my_string"]), wrapperspb.String("hello"))).To(BeTrue())
+		Expect(proto.Equal(unpack(result["my_bool"]), wrapperspb.Bool(true))).To(BeTrue())
+		Expect(proto.Equal(unpack(result["my_int"]), wrapperspb.Int32(42))).To(BeTrue())
+		Expect(proto.Equal(unpack(result["my_duration"]), durationpb.New(60e9))).To(BeTrue())
+	})
+
+	It("Reads values from files", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "value.txt")
+		err := os.WriteFile(file, []byte("from file"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		result, issues := parser.Parse(ctx, nil, []string{
+			"my_string=" + file,
+		})
+		Expect(issues).To(BeEmpty())
+		Expect(proto.Equal(unpack(result["my_string"]), wrapperspb.String("from file"))).To(BeTrue())
+	})
+
+	DescribeTable(
+		"Reports issues",
+		func(values []string, files []string, expected string) {
+			_, issues := parser.Parse(ctx, values, files)
+			Expect(issues).To(ContainElement(expected))
+		},
+		Entry(
+			"Missing value",
+			[]string{"my_string"},
+			nil,
+			"In 'my_string' parameter value is missing",
+		),
+		Entry(
+			"Unknown parameter",
+			[]string{"my_string=a", "junk=1"},
+			nil,
+			"In 'junk=1' parameter 'junk' doesn't exist",
+		),
+		Entry(
+			"Invalid boolean",
+			[]string{"my_string=a", "my_bool=maybe"},
+			nil,
+			"In 'my_bool=maybe' value 'maybe' isn't a valid boolean, valid values are 'true' and 'false'",
+		),
+		Entry(
+			"Invalid integer",
+			[]string{"my_string=a", "my_int=x"},
+			nil,
+			"In 'my_int=x' value 'x' isn't a valid 32-bit integer",
+		),
+		Entry(
+			"Missing required parameter",
+			nil,
+			nil,
+			"Parameter 'my_string' is required",
+		),
+		Entry(
+			"Missing file",
+			nil,
+			[]string{"my_string=/does/not/exist"},
+			"In 'my_string=/does/not/exist' file '/does/not/exist' doesn't exist",
+		),
+	)
+
+	It("Returns the valid parameters sorted by name", func() {
+		Expect(parser.Valid()).To(Equal([]ValidParameter{
+			{Name: "my_bool", Type: "boolean"},
+			{Name: "my_duration", Type: "duration"},
+			{Name: "my_int", Type: "int32"},
+			{Name: "my_string", Type: "string", Title: "My string"},
+		}))
+	})
+
+	It("Uses the initial values", func() {
+		initial, err := anypb.New(wrapperspb.String("old"))
+		Expect(err).ToNot(HaveOccurred())
+		logger := slog.New(slog.NewTextHandler(GinkgoWriter, nil))
+		parser, err := NewParser().
+			SetLogger(logger).
+			AddDefinitions(Definitions([]*ffv1.ClusterTemplateParameterDefinition{
+				ffv1.ClusterTemplateParameterDefinition_builder{
+					Name:     "my_string",
+					Type:     typeString,
+					Required: true,
+				}.Build(),
+				ffv1.ClusterTemplateParameterDefinition_builder{
+					Name: "my_bool",
+					Type: typeBool,
+				}.Build(),
+			})...).
+			SetInitialValues(map[string]*anypb.Any{
+				"my_string": initial,
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// The initial value satisfies the required parameter:
+		values, issues := parser.Parse(ctx, []string{"my_bool=true"}, nil)
+		Expect(issues).To(BeEmpty())
+		Expect(proto.Equal(unpack(values["my_string"]), wrapperspb.String("old"))).To(BeTrue())
+
+		// Values given in the command line replace it:
+		values, issues = parser.Parse(ctx, []string{"my_string=new"}, nil)
+		Expect(issues).To(BeEmpty())
+		Expect(proto.Equal(unpack(values["my_string"]), wrapperspb.String("new"))).To(BeTrue())
+	})
+
+	Describe("Defaults", func() {
+		// newParser creates a parser for a required string parameter and an optional integer parameter, both
+		// with the given defaults.
+		newParser := func(defaults bool, stringDefault, intDefault proto.Message) *Parser {
+			stringValue, err := anypb.New(stringDefault)
+			Expect(err).ToNot(HaveOccurred())
+			intValue, err := anypb.New(intDefault)
+			Expect(err).ToNot(HaveOccurred())
+			logger := slog.New(slog.NewTextHandler(GinkgoWriter, nil))
+			parser, err := NewParser().
+				SetLogger(logger).
+				AddDefinitions(Definitions([]*ffv1.ComputeInstanceTemplateParameterDefinition{
+					ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+						Name:     "my_string",
+						Type:     typeString,
+						Required: true,
+						Default:  stringValue,
+					}.Build(),
+					ffv1.ComputeInstanceTemplateParameterDefinition_builder{
+						Name:    "my_int",
+						Type:    typeInt32,
+						Default: intValue,
+					}.Build(),
+				})...).
+				SetDefaults(defaults).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			return parser
+		}
+
+		It("Applies the defaults to the parameters without value", func() {
+			parser := newParser(true, wrapperspb.String("default"), wrapperspb.Int32(2))
+			values, issues := parser.Parse(ctx, []string{"my_int=3"}, nil)
+			Expect(issues).To(BeEmpty())
+			Expect(proto.Equal(unpack(values["my_string"]), wrapperspb.String("default"))).To(BeTrue())
+			Expect(proto.Equal(unpack(values["my_int"]), wrapperspb.Int32(3))).To(BeTrue())
+		})
+
+		It("Doesn't apply the defaults unless enabled", func() {
+			parser := newParser(false, wrapperspb.String("default"), wrapperspb.Int32(2))
+			values, issues := parser.Parse(ctx, nil, nil)
+			Expect(issues).To(Equal([]string{
+				"Parameter 'my_string' is required",
+			}))
+			Expect(values).To(BeEmpty())
+		})
+
+		It("Reports defaults with the wrong type together with the rest of the issues", func() {
+			parser := newParser(true, wrapperspb.Int32(1), wrapperspb.Int32(2))
+			_, issues := parser.Parse(ctx, []string{"my_int=junk", "junk=1"}, nil)
+			Expect(issues).To(Equal([]string{
+				"In 'my_int=junk' value 'junk' isn't a valid 32-bit integer",
+				"In 'junk=1' parameter 'junk' doesn't exist",
+				"Default value of parameter 'my_string' should be of type 'string', but it is of type 'int32'",
+				"Parameter 'my_string' is required",
+			}))
+		})
+
+		It("Includes the defaults in the valid parameters", func() {
+			parser := newParser(true, wrapperspb.String("default"), wrapperspb.Int32(2))
+			Expect(parser.Valid()).To(Equal([]ValidParameter{
+				{Name: "my_int", Type: "int32", Default: "2"},
+				{Name: "my_string", Type: "string", Default: `"default"`},
+			}))
+		})
+	})
+})
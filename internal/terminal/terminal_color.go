@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/pflag"
+)
+
+// colorFlagName is the name of the flag that controls the use of color.
+const colorFlagName = "color"
+
+// noColorEnv is the name of the environment variable that, when set to any non empty value, disables color unless it
+// is explicitly requested with the flag. See https://no-color.org for details.
+const noColorEnv = "NO_COLOR"
+
+// Values of the flag that controls the use of color:
+const (
+	// ColorAuto uses color only when the output goes to a terminal and the NO_COLOR environment variable isn't set.
+	ColorAuto = "auto"
+
+	// ColorAlways uses color even if the output doesn't go to a terminal.
+	ColorAlways = "always"
+
+	// ColorNever never uses color.
+	ColorNever = "never"
+)
+
+// AddFlags adds to the given flag set the flag that controls the use of color. This is intended for the persistent
+// flags of the root command.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.String(
+		colorFlagName,
+		ColorAuto,
+		fmt.Sprintf(
+			"When to use color, one of '%s', '%s' or '%s'. In '%s' mode color is used only when the output is "+
+				"a terminal and the '%s' environment variable isn't set.",
+			ColorAuto, ColorAlways, ColorNever, ColorAuto, noColorEnv,
+		),
+	)
+}
+
+// ColorFromFlags returns the color mode selected in the given flag set. When the flag isn't explicitly used and the
+// NO_COLOR environment variable is set the result is ColorNever.
+func ColorFromFlags(flags *pflag.FlagSet) (result string, err error) {
+	result = ColorAuto
+	flag := flags.Lookup(colorFlagName)
+	if flag != nil {
+		result = flag.Value.String()
+	}
+	switch result {
+	case ColorAuto:
+		if os.Getenv(noColorEnv) != "" {
+			result = ColorNever
+		}
+	case ColorAlways, ColorNever:
+	default:
+		err = fmt.Errorf(
+			"unknown color mode '%s', should be '%s', '%s' or '%s'",
+			result, ColorAuto, ColorAlways, ColorNever,
+		)
+	}
+	return
+}
+
+// useColor checks if color should be used when writing to the given writer, according to the color mode.
+func useColor(mode string, writer io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		file, ok := writer.(*os.File)
+		return ok && isatty.IsTerminal(file.Fd())
+	}
+}
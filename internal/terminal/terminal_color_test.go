@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Color", func() {
+	var flags *pflag.FlagSet
+
+	BeforeEach(func() {
+		GinkgoT().Setenv(noColorEnv, "")
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddFlags(flags)
+	})
+
+	It("Uses automatic mode by default", func() {
+		mode, err := ColorFromFlags(flags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mode).To(Equal(ColorAuto))
+	})
+
+	It("Disables color in automatic mode if NO_COLOR is set", func() {
+		GinkgoT().Setenv(noColorEnv, "1")
+		mode, err := ColorFromFlags(flags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mode).To(Equal(ColorNever))
+	})
+
+	It("Honors the flag even if NO_COLOR is set", func() {
+		GinkgoT().Setenv(noColorEnv, "1")
+		err := flags.Parse([]string{"--color", "always"})
+		Expect(err).ToNot(HaveOccurred())
+		mode, err := ColorFromFlags(flags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mode).To(Equal(ColorAlways))
+	})
+
+	It("Rejects unknown modes", func() {
+		err := flags.Parse([]string{"--color", "junk"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ColorFromFlags(flags)
+		Expect(err).To(MatchError(ContainSubstring("unknown color mode 'junk'")))
+	})
+
+	It("Highlights states when color is always used", func() {
+		console, err := NewConsole().
+			SetLogger(logger).
+			SetWriter(&bytes.Buffer{}).
+			SetColor(ColorAlways).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(console.State("READY")).To(Equal("\x1b[32mREADY\x1b[0m"))
+		Expect(console.Theme()).ToNot(BeNil())
+	})
+
+	It("Doesn't highlight states when the output isn't a terminal", func() {
+		console, err := NewConsole().
+			SetLogger(logger).
+			SetWriter(&bytes.Buffer{}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(console.State("READY")).To(Equal("READY"))
+		Expect(console.Theme()).To(BeNil())
+	})
+
+	It("Doesn't highlight JSON when color is never used", func() {
+		buffer := &bytes.Buffer{}
+		console, err := NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			SetColor(ColorNever).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		console.RenderJson(ctx, map[string]any{
+			"id": "123",
+		})
+		Expect(buffer.String()).ToNot(ContainSubstring("\x1b["))
+	})
+
+	It("Can't be created with an unknown color mode", func() {
+		_, err := NewConsole().
+			SetLogger(logger).
+			SetColor("junk").
+			Build()
+		Expect(err).To(MatchError(ContainSubstring("color mode should be")))
+	})
+})
@@ -24,12 +24,15 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/alecthomas/chroma/v2/formatters"
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/mattn/go-colorable"
 	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
 
 	"github.com/osac-project/fulfillment-cli/internal/reflection"
@@ -40,18 +43,37 @@ import (
 // ConsoleBuilder contains the data and logic needed to create a console. Don't create objects of this type directly,
 // use the NewConsole function instead.
 type ConsoleBuilder struct {
-	logger *slog.Logger
-	writer io.Writer
-	helper *reflection.Helper
+	logger   *slog.Logger
+	writer   io.Writer
+	messages io.Writer
+	helper   *reflection.Helper
+	color    string
+	theme    *rendering.Theme
+	units    string
+	timeZone string
 }
 
 // Console is helps writing messages to the console. Don't create objects of this type directly, use the NewConsole
 // function instead.
 type Console struct {
-	logger *slog.Logger
-	writer io.Writer
-	engine *templating.Engine
-	helper *reflection.Helper
+	logger   *slog.Logger
+	writer   io.Writer
+	messages io.Writer
+	engine   *templating.Engine
+	helper   *reflection.Helper
+	color    string
+	theme    *rendering.Theme
+	units    string
+	timeZone string
+	location *time.Location
+
+	// interactive indicates if the messages are written to a terminal, and therefore progress of steps can be
+	// reported with a spinner.
+	interactive bool
+
+	// lock protects the messages writer from concurrent writes by the spinner of the current step.
+	lock sync.Mutex
+	step *Step
 }
 
 // NewConsole creates a builder that can the be used to create a template engine.
@@ -72,6 +94,15 @@ func (b *ConsoleBuilder) SetWriter(value io.Writer) *ConsoleBuilder {
 	return b
 }
 
+// SetMessageWriter sets the writer that the console will use for the text written with the Printf and Render methods.
+// This is optional, the default is to use the same writer than for the rest of the output. It is intended for the
+// machine readable output mode, where those messages are written to the standard error so that the standard output
+// contains only the JSON or YAML results.
+func (b *ConsoleBuilder) SetMessageWriter(value io.Writer) *ConsoleBuilder {
+	b.messages = value
+	return b
+}
+
 // SetHelper sets the reflection helper that will be used to introspect objects. This is optional. If not set then
 // functions like 'table' that need reflection will not be available.
 func (b *ConsoleBuilder) SetHelper(value *reflection.Helper) *ConsoleBuilder {
@@ -79,6 +110,34 @@ func (b *ConsoleBuilder) SetHelper(value *reflection.Helper) *ConsoleBuilder {
 	return b
 }
 
+// SetColor sets the color mode, one of ColorAuto, ColorAlways or ColorNever. This is optional, the default is
+// ColorAuto.
+func (b *ConsoleBuilder) SetColor(value string) *ConsoleBuilder {
+	b.color = value
+	return b
+}
+
+// SetTheme sets the theme used to highlight the output when color is enabled. This is optional, the default is the
+// theme named 'default'.
+func (b *ConsoleBuilder) SetTheme(value *rendering.Theme) *ConsoleBuilder {
+	b.theme = value
+	return b
+}
+
+// SetUnits sets the system of units used to display sizes, one of rendering.UnitsIEC, rendering.UnitsDecimal or
+// rendering.UnitsRaw. This is optional, the default is rendering.UnitsIEC.
+func (b *ConsoleBuilder) SetUnits(value string) *ConsoleBuilder {
+	b.units = value
+	return b
+}
+
+// SetTimeZone sets the name of the time zone used to display timestamps, like rendering.TimeZoneUTC or
+// 'Europe/Madrid'. This is optional, the default is rendering.TimeZoneLocal.
+func (b *ConsoleBuilder) SetTimeZone(value string) *ConsoleBuilder {
+	b.timeZone = value
+	return b
+}
+
 // Build uses the configuration stored in the builder to create a new console.
 func (b *ConsoleBuilder) Build() (result *Console, err error) {
 	// Check parameters:
@@ -86,25 +145,72 @@ func (b *ConsoleBuilder) Build() (result *Console, err error) {
 		err = errors.New("logger is mandatory")
 		return
 	}
+	color := b.color
+	switch color {
+	case "":
+		color = ColorAuto
+	case ColorAuto, ColorAlways, ColorNever:
+	default:
+		err = fmt.Errorf(
+			"color mode should be '%s', '%s' or '%s', but it is '%s'",
+			ColorAuto, ColorAlways, ColorNever, color,
+		)
+		return
+	}
+	theme := b.theme
+	if theme == nil {
+		theme = rendering.LookupTheme(rendering.DefaultThemeName)
+	}
+	units := b.units
+	if units == "" {
+		units = rendering.DefaultUnits
+	}
+	err = rendering.CheckUnits(units)
+	if err != nil {
+		return
+	}
+	timeZone := b.timeZone
+	if timeZone == "" {
+		timeZone = rendering.DefaultTimeZone
+	}
+	location, err := rendering.LoadTimeZone(timeZone)
+	if err != nil {
+		return
+	}
 
 	// Set the default writer if needed:
 	writer := b.writer
 	if writer == nil {
 		writer = os.Stdout
 	}
+	messages := b.messages
+	if messages == nil {
+		messages = writer
+	}
 
 	// Create the console object first so we can reference its methods when building the template engine:
 	console := &Console{
-		logger: b.logger,
-		writer: writer,
-		helper: b.helper,
+		logger:   b.logger,
+		writer:   writer,
+		messages: messages,
+		helper:   b.helper,
+		color:    color,
+		theme:    theme,
+		units:    units,
+		timeZone: timeZone,
+		location: location,
 	}
+	file, ok := messages.(*os.File)
+	console.interactive = ok && isatty.IsTerminal(file.Fd())
 
 	// Create the template engine:
 	console.engine, err = templating.NewEngine().
 		SetLogger(b.logger).
 		AddFunction("binary", console.binaryFunc).
 		AddFunction("table", console.tableFunc).
+		AddFunction("state", console.State).
+		AddFunction("size", console.Size).
+		AddFunction("time", console.Time).
 		Build()
 	if err != nil {
 		err = fmt.Errorf("failed to create templating engine: %w", err)
@@ -132,6 +238,49 @@ func (c *Console) SetHelper(value *reflection.Helper) {
 	c.helper = value
 }
 
+// Theme returns the theme that should be used to highlight the results written with the Write method, or nil if color
+// shouldn't be used for them.
+func (c *Console) Theme() *rendering.Theme {
+	if !useColor(c.color, c.writer) {
+		return nil
+	}
+	return c.theme
+}
+
+// State returns the given state highlighted according to the theme, if color should be used for messages, or
+// unchanged otherwise. It is also available in templates as the 'state' function.
+func (c *Console) State(text string) string {
+	return c.MessagesTheme().State(text)
+}
+
+// Units returns the system of units that should be used to display sizes.
+func (c *Console) Units() string {
+	return c.units
+}
+
+// Size returns the text that represents the given size, measured in the given unit, in the system of units of the
+// console. For example, for 8 'GiB' the result is '8 GiB', or '8.6 GB' if decimal units were selected. It is also
+// available in templates as the 'size' function.
+func (c *Console) Size(value int64, unit string) string {
+	return rendering.FormatSize(value, unit, c.units)
+}
+
+// TimeZone returns the name of the time zone that should be used to display timestamps.
+func (c *Console) TimeZone() string {
+	return c.timeZone
+}
+
+// Location returns the location of the time zone that should be used to display timestamps.
+func (c *Console) Location() *time.Location {
+	return c.location
+}
+
+// Time returns the text that represents the given timestamp in the time zone of the console, for example
+// '2025-11-04 10:58:02 CET'. It is also available in templates as the 'time' function.
+func (c *Console) Time(value time.Time) string {
+	return rendering.FormatTime(value, c.location)
+}
+
 func (c *Console) Printf(ctx context.Context, format string, args ...any) {
 	text := fmt.Sprintf(format, args...)
 	c.logger.DebugContext(
@@ -141,7 +290,10 @@ func (c *Console) Printf(ctx context.Context, format string, args ...any) {
 		slog.Any("args", args),
 		slog.Any("text", text),
 	)
-	_, err := c.writer.Write([]byte(text))
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.clearStep()
+	_, err := c.messages.Write([]byte(text))
 	if err != nil {
 		c.logger.ErrorContext(
 			ctx,
@@ -155,8 +307,7 @@ func (c *Console) Printf(ctx context.Context, format string, args ...any) {
 // Render renders the given template with the given data to stdout. The template should be a template file name that
 // was added via AddTemplatesFS. If no template file systems have been added, this method will log an error.
 func (c *Console) Render(ctx context.Context, template string, data any) {
-	var buffer bytes.Buffer
-	err := c.engine.Execute(&buffer, template, data)
+	text, err := c.Execute(template, data)
 	if err != nil {
 		c.logger.ErrorContext(
 			ctx,
@@ -166,14 +317,16 @@ func (c *Console) Render(ctx context.Context, template string, data any) {
 		)
 		return
 	}
-	text := buffer.String()
 	lines := strings.Split(text, "\n")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.clearStep()
 	previousEmpty := true
 	for _, line := range lines {
 		currentEmpty := len(line) == 0
 		if currentEmpty {
 			if !previousEmpty {
-				_, err := fmt.Fprintf(c.writer, "\n")
+				_, err := fmt.Fprintf(c.messages, "\n")
 				if err != nil {
 					c.logger.ErrorContext(
 						ctx,
@@ -184,7 +337,7 @@ func (c *Console) Render(ctx context.Context, template string, data any) {
 				previousEmpty = true
 			}
 		} else {
-			_, err := fmt.Fprintf(c.writer, "%s\n", line)
+			_, err := fmt.Fprintf(c.messages, "%s\n", line)
 			if err != nil {
 				c.logger.ErrorContext(
 					ctx,
@@ -198,6 +351,23 @@ func (c *Console) Render(ctx context.Context, template string, data any) {
 	}
 }
 
+// Execute executes the given template with the given data and returns the resulting text, without writing it. This
+// is intended for tests that check that the templates work with the data that the commands pass to them.
+func (c *Console) Execute(template string, data any) (result string, err error) {
+	var buffer bytes.Buffer
+	err = c.engine.Execute(&buffer, template, data)
+	if err != nil {
+		return
+	}
+	result = buffer.String()
+	return
+}
+
+// Templates returns the names of the templates that have been added to the console.
+func (c *Console) Templates() []string {
+	return c.engine.Names()
+}
+
 // RenderJson renders the given data as JSON to stdout. If the terminal supports color, the output will be colorized
 // using the chroma syntax highlighter.
 func (c *Console) RenderJson(ctx context.Context, data any) {
@@ -233,21 +403,24 @@ func (c *Console) RenderYaml(ctx context.Context, data any) {
 	c.renderColored(ctx, buffer.String(), "yaml")
 }
 
+// RenderDiff renders the given text, which should use the syntax of unified diffs, to stdout. If the terminal supports
+// color, the output will be colorized.
+func (c *Console) RenderDiff(ctx context.Context, text string) {
+	c.renderColored(ctx, text, "diff")
+}
+
 // renderColored renders the given text to stdout with syntax highlighting using the specified lexer. If the terminal
 // doesn't support color or an error occurs, it falls back to plain text output.
 func (c *Console) renderColored(ctx context.Context, text string, format string) error {
-	// If the writer isn't a file then we can't decide if it supports color, so we just print the text:
-	file, ok := c.writer.(*os.File)
-	if !ok {
+	// If the writer isn't a terminal, then we don't want to use color to not interfere with other tools that may
+	// want to process the output, unless the user explicitly asked for it:
+	if !useColor(c.color, c.writer) {
 		_, err := c.writer.Write([]byte(text))
 		return err
 	}
-
-	// If the file isn't a terminal, then we don't want to use color to not interfere with other tools
-	// thayt may want to process the output.
-	if !isatty.IsTerminal(file.Fd()) {
-		_, err := file.Write([]byte(text))
-		return err
+	writer := c.writer
+	if file, ok := writer.(*os.File); ok {
+		writer = colorable.NewColorable(file)
 	}
 
 	// If we are here then we can use color:
@@ -255,7 +428,7 @@ func (c *Console) renderColored(ctx context.Context, text string, format string)
 	if lexer == nil {
 		lexer = lexers.Fallback
 	}
-	style := styles.Get(colorStyleName)
+	style := styles.Get(c.theme.Style)
 	if style == nil {
 		style = styles.Fallback
 	}
@@ -271,10 +444,34 @@ func (c *Console) renderColored(ctx context.Context, text string, format string)
 			slog.String("format", format),
 			slog.Any("error", err),
 		)
-		_, err := file.Write([]byte(text))
+		_, err := writer.Write([]byte(text))
 		return err
 	}
-	return formatter.Format(colorable.NewColorable(file), style, iterator)
+	return formatter.Format(writer, style, iterator)
+}
+
+// Width returns the width of the terminal, in characters. If the console isn't writing to a terminal, or the width
+// can't be determined, it returns zero.
+func (c *Console) Width() int {
+	file, ok := c.writer.(*os.File)
+	if !ok || !isatty.IsTerminal(file.Fd()) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(file.Fd()))
+	if err != nil {
+		c.logger.Debug(
+			"Failed to get terminal size",
+			slog.Any("error", err),
+		)
+		return 0
+	}
+	return width
+}
+
+// IsTerminal checks if the results written with the Write method go to a terminal.
+func (c *Console) IsTerminal() bool {
+	file, ok := c.writer.(*os.File)
+	return ok && isatty.IsTerminal(file.Fd())
 }
 
 // Write is an implementation of the io.Write interface that allows the console to be used as a writer if needed.
@@ -296,6 +493,9 @@ func (c *Console) tableFunc(objects any) (result string, err error) {
 		SetLogger(c.logger).
 		SetHelper(c.helper).
 		SetWriter(&buffer).
+		SetMaxWidth(c.Width()).
+		SetTheme(c.MessagesTheme()).
+		SetUnits(c.units).
 		Build()
 	if err != nil {
 		err = fmt.Errorf("failed to create table renderer: %w", err)
@@ -310,13 +510,19 @@ func (c *Console) tableFunc(objects any) (result string, err error) {
 	return
 }
 
+// MessagesTheme returns the theme that should be used for the text written with the Printf and Render methods, or nil
+// if color shouldn't be used for them.
+func (c *Console) MessagesTheme() *rendering.Theme {
+	if !useColor(c.color, c.messages) {
+		return nil
+	}
+	return c.theme
+}
+
 // binaryFunc is a template function that returns the name of the binary.
 func (c *Console) binaryFunc() string {
 	return os.Args[0]
 }
 
-// Details of the color style and formatter used by the console.
-const (
-	colorStyleName     = "friendly"
-	colorFormatterName = "terminal256"
-)
+// colorFormatterName is the name of the chroma formatter used by the console.
+const colorFormatterName = "terminal256"
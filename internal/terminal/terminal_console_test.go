@@ -14,6 +14,7 @@ language governing permissions and limitations under the License.
 package terminal
 
 import (
+	"bytes"
 	"os"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
@@ -39,6 +40,36 @@ var _ = Describe("Console", func() {
 		})
 	})
 
+	Describe("Message writer", func() {
+		It("Writes messages and results to different writers", func() {
+			results := &bytes.Buffer{}
+			messages := &bytes.Buffer{}
+			console, err := NewConsole().
+				SetLogger(logger).
+				SetWriter(results).
+				SetMessageWriter(messages).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			console.Printf(ctx, "Created cluster '%s'.\n", "123")
+			console.RenderJson(ctx, map[string]any{
+				"id": "123",
+			})
+			Expect(messages.String()).To(Equal("Created cluster '123'.\n"))
+			Expect(results.String()).To(MatchJSON(`{"id": "123"}`))
+		})
+
+		It("Uses the same writer for messages by default", func() {
+			buffer := &bytes.Buffer{}
+			console, err := NewConsole().
+				SetLogger(logger).
+				SetWriter(buffer).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			console.Printf(ctx, "Hello\n")
+			Expect(buffer.String()).To(Equal("Hello\n"))
+		})
+	})
+
 	Describe("Render YAML", func() {
 		It("Can render a simple map as YAML", func() {
 			// Ceate a temporary file to write the YAML to:
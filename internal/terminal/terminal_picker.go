@@ -0,0 +1,348 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+// ErrPickCancelled is returned by the Pick and PickObject methods when the user cancels the selection pressing the
+// escape key or Ctrl+C.
+var ErrPickCancelled = errors.New("selection cancelled")
+
+// CanPick checks if the console can ask the user to pick one item from a list, which requires the standard input and
+// the messages to be connected to a terminal. Commands should check this before calling the Pick or PickObject methods,
+// and fall back to the non interactive behaviour when it returns false.
+func (c *Console) CanPick() bool {
+	return c.interactive && isatty.IsTerminal(os.Stdin.Fd())
+}
+
+// PickObject asks the user to pick one of the given objects, displayed as the rows of a table like the one generated
+// by the 'get' command. It returns the index of the selected object. This requires the reflection helper.
+func (c *Console) PickObject(ctx context.Context, prompt string, objects []proto.Message) (result int, err error) {
+	if c.helper == nil {
+		err = fmt.Errorf("picking objects requires the reflection helper, but it isn't set")
+		return
+	}
+
+	// Render the table without colors, as the rows will be filtered and highlighted by the picker:
+	var buffer bytes.Buffer
+	renderer, err := rendering.NewTableRenderer().
+		SetLogger(c.logger).
+		SetHelper(c.helper).
+		SetWriter(&buffer).
+		SetMaxWidth(c.Width()).
+		SetUnits(c.units).
+		Build()
+	if err != nil {
+		err = fmt.Errorf("failed to create table renderer: %w", err)
+		return
+	}
+	err = renderer.Render(ctx, objects)
+	if err != nil {
+		err = fmt.Errorf("failed to render table: %w", err)
+		return
+	}
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+	if len(lines) != len(objects)+1 {
+		err = fmt.Errorf(
+			"expected the table to have %d rows, but it has %d",
+			len(objects)+1, len(lines),
+		)
+		return
+	}
+	result, err = c.Pick(ctx, prompt, lines[0], lines[1:])
+	return
+}
+
+// Pick asks the user to pick one of the given items, and returns its index. The user moves between the items with the
+// arrow keys and can type to filter them; the characters typed need to appear in the item in the same order, but not
+// necessarily together. The header is optional, and it is displayed above the items, for example the titles of the
+// columns of a table.
+func (c *Console) Pick(ctx context.Context, prompt string, header string, items []string) (result int, err error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		err = fmt.Errorf("failed to configure the terminal: %w", err)
+		return
+	}
+	defer func() {
+		restoreErr := term.Restore(fd, state)
+		if restoreErr != nil {
+			c.logger.ErrorContext(
+				ctx,
+				"Failed to restore terminal",
+				slog.Any("error", restoreErr),
+			)
+		}
+	}()
+	result, err = c.pick(ctx, os.Stdin, prompt, header, items)
+	return
+}
+
+// pick contains the logic of the Pick method, reading the keys from the given reader, which should be a terminal in
+// raw mode, or a buffer in unit tests.
+func (c *Console) pick(ctx context.Context, input io.Reader, prompt string, header string,
+	items []string) (result int, err error) {
+	p := &picker{
+		prompt: prompt,
+		header: header,
+		items:  items,
+	}
+	p.filter()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.clearStep()
+	reader := bufio.NewReader(input)
+	for {
+		c.write(ctx, p.draw())
+		var key pickerKey
+		key, err = readPickerKey(reader)
+		if errors.Is(err, io.EOF) {
+			err = ErrPickCancelled
+		}
+		if err != nil {
+			c.write(ctx, p.clear())
+			return
+		}
+		done, cancelled := p.handle(key)
+		if cancelled {
+			c.write(ctx, p.clear())
+			err = ErrPickCancelled
+			return
+		}
+		if done {
+			result = p.matches[p.cursor]
+			c.write(ctx, p.clear())
+			c.write(ctx, fmt.Sprintf("%s: %s\r\n", prompt, strings.TrimSpace(items[result])))
+			c.logger.DebugContext(
+				ctx,
+				"Picked item",
+				slog.String("prompt", prompt),
+				slog.Int("index", result),
+			)
+			return
+		}
+	}
+}
+
+// picker contains the state of an interactive selection.
+type picker struct {
+	prompt  string
+	header  string
+	items   []string
+	query   string
+	matches []int
+	cursor  int
+	offset  int
+	drawn   int
+}
+
+// handle updates the state of the picker according to the given key. It returns done if the user selected an item,
+// and cancelled if the user cancelled the selection.
+func (p *picker) handle(key pickerKey) (done, cancelled bool) {
+	switch key.code {
+	case pickerKeyCancel:
+		cancelled = true
+	case pickerKeyEnter:
+		done = len(p.matches) > 0
+	case pickerKeyUp:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case pickerKeyDown:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	case pickerKeyBackspace:
+		if p.query != "" {
+			runes := []rune(p.query)
+			p.query = string(runes[:len(runes)-1])
+			p.filter()
+		}
+	case pickerKeyClear:
+		p.query = ""
+		p.filter()
+	case pickerKeyRune:
+		p.query += string(key.char)
+		p.filter()
+	}
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+pickerMaxRows {
+		p.offset = p.cursor - pickerMaxRows + 1
+	}
+	return
+}
+
+// filter recalculates the items that match the query, and moves the cursor to the first of them. Items that contain
+// the query literally are placed before the items that only contain its characters in the same order, as they are
+// more likely to be what the user is looking for.
+func (p *picker) filter() {
+	p.matches = p.matches[:0]
+	var others []int
+	query := strings.ToLower(p.query)
+	for i, item := range p.items {
+		switch {
+		case strings.Contains(strings.ToLower(item), query):
+			p.matches = append(p.matches, i)
+		case fuzzyMatch(query, item):
+			others = append(others, i)
+		}
+	}
+	p.matches = append(p.matches, others...)
+	p.cursor = 0
+	p.offset = 0
+}
+
+// draw returns the text that draws the picker, replacing the previous version if it was already drawn. Lines are
+// separated with carriage return and line feed because the terminal is in raw mode.
+func (p *picker) draw() string {
+	var lines []string
+	lines = append(lines, fmt.Sprintf("%s (type to filter, arrows to move, enter to select): %s", p.prompt, p.query))
+	if p.header != "" {
+		lines = append(lines, "  "+p.header)
+	}
+	end := min(p.offset+pickerMaxRows, len(p.matches))
+	for i := p.offset; i < end; i++ {
+		mark := " "
+		if i == p.cursor {
+			mark = ">"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", mark, p.items[p.matches[i]]))
+	}
+	lines = append(lines, fmt.Sprintf("  %d/%d", len(p.matches), len(p.items)))
+	result := p.clear() + strings.Join(lines, "\r\n")
+	p.drawn = len(lines)
+	return result
+}
+
+// clear returns the text that erases the picker, if it was drawn.
+func (p *picker) clear() string {
+	if p.drawn == 0 {
+		return ""
+	}
+	result := "\r\033[J"
+	if p.drawn > 1 {
+		result = fmt.Sprintf("\r\033[%dA\033[J", p.drawn-1)
+	}
+	p.drawn = 0
+	return result
+}
+
+// fuzzyMatch checks if all the characters of the query appear in the text in the same order, ignoring case.
+func fuzzyMatch(query, text string) bool {
+	remaining := []rune(strings.ToLower(query))
+	for _, char := range strings.ToLower(text) {
+		if len(remaining) == 0 {
+			break
+		}
+		if char == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
+
+// pickerKey is a key pressed by the user while the picker is active.
+type pickerKey struct {
+	code pickerKeyCode
+	char rune
+}
+
+type pickerKeyCode int
+
+const (
+	pickerKeyNone pickerKeyCode = iota
+	pickerKeyRune
+	pickerKeyEnter
+	pickerKeyCancel
+	pickerKeyUp
+	pickerKeyDown
+	pickerKeyBackspace
+	pickerKeyClear
+)
+
+// readPickerKey reads one key from the terminal, translating the escape sequences of the arrow keys and the control
+// characters. Keys that the picker doesn't use are returned with the pickerKeyNone code.
+func readPickerKey(reader *bufio.Reader) (result pickerKey, err error) {
+	char, _, err := reader.ReadRune()
+	if err != nil {
+		return
+	}
+	switch char {
+	case '\r', '\n':
+		result.code = pickerKeyEnter
+	case 0x03, 0x04:
+		result.code = pickerKeyCancel
+	case 0x7f, 0x08:
+		result.code = pickerKeyBackspace
+	case 0x15:
+		result.code = pickerKeyClear
+	case 0x10:
+		result.code = pickerKeyUp
+	case 0x0e:
+		result.code = pickerKeyDown
+	case 0x1b:
+		// A lone escape cancels the selection, but it is also the start of the sequences sent by the arrow keys,
+		// which arrive all together:
+		if reader.Buffered() == 0 {
+			result.code = pickerKeyCancel
+			return
+		}
+		var next byte
+		next, err = reader.ReadByte()
+		if err != nil {
+			return
+		}
+		if next != '[' && next != 'O' {
+			return
+		}
+		next, err = reader.ReadByte()
+		if err != nil {
+			return
+		}
+		switch next {
+		case 'A':
+			result.code = pickerKeyUp
+		case 'B':
+			result.code = pickerKeyDown
+		}
+	default:
+		if unicode.IsPrint(char) {
+			result.code = pickerKeyRune
+			result.char = char
+		}
+	}
+	return
+}
+
+// pickerMaxRows is the maximum number of items displayed at the same time, the rest are reached scrolling.
+const pickerMaxRows = 10
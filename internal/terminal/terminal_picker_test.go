@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Picker", func() {
+	var (
+		buffer  *bytes.Buffer
+		console *Console
+		items   []string
+	)
+
+	BeforeEach(func() {
+		var err error
+		buffer = &bytes.Buffer{}
+		console, err = NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		items = []string{
+			"123  my-cluster  READY",
+			"456  my-cluster  PROGRESSING",
+			"789  my-cluster  FAILED",
+		}
+	})
+
+	It("Selects the first item when enter is pressed", func() {
+		index, err := console.pick(ctx, strings.NewReader("\r"), "Cluster", "ID  NAME  STATE", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(0))
+		Expect(buffer.String()).To(ContainSubstring("  ID  NAME  STATE\r\n> 123  my-cluster  READY\r\n"))
+		Expect(buffer.String()).To(HaveSuffix("Cluster: 123  my-cluster  READY\r\n"))
+	})
+
+	It("Moves with the arrow keys", func() {
+		index, err := console.pick(ctx, strings.NewReader("\033[B\033[B\033[A\r"), "Cluster", "", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(1))
+	})
+
+	It("Doesn't move beyond the last item", func() {
+		index, err := console.pick(ctx, strings.NewReader("\033[B\033[B\033[B\033[B\r"), "Cluster", "", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(2))
+	})
+
+	It("Filters the items with the typed text", func() {
+		index, err := console.pick(ctx, strings.NewReader("fld\r"), "Cluster", "", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(2))
+		Expect(buffer.String()).To(ContainSubstring("  1/3"))
+	})
+
+	It("Puts first the items that contain the typed text", func() {
+		items = []string{
+			"a-b-c  my-cluster",
+			"abc    my-cluster",
+		}
+		index, err := console.pick(ctx, strings.NewReader("abc\r"), "Cluster", "", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(1))
+		Expect(buffer.String()).To(ContainSubstring("  2/2"))
+	})
+
+	It("Removes typed text with backspace", func() {
+		index, err := console.pick(ctx, strings.NewReader("fldx\x7f\x7f\x7f\x7f\033[B\r"), "Cluster", "", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(1))
+	})
+
+	It("Ignores enter when nothing matches", func() {
+		index, err := console.pick(ctx, strings.NewReader("xyz\r\x15\r"), "Cluster", "", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(0))
+	})
+
+	DescribeTable(
+		"Cancels the selection",
+		func(input string) {
+			_, err := console.pick(ctx, strings.NewReader(input), "Cluster", "", items)
+			Expect(err).To(MatchError(ErrPickCancelled))
+		},
+		Entry("Escape", "\033"),
+		Entry("Ctrl+C", "\x03"),
+		Entry("End of input", "abc"),
+	)
+
+	It("Erases the picker when it finishes", func() {
+		_, err := console.pick(ctx, strings.NewReader("\x03"), "Cluster", "ID  NAME  STATE", items)
+		Expect(err).To(HaveOccurred())
+		Expect(buffer.String()).To(HaveSuffix("\r\033[5A\033[J"))
+	})
+
+	It("Scrolls when there are more items than fit", func() {
+		items = make([]string, 15)
+		for i := range items {
+			items[i] = strings.Repeat("x", i+1)
+		}
+		input := strings.Repeat("\033[B", 12) + "\r"
+		index, err := console.pick(ctx, strings.NewReader(input), "Item", "", items)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(12))
+	})
+})
+
+var _ = DescribeTable(
+	"Fuzzy match",
+	func(query, text string, expected bool) {
+		Expect(fuzzyMatch(query, text)).To(Equal(expected))
+	},
+	Entry("Empty query", "", "my-cluster", true),
+	Entry("Exact text", "my-cluster", "my-cluster", true),
+	Entry("Characters in order", "mcl", "my-cluster", true),
+	Entry("Ignores case", "MCL", "my-cluster", true),
+	Entry("Characters out of order", "lcm", "my-cluster", false),
+	Entry("Missing characters", "mz", "my-cluster", false),
+)
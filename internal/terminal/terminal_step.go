@@ -0,0 +1,150 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Step reports the progress of one of the steps of a long running operation. When the console writes to a terminal
+// it displays a spinner while the step is running, and replaces it with a line indicating if the step succeeded or
+// failed. Otherwise it only writes the progress to the log. Don't create objects of this type directly, use the
+// StartStep method of the console instead.
+type Step struct {
+	console *Console
+	ctx     context.Context
+	title   string
+	start   time.Time
+	once    sync.Once
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// StartStep starts a step with the given title, for example 'Fetching server metadata'. The caller must call the Done
+// or Fail methods when the step finishes.
+func (c *Console) StartStep(ctx context.Context, title string) *Step {
+	c.logger.DebugContext(
+		ctx,
+		"Started step",
+		slog.String("title", title),
+	)
+	step := &Step{
+		console: c,
+		ctx:     ctx,
+		title:   title,
+		start:   time.Now(),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if !c.interactive {
+		close(step.done)
+		return step
+	}
+	c.lock.Lock()
+	c.step = step
+	c.lock.Unlock()
+	go step.spin()
+	return step
+}
+
+// Done indicates that the step finished successfully.
+func (s *Step) Done() {
+	s.finish(true)
+}
+
+// Fail indicates that the step failed. The details of the error aren't written, that is the responsibility of the
+// caller.
+func (s *Step) Fail() {
+	s.finish(false)
+}
+
+func (s *Step) finish(success bool) {
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.console.logger.DebugContext(
+			s.ctx,
+			"Finished step",
+			slog.String("title", s.title),
+			slog.Bool("success", success),
+			slog.Duration("duration", time.Since(s.start)),
+		)
+		if !s.console.interactive {
+			return
+		}
+		mark := stepSuccessMark
+		if !success {
+			mark = stepFailureMark
+		}
+		s.console.lock.Lock()
+		defer s.console.lock.Unlock()
+		s.console.clearStep()
+		if s.console.step == s {
+			s.console.step = nil
+		}
+		s.console.write(s.ctx, fmt.Sprintf("%s %s\n", mark, s.title))
+	})
+}
+
+// spin draws the spinner till the step is stopped.
+func (s *Step) spin() {
+	defer close(s.done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for frame := 0; ; frame = (frame + 1) % len(spinnerFrames) {
+		s.console.lock.Lock()
+		if s.console.step == s {
+			s.console.write(s.ctx, fmt.Sprintf("\r%s %s", spinnerFrames[frame], s.title))
+		}
+		s.console.lock.Unlock()
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// clearStep erases the spinner of the current step, if any, so that other messages can be written. The spinner will
+// be drawn again, after those messages, in the next tick. This must be called with the lock acquired.
+func (c *Console) clearStep() {
+	if c.step != nil {
+		c.write(context.Background(), "\r\033[K")
+	}
+}
+
+// write writes the given text to the messages writer, logging errors. This must be called with the lock acquired.
+func (c *Console) write(ctx context.Context, text string) {
+	_, err := c.messages.Write([]byte(text))
+	if err != nil {
+		c.logger.ErrorContext(
+			ctx,
+			"Failed to write text",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// Details of the spinner and of the marks used to indicate the result of steps.
+const (
+	spinnerInterval = 100 * time.Millisecond
+	stepSuccessMark = "✓"
+	stepFailureMark = "✗"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Step", func() {
+	var (
+		buffer  *bytes.Buffer
+		console *Console
+	)
+
+	BeforeEach(func() {
+		var err error
+		buffer = &bytes.Buffer{}
+		console, err = NewConsole().
+			SetLogger(logger).
+			SetWriter(buffer).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Doesn't write anything when the console isn't a terminal", func() {
+		step := console.StartStep(ctx, "Fetching server metadata")
+		step.Done()
+		step = console.StartStep(ctx, "Checking server health")
+		step.Fail()
+		Expect(buffer.String()).To(BeEmpty())
+	})
+
+	It("Replaces the spinner with the success mark", func() {
+		console.interactive = true
+		step := console.StartStep(ctx, "Fetching server metadata")
+		step.Done()
+		text := buffer.String()
+		Expect(text).To(HavePrefix("\r" + spinnerFrames[0] + " Fetching server metadata"))
+		Expect(text).To(HaveSuffix("\r\033[K✓ Fetching server metadata\n"))
+	})
+
+	It("Replaces the spinner with the failure mark", func() {
+		console.interactive = true
+		step := console.StartStep(ctx, "Checking server health")
+		step.Fail()
+		Expect(buffer.String()).To(HaveSuffix("\r\033[K✗ Checking server health\n"))
+	})
+
+	It("Ignores calls after the step finished", func() {
+		console.interactive = true
+		step := console.StartStep(ctx, "Saving configuration")
+		step.Done()
+		step.Fail()
+		Expect(strings.Count(buffer.String(), "Saving configuration\n")).To(Equal(1))
+		Expect(buffer.String()).ToNot(ContainSubstring("✗"))
+	})
+
+	It("Erases the spinner before writing other messages", func() {
+		console.interactive = true
+		step := console.StartStep(ctx, "Obtaining token")
+		console.Printf(ctx, "Open the browser.\n")
+		step.Done()
+		Expect(buffer.String()).To(ContainSubstring("\r\033[KOpen the browser.\n"))
+		Expect(buffer.String()).To(HaveSuffix("✓ Obtaining token\n"))
+	})
+})
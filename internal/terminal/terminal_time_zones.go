@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+// timeZoneFlagName is the name of the flag that controls the time zone used to display timestamps.
+const timeZoneFlagName = "time-zone"
+
+// AddTimeZoneFlags adds to the given flag set the flag that controls the time zone used to display timestamps, in
+// tables, in the details of objects and in the events. This is intended for the persistent flags of the root command.
+func AddTimeZoneFlags(flags *pflag.FlagSet) {
+	flags.String(
+		timeZoneFlagName,
+		"",
+		fmt.Sprintf(
+			"Time zone used to display timestamps, '%s' for the time zone of this machine, '%s', or a name "+
+				"of the time zone database like 'Europe/Madrid'. The default is the 'time_zone' setting of "+
+				"the configuration file, or '%s' if it isn't set.",
+			rendering.TimeZoneLocal, rendering.TimeZoneUTC, rendering.DefaultTimeZone,
+		),
+	)
+}
+
+// TimeZoneFromFlags returns the name of the time zone selected in the given flag set. When the flag isn't used the
+// result is the given configured value.
+func TimeZoneFromFlags(flags *pflag.FlagSet, configured string) (result string, err error) {
+	result = configured
+	flag := flags.Lookup(timeZoneFlagName)
+	if flag != nil && flag.Value.String() != "" {
+		result = flag.Value.String()
+	}
+	if result == "" {
+		result = rendering.DefaultTimeZone
+	}
+	_, err = rendering.LoadTimeZone(result)
+	return
+}
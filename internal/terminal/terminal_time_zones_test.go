@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+var _ = Describe("Time zone", func() {
+	var flags *pflag.FlagSet
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddTimeZoneFlags(flags)
+	})
+
+	It("Uses the local time zone by default", func() {
+		timeZone, err := TimeZoneFromFlags(flags, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(timeZone).To(Equal(rendering.TimeZoneLocal))
+	})
+
+	It("Uses the configured time zone if the flag isn't used", func() {
+		timeZone, err := TimeZoneFromFlags(flags, "Europe/Madrid")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(timeZone).To(Equal("Europe/Madrid"))
+	})
+
+	It("Gives precedence to the flag over the configured time zone", func() {
+		err := flags.Parse([]string{"--time-zone", "utc"})
+		Expect(err).ToNot(HaveOccurred())
+		timeZone, err := TimeZoneFromFlags(flags, "Europe/Madrid")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(timeZone).To(Equal(rendering.TimeZoneUTC))
+	})
+
+	It("Rejects unknown time zones", func() {
+		err := flags.Parse([]string{"--time-zone", "junk"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = TimeZoneFromFlags(flags, "")
+		Expect(err).To(MatchError(ContainSubstring("unknown time zone 'junk'")))
+	})
+
+	It("Formats timestamps in the selected time zone", func() {
+		console, err := NewConsole().
+			SetLogger(logger).
+			SetWriter(&bytes.Buffer{}).
+			SetTimeZone("Europe/Madrid").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(console.TimeZone()).To(Equal("Europe/Madrid"))
+		Expect(console.Location().String()).To(Equal("Europe/Madrid"))
+		value := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		Expect(console.Time(value)).To(Equal("2025-06-01 14:00:00 CEST"))
+	})
+})
@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+// Names of the flags that control how sizes are displayed:
+const (
+	unitsFlagName    = "units"
+	rawUnitsFlagName = "raw-units"
+)
+
+// AddUnitsFlags adds to the given flag set the flags that control the system of units used to display sizes, like the
+// memory of compute instances. This is intended for the persistent flags of the root command.
+func AddUnitsFlags(flags *pflag.FlagSet) {
+	flags.String(
+		unitsFlagName,
+		"",
+		fmt.Sprintf(
+			"System of units used to display sizes, '%s' for binary units like 'GiB' or '%s' for decimal "+
+				"units like 'GB'. The default is the 'units' setting of the configuration file, or '%s' if "+
+				"it isn't set.",
+			rendering.UnitsIEC, rendering.UnitsDecimal, rendering.UnitsIEC,
+		),
+	)
+	flags.Bool(
+		rawUnitsFlagName,
+		false,
+		"Display sizes as the exact numbers returned by the server, without converting them or adding the unit. "+
+			"This is intended for scripts.",
+	)
+}
+
+// UnitsFromFlags returns the system of units selected in the given flag set. The '--raw-units' flag takes precedence
+// over '--units', and when neither is used the result is the given configured value.
+func UnitsFromFlags(flags *pflag.FlagSet, configured string) (result string, err error) {
+	result = configured
+	flag := flags.Lookup(rawUnitsFlagName)
+	if flag != nil && flag.Value.String() == "true" {
+		result = rendering.UnitsRaw
+		return
+	}
+	flag = flags.Lookup(unitsFlagName)
+	if flag != nil && flag.Value.String() != "" {
+		result = flag.Value.String()
+	}
+	if result == "" {
+		result = rendering.DefaultUnits
+	}
+	err = rendering.CheckUnits(result)
+	return
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/osac-project/fulfillment-cli/internal/rendering"
+)
+
+var _ = Describe("Units", func() {
+	var flags *pflag.FlagSet
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddUnitsFlags(flags)
+	})
+
+	It("Uses IEC units by default", func() {
+		units, err := UnitsFromFlags(flags, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(units).To(Equal(rendering.UnitsIEC))
+	})
+
+	It("Uses the configured units if the flags aren't used", func() {
+		units, err := UnitsFromFlags(flags, rendering.UnitsDecimal)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(units).To(Equal(rendering.UnitsDecimal))
+	})
+
+	It("Gives precedence to the flag over the configured units", func() {
+		err := flags.Parse([]string{"--units", "iec"})
+		Expect(err).ToNot(HaveOccurred())
+		units, err := UnitsFromFlags(flags, rendering.UnitsDecimal)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(units).To(Equal(rendering.UnitsIEC))
+	})
+
+	It("Gives precedence to raw units over the other flag", func() {
+		err := flags.Parse([]string{"--units", "decimal", "--raw-units"})
+		Expect(err).ToNot(HaveOccurred())
+		units, err := UnitsFromFlags(flags, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(units).To(Equal(rendering.UnitsRaw))
+	})
+
+	It("Rejects unknown units", func() {
+		err := flags.Parse([]string{"--units", "junk"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = UnitsFromFlags(flags, "")
+		Expect(err).To(MatchError(ContainSubstring("unknown units 'junk'")))
+	})
+
+	It("Formats sizes with the selected units", func() {
+		console, err := NewConsole().
+			SetLogger(logger).
+			SetWriter(&bytes.Buffer{}).
+			SetUnits(rendering.UnitsDecimal).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(console.Units()).To(Equal(rendering.UnitsDecimal))
+		Expect(console.Size(8, "GiB")).To(Equal("8.6 GB"))
+	})
+})
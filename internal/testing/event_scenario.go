@@ -14,28 +14,121 @@ language governing permissions and limitations under the License.
 package testing
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
 	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v3"
+
+	. "github.com/onsi/gomega"
 )
 
 // EventScenario represents a test scenario with a sequence of events
 type EventScenario struct {
 	Name        string
 	Description string
+	Auth        *ScenarioAuth
+	Faults      *ScenarioFaults
 	Events      []*ScenarioEvent
 }
 
+// ScenarioAuth contains the authentication settings that the server advertises in the metadata and enforces.
+type ScenarioAuth struct {
+	// TrustedTokenIssuers is the list of token issuers returned by the metadata service, the login command uses the
+	// first one.
+	TrustedTokenIssuers []string
+
+	// Required indicates if the server rejects requests that don't have a bearer token. The metadata and health
+	// services are always available without a token.
+	Required bool
+}
+
+// ScenarioFaults contains the faults that the server injects in the calls, to check how the CLI handles errors, slow
+// responses and interrupted streams.
+type ScenarioFaults struct {
+	// Seed is the seed of the random number generator used to decide if the faults that have a probability happen.
+	// The same seed produces the same sequence of decisions, so that the runs are reproducible.
+	Seed int64
+
+	// Rules are the faults. When several rules match a call all of them are applied, in order.
+	Rules []*ScenarioFault
+}
+
+// ScenarioFault describes a fault injected in the calls to the methods that match a pattern.
+type ScenarioFault struct {
+	// Method is the pattern of the full names of the methods, like '/fulfillment.v1.Clusters/List', using the
+	// syntax of path.Match, for example '/fulfillment.v1.Clusters/*' matches all the methods of the clusters service.
+	Method string
+
+	// Code is the gRPC status code returned by the call. Zero means that the call doesn't fail, which is useful when
+	// only latency should be added.
+	Code codes.Code
+
+	// Message is the message of the error.
+	Message string
+
+	// Probability is the probability, between zero and one, that the call fails. The default is one.
+	Probability float64
+
+	// Times is the maximum number of calls that fail. The default, zero, means that there is no limit.
+	Times int
+
+	// Latency is waited before processing the call.
+	Latency time.Duration
+
+	// DisconnectAfter, for streaming methods, is the number of messages sent to the client before the stream fails
+	// with the code. The default, zero, means that the stream fails before sending any message.
+	DisconnectAfter int
+}
+
 // ScenarioEvent represents a single event in a test scenario
 type ScenarioEvent struct {
 	ID           string
 	Type         eventsv1.EventType
 	DelaySeconds int
-	Cluster      *ClusterEventData
+
+	// Object of the event. Only one of these should be set. Note that the events API can only carry clusters and
+	// cluster templates, events for other types of objects are applied to the mock objects but not sent to the clients
+	// that watch events.
+	Cluster         *ClusterEventData
+	ClusterTemplate *ClusterTemplateEventData
+	Host            *HostEventData
+	HostPool        *HostPoolEventData
+	HostClass       *HostClassEventData
+
+	// Delay is waited before sending the event, in addition to DelaySeconds. It is intended for tests that need
+	// delays shorter than one second.
+	Delay time.Duration
+
+	// Failure, when set, makes the stream end with an error at this point instead of sending an event.
+	Failure *ScenarioFailure
+}
+
+// ScenarioFailure describes an error that interrupts the events stream, simulating for example a server that is
+// restarted.
+type ScenarioFailure struct {
+	// Code is the gRPC status code of the error.
+	Code codes.Code
+
+	// Message is the message of the error.
+	Message string
+
+	// Times is the number of streams that will fail at this point. Once exhausted the failure is ignored, so that
+	// the client can make progress after reconnecting. The default, zero, means that only one stream fails.
+	Times int
 }
 
 // ClusterEventData contains cluster-specific event data
@@ -53,94 +146,564 @@ type ConditionData struct {
 	Message string
 }
 
+// ClusterTemplateEventData contains cluster template specific event data
+type ClusterTemplateEventData struct {
+	ID          string
+	Name        string
+	Title       string
+	Description string
+}
+
+// HostEventData contains host specific event data
+type HostEventData struct {
+	ID         string
+	Name       string
+	State      ffv1.HostState
+	PowerState ffv1.HostPowerState
+	Conditions []*HostConditionData
+}
+
+// HostConditionData represents a condition of a host in the event
+type HostConditionData struct {
+	Type    ffv1.HostConditionType
+	Status  sharedv1.ConditionStatus
+	Message string
+}
+
+// HostPoolEventData contains host pool specific event data
+type HostPoolEventData struct {
+	ID         string
+	Name       string
+	State      ffv1.HostPoolState
+	Conditions []*HostPoolConditionData
+}
+
+// HostPoolConditionData represents a condition of a host pool in the event
+type HostPoolConditionData struct {
+	Type    ffv1.HostPoolConditionType
+	Status  sharedv1.ConditionStatus
+	Message string
+}
+
+// HostClassEventData contains host class specific event data
+type HostClassEventData struct {
+	ID          string
+	Name        string
+	Title       string
+	Description string
+}
+
 // YAML parsing structures - used only for loading from YAML files
 type scenarioFile struct {
 	Name        string       `yaml:"name"`
 	Description string       `yaml:"description"`
+	Auth        *authFile    `yaml:"auth,omitempty"`
+	Faults      *faultsFile  `yaml:"faults,omitempty"`
 	Events      []*eventFile `yaml:"events"`
 }
 
+type faultsFile struct {
+	Seed  int64        `yaml:"seed"`
+	Rules []*faultFile `yaml:"rules"`
+}
+
+type faultFile struct {
+	Method          scenarioValue `yaml:"method"`
+	Code            scenarioValue `yaml:"code"`
+	Message         string        `yaml:"message"`
+	Probability     scenarioValue `yaml:"probability"`
+	Times           int           `yaml:"times"`
+	Latency         scenarioValue `yaml:"latency"`
+	DisconnectAfter int           `yaml:"disconnectAfter"`
+}
+
+type authFile struct {
+	TrustedTokenIssuers []scenarioValue `yaml:"trustedTokenIssuers"`
+	Required            bool            `yaml:"required"`
+}
+
 type eventFile struct {
-	ID           string            `yaml:"id"`
-	Type         string            `yaml:"type"`
-	DelaySeconds int               `yaml:"delaySeconds"`
-	Cluster      *clusterEventFile `yaml:"cluster,omitempty"`
+	ID              string                    `yaml:"id"`
+	Type            scenarioValue             `yaml:"type"`
+	DelaySeconds    int                       `yaml:"delaySeconds"`
+	Delay           scenarioValue             `yaml:"delay"`
+	Cluster         *clusterEventFile         `yaml:"cluster,omitempty"`
+	ClusterTemplate *clusterTemplateEventFile `yaml:"clusterTemplate,omitempty"`
+	Host            *hostEventFile            `yaml:"host,omitempty"`
+	HostPool        *hostPoolEventFile        `yaml:"hostPool,omitempty"`
+	HostClass       *hostClassEventFile       `yaml:"hostClass,omitempty"`
+	Failure         *failureFile              `yaml:"failure,omitempty"`
+}
+
+type failureFile struct {
+	Code    scenarioValue `yaml:"code"`
+	Message string        `yaml:"message"`
+	Times   int           `yaml:"times"`
 }
 
 type clusterEventFile struct {
 	ID         string           `yaml:"id"`
 	Name       string           `yaml:"name"`
-	State      string           `yaml:"state"`
+	State      scenarioValue    `yaml:"state"`
 	Conditions []*conditionFile `yaml:"conditions,omitempty"`
 }
 
+type clusterTemplateEventFile struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
+type hostEventFile struct {
+	ID         string           `yaml:"id"`
+	Name       string           `yaml:"name"`
+	State      scenarioValue    `yaml:"state"`
+	PowerState scenarioValue    `yaml:"powerState"`
+	Conditions []*conditionFile `yaml:"conditions,omitempty"`
+}
+
+type hostPoolEventFile struct {
+	ID         string           `yaml:"id"`
+	Name       string           `yaml:"name"`
+	State      scenarioValue    `yaml:"state"`
+	Conditions []*conditionFile `yaml:"conditions,omitempty"`
+}
+
+type hostClassEventFile struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
 type conditionFile struct {
-	Type    string `yaml:"type"`
-	Status  string `yaml:"status"`
-	Message string `yaml:"message"`
+	Type    scenarioValue `yaml:"type"`
+	Status  scenarioValue `yaml:"status"`
+	Message string        `yaml:"message"`
+}
+
+// scenarioValue is a scalar value of a scenario file together with its location, so that validation errors can point
+// to the exact place of the problem.
+type scenarioValue struct {
+	Value  string
+	Line   int
+	Column int
 }
 
-// LoadScenarioFromFile loads an event scenario from a YAML file
+// UnmarshalYAML is the implementation of the yaml.Unmarshaler interface.
+func (v *scenarioValue) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: expected a scalar value", node.Line)
+	}
+	v.Value = node.Value
+	v.Line = node.Line
+	v.Column = node.Column
+	return nil
+}
+
+// ScenarioError describes a problem found in a scenario file, including the location.
+type ScenarioError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error is the implementation of the error interface.
+func (e *ScenarioError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// LoadScenarioFromFile loads an event scenario from a YAML file. It fails if the file contains fields or enum values
+// that don't exist, the returned error contains all the problems found.
 func LoadScenarioFromFile(filename string) (*EventScenario, error) {
+	scenario, errs := loadScenarioFile(filename)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return scenario, nil
+}
+
+// ValidateScenarioFile checks that the given scenario file contains only known fields and valid enum values, and
+// returns the list of problems found.
+func ValidateScenarioFile(filename string) []error {
+	_, errs := loadScenarioFile(filename)
+	return errs
+}
+
+// ExpectValidScenario is a test helper that checks that the given scenario file is valid, reporting all the problems
+// if it isn't.
+func ExpectValidScenario(filename string) {
+	errs := ValidateScenarioFile(filename)
+	ExpectWithOffset(1, errors.Join(errs...)).ToNot(HaveOccurred(), "scenario file '%s' is invalid", filename)
+}
+
+func loadScenarioFile(filename string) (result *EventScenario, errs []error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+		errs = append(errs, fmt.Errorf("failed to read scenario file: %w", err))
+		return
 	}
 
 	var file scenarioFile
-	if err := yaml.Unmarshal(data, &file); err != nil {
-		return nil, fmt.Errorf("failed to parse scenario YAML: %w", err)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&file)
+	if err != nil && !errors.Is(err, io.EOF) {
+		errs = append(errs, fmt.Errorf("failed to parse scenario file '%s': %w", filename, err))
+		return
 	}
 
-	return file.toEventScenario(), nil
+	result, errs = file.toEventScenario(filename)
+	return
 }
 
-// toEventScenario converts a scenarioFile to an EventScenario with proper proto enums
-func (sf *scenarioFile) toEventScenario() *EventScenario {
+// toEventScenario converts a scenarioFile to an EventScenario with proper proto enums. It returns the list of problems
+// found, for example enum values that don't exist.
+func (sf *scenarioFile) toEventScenario(filename string) (*EventScenario, []error) {
+	var errs []error
 	scenario := &EventScenario{
 		Name:        sf.Name,
 		Description: sf.Description,
 		Events:      make([]*ScenarioEvent, len(sf.Events)),
 	}
 
+	if sf.Auth != nil {
+		scenario.Auth = &ScenarioAuth{
+			Required: sf.Auth.Required,
+		}
+		for _, issuer := range sf.Auth.TrustedTokenIssuers {
+			parsed, err := url.Parse(issuer.Value)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				errs = append(errs, &ScenarioError{
+					File:    filename,
+					Line:    issuer.Line,
+					Column:  issuer.Column,
+					Message: fmt.Sprintf("invalid token issuer '%s', should be an 'http' or 'https' URL", issuer.Value),
+				})
+				continue
+			}
+			scenario.Auth.TrustedTokenIssuers = append(scenario.Auth.TrustedTokenIssuers, issuer.Value)
+		}
+	}
+
+	if sf.Faults != nil {
+		scenario.Faults = &ScenarioFaults{
+			Seed:  sf.Faults.Seed,
+			Rules: make([]*ScenarioFault, len(sf.Faults.Rules)),
+		}
+		for i, fileFault := range sf.Faults.Rules {
+			scenario.Faults.Rules[i] = fileFault.toScenarioFault(filename, i, &errs)
+		}
+	}
+
 	for i, fileEvent := range sf.Events {
+		if fileEvent.Type.Value == "" && fileEvent.Failure == nil {
+			errs = append(errs, &ScenarioError{
+				File:    filename,
+				Message: fmt.Sprintf("event %d doesn't have a type", i),
+			})
+		}
 		scenario.Events[i] = &ScenarioEvent{
-			ID:           fileEvent.ID,
-			Type:         eventsv1.EventType(eventsv1.EventType_value[fileEvent.Type]),
+			ID: fileEvent.ID,
+			Type: parseScenarioEnum[eventsv1.EventType](
+				filename, "type", fileEvent.Type, eventsv1.EventType_value, &errs,
+			),
 			DelaySeconds: fileEvent.DelaySeconds,
+			Delay:        parseScenarioDuration(filename, "delay", fileEvent.Delay, &errs),
+		}
+
+		if fileEvent.Failure != nil {
+			scenario.Events[i].Failure = &ScenarioFailure{
+				Code:    parseScenarioCode(filename, "code", fileEvent.Failure.Code, &errs),
+				Message: fileEvent.Failure.Message,
+				Times:   fileEvent.Failure.Times,
+			}
 		}
 
 		if fileEvent.Cluster != nil {
 			scenario.Events[i].Cluster = &ClusterEventData{
-				ID:    fileEvent.Cluster.ID,
-				Name:  fileEvent.Cluster.Name,
-				State: ffv1.ClusterState(ffv1.ClusterState_value[fileEvent.Cluster.State]),
+				ID:   fileEvent.Cluster.ID,
+				Name: fileEvent.Cluster.Name,
+				State: parseScenarioEnum[ffv1.ClusterState](
+					filename, "state", fileEvent.Cluster.State, ffv1.ClusterState_value, &errs,
+				),
 			}
 
 			if len(fileEvent.Cluster.Conditions) > 0 {
 				scenario.Events[i].Cluster.Conditions = make([]*ConditionData, len(fileEvent.Cluster.Conditions))
 				for j, fileCond := range fileEvent.Cluster.Conditions {
 					scenario.Events[i].Cluster.Conditions[j] = &ConditionData{
-						Type:    ffv1.ClusterConditionType(ffv1.ClusterConditionType_value[fileCond.Type]),
-						Status:  sharedv1.ConditionStatus(sharedv1.ConditionStatus_value[fileCond.Status]),
+						Type: parseScenarioEnum[ffv1.ClusterConditionType](
+							filename, "type", fileCond.Type, ffv1.ClusterConditionType_value, &errs,
+						),
+						Status: parseScenarioEnum[sharedv1.ConditionStatus](
+							filename, "status", fileCond.Status, sharedv1.ConditionStatus_value, &errs,
+						),
 						Message: fileCond.Message,
 					}
 				}
 			}
 		}
+
+		if fileEvent.ClusterTemplate != nil {
+			scenario.Events[i].ClusterTemplate = &ClusterTemplateEventData{
+				ID:          fileEvent.ClusterTemplate.ID,
+				Name:        fileEvent.ClusterTemplate.Name,
+				Title:       fileEvent.ClusterTemplate.Title,
+				Description: fileEvent.ClusterTemplate.Description,
+			}
+		}
+
+		if fileEvent.Host != nil {
+			scenario.Events[i].Host = &HostEventData{
+				ID:   fileEvent.Host.ID,
+				Name: fileEvent.Host.Name,
+				State: parseScenarioEnum[ffv1.HostState](
+					filename, "state", fileEvent.Host.State, ffv1.HostState_value, &errs,
+				),
+				PowerState: parseScenarioEnum[ffv1.HostPowerState](
+					filename, "powerState", fileEvent.Host.PowerState, ffv1.HostPowerState_value, &errs,
+				),
+			}
+			for _, fileCond := range fileEvent.Host.Conditions {
+				scenario.Events[i].Host.Conditions = append(scenario.Events[i].Host.Conditions, &HostConditionData{
+					Type: parseScenarioEnum[ffv1.HostConditionType](
+						filename, "type", fileCond.Type, ffv1.HostConditionType_value, &errs,
+					),
+					Status: parseScenarioEnum[sharedv1.ConditionStatus](
+						filename, "status", fileCond.Status, sharedv1.ConditionStatus_value, &errs,
+					),
+					Message: fileCond.Message,
+				})
+			}
+		}
+
+		if fileEvent.HostPool != nil {
+			scenario.Events[i].HostPool = &HostPoolEventData{
+				ID:   fileEvent.HostPool.ID,
+				Name: fileEvent.HostPool.Name,
+				State: parseScenarioEnum[ffv1.HostPoolState](
+					filename, "state", fileEvent.HostPool.State, ffv1.HostPoolState_value, &errs,
+				),
+			}
+			for _, fileCond := range fileEvent.HostPool.Conditions {
+				scenario.Events[i].HostPool.Conditions = append(
+					scenario.Events[i].HostPool.Conditions,
+					&HostPoolConditionData{
+						Type: parseScenarioEnum[ffv1.HostPoolConditionType](
+							filename, "type", fileCond.Type, ffv1.HostPoolConditionType_value, &errs,
+						),
+						Status: parseScenarioEnum[sharedv1.ConditionStatus](
+							filename, "status", fileCond.Status, sharedv1.ConditionStatus_value, &errs,
+						),
+						Message: fileCond.Message,
+					},
+				)
+			}
+		}
+
+		if fileEvent.HostClass != nil {
+			scenario.Events[i].HostClass = &HostClassEventData{
+				ID:          fileEvent.HostClass.ID,
+				Name:        fileEvent.HostClass.Name,
+				Title:       fileEvent.HostClass.Title,
+				Description: fileEvent.HostClass.Description,
+			}
+		}
+
+		// Check that the event has at most one object:
+		objects := 0
+		for _, present := range []bool{
+			fileEvent.Cluster != nil,
+			fileEvent.ClusterTemplate != nil,
+			fileEvent.Host != nil,
+			fileEvent.HostPool != nil,
+			fileEvent.HostClass != nil,
+		} {
+			if present {
+				objects++
+			}
+		}
+		if objects > 1 {
+			errs = append(errs, &ScenarioError{
+				File: filename,
+				Message: fmt.Sprintf(
+					"event %d has %d objects, but only one of 'cluster', 'clusterTemplate', 'host', "+
+						"'hostPool' or 'hostClass' can be used",
+					i, objects,
+				),
+			})
+		}
+	}
+
+	return scenario, errs
+}
+
+// toScenarioFault converts a fault rule of the scenario file, adding to the list of errors the problems found.
+func (ff *faultFile) toScenarioFault(filename string, index int, errs *[]error) *ScenarioFault {
+	fault := &ScenarioFault{
+		Method:          ff.Method.Value,
+		Message:         ff.Message,
+		Probability:     1,
+		Times:           ff.Times,
+		Latency:         parseScenarioDuration(filename, "latency", ff.Latency, errs),
+		DisconnectAfter: ff.DisconnectAfter,
+	}
+	if ff.Method.Value == "" {
+		*errs = append(*errs, &ScenarioError{
+			File:    filename,
+			Message: fmt.Sprintf("fault %d doesn't have a method", index),
+		})
+	} else if _, err := path.Match(ff.Method.Value, ""); err != nil || !strings.HasPrefix(ff.Method.Value, "/") {
+		*errs = append(*errs, &ScenarioError{
+			File:   filename,
+			Line:   ff.Method.Line,
+			Column: ff.Method.Column,
+			Message: fmt.Sprintf(
+				"invalid method '%s', should be a pattern like '/fulfillment.v1.Clusters/List' or "+
+					"'/fulfillment.v1.Clusters/*'",
+				ff.Method.Value,
+			),
+		})
+	}
+	if ff.Code.Value != "" {
+		fault.Code = parseScenarioCode(filename, "code", ff.Code, errs)
+	} else if fault.Latency == 0 {
+		*errs = append(*errs, &ScenarioError{
+			File:    filename,
+			Message: fmt.Sprintf("fault %d doesn't have a code or a latency", index),
+		})
+	}
+	if ff.Probability.Value != "" {
+		probability, err := strconv.ParseFloat(ff.Probability.Value, 64)
+		if err != nil || probability <= 0 || probability > 1 {
+			*errs = append(*errs, &ScenarioError{
+				File:   filename,
+				Line:   ff.Probability.Line,
+				Column: ff.Probability.Column,
+				Message: fmt.Sprintf(
+					"invalid value '%s' for field 'probability', should be a number greater than zero and "+
+						"less or equal than one",
+					ff.Probability.Value,
+				),
+			})
+		} else {
+			fault.Probability = probability
+		}
+	}
+	return fault
+}
+
+// parseScenarioEnum converts the text of an enum value to the enum type. Empty values are converted to zero. Values
+// that don't exist are added to the list of errors, together with the list of valid values.
+func parseScenarioEnum[T ~int32](filename, field string, value scenarioValue, values map[string]int32,
+	errs *[]error) T {
+	if value.Value == "" {
+		return 0
+	}
+	number, ok := values[value.Value]
+	if !ok {
+		names := make([]string, 0, len(values))
+		for name, number := range values {
+			if number != 0 {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		*errs = append(*errs, &ScenarioError{
+			File:   filename,
+			Line:   value.Line,
+			Column: value.Column,
+			Message: fmt.Sprintf(
+				"invalid value '%s' for field '%s', valid values are %s",
+				value.Value, field, strings.Join(names, ", "),
+			),
+		})
 	}
+	return T(number)
+}
 
-	return scenario
+// parseScenarioDuration converts the text of a duration, like '100ms', to a duration. Empty values are converted to zero.
+// Values that can't be parsed are added to the list of errors.
+func parseScenarioDuration(filename, field string, value scenarioValue, errs *[]error) time.Duration {
+	if value.Value == "" {
+		return 0
+	}
+	result, err := time.ParseDuration(value.Value)
+	if err != nil || result < 0 {
+		*errs = append(*errs, &ScenarioError{
+			File:   filename,
+			Line:   value.Line,
+			Column: value.Column,
+			Message: fmt.Sprintf(
+				"invalid value '%s' for field '%s', should be a duration like '100ms'",
+				value.Value, field,
+			),
+		})
+	}
+	return result
+}
+
+// parseScenarioCode converts the name of a gRPC status code, like 'Unavailable', to the code. Values that don't exist
+// are added to the list of errors, together with the list of valid values.
+func parseScenarioCode(filename, field string, value scenarioValue, errs *[]error) codes.Code {
+	var names []string
+	for code := codes.OK + 1; code <= codes.Unauthenticated; code++ {
+		if code.String() == value.Value {
+			return code
+		}
+		names = append(names, code.String())
+	}
+	*errs = append(*errs, &ScenarioError{
+		File:   filename,
+		Line:   value.Line,
+		Column: value.Column,
+		Message: fmt.Sprintf(
+			"invalid value '%s' for field '%s', valid values are %s",
+			value.Value, field, strings.Join(names, ", "),
+		),
+	})
+	return codes.Unknown
 }
 
-// ToProtoEvent converts a ScenarioEvent to a proto Event
+// ToProtoEvent converts a ScenarioEvent to a proto Event. The payload is empty when the object of the event can't be
+// carried by the events API, for example hosts.
 func (se *ScenarioEvent) ToProtoEvent() *eventsv1.Event {
+	return newProtoEvent(se.ID, se.Type, se.ToProtoObject())
+}
+
+// newProtoEvent creates an event of the given type for the given object. The payload is empty when the object can't
+// be carried by the events API.
+func newProtoEvent(id string, eventType eventsv1.EventType, object proto.Message) *eventsv1.Event {
 	event := &eventsv1.Event{
-		Id:   se.ID,
-		Type: se.Type,
+		Id:   id,
+		Type: eventType,
 	}
 
-	if se.Cluster != nil {
+	switch object := object.(type) {
+	case *ffv1.Cluster:
+		event.Payload = &eventsv1.Event_Cluster{
+			Cluster: object,
+		}
+	case *ffv1.ClusterTemplate:
+		event.Payload = &eventsv1.Event_ClusterTemplate{
+			ClusterTemplate: object,
+		}
+	}
+
+	return event
+}
+
+// ToProtoObject converts the object of the ScenarioEvent to the corresponding proto message. It returns nil if the
+// event doesn't have an object, for example when it is a failure.
+func (se *ScenarioEvent) ToProtoObject() proto.Message {
+	switch {
+	case se.Cluster != nil:
 		cluster := &ffv1.Cluster{
 			Id: se.Cluster.ID,
 			Metadata: &sharedv1.Metadata{
@@ -150,23 +713,73 @@ func (se *ScenarioEvent) ToProtoEvent() *eventsv1.Event {
 				State: se.Cluster.State,
 			},
 		}
-
-		if len(se.Cluster.Conditions) > 0 {
-			cluster.Status.Conditions = make([]*ffv1.ClusterCondition, len(se.Cluster.Conditions))
-			for i, cond := range se.Cluster.Conditions {
-				msg := cond.Message
-				cluster.Status.Conditions[i] = &ffv1.ClusterCondition{
-					Type:    cond.Type,
-					Status:  cond.Status,
-					Message: &msg,
-				}
-			}
+		for _, cond := range se.Cluster.Conditions {
+			msg := cond.Message
+			cluster.Status.Conditions = append(cluster.Status.Conditions, &ffv1.ClusterCondition{
+				Type:    cond.Type,
+				Status:  cond.Status,
+				Message: &msg,
+			})
 		}
-
-		event.Payload = &eventsv1.Event_Cluster{
-			Cluster: cluster,
+		return cluster
+	case se.ClusterTemplate != nil:
+		return &ffv1.ClusterTemplate{
+			Id: se.ClusterTemplate.ID,
+			Metadata: &sharedv1.Metadata{
+				Name: se.ClusterTemplate.Name,
+			},
+			Title:       se.ClusterTemplate.Title,
+			Description: se.ClusterTemplate.Description,
+		}
+	case se.Host != nil:
+		host := &ffv1.Host{
+			Id: se.Host.ID,
+			Metadata: &sharedv1.Metadata{
+				Name: se.Host.Name,
+			},
+			Status: &ffv1.HostStatus{
+				State:      se.Host.State,
+				PowerState: se.Host.PowerState,
+			},
+		}
+		for _, cond := range se.Host.Conditions {
+			msg := cond.Message
+			host.Status.Conditions = append(host.Status.Conditions, &ffv1.HostCondition{
+				Type:    cond.Type,
+				Status:  cond.Status,
+				Message: &msg,
+			})
 		}
+		return host
+	case se.HostPool != nil:
+		pool := &ffv1.HostPool{
+			Id: se.HostPool.ID,
+			Metadata: &sharedv1.Metadata{
+				Name: se.HostPool.Name,
+			},
+			Status: &ffv1.HostPoolStatus{
+				State: se.HostPool.State,
+			},
+		}
+		for _, cond := range se.HostPool.Conditions {
+			msg := cond.Message
+			pool.Status.Conditions = append(pool.Status.Conditions, &ffv1.HostPoolCondition{
+				Type:    cond.Type,
+				Status:  cond.Status,
+				Message: &msg,
+			})
+		}
+		return pool
+	case se.HostClass != nil:
+		return &ffv1.HostClass{
+			Id: se.HostClass.ID,
+			Metadata: &sharedv1.Metadata{
+				Name: se.HostClass.Name,
+			},
+			Title:       se.HostClass.Title,
+			Description: se.HostClass.Description,
+		}
+	default:
+		return nil
 	}
-
-	return event
 }
@@ -0,0 +1,291 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc/codes"
+)
+
+var _ = Describe("Event scenario", func() {
+	It("Accepts all the scenarios in the test data", func() {
+		files, err := filepath.Glob("testdata/*.yaml")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).ToNot(BeEmpty())
+		for _, file := range files {
+			ExpectValidScenario(file)
+		}
+	})
+
+	It("Loads enum values", func() {
+		dir, _ := TmpFS("scenario.yaml", `
+name: my-scenario
+events:
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  cluster:
+    id: my-cluster
+    state: CLUSTER_STATE_READY
+`)
+		scenario, err := LoadScenarioFromFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scenario.Events).To(HaveLen(1))
+		Expect(scenario.Events[0].Type).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED))
+		Expect(scenario.Events[0].Cluster.State).To(Equal(ffv1.ClusterState_CLUSTER_STATE_READY))
+	})
+
+	It("Reports invalid enum values with their location", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+events:
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  cluster:
+    id: my-cluster
+    state: CLUSTER_STATE_REDY
+    conditions:
+    - type: CLUSTER_CONDITION_TYPE_READY
+      status: MAYBE
+`)
+		file := filepath.Join(dir, "scenario.yaml")
+		errs := ValidateScenarioFile(file)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0]).To(MatchError(HavePrefix(file + ":7:12: invalid value 'CLUSTER_STATE_REDY' for field 'state'")))
+		Expect(errs[0]).To(MatchError(ContainSubstring("CLUSTER_STATE_READY")))
+		Expect(errs[1]).To(MatchError(HavePrefix(file + ":10:15: invalid value 'MAYBE' for field 'status'")))
+		_, err := LoadScenarioFromFile(file)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Loads authentication settings", func() {
+		dir, _ := TmpFS("scenario.yaml", `
+name: my-scenario
+auth:
+  trustedTokenIssuers:
+  - https://issuer.example.com
+  required: true
+`)
+		scenario, err := LoadScenarioFromFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scenario.Auth).ToNot(BeNil())
+		Expect(scenario.Auth.TrustedTokenIssuers).To(Equal([]string{"https://issuer.example.com"}))
+		Expect(scenario.Auth.Required).To(BeTrue())
+	})
+
+	It("Doesn't have authentication settings by default", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario`)
+		scenario, err := LoadScenarioFromFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scenario.Auth).To(BeNil())
+	})
+
+	It("Reports invalid token issuers with their location", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+auth:
+  trustedTokenIssuers:
+  - issuer.example.com
+`)
+		file := filepath.Join(dir, "scenario.yaml")
+		errs := ValidateScenarioFile(file)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(HavePrefix(file + ":4:5: invalid token issuer 'issuer.example.com'")))
+	})
+
+	It("Reports unknown fields", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+events:
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  pause: 5
+`)
+		errs := ValidateScenarioFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(ContainSubstring("line 5: field pause not found")))
+	})
+
+	It("Reports events without type", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+events:
+- id: event-1
+`)
+		errs := ValidateScenarioFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(ContainSubstring("event 0 doesn't have a type")))
+	})
+
+	It("Loads failures and delays", func() {
+		dir, _ := TmpFS("scenario.yaml", `
+name: my-scenario
+events:
+- failure:
+    code: Unavailable
+    message: server is restarting
+    times: 2
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  delay: 100ms
+`)
+		scenario, err := LoadScenarioFromFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scenario.Events).To(HaveLen(2))
+		Expect(scenario.Events[0].Failure).To(Equal(&ScenarioFailure{
+			Code:    codes.Unavailable,
+			Message: "server is restarting",
+			Times:   2,
+		}))
+		Expect(scenario.Events[1].Delay).To(Equal(100 * time.Millisecond))
+	})
+
+	It("Reports invalid failure codes and delays with their location", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+events:
+- failure:
+    code: Unavalable
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  delay: soon
+`)
+		file := filepath.Join(dir, "scenario.yaml")
+		errs := ValidateScenarioFile(file)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0]).To(MatchError(HavePrefix(file + ":4:11: invalid value 'Unavalable' for field 'code'")))
+		Expect(errs[0]).To(MatchError(ContainSubstring("Unavailable")))
+		Expect(errs[1]).To(MatchError(HavePrefix(file + ":7:10: invalid value 'soon' for field 'delay'")))
+	})
+
+	It("Loads objects of all types", func() {
+		dir, _ := TmpFS("scenario.yaml", `
+name: my-scenario
+events:
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  host:
+    id: my-host
+    state: HOST_STATE_READY
+    powerState: HOST_POWER_STATE_ON
+    conditions:
+    - type: HOST_CONDITION_TYPE_READY
+      status: CONDITION_STATUS_TRUE
+- id: event-2
+  type: EVENT_TYPE_OBJECT_CREATED
+  hostPool:
+    id: my-pool
+    state: HOST_POOL_STATE_FAILED
+    conditions:
+    - type: HOST_POOL_CONDITION_TYPE_FAILED
+      status: CONDITION_STATUS_TRUE
+      message: Not enough hosts
+- id: event-3
+  type: EVENT_TYPE_OBJECT_CREATED
+  hostClass:
+    id: my-class
+    title: My class
+- id: event-4
+  type: EVENT_TYPE_OBJECT_CREATED
+  clusterTemplate:
+    id: my-template
+    description: My template
+`)
+		scenario, err := LoadScenarioFromFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scenario.Events).To(HaveLen(4))
+		Expect(scenario.Events[0].Host).To(Equal(&HostEventData{
+			ID:         "my-host",
+			State:      ffv1.HostState_HOST_STATE_READY,
+			PowerState: ffv1.HostPowerState_HOST_POWER_STATE_ON,
+			Conditions: []*HostConditionData{{
+				Type:   ffv1.HostConditionType_HOST_CONDITION_TYPE_READY,
+				Status: sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+			}},
+		}))
+		Expect(scenario.Events[1].HostPool).To(Equal(&HostPoolEventData{
+			ID:    "my-pool",
+			State: ffv1.HostPoolState_HOST_POOL_STATE_FAILED,
+			Conditions: []*HostPoolConditionData{{
+				Type:    ffv1.HostPoolConditionType_HOST_POOL_CONDITION_TYPE_FAILED,
+				Status:  sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+				Message: "Not enough hosts",
+			}},
+		}))
+		Expect(scenario.Events[2].HostClass).To(Equal(&HostClassEventData{
+			ID:    "my-class",
+			Title: "My class",
+		}))
+		Expect(scenario.Events[3].ClusterTemplate).To(Equal(&ClusterTemplateEventData{
+			ID:          "my-template",
+			Description: "My template",
+		}))
+	})
+
+	It("Reports invalid host states with their location", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+events:
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  host:
+    id: my-host
+    state: CLUSTER_STATE_READY
+`)
+		file := filepath.Join(dir, "scenario.yaml")
+		errs := ValidateScenarioFile(file)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(HavePrefix(file + ":7:12: invalid value 'CLUSTER_STATE_READY' for field 'state'")))
+		Expect(errs[0]).To(MatchError(ContainSubstring("HOST_STATE_READY")))
+	})
+
+	It("Reports events with more than one object", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+events:
+- id: event-1
+  type: EVENT_TYPE_OBJECT_CREATED
+  cluster:
+    id: my-cluster
+  host:
+    id: my-host
+`)
+		errs := ValidateScenarioFile(filepath.Join(dir, "scenario.yaml"))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(ContainSubstring("event 0 has 2 objects")))
+	})
+
+	It("Converts cluster templates to events", func() {
+		event := (&ScenarioEvent{
+			ID:   "event-1",
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			ClusterTemplate: &ClusterTemplateEventData{
+				ID:   "my-template",
+				Name: "my-name",
+			},
+		}).ToProtoEvent()
+		Expect(event.GetClusterTemplate().GetId()).To(Equal("my-template"))
+		Expect(event.GetClusterTemplate().GetMetadata().GetName()).To(Equal("my-name"))
+	})
+
+	It("Doesn't add payload to events for hosts", func() {
+		event := (&ScenarioEvent{
+			ID:   "event-1",
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			Host: &HostEventData{
+				ID: "my-host",
+			},
+		}).ToProtoEvent()
+		Expect(event.Payload).To(BeNil())
+	})
+})
@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// DefaultScenarioWatchInterval is the default interval between checks of the scenario file.
+const DefaultScenarioWatchInterval = time.Second
+
+// ScenarioWatcherBuilder contains the data and logic needed to create a scenario watcher. Don't create instances of
+// this type directly, use the NewScenarioWatcher function instead.
+type ScenarioWatcherBuilder struct {
+	logger   *slog.Logger
+	file     string
+	interval time.Duration
+	handler  func(*EventScenario)
+}
+
+// ScenarioWatcher checks periodically if a scenario file has changed, and when it has it loads it again and passes
+// the result to a handler, so that the test server can be updated without restarting it. Files that fail to load are
+// reported and ignored, keeping the previous scenario till the file is fixed. Don't create instances of this type
+// directly, use the NewScenarioWatcher function instead.
+type ScenarioWatcher struct {
+	logger   *slog.Logger
+	file     string
+	interval time.Duration
+	handler  func(*EventScenario)
+	stamp    scenarioStamp
+}
+
+// scenarioStamp contains the details of the file used to detect changes.
+type scenarioStamp struct {
+	time time.Time
+	size int64
+}
+
+// NewScenarioWatcher creates a builder that can then be used to configure and create a scenario watcher.
+func NewScenarioWatcher() *ScenarioWatcherBuilder {
+	return &ScenarioWatcherBuilder{
+		interval: DefaultScenarioWatchInterval,
+	}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *ScenarioWatcherBuilder) SetLogger(value *slog.Logger) *ScenarioWatcherBuilder {
+	b.logger = value
+	return b
+}
+
+// SetFile sets the name of the scenario file. This is mandatory.
+func (b *ScenarioWatcherBuilder) SetFile(value string) *ScenarioWatcherBuilder {
+	b.file = value
+	return b
+}
+
+// SetInterval sets the interval between checks of the file. This is optional, the default is one second.
+func (b *ScenarioWatcherBuilder) SetInterval(value time.Duration) *ScenarioWatcherBuilder {
+	b.interval = value
+	return b
+}
+
+// SetHandler sets the function that will be called with the new scenario each time that the file changes. This is
+// mandatory.
+func (b *ScenarioWatcherBuilder) SetHandler(value func(*EventScenario)) *ScenarioWatcherBuilder {
+	b.handler = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new scenario watcher. The current state of the file is taken
+// as the starting point, so the handler is called only for changes made after this.
+func (b *ScenarioWatcherBuilder) Build() (result *ScenarioWatcher, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.file == "" {
+		err = errors.New("file is mandatory")
+		return
+	}
+	if b.interval <= 0 {
+		err = errors.New("interval should be positive")
+		return
+	}
+	if b.handler == nil {
+		err = errors.New("handler is mandatory")
+		return
+	}
+
+	// Create and populate the object:
+	result = &ScenarioWatcher{
+		logger:   b.logger,
+		file:     b.file,
+		interval: b.interval,
+		handler:  b.handler,
+		stamp:    loadScenarioStamp(b.file),
+	}
+	return
+}
+
+// Run checks the file till the context is cancelled.
+func (w *ScenarioWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check loads the file and calls the handler if it changed since the last check.
+func (w *ScenarioWatcher) check(ctx context.Context) {
+	stamp := loadScenarioStamp(w.file)
+	if stamp == w.stamp {
+		return
+	}
+	w.stamp = stamp
+	if stamp == (scenarioStamp{}) {
+		return
+	}
+	scenario, err := LoadScenarioFromFile(w.file)
+	if err != nil {
+		w.logger.ErrorContext(
+			ctx,
+			"Failed to reload scenario, will keep the previous one",
+			slog.String("file", w.file),
+			slog.Any("error", err),
+		)
+		return
+	}
+	w.logger.InfoContext(
+		ctx,
+		"Reloaded scenario",
+		slog.String("file", w.file),
+		slog.String("name", scenario.Name),
+		slog.Int("events", len(scenario.Events)),
+	)
+	w.handler(scenario)
+}
+
+// loadScenarioStamp returns the modification time and size of the file, or the zero value if the file doesn't exist,
+// for example while an editor is replacing it.
+func loadScenarioStamp(file string) scenarioStamp {
+	info, err := os.Stat(file)
+	if err != nil {
+		return scenarioStamp{}
+	}
+	return scenarioStamp{
+		time: info.ModTime(),
+		size: info.Size(),
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scenario watcher", func() {
+	var (
+		file      string
+		scenarios chan *EventScenario
+	)
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "scenario.yaml")
+		err := os.WriteFile(file, []byte("name: first\nevents: []\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		scenarios = make(chan *EventScenario, 10)
+	})
+
+	start := func() {
+		watcher, err := NewScenarioWatcher().
+			SetLogger(logger).
+			SetFile(file).
+			SetInterval(10 * time.Millisecond).
+			SetHandler(func(scenario *EventScenario) {
+				scenarios <- scenario
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go watcher.Run(ctx)
+	}
+
+	write := func(content string) {
+		err := os.WriteFile(file, []byte(content), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Move the modification time forward, as the granularity of the file system may be too coarse to notice
+		// changes made quickly:
+		now := time.Now().Add(time.Duration(len(content)) * time.Second)
+		err = os.Chtimes(file, now, now)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("Can't be created without a logger", func() {
+		_, err := NewScenarioWatcher().
+			SetFile(file).
+			SetHandler(func(*EventScenario) {}).
+			Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Can't be created without a handler", func() {
+		_, err := NewScenarioWatcher().
+			SetLogger(logger).
+			SetFile(file).
+			Build()
+		Expect(err).To(MatchError("handler is mandatory"))
+	})
+
+	It("Doesn't call the handler if the file doesn't change", func() {
+		start()
+		Consistently(scenarios, 100*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("Calls the handler when the file changes", func() {
+		start()
+		write("name: second\nevents: []\n")
+		var scenario *EventScenario
+		Eventually(scenarios).Should(Receive(&scenario))
+		Expect(scenario.Name).To(Equal("second"))
+	})
+
+	It("Ignores files that can't be loaded", func() {
+		start()
+		write("name: [\n")
+		Consistently(scenarios, 100*time.Millisecond).ShouldNot(Receive())
+		write("name: third\nevents: []\n")
+		var scenario *EventScenario
+		Eventually(scenarios).Should(Receive(&scenario))
+		Expect(scenario.Name).To(Equal("third"))
+	})
+})
@@ -14,14 +14,18 @@ language governing permissions and limitations under the License.
 package testing
 
 import (
+	"sync"
 	"time"
 
 	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 // MockEventsServerBuilder builds a mock events server with configurable scenarios
 type MockEventsServerBuilder struct {
 	scenario *EventScenario
+	objects  *MockObjects
+	replay   bool
 }
 
 // NewMockEventsServerBuilder creates a new builder for mock events server
@@ -35,39 +39,133 @@ func (b *MockEventsServerBuilder) WithScenario(scenario *EventScenario) *MockEve
 	return b
 }
 
+// WithObjects sets the mock objects whose changes, made through the mock servers, will be sent as events in addition
+// to the events of the scenario.
+func (b *MockEventsServerBuilder) WithObjects(objects *MockObjects) *MockEventsServerBuilder {
+	b.objects = objects
+	return b
+}
+
+// WithReplay sets whether the streams opened after a failure of the scenario start again from the first event, like
+// servers that send again the recent events when a watch starts. The default is to continue with the event where the
+// previous stream failed.
+func (b *MockEventsServerBuilder) WithReplay(value bool) *MockEventsServerBuilder {
+	b.replay = value
+	return b
+}
+
 // Build creates the EventsServerFuncs with the configured scenario
 // If no scenario is set, the server will send no events
 func (b *MockEventsServerBuilder) Build() *EventsServerFuncs {
+	state := &mockEventsState{
+		failures: map[int]int{},
+	}
 	return &EventsServerFuncs{
-		WatchFunc: b.createWatchFunc(),
+		WatchFunc: b.createWatchFunc(state),
+	}
+}
+
+// mockEventsState contains the progress of the scenario that is shared by all the streams of the server, so that
+// failures happen only the requested number of times and streams can continue where the previous one failed.
+type mockEventsState struct {
+	lock     sync.Mutex
+	position int
+	failures map[int]int
+}
+
+// start returns the index of the event where a new stream should start.
+func (s *mockEventsState) start(replay bool) int {
+	if replay {
+		return 0
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.position
+}
+
+// fail checks if the failure at the given index should still happen, and if so records it.
+func (s *mockEventsState) fail(index int, failure *ScenarioFailure) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failures[index] >= max(failure.Times, 1) {
+		return false
 	}
+	s.failures[index]++
+	s.position = index
+	return true
 }
 
 // createWatchFunc creates a WatchFunc that sends events from the scenario
-func (b *MockEventsServerBuilder) createWatchFunc() func(*eventsv1.EventsWatchRequest, eventsv1.Events_WatchServer) error {
+func (b *MockEventsServerBuilder) createWatchFunc(
+	state *mockEventsState) func(*eventsv1.EventsWatchRequest, eventsv1.Events_WatchServer) error {
 	return func(request *eventsv1.EventsWatchRequest, stream eventsv1.Events_WatchServer) error {
-		filter := request.GetFilter()
+		ctx := stream.Context()
+
+		// Compile the filter before sending anything, so that an invalid one is reported immediately like the real
+		// server does:
+		filter, err := newMockFilter("event", &eventsv1.Event{}, request.GetFilter())
+		if err != nil {
+			return err
+		}
+
+		// Subscribe to the changes made through the mock servers before sending anything, so that none is lost. The
+		// events of those changes are sent while waiting, so that they aren't delayed by the scenario.
+		var live <-chan *eventsv1.Event
+		if b.objects != nil {
+			live = b.objects.Subscribe(ctx)
+		}
+		wait := func(timer <-chan time.Time) error {
+			for {
+				select {
+				case <-timer:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				case event := <-live:
+					err := sendEventIfMatches(event, filter, stream)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
 
 		// If no scenario is set, just wait for context cancellation
 		if b.scenario != nil {
-			for _, scenarioEvent := range b.scenario.Events {
+			for i := state.start(b.replay); i < len(b.scenario.Events); i++ {
+				scenarioEvent := b.scenario.Events[i]
+
 				// Apply delay if specified
-				if scenarioEvent.DelaySeconds > 0 {
-					time.Sleep(time.Duration(scenarioEvent.DelaySeconds) * time.Second)
+				delay := time.Duration(scenarioEvent.DelaySeconds)*time.Second + scenarioEvent.Delay
+				if delay > 0 {
+					err = wait(time.After(delay))
+					if err != nil {
+						return err
+					}
 				}
 
-				// Convert scenario event to proto event
+				// Interrupt the stream if this is a failure that should still happen:
+				if scenarioEvent.Failure != nil {
+					if state.fail(i, scenarioEvent.Failure) {
+						return grpcstatus.Error(scenarioEvent.Failure.Code, scenarioEvent.Failure.Message)
+					}
+					continue
+				}
+
+				// Convert scenario event to proto event, skipping the objects that the events API can't carry:
 				event := scenarioEvent.ToProtoEvent()
+				if event.Payload == nil {
+					continue
+				}
 
 				// Send event if it matches the filter
-				if err := SendEventIfMatches(event, filter, stream); err != nil {
+				if err := sendEventIfMatches(event, filter, stream); err != nil {
 					return err
 				}
 			}
 		}
 
-		// Wait for context cancellation
-		<-stream.Context().Done()
-		return stream.Context().Err()
+		// Send the events of the changes made through the mock servers till the context is cancelled:
+		return wait(nil)
 	}
 }
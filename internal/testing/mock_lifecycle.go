@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultLifecycleDelay is the default time that objects created through the mock servers take to be ready.
+const DefaultLifecycleDelay = 3 * time.Second
+
+// mockLifecycle describes how objects of a type evolve after they are created through the mock servers: the start
+// function sets the status that they have when they are created, and the finish function the status that they have
+// once they are ready.
+type mockLifecycle struct {
+	start  func(object proto.Message)
+	finish func(object proto.Message)
+}
+
+// mockLifecycles contains the lifecycles of the types of objects that can be created through the mock servers.
+var mockLifecycles = map[protoreflect.FullName]mockLifecycle{
+	mockObjectName[*ffv1.Cluster](): {
+		start: func(object proto.Message) {
+			object.(*ffv1.Cluster).SetStatus(ffv1.ClusterStatus_builder{
+				State: ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+				Conditions: []*ffv1.ClusterCondition{
+					ffv1.ClusterCondition_builder{
+						Type:    ffv1.ClusterConditionType_CLUSTER_CONDITION_TYPE_PROGRESSING,
+						Status:  sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+						Message: proto.String("Cluster is being provisioned"),
+					}.Build(),
+				},
+			}.Build())
+		},
+		finish: func(object proto.Message) {
+			cluster := object.(*ffv1.Cluster)
+			domain := cluster.GetMetadata().GetName()
+			if domain == "" {
+				domain = cluster.GetId()
+			}
+			cluster.SetStatus(ffv1.ClusterStatus_builder{
+				State: ffv1.ClusterState_CLUSTER_STATE_READY,
+				Conditions: []*ffv1.ClusterCondition{
+					ffv1.ClusterCondition_builder{
+						Type:   ffv1.ClusterConditionType_CLUSTER_CONDITION_TYPE_PROGRESSING,
+						Status: sharedv1.ConditionStatus_CONDITION_STATUS_FALSE,
+					}.Build(),
+					ffv1.ClusterCondition_builder{
+						Type:    ffv1.ClusterConditionType_CLUSTER_CONDITION_TYPE_READY,
+						Status:  sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+						Message: proto.String("Cluster is ready"),
+					}.Build(),
+				},
+				ApiUrl:     fmt.Sprintf("https://api.%s.example.com:6443", domain),
+				ConsoleUrl: fmt.Sprintf("https://console.%s.example.com", domain),
+			}.Build())
+		},
+	},
+	mockObjectName[*ffv1.ComputeInstance](): {
+		start: func(object proto.Message) {
+			object.(*ffv1.ComputeInstance).SetStatus(ffv1.ComputeInstanceStatus_builder{
+				State: ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_STARTING,
+			}.Build())
+		},
+		finish: func(object proto.Message) {
+			object.(*ffv1.ComputeInstance).SetStatus(ffv1.ComputeInstanceStatus_builder{
+				State: ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
+				Conditions: []*ffv1.ComputeInstanceCondition{
+					ffv1.ComputeInstanceCondition_builder{
+						Type:    ffv1.ComputeInstanceConditionType_COMPUTE_INSTANCE_CONDITION_TYPE_AVAILABLE,
+						Status:  sharedv1.ConditionStatus_CONDITION_STATUS_TRUE,
+						Message: proto.String("The compute instance is available"),
+					}.Build(),
+				},
+				IpAddress: "192.168.1.100",
+			}.Build())
+		},
+	},
+}
+
+// SetLifecycleDelay sets the time that objects created through the mock servers take to be ready. The default is
+// given by the DefaultLifecycleDelay constant.
+func (o *MockObjects) SetLifecycleDelay(value time.Duration) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.lifecycleDelay = value
+}
+
+// Subscribe returns a channel where the events generated by the changes made through the mock servers will be sent,
+// until the context is cancelled. Only changes to the objects that the events API can carry generate events, and the
+// changes made by the events of the scenario don't, as the mock events server already sends them.
+func (o *MockObjects) Subscribe(ctx context.Context) <-chan *eventsv1.Event {
+	channel := make(chan *eventsv1.Event, mockSubscriptionSize)
+	o.lock.Lock()
+	o.subscribers[channel] = struct{}{}
+	o.lock.Unlock()
+	go func() {
+		<-ctx.Done()
+		o.lock.Lock()
+		delete(o.subscribers, channel)
+		o.lock.Unlock()
+	}()
+	return channel
+}
+
+// publish sends the event for the given change to all the subscribers. Subscribers that aren't keeping up lose the
+// event, so that a slow client can't block the mock servers.
+func (o *MockObjects) publish(eventType eventsv1.EventType, object proto.Message) {
+	event := newProtoEvent(newMockId(), eventType, object)
+	if event.Payload == nil {
+		return
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for subscriber := range o.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// modify applies the given change to the object with the given type and identifier, and returns a copy of the
+// result. It returns nil if there is no such object, for example because it was deleted in the meantime.
+func (o *MockObjects) modify(name protoreflect.FullName, id string, change func(proto.Message)) proto.Message {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for _, item := range o.objects[name] {
+		if item.(mockObject).GetId() == id {
+			change(item)
+			return proto.Clone(item)
+		}
+	}
+	return nil
+}
+
+// mockCreate adds a new object with a generated identifier and the creation timestamp set, like the real server
+// does. If the type of object has a lifecycle the object starts in the initial state, and then it moves to the final
+// state after the lifecycle delay, generating the corresponding update event.
+func mockCreate[T mockObject](o *MockObjects, object T) (result T, err error) {
+	name := mockObjectName[T]()
+	result = proto.Clone(object).(T)
+	message := result.ProtoReflect()
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("id"), protoreflect.ValueOfString(newMockId()))
+	metadata := message.Mutable(fields.ByName("metadata")).Message().Interface().(*sharedv1.Metadata)
+	metadata.SetCreationTimestamp(timestamppb.Now())
+	lifecycle, ok := mockLifecycles[name]
+	if ok {
+		lifecycle.start(result)
+	}
+	o.put(result)
+	o.publish(eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED, result)
+	if ok {
+		id := result.GetId()
+		o.lock.Lock()
+		delay := o.lifecycleDelay
+		o.lock.Unlock()
+		time.AfterFunc(delay, func() {
+			updated := o.modify(name, id, lifecycle.finish)
+			if updated != nil {
+				o.publish(eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED, updated)
+			}
+		})
+	}
+	result = proto.Clone(result).(T)
+	return
+}
+
+// newMockId generates a random identifier with the same format that the real server uses.
+func newMockId() string {
+	var data [16]byte
+	rand.Read(data[:])
+	data[6] = (data[6] & 0x0f) | 0x40
+	data[8] = (data[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:16])
+}
+
+// mockSubscriptionSize is the number of events that can be waiting to be sent to a subscriber.
+const mockSubscriptionSize = 100
@@ -0,0 +1,518 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MockObjects keeps the objects described by the events of a scenario, so that the mock servers can return them and
+// the get, edit and delete commands can be exercised for all the types of objects, not only for the ones that the
+// events API can carry. Clusters and compute instances can also be created, and the changes made through the mock
+// servers generate events that are sent to the subscribers.
+type MockObjects struct {
+	lock           sync.Mutex
+	objects        map[protoreflect.FullName][]proto.Message
+	subscribers    map[chan *eventsv1.Event]struct{}
+	lifecycleDelay time.Duration
+
+	// These are used to stop the scenario that is played in the background:
+	playLock   sync.Mutex
+	playCancel context.CancelFunc
+	playDone   chan struct{}
+}
+
+// mockObject is the set of methods that all the objects kept by the mock servers have.
+type mockObject interface {
+	proto.Message
+	GetId() string
+	GetMetadata() *sharedv1.Metadata
+}
+
+// NewMockObjects creates an empty set of mock objects.
+func NewMockObjects() *MockObjects {
+	return &MockObjects{
+		objects:        map[protoreflect.FullName][]proto.Message{},
+		subscribers:    map[chan *eventsv1.Event]struct{}{},
+		lifecycleDelay: DefaultLifecycleDelay,
+	}
+}
+
+// Apply updates the objects according to the given event: created and updated events replace the object that has the
+// same identifier, and deleted events remove it. Events without objects, like failures, are ignored.
+func (o *MockObjects) Apply(event *ScenarioEvent) {
+	object, ok := event.ToProtoObject().(mockObject)
+	if !ok {
+		return
+	}
+	switch event.Type {
+	case eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED:
+		o.remove(object.ProtoReflect().Descriptor().FullName(), object.GetId())
+	default:
+		o.put(object)
+	}
+}
+
+// Play applies the events of the scenario waiting before each one the same delay that the mock events server waits
+// before sending it, so that the objects returned by the mock servers are consistent with the events. It returns when
+// all the events have been applied or when the context is cancelled.
+func (o *MockObjects) Play(ctx context.Context, scenario *EventScenario) {
+	for _, event := range scenario.Events {
+		delay := time.Duration(event.DelaySeconds)*time.Second + event.Delay
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+		}
+		o.Apply(event)
+	}
+}
+
+// Start plays the events of the scenario in the background, like the Play method. If a scenario is already being played
+// it is stopped first.
+func (o *MockObjects) Start(scenario *EventScenario) {
+	o.playLock.Lock()
+	defer o.playLock.Unlock()
+	o.stopLocked()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		o.Play(ctx, scenario)
+	}()
+	o.playCancel = cancel
+	o.playDone = done
+}
+
+// Stop stops the scenario that is being played in the background, if any, and waits till it finishes, so that no more
+// events of that scenario are applied after this returns.
+func (o *MockObjects) Stop() {
+	o.playLock.Lock()
+	defer o.playLock.Unlock()
+	o.stopLocked()
+}
+
+// stopLocked is the implementation of the Stop method, it assumes that the play lock is already acquired.
+func (o *MockObjects) stopLocked() {
+	if o.playCancel == nil {
+		return
+	}
+	o.playCancel()
+	<-o.playDone
+	o.playCancel = nil
+	o.playDone = nil
+}
+
+// Reset removes all the objects, for example before playing a scenario that replaces the previous one. The
+// subscribers receive deleted events for the removed objects, so that watches see them disappear.
+func (o *MockObjects) Reset() {
+	o.lock.Lock()
+	var removed []proto.Message
+	for _, items := range o.objects {
+		removed = append(removed, items...)
+	}
+	o.objects = map[protoreflect.FullName][]proto.Message{}
+	o.lock.Unlock()
+	for _, object := range removed {
+		o.publish(eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED, object)
+	}
+}
+
+// ClustersServer returns a clusters server that implements the create, get, list, update and delete methods using
+// these objects. Created clusters are progressing at first, and ready after the lifecycle delay.
+func (o *MockObjects) ClustersServer() *ClustersServerFuncs {
+	return &ClustersServerFuncs{
+		CreateFunc: func(ctx context.Context, request *ffv1.ClustersCreateRequest) (*ffv1.ClustersCreateResponse,
+			error) {
+			object, err := mockCreate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ClustersCreateResponse{Object: object}, nil
+		},
+		DeleteFunc: func(ctx context.Context, request *ffv1.ClustersDeleteRequest) (*ffv1.ClustersDeleteResponse,
+			error) {
+			err := mockDelete[*ffv1.Cluster](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ClustersDeleteResponse{}, nil
+		},
+		GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest) (*ffv1.ClustersGetResponse, error) {
+			object, err := mockGet[*ffv1.Cluster](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ClustersGetResponse{Object: object}, nil
+		},
+		ListFunc: func(ctx context.Context, request *ffv1.ClustersListRequest) (*ffv1.ClustersListResponse, error) {
+			items, err := mockList[*ffv1.Cluster](o, request.GetFilter())
+			if err != nil {
+				return nil, err
+			}
+			size := int32(len(items))
+			return &ffv1.ClustersListResponse{Items: items, Size: &size, Total: &size}, nil
+		},
+		UpdateFunc: func(ctx context.Context, request *ffv1.ClustersUpdateRequest) (*ffv1.ClustersUpdateResponse,
+			error) {
+			object, err := mockUpdate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ClustersUpdateResponse{Object: object}, nil
+		},
+		GetKubeconfigFunc: mockUnimplemented[
+			*ffv1.ClustersGetKubeconfigRequest, *ffv1.ClustersGetKubeconfigResponse,
+		],
+		GetKubeconfigViaHttpFunc: mockUnimplemented[
+			*ffv1.ClustersGetKubeconfigViaHttpRequest, *httpbody.HttpBody,
+		],
+	}
+}
+
+// ClusterTemplatesServer returns a cluster templates server that implements the get, list, update and delete methods
+// using these objects.
+func (o *MockObjects) ClusterTemplatesServer() *ClusterTemplatesServerFuncs {
+	return &ClusterTemplatesServerFuncs{
+		CreateFunc: mockUnimplemented[*ffv1.ClusterTemplatesCreateRequest, *ffv1.ClusterTemplatesCreateResponse],
+		DeleteFunc: func(ctx context.Context,
+			request *ffv1.ClusterTemplatesDeleteRequest) (*ffv1.ClusterTemplatesDeleteResponse, error) {
+			err := mockDelete[*ffv1.ClusterTemplate](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ClusterTemplatesDeleteResponse{}, nil
+		},
+		GetFunc: func(ctx context.Context,
+			request *ffv1.ClusterTemplatesGetRequest) (*ffv1.ClusterTemplatesGetResponse, error) {
+			object, err := mockGet[*ffv1.ClusterTemplate](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ClusterTemplatesGetResponse{Object: object}, nil
+		},
+		ListFunc: func(ctx context.Context,
+			request *ffv1.ClusterTemplatesListRequest) (*ffv1.ClusterTemplatesListResponse, error) {
+			items, err := mockList[*ffv1.ClusterTemplate](o, request.GetFilter())
+			if err != nil {
+				return nil, err
+			}
+			size := int32(len(items))
+			return &ffv1.ClusterTemplatesListResponse{Items: items, Size: &size, Total: &size}, nil
+		},
+		UpdateFunc: func(ctx context.Context,
+			request *ffv1.ClusterTemplatesUpdateRequest) (*ffv1.ClusterTemplatesUpdateResponse, error) {
+			object, err := mockUpdate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ClusterTemplatesUpdateResponse{Object: object}, nil
+		},
+	}
+}
+
+// ComputeInstancesServer returns a compute instances server that implements the create, get, list, update and delete
+// methods using these objects. Created compute instances are starting at first, and running after the lifecycle
+// delay.
+func (o *MockObjects) ComputeInstancesServer() *ComputeInstancesServerFuncs {
+	return &ComputeInstancesServerFuncs{
+		CreateFunc: func(ctx context.Context,
+			request *ffv1.ComputeInstancesCreateRequest) (*ffv1.ComputeInstancesCreateResponse, error) {
+			object, err := mockCreate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ComputeInstancesCreateResponse{Object: object}, nil
+		},
+		DeleteFunc: func(ctx context.Context,
+			request *ffv1.ComputeInstancesDeleteRequest) (*ffv1.ComputeInstancesDeleteResponse, error) {
+			err := mockDelete[*ffv1.ComputeInstance](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ComputeInstancesDeleteResponse{}, nil
+		},
+		GetFunc: func(ctx context.Context,
+			request *ffv1.ComputeInstancesGetRequest) (*ffv1.ComputeInstancesGetResponse, error) {
+			object, err := mockGet[*ffv1.ComputeInstance](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ComputeInstancesGetResponse{Object: object}, nil
+		},
+		ListFunc: func(ctx context.Context,
+			request *ffv1.ComputeInstancesListRequest) (*ffv1.ComputeInstancesListResponse, error) {
+			items, err := mockList[*ffv1.ComputeInstance](o, request.GetFilter())
+			if err != nil {
+				return nil, err
+			}
+			size := int32(len(items))
+			return &ffv1.ComputeInstancesListResponse{Items: items, Size: &size, Total: &size}, nil
+		},
+		UpdateFunc: func(ctx context.Context,
+			request *ffv1.ComputeInstancesUpdateRequest) (*ffv1.ComputeInstancesUpdateResponse, error) {
+			object, err := mockUpdate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.ComputeInstancesUpdateResponse{Object: object}, nil
+		},
+	}
+}
+
+// HostsServer returns a hosts server that implements the get, list, update and delete methods using these objects.
+func (o *MockObjects) HostsServer() *HostsServerFuncs {
+	return &HostsServerFuncs{
+		CreateFunc: mockUnimplemented[*ffv1.HostsCreateRequest, *ffv1.HostsCreateResponse],
+		DeleteFunc: func(ctx context.Context, request *ffv1.HostsDeleteRequest) (*ffv1.HostsDeleteResponse, error) {
+			err := mockDelete[*ffv1.Host](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostsDeleteResponse{}, nil
+		},
+		GetFunc: func(ctx context.Context, request *ffv1.HostsGetRequest) (*ffv1.HostsGetResponse, error) {
+			object, err := mockGet[*ffv1.Host](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostsGetResponse{Object: object}, nil
+		},
+		ListFunc: func(ctx context.Context, request *ffv1.HostsListRequest) (*ffv1.HostsListResponse, error) {
+			items, err := mockList[*ffv1.Host](o, request.GetFilter())
+			if err != nil {
+				return nil, err
+			}
+			size := int32(len(items))
+			return &ffv1.HostsListResponse{Items: items, Size: &size, Total: &size}, nil
+		},
+		UpdateFunc: func(ctx context.Context, request *ffv1.HostsUpdateRequest) (*ffv1.HostsUpdateResponse, error) {
+			object, err := mockUpdate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostsUpdateResponse{Object: object}, nil
+		},
+	}
+}
+
+// HostPoolsServer returns a host pools server that implements the get, list, update and delete methods using these
+// objects.
+func (o *MockObjects) HostPoolsServer() *HostPoolsServerFuncs {
+	return &HostPoolsServerFuncs{
+		CreateFunc: mockUnimplemented[*ffv1.HostPoolsCreateRequest, *ffv1.HostPoolsCreateResponse],
+		DeleteFunc: func(ctx context.Context, request *ffv1.HostPoolsDeleteRequest) (*ffv1.HostPoolsDeleteResponse,
+			error) {
+			err := mockDelete[*ffv1.HostPool](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostPoolsDeleteResponse{}, nil
+		},
+		GetFunc: func(ctx context.Context, request *ffv1.HostPoolsGetRequest) (*ffv1.HostPoolsGetResponse, error) {
+			object, err := mockGet[*ffv1.HostPool](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostPoolsGetResponse{Object: object}, nil
+		},
+		ListFunc: func(ctx context.Context, request *ffv1.HostPoolsListRequest) (*ffv1.HostPoolsListResponse, error) {
+			items, err := mockList[*ffv1.HostPool](o, request.GetFilter())
+			if err != nil {
+				return nil, err
+			}
+			size := int32(len(items))
+			return &ffv1.HostPoolsListResponse{Items: items, Size: &size, Total: &size}, nil
+		},
+		UpdateFunc: func(ctx context.Context, request *ffv1.HostPoolsUpdateRequest) (*ffv1.HostPoolsUpdateResponse,
+			error) {
+			object, err := mockUpdate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostPoolsUpdateResponse{Object: object}, nil
+		},
+	}
+}
+
+// HostClassesServer returns a host classes server that implements the get, list, update and delete methods using
+// these objects.
+func (o *MockObjects) HostClassesServer() *HostClassesServerFuncs {
+	return &HostClassesServerFuncs{
+		CreateFunc: mockUnimplemented[*ffv1.HostClassesCreateRequest, *ffv1.HostClassesCreateResponse],
+		DeleteFunc: func(ctx context.Context,
+			request *ffv1.HostClassesDeleteRequest) (*ffv1.HostClassesDeleteResponse, error) {
+			err := mockDelete[*ffv1.HostClass](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostClassesDeleteResponse{}, nil
+		},
+		GetFunc: func(ctx context.Context,
+			request *ffv1.HostClassesGetRequest) (*ffv1.HostClassesGetResponse, error) {
+			object, err := mockGet[*ffv1.HostClass](o, request.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostClassesGetResponse{Object: object}, nil
+		},
+		ListFunc: func(ctx context.Context,
+			request *ffv1.HostClassesListRequest) (*ffv1.HostClassesListResponse, error) {
+			items, err := mockList[*ffv1.HostClass](o, request.GetFilter())
+			if err != nil {
+				return nil, err
+			}
+			size := int32(len(items))
+			return &ffv1.HostClassesListResponse{Items: items, Size: &size, Total: &size}, nil
+		},
+		UpdateFunc: func(ctx context.Context,
+			request *ffv1.HostClassesUpdateRequest) (*ffv1.HostClassesUpdateResponse, error) {
+			object, err := mockUpdate(o, request.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			return &ffv1.HostClassesUpdateResponse{Object: object}, nil
+		},
+	}
+}
+
+// put adds a copy of the object, replacing the existing one with the same identifier, if any.
+func (o *MockObjects) put(object mockObject) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	name := object.ProtoReflect().Descriptor().FullName()
+	items := o.objects[name]
+	clone := proto.Clone(object)
+	for i, item := range items {
+		if item.(mockObject).GetId() == object.GetId() {
+			items[i] = clone
+			return
+		}
+	}
+	o.objects[name] = append(items, clone)
+}
+
+// find returns a copy of the object of the given type and identifier, or nil if there is no such object.
+func (o *MockObjects) find(name protoreflect.FullName, id string) proto.Message {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for _, item := range o.objects[name] {
+		if item.(mockObject).GetId() == id {
+			return proto.Clone(item)
+		}
+	}
+	return nil
+}
+
+// remove removes the object of the given type and identifier, and returns a flag indicating if it existed.
+func (o *MockObjects) remove(name protoreflect.FullName, id string) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	items := o.objects[name]
+	for i, item := range items {
+		if item.(mockObject).GetId() == id {
+			o.objects[name] = append(items[:i:i], items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// items returns copies of all the objects of the given type, in the order they were added.
+func (o *MockObjects) items(name protoreflect.FullName) []proto.Message {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	result := make([]proto.Message, len(o.objects[name]))
+	for i, item := range o.objects[name] {
+		result[i] = proto.Clone(item)
+	}
+	return result
+}
+
+// mockObjectName returns the full name of the given type of object.
+func mockObjectName[T mockObject]() protoreflect.FullName {
+	var object T
+	return object.ProtoReflect().Descriptor().FullName()
+}
+
+func mockGet[T mockObject](o *MockObjects, id string) (result T, err error) {
+	object := o.find(mockObjectName[T](), id)
+	if object == nil {
+		err = mockNotFound(id)
+		return
+	}
+	result = object.(T)
+	return
+}
+
+// mockList returns the objects of the given type that match the filter.
+func mockList[T mockObject](o *MockObjects, filter string) (result []T, err error) {
+	items := o.items(mockObjectName[T]())
+	objects := make([]T, len(items))
+	for i, item := range items {
+		objects[i] = item.(T)
+	}
+	return FilterObjects(objects, filter)
+}
+
+// mockDelete removes the object with the given identifier, generating the corresponding delete event.
+func mockDelete[T mockObject](o *MockObjects, id string) error {
+	name := mockObjectName[T]()
+	existing := o.find(name, id)
+	if existing == nil || !o.remove(name, id) {
+		return mockNotFound(id)
+	}
+	o.publish(eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED, existing)
+	return nil
+}
+
+// mockUpdate replaces the object with the same identifier, generating the corresponding update event. The status is
+// owned by the server, so it is preserved when the update doesn't contain it.
+func mockUpdate[T mockObject](o *MockObjects, update T) (result T, err error) {
+	existing := o.find(mockObjectName[T](), update.GetId())
+	if existing == nil {
+		err = mockNotFound(update.GetId())
+		return
+	}
+	result = proto.Clone(update).(T)
+	status := existing.ProtoReflect().Descriptor().Fields().ByName("status")
+	if status != nil && !result.ProtoReflect().Has(status) && existing.ProtoReflect().Has(status) {
+		result.ProtoReflect().Set(status, existing.ProtoReflect().Get(status))
+	}
+	o.put(result)
+	o.publish(eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED, result)
+	return
+}
+
+func mockNotFound(id string) error {
+	return grpcstatus.Errorf(codes.NotFound, "object with identifier '%s' doesn't exist", id)
+}
+
+func mockUnimplemented[Q, R any](ctx context.Context, request Q) (response R, err error) {
+	err = grpcstatus.Error(codes.Unimplemented, "method isn't implemented by the mock server")
+	return
+}
@@ -0,0 +1,425 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Mock objects", func() {
+	var (
+		ctx     context.Context
+		objects *MockObjects
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		objects = NewMockObjects()
+	})
+
+	It("Adds and replaces objects", func() {
+		objects.Apply(&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			Host: &HostEventData{
+				ID:    "my-host",
+				Name:  "my-name",
+				State: ffv1.HostState_HOST_STATE_PROGRESSING,
+			},
+		})
+		objects.Apply(&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+			Host: &HostEventData{
+				ID:    "my-host",
+				Name:  "my-name",
+				State: ffv1.HostState_HOST_STATE_READY,
+			},
+		})
+		response, err := objects.HostsServer().Get(ctx, ffv1.HostsGetRequest_builder{
+			Id: "my-host",
+		}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetObject().GetMetadata().GetName()).To(Equal("my-name"))
+		Expect(response.GetObject().GetStatus().GetState()).To(Equal(ffv1.HostState_HOST_STATE_READY))
+	})
+
+	It("Removes deleted objects", func() {
+		objects.Apply(&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			HostPool: &HostPoolEventData{
+				ID: "my-pool",
+			},
+		})
+		objects.Apply(&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED,
+			HostPool: &HostPoolEventData{
+				ID: "my-pool",
+			},
+		})
+		response, err := objects.HostPoolsServer().List(ctx, ffv1.HostPoolsListRequest_builder{}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetItems()).To(BeEmpty())
+		Expect(response.GetTotal()).To(BeZero())
+	})
+
+	It("Lists objects in the order they were added", func() {
+		for _, id := range []string{"small", "large"} {
+			objects.Apply(&ScenarioEvent{
+				Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				HostClass: &HostClassEventData{
+					ID: id,
+				},
+			})
+		}
+		response, err := objects.HostClassesServer().List(ctx, ffv1.HostClassesListRequest_builder{}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetItems()).To(HaveLen(2))
+		Expect(response.GetItems()[0].GetId()).To(Equal("small"))
+		Expect(response.GetItems()[1].GetId()).To(Equal("large"))
+		Expect(response.GetSize()).To(BeNumerically("==", 2))
+	})
+
+	It("Deletes objects", func() {
+		objects.Apply(&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			ClusterTemplate: &ClusterTemplateEventData{
+				ID: "my-template",
+			},
+		})
+		server := objects.ClusterTemplatesServer()
+		_, err := server.Delete(ctx, ffv1.ClusterTemplatesDeleteRequest_builder{
+			Id: "my-template",
+		}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = server.Get(ctx, ffv1.ClusterTemplatesGetRequest_builder{
+			Id: "my-template",
+		}.Build())
+		Expect(grpcstatus.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("Preserves the status when updating", func() {
+		objects.Apply(&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			Cluster: &ClusterEventData{
+				ID:    "my-cluster",
+				State: ffv1.ClusterState_CLUSTER_STATE_READY,
+			},
+		})
+		server := objects.ClustersServer()
+		_, err := server.Update(ctx, ffv1.ClustersUpdateRequest_builder{
+			Object: ffv1.Cluster_builder{
+				Id: "my-cluster",
+				Metadata: sharedv1.Metadata_builder{
+					Name: "my-new-name",
+				}.Build(),
+			}.Build(),
+		}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		response, err := server.Get(ctx, ffv1.ClustersGetRequest_builder{
+			Id: "my-cluster",
+		}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetObject().GetMetadata().GetName()).To(Equal("my-new-name"))
+		Expect(response.GetObject().GetStatus().GetState()).To(Equal(ffv1.ClusterState_CLUSTER_STATE_READY))
+	})
+
+	It("Rejects updates of objects that don't exist", func() {
+		_, err := objects.HostsServer().Update(ctx, ffv1.HostsUpdateRequest_builder{
+			Object: ffv1.Host_builder{
+				Id: "my-host",
+			}.Build(),
+		}.Build())
+		Expect(grpcstatus.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("Doesn't support creation", func() {
+		_, err := objects.HostsServer().Create(ctx, ffv1.HostsCreateRequest_builder{}.Build())
+		Expect(grpcstatus.Code(err)).To(Equal(codes.Unimplemented))
+	})
+
+	It("Plays the events of a scenario", func() {
+		scenario := &EventScenario{
+			Events: []*ScenarioEvent{
+				{
+					Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+					Host: &HostEventData{ID: "my-host"},
+				},
+				{
+					Failure: &ScenarioFailure{Code: codes.Unavailable},
+				},
+				{
+					Type:  eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED,
+					Delay: 10 * time.Millisecond,
+					Host:  &HostEventData{ID: "my-host"},
+				},
+			},
+		}
+		objects.Play(ctx, scenario)
+		response, err := objects.HostsServer().List(ctx, ffv1.HostsListRequest_builder{}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetItems()).To(BeEmpty())
+	})
+
+	It("Doesn't apply events of a stopped scenario after reset", func() {
+		scenario := &EventScenario{}
+		for i := range 1000 {
+			scenario.Events = append(scenario.Events, &ScenarioEvent{
+				Type:  eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				Delay: time.Millisecond,
+				Host:  &HostEventData{ID: fmt.Sprintf("my-host-%d", i)},
+			})
+		}
+		objects.Start(scenario)
+		time.Sleep(10 * time.Millisecond)
+		objects.Stop()
+		objects.Reset()
+		Consistently(func(g Gomega) {
+			response, err := objects.HostsServer().List(ctx, ffv1.HostsListRequest_builder{}.Build())
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(response.GetItems()).To(BeEmpty())
+		}).WithTimeout(50 * time.Millisecond).Should(Succeed())
+	})
+
+	It("Replaces the scenario that is being played", func() {
+		first := &EventScenario{}
+		for i := range 1000 {
+			first.Events = append(first.Events, &ScenarioEvent{
+				Type:  eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				Delay: time.Millisecond,
+				Host:  &HostEventData{ID: fmt.Sprintf("first-host-%d", i)},
+			})
+		}
+		second := &EventScenario{
+			Events: []*ScenarioEvent{
+				{
+					Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+					Host: &HostEventData{ID: "second-host"},
+				},
+			},
+		}
+		DeferCleanup(objects.Stop)
+
+		// Reload several times while the objects are being read, so that the race detector can find unsynchronized
+		// accesses:
+		for range 10 {
+			objects.Start(first)
+			time.Sleep(time.Millisecond)
+			_, err := objects.HostsServer().List(ctx, ffv1.HostsListRequest_builder{}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			objects.Stop()
+			objects.Reset()
+		}
+		objects.Start(second)
+		Eventually(func(g Gomega) {
+			response, err := objects.HostsServer().List(ctx, ffv1.HostsListRequest_builder{}.Build())
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(response.GetItems()).To(HaveLen(1))
+			g.Expect(response.GetItems()[0].GetId()).To(Equal("second-host"))
+		}).Should(Succeed())
+	})
+
+	It("Removes all the objects when reset", func() {
+		objects.Apply(&ScenarioEvent{
+			Type:    eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			Cluster: &ClusterEventData{ID: "my-cluster"},
+		})
+		objects.Apply(&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			Host: &HostEventData{ID: "my-host"},
+		})
+		subscribeCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		events := objects.Subscribe(subscribeCtx)
+		objects.Reset()
+
+		// Check that the objects are gone:
+		clusters, err := objects.ClustersServer().List(ctx, ffv1.ClustersListRequest_builder{}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusters.GetItems()).To(BeEmpty())
+		hosts, err := objects.HostsServer().List(ctx, ffv1.HostsListRequest_builder{}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hosts.GetItems()).To(BeEmpty())
+
+		// Check that the deletion of the cluster was published, the hosts can't be carried by events:
+		var event *eventsv1.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.GetType()).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED))
+		Expect(event.GetCluster().GetId()).To(Equal("my-cluster"))
+	})
+
+	Describe("Lifecycle", func() {
+		BeforeEach(func() {
+			objects.SetLifecycleDelay(10 * time.Millisecond)
+		})
+
+		It("Creates clusters that become ready after the lifecycle delay", func() {
+			subscribeCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			events := objects.Subscribe(subscribeCtx)
+
+			// Create the cluster:
+			server := objects.ClustersServer()
+			response, err := server.Create(ctx, ffv1.ClustersCreateRequest_builder{
+				Object: ffv1.Cluster_builder{
+					Metadata: sharedv1.Metadata_builder{
+						Name: "my-cluster",
+					}.Build(),
+				}.Build(),
+			}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			cluster := response.GetObject()
+			Expect(cluster.GetId()).ToNot(BeEmpty())
+			Expect(cluster.GetMetadata().HasCreationTimestamp()).To(BeTrue())
+			Expect(cluster.GetStatus().GetState()).To(Equal(ffv1.ClusterState_CLUSTER_STATE_PROGRESSING))
+
+			// Check that it generates the created and then the updated event:
+			var event *eventsv1.Event
+			Eventually(events).Should(Receive(&event))
+			Expect(event.GetType()).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED))
+			Expect(event.GetCluster().GetId()).To(Equal(cluster.GetId()))
+			Eventually(events).Should(Receive(&event))
+			Expect(event.GetType()).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED))
+			Expect(event.GetCluster().GetStatus().GetState()).To(Equal(ffv1.ClusterState_CLUSTER_STATE_READY))
+			Expect(event.GetCluster().GetStatus().GetApiUrl()).To(Equal("https://api.my-cluster.example.com:6443"))
+
+			// Check that the server returns the ready version:
+			getResponse, err := server.Get(ctx, ffv1.ClustersGetRequest_builder{
+				Id: cluster.GetId(),
+			}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getResponse.GetObject().GetStatus().GetState()).To(Equal(ffv1.ClusterState_CLUSTER_STATE_READY))
+		})
+
+		It("Generates events when clusters are updated and deleted", func() {
+			objects.Apply(&ScenarioEvent{
+				Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				Cluster: &ClusterEventData{
+					ID: "my-cluster",
+				},
+			})
+			subscribeCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			events := objects.Subscribe(subscribeCtx)
+			server := objects.ClustersServer()
+			_, err := server.Update(ctx, ffv1.ClustersUpdateRequest_builder{
+				Object: ffv1.Cluster_builder{
+					Id: "my-cluster",
+					Metadata: sharedv1.Metadata_builder{
+						Name: "your-cluster",
+					}.Build(),
+				}.Build(),
+			}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			_, err = server.Delete(ctx, ffv1.ClustersDeleteRequest_builder{
+				Id: "my-cluster",
+			}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			var event *eventsv1.Event
+			Eventually(events).Should(Receive(&event))
+			Expect(event.GetType()).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED))
+			Expect(event.GetCluster().GetMetadata().GetName()).To(Equal("your-cluster"))
+			Eventually(events).Should(Receive(&event))
+			Expect(event.GetType()).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED))
+			Expect(event.GetCluster().GetId()).To(Equal("my-cluster"))
+		})
+
+		It("Creates compute instances that start running after the lifecycle delay", func() {
+			subscribeCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			events := objects.Subscribe(subscribeCtx)
+			server := objects.ComputeInstancesServer()
+			response, err := server.Create(ctx, ffv1.ComputeInstancesCreateRequest_builder{
+				Object: ffv1.ComputeInstance_builder{}.Build(),
+			}.Build())
+			Expect(err).ToNot(HaveOccurred())
+			id := response.GetObject().GetId()
+			Expect(response.GetObject().GetStatus().GetState()).To(
+				Equal(ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_STARTING),
+			)
+			Eventually(func() ffv1.ComputeInstanceState {
+				getResponse, err := server.Get(ctx, ffv1.ComputeInstancesGetRequest_builder{
+					Id: id,
+				}.Build())
+				Expect(err).ToNot(HaveOccurred())
+				return getResponse.GetObject().GetStatus().GetState()
+			}).Should(Equal(ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING))
+
+			// The events API can't carry compute instances, so there should be no events:
+			Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("Sends the events of the changes to the watch streams", func() {
+			eventsServer := NewMockEventsServerBuilder().
+				WithObjects(objects).
+				Build()
+			watchCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			stream := &fakeWatchStream{
+				ctx:  watchCtx,
+				sent: make(chan *eventsv1.Event, 10),
+			}
+			go eventsServer.Watch(eventsv1.EventsWatchRequest_builder{
+				Filter: proto.String(`has(event.cluster) && event.cluster.metadata.name == "mine"`),
+			}.Build(), stream)
+
+			// Create two clusters, only one matching the filter. The subscription happens when the watch starts, so
+			// we need to wait a bit to make sure that it is already started.
+			time.Sleep(10 * time.Millisecond)
+			server := objects.ClustersServer()
+			for _, name := range []string{"yours", "mine"} {
+				_, err := server.Create(ctx, ffv1.ClustersCreateRequest_builder{
+					Object: ffv1.Cluster_builder{
+						Metadata: sharedv1.Metadata_builder{
+							Name: name,
+						}.Build(),
+					}.Build(),
+				}.Build())
+				Expect(err).ToNot(HaveOccurred())
+			}
+			var event *eventsv1.Event
+			Eventually(stream.sent).Should(Receive(&event))
+			Expect(event.GetType()).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED))
+			Expect(event.GetCluster().GetMetadata().GetName()).To(Equal("mine"))
+			Eventually(stream.sent).Should(Receive(&event))
+			Expect(event.GetType()).To(Equal(eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED))
+			Expect(event.GetCluster().GetMetadata().GetName()).To(Equal("mine"))
+		})
+	})
+})
+
+// fakeWatchStream is a watch stream that sends the events to a channel.
+type fakeWatchStream struct {
+	eventsv1.Events_WatchServer
+	ctx  context.Context
+	sent chan *eventsv1.Event
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(response *eventsv1.EventsWatchResponse) error {
+	s.sent <- response.GetEvent()
+	return nil
+}
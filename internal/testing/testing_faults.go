@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"path"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// FaultInjectorBuilder contains the data and logic needed to create a fault injector. Don't create instances of this
+// type directly, use the NewFaultInjector function instead.
+type FaultInjectorBuilder struct {
+	logger *slog.Logger
+	faults *ScenarioFaults
+}
+
+// FaultInjector is a set of gRPC server interceptors that inject the faults of a scenario: errors, latency and
+// streams that are interrupted after sending some messages. Don't create instances of this type directly, use the
+// NewFaultInjector function instead.
+type FaultInjector struct {
+	logger *slog.Logger
+	lock   sync.Mutex
+	random *rand.Rand
+	rules  []*ScenarioFault
+	counts map[*ScenarioFault]int
+}
+
+// NewFaultInjector creates a builder that can then be used to configure and create a fault injector.
+func NewFaultInjector() *FaultInjectorBuilder {
+	return &FaultInjectorBuilder{}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *FaultInjectorBuilder) SetLogger(value *slog.Logger) *FaultInjectorBuilder {
+	b.logger = value
+	return b
+}
+
+// SetFaults sets the faults to inject. If not set, or if nil, no fault is injected.
+func (b *FaultInjectorBuilder) SetFaults(value *ScenarioFaults) *FaultInjectorBuilder {
+	b.faults = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new fault injector.
+func (b *FaultInjectorBuilder) Build() (result *FaultInjector, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+
+	// Create and populate the object:
+	var (
+		seed  int64
+		rules []*ScenarioFault
+	)
+	if b.faults != nil {
+		seed = b.faults.Seed
+		rules = b.faults.Rules
+	}
+	result = &FaultInjector{
+		logger: b.logger,
+		random: rand.New(rand.NewPCG(uint64(seed), uint64(seed))),
+		rules:  rules,
+		counts: map[*ScenarioFault]int{},
+	}
+	return
+}
+
+// UnaryInterceptor returns the interceptor that injects the faults in unary calls.
+func (i *FaultInjector) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (response any, err error) {
+		for _, rule := range i.match(info.FullMethod) {
+			err = i.inject(ctx, info.FullMethod, rule)
+			if err != nil {
+				return
+			}
+		}
+		response, err = handler(ctx, request)
+		return
+	}
+}
+
+// StreamInterceptor returns the interceptor that injects the faults in streaming calls. Faults with the
+// DisconnectAfter field send that number of messages and then fail.
+func (i *FaultInjector) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+		for _, rule := range i.match(info.FullMethod) {
+			if rule.DisconnectAfter > 0 {
+				err := i.delay(ctx, rule.Latency)
+				if err != nil {
+					return err
+				}
+				if i.decide(info.FullMethod, rule) {
+					stream = &faultyStream{
+						ServerStream: stream,
+						remaining:    rule.DisconnectAfter,
+						err:          grpcstatus.Error(rule.Code, rule.Message),
+					}
+				}
+				continue
+			}
+			err := i.inject(ctx, info.FullMethod, rule)
+			if err != nil {
+				return err
+			}
+		}
+		return handler(server, stream)
+	}
+}
+
+// match returns the rules that match the given method.
+func (i *FaultInjector) match(method string) []*ScenarioFault {
+	var result []*ScenarioFault
+	for _, rule := range i.rules {
+		matches, _ := path.Match(rule.Method, method)
+		if matches {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// inject waits the latency of the rule, and then returns the error of the rule if it has to fail.
+func (i *FaultInjector) inject(ctx context.Context, method string, rule *ScenarioFault) error {
+	err := i.delay(ctx, rule.Latency)
+	if err != nil {
+		return err
+	}
+	if !i.decide(method, rule) {
+		return nil
+	}
+	return grpcstatus.Error(rule.Code, rule.Message)
+}
+
+// delay waits the given time, or till the context is cancelled.
+func (i *FaultInjector) delay(ctx context.Context, latency time.Duration) error {
+	if latency <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(latency):
+		return nil
+	case <-ctx.Done():
+		return grpcstatus.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// decide checks if the rule should fail this call, taking into account the probability and the number of times that
+// it already failed.
+func (i *FaultInjector) decide(method string, rule *ScenarioFault) bool {
+	if rule.Code == codes.OK {
+		return false
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if rule.Times > 0 && i.counts[rule] >= rule.Times {
+		return false
+	}
+	if rule.Probability < 1 && i.random.Float64() >= rule.Probability {
+		return false
+	}
+	i.counts[rule]++
+	i.logger.Info(
+		"Injected fault",
+		slog.String("method", method),
+		slog.String("code", rule.Code.String()),
+		slog.Int("disconnect_after", rule.DisconnectAfter),
+	)
+	return true
+}
+
+// faultyStream is a server stream that fails after sending a number of messages.
+type faultyStream struct {
+	grpc.ServerStream
+	remaining int
+	err       error
+}
+
+// SendMsg is the implementation of the grpc.ServerStream interface.
+func (s *faultyStream) SendMsg(message any) error {
+	if s.remaining <= 0 {
+		return s.err
+	}
+	s.remaining--
+	return s.ServerStream.SendMsg(message)
+}
@@ -0,0 +1,246 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+var _ = Describe("Fault injector", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	// call calls the unary interceptor with a handler that always succeeds, and returns the error.
+	call := func(injector *FaultInjector, method string) error {
+		_, err := injector.UnaryInterceptor()(
+			ctx,
+			nil,
+			&grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, request any) (any, error) {
+				return "ok", nil
+			},
+		)
+		return err
+	}
+
+	It("Can't be created without a logger", func() {
+		injector, err := NewFaultInjector().Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+		Expect(injector).To(BeNil())
+	})
+
+	It("Doesn't inject anything without faults", func() {
+		injector, err := NewFaultInjector().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(call(injector, "/fulfillment.v1.Clusters/List")).To(Succeed())
+	})
+
+	It("Fails the methods that match the pattern", func() {
+		injector, err := NewFaultInjector().
+			SetLogger(logger).
+			SetFaults(&ScenarioFaults{
+				Rules: []*ScenarioFault{{
+					Method:      "/fulfillment.v1.Clusters/*",
+					Code:        codes.Unavailable,
+					Message:     "server is overloaded",
+					Probability: 1,
+				}},
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		err = call(injector, "/fulfillment.v1.Clusters/List")
+		Expect(grpcstatus.Code(err)).To(Equal(codes.Unavailable))
+		Expect(grpcstatus.Convert(err).Message()).To(Equal("server is overloaded"))
+		Expect(call(injector, "/fulfillment.v1.Hosts/List")).To(Succeed())
+	})
+
+	It("Stops failing after the given number of times", func() {
+		injector, err := NewFaultInjector().
+			SetLogger(logger).
+			SetFaults(&ScenarioFaults{
+				Rules: []*ScenarioFault{{
+					Method:      "/fulfillment.v1.Clusters/List",
+					Code:        codes.Internal,
+					Probability: 1,
+					Times:       2,
+				}},
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(grpcstatus.Code(call(injector, "/fulfillment.v1.Clusters/List"))).To(Equal(codes.Internal))
+		Expect(grpcstatus.Code(call(injector, "/fulfillment.v1.Clusters/List"))).To(Equal(codes.Internal))
+		Expect(call(injector, "/fulfillment.v1.Clusters/List")).To(Succeed())
+	})
+
+	It("Makes the same decisions with the same seed", func() {
+		faults := &ScenarioFaults{
+			Seed: 42,
+			Rules: []*ScenarioFault{{
+				Method:      "/fulfillment.v1.Clusters/List",
+				Code:        codes.Internal,
+				Probability: 0.5,
+			}},
+		}
+		decisions := func() []bool {
+			injector, err := NewFaultInjector().
+				SetLogger(logger).
+				SetFaults(faults).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			var result []bool
+			for range 20 {
+				result = append(result, call(injector, "/fulfillment.v1.Clusters/List") != nil)
+			}
+			return result
+		}
+		first := decisions()
+		Expect(first).To(ContainElement(true))
+		Expect(first).To(ContainElement(false))
+		Expect(decisions()).To(Equal(first))
+	})
+
+	It("Adds latency", func() {
+		injector, err := NewFaultInjector().
+			SetLogger(logger).
+			SetFaults(&ScenarioFaults{
+				Rules: []*ScenarioFault{{
+					Method:      "/fulfillment.v1.Clusters/Get",
+					Probability: 1,
+					Latency:     50 * time.Millisecond,
+				}},
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		start := time.Now()
+		Expect(call(injector, "/fulfillment.v1.Clusters/Get")).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+	})
+
+	It("Returns the error of the context if it is cancelled while waiting", func() {
+		injector, err := NewFaultInjector().
+			SetLogger(logger).
+			SetFaults(&ScenarioFaults{
+				Rules: []*ScenarioFault{{
+					Method:      "/fulfillment.v1.Clusters/Get",
+					Probability: 1,
+					Latency:     time.Minute,
+				}},
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		err = call(injector, "/fulfillment.v1.Clusters/Get")
+		Expect(grpcstatus.Code(err)).To(Equal(codes.DeadlineExceeded))
+	})
+
+	It("Interrupts streams after the given number of messages", func() {
+		injector, err := NewFaultInjector().
+			SetLogger(logger).
+			SetFaults(&ScenarioFaults{
+				Rules: []*ScenarioFault{{
+					Method:          "/events.v1.Events/Watch",
+					Code:            codes.Unavailable,
+					Probability:     1,
+					DisconnectAfter: 2,
+				}},
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		stream := &fakeServerStream{ctx: ctx}
+		err = injector.StreamInterceptor()(
+			nil,
+			stream,
+			&grpc.StreamServerInfo{FullMethod: "/events.v1.Events/Watch"},
+			func(server any, stream grpc.ServerStream) error {
+				for i := range 5 {
+					err := stream.SendMsg(i)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		)
+		Expect(grpcstatus.Code(err)).To(Equal(codes.Unavailable))
+		Expect(stream.sent).To(Equal([]any{0, 1}))
+	})
+
+	It("Loads faults from the scenario file", func() {
+		scenario, err := LoadScenarioFromFile(filepath.Join("testdata", "faulty-server.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scenario.Faults).ToNot(BeNil())
+		Expect(scenario.Faults.Seed).To(BeNumerically("==", 42))
+		Expect(scenario.Faults.Rules).To(HaveLen(4))
+		Expect(scenario.Faults.Rules[0]).To(Equal(&ScenarioFault{
+			Method:      "/fulfillment.v1.Clusters/List",
+			Code:        codes.Unavailable,
+			Message:     "server is overloaded",
+			Probability: 1,
+			Times:       2,
+		}))
+		Expect(scenario.Faults.Rules[1].Latency).To(Equal(2 * time.Second))
+		Expect(scenario.Faults.Rules[2].Probability).To(Equal(0.3))
+		Expect(scenario.Faults.Rules[3].DisconnectAfter).To(Equal(1))
+	})
+
+	It("Reports invalid faults with their location", func() {
+		dir, _ := TmpFS("scenario.yaml", `name: my-scenario
+faults:
+  rules:
+  - method: fulfillment.v1.Clusters/List
+    code: Unavailable
+  - method: /fulfillment.v1.Clusters/Get
+    probability: 2
+    code: Internal
+  - method: /fulfillment.v1.Clusters/Delete
+`)
+		file := filepath.Join(dir, "scenario.yaml")
+		errs := ValidateScenarioFile(file)
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0]).To(MatchError(HavePrefix(file + ":4:13: invalid method 'fulfillment.v1.Clusters/List'")))
+		Expect(errs[1]).To(MatchError(HavePrefix(file + ":7:18: invalid value '2' for field 'probability'")))
+		Expect(errs[2]).To(MatchError(ContainSubstring("fault 2 doesn't have a code or a latency")))
+	})
+})
+
+// fakeServerStream is a server stream that records the messages sent.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []any
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) SendMsg(message any) error {
+	s.sent = append(s.sent, message)
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// mockFilter is a compiled CEL filter expression, like the ones that the real server accepts in the 'filter' field of
+// the list and watch requests. The object is available in a variable, 'this' for the list requests and 'event' for
+// the watch requests.
+type mockFilter struct {
+	variable string
+	text     string
+	program  cel.Program
+}
+
+// newMockFilter compiles the given filter expression for objects of the same type than the prototype. It returns nil
+// if the expression is empty, and a nil filter matches all the objects. Errors are returned as gRPC errors with the
+// invalid argument code, so that the mock servers can return them directly.
+func newMockFilter(variable string, prototype proto.Message, text string) (result *mockFilter, err error) {
+	if text == "" {
+		return
+	}
+	descriptor := prototype.ProtoReflect().Descriptor()
+	env, err := cel.NewEnv(
+		cel.Types(prototype),
+		cel.Variable(variable, cel.ObjectType(string(descriptor.FullName()))),
+		ext.Strings(),
+	)
+	if err != nil {
+		err = grpcstatus.Errorf(codes.Internal, "failed to create CEL environment: %v", err)
+		return
+	}
+	ast, issues := env.Compile(text)
+	if issues.Err() != nil {
+		err = grpcstatus.Errorf(codes.InvalidArgument, "filter '%s' isn't valid: %v", text, issues.Err())
+		return
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		err = grpcstatus.Errorf(
+			codes.InvalidArgument,
+			"filter '%s' should be a boolean expression, but it is of type '%s'",
+			text, ast.OutputType(),
+		)
+		return
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		err = grpcstatus.Errorf(codes.InvalidArgument, "filter '%s' isn't valid: %v", text, err)
+		return
+	}
+	result = &mockFilter{
+		variable: variable,
+		text:     text,
+		program:  program,
+	}
+	return
+}
+
+// matches evaluates the filter for the given object.
+func (f *mockFilter) matches(object proto.Message) (result bool, err error) {
+	if f == nil {
+		result = true
+		return
+	}
+	value, _, err := f.program.Eval(map[string]any{
+		f.variable: object,
+	})
+	if err != nil {
+		err = grpcstatus.Errorf(codes.InvalidArgument, "failed to evaluate filter '%s': %v", f.text, err)
+		return
+	}
+	result, ok := value.Value().(bool)
+	if !ok {
+		err = grpcstatus.Errorf(
+			codes.InvalidArgument,
+			"filter '%s' should evaluate to a boolean, but the result is of type '%s'",
+			f.text, value.Type(),
+		)
+	}
+	return
+}
+
+// FilterObjects returns the objects that match the filter, evaluated as a CEL expression with the object in the same
+// 'this' variable that the real server uses for list requests. An empty filter matches all the objects.
+func FilterObjects[T proto.Message](objects []T, filter string) (result []T, err error) {
+	var prototype T
+	compiled, err := newMockFilter("this", prototype, filter)
+	if err != nil {
+		return
+	}
+	result = make([]T, 0, len(objects))
+	for _, object := range objects {
+		var matches bool
+		matches, err = compiled.matches(object)
+		if err != nil {
+			return
+		}
+		if matches {
+			result = append(result, object)
+		}
+	}
+	return
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+var _ = Describe("Mock filters", func() {
+	var (
+		ctx     context.Context
+		objects *MockObjects
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		objects = NewMockObjects()
+		for _, name := range []string{"web-1", "web-2", "db-1"} {
+			objects.Apply(&ScenarioEvent{
+				Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+				Host: &HostEventData{
+					ID:    name + "-id",
+					Name:  name,
+					State: ffv1.HostState_HOST_STATE_READY,
+				},
+			})
+		}
+	})
+
+	list := func(filter string) ([]string, error) {
+		response, err := objects.HostsServer().List(ctx, ffv1.HostsListRequest_builder{
+			Filter: &filter,
+		}.Build())
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, item := range response.GetItems() {
+			names = append(names, item.GetMetadata().GetName())
+		}
+		Expect(response.GetTotal()).To(BeNumerically("==", len(names)))
+		return names, nil
+	}
+
+	It("Returns all the objects when there is no filter", func() {
+		names, err := list("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(Equal([]string{"web-1", "web-2", "db-1"}))
+	})
+
+	It("Evaluates the filter for each object", func() {
+		names, err := list(`this.metadata.name.startsWith("web")`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(Equal([]string{"web-1", "web-2"}))
+	})
+
+	It("Evaluates filters that use identifiers and names like the CLI does", func() {
+		names, err := list(`this.id == "db-1" || this.metadata.name == "db-1"`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(Equal([]string{"db-1"}))
+	})
+
+	It("Doesn't match by substring", func() {
+		names, err := list(`this.metadata.name == "web"`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(BeEmpty())
+	})
+
+	It("Rejects filters that can't be compiled", func() {
+		_, err := list(`this.metadata.name ==`)
+		Expect(grpcstatus.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("Rejects filters that use unknown fields", func() {
+		_, err := list(`this.junk == "web"`)
+		Expect(grpcstatus.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("Rejects filters that aren't boolean", func() {
+		_, err := list(`this.metadata.name`)
+		Expect(grpcstatus.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("Filters objects that aren't kept by the mock servers", func() {
+		templates := []*ffv1.ComputeInstanceTemplate{
+			ffv1.ComputeInstanceTemplate_builder{Id: "small"}.Build(),
+			ffv1.ComputeInstanceTemplate_builder{Id: "large"}.Build(),
+		}
+		result, err := FilterObjects(templates, `this.id == "large"`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].GetId()).To(Equal("large"))
+	})
+
+	It("Evaluates filters for events", func() {
+		clusterEvent := (&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			Cluster: &ClusterEventData{
+				ID: "my-cluster",
+			},
+		}).ToProtoEvent()
+		templateEvent := (&ScenarioEvent{
+			Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+			ClusterTemplate: &ClusterTemplateEventData{
+				ID: "my-cluster",
+			},
+		}).ToProtoEvent()
+		filter, err := newMockFilter(
+			"event",
+			&eventsv1.Event{},
+			`has(event.cluster) && event.cluster.id == "my-cluster"`,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		matches, err := filter.matches(clusterEvent)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matches).To(BeTrue())
+		matches, err = filter.matches(templateEvent)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matches).To(BeFalse())
+	})
+})
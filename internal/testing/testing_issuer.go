@@ -0,0 +1,486 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IssuerBuilder contains the data and logic needed to create a fake OAuth token issuer. Don't create instances of this
+// type directly, use the NewIssuer function instead.
+type IssuerBuilder struct {
+	logger      *slog.Logger
+	address     string
+	clients     map[string]string
+	autoApprove bool
+	tokenLife   time.Duration
+}
+
+// Issuer is a minimal OAuth token issuer intended for tests and for the standalone test server. It supports the
+// device and client credentials flows, refreshing and revoking tokens, and issues JSON web tokens signed with a key
+// generated when it is created. It serves the OAuth and OpenID Connect discovery documents, so it can be used as the
+// issuer of the login command. Don't create instances of this type directly, use the NewIssuer function instead.
+type Issuer struct {
+	logger      *slog.Logger
+	listener    net.Listener
+	server      *http.Server
+	url         string
+	key         *rsa.PrivateKey
+	keyId       string
+	clients     map[string]string
+	autoApprove bool
+	tokenLife   time.Duration
+	lock        *sync.Mutex
+	devices     map[string]*issuerDevice
+	revoked     map[string]bool
+}
+
+// issuerDevice contains the state of a device authorization request.
+type issuerDevice struct {
+	clientId string
+	userCode string
+	approved bool
+	expiry   time.Time
+}
+
+// Grant types supported by the issuer:
+const (
+	issuerDeviceGrant      = "urn:ietf:params:oauth:grant-type:device_code"
+	issuerCredentialsGrant = "client_credentials"
+	issuerRefreshGrant     = "refresh_token"
+)
+
+// NewIssuer creates a builder that can then be used to configure and create a fake OAuth token issuer.
+func NewIssuer() *IssuerBuilder {
+	return &IssuerBuilder{
+		address:   "127.0.0.1:0",
+		clients:   map[string]string{},
+		tokenLife: 5 * time.Minute,
+	}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *IssuerBuilder) SetLogger(value *slog.Logger) *IssuerBuilder {
+	b.logger = value
+	return b
+}
+
+// SetAddress sets the address where the issuer will listen. This is optional, the default is a randomly selected port
+// of the local host.
+func (b *IssuerBuilder) SetAddress(value string) *IssuerBuilder {
+	b.address = value
+	return b
+}
+
+// AddClient adds a client that is allowed to request tokens. Clients without secret can only use the device flow,
+// clients with secret can also use the client credentials flow. This is mandatory, at least one client is required.
+func (b *IssuerBuilder) AddClient(id, secret string) *IssuerBuilder {
+	b.clients[id] = secret
+	return b
+}
+
+// SetAutoApprove sets a flag that indicates if device authorization requests are approved automatically. This is
+// optional, the default is to wait till they are approved using the verification URI or the Approve method.
+func (b *IssuerBuilder) SetAutoApprove(value bool) *IssuerBuilder {
+	b.autoApprove = value
+	return b
+}
+
+// SetTokenLife sets the life of the access tokens. This is optional, the default is five minutes. Refresh tokens don't
+// expire, but can be revoked.
+func (b *IssuerBuilder) SetTokenLife(value time.Duration) *IssuerBuilder {
+	b.tokenLife = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new issuer. Note that the issuer will not accept requests
+// till the Start method is called.
+func (b *IssuerBuilder) Build() (result *Issuer, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if len(b.clients) == 0 {
+		err = errors.New("at least one client is mandatory")
+		return
+	}
+	if b.tokenLife <= 0 {
+		err = fmt.Errorf("token life should be positive, but it is %s", b.tokenLife)
+		return
+	}
+
+	// Generate the signing key:
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		err = fmt.Errorf("failed to generate signing key: %w", err)
+		return
+	}
+
+	// Create the listener:
+	listener, err := net.Listen("tcp", b.address)
+	if err != nil {
+		err = fmt.Errorf("failed to listen in '%s': %w", b.address, err)
+		return
+	}
+
+	// Create and populate the object:
+	clients := make(map[string]string, len(b.clients))
+	for id, secret := range b.clients {
+		clients[id] = secret
+	}
+	result = &Issuer{
+		logger:      b.logger,
+		listener:    listener,
+		url:         fmt.Sprintf("http://%s", listener.Addr()),
+		key:         key,
+		keyId:       issuerRandom(8),
+		clients:     clients,
+		autoApprove: b.autoApprove,
+		tokenLife:   b.tokenLife,
+		lock:        &sync.Mutex{},
+		devices:     map[string]*issuerDevice{},
+		revoked:     map[string]bool{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/oauth-authorization-server", result.serveMetadata)
+	mux.HandleFunc("GET /.well-known/openid-configuration", result.serveMetadata)
+	mux.HandleFunc("GET /jwks", result.serveKeys)
+	mux.HandleFunc("POST /device", result.serveDevice)
+	mux.HandleFunc("GET /device/verify", result.serveVerify)
+	mux.HandleFunc("POST /token", result.serveToken)
+	mux.HandleFunc("POST /revoke", result.serveRevoke)
+	result.server = &http.Server{
+		Handler: mux,
+	}
+	return
+}
+
+// URL returns the URL of the issuer, the one that should be used as the value of the 'iss' claim and for discovery.
+func (i *Issuer) URL() string {
+	return i.url
+}
+
+// Start starts serving requests in a separate goroutine.
+func (i *Issuer) Start() {
+	go func() {
+		err := i.server.Serve(i.listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			i.logger.Error(
+				"Issuer failed",
+				slog.Any("error", err),
+			)
+		}
+	}()
+}
+
+// Stop stops the issuer, closing all connections and releasing all the resources it was using.
+func (i *Issuer) Stop() {
+	i.server.Close()
+}
+
+// Approve approves the pending device authorization request that has the given user code.
+func (i *Issuer) Approve(userCode string) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, device := range i.devices {
+		if device.userCode == userCode {
+			device.approved = true
+			return nil
+		}
+	}
+	return fmt.Errorf("there is no pending request with user code '%s'", userCode)
+}
+
+// Verify checks that the given access token was issued by this issuer, that it hasn't expired and that it hasn't been
+// revoked. It returns the claims of the token.
+func (i *Issuer) Verify(token string) (result jwt.MapClaims, err error) {
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(
+		token, claims,
+		func(*jwt.Token) (any, error) {
+			return &i.key.PublicKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(i.url),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		err = fmt.Errorf("invalid token: %w", err)
+		return
+	}
+	if claims["typ"] != "Bearer" {
+		err = fmt.Errorf("token type should be 'Bearer', but it is '%v'", claims["typ"])
+		return
+	}
+	if i.isRevoked(claims) {
+		err = errors.New("token has been revoked")
+		return
+	}
+	result = claims
+	return
+}
+
+func (i *Issuer) serveMetadata(w http.ResponseWriter, r *http.Request) {
+	i.sendJson(w, http.StatusOK, map[string]any{
+		"issuer":                        i.url,
+		"token_endpoint":                i.url + "/token",
+		"device_authorization_endpoint": i.url + "/device",
+		"revocation_endpoint":           i.url + "/revoke",
+		"jwks_uri":                      i.url + "/jwks",
+		"scopes_supported":              []string{"openid"},
+		"grant_types_supported":         []string{issuerDeviceGrant, issuerCredentialsGrant, issuerRefreshGrant},
+	})
+}
+
+func (i *Issuer) serveKeys(w http.ResponseWriter, r *http.Request) {
+	encode := func(value *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(value.Bytes())
+	}
+	i.sendJson(w, http.StatusOK, map[string]any{
+		"keys": []any{
+			map[string]any{
+				"kty": "RSA",
+				"use": "sig",
+				"alg": jwt.SigningMethodRS256.Alg(),
+				"kid": i.keyId,
+				"n":   encode(i.key.PublicKey.N),
+				"e":   encode(big.NewInt(int64(i.key.PublicKey.E))),
+			},
+		},
+	})
+}
+
+func (i *Issuer) serveDevice(w http.ResponseWriter, r *http.Request) {
+	clientId := r.PostFormValue("client_id")
+	if _, ok := i.clients[clientId]; !ok {
+		i.sendError(w, http.StatusBadRequest, "invalid_client", fmt.Sprintf("unknown client '%s'", clientId))
+		return
+	}
+	deviceCode := issuerRandom(32)
+	userCode := strings.ToUpper(issuerRandom(4))
+	i.lock.Lock()
+	i.devices[deviceCode] = &issuerDevice{
+		clientId: clientId,
+		userCode: userCode,
+		approved: i.autoApprove,
+		expiry:   time.Now().Add(5 * time.Minute),
+	}
+	i.lock.Unlock()
+	i.logger.Info(
+		"Started device authorization",
+		slog.String("client_id", clientId),
+		slog.String("user_code", userCode),
+		slog.Bool("approved", i.autoApprove),
+	)
+	i.sendJson(w, http.StatusOK, map[string]any{
+		"device_code":               deviceCode,
+		"user_code":                 userCode,
+		"verification_uri":          i.url + "/device/verify",
+		"verification_uri_complete": i.url + "/device/verify?user_code=" + userCode,
+		"expires_in":                300,
+		"interval":                  1,
+	})
+}
+
+func (i *Issuer) serveVerify(w http.ResponseWriter, r *http.Request) {
+	userCode := r.URL.Query().Get("user_code")
+	err := i.Approve(userCode)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "Approved request with user code '%s', you can close this window.\n", userCode)
+}
+
+func (i *Issuer) serveToken(w http.ResponseWriter, r *http.Request) {
+	switch grantType := r.PostFormValue("grant_type"); grantType {
+	case issuerDeviceGrant:
+		i.serveDeviceToken(w, r)
+	case issuerCredentialsGrant:
+		i.serveCredentialsToken(w, r)
+	case issuerRefreshGrant:
+		i.serveRefreshToken(w, r)
+	default:
+		i.sendError(
+			w, http.StatusBadRequest, "unsupported_grant_type",
+			fmt.Sprintf("grant type '%s' isn't supported", grantType),
+		)
+	}
+}
+
+func (i *Issuer) serveDeviceToken(w http.ResponseWriter, r *http.Request) {
+	deviceCode := r.PostFormValue("device_code")
+	i.lock.Lock()
+	device, ok := i.devices[deviceCode]
+	switch {
+	case !ok || device.clientId != r.PostFormValue("client_id"):
+		i.lock.Unlock()
+		i.sendError(w, http.StatusBadRequest, "invalid_grant", "unknown device code")
+		return
+	case time.Now().After(device.expiry):
+		delete(i.devices, deviceCode)
+		i.lock.Unlock()
+		i.sendError(w, http.StatusBadRequest, "expired_token", "device code has expired")
+		return
+	case !device.approved:
+		i.lock.Unlock()
+		i.sendError(w, http.StatusBadRequest, "authorization_pending", "request hasn't been approved yet")
+		return
+	}
+	delete(i.devices, deviceCode)
+	i.lock.Unlock()
+	i.sendTokens(w, device.clientId, "user")
+}
+
+func (i *Issuer) serveCredentialsToken(w http.ResponseWriter, r *http.Request) {
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientId = r.PostFormValue("client_id")
+		clientSecret = r.PostFormValue("client_secret")
+	}
+	secret, ok := i.clients[clientId]
+	if !ok || secret == "" || secret != clientSecret {
+		i.sendError(w, http.StatusBadRequest, "invalid_client", "invalid client credentials")
+		return
+	}
+	i.sendTokens(w, clientId, clientId)
+}
+
+func (i *Issuer) serveRefreshToken(w http.ResponseWriter, r *http.Request) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(
+		r.PostFormValue("refresh_token"), claims,
+		func(*jwt.Token) (any, error) {
+			return &i.key.PublicKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(i.url),
+	)
+	if err != nil || claims["typ"] != "Refresh" || i.isRevoked(claims) {
+		i.sendError(w, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+		return
+	}
+	clientId, _ := claims["azp"].(string)
+	subject, _ := claims["sub"].(string)
+	i.sendTokens(w, clientId, subject)
+}
+
+func (i *Issuer) serveRevoke(w http.ResponseWriter, r *http.Request) {
+	// According to RFC 7009 invalid tokens are ignored, the response is a success anyhow:
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(r.PostFormValue("token"), claims)
+	if err == nil {
+		if id, ok := claims["jti"].(string); ok {
+			i.lock.Lock()
+			i.revoked[id] = true
+			i.lock.Unlock()
+			i.logger.Info(
+				"Revoked token",
+				slog.String("jti", id),
+				slog.Any("typ", claims["typ"]),
+			)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (i *Issuer) sendTokens(w http.ResponseWriter, clientId, subject string) {
+	now := time.Now()
+	makeToken := func(typ string, expiry time.Time) (string, error) {
+		claims := jwt.MapClaims{
+			"iss": i.url,
+			"sub": subject,
+			"azp": clientId,
+			"typ": typ,
+			"iat": now.Unix(),
+			"jti": issuerRandom(16),
+		}
+		if !expiry.IsZero() {
+			claims["exp"] = expiry.Unix()
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = i.keyId
+		return token.SignedString(i.key)
+	}
+	accessToken, err := makeToken("Bearer", now.Add(i.tokenLife))
+	if err != nil {
+		i.sendError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	refreshToken, err := makeToken("Refresh", time.Time{})
+	if err != nil {
+		i.sendError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	i.logger.Info(
+		"Issued tokens",
+		slog.String("client_id", clientId),
+		slog.String("subject", subject),
+	)
+	i.sendJson(w, http.StatusOK, map[string]any{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(i.tokenLife.Seconds()),
+	})
+}
+
+func (i *Issuer) isRevoked(claims jwt.MapClaims) bool {
+	id, _ := claims["jti"].(string)
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.revoked[id]
+}
+
+func (i *Issuer) sendError(w http.ResponseWriter, status int, code, description string) {
+	i.sendJson(w, status, map[string]any{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func (i *Issuer) sendJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		i.logger.Error(
+			"Failed to send response",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// issuerRandom generates a random string containing the given number of random bytes, encoded in hexadecimal.
+func issuerRandom(size int) string {
+	data := make([]byte, size)
+	_, _ = rand.Read(data)
+	return fmt.Sprintf("%x", data)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/osac-project/fulfillment-common/auth"
+	"github.com/osac-project/fulfillment-common/oauth"
+)
+
+var _ = Describe("Issuer", func() {
+	var (
+		ctx    context.Context
+		issuer *Issuer
+	)
+
+	BeforeEach(func() {
+		var err error
+		ctx = context.Background()
+		issuer, err = NewIssuer().
+			SetLogger(logger).
+			AddClient("my-public", "").
+			AddClient("my-private", "my-secret").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		issuer.Start()
+		DeferCleanup(issuer.Stop)
+	})
+
+	makeSource := func(flow oauth.Flow, clientId, clientSecret string,
+		listener oauth.FlowListener) *oauth.TokenSource {
+		store, err := auth.NewMemoryTokenStore().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		source, err := oauth.NewTokenSource().
+			SetLogger(logger).
+			SetStore(store).
+			SetIssuer(issuer.URL()).
+			SetFlow(flow).
+			SetClientId(clientId).
+			SetClientSecret(clientSecret).
+			SetListener(listener).
+			SetInteractive(listener != nil).
+			SetPollInterval(10 * time.Millisecond).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return source
+	}
+
+	It("Can't be created without clients", func() {
+		_, err := NewIssuer().
+			SetLogger(logger).
+			Build()
+		Expect(err).To(MatchError("at least one client is mandatory"))
+	})
+
+	It("Issues tokens with the device flow", func() {
+		listener := &issuerTestListener{
+			issuer: issuer,
+		}
+		source := makeSource(oauth.DeviceFlow, "my-public", "", listener)
+		token, err := source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(listener.userCode).ToNot(BeEmpty())
+		claims, err := issuer.Verify(token.Access)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claims).To(HaveKeyWithValue("sub", "user"))
+		Expect(claims).To(HaveKeyWithValue("azp", "my-public"))
+		Expect(token.Refresh).ToNot(BeEmpty())
+	})
+
+	It("Issues tokens with the client credentials flow", func() {
+		source := makeSource(oauth.CredentialsFlow, "my-private", "my-secret", nil)
+		token, err := source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		claims, err := issuer.Verify(token.Access)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claims).To(HaveKeyWithValue("sub", "my-private"))
+	})
+
+	It("Rejects wrong client credentials", func() {
+		source := makeSource(oauth.CredentialsFlow, "my-private", "junk", nil)
+		_, err := source.Token(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Approves device requests automatically if configured", func() {
+		auto, err := NewIssuer().
+			SetLogger(logger).
+			AddClient("my-public", "").
+			SetAutoApprove(true).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		auto.Start()
+		DeferCleanup(auto.Stop)
+		response, err := http.PostForm(auto.URL()+"/device", url.Values{
+			"client_id": {"my-public"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		var device struct {
+			DeviceCode string `json:"device_code"`
+		}
+		err = json.NewDecoder(response.Body).Decode(&device)
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+		response, err = http.PostForm(auto.URL()+"/token", url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"client_id":   {"my-public"},
+			"device_code": {device.DeviceCode},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		response.Body.Close()
+	})
+
+	It("Refreshes and revokes tokens", func() {
+		// Get the initial tokens:
+		source := makeSource(oauth.CredentialsFlow, "my-private", "my-secret", nil)
+		first, err := source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Refresh them:
+		response, err := http.PostForm(issuer.URL()+"/token", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {first.Refresh},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		response.Body.Close()
+
+		// Revoke the access token:
+		response, err = http.PostForm(issuer.URL()+"/revoke", url.Values{
+			"token": {first.Access},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		response.Body.Close()
+		_, err = issuer.Verify(first.Access)
+		Expect(err).To(MatchError("token has been revoked"))
+
+		// Revoke the refresh token and check that it can no longer be used:
+		response, err = http.PostForm(issuer.URL()+"/revoke", url.Values{
+			"token": {first.Refresh},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+		response, err = http.PostForm(issuer.URL()+"/token", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {first.Refresh},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		response.Body.Close()
+	})
+
+	It("Rejects tokens from other issuers", func() {
+		_, err := issuer.Verify(MakeTokenString("Bearer", time.Minute))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// issuerTestListener is a flow listener that approves the device requests as soon as they start.
+type issuerTestListener struct {
+	issuer   *Issuer
+	userCode string
+}
+
+func (l *issuerTestListener) Start(ctx context.Context, event oauth.FlowStartEvent) error {
+	l.userCode = event.UserCode
+	return l.issuer.Approve(event.UserCode)
+}
+
+func (l *issuerTestListener) End(ctx context.Context, event oauth.FlowEndEvent) error {
+	return nil
+}
@@ -16,7 +16,6 @@ package testing
 import (
 	"context"
 	"net"
-	"strings"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
 	. "github.com/onsi/gomega"
@@ -217,6 +216,96 @@ func (s *HostPoolsServerFuncs) Update(ctx context.Context,
 	return
 }
 
+// Make sure that we implement the interface.
+var _ ffv1.HostClassesServer = (*HostClassesServerFuncs)(nil)
+
+// HostClassesServerFuncs is an implementation of the host classes server that uses configurable functions to
+// implement the methods.
+type HostClassesServerFuncs struct {
+	ffv1.UnimplementedHostClassesServer
+
+	CreateFunc func(context.Context, *ffv1.HostClassesCreateRequest) (*ffv1.HostClassesCreateResponse, error)
+	DeleteFunc func(context.Context, *ffv1.HostClassesDeleteRequest) (*ffv1.HostClassesDeleteResponse, error)
+	GetFunc    func(context.Context, *ffv1.HostClassesGetRequest) (*ffv1.HostClassesGetResponse, error)
+	ListFunc   func(context.Context, *ffv1.HostClassesListRequest) (*ffv1.HostClassesListResponse, error)
+	UpdateFunc func(context.Context, *ffv1.HostClassesUpdateRequest) (*ffv1.HostClassesUpdateResponse, error)
+}
+
+func (s *HostClassesServerFuncs) Create(ctx context.Context,
+	request *ffv1.HostClassesCreateRequest) (response *ffv1.HostClassesCreateResponse, err error) {
+	response, err = s.CreateFunc(ctx, request)
+	return
+}
+
+func (s *HostClassesServerFuncs) Delete(ctx context.Context,
+	request *ffv1.HostClassesDeleteRequest) (response *ffv1.HostClassesDeleteResponse, err error) {
+	response, err = s.DeleteFunc(ctx, request)
+	return
+}
+
+func (s *HostClassesServerFuncs) Get(ctx context.Context,
+	request *ffv1.HostClassesGetRequest) (response *ffv1.HostClassesGetResponse, err error) {
+	response, err = s.GetFunc(ctx, request)
+	return
+}
+
+func (s *HostClassesServerFuncs) List(ctx context.Context,
+	request *ffv1.HostClassesListRequest) (response *ffv1.HostClassesListResponse, err error) {
+	response, err = s.ListFunc(ctx, request)
+	return
+}
+
+func (s *HostClassesServerFuncs) Update(ctx context.Context,
+	request *ffv1.HostClassesUpdateRequest) (response *ffv1.HostClassesUpdateResponse, err error) {
+	response, err = s.UpdateFunc(ctx, request)
+	return
+}
+
+// Make sure that we implement the interface.
+var _ ffv1.ClusterTemplatesServer = (*ClusterTemplatesServerFuncs)(nil)
+
+// ClusterTemplatesServerFuncs is an implementation of the cluster templates server that uses configurable functions
+// to implement the methods.
+type ClusterTemplatesServerFuncs struct {
+	ffv1.UnimplementedClusterTemplatesServer
+
+	CreateFunc func(context.Context, *ffv1.ClusterTemplatesCreateRequest) (*ffv1.ClusterTemplatesCreateResponse, error)
+	DeleteFunc func(context.Context, *ffv1.ClusterTemplatesDeleteRequest) (*ffv1.ClusterTemplatesDeleteResponse, error)
+	GetFunc    func(context.Context, *ffv1.ClusterTemplatesGetRequest) (*ffv1.ClusterTemplatesGetResponse, error)
+	ListFunc   func(context.Context, *ffv1.ClusterTemplatesListRequest) (*ffv1.ClusterTemplatesListResponse, error)
+	UpdateFunc func(context.Context, *ffv1.ClusterTemplatesUpdateRequest) (*ffv1.ClusterTemplatesUpdateResponse, error)
+}
+
+func (s *ClusterTemplatesServerFuncs) Create(ctx context.Context,
+	request *ffv1.ClusterTemplatesCreateRequest) (response *ffv1.ClusterTemplatesCreateResponse, err error) {
+	response, err = s.CreateFunc(ctx, request)
+	return
+}
+
+func (s *ClusterTemplatesServerFuncs) Delete(ctx context.Context,
+	request *ffv1.ClusterTemplatesDeleteRequest) (response *ffv1.ClusterTemplatesDeleteResponse, err error) {
+	response, err = s.DeleteFunc(ctx, request)
+	return
+}
+
+func (s *ClusterTemplatesServerFuncs) Get(ctx context.Context,
+	request *ffv1.ClusterTemplatesGetRequest) (response *ffv1.ClusterTemplatesGetResponse, err error) {
+	response, err = s.GetFunc(ctx, request)
+	return
+}
+
+func (s *ClusterTemplatesServerFuncs) List(ctx context.Context,
+	request *ffv1.ClusterTemplatesListRequest) (response *ffv1.ClusterTemplatesListResponse, err error) {
+	response, err = s.ListFunc(ctx, request)
+	return
+}
+
+func (s *ClusterTemplatesServerFuncs) Update(ctx context.Context,
+	request *ffv1.ClusterTemplatesUpdateRequest) (response *ffv1.ClusterTemplatesUpdateResponse, err error) {
+	response, err = s.UpdateFunc(ctx, request)
+	return
+}
+
 // Make sure that we implement the interface.
 var _ ffv1.ComputeInstancesServer = (*ComputeInstancesServerFuncs)(nil)
 
@@ -337,67 +426,11 @@ func GetEventObjectID(event *eventsv1.Event) string {
 	return ""
 }
 
-// Helper function to check if an event matches the filter
-func MatchesFilter(event *eventsv1.Event, filter string) bool {
-	// Empty filter - send all events
-	if filter == "" {
-		return true
-	}
-
-	// Determine what type the filter is for
-	// Check for cluster_template first (more specific) to avoid substring issues
-	isClusterTemplateFilter := strings.Contains(filter, "event.cluster_template")
-	isClusterFilter := strings.Contains(filter, "event.cluster") && !isClusterTemplateFilter
-
-	// Check if the event type matches the filter type
-	switch event.Payload.(type) {
-	case *eventsv1.Event_Cluster:
-		if !isClusterFilter {
-			return false
-		}
-		// If filter is just a type check, send all cluster events
-		if filter == "has(event.cluster)" {
-			return true
-		}
-	case *eventsv1.Event_ClusterTemplate:
-		if !isClusterTemplateFilter {
-			return false
-		}
-		// If filter is just a type check, send all cluster template events
-		if filter == "has(event.cluster_template)" {
-			return true
-		}
-	default:
-		return false
-	}
-
-	// Extract ID and name from the event
-	var id, name string
-	switch payload := event.Payload.(type) {
-	case *eventsv1.Event_Cluster:
-		if payload.Cluster != nil {
-			id = payload.Cluster.Id
-			if payload.Cluster.Metadata != nil {
-				name = payload.Cluster.Metadata.Name
-			}
-		}
-	case *eventsv1.Event_ClusterTemplate:
-		if payload.ClusterTemplate != nil {
-			id = payload.ClusterTemplate.Id
-			if payload.ClusterTemplate.Metadata != nil {
-				name = payload.ClusterTemplate.Metadata.Name
-			}
-		}
-	}
-
-	// Check if filter contains the specific ID or name
-	return strings.Contains(filter, id) || strings.Contains(filter, name)
-}
-
-// Helper function to send an event if it matches the filter
-func SendEventIfMatches(event *eventsv1.Event, filter string, stream eventsv1.Events_WatchServer) error {
-	if MatchesFilter(event, filter) {
-		return stream.Send(&eventsv1.EventsWatchResponse{Event: event})
+// sendEventIfMatches sends the event to the stream if it matches the filter.
+func sendEventIfMatches(event *eventsv1.Event, filter *mockFilter, stream eventsv1.Events_WatchServer) error {
+	matches, err := filter.matches(event)
+	if err != nil || !matches {
+		return err
 	}
-	return nil
+	return stream.Send(&eventsv1.EventsWatchResponse{Event: event})
 }
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package timing
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/osac-project/fulfillment-common/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// verboseFlagName is the name of the flag that enables the footer with the time and the number of calls.
+const verboseFlagName = "verbose"
+
+// AddFlags adds the flag that enables the footer with the time and the number of calls to the given flag set.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.Bool(
+		verboseFlagName,
+		false,
+		"Print after the command finishes the time that it took and the number of calls to the server.",
+	)
+}
+
+// Report writes to the log the time that the given command took and the number of calls that it did, and if the
+// verbose flag is set also writes a one line footer with that information to the given writer. It does nothing if
+// the context of the command doesn't contain statistics, which happens when the command fails before they are created.
+func Report(writer io.Writer, cmd *cobra.Command, err error) {
+	if cmd == nil {
+		return
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		return
+	}
+	stats := StatsFromContext(ctx)
+	if stats == nil {
+		return
+	}
+	elapsed := stats.Elapsed()
+	logger := logging.LoggerFromContext(ctx)
+	logger.InfoContext(
+		ctx,
+		"Command finished",
+		slog.String("command", cmd.CommandPath()),
+		slog.Duration("elapsed", elapsed),
+		slog.Int64("calls", stats.Calls()),
+		slog.Duration("call_time", stats.CallTime()),
+		slog.Bool("success", err == nil),
+	)
+	verbose, _ := cmd.Flags().GetBool(verboseFlagName)
+	if verbose {
+		fmt.Fprintln(writer, Footer(err == nil, elapsed, stats.Calls(), stats.CallTime()))
+	}
+}
+
+// Footer returns the text of the footer, for example 'done in 1.8s, 4 RPCs (1.2s waiting for the server)'.
+func Footer(success bool, elapsed time.Duration, calls int64, callTime time.Duration) string {
+	result := "done"
+	if !success {
+		result = "failed"
+	}
+	result = fmt.Sprintf("%s in %s", result, formatDuration(elapsed))
+	switch calls {
+	case 0:
+		return result + ", no RPCs"
+	case 1:
+		result += ", 1 RPC"
+	default:
+		result += fmt.Sprintf(", %d RPCs", calls)
+	}
+	return fmt.Sprintf("%s (%s waiting for the server)", result, formatDuration(callTime))
+}
+
+// formatDuration rounds the duration so that it is easy to read: to milliseconds when it is less than a second, and
+// to tenths of a second otherwise.
+func formatDuration(value time.Duration) string {
+	if value < time.Second {
+		return value.Round(time.Millisecond).String()
+	}
+	return value.Round(100 * time.Millisecond).String()
+}
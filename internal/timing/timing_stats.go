@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package timing contains the logic used to measure the time that commands take, and the number of remote procedure
+// calls that they do, so that users can tell if a slow command is caused by the server or by the tool itself.
+package timing
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Stats collects the time and the number of calls done during the execution of a command. It is safe to use from
+// multiple goroutines. Don't create instances of this type directly, use the NewStats function instead.
+type Stats struct {
+	start    time.Time
+	calls    atomic.Int64
+	callTime atomic.Int64
+}
+
+// NewStats creates a new object to collect statistics, and starts measuring the time.
+func NewStats() *Stats {
+	return &Stats{
+		start: time.Now(),
+	}
+}
+
+// Elapsed returns the time elapsed since the statistics were created.
+func (s *Stats) Elapsed() time.Duration {
+	return time.Since(s.start)
+}
+
+// Calls returns the number of calls done.
+func (s *Stats) Calls() int64 {
+	return s.calls.Load()
+}
+
+// CallTime returns the total time spent waiting for the responses of calls. For streaming calls this only includes
+// the time needed to start the stream.
+func (s *Stats) CallTime() time.Duration {
+	return time.Duration(s.callTime.Load())
+}
+
+// UnaryClient is the unary client interceptor function that counts the calls and measures their time.
+func (s *Stats) UnaryClient(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	defer s.record(start)
+	return invoker(ctx, method, request, response, conn, opts...)
+}
+
+// StreamClient is the stream client interceptor function that counts the calls and measures the time needed to start
+// the streams.
+func (s *Stats) StreamClient(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	defer s.record(start)
+	return streamer(ctx, desc, conn, method, opts...)
+}
+
+func (s *Stats) record(start time.Time) {
+	s.calls.Add(1)
+	s.callTime.Add(int64(time.Since(start)))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package timing
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("Timing", func() {
+	DescribeTable("Footer",
+		func(success bool, elapsed time.Duration, calls int64, callTime time.Duration, expected string) {
+			Expect(Footer(success, elapsed, calls, callTime)).To(Equal(expected))
+		},
+		Entry("no calls", true, 5*time.Millisecond, int64(0), time.Duration(0), "done in 5ms, no RPCs"),
+		Entry("one call", true, 250*time.Millisecond, int64(1), 200*time.Millisecond,
+			"done in 250ms, 1 RPC (200ms waiting for the server)"),
+		Entry("multiple calls", true, 1812*time.Millisecond, int64(4), 1234*time.Millisecond,
+			"done in 1.8s, 4 RPCs (1.2s waiting for the server)"),
+		Entry("failure", false, 3*time.Second, int64(2), 3*time.Second,
+			"failed in 3s, 2 RPCs (3s waiting for the server)"),
+	)
+
+	It("Counts the calls", func() {
+		stats := NewStats()
+		invoker := func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+			opts ...grpc.CallOption) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}
+		for range 3 {
+			err := stats.UnaryClient(context.Background(), "/my.Service/Get", nil, nil, nil, invoker)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(stats.Calls()).To(BeNumerically("==", 3))
+		Expect(stats.CallTime()).To(BeNumerically(">=", 30*time.Millisecond))
+		Expect(stats.Elapsed()).To(BeNumerically(">=", stats.CallTime()))
+	})
+
+	It("Returns nil when the context doesn't contain statistics", func() {
+		Expect(StatsFromContext(context.Background())).To(BeNil())
+		stats := NewStats()
+		Expect(StatsFromContext(StatsIntoContext(context.Background(), stats))).To(BeIdenticalTo(stats))
+	})
+})
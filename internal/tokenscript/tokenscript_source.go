@@ -0,0 +1,259 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package tokenscript contains a token source that runs a shell script to generate the tokens. It limits the time
+// that the script can run and the environment variables that it receives, and reports the errors written by the script
+// when it fails.
+package tokenscript
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/osac-project/fulfillment-common/auth"
+
+	"github.com/osac-project/fulfillment-cli/internal/shell"
+)
+
+// DefaultTimeout is the time that the script can run when no other timeout is configured.
+const DefaultTimeout = 30 * time.Second
+
+// maxStderr is the maximum number of bytes of the standard error of the script that are included in error messages.
+// When the script writes more only the end is included, as that is usually where the cause of the failure is.
+const maxStderr = 4096
+
+// waitDelay is the time to wait for the standard output and error of the script to be closed after it finishes or is
+// killed. This prevents hangs when the script starts background processes that inherit them.
+const waitDelay = time.Second
+
+// TokenSourceBuilder contains the data and logic needed to create a token source that runs a script.
+//
+// Don't create instances of this type directly, use the NewTokenSource function instead.
+type TokenSourceBuilder struct {
+	logger  *slog.Logger
+	script  string
+	shell   string
+	store   auth.TokenStore
+	timeout time.Duration
+}
+
+type tokenSource struct {
+	logger      *slog.Logger
+	script      string
+	shell       string
+	store       auth.TokenStore
+	timeout     time.Duration
+	tokenParser *jwt.Parser
+}
+
+// NewTokenSource creates a builder that can then be used to configure and create a token source that runs a script.
+func NewTokenSource() *TokenSourceBuilder {
+	return &TokenSourceBuilder{
+		timeout: DefaultTimeout,
+	}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *TokenSourceBuilder) SetLogger(value *slog.Logger) *TokenSourceBuilder {
+	b.logger = value
+	return b
+}
+
+// SetScript sets the shell script that generates the tokens. This is mandatory.
+func (b *TokenSourceBuilder) SetScript(value string) *TokenSourceBuilder {
+	b.script = value
+	return b
+}
+
+// SetShell sets the shell used to run the script. It can be a POSIX shell like 'bash', the Windows command
+// interpreter 'cmd.exe' or PowerShell. This is optional, by default it is the value of the 'SHELL' environment
+// variable or '/bin/sh', and in Windows the value of the 'ComSpec' environment variable, usually 'cmd.exe'.
+func (b *TokenSourceBuilder) SetShell(value string) *TokenSourceBuilder {
+	b.shell = value
+	return b
+}
+
+// SetStore sets the store used to load and save the tokens. This is mandatory.
+func (b *TokenSourceBuilder) SetStore(value auth.TokenStore) *TokenSourceBuilder {
+	b.store = value
+	return b
+}
+
+// SetTimeout sets the maximum time that the script can run. The default is 30 seconds. Zero means no limit.
+func (b *TokenSourceBuilder) SetTimeout(value time.Duration) *TokenSourceBuilder {
+	b.timeout = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new token source.
+func (b *TokenSourceBuilder) Build() (result auth.TokenSource, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.script == "" {
+		err = errors.New("token generation script is mandatory")
+		return
+	}
+	if b.store == nil {
+		err = errors.New("token store is mandatory")
+		return
+	}
+	if b.timeout < 0 {
+		err = fmt.Errorf("token script timeout should be zero or positive, but it is %s", b.timeout)
+		return
+	}
+
+	// Select the shell:
+	selected := b.shell
+	if selected == "" {
+		selected = shell.System()
+	}
+
+	// Create and populate the object:
+	result = &tokenSource{
+		logger:  b.logger,
+		script:  b.script,
+		shell:   selected,
+		store:   b.store,
+		timeout: b.timeout,
+		tokenParser: jwt.NewParser(
+			jwt.WithValidMethods([]string{
+				"RS256",
+			}),
+			jwt.WithIssuedAt(),
+		),
+	}
+	return
+}
+
+// Token is the implementation of the auth.TokenSource interface.
+func (s *tokenSource) Token(ctx context.Context) (result *auth.Token, err error) {
+	// Use the stored token if it hasn't expired yet. Note that tokens that aren't JWTs are never saved, as there is
+	// no way to check their expiration.
+	existing, err := s.store.Load(ctx)
+	if err != nil {
+		return
+	}
+	if existing != nil && existing.Access != "" {
+		parsed, parseErr := s.parseToken(existing.Access)
+		if parseErr == nil && !parsed.Expiry.Before(time.Now()) {
+			result = parsed
+			return
+		}
+	}
+
+	// Generate a new token:
+	raw, err := s.runScript(ctx)
+	if err != nil {
+		return
+	}
+	parsed, parseErr := s.parseToken(raw)
+	if parseErr != nil {
+		result = &auth.Token{
+			Access: raw,
+		}
+		return
+	}
+	err = s.store.Save(ctx, parsed)
+	if err != nil {
+		return
+	}
+	result = parsed
+	return
+}
+
+func (s *tokenSource) runScript(ctx context.Context) (result string, err error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd := shell.Command(ctx, s.shell, s.script)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+	start := time.Now()
+	err = cmd.Run()
+	s.logger.DebugContext(
+		ctx,
+		"Ran token script",
+		slog.String("script", s.script),
+		slog.String("shell", s.shell),
+		slog.Duration("duration", time.Since(start)),
+		slog.Any("error", err),
+	)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf(
+				"token script '%s' didn't finish in %s, check that it doesn't wait for input, or increase "+
+					"the timeout with the '--token-script-timeout' option of the 'login' command%s",
+				s.script, s.timeout, formatStderr(stderr.Bytes()),
+			)
+			return
+		}
+		err = fmt.Errorf(
+			"token script '%s' failed: %w%s",
+			s.script, err, formatStderr(stderr.Bytes()),
+		)
+		return
+	}
+	result = strings.TrimSpace(stdout.String())
+	if result == "" {
+		err = fmt.Errorf("token script '%s' didn't write a token%s", s.script, formatStderr(stderr.Bytes()))
+	}
+	return
+}
+
+func (s *tokenSource) parseToken(text string) (result *auth.Token, err error) {
+	claims := jwt.MapClaims{}
+	_, _, err = s.tokenParser.ParseUnverified(text, claims)
+	if err != nil {
+		return
+	}
+	expiry, err := claims.GetExpirationTime()
+	if err != nil {
+		return
+	}
+	if expiry == nil {
+		err = errors.New("token doesn't have an expiration time")
+		return
+	}
+	result = &auth.Token{
+		Access: text,
+		Expiry: expiry.Time,
+	}
+	return
+}
+
+// formatStderr returns the text that the script wrote to the standard error, prefixed with a line break so that it
+// can be appended to an error message, or an empty string if the script didn't write anything.
+func formatStderr(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return ""
+	}
+	if len(data) > maxStderr {
+		data = append([]byte("..."), data[len(data)-maxStderr:]...)
+	}
+	return ":\n" + string(data)
+}
@@ -0,0 +1,144 @@
+//go:build !windows
+
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package tokenscript
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/osac-project/fulfillment-common/auth"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Token source", func() {
+	var (
+		ctx   context.Context
+		store auth.TokenStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		ctx = context.Background()
+		store, err = auth.NewMemoryTokenStore().
+			SetLogger(logger).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		GinkgoT().Setenv("SHELL", "/bin/sh")
+	})
+
+	makeSource := func(script string, timeout time.Duration) auth.TokenSource {
+		source, err := NewTokenSource().
+			SetLogger(logger).
+			SetScript(script).
+			SetStore(store).
+			SetTimeout(timeout).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return source
+	}
+
+	It("Can't be created without a logger", func() {
+		_, err := NewTokenSource().
+			SetScript("true").
+			SetStore(store).
+			Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Can't be created with a negative timeout", func() {
+		_, err := NewTokenSource().
+			SetLogger(logger).
+			SetScript("true").
+			SetStore(store).
+			SetTimeout(-time.Second).
+			Build()
+		Expect(err).To(MatchError("token script timeout should be zero or positive, but it is -1s"))
+	})
+
+	It("Returns the token written by the script", func() {
+		source := makeSource("echo my-token", time.Minute)
+		token, err := source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Access).To(Equal("my-token"))
+	})
+
+	It("Saves JWT tokens and reuses them till they expire", func() {
+		text := testing.MakeTokenString("Bearer", time.Hour)
+		source := makeSource(fmt.Sprintf("echo %s", text), time.Minute)
+		token, err := source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Access).To(Equal(text))
+		saved, err := store.Load(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(saved.Access).To(Equal(text))
+
+		// A script that fails proves that the saved token is used:
+		source = makeSource("exit 1", time.Minute)
+		token, err = source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Access).To(Equal(text))
+	})
+
+	It("Includes the standard error in the error message when the script fails", func() {
+		source := makeSource("echo 'not logged in' >&2; exit 3", time.Minute)
+		_, err := source.Token(ctx)
+		Expect(err).To(MatchError(
+			"token script 'echo 'not logged in' >&2; exit 3' failed: exit status 3:\nnot logged in",
+		))
+	})
+
+	It("Fails if the script doesn't write a token", func() {
+		source := makeSource("true", time.Minute)
+		_, err := source.Token(ctx)
+		Expect(err).To(MatchError("token script 'true' didn't write a token"))
+	})
+
+	It("Kills the script when it takes too long", func() {
+		source := makeSource("echo 'waiting' >&2; sleep 10", 100*time.Millisecond)
+		start := time.Now()
+		_, err := source.Token(ctx)
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("token script 'echo 'waiting' >&2; sleep 10' didn't finish in 100ms"))
+		Expect(err.Error()).To(HaveSuffix(":\nwaiting"))
+	})
+
+	It("Uses the configured shell", func() {
+		source, err := NewTokenSource().
+			SetLogger(logger).
+			SetScript("echo $0").
+			SetShell("/bin/sh").
+			SetStore(store).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		token, err := source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Access).To(Equal("/bin/sh"))
+	})
+
+	It("Doesn't pass the variables of the tool to the script", func() {
+		GinkgoT().Setenv("FULFILLMENT_SERVICE_TOKEN", "secret")
+		GinkgoT().Setenv("MY_VARIABLE", "my-value")
+		source := makeSource("echo \"${FULFILLMENT_SERVICE_TOKEN:-none}-${MY_VARIABLE}\"", time.Minute)
+		token, err := source.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Access).To(Equal("none-my-value"))
+	})
+})
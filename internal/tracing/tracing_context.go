@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+)
+
+// contextKey is the type used to store the tracer in the context.
+type contextKey int
+
+const (
+	contextTracerKey contextKey = iota
+)
+
+// TracerFromContext returns the tracer from the context, or nil if the context doesn't contain one.
+func TracerFromContext(ctx context.Context) *Tracer {
+	tracer, _ := ctx.Value(contextTracerKey).(*Tracer)
+	return tracer
+}
+
+// TracerIntoContext creates a new context that contains the given tracer.
+func TracerIntoContext(ctx context.Context, tracer *Tracer) context.Context {
+	return context.WithValue(ctx, contextTracerKey, tracer)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package tracing
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// Names of the flags that enable tracing:
+const (
+	callsFlagName    = "debug-grpc"
+	messagesFlagName = "debug-grpc-messages"
+)
+
+// AddFlags adds the flags that enable tracing of remote procedure calls to the given flag set.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.Bool(
+		callsFlagName,
+		false,
+		"Write to the log the method, duration and status code of each call to the server. Unless the "+
+			"'--log-level' option is used the log level is changed to 'info' so that the calls are written.",
+	)
+	flags.Bool(
+		messagesFlagName,
+		false,
+		"Write to the log also the request and response messages of each call to the server, with tokens, "+
+			"passwords and other secrets redacted. This implies '--"+callsFlagName+"'.",
+	)
+}
+
+// EnabledFromFlags checks if tracing was enabled in the command line, and if the messages should be included.
+func EnabledFromFlags(flags *pflag.FlagSet) (enabled, messages bool) {
+	messages, _ = flags.GetBool(messagesFlagName)
+	enabled, _ = flags.GetBool(callsFlagName)
+	enabled = enabled || messages
+	return
+}
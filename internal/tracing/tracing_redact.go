@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RedactedValue is the value that replaces the security sensitive fields.
+const RedactedValue = "***"
+
+// sensitiveWords are the words that, when they appear in the name of a field or in the key of a map entry, indicate
+// that the value is security sensitive.
+var sensitiveWords = []string{
+	"token",
+	"password",
+	"secret",
+	"credential",
+	"kubeconfig",
+	"private_key",
+	"privatekey",
+	"authorization",
+}
+
+// IsSensitive checks if the given field name or map key indicates that the value is security sensitive.
+func IsSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of the given message where the values of the security sensitive fields are replaced. String
+// fields are replaced by the RedactedValue constant, and fields of other types are cleared. The same is done for the
+// entries of maps whose keys look sensitive, for example the 'password' entry of the template parameters. The original
+// message isn't modified.
+func Redact(message proto.Message) proto.Message {
+	if message == nil {
+		return nil
+	}
+	result := proto.Clone(message)
+	redactMessage(result.ProtoReflect())
+	return result
+}
+
+func redactMessage(message protoreflect.Message) {
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if IsSensitive(string(field.Name())) {
+			redactField(message, field)
+			return true
+		}
+		switch {
+		case field.IsMap():
+			entries := value.Map()
+			entries.Range(func(key protoreflect.MapKey, entry protoreflect.Value) bool {
+				if IsSensitive(key.String()) {
+					if field.MapValue().Kind() == protoreflect.StringKind {
+						entries.Set(key, protoreflect.ValueOfString(RedactedValue))
+					} else {
+						entries.Clear(key)
+					}
+					return true
+				}
+				if field.MapValue().Message() != nil {
+					redactMessage(entry.Message())
+				}
+				return true
+			})
+		case field.IsList():
+			if field.Message() != nil {
+				items := value.List()
+				for i := range items.Len() {
+					redactMessage(items.Get(i).Message())
+				}
+			}
+		case field.Message() != nil:
+			redactMessage(value.Message())
+		}
+		return true
+	})
+}
+
+func redactField(message protoreflect.Message, field protoreflect.FieldDescriptor) {
+	if field.Kind() == protoreflect.StringKind && !field.IsList() && !field.IsMap() {
+		message.Set(field, protoreflect.ValueOfString(RedactedValue))
+		return
+	}
+	message.Clear(field)
+}
+
+// Encode converts the given message into a value that the JSON log handler writes as a nested object. If the message
+// can't be converted it returns the text representation of the message.
+func Encode(message proto.Message) any {
+	if message == nil {
+		return nil
+	}
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return message.ProtoReflect().Descriptor().FullName()
+	}
+	var result any
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return string(data)
+	}
+	return result
+}
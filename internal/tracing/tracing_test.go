@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Redact", func() {
+	It("Detects sensitive names", func() {
+		Expect(IsSensitive("access_token")).To(BeTrue())
+		Expect(IsSensitive("adminPassword")).To(BeTrue())
+		Expect(IsSensitive("bmc-credentials")).To(BeTrue())
+		Expect(IsSensitive("name")).To(BeFalse())
+	})
+
+	It("Redacts sensitive entries of maps without changing the original", func() {
+		value, err := anypb.New(wrapperspb.String("my-secret"))
+		Expect(err).ToNot(HaveOccurred())
+		size, err := anypb.New(wrapperspb.Int32(3))
+		Expect(err).ToNot(HaveOccurred())
+		original := ffv1.Cluster_builder{
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-cluster",
+				Annotations: map[string]string{
+					"my-token": "abc",
+					"owner":    "me",
+				},
+			}.Build(),
+			Spec: ffv1.ClusterSpec_builder{
+				TemplateParameters: map[string]*anypb.Any{
+					"admin_password": value,
+					"size":           size,
+				},
+			}.Build(),
+		}.Build()
+		redacted := Redact(original).(*ffv1.Cluster)
+		Expect(redacted.GetMetadata().GetName()).To(Equal("my-cluster"))
+		Expect(redacted.GetMetadata().GetAnnotations()).To(Equal(map[string]string{
+			"my-token": RedactedValue,
+			"owner":    "me",
+		}))
+		Expect(redacted.GetSpec().GetTemplateParameters()).To(HaveKey("size"))
+		Expect(redacted.GetSpec().GetTemplateParameters()).ToNot(HaveKey("admin_password"))
+		Expect(original.GetMetadata().GetAnnotations()).To(HaveKeyWithValue("my-token", "abc"))
+		Expect(original.GetSpec().GetTemplateParameters()).To(HaveKey("admin_password"))
+	})
+
+	It("Redacts sensitive fields", func() {
+		original := ffv1.ClustersGetKubeconfigResponse_builder{
+			Kubeconfig: "apiVersion: v1",
+		}.Build()
+		redacted := Redact(original).(*ffv1.ClustersGetKubeconfigResponse)
+		Expect(redacted.GetKubeconfig()).To(Equal(RedactedValue))
+	})
+})
+
+var _ = Describe("Tracer", func() {
+	var (
+		ctx    context.Context
+		buffer *bytes.Buffer
+		conn   *grpc.ClientConn
+	)
+
+	// start starts a server with the clusters and events services, and creates a connection that uses a tracer that
+	// writes to the buffer.
+	start := func(messages bool) {
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		ffv1.RegisterClustersServer(server.Registrar(), &testing.ClustersServerFuncs{
+			GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest) (*ffv1.ClustersGetResponse,
+				error) {
+				if request.GetId() == "missing" {
+					return nil, grpcstatus.Errorf(codes.NotFound, "cluster doesn't exist")
+				}
+				return ffv1.ClustersGetResponse_builder{
+					Object: ffv1.Cluster_builder{
+						Id: request.GetId(),
+					}.Build(),
+				}.Build(), nil
+			},
+			GetKubeconfigFunc: func(ctx context.Context, request *ffv1.ClustersGetKubeconfigRequest) (
+				*ffv1.ClustersGetKubeconfigResponse, error) {
+				return ffv1.ClustersGetKubeconfigResponse_builder{
+					Kubeconfig: "my-secret-kubeconfig",
+				}.Build(), nil
+			},
+		})
+		eventsv1.RegisterEventsServer(
+			server.Registrar(),
+			testing.NewMockEventsServerBuilder().
+				WithScenario(&testing.EventScenario{
+					Name: "one",
+					Events: []*testing.ScenarioEvent{{
+						ID:   "event-1",
+						Type: eventsv1.EventType_EVENT_TYPE_OBJECT_CREATED,
+						Cluster: &testing.ClusterEventData{
+							ID: "cluster-1",
+						},
+					}},
+				}).
+				Build(),
+		)
+		server.Start()
+
+		buffer = &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buffer, nil))
+		tracer, err := NewTracer().
+			SetLogger(logger).
+			SetMessages(messages).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		conn, err = grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(tracer.UnaryClient),
+			grpc.WithStreamInterceptor(tracer.StreamClient),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+	}
+
+	// records parses the log records written to the buffer.
+	records := func() []map[string]any {
+		var result []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			var record map[string]any
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			result = append(result, record)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("Requires a logger", func() {
+		_, err := NewTracer().Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Writes the method and code of unary calls without messages", func() {
+		start(false)
+		client := ffv1.NewClustersClient(conn)
+		_, err := client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "cluster-1"}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.Get(ctx, ffv1.ClustersGetRequest_builder{Id: "missing"}.Build())
+		Expect(err).To(HaveOccurred())
+		logged := records()
+		Expect(logged).To(HaveLen(2))
+		Expect(logged[0]).To(HaveKeyWithValue("method", "/fulfillment.v1.Clusters/Get"))
+		Expect(logged[0]).To(HaveKeyWithValue("code", "OK"))
+		Expect(logged[0]).To(HaveKey("duration"))
+		Expect(logged[0]).ToNot(HaveKey("request"))
+		Expect(logged[1]).To(HaveKeyWithValue("call", BeEquivalentTo(2)))
+		Expect(logged[1]).To(HaveKeyWithValue("code", "NotFound"))
+		Expect(logged[1]).To(HaveKeyWithValue("message", "cluster doesn't exist"))
+	})
+
+	It("Writes the redacted messages of unary calls", func() {
+		start(true)
+		client := ffv1.NewClustersClient(conn)
+		_, err := client.GetKubeconfig(ctx, ffv1.ClustersGetKubeconfigRequest_builder{Id: "cluster-1"}.Build())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).ToNot(ContainSubstring("my-secret-kubeconfig"))
+		logged := records()
+		Expect(logged).To(HaveLen(1))
+		Expect(logged[0]).To(HaveKeyWithValue("request", HaveKeyWithValue("id", "cluster-1")))
+		Expect(logged[0]).To(HaveKeyWithValue("response", HaveKeyWithValue("kubeconfig", RedactedValue)))
+	})
+
+	It("Writes the messages and the end of streams", func() {
+		start(true)
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := eventsv1.NewEventsClient(conn).Watch(streamCtx, &eventsv1.EventsWatchRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).ToNot(HaveOccurred())
+		cancel()
+		_, err = stream.Recv()
+		Expect(err).To(HaveOccurred())
+		messages := []string{}
+		for _, record := range records() {
+			messages = append(messages, record["msg"].(string))
+		}
+		Expect(messages).To(Equal([]string{
+			"Started gRPC stream",
+			"Sent gRPC stream message",
+			"Received gRPC stream message",
+			"Finished gRPC stream",
+		}))
+		last := records()[3]
+		Expect(last).To(HaveKeyWithValue("code", "Canceled"))
+		Expect(last).To(HaveKeyWithValue("received", BeEquivalentTo(1)))
+	})
+})
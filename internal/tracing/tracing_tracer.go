@@ -0,0 +1,226 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package tracing contains the interceptors that write to the log the details of the remote procedure calls, like the
+// method, the time that they take and the resulting status code, and optionally the request and response messages
+// with the security sensitive fields redacted. This is intended to help users to write actionable bug reports.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TracerBuilder contains the data and logic needed to create a tracer. Don't create instances of this type directly,
+// use the NewTracer function instead.
+type TracerBuilder struct {
+	logger   *slog.Logger
+	messages bool
+}
+
+// Tracer writes to the log the details of the remote procedure calls. Don't create instances of this type directly,
+// use the NewTracer function instead.
+type Tracer struct {
+	logger   *slog.Logger
+	messages bool
+	calls    atomic.Int64
+}
+
+// NewTracer creates a builder that can then be used to configure and create a tracer.
+func NewTracer() *TracerBuilder {
+	return &TracerBuilder{}
+}
+
+// SetLogger sets the logger where the details of the calls will be written. This is mandatory.
+func (b *TracerBuilder) SetLogger(value *slog.Logger) *TracerBuilder {
+	b.logger = value
+	return b
+}
+
+// SetMessages sets the flag that indicates if the request and response messages should be written to the log. The
+// default is false.
+func (b *TracerBuilder) SetMessages(value bool) *TracerBuilder {
+	b.messages = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new tracer.
+func (b *TracerBuilder) Build() (result *Tracer, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+
+	// Create and populate the object:
+	result = &Tracer{
+		logger:   b.logger,
+		messages: b.messages,
+	}
+	return
+}
+
+// UnaryClient is the unary client interceptor function that writes the details of the calls to the log.
+func (t *Tracer) UnaryClient(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	call := t.calls.Add(1)
+	start := time.Now()
+	err := invoker(ctx, method, request, response, conn, opts...)
+	attrs := []slog.Attr{
+		slog.Int64("call", call),
+		slog.String("method", method),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("code", grpcstatus.Code(err).String()),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("message", grpcstatus.Convert(err).Message()))
+	}
+	if t.messages {
+		attrs = append(attrs, t.messageAttr("request", request))
+		if err == nil {
+			attrs = append(attrs, t.messageAttr("response", response))
+		}
+	}
+	t.logger.LogAttrs(ctx, slog.LevelInfo, "Finished gRPC call", attrs...)
+	return err
+}
+
+// StreamClient is the stream client interceptor function that writes the details of the calls to the log. For streams
+// the messages are written when they are sent or received, and the duration and status code when the stream ends.
+func (t *Tracer) StreamClient(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	call := t.calls.Add(1)
+	start := time.Now()
+	stream, err := streamer(ctx, desc, conn, method, opts...)
+	if err != nil {
+		t.logger.LogAttrs(
+			ctx,
+			slog.LevelInfo,
+			"Failed to start gRPC stream",
+			slog.Int64("call", call),
+			slog.String("method", method),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("code", grpcstatus.Code(err).String()),
+			slog.String("message", grpcstatus.Convert(err).Message()),
+		)
+		return nil, err
+	}
+	t.logger.LogAttrs(
+		ctx,
+		slog.LevelInfo,
+		"Started gRPC stream",
+		slog.Int64("call", call),
+		slog.String("method", method),
+		slog.Duration("duration", time.Since(start)),
+	)
+	return &tracedStream{
+		ClientStream: stream,
+		tracer:       t,
+		ctx:          ctx,
+		call:         call,
+		method:       method,
+		start:        start,
+	}, nil
+}
+
+// messageAttr creates the log attribute for the given message, with the security sensitive fields redacted.
+func (t *Tracer) messageAttr(key string, value any) slog.Attr {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return slog.Any(key, value)
+	}
+	return slog.Any(key, Encode(Redact(message)))
+}
+
+// tracedStream wraps a client stream to write to the log the messages and the end of the stream.
+type tracedStream struct {
+	grpc.ClientStream
+	tracer   *Tracer
+	ctx      context.Context
+	call     int64
+	method   string
+	start    time.Time
+	sent     int64
+	received int64
+	finished bool
+}
+
+func (s *tracedStream) SendMsg(message any) error {
+	err := s.ClientStream.SendMsg(message)
+	if err == nil {
+		s.sent++
+		if s.tracer.messages {
+			s.tracer.logger.LogAttrs(
+				s.ctx,
+				slog.LevelInfo,
+				"Sent gRPC stream message",
+				slog.Int64("call", s.call),
+				slog.String("method", s.method),
+				s.tracer.messageAttr("request", message),
+			)
+		}
+	}
+	return err
+}
+
+func (s *tracedStream) RecvMsg(message any) error {
+	err := s.ClientStream.RecvMsg(message)
+	if err != nil {
+		s.finish(err)
+		return err
+	}
+	s.received++
+	if s.tracer.messages {
+		s.tracer.logger.LogAttrs(
+			s.ctx,
+			slog.LevelInfo,
+			"Received gRPC stream message",
+			slog.Int64("call", s.call),
+			slog.String("method", s.method),
+			s.tracer.messageAttr("response", message),
+		)
+	}
+	return nil
+}
+
+// finish writes to the log the end of the stream. The end of the stream is signaled by an io.EOF error, which means
+// that the call finished successfully.
+func (s *tracedStream) finish(err error) {
+	if s.finished {
+		return
+	}
+	s.finished = true
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	attrs := []slog.Attr{
+		slog.Int64("call", s.call),
+		slog.String("method", s.method),
+		slog.Duration("duration", time.Since(s.start)),
+		slog.String("code", grpcstatus.Code(err).String()),
+		slog.Int64("sent", s.sent),
+		slog.Int64("received", s.received),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("message", grpcstatus.Convert(err).Message()))
+	}
+	s.tracer.logger.LogAttrs(s.ctx, slog.LevelInfo, "Finished gRPC stream", attrs...)
+}
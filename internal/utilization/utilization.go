@@ -0,0 +1,298 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package utilization contains the functions that summarize how the hosts requested by host pools are allocated. The
+// calculations use only the generic protocol buffers reflection API, so they work with the host pools of all the
+// packages, and take advantage of the details that some of them report, like the hosts allocated for each host set.
+package utilization
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Pool contains the utilization of a host pool.
+type Pool struct {
+	// Id is the identifier of the host pool.
+	Id string `json:"id"`
+
+	// Name is the name of the host pool, may be empty.
+	Name string `json:"name,omitempty"`
+
+	// State is the state of the host pool, without the prefix of the enum type, for example 'READY'.
+	State string `json:"state"`
+
+	// Requested is the total number of hosts requested by the host sets of the pool.
+	Requested int `json:"requested"`
+
+	// Allocated is the number of hosts assigned to the pool.
+	Allocated int `json:"allocated"`
+
+	// Classes contains the number of hosts requested and allocated for each host class, indexed by the identifier
+	// of the host class.
+	Classes map[string]*Counts `json:"classes,omitempty"`
+}
+
+// Class contains the utilization of a host class, summarized from all the host pools that use it.
+type Class struct {
+	// Id is the identifier of the host class.
+	Id string `json:"id"`
+
+	// Name is the name of the host class, may be empty.
+	Name string `json:"name,omitempty"`
+
+	// Pools is the number of host pools that request hosts of this class.
+	Pools int `json:"pools"`
+
+	Counts
+}
+
+// Counts contains the number of hosts requested and allocated.
+type Counts struct {
+	// Requested is the number of hosts requested.
+	Requested int `json:"requested"`
+
+	// Allocated is the number of hosts allocated. It is nil when it can't be calculated, for example when the server
+	// doesn't report the hosts allocated for each host set of a pool that has more than one.
+	Allocated *int `json:"allocated,omitempty"`
+}
+
+// CalculatePools calculates the utilization of the given host pools. The hosts allocated for each host class are taken
+// from the 'status.host_sets' field when the server reports it. Otherwise they are known only for pools that have one
+// host set, as then all the allocated hosts belong to it.
+func CalculatePools(objects []proto.Message) []*Pool {
+	result := make([]*Pool, len(objects))
+	for i, object := range objects {
+		result[i] = calculatePool(object.ProtoReflect())
+	}
+	return result
+}
+
+func calculatePool(message protoreflect.Message) *Pool {
+	pool := &Pool{
+		Id:      stringField(message, "id"),
+		Name:    stringField(messageField(message, "metadata"), "name"),
+		State:   "-",
+		Classes: map[string]*Counts{},
+	}
+	spec := messageField(message, "spec")
+	status := messageField(message, "status")
+
+	// Add the hosts requested by the host sets of the specification:
+	requested := hostSets(spec)
+	for _, set := range requested {
+		counts := pool.Classes[set.class]
+		if counts == nil {
+			counts = &Counts{}
+			pool.Classes[set.class] = counts
+		}
+		counts.Requested += set.size
+		pool.Requested += set.size
+	}
+
+	// Get the state and the number of allocated hosts from the status:
+	if status != nil {
+		pool.State = enumField(status, "state")
+		if field := status.Descriptor().Fields().ByName("hosts"); field != nil && field.IsList() {
+			pool.Allocated = status.Get(field).List().Len()
+		}
+	}
+
+	// Calculate the hosts allocated for each host class. Servers that don't report the host sets of the status leave
+	// them empty, and then we can only tell the allocation when the pool has a single host class.
+	switch {
+	case len(hostSets(status)) > 0:
+		for _, counts := range pool.Classes {
+			counts.Allocated = new(int)
+		}
+		for _, set := range hostSets(status) {
+			counts := pool.Classes[set.class]
+			if counts == nil {
+				counts = &Counts{
+					Allocated: new(int),
+				}
+				pool.Classes[set.class] = counts
+			}
+			*counts.Allocated += set.size
+		}
+	case len(pool.Classes) == 1:
+		for _, counts := range pool.Classes {
+			counts.Allocated = &pool.Allocated
+		}
+	}
+	return pool
+}
+
+// CalculateClasses summarizes the utilization of the given pools by host class. The names parameter contains the names
+// of the host classes indexed by identifier, and may be nil. The result is sorted by identifier.
+func CalculateClasses(pools []*Pool, names map[string]string) []*Class {
+	index := map[string]*Class{}
+	for _, pool := range pools {
+		for id, counts := range pool.Classes {
+			class := index[id]
+			if class == nil {
+				class = &Class{
+					Id:   id,
+					Name: names[id],
+					Counts: Counts{
+						Allocated: new(int),
+					},
+				}
+				index[id] = class
+			}
+			class.Pools++
+			class.Requested += counts.Requested
+			if counts.Allocated != nil && class.Allocated != nil {
+				*class.Allocated += *counts.Allocated
+			} else {
+				class.Allocated = nil
+			}
+		}
+	}
+	result := make([]*Class, 0, len(index))
+	for _, class := range index {
+		result = append(result, class)
+	}
+	slices.SortFunc(result, func(a, b *Class) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return result
+}
+
+// ClassNames returns the names of the given host classes, indexed by identifier and by name, so that they can be
+// found regardless of which of them the host pools use to reference the class. The name is the title of the class
+// when it has one, or else the name from the metadata.
+func ClassNames(objects []proto.Message) map[string]string {
+	result := map[string]string{}
+	for _, object := range objects {
+		message := object.ProtoReflect()
+		id := stringField(message, "id")
+		name := stringField(messageField(message, "metadata"), "name")
+		title := stringField(message, "title")
+		if title == "" {
+			title = name
+		}
+		if title == "" {
+			continue
+		}
+		if id != "" {
+			result[id] = title
+		}
+		if name != "" {
+			result[name] = title
+		}
+	}
+	return result
+}
+
+// Usage returns the percentage of the requested hosts that are allocated, for example '75%', or a dash if no hosts
+// are requested.
+func Usage(allocated, requested int) string {
+	if requested == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", allocated*100/requested)
+}
+
+// hostSet is a host set extracted from a specification or status.
+type hostSet struct {
+	class string
+	size  int
+}
+
+// hostSets extracts the host sets of the 'host_sets' map field of the given message.
+func hostSets(message protoreflect.Message) (result []hostSet) {
+	if message == nil {
+		return
+	}
+	field := message.Descriptor().Fields().ByName("host_sets")
+	if field == nil || !field.IsMap() || field.MapValue().Message() == nil {
+		return
+	}
+	message.Get(field).Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		set := value.Message()
+		result = append(result, hostSet{
+			class: stringField(set, "host_class"),
+			size:  int(intField(set, "size")),
+		})
+		return true
+	})
+	return
+}
+
+// messageField returns the value of the message field with the given name, or nil if there is no such field.
+func messageField(message protoreflect.Message, name protoreflect.Name) protoreflect.Message {
+	if message == nil {
+		return nil
+	}
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.Message() == nil || field.IsList() || field.IsMap() {
+		return nil
+	}
+	return message.Get(field).Message()
+}
+
+// stringField returns the value of the string field with the given name, or an empty string if there is no such field.
+func stringField(message protoreflect.Message, name protoreflect.Name) string {
+	if message == nil {
+		return ""
+	}
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return ""
+	}
+	return message.Get(field).String()
+}
+
+// intField returns the value of the integer field with the given name, or zero if there is no such field.
+func intField(message protoreflect.Message, name protoreflect.Name) int64 {
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.IsList() {
+		return 0
+	}
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return message.Get(field).Int()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		return int64(message.Get(field).Uint())
+	default:
+		return 0
+	}
+}
+
+// enumField returns the name of the value of the enum field with the given name, without the prefix that is common
+// to all the values of the type, or a dash if there is no such field.
+func enumField(message protoreflect.Message, name protoreflect.Name) string {
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.Enum() == nil {
+		return "-"
+	}
+	values := field.Enum().Values()
+	value := values.ByNumber(message.Get(field).Enum())
+	if value == nil {
+		return "-"
+	}
+	text := string(value.Name())
+	unspecified := values.ByNumber(0)
+	if unspecified != nil {
+		index := strings.LastIndex(string(unspecified.Name()), "_")
+		if index != -1 && strings.HasPrefix(text, string(unspecified.Name()[:index+1])) {
+			text = text[index+1:]
+		}
+	}
+	return text
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package utilization
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/osac-project/fulfillment-common/api/shared/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Utilization", func() {
+	// publicPool creates a public host pool with the given host sets and number of allocated hosts.
+	publicPool := func(id string, sets map[string]int32, allocated int) *ffv1.HostPool {
+		hostSets := map[string]*ffv1.HostPoolHostSet{}
+		for class, size := range sets {
+			hostSets[class+"-set"] = ffv1.HostPoolHostSet_builder{
+				HostClass: class,
+				Size:      size,
+			}.Build()
+		}
+		hosts := make([]string, allocated)
+		for i := range hosts {
+			hosts[i] = id + "-host"
+		}
+		return ffv1.HostPool_builder{
+			Id: id,
+			Metadata: sharedv1.Metadata_builder{
+				Name: "my-" + id,
+			}.Build(),
+			Spec: ffv1.HostPoolSpec_builder{
+				HostSets: hostSets,
+			}.Build(),
+			Status: ffv1.HostPoolStatus_builder{
+				State: ffv1.HostPoolState_HOST_POOL_STATE_READY,
+				Hosts: hosts,
+			}.Build(),
+		}.Build()
+	}
+
+	It("Calculates the utilization of a pool with one host set", func() {
+		pools := CalculatePools([]proto.Message{
+			publicPool("pool-1", map[string]int32{"small": 4}, 3),
+		})
+		Expect(pools).To(HaveLen(1))
+		pool := pools[0]
+		Expect(pool.Id).To(Equal("pool-1"))
+		Expect(pool.Name).To(Equal("my-pool-1"))
+		Expect(pool.State).To(Equal("READY"))
+		Expect(pool.Requested).To(Equal(4))
+		Expect(pool.Allocated).To(Equal(3))
+		Expect(pool.Classes).To(HaveKey("small"))
+		Expect(pool.Classes["small"].Requested).To(Equal(4))
+		Expect(pool.Classes["small"].Allocated).To(HaveValue(Equal(3)))
+	})
+
+	It("Doesn't guess the allocation of each class when the server doesn't report it", func() {
+		pools := CalculatePools([]proto.Message{
+			publicPool("pool-1", map[string]int32{"small": 4, "large": 2}, 5),
+		})
+		pool := pools[0]
+		Expect(pool.Requested).To(Equal(6))
+		Expect(pool.Allocated).To(Equal(5))
+		Expect(pool.Classes["small"].Allocated).To(BeNil())
+		Expect(pool.Classes["large"].Allocated).To(BeNil())
+	})
+
+	It("Uses the host sets of the status when available", func() {
+		pools := CalculatePools([]proto.Message{
+			ffv1.HostPool_builder{
+				Id: "pool-1",
+				Spec: ffv1.HostPoolSpec_builder{
+					HostSets: map[string]*ffv1.HostPoolHostSet{
+						"a": ffv1.HostPoolHostSet_builder{
+							HostClass: "small",
+							Size:      4,
+						}.Build(),
+						"b": ffv1.HostPoolHostSet_builder{
+							HostClass: "large",
+							Size:      2,
+						}.Build(),
+					},
+				}.Build(),
+				Status: ffv1.HostPoolStatus_builder{
+					Hosts: []string{"h1", "h2", "h3"},
+					HostSets: map[string]*ffv1.HostPoolHostSet{
+						"a": ffv1.HostPoolHostSet_builder{
+							HostClass: "small",
+							Size:      2,
+						}.Build(),
+						"b": ffv1.HostPoolHostSet_builder{
+							HostClass: "large",
+							Size:      1,
+						}.Build(),
+					},
+				}.Build(),
+			}.Build(),
+		})
+		pool := pools[0]
+		Expect(pool.Classes["small"].Allocated).To(HaveValue(Equal(2)))
+		Expect(pool.Classes["large"].Allocated).To(HaveValue(Equal(1)))
+	})
+
+	It("Summarizes the pools by host class", func() {
+		pools := CalculatePools([]proto.Message{
+			publicPool("pool-1", map[string]int32{"small": 4}, 3),
+			publicPool("pool-2", map[string]int32{"small": 2}, 2),
+			publicPool("pool-3", map[string]int32{"small": 1, "large": 2}, 1),
+		})
+		classes := CalculateClasses(pools, map[string]string{
+			"small": "Small hosts",
+		})
+		Expect(classes).To(HaveLen(2))
+		Expect(classes[0].Id).To(Equal("large"))
+		Expect(classes[0].Pools).To(Equal(1))
+		Expect(classes[0].Requested).To(Equal(2))
+		Expect(classes[0].Allocated).To(BeNil())
+		Expect(classes[1].Id).To(Equal("small"))
+		Expect(classes[1].Name).To(Equal("Small hosts"))
+		Expect(classes[1].Pools).To(Equal(3))
+		Expect(classes[1].Requested).To(Equal(7))
+		Expect(classes[1].Allocated).To(BeNil())
+	})
+
+	It("Indexes the names of the host classes by identifier and name", func() {
+		names := ClassNames([]proto.Message{
+			ffv1.HostClass_builder{
+				Id: "123",
+				Metadata: sharedv1.Metadata_builder{
+					Name: "small",
+				}.Build(),
+				Title: "Small hosts",
+			}.Build(),
+			ffv1.HostClass_builder{
+				Id: "456",
+				Metadata: sharedv1.Metadata_builder{
+					Name: "large",
+				}.Build(),
+			}.Build(),
+		})
+		Expect(names).To(Equal(map[string]string{
+			"123":   "Small hosts",
+			"small": "Small hosts",
+			"456":   "large",
+			"large": "large",
+		}))
+	})
+
+	It("Calculates the usage percentage", func() {
+		Expect(Usage(3, 4)).To(Equal("75%"))
+		Expect(Usage(0, 0)).To(Equal("-"))
+		Expect(Usage(5, 4)).To(Equal("125%"))
+	})
+})
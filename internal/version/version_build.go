@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// This will be injected into the binary during the build. Release builds set it to the URL of the workflow run that
+// built them.
+var builder = Unknown
+
+// Build contains the provenance details of the running binary, extracted from the information that the Go tool
+// embeds in the binary and from the values injected during the build.
+type Build struct {
+	// Version is the version identifier, the same returned by the Get function.
+	Version string `json:"version"`
+
+	// Release is true when the version is a semantic version, which means that the binary was built for a release.
+	Release bool `json:"release"`
+
+	// Commit is the identifier of the commit that the binary was built from.
+	Commit string `json:"commit,omitempty"`
+
+	// CommitTime is the time of that commit.
+	CommitTime string `json:"commit_time,omitempty"`
+
+	// Modified is true if the working tree had uncommitted changes when the binary was built.
+	Modified bool `json:"modified,omitempty"`
+
+	// Builder identifies the system that built the binary.
+	Builder string `json:"builder"`
+
+	// GoVersion is the version of the Go tool used to build the binary.
+	GoVersion string `json:"go_version"`
+
+	// Os and Arch are the operating system and the architecture that the binary was built for.
+	Os   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+// GetBuild returns the provenance details of the running binary.
+func GetBuild() *Build {
+	result := &Build{
+		Version:   Get(),
+		Builder:   builder,
+		GoVersion: runtime.Version(),
+		Os:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	_, err := semver.StrictNewVersion(result.Version)
+	result.Release = err == nil
+	info, ok := debug.ReadBuildInfo()
+	if ok {
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				result.Commit = setting.Value
+			case "vcs.time":
+				result.CommitTime = setting.Value
+			case "vcs.modified":
+				result.Modified = setting.Value == "true"
+			}
+		}
+	}
+	return result
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -36,9 +37,10 @@ type InterceptorBuilder struct {
 
 // Interceptor contains the data needed by the interceptor.
 type Interceptor struct {
-	logger  *slog.Logger
-	product string
-	version string
+	logger    *slog.Logger
+	product   string
+	version   string
+	checkOnce sync.Once
 }
 
 // NewInterceptor creates a builder that can then be used to configure and create a interceptor.
@@ -137,11 +139,44 @@ func (i *Interceptor) userAgentHeaderValue() string {
 	return fmt.Sprintf("%s/%s", i.product, i.version)
 }
 
-// UnaryClient is the unary client interceptor function that adds the version details.
+// UnaryClient is the unary client interceptor function that adds the version details. It also checks the version
+// that the server advertises in the response header, and writes a warning to the log if it is known to be
+// incompatible.
 func (i *Interceptor) UnaryClient(ctx context.Context, method string, request, response any,
 	conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	ctx = metadata.AppendToOutgoingContext(ctx, userAgentHeaderName, i.userAgentHeaderValue())
-	return invoker(ctx, method, request, response, conn, opts...)
+	var header metadata.MD
+	opts = append(opts, grpc.Header(&header))
+	err := invoker(ctx, method, request, response, conn, opts...)
+	i.checkServer(ctx, header)
+	return err
+}
+
+// checkServer checks the server version advertised in the given response header. This is done only for the first
+// response that contains it, as it will be the same for the rest.
+func (i *Interceptor) checkServer(ctx context.Context, header metadata.MD) {
+	server := ServerFromHeader(header)
+	if server == "" {
+		return
+	}
+	i.checkOnce.Do(func() {
+		i.logger.DebugContext(
+			ctx,
+			"Received server version",
+			slog.String("client", i.version),
+			slog.String("server", server),
+		)
+		reason := CheckCompatibility(i.version, server)
+		if reason != "" {
+			i.logger.WarnContext(
+				ctx,
+				"Server version is incompatible",
+				slog.String("client", i.version),
+				slog.String("server", server),
+				slog.String("reason", reason),
+			)
+		}
+	})
 }
 
 // StreamClient is the stream client interceptor function that adds the user agent header.
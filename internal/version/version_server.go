@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"google.golang.org/grpc/metadata"
+)
+
+// serverHeaderName is the name of the response header where the server advertises its product name and version, in
+// the same 'product/version' format that the client uses for the user agent header.
+const serverHeaderName = "Server"
+
+// ServerFromHeader extracts the version of the server from the given response header. It returns an empty string if
+// the header doesn't contain it.
+func ServerFromHeader(header metadata.MD) string {
+	for _, value := range header.Get(serverHeaderName) {
+		_, version, ok := strings.Cut(strings.TrimSpace(value), "/")
+		if ok && version != "" {
+			return strings.TrimPrefix(version, "v")
+		}
+	}
+	return ""
+}
+
+// CheckCompatibility checks if the given client and server versions are known to be incompatible, and returns the
+// explanation, or an empty string if they are compatible. According to the semantic versioning rules that is the case
+// when the major versions are different, or when they are both zero and the minor versions are different. Versions
+// that aren't semantic versions, like the git commits used for development builds, are never reported as
+// incompatible.
+func CheckCompatibility(client, server string) string {
+	clientVersion, err := semver.StrictNewVersion(client)
+	if err != nil {
+		return ""
+	}
+	serverVersion, err := semver.StrictNewVersion(server)
+	if err != nil {
+		return ""
+	}
+	if clientVersion.Major() != serverVersion.Major() {
+		return fmt.Sprintf(
+			"client version %s and server version %s have different major versions, some commands may fail",
+			clientVersion, serverVersion,
+		)
+	}
+	if clientVersion.Major() == 0 && clientVersion.Minor() != serverVersion.Minor() {
+		return fmt.Sprintf(
+			"client version %s and server version %s have different minor versions, and for versions before "+
+				"1.0.0 that means that some commands may fail",
+			clientVersion, serverVersion,
+		)
+	}
+	return ""
+}
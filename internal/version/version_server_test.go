@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package version
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ = Describe("Server version", func() {
+	DescribeTable(
+		"Extracts the version from the header",
+		func(header metadata.MD, expected string) {
+			Expect(ServerFromHeader(header)).To(Equal(expected))
+		},
+		Entry("No header", metadata.MD{}, ""),
+		Entry("Product and version", metadata.Pairs("Server", "fulfillment-service/1.2.3"), "1.2.3"),
+		Entry("Version with prefix", metadata.Pairs("Server", "fulfillment-service/v1.2.3"), "1.2.3"),
+		Entry("Only product", metadata.Pairs("Server", "envoy"), ""),
+		Entry(
+			"First value with version",
+			metadata.Pairs("Server", "envoy", "Server", "fulfillment-service/1.2.3"),
+			"1.2.3",
+		),
+	)
+
+	DescribeTable(
+		"Checks compatibility",
+		func(client, server string, incompatible bool) {
+			reason := CheckCompatibility(client, server)
+			if incompatible {
+				Expect(reason).ToNot(BeEmpty())
+			} else {
+				Expect(reason).To(BeEmpty())
+			}
+		},
+		Entry("Same version", "1.2.3", "1.2.3", false),
+		Entry("Different minor version", "1.2.3", "1.5.0", false),
+		Entry("Different major version", "1.2.3", "2.0.0", true),
+		Entry("Different minor version before 1.0.0", "0.1.0", "0.2.0", true),
+		Entry("Different patch version before 1.0.0", "0.1.0", "0.1.7", false),
+		Entry("Development client", "e2c4f1a9b0d3", "2.0.0", false),
+		Entry("Unknown server", "1.2.3", Unknown, false),
+	)
+})
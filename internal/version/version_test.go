@@ -61,3 +61,33 @@ var _ = Describe("Get", func() {
 		})
 	})
 })
+
+var _ = Describe("GetBuild", func() {
+	It("Marks semantic versions as releases", func() {
+		value := Get()
+		Set("v1.2.3")
+		DeferCleanup(func() {
+			Set(value)
+		})
+		build := GetBuild()
+		Expect(build.Version).To(Equal("1.2.3"))
+		Expect(build.Release).To(BeTrue())
+	})
+
+	It("Doesn't mark unknown versions as releases", func() {
+		value := Get()
+		Set(Unknown)
+		DeferCleanup(func() {
+			Set(value)
+		})
+		build := GetBuild()
+		Expect(build.Release).To(BeFalse())
+	})
+
+	It("Returns the platform details", func() {
+		build := GetBuild()
+		Expect(build.GoVersion).ToNot(BeEmpty())
+		Expect(build.Os).ToNot(BeEmpty())
+		Expect(build.Arch).ToNot(BeEmpty())
+	})
+})
@@ -0,0 +1,358 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package wait contains the logic used to wait till objects that have just been created are ready.
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+)
+
+// Listener is a function that is called when the state of the object changes. The state is the name of the enum
+// value without the prefix, for example 'PROGRESSING' or 'READY'.
+type Listener func(ctx context.Context, object proto.Message, state string)
+
+// WaiterBuilder contains the data and logic needed to create a waiter. Don't create objects of this type directly, use
+// the NewWaiter function instead.
+type WaiterBuilder struct {
+	logger       *slog.Logger
+	conn         *grpc.ClientConn
+	pollInterval time.Duration
+	listener     Listener
+}
+
+// Waiter waits till an object reaches the ready state. It uses the events stream when the server supports it for the
+// type of object, and polls the object otherwise, or when the stream fails. Don't create objects of this type
+// directly, use the NewWaiter function instead.
+type Waiter struct {
+	logger       *slog.Logger
+	conn         *grpc.ClientConn
+	pollInterval time.Duration
+	listener     Listener
+}
+
+// NewWaiter creates a builder that can then be used to configure and create a waiter.
+func NewWaiter() *WaiterBuilder {
+	return &WaiterBuilder{
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// SetLogger sets the logger. This is mandatory.
+func (b *WaiterBuilder) SetLogger(value *slog.Logger) *WaiterBuilder {
+	b.logger = value
+	return b
+}
+
+// SetConnection sets the gRPC connection used to watch and get the objects. This is mandatory.
+func (b *WaiterBuilder) SetConnection(value *grpc.ClientConn) *WaiterBuilder {
+	b.conn = value
+	return b
+}
+
+// SetPollInterval sets the time between requests to get the object when the events stream isn't available. This is
+// optional, the default is five seconds.
+func (b *WaiterBuilder) SetPollInterval(value time.Duration) *WaiterBuilder {
+	b.pollInterval = value
+	return b
+}
+
+// SetListener sets the function that will be called when the state of the object changes. This is optional.
+func (b *WaiterBuilder) SetListener(value Listener) *WaiterBuilder {
+	b.listener = value
+	return b
+}
+
+// Build uses the data stored in the builder to create a new waiter.
+func (b *WaiterBuilder) Build() (result *Waiter, err error) {
+	// Check parameters:
+	if b.logger == nil {
+		err = errors.New("logger is mandatory")
+		return
+	}
+	if b.conn == nil {
+		err = errors.New("connection is mandatory")
+		return
+	}
+	if b.pollInterval <= 0 {
+		err = fmt.Errorf("poll interval should be positive, but it is %s", b.pollInterval)
+		return
+	}
+
+	// Create and populate the object:
+	result = &Waiter{
+		logger:       b.logger,
+		conn:         b.conn,
+		pollInterval: b.pollInterval,
+		listener:     b.listener,
+	}
+	return
+}
+
+// Wait waits till the given object reaches the ready state, and returns the ready version of the object. It returns
+// an error if the object fails, if it is deleted, or if the context is canceled, for example because a timeout
+// expired. The given object only needs to contain the identifier.
+func (w *Waiter) Wait(ctx context.Context, object proto.Message) (result proto.Message, err error) {
+	// Find the details of the type of object:
+	name := object.ProtoReflect().Descriptor().FullName()
+	kind, ok := kinds[name]
+	if !ok {
+		err = fmt.Errorf("waiting for objects of type '%s' isn't supported", name)
+		return
+	}
+	id := objectId(object)
+	if id == "" {
+		err = fmt.Errorf("object of type '%s' doesn't have an identifier", name)
+		return
+	}
+	state := &waitState{
+		kind: kind,
+		id:   id,
+	}
+
+	// Start watching before getting the current state, so that changes that happen in between aren't lost. If the
+	// server doesn't support the stream we will poll.
+	var stream grpc.ServerStreamingClient[eventsv1.EventsWatchResponse]
+	if kind.eventField != "" {
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err = w.watch(watchCtx, kind, id)
+		if err != nil {
+			w.logger.InfoContext(
+				ctx,
+				"Failed to watch events, will poll",
+				slog.String("type", string(name)),
+				slog.String("id", id),
+				slog.Any("error", err),
+			)
+			stream = nil
+			err = nil
+		}
+	}
+
+	// Get and check the current state:
+	current, err := kind.get(ctx, w.conn, id)
+	if err != nil {
+		err = w.wrapError(ctx, kind, id, err)
+		return
+	}
+	done, err := w.check(ctx, state, current)
+	if done || err != nil {
+		result = current
+		return
+	}
+
+	// Wait for changes:
+	for {
+		if stream != nil {
+			var event *eventsv1.Event
+			event, err = w.receive(stream)
+			if err != nil {
+				if ctx.Err() != nil {
+					err = w.wrapError(ctx, kind, id, ctx.Err())
+					return
+				}
+				w.logger.InfoContext(
+					ctx,
+					"Events stream failed, will poll",
+					slog.String("id", id),
+					slog.Any("error", err),
+				)
+				stream = nil
+				err = nil
+				continue
+			}
+			if event.GetType() == eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED {
+				err = fmt.Errorf("%s '%s' was deleted", kind.name, id)
+				return
+			}
+			current = event.ProtoReflect().Get(kind.eventDescriptor()).Message().Interface()
+		} else {
+			select {
+			case <-ctx.Done():
+				err = w.wrapError(ctx, kind, id, ctx.Err())
+				return
+			case <-time.After(w.pollInterval):
+			}
+			current, err = kind.get(ctx, w.conn, id)
+			if err != nil {
+				err = w.wrapError(ctx, kind, id, err)
+				return
+			}
+		}
+		done, err = w.check(ctx, state, current)
+		if done || err != nil {
+			result = current
+			return
+		}
+	}
+}
+
+// watch opens the events stream for the given object.
+func (w *Waiter) watch(ctx context.Context, kind *kind,
+	id string) (result grpc.ServerStreamingClient[eventsv1.EventsWatchResponse], err error) {
+	filter := fmt.Sprintf("has(event.%[1]s) && event.%[1]s.id == %[2]q", kind.eventField, id)
+	client := eventsv1.NewEventsClient(w.conn)
+	result, err = client.Watch(ctx, eventsv1.EventsWatchRequest_builder{
+		Filter: proto.String(filter),
+	}.Build())
+	return
+}
+
+// receive waits for the next event of the stream that contains the object.
+func (w *Waiter) receive(stream grpc.ServerStreamingClient[eventsv1.EventsWatchResponse]) (result *eventsv1.Event,
+	err error) {
+	for {
+		var response *eventsv1.EventsWatchResponse
+		response, err = stream.Recv()
+		if err != nil {
+			return
+		}
+		event := response.GetEvent()
+		if event == nil {
+			continue
+		}
+		result = event
+		return
+	}
+}
+
+// check reports the state of the object if it changed, and checks if it is ready or failed.
+func (w *Waiter) check(ctx context.Context, state *waitState, object proto.Message) (done bool, err error) {
+	value, ready, failed := state.kind.state(object)
+	name := string(value.Descriptor().Values().ByNumber(value.Number()).Name())
+	name = strings.TrimPrefix(name, state.kind.statePrefix)
+	if name != state.last {
+		w.logger.DebugContext(
+			ctx,
+			"State changed",
+			slog.String("id", state.id),
+			slog.String("from", state.last),
+			slog.String("to", name),
+		)
+		state.last = name
+		if w.listener != nil {
+			w.listener(ctx, object, name)
+		}
+	}
+	if failed {
+		err = fmt.Errorf("%s '%s' failed", state.kind.name, state.id)
+		return
+	}
+	done = ready
+	return
+}
+
+// wrapError adds to the given error the context of the object that we were waiting for. Errors that indicate that
+// the object doesn't exist are replaced by a message saying that it was deleted.
+func (w *Waiter) wrapError(ctx context.Context, kind *kind, id string, err error) error {
+	if grpcstatus.Code(err) == codes.NotFound {
+		return exit.WithCode(exit.NotFound, fmt.Errorf("%s '%s' was deleted", kind.name, id))
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return exit.WithCode(exit.Timeout, fmt.Errorf("timed out waiting for %s '%s' to be ready", kind.name, id))
+	}
+	return fmt.Errorf("failed to wait for %s '%s': %w", kind.name, id, err)
+}
+
+// waitState contains the information about the object that we are waiting for.
+type waitState struct {
+	kind *kind
+	id   string
+	last string
+}
+
+// kind contains the details that the waiter needs to know about a type of object.
+type kind struct {
+	// name is the name of the type used in messages, for example 'cluster'.
+	name string
+
+	// eventField is the name of the field of the event that contains the object. It is empty for types of objects
+	// that aren't included in events.
+	eventField string
+
+	// statePrefix is the prefix of the names of the values of the state enum, that is removed when reporting it.
+	statePrefix string
+
+	// get fetches the current version of the object.
+	get func(ctx context.Context, conn *grpc.ClientConn, id string) (proto.Message, error)
+
+	// state returns the state of the object, and if it is ready or failed.
+	state func(object proto.Message) (value protoreflect.Enum, ready bool, failed bool)
+}
+
+// eventDescriptor returns the descriptor of the field of the event that contains the object.
+func (k *kind) eventDescriptor() protoreflect.FieldDescriptor {
+	return (*eventsv1.Event)(nil).ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(k.eventField))
+}
+
+var kinds = map[protoreflect.FullName]*kind{
+	proto.MessageName((*ffv1.Cluster)(nil)): {
+		name:        "cluster",
+		eventField:  "cluster",
+		statePrefix: "CLUSTER_STATE_",
+		get: func(ctx context.Context, conn *grpc.ClientConn, id string) (proto.Message, error) {
+			response, err := ffv1.NewClustersClient(conn).Get(ctx, ffv1.ClustersGetRequest_builder{
+				Id: id,
+			}.Build())
+			return response.GetObject(), err
+		},
+		state: func(object proto.Message) (protoreflect.Enum, bool, bool) {
+			state := object.(*ffv1.Cluster).GetStatus().GetState()
+			return state, state == ffv1.ClusterState_CLUSTER_STATE_READY, state == ffv1.ClusterState_CLUSTER_STATE_FAILED
+		},
+	},
+	proto.MessageName((*ffv1.ComputeInstance)(nil)): {
+		name:        "compute instance",
+		statePrefix: "COMPUTE_INSTANCE_STATE_",
+		get: func(ctx context.Context, conn *grpc.ClientConn, id string) (proto.Message, error) {
+			response, err := ffv1.NewComputeInstancesClient(conn).Get(ctx, ffv1.ComputeInstancesGetRequest_builder{
+				Id: id,
+			}.Build())
+			return response.GetObject(), err
+		},
+		state: func(object proto.Message) (protoreflect.Enum, bool, bool) {
+			state := object.(*ffv1.ComputeInstance).GetStatus().GetState()
+			return state, state == ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING,
+				state == ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_FAILED
+		},
+	},
+}
+
+// objectId returns the value of the 'id' field of the object, or an empty string if it doesn't have that field.
+func objectId(object proto.Message) string {
+	message := object.ProtoReflect()
+	field := message.Descriptor().Fields().ByName("id")
+	if field == nil {
+		return ""
+	}
+	return message.Get(field).String()
+}
+
+// defaultPollInterval is the default time between requests to get the object when the events stream isn't available.
+const defaultPollInterval = 5 * time.Second
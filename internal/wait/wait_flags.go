@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package wait
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Args contains the values of the flags that control waiting.
+type Args struct {
+	Wait    bool
+	Timeout time.Duration
+}
+
+// AddFlags adds to the given flag set the flags used to wait till created objects are ready, storing their values in
+// the given arguments. All the commands that create objects that have a state should use this, so that the flags are
+// the same.
+func AddFlags(flags *pflag.FlagSet, args *Args) {
+	flags.BoolVar(
+		&args.Wait,
+		"wait",
+		false,
+		"Wait till the created object is ready, displaying the changes of its state.",
+	)
+	flags.DurationVar(
+		&args.Timeout,
+		"timeout",
+		DefaultTimeout,
+		"Maximum time to wait when the '--wait' flag is used.",
+	)
+}
+
+// DefaultTimeout is the default maximum time to wait.
+const DefaultTimeout = 30 * time.Minute
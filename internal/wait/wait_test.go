@@ -0,0 +1,270 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	eventsv1 "github.com/osac-project/fulfillment-common/api/events/v1"
+	ffv1 "github.com/osac-project/fulfillment-common/api/fulfillment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/testing"
+)
+
+var _ = Describe("Waiter", func() {
+	var (
+		ctx    context.Context
+		states []string
+	)
+
+	// makeCluster creates a cluster with the given state.
+	makeCluster := func(state ffv1.ClusterState) *ffv1.Cluster {
+		return ffv1.Cluster_builder{
+			Id: "123",
+			Status: ffv1.ClusterStatus_builder{
+				State: state,
+			}.Build(),
+		}.Build()
+	}
+
+	// makeInstance creates a compute instance with the given state.
+	makeInstance := func(state ffv1.ComputeInstanceState) *ffv1.ComputeInstance {
+		return ffv1.ComputeInstance_builder{
+			Id: "456",
+			Status: ffv1.ComputeInstanceStatus_builder{
+				State: state,
+			}.Build(),
+		}.Build()
+	}
+
+	// start starts a server with the given implementations, and returns a waiter connected to it that polls very
+	// frequently.
+	start := func(clusters *testing.ClustersServerFuncs, instances *testing.ComputeInstancesServerFuncs,
+		events *testing.EventsServerFuncs) *Waiter {
+		server := testing.NewServer()
+		DeferCleanup(server.Stop)
+		if clusters != nil {
+			ffv1.RegisterClustersServer(server.Registrar(), clusters)
+		}
+		if instances != nil {
+			ffv1.RegisterComputeInstancesServer(server.Registrar(), instances)
+		}
+		if events != nil {
+			eventsv1.RegisterEventsServer(server.Registrar(), events)
+		}
+		server.Start()
+		conn, err := grpc.NewClient(
+			server.Address(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+		waiter, err := NewWaiter().
+			SetLogger(logger).
+			SetConnection(conn).
+			SetPollInterval(10 * time.Millisecond).
+			SetListener(func(ctx context.Context, object proto.Message, state string) {
+				states = append(states, state)
+			}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return waiter
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		states = nil
+	})
+
+	It("Can't be created without a logger", func() {
+		_, err := NewWaiter().
+			SetConnection(&grpc.ClientConn{}).
+			Build()
+		Expect(err).To(MatchError("logger is mandatory"))
+	})
+
+	It("Can't be created without a connection", func() {
+		_, err := NewWaiter().
+			SetLogger(logger).
+			Build()
+		Expect(err).To(MatchError("connection is mandatory"))
+	})
+
+	It("Uses the events to wait for clusters", func() {
+		waiter := start(
+			&testing.ClustersServerFuncs{
+				GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest,
+				) (*ffv1.ClustersGetResponse, error) {
+					return ffv1.ClustersGetResponse_builder{
+						Object: makeCluster(ffv1.ClusterState_CLUSTER_STATE_PROGRESSING),
+					}.Build(), nil
+				},
+			},
+			nil,
+			&testing.EventsServerFuncs{
+				WatchFunc: func(request *eventsv1.EventsWatchRequest, stream eventsv1.Events_WatchServer) error {
+					Expect(request.GetFilter()).To(Equal(`has(event.cluster) && event.cluster.id == "123"`))
+					for _, state := range []ffv1.ClusterState{
+						ffv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+						ffv1.ClusterState_CLUSTER_STATE_READY,
+					} {
+						err := stream.Send(eventsv1.EventsWatchResponse_builder{
+							Event: eventsv1.Event_builder{
+								Type:    eventsv1.EventType_EVENT_TYPE_OBJECT_UPDATED,
+								Cluster: makeCluster(state),
+							}.Build(),
+						}.Build())
+						if err != nil {
+							return err
+						}
+					}
+					<-stream.Context().Done()
+					return nil
+				},
+			},
+		)
+		result, err := waiter.Wait(ctx, makeCluster(ffv1.ClusterState_CLUSTER_STATE_UNSPECIFIED))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.(*ffv1.Cluster).GetStatus().GetState()).To(Equal(ffv1.ClusterState_CLUSTER_STATE_READY))
+		Expect(states).To(Equal([]string{"PROGRESSING", "READY"}))
+	})
+
+	It("Polls when the server doesn't support events", func() {
+		var calls atomic.Int32
+		waiter := start(
+			&testing.ClustersServerFuncs{
+				GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest,
+				) (*ffv1.ClustersGetResponse, error) {
+					state := ffv1.ClusterState_CLUSTER_STATE_PROGRESSING
+					if calls.Add(1) >= 3 {
+						state = ffv1.ClusterState_CLUSTER_STATE_READY
+					}
+					return ffv1.ClustersGetResponse_builder{
+						Object: makeCluster(state),
+					}.Build(), nil
+				},
+			},
+			nil,
+			nil,
+		)
+		_, err := waiter.Wait(ctx, makeCluster(ffv1.ClusterState_CLUSTER_STATE_UNSPECIFIED))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls.Load()).To(BeNumerically("==", 3))
+		Expect(states).To(Equal([]string{"PROGRESSING", "READY"}))
+	})
+
+	It("Polls compute instances", func() {
+		var calls atomic.Int32
+		waiter := start(
+			nil,
+			&testing.ComputeInstancesServerFuncs{
+				GetFunc: func(ctx context.Context, request *ffv1.ComputeInstancesGetRequest,
+				) (*ffv1.ComputeInstancesGetResponse, error) {
+					Expect(request.GetId()).To(Equal("456"))
+					state := ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_STARTING
+					if calls.Add(1) >= 2 {
+						state = ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_RUNNING
+					}
+					return ffv1.ComputeInstancesGetResponse_builder{
+						Object: makeInstance(state),
+					}.Build(), nil
+				},
+			},
+			nil,
+		)
+		_, err := waiter.Wait(ctx, makeInstance(ffv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(states).To(Equal([]string{"STARTING", "RUNNING"}))
+	})
+
+	It("Fails if the object fails", func() {
+		waiter := start(
+			&testing.ClustersServerFuncs{
+				GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest,
+				) (*ffv1.ClustersGetResponse, error) {
+					return ffv1.ClustersGetResponse_builder{
+						Object: makeCluster(ffv1.ClusterState_CLUSTER_STATE_FAILED),
+					}.Build(), nil
+				},
+			},
+			nil,
+			nil,
+		)
+		_, err := waiter.Wait(ctx, makeCluster(ffv1.ClusterState_CLUSTER_STATE_UNSPECIFIED))
+		Expect(err).To(MatchError("cluster '123' failed"))
+		Expect(states).To(Equal([]string{"FAILED"}))
+	})
+
+	It("Fails if the object is deleted", func() {
+		var calls atomic.Int32
+		waiter := start(
+			&testing.ClustersServerFuncs{
+				GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest,
+				) (*ffv1.ClustersGetResponse, error) {
+					if calls.Add(1) >= 2 {
+						return nil, grpcstatus.Errorf(codes.NotFound, "cluster '123' doesn't exist")
+					}
+					return ffv1.ClustersGetResponse_builder{
+						Object: makeCluster(ffv1.ClusterState_CLUSTER_STATE_PROGRESSING),
+					}.Build(), nil
+				},
+			},
+			nil,
+			nil,
+		)
+		_, err := waiter.Wait(ctx, makeCluster(ffv1.ClusterState_CLUSTER_STATE_UNSPECIFIED))
+		Expect(err).To(MatchError("cluster '123' was deleted"))
+		code, ok := exit.CodeOf(err)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(exit.NotFound))
+	})
+
+	It("Fails when the timeout expires", func() {
+		waiter := start(
+			&testing.ClustersServerFuncs{
+				GetFunc: func(ctx context.Context, request *ffv1.ClustersGetRequest,
+				) (*ffv1.ClustersGetResponse, error) {
+					return ffv1.ClustersGetResponse_builder{
+						Object: makeCluster(ffv1.ClusterState_CLUSTER_STATE_PROGRESSING),
+					}.Build(), nil
+				},
+			},
+			nil,
+			nil,
+		)
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err := waiter.Wait(timeoutCtx, makeCluster(ffv1.ClusterState_CLUSTER_STATE_UNSPECIFIED))
+		Expect(err).To(MatchError("timed out waiting for cluster '123' to be ready"))
+		code, ok := exit.CodeOf(err)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(exit.Timeout))
+	})
+
+	It("Rejects objects without identifier", func() {
+		waiter := start(nil, nil, nil)
+		_, err := waiter.Wait(ctx, &ffv1.Cluster{})
+		Expect(err).To(MatchError("object of type 'fulfillment.v1.Cluster' doesn't have an identifier"))
+	})
+})
@@ -15,11 +15,18 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/osac-project/fulfillment-cli/internal/cmd"
+	"github.com/osac-project/fulfillment-cli/internal/config"
 	"github.com/osac-project/fulfillment-cli/internal/exit"
+	"github.com/osac-project/fulfillment-cli/internal/failure"
+	"github.com/osac-project/fulfillment-cli/internal/output"
+	"github.com/osac-project/fulfillment-cli/internal/recording"
+	"github.com/osac-project/fulfillment-cli/internal/telemetry"
+	"github.com/osac-project/fulfillment-cli/internal/timing"
 )
 
 func main() {
@@ -28,14 +35,45 @@ func main() {
 
 	// Execute the main command:
 	root := cmd.Root()
-	err := root.ExecuteContext(ctx)
+	executed, err := root.ExecuteContextC(ctx)
 	if err != nil {
-		exitErr, ok := err.(exit.Error)
-		if ok {
-			os.Exit(exitErr.Code())
-		} else {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+		// Cobra reports unknown commands while looking them up, before calling the argument validators, and with an
+		// error that has no specific type, so the only way to give them the usage exit code is to check the message.
+		if strings.HasPrefix(err.Error(), "unknown command ") {
+			err = exit.WithCode(exit.Usage, err)
 		}
+
+		// Errors are written in machine readable format if that is the output format selected for the command that
+		// failed. Note that the format may be invalid, in that case the error is about that, and it is written as
+		// text.
+		render := failure.Render
+		format, _ := output.FormatFromCommand(executed)
+		if format == output.FormatJson {
+			render = failure.RenderJson
+		}
+		code := render(os.Stderr, err)
+		recording.Finish(os.Stderr, executed)
+		timing.Report(os.Stderr, executed, err)
+		telemetry.Finish(executed, err)
+
+		// Record the error so that it can be explained later with the 'explain-error last' command. This is best
+		// effort, failing to record it shouldn't hide the original error.
+		if executed != nil && executed.Annotations[failure.SkipRecordAnnotation] == "" {
+			settings, stateErr := config.LoadFile()
+			var stateDir string
+			if stateErr == nil {
+				stateDir, stateErr = settings.StateDirectory()
+			}
+			if stateErr == nil {
+				_ = failure.Save(filepath.Join(stateDir, failure.RecordFileName), executed.CommandPath(), err)
+			}
+		}
+		os.Exit(code.Code())
 	}
+
+	// Save the recorded calls, report the time that the command took and the number of calls that it did, and export
+	// the spans:
+	recording.Finish(os.Stderr, executed)
+	timing.Report(os.Stderr, executed, nil)
+	telemetry.Finish(executed, nil)
 }